@@ -216,6 +216,8 @@ type CallOpts = bind2.CallOpts
 
 type TransactOpts = bind2.TransactOpts
 
+type TransactCost = bind2.TransactCost
+
 type FilterOpts = bind2.FilterOpts
 
 type WatchOpts = bind2.WatchOpts
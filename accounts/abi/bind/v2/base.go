@@ -413,6 +413,78 @@ func (c *BoundContract) getNonce(opts *TransactOpts) (uint64, error) {
 // transact executes an actual transaction invocation, first deriving any missing
 // authorization fields, and then scheduling the transaction for execution.
 func (c *BoundContract) transact(opts *TransactOpts, contract *common.Address, input []byte) (*types.Transaction, error) {
+	rawTx, err := c.createTx(opts, contract, input)
+	if err != nil {
+		return nil, err
+	}
+	// Sign the transaction and schedule it for execution
+	if opts.Signer == nil {
+		return nil, errors.New("no signer to authorize the transaction with")
+	}
+	signedTx, err := opts.Signer(opts.From, rawTx)
+	if err != nil {
+		return nil, err
+	}
+	if opts.NoSend {
+		return signedTx, nil
+	}
+	if err := c.transactor.SendTransaction(ensureContext(opts.Context), signedTx); err != nil {
+		return nil, err
+	}
+	return signedTx, nil
+}
+
+// TransactCost is the fee quote of a contract transaction that has not been
+// signed or sent yet. The fees do not include the value transferred.
+type TransactCost struct {
+	Tx       *types.Transaction // Unsigned transaction the quote was computed for
+	BaseFee  *big.Int           // Base fee of the head block the quote was priced at (nil = pre-London)
+	Expected *big.Int           // Fee paid if the transaction is included at BaseFee
+	Max      *big.Int           // Upper bound of the fee, the gas limit times the fee cap
+}
+
+// EstimateCost derives the transaction a RawTransact call with the same options
+// would send, and quotes its fees against the current head block. Gas limit
+// estimation honours the context set in the options.
+func (c *BoundContract) EstimateCost(opts *TransactOpts, calldata []byte) (*TransactCost, error) {
+	return c.estimateCost(opts, &c.address, calldata)
+}
+
+// EstimateCreationCost is the counterpart of EstimateCost for contract-creation
+// transactions, quoting what RawCreationTransact would send.
+func (c *BoundContract) EstimateCreationCost(opts *TransactOpts, calldata []byte) (*TransactCost, error) {
+	return c.estimateCost(opts, nil, calldata)
+}
+
+func (c *BoundContract) estimateCost(opts *TransactOpts, contract *common.Address, input []byte) (*TransactCost, error) {
+	tx, err := c.createTx(opts, contract, input)
+	if err != nil {
+		return nil, err
+	}
+	head, err := c.transactor.HeaderByNumber(ensureContext(opts.Context), nil)
+	if err != nil {
+		return nil, err
+	}
+	tip, err := tx.EffectiveGasTip(head.BaseFee)
+	if err != nil {
+		return nil, fmt.Errorf("transaction not includable at base fee %v: %w", head.BaseFee, err)
+	}
+	price := tip
+	if head.BaseFee != nil {
+		price.Add(price, head.BaseFee)
+	}
+	gas := new(big.Int).SetUint64(tx.Gas())
+	return &TransactCost{
+		Tx:       tx,
+		BaseFee:  head.BaseFee,
+		Expected: price.Mul(price, gas),
+		Max:      new(big.Int).Mul(gas, tx.GasFeeCap()),
+	}, nil
+}
+
+// createTx assembles the unsigned transaction for a contract invocation, filling
+// in any fee, gas and nonce fields left unset in the options.
+func (c *BoundContract) createTx(opts *TransactOpts, contract *common.Address, input []byte) (*types.Transaction, error) {
 	if opts.GasPrice != nil && (opts.GasFeeCap != nil || opts.GasTipCap != nil) {
 		return nil, errors.New("both gasPrice and (maxFeePerGas or maxPriorityFeePerGas) specified")
 	}
@@ -436,24 +508,7 @@ func (c *BoundContract) transact(opts *TransactOpts, contract *common.Address, i
 			rawTx, err = c.createLegacyTx(opts, contract, input)
 		}
 	}
-	if err != nil {
-		return nil, err
-	}
-	// Sign the transaction and schedule it for execution
-	if opts.Signer == nil {
-		return nil, errors.New("no signer to authorize the transaction with")
-	}
-	signedTx, err := opts.Signer(opts.From, rawTx)
-	if err != nil {
-		return nil, err
-	}
-	if opts.NoSend {
-		return signedTx, nil
-	}
-	if err := c.transactor.SendTransaction(ensureContext(opts.Context), signedTx); err != nil {
-		return nil, err
-	}
-	return signedTx, nil
+	return rawTx, err
 }
 
 // FilterLogs filters contract logs for past blocks, returning the necessary
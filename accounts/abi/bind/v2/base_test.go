@@ -374,6 +374,38 @@ func TestTransactGasFee(t *testing.T) {
 	assert.True(mt.suggestGasPriceCalled)
 }
 
+func TestEstimateCost(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+
+	// Dynamic fee transaction priced at the head base fee
+	mt := &mockTransactor{baseFee: big.NewInt(100), gasTipCap: big.NewInt(5)}
+	bc := bind.NewBoundContract(common.Address{}, abi.ABI{}, nil, mt, nil)
+	opts := &bind.TransactOpts{GasLimit: 21000}
+	cost, err := bind.EstimateCost(bc, opts, nil)
+	assert.Nil(err)
+	assert.Equal(big.NewInt(100), cost.BaseFee)
+	assert.Equal(big.NewInt(21000*105), cost.Expected)
+	assert.Equal(big.NewInt(21000*205), cost.Max)
+	assert.Equal(uint64(21000), cost.Tx.Gas())
+
+	// A fee cap below the base fee cannot be included
+	opts = &bind.TransactOpts{GasLimit: 21000, GasFeeCap: big.NewInt(50), GasTipCap: big.NewInt(5)}
+	_, err = bind.EstimateCost(bc, opts, nil)
+	assert.ErrorIs(err, types.ErrGasFeeCapTooLow)
+
+	// Legacy transaction on a chain without a base fee
+	mt = &mockTransactor{gasPrice: big.NewInt(7)}
+	bc = bind.NewBoundContract(common.Address{}, abi.ABI{}, nil, mt, nil)
+	opts = &bind.TransactOpts{GasLimit: 30000}
+	cost, err = bc.EstimateCreationCost(opts, []byte{0x60})
+	assert.Nil(err)
+	assert.Nil(cost.BaseFee)
+	assert.Equal(big.NewInt(30000*7), cost.Expected)
+	assert.Equal(big.NewInt(30000*7), cost.Max)
+	assert.Nil(cost.Tx.To())
+}
+
 func unpackAndCheck(t *testing.T, bc *bind.BoundContract, expected map[string]interface{}, mockLog types.Log) {
 	received := make(map[string]interface{})
 	if err := bc.UnpackLogIntoMap(received, "received", mockLog); err != nil {
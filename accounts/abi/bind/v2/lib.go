@@ -211,6 +211,16 @@ func Transact(c *BoundContract, opt *TransactOpts, data []byte) (*types.Transact
 	return c.RawTransact(opt, data)
 }
 
+// EstimateCost quotes the fees of the transaction that Transact would submit
+// with the same options and data, without signing or sending it.
+//
+// EstimateCost is intended to be used with contract method pack methods in
+// bindings generated with the abigen --v2 flag, to check the price of an
+// interaction before committing to it.
+func EstimateCost(c *BoundContract, opt *TransactOpts, data []byte) (*TransactCost, error) {
+	return c.EstimateCost(opt, data)
+}
+
 // DeployContract creates and submits a deployment transaction based on the
 // deployer bytecode and optional ABI-encoded constructor input.  It returns
 // the address and creation transaction of the pending contract, or an error
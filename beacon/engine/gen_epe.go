@@ -21,6 +21,7 @@ func (e ExecutionPayloadEnvelope) MarshalJSON() ([]byte, error) {
 		Requests         []hexutil.Bytes `json:"executionRequests"`
 		Override         bool            `json:"shouldOverrideBuilder"`
 		Witness          *hexutil.Bytes  `json:"witness,omitempty"`
+		Revenue          *PayloadRevenue `json:"revenue,omitempty"`
 	}
 	var enc ExecutionPayloadEnvelope
 	enc.ExecutionPayload = e.ExecutionPayload
@@ -34,6 +35,7 @@ func (e ExecutionPayloadEnvelope) MarshalJSON() ([]byte, error) {
 	}
 	enc.Override = e.Override
 	enc.Witness = e.Witness
+	enc.Revenue = e.Revenue
 	return json.Marshal(&enc)
 }
 
@@ -46,6 +48,7 @@ func (e *ExecutionPayloadEnvelope) UnmarshalJSON(input []byte) error {
 		Requests         []hexutil.Bytes `json:"executionRequests"`
 		Override         *bool           `json:"shouldOverrideBuilder"`
 		Witness          *hexutil.Bytes  `json:"witness,omitempty"`
+		Revenue          *PayloadRevenue `json:"revenue,omitempty"`
 	}
 	var dec ExecutionPayloadEnvelope
 	if err := json.Unmarshal(input, &dec); err != nil {
@@ -74,5 +77,8 @@ func (e *ExecutionPayloadEnvelope) UnmarshalJSON(input []byte) error {
 	if dec.Witness != nil {
 		e.Witness = dec.Witness
 	}
+	if dec.Revenue != nil {
+		e.Revenue = dec.Revenue
+	}
 	return nil
 }
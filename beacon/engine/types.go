@@ -123,6 +123,18 @@ type ExecutionPayloadEnvelope struct {
 	Requests         [][]byte        `json:"executionRequests"`
 	Override         bool            `json:"shouldOverrideBuilder"`
 	Witness          *hexutil.Bytes  `json:"witness,omitempty"`
+	Revenue          *PayloadRevenue `json:"revenue,omitempty"`
+}
+
+// PayloadRevenue breaks down the revenue of the fee recipient of a payload
+// along with the fees burnt by its transactions. The block value only accounts
+// for the priority fees.
+type PayloadRevenue struct {
+	PriorityFees    *hexutil.Big `json:"priorityFees"`    // Transaction tips paid to the fee recipient
+	DirectTransfers *hexutil.Big `json:"directTransfers"` // Other balance changes of the fee recipient, negative if it spent funds
+	Total           *hexutil.Big `json:"total"`           // Net balance change of the fee recipient
+	BurntFees       *hexutil.Big `json:"burntFees"`       // Base fees burnt by the transactions
+	BlobFees        *hexutil.Big `json:"blobFees"`        // Blob fees burnt by the blob transactions
 }
 
 // BlobsBundle includes the marshalled sidecar data. Note this structure is
@@ -136,6 +136,22 @@ var (
 		Name:  "stdio-ui-test",
 		Usage: "Mechanism to test interface between Clef and UI. Requires 'stdio-ui'.",
 	}
+	batchInboxFlag = &cli.StringFlag{
+		Name:  "rollup.batchinbox",
+		Usage: "Batch inbox address of a rollup, transactions to it are reviewed as batch submissions",
+	}
+	batcherFlag = &cli.StringFlag{
+		Name:  "rollup.batcher",
+		Usage: "Batcher address of a rollup, batch submissions from other accounts are flagged",
+	}
+	systemConfigFlag = &cli.StringFlag{
+		Name:  "rollup.systemconfig",
+		Usage: "SystemConfig contract address of a rollup, fee scalar and configuration updates to it are decoded",
+	}
+	portalFlag = &cli.StringFlag{
+		Name:  "rollup.portal",
+		Usage: "Portal contract address of a rollup, deposits through it are decoded",
+	}
 	initCommand = &cli.Command{
 		Action:    initializeSecrets,
 		Name:      "init",
@@ -285,6 +301,10 @@ func init() {
 		testFlag,
 		advancedMode,
 		acceptFlag,
+		batchInboxFlag,
+		batcherFlag,
+		systemConfigFlag,
+		portalFlag,
 	}
 	app.Action = signer
 	app.Commands = []*cli.Command{initCommand,
@@ -705,7 +725,11 @@ func signer(c *cli.Context) error {
 		"light-kdf", lightKdf, "advanced", advanced)
 	am := core.StartClefAccountManager(ksLoc, nousb, lightKdf, scpath)
 	defer am.Close()
-	apiImpl := core.NewSignerAPI(am, chainId, nousb, ui, db, advanced, pwStorage)
+	validator, err := operatorValidator(c, db)
+	if err != nil {
+		utils.Fatalf(err.Error())
+	}
+	apiImpl := core.NewSignerAPI(am, chainId, nousb, ui, validator, advanced, pwStorage)
 
 	// Establish the bidirectional communication, by creating a new UI backend and registering
 	// it with the UI.
@@ -795,6 +819,40 @@ func signer(c *cli.Context) error {
 	return nil
 }
 
+// operatorValidator wraps the 4byte validator with the review of rollup operator
+// transactions, if any rollup contract was configured.
+func operatorValidator(c *cli.Context, db *fourbyte.Database) (core.Validator, error) {
+	var (
+		contracts  core.OperatorContracts
+		configured bool
+	)
+	for _, opt := range []struct {
+		flag *cli.StringFlag
+		addr *common.Address
+	}{
+		{batchInboxFlag, &contracts.BatchInbox},
+		{batcherFlag, &contracts.Batcher},
+		{systemConfigFlag, &contracts.SystemConfig},
+		{portalFlag, &contracts.Portal},
+	} {
+		value := c.String(opt.flag.Name)
+		if value == "" {
+			continue
+		}
+		if !common.IsHexAddress(value) {
+			return nil, fmt.Errorf("invalid address for --%s: %q", opt.flag.Name, value)
+		}
+		*opt.addr = common.HexToAddress(value)
+		configured = true
+	}
+	if !configured {
+		return db, nil
+	}
+	log.Info("Reviewing rollup operator transactions", "inbox", contracts.BatchInbox, "batcher", contracts.Batcher,
+		"systemconfig", contracts.SystemConfig, "portal", contracts.Portal)
+	return core.NewOperatorValidator(db, contracts), nil
+}
+
 // DefaultConfigDir is the default config directory to use for the vaults and other
 // persistence requirements.
 func DefaultConfigDir() string {
@@ -162,6 +162,11 @@ var (
 			strings.Join(vm.ActivateableEips(), ", ")),
 		Value: "GrayGlacier",
 	}
+	ChainConfigFlag = &cli.StringFlag{
+		Name: "state.config",
+		Usage: "File name of a JSON chain configuration to use instead of a named ruleset. " +
+			"The chain id of the configuration is used unless --state.chainid is set",
+	}
 	VerbosityFlag = &cli.IntFlag{
 		Name:  "verbosity",
 		Usage: "sets the verbosity level",
@@ -30,7 +30,6 @@ import (
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/rlp"
-	"github.com/ethereum/go-ethereum/tests"
 	"github.com/urfave/cli/v2"
 )
 
@@ -67,19 +66,14 @@ func Transaction(ctx *cli.Context) error {
 	// We need to load the transactions. May be either in stdin input or in files.
 	// Check if anything needs to be read from stdin
 	var (
-		err         error
-		txStr       = ctx.String(InputTxsFlag.Name)
-		inputData   = &input{}
-		chainConfig *params.ChainConfig
+		txStr     = ctx.String(InputTxsFlag.Name)
+		inputData = &input{}
 	)
 	// Construct the chainconfig
-	if cConf, _, err := tests.GetChainConfig(ctx.String(ForknameFlag.Name)); err != nil {
-		return NewError(ErrorConfig, fmt.Errorf("failed constructing chain configuration: %v", err))
-	} else {
-		chainConfig = cConf
+	chainConfig, _, err := loadChainConfig(ctx)
+	if err != nil {
+		return err
 	}
-	// Set the chain id
-	chainConfig.ChainID = big.NewInt(ctx.Int64(ChainIDFlag.Name))
 
 	var body hexutil.Bytes
 	if txStr == stdinSelector {
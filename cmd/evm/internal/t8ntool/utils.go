@@ -19,8 +19,11 @@ package t8ntool
 import (
 	"encoding/json"
 	"fmt"
+	"math/big"
 	"os"
 
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/tests"
 	"github.com/urfave/cli/v2"
 )
 
@@ -52,3 +55,35 @@ func createBasedir(ctx *cli.Context) (string, error) {
 	}
 	return baseDir, nil
 }
+
+// loadChainConfig constructs the chain configuration to execute with, along with
+// any extra eips to activate. The configuration is either read from the file
+// given by --state.config, or derived from the ruleset named by --state.fork.
+func loadChainConfig(ctx *cli.Context) (*params.ChainConfig, []int, error) {
+	var (
+		config *params.ChainConfig
+		eips   []int
+	)
+	if ctx.IsSet(ChainConfigFlag.Name) {
+		if ctx.IsSet(ForknameFlag.Name) {
+			return nil, nil, NewError(ErrorConfig, fmt.Errorf("--%s and --%s are mutually exclusive", ChainConfigFlag.Name, ForknameFlag.Name))
+		}
+		config = new(params.ChainConfig)
+		if err := readFile(ctx.String(ChainConfigFlag.Name), "chain config", config); err != nil {
+			return nil, nil, err
+		}
+		if err := config.CheckConfigForkOrder(); err != nil {
+			return nil, nil, NewError(ErrorConfig, fmt.Errorf("invalid chain configuration: %v", err))
+		}
+		if config.ChainID != nil && !ctx.IsSet(ChainIDFlag.Name) {
+			return config, nil, nil
+		}
+	} else {
+		var err error
+		if config, eips, err = tests.GetChainConfig(ctx.String(ForknameFlag.Name)); err != nil {
+			return nil, nil, NewError(ErrorConfig, fmt.Errorf("failed constructing chain configuration: %v", err))
+		}
+	}
+	config.ChainID = big.NewInt(ctx.Int64(ChainIDFlag.Name))
+	return config, eips, nil
+}
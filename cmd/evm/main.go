@@ -160,6 +160,7 @@ var (
 			t8ntool.InputTxsFlag,
 			t8ntool.ForknameFlag,
 			t8ntool.ChainIDFlag,
+			t8ntool.ChainConfigFlag,
 			t8ntool.RewardFlag,
 		},
 	}
@@ -217,6 +218,7 @@ var (
 			t8ntool.InputTxsFlag,
 			t8ntool.ChainIDFlag,
 			t8ntool.ForknameFlag,
+			t8ntool.ChainConfigFlag,
 		},
 	}
 
@@ -341,6 +341,56 @@ func TestT8n(t *testing.T) {
 	}
 }
 
+func TestT8nChainConfig(t *testing.T) {
+	t.Parallel()
+	tt := new(testT8n)
+	tt.TestCmd = cmdtest.NewTestCmd(t, tt)
+	for i, tc := range []struct {
+		args        []string
+		expExitCode int
+		expOut      string
+	}{
+		{ // Byzantium rules from a config file, matching --state.fork Byzantium
+			args:   []string{"--state.config", "./testdata/1/config.json"},
+			expOut: "./testdata/1/exp.json",
+		},
+		{ // Test exit (3) on conflicting rule sources
+			args:        []string{"--state.config", "./testdata/1/config.json", "--state.fork", "Byzantium"},
+			expExitCode: 3,
+		},
+		{ // Test exit (11) on missing config file
+			args:        []string{"--state.config", "./testdata/1/missing.json"},
+			expExitCode: 11,
+		},
+	} {
+		input := t8nInput{"alloc.json", "txs.json", "env.json", "", ""}
+		output := t8nOutput{alloc: true, result: true}
+		args := []string{"t8n"}
+		args = append(args, output.get()...)
+		args = append(args, input.get("./testdata/1")...)
+		args = append(args, tc.args...)
+		tt.Run("evm-test", args...)
+		if tc.expOut != "" {
+			want, err := os.ReadFile(tc.expOut)
+			if err != nil {
+				t.Fatalf("test %d: could not read expected output: %v", i, err)
+			}
+			have := tt.Output()
+			ok, err := cmpJson(have, want)
+			switch {
+			case err != nil:
+				t.Fatalf("test %d: json parsing failed: %v", i, err)
+			case !ok:
+				t.Fatalf("test %d: output wrong, have \n%v\nwant\n%v\n", i, string(have), string(want))
+			}
+		}
+		tt.WaitExit()
+		if have, want := tt.ExitStatus(), tc.expExitCode; have != want {
+			t.Fatalf("test %d: wrong exit code, have %d, want %d", i, have, want)
+		}
+	}
+}
+
 func lineIterator(path string) func() (string, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
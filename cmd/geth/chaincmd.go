@@ -33,6 +33,7 @@ import (
 	"github.com/ethereum/go-ethereum/cmd/utils"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/consensus/beacon"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/history"
 	"github.com/ethereum/go-ethereum/core/rawdb"
@@ -111,6 +112,10 @@ if one is set.  Otherwise it prints the genesis from the datadir.`,
 			utils.MetricsInfluxDBTokenFlag,
 			utils.MetricsInfluxDBBucketFlag,
 			utils.MetricsInfluxDBOrganizationFlag,
+			utils.MetricsEnableOTLPFlag,
+			utils.MetricsOTLPEndpointFlag,
+			utils.MetricsOTLPTracingFlag,
+			utils.MetricsOTLPTracingRatioFlag,
 			utils.StateSizeTrackingFlag,
 			utils.TxLookupLimitFlag,
 			utils.VMTraceFlag,
@@ -204,11 +209,14 @@ This command dumps out the state for a given block (or latest, if none provided)
 		Name:      "prune-history",
 		Usage:     "Prune blockchain history (block bodies and receipts) up to the merge block",
 		ArgsUsage: "",
-		Flags:     utils.DatabaseFlags,
+		Flags:     slices.Concat(utils.DatabaseFlags, []cli.Flag{utils.HistoryStartFlag}),
 		Description: `
 The prune-history command removes historical block bodies and receipts from the
 blockchain database up to the merge block, while preserving block headers. This
-helps reduce storage requirements for nodes that don't need full historical data.`,
+helps reduce storage requirements for nodes that don't need full historical data.
+
+If --history.start is given, the history is pruned up to that block instead, so
+that the node only stores a slice of the chain history.`,
 	}
 
 	downloadEraCommand = &cli.Command{
@@ -227,6 +235,26 @@ helps reduce storage requirements for nodes that don't need full historical data
 			},
 		),
 	}
+
+	checkHeaderCommand = &cli.Command{
+		Action:    checkHeader,
+		Name:      "check-header",
+		Usage:     "Validates a JSON block header against the chain configuration",
+		ArgsUsage: "",
+		Flags: slices.Concat(
+			[]cli.Flag{utils.DataDirFlag, headerFileFlag},
+			utils.NetworkFlags,
+		),
+		Description: `
+The check-header command validates the fields of a block header, given in the
+JSON format of eth_getBlockByNumber, against the rules of the forks active at the
+header: the extra-data size, the gas fields, the proof-of-stake seal and the
+presence of the baseFee, withdrawalsRoot, blob gas, parentBeaconBlockRoot and
+requestsHash fields.
+
+The chain configuration is taken from the network preset if one is set, otherwise
+from the datadir. Fields which depend on the parent header are not validated.`,
+	}
 )
 
 var (
@@ -246,6 +274,11 @@ var (
 		Name:  "server",
 		Usage: "era1 server URL",
 	}
+	headerFileFlag = &cli.StringFlag{
+		Name:      "file",
+		Usage:     "JSON file containing the header to check",
+		TakesFile: true,
+	}
 )
 
 // initGenesis will initialise the given JSON format genesis file and writes it as
@@ -286,6 +319,9 @@ func initGenesis(ctx *cli.Context) error {
 		overrides.OverrideBPO2 = &v
 	}
 	if ctx.IsSet(utils.OverrideVerkle.Name) {
+		if !ctx.Bool(utils.StateVerkleFlag.Name) {
+			utils.Fatalf("--%s requires the experimental --%s flag", utils.OverrideVerkle.Name, utils.StateVerkleFlag.Name)
+		}
 		v := ctx.Uint64(utils.OverrideVerkle.Name)
 		overrides.OverrideVerkle = &v
 	}
@@ -345,6 +381,43 @@ func dumpGenesis(ctx *cli.Context) error {
 	return nil
 }
 
+// checkHeader validates a JSON header read from a file against the chain
+// configuration of the network preset or the datadir.
+func checkHeader(ctx *cli.Context) error {
+	if !ctx.IsSet(headerFileFlag.Name) {
+		utils.Fatalf("This command requires the --%s flag.", headerFileFlag.Name)
+	}
+	data, err := os.ReadFile(ctx.String(headerFileFlag.Name))
+	if err != nil {
+		utils.Fatalf("Failed to read header file: %v", err)
+	}
+	header := new(types.Header)
+	if err := json.Unmarshal(data, header); err != nil {
+		utils.Fatalf("Invalid header file: %v", err)
+	}
+	var config *params.ChainConfig
+	if utils.IsNetworkPreset(ctx) {
+		config = utils.MakeGenesis(ctx).Config
+	} else {
+		stack, _ := makeConfigNode(ctx)
+		defer stack.Close()
+
+		db := utils.MakeChainDatabase(ctx, stack, true)
+		defer db.Close()
+
+		config, _, err = core.LoadChainConfig(db, nil)
+		if err != nil {
+			utils.Fatalf("Failed to load chain config: %v", err)
+		}
+	}
+	if err := beacon.VerifyHeaderFields(config, header); err != nil {
+		fmt.Println(err)
+		return fmt.Errorf("header %d (%#x) is invalid", header.Number, header.Hash())
+	}
+	fmt.Printf("Header %d (%#x) is valid\n", header.Number, header.Hash())
+	return nil
+}
+
 func importChain(ctx *cli.Context) error {
 	if ctx.Args().Len() < 1 {
 		utils.Fatalf("This command requires an argument.")
@@ -580,36 +653,12 @@ func importPreimages(ctx *cli.Context) error {
 }
 
 func parseDumpConfig(ctx *cli.Context, db ethdb.Database) (*state.DumpConfig, common.Hash, error) {
-	var header *types.Header
 	if ctx.NArg() > 1 {
 		return nil, common.Hash{}, fmt.Errorf("expected 1 argument (number or hash), got %d", ctx.NArg())
 	}
-	if ctx.NArg() == 1 {
-		arg := ctx.Args().First()
-		if hashish(arg) {
-			hash := common.HexToHash(arg)
-			if number, ok := rawdb.ReadHeaderNumber(db, hash); ok {
-				header = rawdb.ReadHeader(db, hash, number)
-			} else {
-				return nil, common.Hash{}, fmt.Errorf("block %x not found", hash)
-			}
-		} else {
-			number, err := strconv.ParseUint(arg, 10, 64)
-			if err != nil {
-				return nil, common.Hash{}, err
-			}
-			if hash := rawdb.ReadCanonicalHash(db, number); hash != (common.Hash{}) {
-				header = rawdb.ReadHeader(db, hash, number)
-			} else {
-				return nil, common.Hash{}, fmt.Errorf("header for block %d not found", number)
-			}
-		}
-	} else {
-		// Use latest
-		header = rawdb.ReadHeadHeader(db)
-	}
-	if header == nil {
-		return nil, common.Hash{}, errors.New("no head block found")
+	header, err := readDumpHeader(db, ctx.Args().First())
+	if err != nil {
+		return nil, common.Hash{}, err
 	}
 	startArg := common.FromHex(ctx.String(utils.StartKeyFlag.Name))
 	var start common.Hash
@@ -636,6 +685,39 @@ func parseDumpConfig(ctx *cli.Context, db ethdb.Database) (*state.DumpConfig, co
 	return conf, header.Root, nil
 }
 
+// readDumpHeader retrieves the header of the block given by number or hash, or
+// the head header if the argument is empty.
+func readDumpHeader(db ethdb.Database, arg string) (*types.Header, error) {
+	var header *types.Header
+	if arg != "" {
+		if hashish(arg) {
+			hash := common.HexToHash(arg)
+			if number, ok := rawdb.ReadHeaderNumber(db, hash); ok {
+				header = rawdb.ReadHeader(db, hash, number)
+			} else {
+				return nil, fmt.Errorf("block %x not found", hash)
+			}
+		} else {
+			number, err := strconv.ParseUint(arg, 10, 64)
+			if err != nil {
+				return nil, err
+			}
+			if hash := rawdb.ReadCanonicalHash(db, number); hash != (common.Hash{}) {
+				header = rawdb.ReadHeader(db, hash, number)
+			} else {
+				return nil, fmt.Errorf("header for block %d not found", number)
+			}
+		}
+	} else {
+		// Use latest
+		header = rawdb.ReadHeadHeader(db)
+	}
+	if header == nil {
+		return nil, errors.New("no head block found")
+	}
+	return header, nil
+}
+
 func dump(ctx *cli.Context) error {
 	stack, _ := makeConfigNode(ctx)
 	defer stack.Close()
@@ -677,8 +759,17 @@ func pruneHistory(ctx *cli.Context) error {
 	defer chaindb.Close()
 	defer chain.Stop()
 
-	// Determine the prune point. This will be the first PoS block.
+	// Determine the prune point. This will be the first PoS block, unless a
+	// custom history start is requested.
 	prunePoint, ok := history.PrunePoints[chain.Genesis().Hash()]
+	if ctx.IsSet(utils.HistoryStartFlag.Name) {
+		start := ctx.Uint64(utils.HistoryStartFlag.Name)
+		hash := rawdb.ReadCanonicalHash(chaindb, start)
+		if hash == (common.Hash{}) {
+			return fmt.Errorf("history start block %d not found", start)
+		}
+		prunePoint, ok = &history.PrunePoint{BlockNumber: start, BlockHash: hash}, true
+	}
 	if !ok || prunePoint == nil {
 		return errors.New("prune point not found")
 	}
@@ -235,6 +235,9 @@ func makeFullNode(ctx *cli.Context) *node.Node {
 		cfg.Eth.OverrideBPO2 = &v
 	}
 	if ctx.IsSet(utils.OverrideVerkle.Name) {
+		if !ctx.Bool(utils.StateVerkleFlag.Name) {
+			utils.Fatalf("--%s requires the experimental --%s flag", utils.OverrideVerkle.Name, utils.StateVerkleFlag.Name)
+		}
 		v := ctx.Uint64(utils.OverrideVerkle.Name)
 		cfg.Eth.OverrideVerkle = &v
 	}
@@ -244,6 +247,15 @@ func makeFullNode(ctx *cli.Context) *node.Node {
 
 	backend, eth := utils.RegisterEthService(stack, &cfg.Eth)
 
+	// Apply config file changes on SIGHUP
+	if path := ctx.String(configFileFlag.Name); path != "" {
+		reloader, err := newConfigReloader(ctx, stack, eth, path, cfg)
+		if err != nil {
+			utils.Fatalf("Failed to set up config reloading: %v", err)
+		}
+		stack.RegisterLifecycle(reloader)
+	}
+
 	// Create gauge with geth system and build information
 	if eth != nil { // The 'eth' backend may be nil in light mode
 		var protos []string
@@ -265,6 +277,14 @@ func makeFullNode(ctx *cli.Context) *node.Node {
 	if ctx.IsSet(utils.GraphQLEnabledFlag.Name) {
 		utils.RegisterGraphQLService(stack, backend, filterSystem, &cfg.Node)
 	}
+	// Serve the node status page if requested.
+	if ctx.IsSet(utils.HTTPStatusFlag.Name) && eth != nil {
+		utils.RegisterStatusService(stack, eth)
+	}
+	// Serve the chain data streams if requested.
+	if ctx.IsSet(utils.GRPCEnabledFlag.Name) && eth != nil {
+		utils.RegisterChainStreamService(ctx, stack, eth)
+	}
 	// Add the Ethereum Stats daemon if requested.
 	if cfg.Ethstats.URL != "" {
 		utils.RegisterEthStatsService(stack, backend, cfg.Ethstats.URL)
@@ -278,7 +298,33 @@ func makeFullNode(ctx *cli.Context) *node.Node {
 		}
 		synctarget = common.HexToHash(target)
 	}
-	utils.RegisterSyncOverrideService(stack, eth, synctarget, ctx.Bool(utils.ExitWhenSyncedFlag.Name))
+	var checkpoint common.Hash
+	if ctx.IsSet(utils.SyncCheckpointFlag.Name) {
+		hash := ctx.String(utils.SyncCheckpointFlag.Name)
+		if !common.IsHexHash(hash) {
+			utils.Fatalf("sync checkpoint hash is not a valid hex hash: %s", hash)
+		}
+		checkpoint = common.HexToHash(hash)
+	}
+	utils.RegisterSyncOverrideService(stack, eth, synctarget, checkpoint, ctx.Bool(utils.ExitWhenSyncedFlag.Name))
+
+	// Configure storage watchpoint notifications
+	utils.RegisterWatchpointService(stack, eth)
+
+	// Configure the user operation mempool if requested
+	if ctx.Bool(utils.UserOpsEnabledFlag.Name) && eth != nil {
+		utils.RegisterUserOpService(ctx, stack, eth)
+	}
+
+	// Configure the encrypted transaction pool if requested
+	if ctx.IsSet(utils.TxPoolEncryptedDecrypterFlag.Name) && eth != nil {
+		utils.RegisterEncryptedPool(ctx, stack, eth)
+	}
+
+	// Configure the transaction inclusion commitments if requested
+	if ctx.IsSet(utils.InclusionKeyFlag.Name) && eth != nil {
+		utils.RegisterInclusionService(ctx, stack, eth)
+	}
 
 	if ctx.IsSet(utils.DeveloperFlag.Name) {
 		// Start dev mode.
@@ -300,6 +346,17 @@ func makeFullNode(ctx *cli.Context) *node.Node {
 		blsyncer := blsync.NewClient(utils.MakeBeaconLightConfig(ctx))
 		blsyncer.SetEngineRPC(rpc.DialInProc(srv))
 		stack.RegisterLifecycle(blsyncer)
+	} else if timeout := ctx.Duration(utils.MinerSoloTimeoutFlag.Name); timeout > 0 {
+		// Launch the engine API, falling back to local block production if the
+		// consensus client goes missing.
+		err := catalyst.RegisterSolo(stack, eth, catalyst.SoloConfig{
+			Timeout:      timeout,
+			Period:       ctx.Uint64(utils.MinerSoloPeriodFlag.Name),
+			FeeRecipient: cfg.Eth.Miner.PendingFeeRecipient,
+		})
+		if err != nil {
+			utils.Fatalf("failed to register catalyst service: %v", err)
+		}
 	} else {
 		// Launch the engine API for interacting with external consensus client.
 		err := catalyst.Register(stack, eth)
@@ -382,6 +439,18 @@ func applyMetricConfig(ctx *cli.Context, cfg *gethConfig) {
 	if ctx.IsSet(utils.MetricsInfluxDBOrganizationFlag.Name) {
 		cfg.Metrics.InfluxDBOrganization = ctx.String(utils.MetricsInfluxDBOrganizationFlag.Name)
 	}
+	if ctx.IsSet(utils.MetricsEnableOTLPFlag.Name) {
+		cfg.Metrics.EnableOTLP = ctx.Bool(utils.MetricsEnableOTLPFlag.Name)
+	}
+	if ctx.IsSet(utils.MetricsOTLPEndpointFlag.Name) {
+		cfg.Metrics.OTLPEndpoint = ctx.String(utils.MetricsOTLPEndpointFlag.Name)
+	}
+	if ctx.IsSet(utils.MetricsOTLPTracingFlag.Name) {
+		cfg.Metrics.OTLPTracing = ctx.Bool(utils.MetricsOTLPTracingFlag.Name)
+	}
+	if ctx.IsSet(utils.MetricsOTLPTracingRatioFlag.Name) {
+		cfg.Metrics.OTLPTracingRatio = ctx.Float64(utils.MetricsOTLPTracingRatioFlag.Name)
+	}
 	// Sanity-check the commandline flags. It is fine if some unused fields is part
 	// of the toml-config, but we expect the commandline to only contain relevant
 	// arguments, otherwise it indicates an error.
@@ -18,6 +18,7 @@ package main
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"os"
 	"os/signal"
@@ -32,7 +33,9 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/console/prompt"
+	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
 	"github.com/ethereum/go-ethereum/core/state/snapshot"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
@@ -41,6 +44,7 @@ import (
 	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/ethereum/go-ethereum/trie"
 	"github.com/ethereum/go-ethereum/triedb"
+	"github.com/ethereum/go-ethereum/triedb/pathdb"
 	"github.com/urfave/cli/v2"
 )
 
@@ -54,6 +58,11 @@ var (
 		Usage: "If set, selects the state data for removal",
 	}
 
+	migrateDryRunFlag = &cli.BoolFlag{
+		Name:  "dry-run",
+		Usage: "Only list the pending schema migrations, without applying them",
+	}
+
 	removedbCommand = &cli.Command{
 		Action:    removeDB,
 		Name:      "removedb",
@@ -72,16 +81,20 @@ Remove blockchain and state databases`,
 			dbInspectCmd,
 			dbStatCmd,
 			dbCompactCmd,
+			dbMigrateCmd,
 			dbGetCmd,
 			dbDeleteCmd,
 			dbPutCmd,
 			dbGetSlotsCmd,
 			dbDumpFreezerIndex,
+			dbCompressFreezerCmd,
 			dbImportCmd,
 			dbExportCmd,
 			dbMetadataCmd,
 			dbCheckStateContentCmd,
+			dbAuditCmd,
 			dbInspectHistoryCmd,
+			dbConvertSchemeCmd,
 		},
 	}
 	dbInspectCmd = &cli.Command{
@@ -101,6 +114,32 @@ Remove blockchain and state databases`,
 		Description: `This command iterates the entire database for 32-byte keys, looking for rlp-encoded trie nodes.
 For each trie node encountered, it checks that the key corresponds to the keccak256(value). If this is not true, this indicates
 a data corruption.`,
+	}
+	dbAuditCmd = &cli.Command{
+		Action: dbAudit,
+		Name:   "audit",
+		Usage:  "Cross-check the chain data between the key-value store and the freezer",
+		Flags: slices.Concat([]cli.Flag{
+			&cli.Uint64Flag{
+				Name:  "start",
+				Usage: "first block to audit",
+			},
+			&cli.Uint64Flag{
+				Name:  "end",
+				Usage: "last block to audit, zero means the head block",
+			},
+			&cli.BoolFlag{
+				Name:  "repair",
+				Usage: "offer to repair the detected inconsistencies",
+			},
+		}, utils.NetworkFlags, utils.DatabaseFlags),
+		Description: `This command verifies that the headers, bodies and receipts of the canonical
+blocks are present in the freezer or the key-value store as expected, and that the
+canonical hashes, hash to number mappings and transaction lookups are not missing.
+With --repair, the inconsistencies which can be fixed from the available data are
+repaired after confirmation. If chain data is missing, the chain head can be rewound
+below the first gap, so that the node downloads the missing blocks again.
+The node must be stopped while the audit runs.`,
 	}
 	dbStatCmd = &cli.Command{
 		Action: dbStats,
@@ -119,6 +158,19 @@ a data corruption.`,
 		Description: `This command performs a database compaction.
 WARNING: This operation may take a very long time to finish, and may cause database
 corruption if it is aborted during execution'!`,
+	}
+	dbMigrateCmd = &cli.Command{
+		Action: dbMigrate,
+		Name:   "migrate",
+		Usage:  "Apply the pending database schema migrations",
+		Flags: slices.Concat([]cli.Flag{
+			migrateDryRunFlag,
+			utils.DBMigrationBackupFlag,
+		}, utils.NetworkFlags, utils.DatabaseFlags),
+		Description: `This command upgrades the database schema to the latest version. The same
+migrations also run automatically when the node starts. With --dry-run, the pending
+migrations are only listed. With --db.migration.backup, the keys modified by each
+migration are exported first, in a format accepted by 'geth db import'.`,
 	}
 	dbGetCmd = &cli.Command{
 		Action:      dbGet,
@@ -162,6 +214,17 @@ WARNING: This is a low-level operation which may cause database corruption!`,
 		Flags:       slices.Concat(utils.NetworkFlags, utils.DatabaseFlags),
 		Description: "This command displays information about the freezer index.",
 	}
+	dbCompressFreezerCmd = &cli.Command{
+		Action:    freezerCompress,
+		Name:      "freezer-compress",
+		Usage:     "Migrate chain freezer tables from snappy to zstd compression",
+		ArgsUsage: "<table-type (optional)>...",
+		Flags:     slices.Concat(utils.NetworkFlags, utils.DatabaseFlags),
+		Description: `This command rewrites the given chain freezer tables (bodies and receipts if
+none are specified) with zstd compression, using a dictionary trained on the table
+contents. The node must be stopped while the migration runs. An interrupted
+migration is resumed by running the command again.`,
+	}
 	dbImportCmd = &cli.Command{
 		Action:      importLDBdata,
 		Name:        "import",
@@ -206,6 +269,22 @@ WARNING: This is a low-level operation which may cause database corruption!`,
 		}, utils.NetworkFlags, utils.DatabaseFlags),
 		Description: "This command queries the history of the account or storage slot within the specified block range",
 	}
+	dbConvertSchemeCmd = &cli.Command{
+		Action:    dbConvertScheme,
+		Name:      "convert-scheme",
+		Usage:     "Convert the state database between the hash and path schemes",
+		ArgsUsage: "<hash|path>",
+		Flags:     slices.Concat(utils.NetworkFlags, utils.DatabaseFlags),
+		Description: `This command converts the persisted state to the given state scheme in place,
+avoiding a full resync. If the state snapshot is completely generated for one of the
+recent blocks, the trie of that block is regenerated from the snapshot, which is kept.
+Otherwise the state of the most recent block available in the database is copied and
+the snapshot is regenerated in the background. The chain segments in the freezer are
+preserved. All other states are dropped along with the state histories, the node
+rewinds to the converted block on startup if it's not the head.
+The node must be stopped while the conversion runs. If the conversion is interrupted,
+the node refuses to start until the command is run again to resume it.`,
+	}
 )
 
 func removeDB(ctx *cli.Context) error {
@@ -385,6 +464,113 @@ func checkStateContent(ctx *cli.Context) error {
 	return nil
 }
 
+func dbAudit(ctx *cli.Context) error {
+	if ctx.NArg() > 0 {
+		return fmt.Errorf("no arguments required")
+	}
+	stack, _ := makeConfigNode(ctx)
+	defer stack.Close()
+
+	repair := ctx.Bool("repair")
+	db := utils.MakeChainDatabase(ctx, stack, !repair)
+	defer db.Close()
+
+	start, end := ctx.Uint64("start"), ctx.Uint64("end")
+	if end == 0 {
+		head := rawdb.ReadHeadBlock(db)
+		if head == nil {
+			return errors.New("head block not found")
+		}
+		end = head.NumberU64()
+	}
+	report, err := rawdb.AuditChain(db, start, end)
+	if err != nil {
+		return err
+	}
+	const maxPrinted = 100
+	var (
+		counts     = make(map[string]int)
+		repairable = make(map[string]int)
+		kinds      []string
+	)
+	for i, issue := range report.Issues {
+		if i < maxPrinted {
+			fmt.Println(issue)
+		}
+		if counts[issue.Kind] == 0 {
+			kinds = append(kinds, issue.Kind)
+		}
+		counts[issue.Kind]++
+		if issue.Repairable() {
+			repairable[issue.Kind]++
+		}
+	}
+	if len(report.Issues) > maxPrinted {
+		fmt.Printf("... %d more issues\n", len(report.Issues)-maxPrinted)
+	}
+	log.Info("Audited chain data", "start", report.Start, "end", report.End, "frozen", report.Frozen, "tail", report.Tail, "issues", len(report.Issues))
+	if len(report.Issues) == 0 {
+		return nil
+	}
+	var rows [][]string
+	for _, kind := range kinds {
+		rows = append(rows, []string{kind, strconv.Itoa(counts[kind]), strconv.Itoa(repairable[kind])})
+	}
+	table := rawdb.NewTableWriter(os.Stdout)
+	table.SetHeader([]string{"Issue", "Blocks", "Repairable"})
+	table.AppendBulk(rows)
+	table.Render()
+
+	if !repair {
+		fmt.Println("Run the command with --repair to fix the inconsistencies.")
+		return nil
+	}
+	// Guide through the repairs one kind of issue at a time.
+	for _, kind := range kinds {
+		if repairable[kind] == 0 {
+			continue
+		}
+		confirm, err := prompt.Stdin.PromptConfirm(fmt.Sprintf("Repair %d blocks with %s?", repairable[kind], kind))
+		if err != nil {
+			return err
+		}
+		if !confirm {
+			continue
+		}
+		var issues []*rawdb.AuditIssue
+		for _, issue := range report.Issues {
+			if issue.Kind == kind {
+				issues = append(issues, issue)
+			}
+		}
+		repaired, err := rawdb.RepairChain(db, issues)
+		if err != nil {
+			return err
+		}
+		log.Info("Repaired chain data", "issue", kind, "blocks", repaired)
+	}
+	issue := report.FirstUnrepairable()
+	if issue == nil {
+		return nil
+	}
+	if issue.Number == 0 {
+		fmt.Println("The genesis block data is missing, the database must be resynced.")
+		return nil
+	}
+	confirm, err := prompt.Stdin.PromptConfirm(fmt.Sprintf("Chain data of block #%d can't be repaired. Rewind the chain head to #%d?", issue.Number, issue.Number-1))
+	if err != nil {
+		return err
+	}
+	if !confirm {
+		return nil
+	}
+	if err := rawdb.RewindChainHead(db, issue.Number-1); err != nil {
+		return err
+	}
+	log.Info("Rewound chain head, missing blocks are downloaded again on startup", "number", issue.Number-1)
+	return nil
+}
+
 func showDBStats(db ethdb.KeyValueStater) {
 	stats, err := db.Stat()
 	if err != nil {
@@ -425,6 +611,30 @@ func dbCompact(ctx *cli.Context) error {
 	return nil
 }
 
+func dbMigrate(ctx *cli.Context) error {
+	stack, _ := makeConfigNode(ctx)
+	defer stack.Close()
+
+	dryRun := ctx.Bool(migrateDryRunFlag.Name)
+	db := utils.MakeChainDatabase(ctx, stack, dryRun)
+	defer db.Close()
+
+	config := rawdb.SchemaMigrationConfig{DryRun: dryRun}
+	if ctx.IsSet(utils.DBMigrationBackupFlag.Name) {
+		config.BackupDir = stack.ResolvePath(ctx.String(utils.DBMigrationBackupFlag.Name))
+	}
+	migrations, err := rawdb.MigrateSchema(db, config)
+	if err != nil {
+		return err
+	}
+	if dryRun {
+		fmt.Printf("%d pending schema migrations, latest schema version is v%d\n", len(migrations), rawdb.LatestSchemaVersion())
+	} else {
+		log.Info("Database schema is up to date", "version", rawdb.LatestSchemaVersion(), "applied", len(migrations))
+	}
+	return nil
+}
+
 // dbGet shows the value of a given database key
 func dbGet(ctx *cli.Context) error {
 	if ctx.NArg() != 1 {
@@ -604,6 +814,23 @@ func freezerInspect(ctx *cli.Context) error {
 	return rawdb.InspectFreezerTable(ancient, freezer, table, start, end)
 }
 
+func freezerCompress(ctx *cli.Context) error {
+	tables := ctx.Args().Slice()
+	if len(tables) == 0 {
+		tables = []string{rawdb.ChainFreezerBodiesTable, rawdb.ChainFreezerReceiptTable}
+	}
+	stack, _ := makeConfigNode(ctx)
+	ancient := stack.ResolveAncient("chaindata", ctx.String(utils.AncientFlag.Name))
+	stack.Close()
+
+	for _, table := range tables {
+		if err := rawdb.CompressFreezerTable(ancient, table); err != nil {
+			return fmt.Errorf("failed to migrate table %s: %w", table, err)
+		}
+	}
+	return nil
+}
+
 func importLDBdata(ctx *cli.Context) error {
 	start := 0
 	switch ctx.NArg() {
@@ -906,3 +1133,427 @@ func inspectHistory(ctx *cli.Context) error {
 	}
 	return inspectStorage(triedb, start, end, address, slot, ctx.Bool("raw"))
 }
+
+// dbConvertScheme converts the persisted state to the given state scheme.
+func dbConvertScheme(ctx *cli.Context) error {
+	if ctx.NArg() != 1 {
+		return fmt.Errorf("required arguments: %v", ctx.Command.ArgsUsage)
+	}
+	scheme := ctx.Args().First()
+	if scheme != rawdb.HashScheme && scheme != rawdb.PathScheme {
+		return fmt.Errorf("invalid state scheme %s", scheme)
+	}
+	stack, _ := makeConfigNode(ctx)
+	defer stack.Close()
+
+	db := utils.MakeChainDatabase(ctx, stack, false)
+	defer db.Close()
+
+	return convertScheme(db, scheme, stack.ResolvePath("triedb"))
+}
+
+// convertScheme converts the persisted state to the given state scheme. The
+// progress is tracked in the database: an interrupted conversion is resumed by
+// running it again, and the node refuses to start until it's completed.
+//
+// The target state is written completely before anything of the source scheme
+// is deleted. Writing the state and deleting the source scheme are idempotent,
+// so either phase is simply rerun when resuming.
+func convertScheme(db ethdb.Database, scheme string, journalDir string) error {
+	conv := rawdb.ReadSchemeConversion(db)
+	if conv == nil {
+		switch stored := rawdb.ReadStateScheme(db); stored {
+		case "":
+			return errors.New("no state in database")
+		case scheme:
+			log.Info("State is already in the requested scheme", "scheme", scheme)
+			return nil
+		}
+		if rawdb.ReadSnapSyncStatusFlag(db) == rawdb.StateSyncRunning {
+			return errors.New("state sync is not completed")
+		}
+		var err error
+		if conv, err = planConversion(db, scheme, journalDir); err != nil {
+			return err
+		}
+		rawdb.WriteSchemeConversion(db, conv)
+		log.Info("Converting state", "scheme", scheme, "number", conv.Number, "root", conv.Root, "snapshot", conv.Snapshot)
+	} else {
+		if conv.Scheme != scheme {
+			return fmt.Errorf("conversion to %s state scheme is in progress", conv.Scheme)
+		}
+		log.Info("Resuming state conversion", "scheme", scheme, "number", conv.Number, "root", conv.Root, "cleanup", conv.Cleanup)
+	}
+	start := time.Now()
+	if !conv.Cleanup {
+		if err := writeConvertedState(db, conv, journalDir); err != nil {
+			return err
+		}
+		conv.Cleanup = true
+		rawdb.WriteSchemeConversion(db, conv)
+	}
+	if err := deleteSourceState(db, conv, journalDir); err != nil {
+		return err
+	}
+	rawdb.DeleteSchemeConversion(db)
+	log.Info("Converted state", "scheme", scheme, "number", conv.Number, "root", conv.Root, "elapsed", common.PrettyDuration(time.Since(start)))
+	return nil
+}
+
+// planConversion selects the state to convert. The state of the flat snapshot
+// is preferred if it's completely generated and recent enough: its trie is
+// regenerated much faster than copied node by node, and the snapshot stays
+// usable in the target scheme. Otherwise the most recent state available in the
+// source scheme is copied.
+func planConversion(db ethdb.Database, scheme string, journalDir string) (*rawdb.SchemeConversion, error) {
+	if snapshot.GeneratorDone(db) {
+		snapRoot := rawdb.ReadSnapshotRoot(db)
+		header, err := convertibleState(db, func(root common.Hash) bool { return root == snapRoot })
+		if err == nil {
+			return &rawdb.SchemeConversion{Scheme: scheme, Root: header.Root, Number: header.Number.Uint64(), Snapshot: true}, nil
+		}
+	}
+	var available func(root common.Hash) bool
+	if scheme == rawdb.PathScheme {
+		available = func(root common.Hash) bool { return rawdb.HasLegacyTrieNode(db, root) }
+	} else {
+		tdb := openPathDatabase(db, journalDir)
+		defer tdb.Close()
+
+		available = func(root common.Hash) bool {
+			_, err := tdb.NodeReader(root)
+			return err == nil
+		}
+	}
+	header, err := convertibleState(db, available)
+	if err != nil {
+		return nil, err
+	}
+	return &rawdb.SchemeConversion{Scheme: scheme, Root: header.Root, Number: header.Number.Uint64()}, nil
+}
+
+// openPathDatabase opens the path-scheme trie database read-only, including the
+// in-memory layers saved in the journal.
+func openPathDatabase(db ethdb.Database, journalDir string) *triedb.Database {
+	config := *pathdb.ReadOnly
+	config.JournalDirectory = journalDir
+	return triedb.NewDatabase(db, &triedb.Config{PathDB: &config})
+}
+
+// writeConvertedState writes the state to convert in the target scheme, along
+// with the metadata the target scheme detects the state with.
+func writeConvertedState(db ethdb.Database, conv *rawdb.SchemeConversion, journalDir string) error {
+	if conv.Scheme == rawdb.HashScheme {
+		// The state history indexes share their key space with the hash-scheme
+		// nodes, drop them before any node is written.
+		batch := db.NewBatch()
+		rawdb.DeleteStateHistoryIndexMetadata(batch)
+		rawdb.DeleteTrienodeHistoryIndexMetadata(batch)
+		if err := batch.Write(); err != nil {
+			return err
+		}
+		rawdb.DeleteStateHistoryIndexes(db)
+		rawdb.DeleteTrienodeHistoryIndexes(db)
+	}
+	if err := writeState(db, conv, journalDir); err != nil {
+		return err
+	}
+	if conv.Scheme == rawdb.PathScheme {
+		// Mark the converted state as the persistent state with the initial id,
+		// the path database picks it up as its disk layer.
+		batch := db.NewBatch()
+		rawdb.WriteStateID(batch, conv.Root, 0)
+		rawdb.WritePersistentStateID(batch, 0)
+		return batch.Write()
+	}
+	// The hash scheme is detected by the presence of the genesis state.
+	tdb := triedb.NewDatabase(db, triedb.HashDefaults)
+	defer tdb.Close()
+
+	if err := core.CommitGenesisState(db, tdb, rawdb.ReadCanonicalHash(db, 0)); err != nil {
+		return fmt.Errorf("failed to commit genesis state: %v", err)
+	}
+	return nil
+}
+
+// writeState writes the trie nodes of the state to convert in the target scheme,
+// regenerated from the flat state or copied from the source scheme.
+func writeState(db ethdb.Database, conv *rawdb.SchemeConversion, journalDir string) error {
+	if conv.Snapshot {
+		err := snapshot.GenerateTrieFromDisk(db, conv.Root, conv.Scheme, db)
+		if err == nil {
+			return nil
+		}
+		// The flat state doesn't match its root, discard it and fall back to
+		// copying the most recent state. The nodes written so far are harmless
+		// in the hash scheme, but they'd be left dangling in the path scheme.
+		log.Warn("Failed to regenerate state from snapshot", "err", err)
+		if conv.Scheme == rawdb.PathScheme {
+			if _, err := deletePathNodes(db); err != nil {
+				return err
+			}
+		}
+		rawdb.DeleteSnapshotGenerator(db)
+		fallback, err := planConversion(db, conv.Scheme, journalDir)
+		if err != nil {
+			return err
+		}
+		*conv = *fallback
+		rawdb.WriteSchemeConversion(db, conv)
+		log.Info("Converting state", "scheme", conv.Scheme, "number", conv.Number, "root", conv.Root, "snapshot", conv.Snapshot)
+	}
+	var tdb *triedb.Database
+	if conv.Scheme == rawdb.PathScheme {
+		tdb = triedb.NewDatabase(db, triedb.HashDefaults)
+	} else {
+		tdb = openPathDatabase(db, journalDir)
+	}
+	defer tdb.Close()
+
+	return copyState(db, tdb, conv.Root, conv.Scheme)
+}
+
+// deleteSourceState deletes the trie nodes and the metadata of the source scheme
+// once the converted state is written.
+func deleteSourceState(db ethdb.Database, conv *rawdb.SchemeConversion, journalDir string) error {
+	if conv.Scheme == rawdb.PathScheme {
+		deleted, err := deleteHashNodes(db)
+		if err != nil {
+			return err
+		}
+		log.Info("Deleted hash-scheme trie nodes", "deleted", deleted)
+		return nil
+	}
+	deleted, err := deletePathNodes(db)
+	if err != nil {
+		return err
+	}
+	log.Info("Deleted path-scheme trie nodes", "deleted", deleted)
+
+	// Drop the metadata of the path database. Like after a state sync, the
+	// root->id mappings are left in place as they can be huge.
+	batch := db.NewBatch()
+	rawdb.DeletePersistentStateID(batch)
+	rawdb.DeleteTrieJournal(batch)
+	if err := batch.Write(); err != nil {
+		return err
+	}
+	if journalDir != "" {
+		if err := os.Remove(filepath.Join(journalDir, "merkle.journal")); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+	// Drop the state histories, they are meaningless in the hash scheme.
+	ancient, err := db.AncientDatadir()
+	if err != nil {
+		return err
+	}
+	for _, name := range []string{rawdb.MerkleStateFreezerName, rawdb.MerkleTrienodeFreezerName} {
+		if !common.FileExist(filepath.Join(ancient, name)) {
+			continue
+		}
+		var freezer ethdb.ResettableAncientStore
+		if name == rawdb.MerkleStateFreezerName {
+			freezer, err = rawdb.NewStateFreezer(ancient, false, false)
+		} else {
+			freezer, err = rawdb.NewTrienodeFreezer(ancient, false, false)
+		}
+		if err != nil {
+			return err
+		}
+		err = freezer.Reset()
+		freezer.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deleteHashNodes deletes all hash-scheme trie nodes. Contract code stored under
+// its hash without the key prefix is retained, it's told apart from the trie
+// nodes by its encoding.
+func deleteHashNodes(db ethdb.Database) (int, error) {
+	it := rawdb.NewKeyLengthIterator(db.NewIterator(nil, nil), common.HashLength)
+	defer it.Release()
+
+	return deleteNodes(db, it, "hash", func(key, value []byte) bool {
+		return rawdb.IsLegacyTrieNode(key, value) && isTrieNode(value)
+	})
+}
+
+// deletePathNodes deletes all path-scheme trie nodes. The key space is shared
+// with the hash-scheme nodes, which are retained.
+func deletePathNodes(db ethdb.Database) (int, error) {
+	var total int
+	for _, prefix := range [][]byte{rawdb.TrieNodeAccountPrefix, rawdb.TrieNodeStoragePrefix} {
+		it := db.NewIterator(prefix, nil)
+		deleted, err := deleteNodes(db, it, "path", func(key, value []byte) bool {
+			if rawdb.IsLegacyTrieNode(key, value) {
+				return false
+			}
+			return rawdb.IsAccountTrieNode(key) || rawdb.IsStorageTrieNode(key)
+		})
+		it.Release()
+		total += deleted
+		if err != nil {
+			return total, err
+		}
+	}
+	return total, nil
+}
+
+// deleteNodes deletes the entries of the iterator matching the filter.
+func deleteNodes(db ethdb.Database, it ethdb.Iterator, scheme string, match func(key, value []byte) bool) (int, error) {
+	var (
+		batch   = db.NewBatch()
+		deleted int
+		start   = time.Now()
+		logged  = time.Now()
+	)
+	for it.Next() {
+		if !match(it.Key(), it.Value()) {
+			continue
+		}
+		batch.Delete(it.Key())
+		deleted++
+
+		if batch.ValueSize() >= ethdb.IdealBatchSize {
+			if err := batch.Write(); err != nil {
+				return deleted, err
+			}
+			batch.Reset()
+		}
+		if time.Since(logged) > 8*time.Second {
+			log.Info("Deleting trie nodes", "scheme", scheme, "deleted", deleted, "elapsed", common.PrettyDuration(time.Since(start)))
+			logged = time.Now()
+		}
+	}
+	if err := it.Error(); err != nil {
+		return deleted, err
+	}
+	return deleted, batch.Write()
+}
+
+// isTrieNode reports whether the blob is an encoded trie node, that is a list of
+// two items (short node) or seventeen items (full node).
+func isTrieNode(blob []byte) bool {
+	content, rest, err := rlp.SplitList(blob)
+	if err != nil || len(rest) != 0 {
+		return false
+	}
+	n, err := rlp.CountValues(content)
+	return err == nil && (n == 2 || n == 17)
+}
+
+// convertibleState returns the header of the most recent block, up to
+// state.TriesInMemory blocks below the head, whose state is available.
+func convertibleState(db ethdb.Database, available func(root common.Hash) bool) (*types.Header, error) {
+	head := rawdb.ReadHeadBlockHash(db)
+	number, ok := rawdb.ReadHeaderNumber(db, head)
+	if !ok {
+		return nil, errors.New("no head block")
+	}
+	header := rawdb.ReadHeader(db, head, number)
+	for i := 0; header != nil && i <= state.TriesInMemory; i++ {
+		if available(header.Root) {
+			return header, nil
+		}
+		if header.Number.Sign() == 0 {
+			break
+		}
+		header = rawdb.ReadHeader(db, header.ParentHash, header.Number.Uint64()-1)
+	}
+	return nil, errors.New("no recent state available")
+}
+
+// copyState writes all trie nodes of the given state, read from the trie
+// database, into the key-value store in the given scheme. Contract code stored
+// without the key prefix is rewritten with it.
+func copyState(db ethdb.Database, tdb *triedb.Database, root common.Hash, scheme string) error {
+	t, err := trie.NewStateTrie(trie.StateTrieID(root), tdb)
+	if err != nil {
+		return err
+	}
+	accIter, err := t.NodeIterator(nil)
+	if err != nil {
+		return err
+	}
+	var (
+		batch    = db.NewBatch()
+		nodes    int
+		accounts int
+		start    = time.Now()
+		logged   = time.Now()
+	)
+	flush := func() error {
+		if batch.ValueSize() < ethdb.IdealBatchSize {
+			return nil
+		}
+		if err := batch.Write(); err != nil {
+			return err
+		}
+		batch.Reset()
+		return nil
+	}
+	for accIter.Next(true) {
+		if hash := accIter.Hash(); hash != (common.Hash{}) {
+			rawdb.WriteTrieNode(batch, common.Hash{}, accIter.Path(), hash, accIter.NodeBlob(), scheme)
+			nodes++
+		}
+		if !accIter.Leaf() {
+			continue
+		}
+		accounts++
+
+		var acc types.StateAccount
+		if err := rlp.DecodeBytes(accIter.LeafBlob(), &acc); err != nil {
+			return fmt.Errorf("invalid account: %v", err)
+		}
+		if codeHash := common.BytesToHash(acc.CodeHash); codeHash != types.EmptyCodeHash && !rawdb.HasCodeWithPrefix(db, codeHash) {
+			code := rawdb.ReadCode(db, codeHash)
+			if len(code) == 0 {
+				return fmt.Errorf("missing code %x", codeHash)
+			}
+			rawdb.WriteCode(batch, codeHash, code)
+		}
+		if acc.Root != types.EmptyRootHash {
+			owner := common.BytesToHash(accIter.LeafKey())
+			storageTrie, err := trie.NewStateTrie(trie.StorageTrieID(root, owner, acc.Root), tdb)
+			if err != nil {
+				return err
+			}
+			storageIter, err := storageTrie.NodeIterator(nil)
+			if err != nil {
+				return err
+			}
+			for storageIter.Next(true) {
+				if hash := storageIter.Hash(); hash != (common.Hash{}) {
+					rawdb.WriteTrieNode(batch, owner, storageIter.Path(), hash, storageIter.NodeBlob(), scheme)
+					nodes++
+				}
+				if err := flush(); err != nil {
+					return err
+				}
+			}
+			if err := storageIter.Error(); err != nil {
+				return err
+			}
+		}
+		if err := flush(); err != nil {
+			return err
+		}
+		if time.Since(logged) > 8*time.Second {
+			log.Info("Copying state", "accounts", accounts, "nodes", nodes, "elapsed", common.PrettyDuration(time.Since(start)))
+			logged = time.Now()
+		}
+	}
+	if err := accIter.Error(); err != nil {
+		return err
+	}
+	if err := batch.Write(); err != nil {
+		return err
+	}
+	log.Info("Copied state", "accounts", accounts, "nodes", nodes, "elapsed", common.PrettyDuration(time.Since(start)))
+	return nil
+}
@@ -0,0 +1,243 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of go-ethereum.
+//
+// go-ethereum is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-ethereum is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-ethereum. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/triedb"
+	"github.com/ethereum/go-ethereum/triedb/pathdb"
+)
+
+var (
+	convertKey, _   = crypto.GenerateKey()
+	convertBank     = crypto.PubkeyToAddress(convertKey.PublicKey)
+	convertUser     = common.HexToAddress("0x1111")
+	convertContract = common.HexToAddress("0xc0de")
+	convertCode     = []byte{0x60, 0x01, 0x60, 0x00, 0x55, 0x00} // PUSH1 1 PUSH1 0 SSTORE STOP
+	convertSlot     = common.HexToHash("0x01")
+)
+
+// newConvertTestDB creates a database holding a short chain in the given state
+// scheme. The code of the contract is stored without the key prefix, like in
+// legacy databases.
+func newConvertTestDB(t *testing.T, scheme string, snapshot bool) (ethdb.Database, *types.Header) {
+	t.Helper()
+
+	gspec := &core.Genesis{
+		Config: params.TestChainConfig,
+		Alloc: types.GenesisAlloc{
+			convertBank:     {Balance: big.NewInt(params.Ether)},
+			convertContract: {Code: convertCode, Storage: map[common.Hash]common.Hash{convertSlot: common.HexToHash("0x2a")}},
+		},
+	}
+	_, blocks, _ := core.GenerateChainWithGenesis(gspec, ethash.NewFaker(), 3, func(i int, b *core.BlockGen) {
+		tx, _ := types.SignTx(types.NewTransaction(b.TxNonce(convertBank), convertUser, big.NewInt(1000), params.TxGas, b.BaseFee(), nil), types.HomesteadSigner{}, convertKey)
+		b.AddTx(tx)
+	})
+	db, err := rawdb.Open(rawdb.NewMemoryDatabase(), rawdb.OpenOptions{Ancient: t.TempDir()})
+	if err != nil {
+		t.Fatalf("failed to create database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	config := core.DefaultConfig().WithStateScheme(scheme)
+	config.ArchiveMode = true
+	if !snapshot {
+		config.SnapshotLimit = 0
+	}
+	chain, err := core.NewBlockChain(db, gspec, ethash.NewFaker(), config)
+	if err != nil {
+		t.Fatalf("failed to create chain: %v", err)
+	}
+	if _, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("failed to insert chain: %v", err)
+	}
+	head := chain.CurrentBlock()
+	chain.Stop()
+
+	if scheme == rawdb.HashScheme {
+		codeHash := crypto.Keccak256Hash(convertCode)
+		rawdb.DeleteCode(db, codeHash)
+		db.Put(codeHash.Bytes(), convertCode)
+	}
+	return db, head
+}
+
+// checkConvertedState checks that the state with the given root is complete in
+// the given scheme, and that no trie node of the other scheme is left.
+func checkConvertedState(t *testing.T, db ethdb.Database, scheme string, root common.Hash, transfers int64) {
+	t.Helper()
+
+	if have := rawdb.ReadStateScheme(db); have != scheme {
+		t.Fatalf("wrong state scheme: have %q, want %q", have, scheme)
+	}
+	if conv := rawdb.ReadSchemeConversion(db); conv != nil {
+		t.Fatalf("conversion marker left: %+v", conv)
+	}
+	config := triedb.HashDefaults
+	if scheme == rawdb.PathScheme {
+		config = &triedb.Config{PathDB: pathdb.Defaults}
+	}
+	tdb := triedb.NewDatabase(db, config)
+	defer tdb.Close()
+
+	statedb, err := state.New(root, state.NewDatabase(tdb, nil))
+	if err != nil {
+		t.Fatalf("failed to open converted state: %v", err)
+	}
+	if have := statedb.GetBalance(convertUser).Uint64(); have != uint64(1000*transfers) {
+		t.Errorf("wrong balance: have %d, want %d", have, 1000*transfers)
+	}
+	if have := statedb.GetCode(convertContract); string(have) != string(convertCode) {
+		t.Errorf("wrong code: have %x, want %x", have, convertCode)
+	}
+	if have := statedb.GetState(convertContract, convertSlot); have != common.HexToHash("0x2a") {
+		t.Errorf("wrong storage: have %x, want %x", have, common.HexToHash("0x2a"))
+	}
+	if err := statedb.Error(); err != nil {
+		t.Fatalf("failed to read converted state: %v", err)
+	}
+	// Check the trie nodes of the source scheme are gone.
+	it := db.NewIterator(nil, nil)
+	defer it.Release()
+	for it.Next() {
+		switch scheme {
+		case rawdb.PathScheme:
+			if rawdb.IsLegacyTrieNode(it.Key(), it.Value()) && isTrieNode(it.Value()) {
+				t.Fatalf("hash-scheme trie node %x left", it.Key())
+			}
+		case rawdb.HashScheme:
+			if (rawdb.IsAccountTrieNode(it.Key()) || rawdb.IsStorageTrieNode(it.Key())) && !rawdb.IsLegacyTrieNode(it.Key(), it.Value()) {
+				t.Fatalf("path-scheme trie node %x left", it.Key())
+			}
+		}
+	}
+}
+
+func TestConvertScheme(t *testing.T) {
+	db, head := newConvertTestDB(t, rawdb.HashScheme, false)
+
+	// Code stored without prefix that isn't part of the state must survive.
+	orphan := []byte{0x60, 0x00, 0x60, 0x00, 0xfd}
+	db.Put(crypto.Keccak256(orphan), orphan)
+
+	if err := convertScheme(db, rawdb.PathScheme, ""); err != nil {
+		t.Fatalf("failed to convert to path scheme: %v", err)
+	}
+	checkConvertedState(t, db, rawdb.PathScheme, head.Root, 3)
+	if have, _ := db.Get(crypto.Keccak256(orphan)); string(have) != string(orphan) {
+		t.Fatalf("unprefixed contract code deleted")
+	}
+	if err := convertScheme(db, rawdb.HashScheme, ""); err != nil {
+		t.Fatalf("failed to convert to hash scheme: %v", err)
+	}
+	checkConvertedState(t, db, rawdb.HashScheme, head.Root, 3)
+}
+
+func TestConvertSchemeResume(t *testing.T) {
+	db, head := newConvertTestDB(t, rawdb.HashScheme, false)
+
+	// Interrupt the conversion after a part of the state is written.
+	conv, err := planConversion(db, rawdb.PathScheme, "")
+	if err != nil {
+		t.Fatalf("failed to plan conversion: %v", err)
+	}
+	rawdb.WriteSchemeConversion(db, conv)
+	rawdb.WriteAccountTrieNode(db, nil, rawdb.ReadLegacyTrieNode(db, conv.Root))
+
+	if _, err := rawdb.ParseStateScheme("", db); err == nil {
+		t.Fatal("state scheme accepted during conversion")
+	}
+	if err := convertScheme(db, rawdb.HashScheme, ""); err == nil {
+		t.Fatal("conversion to another scheme accepted during conversion")
+	}
+	if err := convertScheme(db, rawdb.PathScheme, ""); err != nil {
+		t.Fatalf("failed to resume conversion: %v", err)
+	}
+	checkConvertedState(t, db, rawdb.PathScheme, head.Root, 3)
+
+	// Interrupt the conversion back while the path-scheme nodes are deleted.
+	conv, err = planConversion(db, rawdb.HashScheme, "")
+	if err != nil {
+		t.Fatalf("failed to plan conversion: %v", err)
+	}
+	if err := writeConvertedState(db, conv, ""); err != nil {
+		t.Fatalf("failed to write state: %v", err)
+	}
+	conv.Cleanup = true
+	rawdb.WriteSchemeConversion(db, conv)
+	rawdb.DeleteAccountTrieNode(db, nil)
+
+	if err := convertScheme(db, rawdb.HashScheme, ""); err != nil {
+		t.Fatalf("failed to resume conversion: %v", err)
+	}
+	checkConvertedState(t, db, rawdb.HashScheme, head.Root, 3)
+}
+
+func TestConvertSchemeSnapshot(t *testing.T) {
+	db, _ := newConvertTestDB(t, rawdb.HashScheme, true)
+
+	// The snapshot disk layer of the short chain is the genesis state.
+	snapRoot := rawdb.ReadSnapshotRoot(db)
+	conv, err := planConversion(db, rawdb.PathScheme, "")
+	if err != nil {
+		t.Fatalf("failed to plan conversion: %v", err)
+	}
+	if !conv.Snapshot || conv.Root != snapRoot || conv.Number != 0 {
+		t.Fatalf("snapshot state not selected: %+v", conv)
+	}
+	if err := convertScheme(db, rawdb.PathScheme, ""); err != nil {
+		t.Fatalf("failed to convert to path scheme: %v", err)
+	}
+	checkConvertedState(t, db, rawdb.PathScheme, snapRoot, 0)
+	if rawdb.ReadSnapshotRoot(db) != snapRoot {
+		t.Fatal("snapshot not kept")
+	}
+}
+
+func TestIsTrieNode(t *testing.T) {
+	full, _ := rlp.EncodeToBytes(make([][]byte, 17))
+	short, _ := rlp.EncodeToBytes([][]byte{{0x20}, {0x01}})
+	list, _ := rlp.EncodeToBytes([][]byte{{0x01}, {0x02}, {0x03}})
+
+	for _, test := range []struct {
+		blob []byte
+		want bool
+	}{
+		{full, true},
+		{short, true},
+		{list, false},
+		{convertCode, false},
+		{append(short, 0x00), false},
+	} {
+		if have := isTrieNode(test.blob); have != test.want {
+			t.Errorf("isTrieNode(%x): have %v, want %v", test.blob, have, test.want)
+		}
+	}
+}
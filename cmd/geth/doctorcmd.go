@@ -0,0 +1,491 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of go-ethereum.
+//
+// go-ethereum is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-ethereum is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-ethereum. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"math/rand"
+	"os"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/cmd/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/ethclient/gethclient"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+	"github.com/ethereum/go-ethereum/node"
+	"github.com/ethereum/go-ethereum/p2p"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	doctorSamplesFlag = &cli.IntFlag{
+		Name:  "samples",
+		Usage: "Number of blocks and accounts sampled by the integrity checks",
+		Value: 16,
+	}
+	doctorMinPeersFlag = &cli.IntFlag{
+		Name:  "minpeers",
+		Usage: "Minimum number of connected peers for a healthy node",
+		Value: 3,
+	}
+	doctorLatencyFlag = &cli.DurationFlag{
+		Name:  "latency",
+		Usage: "Maximum RPC round trip time for a healthy node",
+		Value: 500 * time.Millisecond,
+	}
+	doctorAuthRPCFlag = &cli.StringFlag{
+		Name:  "authrpc",
+		Usage: "Endpoint of the engine API (empty = http://localhost:8551)",
+	}
+
+	doctorCommand = &cli.Command{
+		Action:    doctor,
+		Name:      "doctor",
+		Usage:     "Run a health check suite against a running node",
+		ArgsUsage: "[endpoint]",
+		Flags: []cli.Flag{
+			utils.DataDirFlag,
+			utils.HttpHeaderFlag,
+			utils.JWTSecretFlag,
+			doctorSamplesFlag,
+			doctorMinPeersFlag,
+			doctorLatencyFlag,
+			doctorAuthRPCFlag,
+		},
+		Description: `
+The doctor command connects to a running node (over IPC by default) and checks
+its health end-to-end: RPC latency, block and receipt integrity of sampled blocks,
+consistency of sampled accounts between the state trie and the flat state, peer
+connectivity, engine API reachability and the chain freezer boundary.
+
+The report is printed as JSON. The command fails if any of the checks failed.`,
+	}
+)
+
+// Outcomes of a doctor check.
+const (
+	checkPass = "pass"
+	checkWarn = "warn"
+	checkFail = "fail"
+	checkSkip = "skip"
+)
+
+// doctorCheck is the outcome of a single health check.
+type doctorCheck struct {
+	Name    string         `json:"name"`
+	Status  string         `json:"status"`
+	Message string         `json:"message,omitempty"`
+	Elapsed float64        `json:"elapsedMs"`
+	Details map[string]any `json:"details,omitempty"`
+}
+
+// doctorReport is the machine-readable outcome of the health suite.
+type doctorReport struct {
+	Endpoint string        `json:"endpoint"`
+	Time     time.Time     `json:"time"`
+	Healthy  bool          `json:"healthy"`
+	Checks   []doctorCheck `json:"checks"`
+}
+
+// doctorEnv holds the connections and settings shared by the checks.
+type doctorEnv struct {
+	rpc      *rpc.Client
+	eth      *ethclient.Client
+	geth     *gethclient.Client
+	samples  int
+	minPeers int
+	latency  time.Duration
+
+	authEndpoint string
+	jwtSecret    string
+}
+
+// doctorChecks is the ordered list of checks run by the doctor command.
+var doctorChecks = []struct {
+	name string
+	run  func(ctx context.Context, env *doctorEnv) (status, message string, details map[string]any)
+}{
+	{"rpc-latency", checkRPCLatency},
+	{"sync-status", checkSyncStatus},
+	{"block-integrity", checkBlockIntegrity},
+	{"state-consistency", checkStateConsistency},
+	{"peers", checkPeers},
+	{"engine-api", checkEngineAPI},
+	{"freezer-boundary", checkFreezerBoundary},
+}
+
+func doctor(ctx *cli.Context) error {
+	if ctx.Args().Len() > 1 {
+		utils.Fatalf("invalid command-line: too many arguments")
+	}
+	cfg := defaultNodeConfig()
+	utils.SetDataDir(ctx, &cfg)
+
+	endpoint := ctx.Args().First()
+	if endpoint == "" {
+		endpoint = cfg.IPCEndpoint()
+	}
+	client, err := utils.DialRPCWithHeaders(endpoint, ctx.StringSlice(utils.HttpHeaderFlag.Name))
+	if err != nil {
+		utils.Fatalf("Unable to attach to remote geth: %v", err)
+	}
+	defer client.Close()
+
+	env := &doctorEnv{
+		rpc:          client,
+		eth:          ethclient.NewClient(client),
+		geth:         gethclient.New(client),
+		samples:      ctx.Int(doctorSamplesFlag.Name),
+		minPeers:     ctx.Int(doctorMinPeersFlag.Name),
+		latency:      ctx.Duration(doctorLatencyFlag.Name),
+		authEndpoint: ctx.String(doctorAuthRPCFlag.Name),
+		jwtSecret:    ctx.String(utils.JWTSecretFlag.Name),
+	}
+	if env.authEndpoint == "" {
+		env.authEndpoint = fmt.Sprintf("http://%s:%d", node.DefaultAuthHost, node.DefaultAuthPort)
+	}
+	if env.jwtSecret == "" {
+		env.jwtSecret = cfg.ResolvePath("jwtsecret")
+	}
+	report := runDoctor(ctx.Context, env)
+	report.Endpoint = endpoint
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(report); err != nil {
+		return err
+	}
+	if !report.Healthy {
+		return errors.New("node is unhealthy")
+	}
+	return nil
+}
+
+// runDoctor runs all the checks, a failing check does not prevent the next
+// ones from running.
+func runDoctor(ctx context.Context, env *doctorEnv) *doctorReport {
+	report := &doctorReport{Time: time.Now(), Healthy: true}
+	for _, check := range doctorChecks {
+		cctx, cancel := context.WithTimeout(ctx, time.Minute)
+		start := time.Now()
+		status, message, details := check.run(cctx, env)
+		cancel()
+
+		report.Checks = append(report.Checks, doctorCheck{
+			Name:    check.name,
+			Status:  status,
+			Message: message,
+			Elapsed: float64(time.Since(start).Microseconds()) / 1000,
+			Details: details,
+		})
+		if status == checkFail {
+			report.Healthy = false
+		}
+	}
+	return report
+}
+
+// isMethodNotFound reports whether the error is caused by an RPC method that is
+// unavailable, e.g. because its namespace is not exposed on the endpoint.
+func isMethodNotFound(err error) bool {
+	var rpcErr rpc.Error
+	return errors.As(err, &rpcErr) && rpcErr.ErrorCode() == -32601
+}
+
+// isHistoryUnavailable reports whether the error is caused by requesting chain
+// history that the node legitimately doesn't serve, i.e. missing or pruned.
+func isHistoryUnavailable(err error) bool {
+	if errors.Is(err, ethereum.NotFound) {
+		return true
+	}
+	var rpcErr rpc.Error
+	return errors.As(err, &rpcErr) && rpcErr.ErrorCode() == 4444 // history.PrunedHistoryError
+}
+
+// checkRPCLatency measures the round trip time of a few cheap calls.
+func checkRPCLatency(ctx context.Context, env *doctorEnv) (string, string, map[string]any) {
+	var (
+		total, worst time.Duration
+		calls        = []string{"eth_blockNumber", "eth_chainId", "net_version"}
+		rounds       = 5
+	)
+	for i := 0; i < rounds; i++ {
+		for _, method := range calls {
+			var result any
+			start := time.Now()
+			if err := env.rpc.CallContext(ctx, &result, method); err != nil {
+				return checkFail, fmt.Sprintf("%s failed: %v", method, err), nil
+			}
+			elapsed := time.Since(start)
+			total += elapsed
+			worst = max(worst, elapsed)
+		}
+	}
+	details := map[string]any{
+		"calls": rounds * len(calls),
+		"avgMs": float64(total.Microseconds()) / float64(rounds*len(calls)) / 1000,
+		"maxMs": float64(worst.Microseconds()) / 1000,
+	}
+	if worst > env.latency {
+		return checkWarn, fmt.Sprintf("slowest call took %v", worst), details
+	}
+	return checkPass, "", details
+}
+
+// checkSyncStatus reports whether the node is still syncing.
+func checkSyncStatus(ctx context.Context, env *doctorEnv) (string, string, map[string]any) {
+	progress, err := env.eth.SyncProgress(ctx)
+	if err != nil {
+		return checkFail, err.Error(), nil
+	}
+	head, err := env.eth.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return checkFail, err.Error(), nil
+	}
+	details := map[string]any{
+		"head":    head.Number.Uint64(),
+		"headAge": time.Since(time.Unix(int64(head.Time), 0)).Round(time.Second).String(),
+	}
+	if progress != nil {
+		details["highest"] = progress.HighestBlock
+		return checkWarn, "node is syncing", details
+	}
+	return checkPass, "", details
+}
+
+// checkBlockIntegrity verifies the header hash, transaction root and receipt
+// root of randomly sampled blocks.
+func checkBlockIntegrity(ctx context.Context, env *doctorEnv) (string, string, map[string]any) {
+	head, err := env.eth.BlockNumber(ctx)
+	if err != nil {
+		return checkFail, err.Error(), nil
+	}
+	var checked, unavailable int
+	for i := 0; i < env.samples; i++ {
+		number := uint64(rand.Int63n(int64(head) + 1))
+		if i == 0 {
+			number = head
+		}
+		var raw struct {
+			Hash common.Hash `json:"hash"`
+		}
+		if err := env.rpc.CallContext(ctx, &raw, "eth_getBlockByNumber", hexutil.EncodeUint64(number), false); err != nil {
+			if isHistoryUnavailable(err) {
+				unavailable++
+				continue
+			}
+			return checkFail, fmt.Sprintf("block %d: %v", number, err), nil
+		}
+		block, err := env.eth.BlockByNumber(ctx, new(big.Int).SetUint64(number))
+		if err != nil {
+			// Bodies of pruned history are legitimately missing, anything else
+			// (e.g. undecodable or inconsistent bodies) is a failure.
+			if isHistoryUnavailable(err) {
+				unavailable++
+				continue
+			}
+			return checkFail, fmt.Sprintf("block %d: %v", number, err), nil
+		}
+		if hash := block.Header().Hash(); hash != raw.Hash {
+			return checkFail, fmt.Sprintf("block %d: header hashes to %x, reported %x", number, hash, raw.Hash), nil
+		}
+		if root := types.DeriveSha(block.Transactions(), trie.NewStackTrie(nil)); root != block.TxHash() {
+			return checkFail, fmt.Sprintf("block %d: transaction root mismatch, have %x, want %x", number, root, block.TxHash()), nil
+		}
+		receipts, err := env.eth.BlockReceipts(ctx, rpc.BlockNumberOrHashWithHash(block.Hash(), false))
+		if err != nil {
+			if isHistoryUnavailable(err) {
+				unavailable++
+				continue
+			}
+			return checkFail, fmt.Sprintf("block %d receipts: %v", number, err), nil
+		}
+		if root := types.DeriveSha(types.Receipts(receipts), trie.NewStackTrie(nil)); root != block.ReceiptHash() {
+			return checkFail, fmt.Sprintf("block %d: receipt root mismatch, have %x, want %x", number, root, block.ReceiptHash()), nil
+		}
+		checked++
+	}
+	details := map[string]any{"checked": checked, "unavailable": unavailable}
+	if checked == 0 {
+		return checkWarn, "no block could be checked", details
+	}
+	return checkPass, "", details
+}
+
+// checkStateConsistency compares the accounts touched by the head block, as
+// proven against the state root, with the values served from the flat state.
+func checkStateConsistency(ctx context.Context, env *doctorEnv) (string, string, map[string]any) {
+	block, err := env.eth.BlockByNumber(ctx, nil)
+	if err != nil {
+		return checkFail, err.Error(), nil
+	}
+	accounts := []common.Address{block.Coinbase()}
+	for _, tx := range block.Transactions() {
+		if len(accounts) >= env.samples {
+			break
+		}
+		if tx.To() != nil {
+			accounts = append(accounts, *tx.To())
+		}
+	}
+	for _, addr := range accounts {
+		proof, err := env.geth.GetProof(ctx, addr, nil, block.Number())
+		if err != nil {
+			if isMethodNotFound(err) {
+				return checkSkip, "eth_getProof unavailable", nil
+			}
+			return checkFail, fmt.Sprintf("account %x: %v", addr, err), nil
+		}
+		if err := verifyAccountProof(block.Root(), proof); err != nil {
+			return checkFail, fmt.Sprintf("account %x: %v", addr, err), nil
+		}
+		balance, err := env.eth.BalanceAt(ctx, addr, block.Number())
+		if err != nil {
+			return checkFail, fmt.Sprintf("account %x: %v", addr, err), nil
+		}
+		nonce, err := env.eth.NonceAt(ctx, addr, block.Number())
+		if err != nil {
+			return checkFail, fmt.Sprintf("account %x: %v", addr, err), nil
+		}
+		if balance.Cmp(proof.Balance) != 0 || nonce != proof.Nonce {
+			return checkFail, fmt.Sprintf("account %x: flat state (balance %v, nonce %d) differs from trie (balance %v, nonce %d)", addr, balance, nonce, proof.Balance, proof.Nonce), nil
+		}
+	}
+	return checkPass, "", map[string]any{"block": block.NumberU64(), "accounts": len(accounts)}
+}
+
+// verifyAccountProof checks the account proof against the state root and that
+// the proven account matches the reported fields.
+func verifyAccountProof(root common.Hash, result *gethclient.AccountResult) error {
+	db := memorydb.New()
+	for _, encoded := range result.AccountProof {
+		blob, err := hexutil.Decode(encoded)
+		if err != nil {
+			return err
+		}
+		db.Put(crypto.Keccak256(blob), blob)
+	}
+	value, err := trie.VerifyProof(root, crypto.Keccak256(result.Address.Bytes()), db)
+	if err != nil {
+		return fmt.Errorf("invalid account proof: %v", err)
+	}
+	if value == nil {
+		if result.Nonce != 0 || result.Balance.Sign() != 0 {
+			return errors.New("proof of absence for a non-empty account")
+		}
+		return nil
+	}
+	var account types.StateAccount
+	if err := rlp.DecodeBytes(value, &account); err != nil {
+		return fmt.Errorf("invalid proven account: %v", err)
+	}
+	if account.Nonce != result.Nonce || account.Balance.ToBig().Cmp(result.Balance) != 0 ||
+		common.BytesToHash(account.CodeHash) != result.CodeHash || account.Root != result.StorageHash {
+		return errors.New("proven account differs from the reported one")
+	}
+	return nil
+}
+
+// checkPeers reports the number and direction of the connected peers.
+func checkPeers(ctx context.Context, env *doctorEnv) (string, string, map[string]any) {
+	var peers []*p2p.PeerInfo
+	if err := env.rpc.CallContext(ctx, &peers, "admin_peers"); err != nil {
+		if isMethodNotFound(err) {
+			return checkSkip, "admin namespace unavailable", nil
+		}
+		return checkFail, err.Error(), nil
+	}
+	var inbound int
+	for _, peer := range peers {
+		if peer.Network.Inbound {
+			inbound++
+		}
+	}
+	details := map[string]any{"peers": len(peers), "inbound": inbound, "outbound": len(peers) - inbound}
+	switch {
+	case len(peers) == 0:
+		return checkFail, "no peers connected", details
+	case len(peers) < env.minPeers:
+		return checkWarn, fmt.Sprintf("only %d peers connected", len(peers)), details
+	}
+	return checkPass, "", details
+}
+
+// checkEngineAPI verifies that the authenticated engine API accepts requests.
+func checkEngineAPI(ctx context.Context, env *doctorEnv) (string, string, map[string]any) {
+	if _, err := os.Stat(env.jwtSecret); err != nil {
+		return checkSkip, fmt.Sprintf("jwt secret unavailable: %v", err), nil
+	}
+	secret, err := node.ObtainJWTSecret(env.jwtSecret)
+	if err != nil {
+		return checkFail, err.Error(), nil
+	}
+	client, err := rpc.DialOptions(ctx, env.authEndpoint, rpc.WithHTTPAuth(node.NewJWTAuth(common.BytesToHash(secret))))
+	if err != nil {
+		return checkFail, err.Error(), nil
+	}
+	defer client.Close()
+
+	var capabilities []string
+	if err := client.CallContext(ctx, &capabilities, "engine_exchangeCapabilities", []string{}); err != nil {
+		return checkFail, err.Error(), nil
+	}
+	return checkPass, "", map[string]any{"endpoint": env.authEndpoint, "capabilities": len(capabilities)}
+}
+
+// checkFreezerBoundary verifies that the chain is continuous across the last
+// block moved into the freezer and the first one still in the key-value store.
+func checkFreezerBoundary(ctx context.Context, env *doctorEnv) (string, string, map[string]any) {
+	var frozen hexutil.Uint64
+	if err := env.rpc.CallContext(ctx, &frozen, "debug_dbAncients"); err != nil {
+		if isMethodNotFound(err) {
+			return checkSkip, "debug namespace unavailable", nil
+		}
+		return checkFail, err.Error(), nil
+	}
+	details := map[string]any{"frozen": uint64(frozen)}
+	if frozen == 0 {
+		return checkPass, "freezer is empty", details
+	}
+	last, err := env.eth.HeaderByNumber(ctx, new(big.Int).SetUint64(uint64(frozen)-1))
+	if err != nil {
+		return checkFail, fmt.Sprintf("last frozen header: %v", err), details
+	}
+	next, err := env.eth.HeaderByNumber(ctx, new(big.Int).SetUint64(uint64(frozen)))
+	if errors.Is(err, ethereum.NotFound) {
+		return checkPass, "", details // everything up to the head is frozen
+	}
+	if err != nil {
+		return checkFail, fmt.Sprintf("first live header: %v", err), details
+	}
+	if next.ParentHash != last.Hash() {
+		return checkFail, fmt.Sprintf("chain broken at freezer boundary %d", uint64(frozen)), details
+	}
+	return checkPass, "", details
+}
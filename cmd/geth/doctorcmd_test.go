@@ -0,0 +1,225 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of go-ethereum.
+//
+// go-ethereum is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-ethereum is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-ethereum. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/history"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/internal/ethapi"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/ethereum/go-ethereum/trie"
+)
+
+// doctorTestEth is a minimal eth namespace serving a fixed chain to the checks.
+type doctorTestEth struct {
+	blocks   []*types.Block
+	receipts []types.Receipts
+
+	pruned     uint64 // Bodies and receipts below this block are pruned
+	dropTxs    bool   // Serve block bodies without their transactions
+	corrupt    bool   // Serve receipts not matching the receipt roots
+	receiptErr error  // Error returned by receipt queries
+}
+
+func (api *doctorTestEth) BlockNumber() hexutil.Uint64 {
+	return hexutil.Uint64(len(api.blocks) - 1)
+}
+
+func (api *doctorTestEth) GetBlockByNumber(number rpc.BlockNumber, fullTx bool) (map[string]interface{}, error) {
+	if number < 0 || int(number) >= len(api.blocks) {
+		return nil, nil
+	}
+	if uint64(number) < api.pruned {
+		return nil, &history.PrunedHistoryError{}
+	}
+	fields := ethapi.RPCMarshalBlock(api.blocks[number], true, fullTx, params.TestChainConfig)
+	if api.dropTxs {
+		fields["transactions"] = []interface{}{}
+	}
+	return fields, nil
+}
+
+func (api *doctorTestEth) GetBlockReceipts(blockNrOrHash rpc.BlockNumberOrHash) (types.Receipts, error) {
+	if api.receiptErr != nil {
+		return nil, api.receiptErr
+	}
+	hash, _ := blockNrOrHash.Hash()
+	for i, block := range api.blocks {
+		if block.Hash() != hash {
+			continue
+		}
+		if uint64(i) < api.pruned {
+			return nil, &history.PrunedHistoryError{}
+		}
+		if api.corrupt {
+			return api.receipts[i][:len(api.receipts[i])-1], nil
+		}
+		return api.receipts[i], nil
+	}
+	return nil, nil
+}
+
+// newDoctorTestEth creates a chain of blocks with a few transfers each.
+func newDoctorTestEth(t *testing.T, blocks int) *doctorTestEth {
+	t.Helper()
+
+	var (
+		key, _ = crypto.GenerateKey()
+		signer = types.LatestSigner(params.TestChainConfig)
+		api    = new(doctorTestEth)
+		parent common.Hash
+		nonce  uint64
+	)
+	for i := 0; i < blocks; i++ {
+		var (
+			txs      types.Transactions
+			receipts types.Receipts
+		)
+		for j := 0; j < 3; j++ {
+			tx := types.MustSignNewTx(key, signer, &types.LegacyTx{
+				Nonce:    nonce,
+				To:       &common.Address{0xaa},
+				Value:    big.NewInt(1),
+				Gas:      params.TxGas,
+				GasPrice: big.NewInt(params.InitialBaseFee),
+			})
+			nonce++
+
+			receipt := &types.Receipt{
+				Type:              tx.Type(),
+				Status:            types.ReceiptStatusSuccessful,
+				CumulativeGasUsed: params.TxGas * uint64(j+1),
+				Logs:              []*types.Log{},
+				TxHash:            tx.Hash(),
+				GasUsed:           params.TxGas,
+				TransactionIndex:  uint(j),
+			}
+			receipt.Bloom = types.CreateBloom(receipt)
+			txs = append(txs, tx)
+			receipts = append(receipts, receipt)
+		}
+		header := &types.Header{
+			ParentHash: parent,
+			Number:     big.NewInt(int64(i)),
+			Difficulty: big.NewInt(1),
+			GasLimit:   params.GenesisGasLimit,
+			GasUsed:    params.TxGas * uint64(len(txs)),
+			BaseFee:    big.NewInt(params.InitialBaseFee),
+		}
+		block := types.NewBlock(header, &types.Body{Transactions: txs}, receipts, trie.NewStackTrie(nil))
+		parent = block.Hash()
+
+		api.blocks = append(api.blocks, block)
+		api.receipts = append(api.receipts, receipts)
+	}
+	return api
+}
+
+// newDoctorTestEnv serves the given eth namespace in-process to the checks.
+func newDoctorTestEnv(t *testing.T, api *doctorTestEth, samples int) *doctorEnv {
+	t.Helper()
+
+	server := rpc.NewServer()
+	if err := server.RegisterName("eth", api); err != nil {
+		t.Fatalf("failed to register eth namespace: %v", err)
+	}
+	client := rpc.DialInProc(server)
+	t.Cleanup(func() {
+		client.Close()
+		server.Stop()
+	})
+	return &doctorEnv{
+		rpc:     client,
+		eth:     ethclient.NewClient(client),
+		samples: samples,
+	}
+}
+
+func TestDoctorBlockIntegrity(t *testing.T) {
+	tests := []struct {
+		name        string
+		tweak       func(api *doctorTestEth)
+		status      string
+		unavailable bool // Whether some of the samples are expected to be unavailable
+	}{
+		{
+			name:   "healthy",
+			tweak:  func(api *doctorTestEth) {},
+			status: checkPass,
+		},
+		{
+			// Only the head is served, which is always sampled
+			name:        "pruned",
+			tweak:       func(api *doctorTestEth) { api.pruned = uint64(len(api.blocks) - 1) },
+			status:      checkPass,
+			unavailable: true,
+		},
+		{
+			name:        "all-pruned",
+			tweak:       func(api *doctorTestEth) { api.pruned = uint64(len(api.blocks)) },
+			status:      checkWarn,
+			unavailable: true,
+		},
+		{
+			name:   "missing-transactions",
+			tweak:  func(api *doctorTestEth) { api.dropTxs = true },
+			status: checkFail,
+		},
+		{
+			name:   "receipt-root",
+			tweak:  func(api *doctorTestEth) { api.corrupt = true },
+			status: checkFail,
+		},
+		{
+			name:   "receipt-error",
+			tweak:  func(api *doctorTestEth) { api.receiptErr = errors.New("corrupted receipts") },
+			status: checkFail,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := newDoctorTestEth(t, 16)
+			tt.tweak(api)
+
+			env := newDoctorTestEnv(t, api, 8)
+			status, message, details := checkBlockIntegrity(context.Background(), env)
+			if status != tt.status {
+				t.Fatalf("status mismatch: have %s (%s), want %s", status, message, tt.status)
+			}
+			if status == checkFail {
+				return
+			}
+			checked, unavailable := details["checked"].(int), details["unavailable"].(int)
+			if checked+unavailable != 8 {
+				t.Errorf("samples mismatch: checked %d, unavailable %d, want 8 total", checked, unavailable)
+			}
+			if (unavailable > 0) != tt.unavailable {
+				t.Errorf("unexpected unavailable samples: %d", unavailable)
+			}
+		})
+	}
+}
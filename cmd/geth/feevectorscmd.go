@@ -0,0 +1,109 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of go-ethereum.
+//
+// go-ethereum is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-ethereum is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-ethereum. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/ethereum/go-ethereum/internal/feevectors"
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	feeVectorsSeedFlag = &cli.Int64Flag{
+		Name:  "seed",
+		Usage: "Seed of the pseudo-random transaction generator",
+		Value: 1,
+	}
+	feeVectorsCountFlag = &cli.IntFlag{
+		Name:  "count",
+		Usage: "Number of test vectors to generate",
+		Value: 120,
+	}
+	feeVectorsOutputFlag = &cli.StringFlag{
+		Name:  "output",
+		Usage: "File to write the test vectors to (default = stdout)",
+	}
+
+	genFeeVectorsCommand = &cli.Command{
+		Action: genFeeVectors,
+		Name:   "gen-fee-vectors",
+		Usage:  "Generates deterministic test vectors for the transaction fee functions",
+		Flags: []cli.Flag{
+			feeVectorsSeedFlag,
+			feeVectorsCountFlag,
+			feeVectorsOutputFlag,
+		},
+		Description: `
+The gen-fee-vectors command generates signed transactions of all types with
+varying calldata, and writes their intrinsic gas, EIP-7623 calldata tokens and
+floor data gas, blob base fee and minimum total fee under the Shanghai, Cancun,
+Prague and Osaka rules as JSON. The output only depends on the seed, so other
+clients can check their fee functions against it.`,
+	}
+	verifyFeeVectorsCommand = &cli.Command{
+		Action:    verifyFeeVectors,
+		Name:      "verify-fee-vectors",
+		Usage:     "Verifies transaction fee test vectors produced by another client",
+		ArgsUsage: "<file>",
+		Description: `
+The verify-fee-vectors command recomputes the outputs of the test vectors in the
+given file from their transactions and fee parameters, and reports every output
+which doesn't match. The file uses the format of gen-fee-vectors.`,
+	}
+)
+
+func genFeeVectors(ctx *cli.Context) error {
+	suite, err := feevectors.Generate(ctx.Int64(feeVectorsSeedFlag.Name), ctx.Int(feeVectorsCountFlag.Name))
+	if err != nil {
+		return err
+	}
+	out := os.Stdout
+	if path := ctx.String(feeVectorsOutputFlag.Name); path != "" {
+		f, err := os.Create(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		out = f
+	}
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+	return enc.Encode(suite)
+}
+
+func verifyFeeVectors(ctx *cli.Context) error {
+	if ctx.Args().Len() != 1 {
+		return errors.New("expected the test vector file as the only argument")
+	}
+	data, err := os.ReadFile(ctx.Args().First())
+	if err != nil {
+		return err
+	}
+	var suite feevectors.Suite
+	if err := json.Unmarshal(data, &suite); err != nil {
+		return fmt.Errorf("invalid test vectors: %v", err)
+	}
+	if err := feevectors.Verify(&suite); err != nil {
+		return err
+	}
+	fmt.Printf("All %d test vectors match\n", len(suite.Vectors))
+	return nil
+}
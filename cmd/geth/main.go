@@ -69,9 +69,12 @@ var (
 		utils.OverrideGenesisFlag,
 		utils.EnablePersonal, // deprecated
 		utils.TxPoolLocalsFlag,
+		utils.TxPoolLocalAccountsFlag,
 		utils.TxPoolNoLocalsFlag,
 		utils.TxPoolJournalFlag,
 		utils.TxPoolRejournalFlag,
+		utils.TxPoolSnapshotFlag,
+		utils.TxPoolSnapshotIntervalFlag,
 		utils.TxPoolPriceLimitFlag,
 		utils.TxPoolPriceBumpFlag,
 		utils.TxPoolAccountSlotsFlag,
@@ -79,33 +82,48 @@ var (
 		utils.TxPoolAccountQueueFlag,
 		utils.TxPoolGlobalQueueFlag,
 		utils.TxPoolLifetimeFlag,
+		utils.TxGossipNoIngressFlag,
+		utils.TxGossipNoEgressFlag,
+		utils.TxPoolEncryptedDecrypterFlag,
 		utils.BlobPoolDataDirFlag,
 		utils.BlobPoolDataCapFlag,
 		utils.BlobPoolPriceBumpFlag,
+		utils.BlobPoolRetentionFlag,
 		utils.SyncModeFlag,
 		utils.SyncTargetFlag,
+		utils.SyncCheckpointFlag,
 		utils.ExitWhenSyncedFlag,
+		utils.MaxReorgDepthFlag,
+		utils.SyncBandwidthFlag,
+		utils.SyncPeerBandwidthFlag,
 		utils.GCModeFlag,
 		utils.SnapshotFlag,
 		utils.TxLookupLimitFlag, // deprecated
 		utils.TransactionHistoryFlag,
 		utils.ChainHistoryFlag,
+		utils.HistoryStartFlag,
+		utils.HistoryShardHintsFlag,
 		utils.LogHistoryFlag,
 		utils.LogNoHistoryFlag,
 		utils.LogExportCheckpointsFlag,
+		utils.HaltReasonsFlag,
+		utils.RevertReasonsFlag,
 		utils.StateHistoryFlag,
 		utils.LightKDFFlag,
 		utils.EthRequiredBlocksFlag,
 		utils.LegacyWhitelistFlag, // deprecated
 		utils.CacheFlag,
 		utils.CacheDatabaseFlag,
+		utils.DBMigrationBackupFlag,
 		utils.CacheTrieFlag,
+		utils.CacheTrieHotFlag,
 		utils.CacheTrieJournalFlag,   // deprecated
 		utils.CacheTrieRejournalFlag, // deprecated
 		utils.CacheGCFlag,
 		utils.CacheSnapshotFlag,
 		utils.CacheNoPrefetchFlag,
 		utils.CachePreimagesFlag,
+		utils.CacheSendersFlag,
 		utils.CacheLogSizeFlag,
 		utils.FDLimitFlag,
 		utils.CryptoKZGFlag,
@@ -115,14 +133,35 @@ var (
 		utils.MaxPendingPeersFlag,
 		utils.MiningEnabledFlag, // deprecated
 		utils.MinerGasLimitFlag,
+		utils.MinerGasLimitMinFlag,
+		utils.MinerGasLimitMaxFlag,
+		utils.MinerGasLimitStepFlag,
 		utils.MinerGasPriceFlag,
 		utils.MinerEtherbaseFlag, // deprecated
 		utils.MinerExtraDataFlag,
 		utils.MinerMaxBlobsFlag,
+		utils.MinerTipFloorsFlag,
+		utils.MinerTipPercentileFlag,
+		utils.MinerTipMaxFlag,
+		utils.MinerLocalsGasFlag,
+		utils.MinerLocalsBlobsFlag,
+		utils.MinerBuildWorkersFlag,
+		utils.MinerBuildMemoryFlag,
+		utils.MinerPolicyFlag,
 		utils.MinerRecommitIntervalFlag,
 		utils.MinerPendingFeeRecipientFlag,
+		utils.MinerSoloTimeoutFlag,
+		utils.MinerSoloPeriodFlag,
+		utils.UserOpsEnabledFlag,
+		utils.UserOpsEntryPointFlag,
+		utils.UserOpsKeyFlag,
+		utils.UserOpsBundleGasFlag,
+		utils.InclusionKeyFlag,
+		utils.InclusionWindowFlag,
 		utils.MinerNewPayloadTimeoutFlag, // deprecated
 		utils.NATFlag,
+		utils.PushReplicasFlag,
+		utils.PushSequencersFlag,
 		utils.NoDiscoverFlag,
 		utils.DiscoveryV4Flag,
 		utils.DiscoveryV5Flag,
@@ -169,12 +208,21 @@ var (
 		utils.AuthPortFlag,
 		utils.AuthVirtualHostsFlag,
 		utils.JWTSecretFlag,
+		utils.AuthClientsFlag,
+		utils.RPCPermissionsFlag,
+		utils.RPCAccessLogFlag,
+		utils.RPCAccessLogSamplingFlag,
+		utils.RPCSlowCallFlag,
 		utils.HTTPVirtualHostsFlag,
 		utils.GraphQLEnabledFlag,
 		utils.GraphQLCORSDomainFlag,
 		utils.GraphQLVirtualHostsFlag,
 		utils.HTTPApiFlag,
 		utils.HTTPPathPrefixFlag,
+		utils.HTTPStatusFlag,
+		utils.GRPCEnabledFlag,
+		utils.GRPCListenAddrFlag,
+		utils.GRPCPortFlag,
 		utils.WSEnabledFlag,
 		utils.WSListenAddrFlag,
 		utils.WSPortFlag,
@@ -187,10 +235,14 @@ var (
 		utils.RPCGlobalGasCapFlag,
 		utils.RPCGlobalEVMTimeoutFlag,
 		utils.RPCGlobalTxFeeCapFlag,
+		utils.RPCDebugDangerousOpsFlag,
 		utils.RPCGlobalLogQueryLimit,
 		utils.AllowUnprotectedTxs,
 		utils.BatchRequestLimit,
 		utils.BatchResponseMaxSize,
+		utils.RPCDedupMethodsFlag,
+		utils.RPCStreamMethodsFlag,
+		utils.RPCDrainRejectMethodsFlag,
 		utils.RPCTxSyncDefaultTimeoutFlag,
 		utils.RPCTxSyncMaxTimeoutFlag,
 	}
@@ -210,6 +262,10 @@ var (
 		utils.MetricsInfluxDBTokenFlag,
 		utils.MetricsInfluxDBBucketFlag,
 		utils.MetricsInfluxDBOrganizationFlag,
+		utils.MetricsEnableOTLPFlag,
+		utils.MetricsOTLPEndpointFlag,
+		utils.MetricsOTLPTracingFlag,
+		utils.MetricsOTLPTracingRatioFlag,
 		utils.StateSizeTrackingFlag,
 	}
 )
@@ -232,6 +288,12 @@ func init() {
 		dumpGenesisCommand,
 		pruneHistoryCommand,
 		downloadEraCommand,
+		checkHeaderCommand,
+		// See doctorcmd.go:
+		doctorCommand,
+		// See feevectorscmd.go:
+		genFeeVectorsCommand,
+		verifyFeeVectorsCommand,
 		// See accountcmd.go:
 		accountCommand,
 		walletCommand,
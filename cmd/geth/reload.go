@@ -0,0 +1,274 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of go-ethereum.
+//
+// go-ethereum is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-ethereum is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-ethereum. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"os"
+	"os/signal"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"syscall"
+
+	"github.com/ethereum/go-ethereum/cmd/utils"
+	"github.com/ethereum/go-ethereum/eth"
+	"github.com/ethereum/go-ethereum/eth/ethconfig"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/ethereum/go-ethereum/node"
+	"github.com/urfave/cli/v2"
+)
+
+// reloadFlags maps the reloadable config file settings to the command line
+// flags overriding them. Flags take precedence over the config file, so changes
+// of a setting given on the command line are ignored. The developer mode turns
+// off networking, overriding the p2p settings.
+var reloadFlags = map[string][]string{
+	"Node.HTTPModules":        {utils.HTTPApiFlag.Name},
+	"Node.WSModules":          {utils.WSApiFlag.Name},
+	"Node.AuthClients":        {utils.AuthClientsFlag.Name},
+	"Node.P2P.MaxPeers":       {utils.MaxPeersFlag.Name, utils.DeveloperFlag.Name},
+	"Node.P2P.PeerGroups":     {utils.DeveloperFlag.Name},
+	"Eth.Miner.GasCeil":       {utils.MinerGasLimitFlag.Name},
+	"Eth.Miner.Recommit":      {utils.MinerRecommitIntervalFlag.Name, utils.MinerNewPayloadTimeoutFlag.Name},
+	"Eth.Miner.Policy":        {utils.MinerPolicyFlag.Name},
+	"Eth.TxPool.AccountSlots": {utils.TxPoolAccountSlotsFlag.Name},
+	"Eth.TxPool.GlobalSlots":  {utils.TxPoolGlobalSlotsFlag.Name},
+	"Eth.TxPool.AccountQueue": {utils.TxPoolAccountQueueFlag.Name},
+	"Eth.TxPool.GlobalQueue":  {utils.TxPoolGlobalQueueFlag.Name},
+	"Eth.TrieTimeout":         nil,
+}
+
+// reloadableSettings returns the names of the config file settings which can be
+// applied to a running node.
+func reloadableSettings() []string {
+	var names []string
+	for _, name := range node.ReloadableConfig {
+		names = append(names, "Node."+name)
+	}
+	for _, name := range eth.ReloadableConfig {
+		names = append(names, "Eth."+name)
+	}
+	return names
+}
+
+// configReloader applies changes of the config file to the running node when
+// the process receives SIGHUP. Settings which can't be changed at runtime are
+// reported as requiring a restart.
+type configReloader struct {
+	ctx   *cli.Context
+	stack *node.Node
+	eth   *eth.Ethereum // nil if the node runs without the eth service
+	path  string
+
+	initial gethConfig // Config file contents at startup
+	last    gethConfig // Config file contents at the last reload
+	running gethConfig // Effective configuration of the running node
+
+	sigc chan os.Signal
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+func newConfigReloader(ctx *cli.Context, stack *node.Node, backend *eth.Ethereum, path string, running gethConfig) (*configReloader, error) {
+	initial, err := loadConfigFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return &configReloader{
+		ctx:     ctx,
+		stack:   stack,
+		eth:     backend,
+		path:    path,
+		initial: initial,
+		last:    initial,
+		running: running,
+		sigc:    make(chan os.Signal, 1),
+		quit:    make(chan struct{}),
+	}, nil
+}
+
+// loadConfigFile loads a config file on top of the defaults, without applying
+// any command line flags.
+func loadConfigFile(path string) (gethConfig, error) {
+	cfg := gethConfig{
+		Eth:     ethconfig.Defaults,
+		Node:    defaultNodeConfig(),
+		Metrics: metrics.DefaultConfig,
+	}
+	err := loadConfig(path, &cfg)
+	return cfg, err
+}
+
+// Start implements node.Lifecycle, listening for SIGHUP.
+func (r *configReloader) Start() error {
+	signal.Notify(r.sigc, syscall.SIGHUP)
+	r.wg.Add(1)
+	go r.loop()
+	return nil
+}
+
+// Stop implements node.Lifecycle.
+func (r *configReloader) Stop() error {
+	signal.Stop(r.sigc)
+	close(r.quit)
+	r.wg.Wait()
+	return nil
+}
+
+func (r *configReloader) loop() {
+	defer r.wg.Done()
+	for {
+		select {
+		case <-r.sigc:
+			r.reload()
+		case <-r.quit:
+			return
+		}
+	}
+}
+
+// reload reads the config file again and applies its runtime-changeable
+// settings to the node.
+func (r *configReloader) reload() {
+	log.Info("Reloading config file", "path", r.path)
+	file, err := loadConfigFile(r.path)
+	if err != nil {
+		log.Error("Failed to reload config file", "path", r.path, "err", err)
+		return
+	}
+	changed, restart, ignored := r.update(file, reloadableSettings())
+
+	// Only apply the settings which changed in the file, so that the values
+	// set at runtime through admin_setConfig are kept otherwise.
+	var nodeFields, ethFields, applied []string
+	for _, name := range changed {
+		if field, ok := strings.CutPrefix(name, "Node."); ok {
+			nodeFields = append(nodeFields, field)
+		} else if field, ok := strings.CutPrefix(name, "Eth."); ok {
+			ethFields = append(ethFields, field)
+		}
+	}
+	if len(nodeFields) > 0 {
+		nodeApplied, err := r.stack.Reload(&r.running.Node, nodeFields)
+		if err != nil {
+			log.Error("Failed to apply node settings", "err", err)
+		}
+		for _, name := range nodeApplied {
+			applied = append(applied, "Node."+name)
+		}
+	}
+	if len(ethFields) > 0 && r.eth != nil {
+		ethApplied, err := r.eth.Reload(&r.running.Eth, ethFields)
+		if err != nil {
+			log.Error("Failed to apply eth settings", "err", err)
+		}
+		for _, name := range ethApplied {
+			applied = append(applied, "Eth."+name)
+		}
+	}
+	r.last = file
+
+	log.Info("Reloaded config file", "path", r.path, "applied", strings.Join(applied, ","))
+	if len(ignored) > 0 {
+		log.Warn("Ignored config file changes overridden by flags", "settings", strings.Join(ignored, ","))
+	}
+	if len(restart) > 0 {
+		log.Warn("Config file changes require a restart", "settings", strings.Join(restart, ","))
+	}
+}
+
+// update copies the reloadable settings which changed since the last reload
+// into the running configuration and returns their names. It also returns the
+// settings which changed since startup but can't be applied at runtime, and the
+// changed settings which were ignored because they are overridden.
+func (r *configReloader) update(file gethConfig, reloadable []string) (changed, restart, ignored []string) {
+	var (
+		fileVal    = reflect.ValueOf(file)
+		lastVal    = reflect.ValueOf(r.last)
+		runningVal = reflect.ValueOf(&r.running).Elem()
+	)
+	for _, name := range reloadable {
+		value := fieldByPath(fileVal, name)
+		if reflect.DeepEqual(value.Interface(), fieldByPath(lastVal, name).Interface()) {
+			continue
+		}
+		if r.overridden(name) {
+			ignored = append(ignored, name)
+			continue
+		}
+		fieldByPath(runningVal, name).Set(value)
+		changed = append(changed, name)
+	}
+	for _, name := range changedFields("", reflect.ValueOf(r.initial), fileVal) {
+		if !slices.ContainsFunc(reloadable, func(setting string) bool {
+			return name == setting || strings.HasPrefix(name, setting+".")
+		}) {
+			restart = append(restart, name)
+		}
+	}
+	return changed, restart, ignored
+}
+
+// overridden reports whether changes of the given setting in the config file are
+// overridden, either by a command line flag or, for the transaction policy, by
+// the policy file, which is reloaded with miner_reloadTxPolicy instead.
+func (r *configReloader) overridden(name string) bool {
+	if slices.ContainsFunc(reloadFlags[name], r.ctx.IsSet) {
+		return true
+	}
+	return name == "Eth.Miner.Policy" && r.running.Eth.Miner.PolicyFile != ""
+}
+
+// fieldByPath returns the struct field at the given dot-separated path.
+func fieldByPath(v reflect.Value, path string) reflect.Value {
+	for _, name := range strings.Split(path, ".") {
+		v = v.FieldByName(name)
+	}
+	return v
+}
+
+// changedFields returns the paths of the config fields which differ between
+// the structs a and b. Nested structs are compared field by field, fields which
+// can't be set in the config file are skipped.
+func changedFields(prefix string, a, b reflect.Value) []string {
+	var changed []string
+	for i := 0; i < a.NumField(); i++ {
+		field := a.Type().Field(i)
+		if !field.IsExported() || field.Tag.Get("toml") == "-" {
+			continue
+		}
+		name := prefix + field.Name
+		fa, fb := a.Field(i), b.Field(i)
+		if field.Type.Kind() == reflect.Struct && hasExportedFields(field.Type) {
+			changed = append(changed, changedFields(name+".", fa, fb)...)
+		} else if !reflect.DeepEqual(fa.Interface(), fb.Interface()) {
+			changed = append(changed, name)
+		}
+	}
+	return changed
+}
+
+func hasExportedFields(typ reflect.Type) bool {
+	for i := 0; i < typ.NumField(); i++ {
+		if typ.Field(i).IsExported() {
+			return true
+		}
+	}
+	return false
+}
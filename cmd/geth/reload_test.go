@@ -0,0 +1,92 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of go-ethereum.
+//
+// go-ethereum is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-ethereum is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-ethereum. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/cmd/utils"
+	"github.com/urfave/cli/v2"
+)
+
+func TestConfigReloadUpdate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.toml")
+	write := func(content string) {
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("[Eth.Miner]\nGasCeil = 30000000\n[Eth.TxPool]\nGlobalSlots = 1000\n")
+
+	// The account slots are overridden on the command line.
+	set := flag.NewFlagSet("test", flag.ContinueOnError)
+	utils.TxPoolAccountSlotsFlag.Apply(set)
+	if err := set.Parse([]string{"--" + utils.TxPoolAccountSlotsFlag.Name, "32"}); err != nil {
+		t.Fatal(err)
+	}
+	ctx := cli.NewContext(cli.NewApp(), set, nil)
+
+	running, err := loadConfigFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := newConfigReloader(ctx, nil, nil, path, running)
+	if err != nil {
+		t.Fatal(err)
+	}
+	write("[Eth]\nNetworkId = 5\n[Eth.Miner]\nGasCeil = 45000000\n[Eth.TxPool]\nGlobalSlots = 1000\nAccountSlots = 64\n[Node.P2P]\nMaxPeers = 10\n")
+	file, err := loadConfigFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	changed, restart, ignored := r.update(file, reloadableSettings())
+	if want := []string{"Node.P2P.MaxPeers", "Eth.Miner.GasCeil"}; !reflect.DeepEqual(changed, want) {
+		t.Errorf("changed: have %v, want %v", changed, want)
+	}
+	if want := []string{"Eth.NetworkId"}; !reflect.DeepEqual(restart, want) {
+		t.Errorf("restart: have %v, want %v", restart, want)
+	}
+	if want := []string{"Eth.TxPool.AccountSlots"}; !reflect.DeepEqual(ignored, want) {
+		t.Errorf("ignored: have %v, want %v", ignored, want)
+	}
+	if r.running.Eth.Miner.GasCeil != 45000000 {
+		t.Errorf("gas ceil not updated: %d", r.running.Eth.Miner.GasCeil)
+	}
+	if r.running.Eth.TxPool.AccountSlots != running.Eth.TxPool.AccountSlots {
+		t.Errorf("flag overridden account slots changed: %d", r.running.Eth.TxPool.AccountSlots)
+	}
+	// Reloading the same file again changes nothing, so settings changed at
+	// runtime are not reverted.
+	r.last = file
+	changed, _, ignored = r.update(file, reloadableSettings())
+	if len(changed) != 0 || len(ignored) != 0 {
+		t.Errorf("unchanged file: changed %v, ignored %v", changed, ignored)
+	}
+}
+
+// This test checks that the flags overriding each reloadable setting are known.
+func TestConfigReloadFlags(t *testing.T) {
+	for _, name := range reloadableSettings() {
+		if _, ok := reloadFlags[name]; !ok {
+			t.Errorf("no override flags listed for %s", name)
+		}
+	}
+}
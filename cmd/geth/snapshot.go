@@ -17,25 +17,31 @@
 package main
 
 import (
+	"bufio"
 	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"maps"
 	"os"
 	"slices"
 	"time"
 
 	"github.com/ethereum/go-ethereum/cmd/utils"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/state"
 	"github.com/ethereum/go-ethereum/core/state/pruner"
 	"github.com/ethereum/go-ethereum/core/state/snapshot"
+	"github.com/ethereum/go-ethereum/core/tracing"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/ethereum/go-ethereum/trie"
+	"github.com/ethereum/go-ethereum/triedb"
 	"github.com/urfave/cli/v2"
 )
 
@@ -148,6 +154,47 @@ as the backend data source, making this command a lot faster.
 
 The argument is interpreted as block number or hash. If none is provided, the latest
 block is used.
+`,
+			},
+			{
+				Name:      "export-genesis",
+				Usage:     "Export the state of a block as a genesis specification",
+				ArgsUsage: "<genesisfile> [? <blockHash> | <blockNum>]",
+				Action:    snapshotExportGenesis,
+				Flags:     slices.Concat(utils.NetworkFlags, utils.DatabaseFlags),
+				Description: `
+geth snapshot export-genesis <genesisfile> [? <blockHash> | <blockNum>]
+will write a genesis specification to the given file, holding the chain config
+of the database and all accounts of the given block, with their code and
+storage, in the alloc. The header fields of the block are carried over, so the
+exported genesis can seed a shadow fork or a test network with the state of an
+existing chain, without copying its history.
+
+The argument is interpreted as block number or hash. If none is provided, the
+latest block is used. The state is read from the snapshots, which only hold the
+hashes of addresses and storage slots: their preimages are required, so the
+node must have been run with --cache.preimages.
+`,
+			},
+			{
+				Name:      "convert-bintrie",
+				Usage:     "Convert the state of a block into a binary trie",
+				ArgsUsage: "[? <blockHash> | <blockNum>]",
+				Action:    snapshotConvertBinaryTrie,
+				Flags:     slices.Concat(utils.NetworkFlags, utils.DatabaseFlags),
+				Description: `
+geth snapshot convert-bintrie [? <blockHash> | <blockNum>]
+will convert the state of the given block into the binary trie used by the
+verkle fork, and store it in the database next to the current state, which is
+left untouched. The conversion is experimental and requires --state.verkle. The
+root of the converted state is printed, it can be used to prototype stateless
+execution on a chain activating the fork (see --override.verkle), execution
+witnesses of which are then built from the binary trie.
+
+The argument is interpreted as block number or hash. If none is provided, the
+latest block is used. The state is read from the snapshots, which only hold the
+hashes of addresses and storage slots: their preimages are required, so the
+node must have been run with --cache.preimages.
 `,
 			},
 			{
@@ -623,6 +670,304 @@ func dumpState(ctx *cli.Context) error {
 	return nil
 }
 
+// convertBatchSize is the number of accounts and storage slots converted
+// between two commits of the binary trie, bounding the memory it holds.
+const convertBatchSize = 1_000_000
+
+// snapshotConvertBinaryTrie converts the state of a block into a binary trie,
+// stored next to the current state.
+func snapshotConvertBinaryTrie(ctx *cli.Context) error {
+	if ctx.NArg() > 1 {
+		utils.Fatalf("This command accepts at most one argument.")
+	}
+	if !ctx.Bool(utils.StateVerkleFlag.Name) {
+		utils.Fatalf("The binary trie conversion requires the experimental --%s flag", utils.StateVerkleFlag.Name)
+	}
+	stack, _ := makeConfigNode(ctx)
+	defer stack.Close()
+
+	db := utils.MakeChainDatabase(ctx, stack, false)
+	defer db.Close()
+
+	header, err := readDumpHeader(db, ctx.Args().First())
+	if err != nil {
+		return err
+	}
+	srcdb := utils.MakeTrieDatabase(ctx, stack, db, false, true, false)
+	defer srcdb.Close()
+
+	root, err := convertBinaryTrie(db, srcdb, header)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%#x\n", root)
+	return nil
+}
+
+// convertBinaryTrie converts the state of the given header into a binary trie,
+// committed to the verkle namespace of the database, and returns its root.
+func convertBinaryTrie(db ethdb.Database, srcdb *triedb.Database, header *types.Header) (common.Hash, error) {
+	stateIt, err := utils.NewStateIterator(srcdb, db, header.Root)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	dstdb := triedb.NewDatabase(db, triedb.VerkleDefaults)
+	defer dstdb.Close()
+
+	var (
+		sdb     = state.NewDatabase(dstdb, nil)
+		root    = types.EmptyVerkleHash
+		number  = header.Number.Uint64()
+		statedb *state.StateDB
+	)
+	if statedb, err = state.New(root, sdb); err != nil {
+		return common.Hash{}, err
+	}
+	// commit flushes the converted accounts to disk and resumes from there.
+	commit := func() error {
+		if root, err = statedb.Commit(number, false, false); err != nil {
+			return err
+		}
+		if err := dstdb.Commit(root, false); err != nil {
+			return err
+		}
+		statedb, err = state.New(root, sdb)
+		return err
+	}
+	log.Info("Binary trie conversion started", "block", header.Number, "hash", header.Hash(), "root", header.Root)
+	var (
+		start    = time.Now()
+		logged   = time.Now()
+		accounts uint64
+		pending  int
+	)
+	accIt, err := stateIt.AccountIterator(header.Root, common.Hash{})
+	if err != nil {
+		return common.Hash{}, err
+	}
+	defer accIt.Release()
+
+	for accIt.Next() {
+		preimage := rawdb.ReadPreimage(db, accIt.Hash())
+		if len(preimage) != common.AddressLength {
+			return common.Hash{}, fmt.Errorf("missing preimage of account %x", accIt.Hash())
+		}
+		addr := common.BytesToAddress(preimage)
+
+		account, err := types.FullAccount(accIt.Account())
+		if err != nil {
+			return common.Hash{}, err
+		}
+		statedb.SetNonce(addr, account.Nonce, tracing.NonceChangeUnspecified)
+		statedb.SetBalance(addr, account.Balance, tracing.BalanceChangeUnspecified)
+		if !bytes.Equal(account.CodeHash, types.EmptyCodeHash.Bytes()) {
+			code := rawdb.ReadCode(db, common.BytesToHash(account.CodeHash))
+			if len(code) == 0 {
+				return common.Hash{}, fmt.Errorf("missing code %x", account.CodeHash)
+			}
+			statedb.SetCode(addr, code, tracing.CodeChangeUnspecified)
+		}
+		pending++
+
+		if account.Root != types.EmptyRootHash {
+			stIt, err := stateIt.StorageIterator(header.Root, accIt.Hash(), common.Hash{})
+			if err != nil {
+				return common.Hash{}, err
+			}
+			for stIt.Next() {
+				key := rawdb.ReadPreimage(db, stIt.Hash())
+				if len(key) != common.HashLength {
+					stIt.Release()
+					return common.Hash{}, fmt.Errorf("missing preimage of storage slot %x of account %x", stIt.Hash(), preimage)
+				}
+				_, value, _, err := rlp.Split(stIt.Slot())
+				if err != nil {
+					stIt.Release()
+					return common.Hash{}, err
+				}
+				statedb.SetState(addr, common.BytesToHash(key), common.BytesToHash(value))
+				pending++
+			}
+			err = stIt.Error()
+			stIt.Release()
+			if err != nil {
+				return common.Hash{}, err
+			}
+		}
+		accounts++
+
+		if pending >= convertBatchSize {
+			if err := commit(); err != nil {
+				return common.Hash{}, err
+			}
+			pending = 0
+		}
+		if time.Since(logged) > 8*time.Second {
+			log.Info("Converting state to binary trie", "accounts", accounts, "at", accIt.Hash(), "elapsed", common.PrettyDuration(time.Since(start)))
+			logged = time.Now()
+		}
+	}
+	if err := accIt.Error(); err != nil {
+		return common.Hash{}, err
+	}
+	if err := commit(); err != nil {
+		return common.Hash{}, err
+	}
+	log.Info("Binary trie conversion complete", "accounts", accounts, "root", root, "elapsed", common.PrettyDuration(time.Since(start)))
+	return root, nil
+}
+
+// snapshotExportGenesis writes the state of a block as a genesis specification.
+// The accounts are streamed into the alloc, as the state of a live network is
+// too large to be collected in memory.
+func snapshotExportGenesis(ctx *cli.Context) error {
+	if ctx.NArg() < 1 || ctx.NArg() > 2 {
+		utils.Fatalf("This command requires one or two arguments.")
+	}
+	stack, _ := makeConfigNode(ctx)
+	defer stack.Close()
+
+	db := utils.MakeChainDatabase(ctx, stack, true)
+	defer db.Close()
+
+	header, err := readDumpHeader(db, ctx.Args().Get(1))
+	if err != nil {
+		return err
+	}
+	config, _, err := core.LoadChainConfig(db, nil)
+	if err != nil {
+		return err
+	}
+	triedb := utils.MakeTrieDatabase(ctx, stack, db, false, true, false)
+	defer triedb.Close()
+
+	stateIt, err := utils.NewStateIterator(triedb, db, header.Root)
+	if err != nil {
+		return err
+	}
+	// Encode the genesis without the alloc, which is streamed afterwards.
+	genesis := &core.Genesis{
+		Config:        config,
+		Nonce:         header.Nonce.Uint64(),
+		Timestamp:     header.Time,
+		ExtraData:     header.Extra,
+		GasLimit:      header.GasLimit,
+		Difficulty:    header.Difficulty,
+		Mixhash:       header.MixDigest,
+		Coinbase:      header.Coinbase,
+		BaseFee:       header.BaseFee,
+		ExcessBlobGas: header.ExcessBlobGas,
+		BlobGasUsed:   header.BlobGasUsed,
+	}
+	enc, err := json.Marshal(genesis)
+	if err != nil {
+		return err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(enc, &fields); err != nil {
+		return err
+	}
+	delete(fields, "alloc")
+	keys := slices.Sorted(maps.Keys(fields))
+
+	file, err := os.Create(ctx.Args().First())
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	w := bufio.NewWriter(file)
+
+	w.WriteString("{")
+	for _, key := range keys {
+		fmt.Fprintf(w, "%q:%s,", key, fields[key])
+	}
+	w.WriteString(`"alloc":{`)
+
+	log.Info("Genesis export started", "block", header.Number, "hash", header.Hash(), "root", header.Root)
+	var (
+		start    = time.Now()
+		logged   = time.Now()
+		accounts uint64
+	)
+	accIt, err := stateIt.AccountIterator(header.Root, common.Hash{})
+	if err != nil {
+		return err
+	}
+	defer accIt.Release()
+
+	for accIt.Next() {
+		preimage := rawdb.ReadPreimage(db, accIt.Hash())
+		if len(preimage) != common.AddressLength {
+			return fmt.Errorf("missing preimage of account %x", accIt.Hash())
+		}
+		account, err := types.FullAccount(accIt.Account())
+		if err != nil {
+			return err
+		}
+		alloc := types.Account{
+			Balance: account.Balance.ToBig(),
+			Nonce:   account.Nonce,
+		}
+		if !bytes.Equal(account.CodeHash, types.EmptyCodeHash.Bytes()) {
+			alloc.Code = rawdb.ReadCode(db, common.BytesToHash(account.CodeHash))
+			if len(alloc.Code) == 0 {
+				return fmt.Errorf("missing code %x", account.CodeHash)
+			}
+		}
+		if account.Root != types.EmptyRootHash {
+			alloc.Storage = make(map[common.Hash]common.Hash)
+
+			stIt, err := stateIt.StorageIterator(header.Root, accIt.Hash(), common.Hash{})
+			if err != nil {
+				return err
+			}
+			for stIt.Next() {
+				key := rawdb.ReadPreimage(db, stIt.Hash())
+				if len(key) != common.HashLength {
+					stIt.Release()
+					return fmt.Errorf("missing preimage of storage slot %x of account %x", stIt.Hash(), preimage)
+				}
+				_, value, _, err := rlp.Split(stIt.Slot())
+				if err != nil {
+					stIt.Release()
+					return err
+				}
+				alloc.Storage[common.BytesToHash(key)] = common.BytesToHash(value)
+			}
+			err = stIt.Error()
+			stIt.Release()
+			if err != nil {
+				return err
+			}
+		}
+		enc, err := json.Marshal(alloc)
+		if err != nil {
+			return err
+		}
+		if accounts > 0 {
+			w.WriteString(",")
+		}
+		fmt.Fprintf(w, "%q:%s", common.BytesToAddress(preimage).Hex(), enc)
+		accounts++
+
+		if time.Since(logged) > 8*time.Second {
+			log.Info("Genesis export in progress", "at", accIt.Hash(), "accounts", accounts,
+				"elapsed", common.PrettyDuration(time.Since(start)))
+			logged = time.Now()
+		}
+	}
+	if err := accIt.Error(); err != nil {
+		return err
+	}
+	w.WriteString("}}\n")
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	log.Info("Genesis export complete", "accounts", accounts,
+		"elapsed", common.PrettyDuration(time.Since(start)))
+	return nil
+}
+
 // snapshotExportPreimages dumps the preimage data to a flat file.
 func snapshotExportPreimages(ctx *cli.Context) error {
 	if ctx.NArg() < 1 {
@@ -0,0 +1,90 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of go-ethereum.
+//
+// go-ethereum is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-ethereum is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-ethereum. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/trie/bintrie"
+	"github.com/ethereum/go-ethereum/triedb"
+)
+
+func TestConvertBinaryTrie(t *testing.T) {
+	db, head := newConvertTestDB(t, rawdb.HashScheme, true)
+
+	// The conversion reads the addresses and slots from their preimages.
+	srcdb := triedb.NewDatabase(db, triedb.HashDefaults)
+	defer srcdb.Close()
+	if _, err := convertBinaryTrie(db, srcdb, head); err == nil {
+		t.Fatal("conversion succeeded without preimages")
+	}
+	preimages := make(map[common.Hash][]byte)
+	for _, addr := range []common.Address{convertBank, convertUser, convertContract, {}} {
+		preimages[crypto.Keccak256Hash(addr.Bytes())] = addr.Bytes()
+	}
+	preimages[crypto.Keccak256Hash(convertSlot.Bytes())] = convertSlot.Bytes()
+	rawdb.WritePreimages(db, preimages)
+
+	root, err := convertBinaryTrie(db, srcdb, head)
+	if err != nil {
+		t.Fatalf("failed to convert state: %v", err)
+	}
+	src, err := state.New(head.Root, state.NewDatabase(srcdb, nil))
+	if err != nil {
+		t.Fatalf("source state not kept: %v", err)
+	}
+	dstdb := triedb.NewDatabase(db, triedb.VerkleDefaults)
+	defer dstdb.Close()
+
+	dst, err := state.New(root, state.NewDatabase(dstdb, nil))
+	if err != nil {
+		t.Fatalf("failed to open converted state: %v", err)
+	}
+	for _, addr := range []common.Address{convertBank, convertUser, convertContract} {
+		if have, want := dst.GetBalance(addr), src.GetBalance(addr); !have.Eq(want) {
+			t.Errorf("wrong balance of %x: have %v, want %v", addr, have, want)
+		}
+		if have, want := dst.GetNonce(addr), src.GetNonce(addr); have != want {
+			t.Errorf("wrong nonce of %x: have %d, want %d", addr, have, want)
+		}
+	}
+	if have := dst.GetCode(convertContract); string(have) != string(convertCode) {
+		t.Errorf("wrong code: have %x, want %x", have, convertCode)
+	}
+	if have := dst.GetState(convertContract, convertSlot); have != common.HexToHash("0x2a") {
+		t.Errorf("wrong storage: have %x, want %x", have, common.HexToHash("0x2a"))
+	}
+	if err := dst.Error(); err != nil {
+		t.Fatalf("failed to read converted state: %v", err)
+	}
+	// The nodes accessed in the binary trie are collected into a witness.
+	tr, err := bintrie.NewBinaryTrie(root, dstdb)
+	if err != nil {
+		t.Fatalf("failed to open binary trie: %v", err)
+	}
+	before := len(tr.Witness())
+	if _, err := tr.GetAccount(convertUser); err != nil {
+		t.Fatalf("failed to read account: %v", err)
+	}
+	if after := len(tr.Witness()); after <= before {
+		t.Fatalf("accessed nodes not in the witness: %d before the read, %d after", before, after)
+	}
+}
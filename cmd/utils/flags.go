@@ -40,28 +40,38 @@ import (
 	"github.com/ethereum/go-ethereum/common/fdlimit"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/history"
+	"github.com/ethereum/go-ethereum/core/inclusion"
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/txpool/blobpool"
+	"github.com/ethereum/go-ethereum/core/txpool/encrypted"
 	"github.com/ethereum/go-ethereum/core/txpool/legacypool"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/crypto/kzg4844"
 	"github.com/ethereum/go-ethereum/eth"
+	"github.com/ethereum/go-ethereum/eth/chainstream"
 	"github.com/ethereum/go-ethereum/eth/ethconfig"
 	"github.com/ethereum/go-ethereum/eth/filters"
 	"github.com/ethereum/go-ethereum/eth/gasprice"
+	"github.com/ethereum/go-ethereum/eth/status"
 	"github.com/ethereum/go-ethereum/eth/syncer"
 	"github.com/ethereum/go-ethereum/eth/tracers"
+	"github.com/ethereum/go-ethereum/eth/userops"
+	"github.com/ethereum/go-ethereum/eth/watchpoints"
 	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/ethdb/remotedb"
 	"github.com/ethereum/go-ethereum/ethstats"
 	"github.com/ethereum/go-ethereum/graphql"
 	"github.com/ethereum/go-ethereum/internal/ethapi"
 	"github.com/ethereum/go-ethereum/internal/flags"
+	"github.com/ethereum/go-ethereum/internal/version"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/metrics"
 	"github.com/ethereum/go-ethereum/metrics/exp"
 	"github.com/ethereum/go-ethereum/metrics/influxdb"
+	"github.com/ethereum/go-ethereum/metrics/otlp"
 	"github.com/ethereum/go-ethereum/miner"
 	"github.com/ethereum/go-ethereum/node"
 	"github.com/ethereum/go-ethereum/p2p"
@@ -104,6 +114,11 @@ var (
 		Value:    node.DefaultConfig.DBEngine,
 		Category: flags.EthCategory,
 	}
+	DBMigrationBackupFlag = &flags.DirectoryFlag{
+		Name:     "db.migration.backup",
+		Usage:    "Directory to back up the database keys modified by schema migrations to (default = no backup)",
+		Category: flags.EthCategory,
+	}
 	AncientFlag = &flags.DirectoryFlag{
 		Name:     "datadir.ancient",
 		Usage:    "Root directory for ancient data (default = inside chaindata)",
@@ -189,6 +204,11 @@ var (
 		Usage:    "Exits after block synchronisation completes",
 		Category: flags.EthCategory,
 	}
+	MaxReorgDepthFlag = &cli.Uint64Flag{
+		Name:     "maxreorgdepth",
+		Usage:    "Maximum number of canonical blocks a head update may drop without an admin override (0 = unlimited)",
+		Category: flags.EthCategory,
+	}
 
 	// Dump command options.
 	IterativeOutputFlag = &cli.BoolFlag{
@@ -272,6 +292,16 @@ var (
 		Value:    ethconfig.Defaults.SyncMode.String(),
 		Category: flags.StateCategory,
 	}
+	SyncBandwidthFlag = &cli.Uint64Flag{
+		Name:     "sync.bandwidth",
+		Usage:    "Maximum bandwidth of the sync traffic in KiB/s (0 = unlimited)",
+		Category: flags.NetworkingCategory,
+	}
+	SyncPeerBandwidthFlag = &cli.Uint64Flag{
+		Name:     "sync.peerbandwidth",
+		Usage:    "Maximum bandwidth of the sync traffic from a single peer in KiB/s (0 = unlimited)",
+		Category: flags.NetworkingCategory,
+	}
 	GCModeFlag = &cli.StringFlag{
 		Name:     "gcmode",
 		Usage:    `Blockchain garbage collection mode ("full", "archive")`,
@@ -283,6 +313,11 @@ var (
 		Usage:    "Scheme to use for storing ethereum state ('hash' or 'path')",
 		Category: flags.StateCategory,
 	}
+	StateVerkleFlag = &cli.BoolFlag{
+		Name:     "state.verkle",
+		Usage:    "Enable the experimental verkle state scheme, holding the state in a binary trie (required by --override.verkle)",
+		Category: flags.StateCategory,
+	}
 	StateSizeTrackingFlag = &cli.BoolFlag{
 		Name:     "state.size-tracking",
 		Usage:    "Enable state size tracking, retrieve state size with debug_stateSize.",
@@ -307,6 +342,16 @@ var (
 		Value:    ethconfig.Defaults.HistoryMode.String(),
 		Category: flags.StateCategory,
 	}
+	HistoryStartFlag = &cli.Uint64Flag{
+		Name:     "history.start",
+		Usage:    "First block whose body and receipts are kept, for storing only a slice of the chain history (0 = governed by --history.chain)",
+		Category: flags.StateCategory,
+	}
+	HistoryShardHintsFlag = &cli.StringFlag{
+		Name:     "history.shard.hints",
+		Usage:    "Comma separated RPC endpoints serving history not stored locally, reported to clients requesting it (e.g. 0-15537393=http://archive:8545)",
+		Category: flags.StateCategory,
+	}
 	LogHistoryFlag = &cli.Uint64Flag{
 		Name:     "history.logs",
 		Usage:    "Number of recent blocks to maintain log search index for (default = about one year, 0 = entire chain)",
@@ -324,6 +369,16 @@ var (
 		Category: flags.StateCategory,
 		Value:    "",
 	}
+	HaltReasonsFlag = &cli.BoolFlag{
+		Name:     "history.haltreasons",
+		Usage:    "Record why failed transactions halted at import and include it in receipts, until the block is moved to the ancient store (90000 blocks behind the head)",
+		Category: flags.StateCategory,
+	}
+	RevertReasonsFlag = &cli.BoolFlag{
+		Name:     "history.revertreasons",
+		Usage:    "Record the decoded revert reasons of reverted transactions at import and include them in receipts, until the block is moved to the ancient store (90000 blocks behind the head)",
+		Category: flags.StateCategory,
+	}
 	// Beacon client light sync settings
 	BeaconApiFlag = &cli.StringSliceFlag{
 		Name:     "beacon.api",
@@ -387,6 +442,12 @@ var (
 		Usage:    "Comma separated accounts to treat as locals (no flush, priority inclusion)",
 		Category: flags.TxPoolCategory,
 	}
+	TxPoolLocalAccountsFlag = &cli.StringFlag{
+		Name:     "txpool.localaccounts",
+		Usage:    "Disk file persisting the local accounts added at runtime",
+		Value:    ethconfig.Defaults.TxPool.LocalAccounts,
+		Category: flags.TxPoolCategory,
+	}
 	TxPoolNoLocalsFlag = &cli.BoolFlag{
 		Name:     "txpool.nolocals",
 		Usage:    "Disables price exemptions for locally submitted transactions",
@@ -404,6 +465,17 @@ var (
 		Value:    ethconfig.Defaults.TxPool.Rejournal,
 		Category: flags.TxPoolCategory,
 	}
+	TxPoolSnapshotFlag = &cli.StringFlag{
+		Name:     "txpool.snapshot",
+		Usage:    "Disk snapshot of the pending and queued transactions, loaded on startup to warm up the pool (empty = disabled)",
+		Category: flags.TxPoolCategory,
+	}
+	TxPoolSnapshotIntervalFlag = &cli.DurationFlag{
+		Name:     "txpool.snapshot.interval",
+		Usage:    "Time interval to regenerate the transaction pool snapshot",
+		Value:    ethconfig.Defaults.TxPool.SnapshotInterval,
+		Category: flags.TxPoolCategory,
+	}
 	TxPoolPriceLimitFlag = &cli.Uint64Flag{
 		Name:     "txpool.pricelimit",
 		Usage:    "Minimum gas price tip to enforce for acceptance into the pool",
@@ -446,6 +518,21 @@ var (
 		Value:    ethconfig.Defaults.TxPool.Lifetime,
 		Category: flags.TxPoolCategory,
 	}
+	TxGossipNoIngressFlag = &cli.BoolFlag{
+		Name:     "txgossip.noingress",
+		Usage:    "Ignores transactions announced or broadcast by peers",
+		Category: flags.TxPoolCategory,
+	}
+	TxGossipNoEgressFlag = &cli.BoolFlag{
+		Name:     "txgossip.noegress",
+		Usage:    "Disables announcing and broadcasting transactions to peers",
+		Category: flags.TxPoolCategory,
+	}
+	TxPoolEncryptedDecrypterFlag = &cli.StringFlag{
+		Name:     "txpool.encrypted.decrypter",
+		Usage:    "JSON-RPC endpoint of the decryption key service, enables the encrypted transaction pool",
+		Category: flags.TxPoolCategory,
+	}
 	// Blob transaction pool settings
 	BlobPoolDataDirFlag = &cli.StringFlag{
 		Name:     "blobpool.datadir",
@@ -465,6 +552,12 @@ var (
 		Value:    ethconfig.Defaults.BlobPool.PriceBump,
 		Category: flags.BlobPoolCategory,
 	}
+	BlobPoolRetentionFlag = &cli.Uint64Flag{
+		Name:     "blobpool.retention",
+		Usage:    "Number of recent blocks to retain the blobs of included transactions for serving, beyond finality",
+		Value:    ethconfig.Defaults.BlobPool.Retention,
+		Category: flags.BlobPoolCategory,
+	}
 	// Performance tuning settings
 	CacheFlag = &cli.IntFlag{
 		Name:     "cache",
@@ -484,6 +577,12 @@ var (
 		Value:    15,
 		Category: flags.PerfCategory,
 	}
+	CacheTrieHotFlag = &cli.IntFlag{
+		Name:     "cache.trie.hot",
+		Usage:    "Megabytes of memory allocated to caching hashed shallow trie nodes (path scheme only, 0 = disabled)",
+		Value:    ethconfig.Defaults.TrieHotCache,
+		Category: flags.PerfCategory,
+	}
 	CacheGCFlag = &cli.IntFlag{
 		Name:     "cache.gc",
 		Usage:    "Percentage of cache memory allowance to use for trie pruning (default = 25% full mode, 0% archive mode)",
@@ -506,6 +605,11 @@ var (
 		Usage:    "Enable recording the SHA3/keccak preimages of trie keys",
 		Category: flags.PerfCategory,
 	}
+	CacheSendersFlag = &cli.BoolFlag{
+		Name:     "cache.senders",
+		Usage:    "Persist recovered transaction senders to skip signature recovery when blocks are reprocessed",
+		Category: flags.PerfCategory,
+	}
 	CacheLogSizeFlag = &cli.IntFlag{
 		Name:     "cache.blocklogs",
 		Usage:    "Size (in number of blocks) of the log cache for filtering",
@@ -531,6 +635,21 @@ var (
 		Value:    ethconfig.Defaults.Miner.GasCeil,
 		Category: flags.MinerCategory,
 	}
+	MinerGasLimitMinFlag = &cli.Uint64Flag{
+		Name:     "miner.gaslimit.min",
+		Usage:    "Lowest gas limit target accepted from the command line or miner_setGasLimit (0 = unbounded)",
+		Category: flags.MinerCategory,
+	}
+	MinerGasLimitMaxFlag = &cli.Uint64Flag{
+		Name:     "miner.gaslimit.max",
+		Usage:    "Highest gas limit target accepted from the command line or miner_setGasLimit (0 = unbounded)",
+		Category: flags.MinerCategory,
+	}
+	MinerGasLimitStepFlag = &cli.Uint64Flag{
+		Name:     "miner.gaslimit.step",
+		Usage:    "Maximum gas limit change between consecutive mined blocks (0 = protocol bound only)",
+		Category: flags.MinerCategory,
+	}
 	MinerGasPriceFlag = &flags.BigFlag{
 		Name:     "miner.gasprice",
 		Usage:    "Minimum gas price for mining a transaction",
@@ -558,6 +677,92 @@ var (
 		Usage:    "Maximum number of blobs per block (falls back to protocol maximum if unspecified)",
 		Category: flags.MinerCategory,
 	}
+	MinerLocalsGasFlag = &cli.Uint64Flag{
+		Name:     "miner.locals.gas",
+		Usage:    "Gas budget per block of the priority lane of local transactions (0 = unbounded)",
+		Category: flags.MinerCategory,
+	}
+	MinerLocalsBlobsFlag = &cli.IntFlag{
+		Name:     "miner.locals.blobs",
+		Usage:    "Blob budget per block of the priority lane of local transactions (0 = unbounded)",
+		Category: flags.MinerCategory,
+	}
+	MinerBuildWorkersFlag = &cli.IntFlag{
+		Name:     "miner.build.workers",
+		Usage:    "Maximum number of blocks being filled with transactions concurrently (0 = unbounded)",
+		Category: flags.MinerCategory,
+	}
+	MinerBuildMemoryFlag = &cli.Uint64Flag{
+		Name:     "miner.build.memory",
+		Usage:    "Maximum megabytes allocated while filling a block, sealing it early if exceeded (0 = unbounded)",
+		Category: flags.MinerCategory,
+	}
+	MinerPolicyFlag = &cli.StringFlag{
+		Name:     "miner.policy",
+		Usage:    "JSON file of addresses and 4-byte selectors to deny or allow in built blocks (reloadable via miner_reloadTxPolicy)",
+		Category: flags.MinerCategory,
+	}
+	MinerTipFloorsFlag = &cli.StringSliceFlag{
+		Name:     "miner.tipfloor",
+		Usage:    "Minimum tip for a class of transactions (<type>,<type>...:<min size>:<min tip in wei>, empty type list matches all)",
+		Category: flags.MinerCategory,
+	}
+	MinerTipPercentileFlag = &cli.IntFlag{
+		Name:     "miner.tip.percentile",
+		Usage:    "Percentile of the pending tips required from remote transactions while the pool holds over two blocks worth of gas (0 = disabled)",
+		Category: flags.MinerCategory,
+	}
+	MinerTipMaxFlag = &flags.BigFlag{
+		Name:     "miner.tip.max",
+		Usage:    "Upper bound of the dynamic minimum tip in wei (0 = unbounded)",
+		Category: flags.MinerCategory,
+	}
+	MinerSoloTimeoutFlag = &cli.DurationFlag{
+		Name:     "miner.solo.timeout",
+		Usage:    "Produce blocks locally if the consensus client sends no updates for this long, custom networks only (0 = disabled)",
+		Category: flags.MinerCategory,
+	}
+	MinerSoloPeriodFlag = &cli.Uint64Flag{
+		Name:     "miner.solo.period",
+		Usage:    "Block period in seconds while producing blocks locally",
+		Value:    12,
+		Category: flags.MinerCategory,
+	}
+	UserOpsEnabledFlag = &cli.BoolFlag{
+		Name:     "userops",
+		Usage:    "Enable the ERC-4337 user operation mempool, bundling the operations into the built blocks",
+		Category: flags.MinerCategory,
+	}
+	UserOpsEntryPointFlag = &cli.StringFlag{
+		Name:     "userops.entrypoint",
+		Usage:    "EntryPoint contract the user operations are submitted to",
+		Value:    userops.DefaultEntryPoint.Hex(),
+		Category: flags.MinerCategory,
+	}
+	UserOpsKeyFlag = &cli.PathFlag{
+		Name:      "userops.key",
+		Usage:     "Private key file of the bundler account, which pays for the bundle transactions",
+		TakesFile: true,
+		Category:  flags.MinerCategory,
+	}
+	UserOpsBundleGasFlag = &cli.Uint64Flag{
+		Name:     "userops.bundlegas",
+		Usage:    "Maximum total gas limit of the user operations in a bundle",
+		Value:    userops.DefaultMaxBundleGas,
+		Category: flags.MinerCategory,
+	}
+	InclusionKeyFlag = &cli.PathFlag{
+		Name:      "inclusion.key",
+		Usage:     "Private key file signing transaction inclusion commitments (enables eth_sendRawTransactionWithCommitment)",
+		TakesFile: true,
+		Category:  flags.MinerCategory,
+	}
+	InclusionWindowFlag = &cli.Uint64Flag{
+		Name:     "inclusion.window",
+		Usage:    "Number of blocks after the head by which committed transactions are to be included",
+		Value:    inclusion.DefaultWindow,
+		Category: flags.MinerCategory,
+	}
 
 	// Account settings
 	PasswordFileFlag = &cli.PathFlag{
@@ -618,6 +823,11 @@ var (
 		Value:    ethconfig.Defaults.RPCTxFeeCap,
 		Category: flags.APICategory,
 	}
+	RPCDebugDangerousOpsFlag = &cli.BoolFlag{
+		Name:     "rpc.debug-dangerous-ops",
+		Usage:    "Enables debug RPC methods which irreversibly delete data from the database (e.g. debug_purgeContractStorage)",
+		Category: flags.APICategory,
+	}
 	RPCGlobalLogQueryLimit = &cli.IntFlag{
 		Name:     "rpc.logquerylimit",
 		Usage:    "Maximum number of alternative addresses or topics allowed per search position in eth_getLogs filter criteria (0 = no cap)",
@@ -660,6 +870,32 @@ var (
 		Usage:    "Path to a JWT secret to use for authenticated RPC endpoints",
 		Category: flags.APICategory,
 	}
+	AuthClientsFlag = &cli.StringSliceFlag{
+		Name:     "authrpc.clients",
+		Usage:    "Additional consumers of the authenticated RPC endpoints (name:secretfile:method|method...:callspersecond, empty method list allows all)",
+		Category: flags.APICategory,
+	}
+	RPCPermissionsFlag = &cli.StringFlag{
+		Name:     "rpc.permissions",
+		Usage:    "JSON file restricting the RPC methods allowed per transport, API key and auth client (reloaded on change)",
+		Category: flags.APICategory,
+	}
+	RPCAccessLogFlag = &cli.StringFlag{
+		Name:     "rpc.accesslog",
+		Usage:    "File or socket (unix://, tcp://, udp://) to write a JSON entry for served RPC calls to",
+		Category: flags.APICategory,
+	}
+	RPCAccessLogSamplingFlag = &cli.Float64Flag{
+		Name:     "rpc.accesslog.sampling",
+		Usage:    "Fraction of the RPC calls written to the access log",
+		Value:    node.DefaultConfig.RPCAccessLogSampling,
+		Category: flags.APICategory,
+	}
+	RPCSlowCallFlag = &cli.DurationFlag{
+		Name:     "rpc.slowcall",
+		Usage:    "Duration beyond which RPC calls are always written to the access log and reported as slow (0 = disabled)",
+		Category: flags.APICategory,
+	}
 
 	// Logging and debug settings
 	EthStatsURLFlag = &cli.StringFlag{
@@ -686,6 +922,11 @@ var (
 		TakesFile: true,
 		Category:  flags.MiscCategory,
 	}
+	SyncCheckpointFlag = &cli.StringFlag{
+		Name:     "synccheckpoint",
+		Usage:    "Hash of a trusted block to sync to before the consensus client takes over",
+		Category: flags.EthCategory,
+	}
 
 	// RPC settings
 	IPCDisabledFlag = &cli.BoolFlag{
@@ -739,6 +980,28 @@ var (
 		Value:    "",
 		Category: flags.APICategory,
 	}
+	HTTPStatusFlag = &cli.BoolFlag{
+		Name:     "http.status",
+		Usage:    "Enable the node status page on /status of the HTTP-RPC server",
+		Category: flags.APICategory,
+	}
+	GRPCEnabledFlag = &cli.BoolFlag{
+		Name:     "grpc",
+		Usage:    "Enable the gRPC service streaming blocks, receipts and state diffs",
+		Category: flags.APICategory,
+	}
+	GRPCListenAddrFlag = &cli.StringFlag{
+		Name:     "grpc.addr",
+		Usage:    "gRPC streaming service listening interface",
+		Value:    node.DefaultHTTPHost,
+		Category: flags.APICategory,
+	}
+	GRPCPortFlag = &cli.IntFlag{
+		Name:     "grpc.port",
+		Usage:    "gRPC streaming service listening port",
+		Value:    chainstream.DefaultPort,
+		Category: flags.APICategory,
+	}
 	GraphQLEnabledFlag = &cli.BoolFlag{
 		Name:     "graphql",
 		Usage:    "Enable GraphQL on the HTTP-RPC server. Note that GraphQL can only be started if an HTTP server is started as well.",
@@ -818,6 +1081,23 @@ var (
 		Value:    node.DefaultConfig.BatchResponseMaxSize,
 		Category: flags.APICategory,
 	}
+	RPCDedupMethodsFlag = &cli.StringFlag{
+		Name:     "rpc.dedup-methods",
+		Usage:    "Comma separated list of expensive RPC methods (e.g. debug_traceBlockByNumber,eth_getLogs) whose identical concurrent calls share one execution",
+		Category: flags.APICategory,
+	}
+	RPCStreamMethodsFlag = &cli.StringFlag{
+		Name:     "rpc.stream-methods",
+		Usage:    "Comma separated list of RPC methods whose list results are streamed to the client instead of buffered (empty disables streaming)",
+		Value:    strings.Join(node.DefaultStreamedMethods, ","),
+		Category: flags.APICategory,
+	}
+	RPCDrainRejectMethodsFlag = &cli.StringFlag{
+		Name:     "rpc.drain-reject-methods",
+		Usage:    "Comma separated list of long-running RPC methods rejected while the endpoints are draining, in addition to subscriptions",
+		Value:    strings.Join(node.DefaultDrainRejectedMethods, ","),
+		Category: flags.APICategory,
+	}
 
 	// Network Settings
 	MaxPeersFlag = &cli.IntFlag{
@@ -854,9 +1134,19 @@ var (
 		Usage:    "P2P node key as hex (for testing)",
 		Category: flags.NetworkingCategory,
 	}
+	PushReplicasFlag = &cli.StringFlag{
+		Name:     "push.replicas",
+		Usage:    "Comma separated enode URLs of the replicas to push the new head blocks to",
+		Category: flags.NetworkingCategory,
+	}
+	PushSequencersFlag = &cli.StringFlag{
+		Name:     "push.sequencers",
+		Usage:    "Comma separated enode URLs of the block producers whose pushed blocks are imported",
+		Category: flags.NetworkingCategory,
+	}
 	NATFlag = &cli.StringFlag{
 		Name:     "nat",
-		Usage:    "NAT port mapping mechanism (any|none|upnp|pmp|pmp:<IP>|extip:<IP>|stun:<IP:PORT>)",
+		Usage:    "NAT port mapping mechanism (any|none|upnp|pmp|pmp:<IP>|extip:<IP>|stun|stun:<IP:PORT>)",
 		Value:    "any",
 		Category: flags.NetworkingCategory,
 	}
@@ -1024,6 +1314,29 @@ Please note that --` + MetricsHTTPFlag.Name + ` must be set to start the server.
 		Value:    metrics.DefaultConfig.InfluxDBOrganization,
 		Category: flags.MetricsCategory,
 	}
+
+	MetricsEnableOTLPFlag = &cli.BoolFlag{
+		Name:     "metrics.otlp",
+		Usage:    "Enable metrics export/push to an OpenTelemetry collector",
+		Category: flags.MetricsCategory,
+	}
+	MetricsOTLPEndpointFlag = &cli.StringFlag{
+		Name:     "metrics.otlp.endpoint",
+		Usage:    "OpenTelemetry collector OTLP/HTTP endpoint to push metrics and traces to",
+		Value:    metrics.DefaultConfig.OTLPEndpoint,
+		Category: flags.MetricsCategory,
+	}
+	MetricsOTLPTracingFlag = &cli.BoolFlag{
+		Name:     "metrics.otlp.tracing",
+		Usage:    "Enable tracing of RPC calls, block import and payload building via OpenTelemetry",
+		Category: flags.MetricsCategory,
+	}
+	MetricsOTLPTracingRatioFlag = &cli.Float64Flag{
+		Name:     "metrics.otlp.tracing.ratio",
+		Usage:    "Fraction of traces started by geth itself to sample (traces propagated by callers follow their decision)",
+		Value:    metrics.DefaultConfig.OTLPTracingRatio,
+		Category: flags.MetricsCategory,
+	}
 )
 
 var (
@@ -1044,6 +1357,7 @@ var (
 		RemoteDBFlag,
 		DBEngineFlag,
 		StateSchemeFlag,
+		StateVerkleFlag,
 		HttpHeaderFlag,
 	}
 )
@@ -1196,6 +1510,19 @@ func setNAT(ctx *cli.Context, cfg *p2p.Config) {
 	}
 }
 
+// mustParseEnodes parses a comma separated list of enode URLs given to a flag.
+func mustParseEnodes(flag string, urls string) []*enode.Node {
+	var nodes []*enode.Node
+	for _, url := range SplitAndTrim(urls) {
+		node, err := enode.Parse(enode.ValidSchemes, url)
+		if err != nil {
+			Fatalf("--%s: invalid enode %q: %v", flag, url, err)
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes
+}
+
 // SplitAndTrim splits input separated by a comma
 // and trims excessive white space from the substrings.
 func SplitAndTrim(input string) (ret []string) {
@@ -1262,6 +1589,16 @@ func setHTTP(ctx *cli.Context, cfg *node.Config) {
 	if ctx.IsSet(BatchResponseMaxSize.Name) {
 		cfg.BatchResponseMaxSize = ctx.Int(BatchResponseMaxSize.Name)
 	}
+
+	if ctx.IsSet(RPCDedupMethodsFlag.Name) {
+		cfg.DedupMethods = SplitAndTrim(ctx.String(RPCDedupMethodsFlag.Name))
+	}
+	if ctx.IsSet(RPCStreamMethodsFlag.Name) {
+		cfg.StreamedMethods = SplitAndTrim(ctx.String(RPCStreamMethodsFlag.Name))
+	}
+	if ctx.IsSet(RPCDrainRejectMethodsFlag.Name) {
+		cfg.DrainRejectedMethods = SplitAndTrim(ctx.String(RPCDrainRejectMethodsFlag.Name))
+	}
 }
 
 // setGraphQL creates the GraphQL listener interface string from the set
@@ -1418,6 +1755,28 @@ func SetNodeConfig(ctx *cli.Context, cfg *node.Config) {
 	if ctx.IsSet(JWTSecretFlag.Name) {
 		cfg.JWTSecret = ctx.String(JWTSecretFlag.Name)
 	}
+	if ctx.IsSet(AuthClientsFlag.Name) {
+		cfg.AuthClients = nil
+		for _, spec := range ctx.StringSlice(AuthClientsFlag.Name) {
+			client, err := node.ParseAuthClient(spec)
+			if err != nil {
+				Fatalf("Option %q: %v", AuthClientsFlag.Name, err)
+			}
+			cfg.AuthClients = append(cfg.AuthClients, client)
+		}
+	}
+	if ctx.IsSet(RPCPermissionsFlag.Name) {
+		cfg.RPCPermissions = ctx.String(RPCPermissionsFlag.Name)
+	}
+	if ctx.IsSet(RPCAccessLogFlag.Name) {
+		cfg.RPCAccessLog = ctx.String(RPCAccessLogFlag.Name)
+	}
+	if ctx.IsSet(RPCAccessLogSamplingFlag.Name) {
+		cfg.RPCAccessLogSampling = ctx.Float64(RPCAccessLogSamplingFlag.Name)
+	}
+	if ctx.IsSet(RPCSlowCallFlag.Name) {
+		cfg.RPCSlowCallThreshold = ctx.Duration(RPCSlowCallFlag.Name)
+	}
 	if ctx.IsSet(EnablePersonal.Name) {
 		log.Warn(fmt.Sprintf("Option --%s is deprecated. The 'personal' RPC namespace has been removed.", EnablePersonal.Name))
 	}
@@ -1522,6 +1881,9 @@ func setTxPool(ctx *cli.Context, cfg *legacypool.Config) {
 			}
 		}
 	}
+	if ctx.IsSet(TxPoolLocalAccountsFlag.Name) {
+		cfg.LocalAccounts = ctx.String(TxPoolLocalAccountsFlag.Name)
+	}
 	if ctx.IsSet(TxPoolNoLocalsFlag.Name) {
 		cfg.NoLocals = ctx.Bool(TxPoolNoLocalsFlag.Name)
 	}
@@ -1531,6 +1893,12 @@ func setTxPool(ctx *cli.Context, cfg *legacypool.Config) {
 	if ctx.IsSet(TxPoolRejournalFlag.Name) {
 		cfg.Rejournal = ctx.Duration(TxPoolRejournalFlag.Name)
 	}
+	if ctx.IsSet(TxPoolSnapshotFlag.Name) {
+		cfg.Snapshot = ctx.String(TxPoolSnapshotFlag.Name)
+	}
+	if ctx.IsSet(TxPoolSnapshotIntervalFlag.Name) {
+		cfg.SnapshotInterval = ctx.Duration(TxPoolSnapshotIntervalFlag.Name)
+	}
 	if ctx.IsSet(TxPoolPriceLimitFlag.Name) {
 		cfg.PriceLimit = ctx.Uint64(TxPoolPriceLimitFlag.Name)
 	}
@@ -1564,6 +1932,9 @@ func setBlobPool(ctx *cli.Context, cfg *blobpool.Config) {
 	if ctx.IsSet(BlobPoolPriceBumpFlag.Name) {
 		cfg.PriceBump = ctx.Uint64(BlobPoolPriceBumpFlag.Name)
 	}
+	if ctx.IsSet(BlobPoolRetentionFlag.Name) {
+		cfg.Retention = ctx.Uint64(BlobPoolRetentionFlag.Name)
+	}
 }
 
 func setMiner(ctx *cli.Context, cfg *miner.Config) {
@@ -1576,6 +1947,18 @@ func setMiner(ctx *cli.Context, cfg *miner.Config) {
 	if ctx.IsSet(MinerGasLimitFlag.Name) {
 		cfg.GasCeil = ctx.Uint64(MinerGasLimitFlag.Name)
 	}
+	if ctx.IsSet(MinerGasLimitMinFlag.Name) {
+		cfg.GasLimitMin = ctx.Uint64(MinerGasLimitMinFlag.Name)
+	}
+	if ctx.IsSet(MinerGasLimitMaxFlag.Name) {
+		cfg.GasLimitMax = ctx.Uint64(MinerGasLimitMaxFlag.Name)
+	}
+	if ctx.IsSet(MinerGasLimitStepFlag.Name) {
+		cfg.GasLimitStep = ctx.Uint64(MinerGasLimitStepFlag.Name)
+	}
+	if cfg.GasLimitMax != 0 && cfg.GasLimitMin > cfg.GasLimitMax {
+		Fatalf("--%s (%d) is above --%s (%d)", MinerGasLimitMinFlag.Name, cfg.GasLimitMin, MinerGasLimitMaxFlag.Name, cfg.GasLimitMax)
+	}
 	if ctx.IsSet(MinerGasPriceFlag.Name) {
 		cfg.GasPrice = flags.GlobalBig(ctx, MinerGasPriceFlag.Name)
 	}
@@ -1589,6 +1972,40 @@ func setMiner(ctx *cli.Context, cfg *miner.Config) {
 	if ctx.IsSet(MinerMaxBlobsFlag.Name) {
 		cfg.MaxBlobsPerBlock = ctx.Int(MinerMaxBlobsFlag.Name)
 	}
+	if ctx.IsSet(MinerLocalsGasFlag.Name) {
+		cfg.LocalsGas = ctx.Uint64(MinerLocalsGasFlag.Name)
+	}
+	if ctx.IsSet(MinerLocalsBlobsFlag.Name) {
+		cfg.LocalsBlobs = ctx.Int(MinerLocalsBlobsFlag.Name)
+	}
+	if ctx.IsSet(MinerBuildWorkersFlag.Name) {
+		cfg.BuildWorkers = ctx.Int(MinerBuildWorkersFlag.Name)
+	}
+	if ctx.IsSet(MinerBuildMemoryFlag.Name) {
+		cfg.BuildMemory = ctx.Uint64(MinerBuildMemoryFlag.Name) * 1024 * 1024
+	}
+	if ctx.IsSet(MinerPolicyFlag.Name) {
+		cfg.PolicyFile = ctx.String(MinerPolicyFlag.Name)
+	}
+	if ctx.IsSet(MinerTipFloorsFlag.Name) {
+		cfg.TipFloors = nil
+		for _, spec := range ctx.StringSlice(MinerTipFloorsFlag.Name) {
+			floor, err := miner.ParseTipFloor(spec)
+			if err != nil {
+				Fatalf("Invalid %s: %v", MinerTipFloorsFlag.Name, err)
+			}
+			cfg.TipFloors = append(cfg.TipFloors, floor)
+		}
+	}
+	if ctx.IsSet(MinerTipPercentileFlag.Name) {
+		cfg.TipPercentile = ctx.Int(MinerTipPercentileFlag.Name)
+		if cfg.TipPercentile < 0 || cfg.TipPercentile > 100 {
+			Fatalf("Invalid %s: %d, must be between 0 and 100", MinerTipPercentileFlag.Name, cfg.TipPercentile)
+		}
+	}
+	if ctx.IsSet(MinerTipMaxFlag.Name) {
+		cfg.TipMax = flags.GlobalBig(ctx, MinerTipMaxFlag.Name)
+	}
 }
 
 func setRequiredBlocks(ctx *cli.Context, cfg *ethconfig.Config) {
@@ -1624,6 +2041,8 @@ func SetEthConfig(ctx *cli.Context, stack *node.Node, cfg *ethconfig.Config) {
 	// Avoid conflicting network flags
 	flags.CheckExclusive(ctx, MainnetFlag, DeveloperFlag, SepoliaFlag, HoleskyFlag, HoodiFlag, OverrideGenesisFlag)
 	flags.CheckExclusive(ctx, DeveloperFlag, ExternalSignerFlag) // Can't use both ephemeral unlocked and external signer
+	flags.CheckExclusive(ctx, MainnetFlag, SepoliaFlag, HoleskyFlag, HoodiFlag, MinerSoloTimeoutFlag)
+	flags.CheckExclusive(ctx, SyncTargetFlag, SyncCheckpointFlag)
 
 	// Set configurations from CLI flags
 	setEtherbase(ctx, cfg)
@@ -1662,12 +2081,47 @@ func SetEthConfig(ctx *cli.Context, stack *node.Node, cfg *ethconfig.Config) {
 		}
 	}
 
+	if ctx.IsSet(SyncBandwidthFlag.Name) {
+		cfg.SyncBandwidth = ctx.Uint64(SyncBandwidthFlag.Name) * 1024
+	}
+	if ctx.IsSet(SyncPeerBandwidthFlag.Name) {
+		cfg.SyncPeerBandwidth = ctx.Uint64(SyncPeerBandwidthFlag.Name) * 1024
+	}
+
 	if ctx.IsSet(ChainHistoryFlag.Name) {
 		value := ctx.String(ChainHistoryFlag.Name)
 		if err = cfg.HistoryMode.UnmarshalText([]byte(value)); err != nil {
 			Fatalf("--%s: %v", ChainHistoryFlag.Name, err)
 		}
 	}
+	if ctx.IsSet(TxGossipNoIngressFlag.Name) {
+		cfg.TxGossipNoIngress = ctx.Bool(TxGossipNoIngressFlag.Name)
+	}
+	if ctx.IsSet(TxGossipNoEgressFlag.Name) {
+		cfg.TxGossipNoEgress = ctx.Bool(TxGossipNoEgressFlag.Name)
+	}
+	if ctx.IsSet(PushReplicasFlag.Name) {
+		cfg.PushReplicas = mustParseEnodes(PushReplicasFlag.Name, ctx.String(PushReplicasFlag.Name))
+	}
+	if ctx.IsSet(PushSequencersFlag.Name) {
+		cfg.PushSequencers = mustParseEnodes(PushSequencersFlag.Name, ctx.String(PushSequencersFlag.Name))
+	}
+	if ctx.IsSet(HistoryStartFlag.Name) {
+		cfg.HistoryStart = ctx.Uint64(HistoryStartFlag.Name)
+	}
+	if ctx.IsSet(MaxReorgDepthFlag.Name) {
+		cfg.MaxReorgDepth = ctx.Uint64(MaxReorgDepthFlag.Name)
+	}
+	if ctx.IsSet(HistoryShardHintsFlag.Name) {
+		cfg.HistoryShardHints = nil
+		for _, spec := range SplitAndTrim(ctx.String(HistoryShardHintsFlag.Name)) {
+			var hint history.ShardHint
+			if err := hint.UnmarshalText([]byte(spec)); err != nil {
+				Fatalf("--%s: %v", HistoryShardHintsFlag.Name, err)
+			}
+			cfg.HistoryShardHints = append(cfg.HistoryShardHints, hint)
+		}
+	}
 
 	if ctx.IsSet(CacheFlag.Name) || ctx.IsSet(CacheDatabaseFlag.Name) {
 		cfg.DatabaseCache = ctx.Int(CacheFlag.Name) * ctx.Int(CacheDatabaseFlag.Name) / 100
@@ -1679,6 +2133,9 @@ func SetEthConfig(ctx *cli.Context, stack *node.Node, cfg *ethconfig.Config) {
 	if ctx.IsSet(EraFlag.Name) {
 		cfg.DatabaseEra = ctx.String(EraFlag.Name)
 	}
+	if ctx.IsSet(DBMigrationBackupFlag.Name) {
+		cfg.DatabaseMigrationBackup = ctx.String(DBMigrationBackupFlag.Name)
+	}
 
 	if gcmode := ctx.String(GCModeFlag.Name); gcmode != "full" && gcmode != "archive" {
 		Fatalf("--%s must be either 'full' or 'archive'", GCModeFlag.Name)
@@ -1692,6 +2149,9 @@ func SetEthConfig(ctx *cli.Context, stack *node.Node, cfg *ethconfig.Config) {
 	if ctx.IsSet(CachePreimagesFlag.Name) {
 		cfg.Preimages = ctx.Bool(CachePreimagesFlag.Name)
 	}
+	if ctx.IsSet(CacheSendersFlag.Name) {
+		cfg.SenderCache = ctx.Bool(CacheSendersFlag.Name)
+	}
 	if cfg.NoPruning && !cfg.Preimages {
 		cfg.Preimages = true
 		log.Info("Enabling recording of key preimages since archive mode is used")
@@ -1732,9 +2192,18 @@ func SetEthConfig(ctx *cli.Context, stack *node.Node, cfg *ethconfig.Config) {
 	if ctx.IsSet(LogExportCheckpointsFlag.Name) {
 		cfg.LogExportCheckpoints = ctx.String(LogExportCheckpointsFlag.Name)
 	}
+	if ctx.IsSet(HaltReasonsFlag.Name) {
+		cfg.HaltReasons = ctx.Bool(HaltReasonsFlag.Name)
+	}
+	if ctx.IsSet(RevertReasonsFlag.Name) {
+		cfg.RevertReasons = ctx.Bool(RevertReasonsFlag.Name)
+	}
 	if ctx.IsSet(CacheFlag.Name) || ctx.IsSet(CacheTrieFlag.Name) {
 		cfg.TrieCleanCache = ctx.Int(CacheFlag.Name) * ctx.Int(CacheTrieFlag.Name) / 100
 	}
+	if ctx.IsSet(CacheTrieHotFlag.Name) {
+		cfg.TrieHotCache = ctx.Int(CacheTrieHotFlag.Name)
+	}
 	if ctx.IsSet(CacheFlag.Name) || ctx.IsSet(CacheGCFlag.Name) {
 		cfg.TrieDirtyCache = ctx.Int(CacheFlag.Name) * ctx.Int(CacheGCFlag.Name) / 100
 	}
@@ -1796,6 +2265,9 @@ func SetEthConfig(ctx *cli.Context, stack *node.Node, cfg *ethconfig.Config) {
 	if ctx.IsSet(RPCGlobalTxFeeCapFlag.Name) {
 		cfg.RPCTxFeeCap = ctx.Float64(RPCGlobalTxFeeCapFlag.Name)
 	}
+	if ctx.IsSet(RPCDebugDangerousOpsFlag.Name) {
+		cfg.DebugDangerousOps = ctx.Bool(RPCDebugDangerousOpsFlag.Name)
+	}
 	if ctx.IsSet(NoDiscoverFlag.Name) {
 		cfg.EthDiscoveryURLs, cfg.SnapDiscoveryURLs = []string{}, []string{}
 	} else if ctx.IsSet(DNSDiscoveryFlag.Name) {
@@ -2092,6 +2564,20 @@ func RegisterGraphQLService(stack *node.Node, backend ethapi.Backend, filterSyst
 	}
 }
 
+// RegisterStatusService adds the node status page to the node.
+func RegisterStatusService(stack *node.Node, backend *eth.Ethereum) {
+	if err := status.Register(stack, backend); err != nil {
+		Fatalf("Failed to register the node status page: %v", err)
+	}
+}
+
+// RegisterChainStreamService adds the gRPC chain data streaming service to the
+// node.
+func RegisterChainStreamService(ctx *cli.Context, stack *node.Node, backend *eth.Ethereum) {
+	addr := net.JoinHostPort(ctx.String(GRPCListenAddrFlag.Name), strconv.Itoa(ctx.Int(GRPCPortFlag.Name)))
+	chainstream.Register(stack, backend, addr)
+}
+
 // RegisterFilterAPI adds the eth log filtering RPC API to the node.
 func RegisterFilterAPI(stack *node.Node, backend ethapi.Backend, ethcfg *ethconfig.Config) *filters.FilterSystem {
 	filterSystem := filters.NewFilterSystem(backend, filters.Config{
@@ -2106,17 +2592,92 @@ func RegisterFilterAPI(stack *node.Node, backend ethapi.Backend, ethcfg *ethconf
 }
 
 // RegisterSyncOverrideService adds the synchronization override service into node.
-func RegisterSyncOverrideService(stack *node.Node, eth *eth.Ethereum, target common.Hash, exitWhenSynced bool) {
-	if target != (common.Hash{}) {
+func RegisterSyncOverrideService(stack *node.Node, eth *eth.Ethereum, target common.Hash, checkpoint common.Hash, exitWhenSynced bool) {
+	switch {
+	case target != (common.Hash{}):
 		log.Info("Registered sync override service", "hash", target, "exitWhenSynced", exitWhenSynced)
-	} else {
+	case checkpoint != (common.Hash{}):
+		log.Info("Registered sync override service", "checkpoint", checkpoint, "exitWhenSynced", exitWhenSynced)
+	default:
 		log.Info("Registered sync override service")
 	}
-	syncer.Register(stack, eth, target, exitWhenSynced)
+	if _, err := syncer.Register(stack, eth, target, checkpoint, exitWhenSynced); err != nil {
+		Fatalf("Failed to register sync override service: %v", err)
+	}
+}
+
+// RegisterWatchpointService adds the storage watchpoint service into node.
+func RegisterWatchpointService(stack *node.Node, eth *eth.Ethereum) {
+	watchpoints.Register(stack, eth)
+}
+
+// RegisterUserOpService adds the ERC-4337 user operation mempool into node.
+func RegisterUserOpService(ctx *cli.Context, stack *node.Node, eth *eth.Ethereum) {
+	if !ctx.IsSet(UserOpsKeyFlag.Name) {
+		Fatalf("The user operation mempool requires a bundler key (--%s)", UserOpsKeyFlag.Name)
+	}
+	key, err := crypto.LoadECDSA(ctx.Path(UserOpsKeyFlag.Name))
+	if err != nil {
+		Fatalf("Failed to load the bundler key: %v", err)
+	}
+	entryPoint := ctx.String(UserOpsEntryPointFlag.Name)
+	if !common.IsHexAddress(entryPoint) {
+		Fatalf("Invalid EntryPoint address %q", entryPoint)
+	}
+	userops.Register(stack, eth, userops.Config{
+		EntryPoint:   common.HexToAddress(entryPoint),
+		Key:          key,
+		MaxBundleGas: ctx.Uint64(UserOpsBundleGasFlag.Name),
+	})
+}
+
+// RegisterInclusionService adds the transaction inclusion commitments to the node.
+func RegisterInclusionService(ctx *cli.Context, stack *node.Node, backend *eth.Ethereum) {
+	key, err := crypto.LoadECDSA(ctx.Path(InclusionKeyFlag.Name))
+	if err != nil {
+		Fatalf("Failed to load the inclusion commitment key: %v", err)
+	}
+	committer := inclusion.New(backend.BlockChain(), inclusion.Config{
+		Key:    key,
+		Window: ctx.Uint64(InclusionWindowFlag.Name),
+	})
+	submit := func(ctx context.Context, tx *types.Transaction) (common.Hash, error) {
+		return ethapi.SubmitTransaction(ctx, backend.APIBackend, tx)
+	}
+	stack.RegisterAPIs([]rpc.API{{
+		Namespace: "eth",
+		Service:   inclusion.NewAPI(committer, submit),
+	}})
+	stack.RegisterLifecycle(committer)
+}
+
+// RegisterEncryptedPool adds the encrypted transaction pool to the node, its
+// transactions being revealed by the configured decryption service while the
+// blocks are built.
+func RegisterEncryptedPool(ctx *cli.Context, stack *node.Node, backend *eth.Ethereum) {
+	endpoint := ctx.String(TxPoolEncryptedDecrypterFlag.Name)
+	client, err := rpc.DialContext(context.Background(), endpoint)
+	if err != nil {
+		Fatalf("Failed to connect to the decryption service %q: %v", endpoint, err)
+	}
+	pool := encrypted.New(encrypted.DefaultConfig, backend.BlockChain(), encrypted.NewRPCDecrypter(client))
+	stack.RegisterAPIs([]rpc.API{{
+		Namespace: "eth",
+		Service:   encrypted.NewAPI(pool),
+	}})
+	stack.RegisterLifecycle(pool)
+	backend.Miner().AddBundleSource(pool)
+
+	log.Info("Enabled encrypted transaction pool", "decrypter", endpoint)
 }
 
 // SetupMetrics configures the metrics system.
 func SetupMetrics(cfg *metrics.Config) {
+	// Tracing is independent of metrics collection, set it up first.
+	if cfg.OTLPTracing {
+		log.Info("Enabling tracing export to OpenTelemetry collector", "endpoint", cfg.OTLPEndpoint, "ratio", cfg.OTLPTracingRatio)
+		otlp.EnableTracing(cfg.OTLPEndpoint, cfg.OTLPTracingRatio, otlpResource())
+	}
 	if !cfg.Enabled {
 		return
 	}
@@ -2150,6 +2711,12 @@ func SetupMetrics(cfg *metrics.Config) {
 		go influxdb.InfluxDBV2WithTags(metrics.DefaultRegistry, 10*time.Second, endpoint, token, bucket, organization, "geth.", tagsMap)
 	}
 
+	// OpenTelemetry exporter.
+	if cfg.EnableOTLP {
+		log.Info("Enabling metrics export to OpenTelemetry collector", "endpoint", cfg.OTLPEndpoint)
+		go otlp.OTLP(metrics.DefaultRegistry, 10*time.Second, cfg.OTLPEndpoint, "geth.", otlpResource())
+	}
+
 	// Expvar exporter.
 	if cfg.HTTP != "" {
 		address := net.JoinHostPort(cfg.HTTP, fmt.Sprintf("%d", cfg.Port))
@@ -2163,6 +2730,15 @@ func SetupMetrics(cfg *metrics.Config) {
 	go metrics.CollectProcessMetrics(3 * time.Second)
 }
 
+// otlpResource returns the attributes identifying this node in the telemetry
+// exported to OpenTelemetry collectors.
+func otlpResource() map[string]string {
+	return map[string]string{
+		"service.name":    "geth",
+		"service.version": version.WithMeta,
+	}
+}
+
 // SplitTagsFlag parses a comma-separated list of k=v metrics tags.
 func SplitTagsFlag(tagsFlag string) map[string]string {
 	tags := strings.Split(tagsFlag, ",")
@@ -2300,12 +2876,14 @@ func MakeChain(ctx *cli.Context, stack *node.Node, readonly bool) (*core.BlockCh
 	}
 	options := &core.BlockChainConfig{
 		TrieCleanLimit: ethconfig.Defaults.TrieCleanCache,
+		TrieHotLimit:   ctx.Int(CacheTrieHotFlag.Name),
 		NoPrefetch:     ctx.Bool(CacheNoPrefetchFlag.Name),
 		TrieDirtyLimit: ethconfig.Defaults.TrieDirtyCache,
 		ArchiveMode:    ctx.String(GCModeFlag.Name) == "archive",
 		TrieTimeLimit:  ethconfig.Defaults.TrieTimeout,
 		SnapshotLimit:  ethconfig.Defaults.SnapshotCache,
 		Preimages:      ctx.Bool(CachePreimagesFlag.Name),
+		SenderCache:    ctx.Bool(CacheSendersFlag.Name),
 		StateScheme:    scheme,
 		StateHistory:   ctx.Uint64(StateHistoryFlag.Name),
 
@@ -60,7 +60,8 @@ var (
 // is only used for necessary consensus checks. The legacy consensus engine can be any
 // engine implements the consensus interface (except the beacon itself).
 type Beacon struct {
-	ethone consensus.Engine // Original consensus engine used in eth1, e.g. ethash or clique
+	ethone     consensus.Engine // Original consensus engine used in eth1, e.g. ethash or clique
+	extensions []*Extension     // Chain specific rules on top of the proof-of-stake ones
 }
 
 // New creates a consensus engine with the given embedded eth1 engine.
@@ -196,7 +197,7 @@ func (beacon *Beacon) VerifyUncles(chain consensus.ChainReader, block *types.Blo
 	if len(block.Uncles()) > 0 {
 		return errTooManyUncles
 	}
-	return nil
+	return beacon.verifyBodyExtensions(chain, block)
 }
 
 // verifyHeader checks whether a header conforms to the consensus rules of the
@@ -272,7 +273,7 @@ func (beacon *Beacon) verifyHeader(chain consensus.ChainHeaderReader, header, pa
 			return err
 		}
 	}
-	return nil
+	return beacon.verifyHeaderExtensions(chain, header, parent)
 }
 
 // verifyHeaders is similar to verifyHeader, but verifies a batch of headers
@@ -338,6 +339,7 @@ func (beacon *Beacon) Finalize(chain consensus.ChainHeaderReader, header *types.
 		amount = amount.Mul(amount, uint256.NewInt(params.GWei))
 		state.AddBalance(w.Address, amount, tracing.BalanceIncreaseWithdrawal)
 	}
+	beacon.finalizeExtensions(chain, header, state, body)
 	// No block reward which is issued by consensus layer instead.
 }
 
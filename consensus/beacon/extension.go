@@ -0,0 +1,109 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package beacon
+
+import (
+	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+)
+
+// Extension adds chain specific rules to the proof-of-stake part of the beacon
+// engine, on top of the stock Ethereum ones. All hooks are optional and only
+// invoked for proof-of-stake blocks; pre-merge blocks are left to the embedded
+// eth1 engine.
+type Extension struct {
+	// Name identifies the extension in errors it returns.
+	Name string
+
+	// VerifyHeader is called after a header passed the stock header checks,
+	// with its already verified parent.
+	VerifyHeader func(chain consensus.ChainHeaderReader, header, parent *types.Header) error
+
+	// VerifyBody is called during body validation of a block, after the stock
+	// body checks passed. Rules on the transactions a block must or must not
+	// contain, such as system transactions, belong here.
+	VerifyBody func(chain consensus.ChainReader, block *types.Block) error
+
+	// Finalize is called after the withdrawals of a block have been applied to
+	// the state, both when importing and when assembling blocks.
+	Finalize func(chain consensus.ChainHeaderReader, header *types.Header, state vm.StateDB, body *types.Body)
+}
+
+// Extend registers additional rules with the engine. Extensions are applied in
+// the order of registration and must be registered before the engine is used.
+func (beacon *Beacon) Extend(ext *Extension) {
+	beacon.extensions = append(beacon.extensions, ext)
+}
+
+// verifyHeaderExtensions runs the header hooks of all extensions.
+func (beacon *Beacon) verifyHeaderExtensions(chain consensus.ChainHeaderReader, header, parent *types.Header) error {
+	for _, ext := range beacon.extensions {
+		if ext.VerifyHeader == nil {
+			continue
+		}
+		if err := ext.VerifyHeader(chain, header, parent); err != nil {
+			return extensionError(ext, err)
+		}
+	}
+	return nil
+}
+
+// verifyBodyExtensions runs the body hooks of all extensions.
+func (beacon *Beacon) verifyBodyExtensions(chain consensus.ChainReader, block *types.Block) error {
+	for _, ext := range beacon.extensions {
+		if ext.VerifyBody == nil {
+			continue
+		}
+		if err := ext.VerifyBody(chain, block); err != nil {
+			return extensionError(ext, err)
+		}
+	}
+	return nil
+}
+
+// finalizeExtensions runs the finalization hooks of all extensions.
+func (beacon *Beacon) finalizeExtensions(chain consensus.ChainHeaderReader, header *types.Header, state vm.StateDB, body *types.Body) {
+	for _, ext := range beacon.extensions {
+		if ext.Finalize != nil {
+			ext.Finalize(chain, header, state, body)
+		}
+	}
+}
+
+// extensionError attributes a rule violation to the extension enforcing it.
+func extensionError(ext *Extension, err error) error {
+	if ext.Name == "" {
+		return err
+	}
+	return &ExtensionError{Extension: ext.Name, Err: err}
+}
+
+// ExtensionError is returned when a block violates a rule added by an
+// extension of the engine.
+type ExtensionError struct {
+	Extension string
+	Err       error
+}
+
+func (e *ExtensionError) Error() string {
+	return e.Extension + ": " + e.Err.Error()
+}
+
+func (e *ExtensionError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,132 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package beacon
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/consensus/misc/eip1559"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// headerChain is a minimal consensus.ChainHeaderReader over a fixed header set.
+type headerChain struct {
+	config  *params.ChainConfig
+	headers map[common.Hash]*types.Header
+}
+
+func (c *headerChain) Config() *params.ChainConfig  { return c.config }
+func (c *headerChain) CurrentHeader() *types.Header { return nil }
+func (c *headerChain) GetHeader(hash common.Hash, number uint64) *types.Header {
+	return c.headers[hash]
+}
+func (c *headerChain) GetHeaderByNumber(number uint64) *types.Header { return nil }
+func (c *headerChain) GetHeaderByHash(hash common.Hash) *types.Header {
+	return c.headers[hash]
+}
+
+func TestExtensionHooks(t *testing.T) {
+	config := &params.ChainConfig{
+		ChainID:                 big.NewInt(1),
+		HomesteadBlock:          common.Big0,
+		EIP150Block:             common.Big0,
+		EIP155Block:             common.Big0,
+		EIP158Block:             common.Big0,
+		ByzantiumBlock:          common.Big0,
+		ConstantinopleBlock:     common.Big0,
+		PetersburgBlock:         common.Big0,
+		IstanbulBlock:           common.Big0,
+		BerlinBlock:             common.Big0,
+		LondonBlock:             common.Big0,
+		TerminalTotalDifficulty: common.Big0,
+	}
+	parent := &types.Header{
+		Number:     common.Big0,
+		Difficulty: common.Big0,
+		GasLimit:   30_000_000,
+		GasUsed:    15_000_000,
+		BaseFee:    big.NewInt(params.InitialBaseFee),
+		UncleHash:  types.EmptyUncleHash,
+	}
+	chain := &headerChain{config: config, headers: map[common.Hash]*types.Header{parent.Hash(): parent}}
+
+	child := func(extra string) *types.Header {
+		return &types.Header{
+			ParentHash: parent.Hash(),
+			Number:     common.Big1,
+			Time:       1,
+			Difficulty: common.Big0,
+			GasLimit:   parent.GasLimit,
+			BaseFee:    eip1559.CalcBaseFee(config, parent),
+			UncleHash:  types.EmptyUncleHash,
+			Extra:      []byte(extra),
+		}
+	}
+	errBadExtra := errors.New("bad extra-data")
+	errNoTxs := errors.New("missing system transaction")
+
+	var finalized *types.Header
+	engine := New(ethash.NewFaker())
+	engine.Extend(&Extension{
+		Name: "test",
+		VerifyHeader: func(chain consensus.ChainHeaderReader, header, parent *types.Header) error {
+			if string(header.Extra) != "ok" {
+				return errBadExtra
+			}
+			return nil
+		},
+		VerifyBody: func(chain consensus.ChainReader, block *types.Block) error {
+			if len(block.Transactions()) == 0 {
+				return errNoTxs
+			}
+			return nil
+		},
+		Finalize: func(chain consensus.ChainHeaderReader, header *types.Header, state vm.StateDB, body *types.Body) {
+			finalized = header
+		},
+	})
+	// Hooks without a function are skipped
+	engine.Extend(&Extension{Name: "empty"})
+
+	if err := engine.VerifyHeader(chain, child("ok")); err != nil {
+		t.Fatalf("valid header rejected: %v", err)
+	}
+	err := engine.VerifyHeader(chain, child("bad"))
+	if !errors.Is(err, errBadExtra) {
+		t.Fatalf("wrong header error: have %v, want %v", err, errBadExtra)
+	}
+	var extErr *ExtensionError
+	if !errors.As(err, &extErr) || extErr.Extension != "test" {
+		t.Fatalf("header error not attributed to extension: %v", err)
+	}
+	block := types.NewBlockWithHeader(child("ok"))
+	if err := engine.VerifyUncles(nil, block); !errors.Is(err, errNoTxs) {
+		t.Fatalf("wrong body error: have %v, want %v", err, errNoTxs)
+	}
+	header := child("ok")
+	engine.Finalize(chain, header, nil, new(types.Body))
+	if finalized != header {
+		t.Fatal("finalize hook not invoked")
+	}
+}
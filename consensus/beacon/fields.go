@@ -0,0 +1,122 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package beacon
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/consensus/misc/eip4844"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// VerifyHeaderFields checks the fields of a header which can be verified without
+// its parent against the rules of the forks active at the header. Unlike header
+// verification by the engine, it reports all violations instead of stopping at
+// the first one, which makes it suitable for validating headers built by tools.
+//
+// Fields depending on the parent, such as the base fee, the excess blob gas and
+// the timestamp, and the seal of pre-merge headers are not verified.
+func VerifyHeaderFields(config *params.ChainConfig, header *types.Header) error {
+	if header.Number == nil {
+		return errors.New("header is missing number")
+	}
+	var (
+		errs   []error
+		number = header.Number.Uint64()
+	)
+	// The extra-data of clique headers carries the signers and the seal, it's
+	// only limited for the other engines.
+	if config.Clique == nil && len(header.Extra) > int(params.MaximumExtraDataSize) {
+		errs = append(errs, fmt.Errorf("extra-data longer than %d bytes (%d)", params.MaximumExtraDataSize, len(header.Extra)))
+	}
+	if header.GasLimit > params.MaxGasLimit {
+		errs = append(errs, fmt.Errorf("invalid gasLimit: have %v, max %v", header.GasLimit, params.MaxGasLimit))
+	}
+	if header.GasLimit < params.MinGasLimit {
+		errs = append(errs, fmt.Errorf("invalid gasLimit: have %v, min %v", header.GasLimit, params.MinGasLimit))
+	}
+	if header.GasUsed > header.GasLimit {
+		errs = append(errs, fmt.Errorf("invalid gasUsed: have %d, gasLimit %d", header.GasUsed, header.GasLimit))
+	}
+	// Verify the seal parts of proof-of-stake headers
+	if config.IsPostMerge(number, header.Time) {
+		if header.Difficulty == nil || header.Difficulty.Cmp(beaconDifficulty) != 0 {
+			errs = append(errs, fmt.Errorf("invalid difficulty: have %v, want %v", header.Difficulty, beaconDifficulty))
+		}
+		if header.Nonce != beaconNonce {
+			errs = append(errs, errInvalidNonce)
+		}
+		if header.UncleHash != types.EmptyUncleHash {
+			errs = append(errs, errInvalidUncleHash)
+		}
+	} else if header.Difficulty == nil || header.Difficulty.Sign() <= 0 {
+		errs = append(errs, fmt.Errorf("invalid difficulty: have %v, want positive", header.Difficulty))
+	}
+	// Verify the existence / non-existence of the fork specific fields
+	london := config.IsLondon(header.Number)
+	if london && header.BaseFee == nil {
+		errs = append(errs, errors.New("header is missing baseFee"))
+	}
+	if !london && header.BaseFee != nil {
+		errs = append(errs, fmt.Errorf("invalid baseFee: have %v, expected nil", header.BaseFee))
+	}
+	shanghai := config.IsShanghai(header.Number, header.Time)
+	if shanghai && header.WithdrawalsHash == nil {
+		errs = append(errs, errors.New("missing withdrawalsHash"))
+	}
+	if !shanghai && header.WithdrawalsHash != nil {
+		errs = append(errs, fmt.Errorf("invalid withdrawalsHash: have %x, expected nil", header.WithdrawalsHash))
+	}
+	if config.IsCancun(header.Number, header.Time) {
+		if header.ExcessBlobGas == nil {
+			errs = append(errs, errors.New("header is missing excessBlobGas"))
+		}
+		if header.ParentBeaconRoot == nil {
+			errs = append(errs, errors.New("header is missing beaconRoot"))
+		}
+		if header.BlobGasUsed == nil {
+			errs = append(errs, errors.New("header is missing blobGasUsed"))
+		} else {
+			if limit := eip4844.MaxBlobGasPerBlock(config, header.Time); *header.BlobGasUsed > limit {
+				errs = append(errs, fmt.Errorf("blob gas used %d exceeds maximum allowance %d", *header.BlobGasUsed, limit))
+			}
+			if *header.BlobGasUsed%params.BlobTxBlobGasPerBlob != 0 {
+				errs = append(errs, fmt.Errorf("blob gas used %d not a multiple of blob gas per blob %d", *header.BlobGasUsed, params.BlobTxBlobGasPerBlob))
+			}
+		}
+	} else {
+		if header.ExcessBlobGas != nil {
+			errs = append(errs, fmt.Errorf("invalid excessBlobGas: have %d, expected nil", *header.ExcessBlobGas))
+		}
+		if header.BlobGasUsed != nil {
+			errs = append(errs, fmt.Errorf("invalid blobGasUsed: have %d, expected nil", *header.BlobGasUsed))
+		}
+		if header.ParentBeaconRoot != nil {
+			errs = append(errs, fmt.Errorf("invalid parentBeaconRoot, have %#x, expected nil", *header.ParentBeaconRoot))
+		}
+	}
+	prague := config.IsPrague(header.Number, header.Time)
+	if prague && header.RequestsHash == nil {
+		errs = append(errs, errors.New("header is missing requestsHash"))
+	}
+	if !prague && header.RequestsHash != nil {
+		errs = append(errs, fmt.Errorf("invalid requestsHash: have %x, expected nil", *header.RequestsHash))
+	}
+	return errors.Join(errs...)
+}
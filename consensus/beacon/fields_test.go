@@ -0,0 +1,114 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package beacon
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func TestVerifyHeaderFields(t *testing.T) {
+	config := params.MergedTestChainConfig
+
+	valid := func() *types.Header {
+		var (
+			blobGasUsed   = uint64(2 * params.BlobTxBlobGasPerBlob)
+			excessBlobGas = uint64(0)
+		)
+		return &types.Header{
+			Number:           big.NewInt(1),
+			Time:             1,
+			GasLimit:         30_000_000,
+			GasUsed:          21000,
+			Difficulty:       new(big.Int),
+			UncleHash:        types.EmptyUncleHash,
+			BaseFee:          big.NewInt(params.InitialBaseFee),
+			WithdrawalsHash:  &types.EmptyWithdrawalsHash,
+			BlobGasUsed:      &blobGasUsed,
+			ExcessBlobGas:    &excessBlobGas,
+			ParentBeaconRoot: &common.Hash{},
+			RequestsHash:     &types.EmptyRequestsHash,
+		}
+	}
+	if err := VerifyHeaderFields(config, valid()); err != nil {
+		t.Fatalf("valid header rejected: %v", err)
+	}
+	tests := []struct {
+		modify func(h *types.Header)
+		errs   []string
+	}{
+		{
+			modify: func(h *types.Header) { h.Extra = make([]byte, 33) },
+			errs:   []string{"extra-data longer than 32 bytes"},
+		},
+		{
+			modify: func(h *types.Header) { h.GasUsed = h.GasLimit + 1 },
+			errs:   []string{"invalid gasUsed"},
+		},
+		{
+			modify: func(h *types.Header) {
+				h.Difficulty = big.NewInt(1)
+				h.Nonce = types.EncodeNonce(1)
+			},
+			errs: []string{"invalid difficulty", "invalid nonce"},
+		},
+		{
+			modify: func(h *types.Header) {
+				h.BaseFee = nil
+				h.WithdrawalsHash = nil
+				h.RequestsHash = nil
+			},
+			errs: []string{"missing baseFee", "missing withdrawalsHash", "missing requestsHash"},
+		},
+		{
+			modify: func(h *types.Header) {
+				used := uint64(params.BlobTxBlobGasPerBlob + 1)
+				h.BlobGasUsed = &used
+				h.ParentBeaconRoot = nil
+			},
+			errs: []string{"missing beaconRoot", "not a multiple of blob gas per blob"},
+		},
+	}
+	for i, tt := range tests {
+		header := valid()
+		tt.modify(header)
+
+		err := VerifyHeaderFields(config, header)
+		if err == nil {
+			t.Errorf("test %d: invalid header accepted", i)
+			continue
+		}
+		for _, want := range tt.errs {
+			if !strings.Contains(err.Error(), want) {
+				t.Errorf("test %d: error %q missing %q", i, err, want)
+			}
+		}
+	}
+	// Fork specific fields must be absent before their forks
+	header := valid()
+	header.Difficulty = big.NewInt(1)
+	if err := VerifyHeaderFields(params.TestChainConfig, header); err == nil {
+		t.Fatal("header with future fields accepted before the forks")
+	} else if !strings.Contains(err.Error(), "invalid excessBlobGas") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
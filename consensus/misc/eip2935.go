@@ -0,0 +1,67 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package misc
+
+import (
+	"maps"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// ApplyHistoryStorage installs the EIP-2935 history storage contract if the
+// account holds no code yet. Chains activating the block hash history ahead of
+// Prague don't deploy the contract with a transaction, it is installed by the
+// first block of the fork instead.
+func ApplyHistoryStorage(statedb vm.StateDB) {
+	if statedb.GetCodeSize(params.HistoryStorageAddress) != 0 {
+		return
+	}
+	if statedb.GetNonce(params.HistoryStorageAddress) == 0 {
+		statedb.SetNonce(params.HistoryStorageAddress, 1, tracing.NonceChangeUnspecified)
+	}
+	statedb.SetCode(params.HistoryStorageAddress, params.HistoryStorageCode, tracing.CodeChangeUnspecified)
+}
+
+// HistoryStorageAlloc returns the genesis allocation with the history storage
+// contract added, if the block hash history fork is active at genesis and the
+// allocation doesn't hold the contract yet. Otherwise, alloc is returned as is.
+func HistoryStorageAlloc(config *params.ChainConfig, time uint64, alloc types.GenesisAlloc) types.GenesisAlloc {
+	if config == nil || !config.IsBlockHashHistoryFork(common.Big0, time) {
+		return alloc
+	}
+	account := alloc[params.HistoryStorageAddress]
+	if len(account.Code) != 0 {
+		return alloc
+	}
+	account.Code = params.HistoryStorageCode
+	if account.Nonce == 0 {
+		account.Nonce = 1
+	}
+	if account.Balance == nil {
+		account.Balance = common.Big0
+	}
+	injected := maps.Clone(alloc)
+	if injected == nil {
+		injected = make(types.GenesisAlloc)
+	}
+	injected[params.HistoryStorageAddress] = account
+	return injected
+}
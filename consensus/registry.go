@@ -0,0 +1,77 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package consensus
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// EngineConstructor creates a consensus engine for a chain whose configuration
+// selects it by name.
+type EngineConstructor func(config *params.ChainConfig, db ethdb.Database) (Engine, error)
+
+var (
+	enginesLock sync.RWMutex
+	engines     = make(map[string]EngineConstructor)
+)
+
+// RegisterEngine makes a consensus engine available under the given name. Chain
+// configurations select it through their engine field. Registration is meant
+// to happen from an init function of the package defining the engine, and
+// panics if the name is empty or already taken.
+func RegisterEngine(name string, ctor EngineConstructor) {
+	enginesLock.Lock()
+	defer enginesLock.Unlock()
+
+	if name == "" {
+		panic("consensus: engine registered without a name")
+	}
+	if ctor == nil {
+		panic(fmt.Sprintf("consensus: nil constructor for engine %q", name))
+	}
+	if _, ok := engines[name]; ok {
+		panic(fmt.Sprintf("consensus: engine %q registered twice", name))
+	}
+	engines[name] = ctor
+}
+
+// LookupEngine returns the constructor registered under the given name.
+func LookupEngine(name string) (EngineConstructor, bool) {
+	enginesLock.RLock()
+	defer enginesLock.RUnlock()
+
+	ctor, ok := engines[name]
+	return ctor, ok
+}
+
+// RegisteredEngines returns the sorted names of all registered engines.
+func RegisteredEngines() []string {
+	enginesLock.RLock()
+	defer enginesLock.RUnlock()
+
+	names := make([]string, 0, len(engines))
+	for name := range engines {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -199,7 +199,7 @@ func TestProcessParentBlockHash(t *testing.T) {
 			if isVerkle {
 				chainConfig = testVerkleChainConfig
 			}
-			vmContext := NewEVMBlockContext(header, nil, new(common.Address))
+			vmContext, _ := NewEVMBlockContext(header, nil, new(common.Address))
 			evm := vm.NewEVM(vmContext, statedb, chainConfig, vm.Config{})
 			ProcessParentBlockHash(header.ParentHash, evm)
 		}
@@ -30,6 +30,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/trie"
 )
 
 // Tests that simple header verification works, for both good and bad blocks.
@@ -271,3 +272,28 @@ func TestCalcGasLimit(t *testing.T) {
 		}
 	}
 }
+
+func TestValidateWithdrawals(t *testing.T) {
+	var (
+		withdrawals = types.Withdrawals{{Index: 1, Validator: 2, Address: common.Address{0x03}, Amount: 4}}
+		root        = types.DeriveSha(withdrawals, trie.NewStackTrie(nil))
+	)
+	tests := []struct {
+		root        *common.Hash
+		withdrawals types.Withdrawals
+		valid       bool
+	}{
+		{nil, nil, true},          // Pre-Shanghai
+		{nil, withdrawals, false}, // Withdrawals before Shanghai
+		{&types.EmptyWithdrawalsHash, types.Withdrawals{}, true}, // Empty withdrawals
+		{&types.EmptyWithdrawalsHash, nil, false},                // Missing withdrawals
+		{&root, withdrawals, true},                               // Matching withdrawals
+		{&types.EmptyWithdrawalsHash, withdrawals, false},        // Mismatching withdrawals
+	}
+	for i, tt := range tests {
+		err := ValidateWithdrawals(&types.Header{WithdrawalsHash: tt.root}, tt.withdrawals)
+		if (err == nil) != tt.valid {
+			t.Errorf("test %d: validity mismatch: have %v, want valid %v", i, err, tt.valid)
+		}
+	}
+}
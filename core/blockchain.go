@@ -18,11 +18,13 @@
 package core
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"io"
 	"math/big"
 	"runtime"
+	"runtime/pprof"
 	"slices"
 	"sort"
 	"strings"
@@ -44,12 +46,14 @@ import (
 	"github.com/ethereum/go-ethereum/core/tracing"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/internal/syncx"
 	"github.com/ethereum/go-ethereum/internal/version"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/ethereum/go-ethereum/metrics/otlp"
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/ethereum/go-ethereum/triedb"
@@ -87,6 +91,11 @@ var (
 	storageCacheHitPrefetchMeter  = metrics.NewRegisteredMeter("chain/storage/reads/cache/prefetch/hit", nil)
 	storageCacheMissPrefetchMeter = metrics.NewRegisteredMeter("chain/storage/reads/cache/prefetch/miss", nil)
 
+	accountColdReadMeter = metrics.NewRegisteredMeter("chain/account/reads/cold", nil)
+	accountWarmReadMeter = metrics.NewRegisteredMeter("chain/account/reads/warm", nil)
+	storageColdReadMeter = metrics.NewRegisteredMeter("chain/storage/reads/cold", nil)
+	storageWarmReadMeter = metrics.NewRegisteredMeter("chain/storage/reads/warm", nil)
+
 	accountReadSingleTimer = metrics.NewRegisteredResettingTimer("chain/account/single/reads", nil)
 	storageReadSingleTimer = metrics.NewRegisteredResettingTimer("chain/storage/single/reads", nil)
 	codeReadSingleTimer    = metrics.NewRegisteredResettingTimer("chain/code/single/reads", nil)
@@ -104,6 +113,11 @@ var (
 	blockReorgAddMeter  = metrics.NewRegisteredMeter("chain/reorg/add", nil)
 	blockReorgDropMeter = metrics.NewRegisteredMeter("chain/reorg/drop", nil)
 
+	warmSlotsAccessedMeter  = metrics.NewRegisteredMeter("chain/warmslots/accessed", nil)
+	warmSlotsInheritedMeter = metrics.NewRegisteredMeter("chain/warmslots/inherited", nil)
+	warmSlotsHitMeter       = metrics.NewRegisteredMeter("chain/warmslots/hits", nil)
+	warmSlotsGasSavedMeter  = metrics.NewRegisteredMeter("chain/warmslots/gassaved", nil)
+
 	blockPrefetchExecuteTimer    = metrics.NewRegisteredResettingTimer("chain/prefetch/executes", nil)
 	blockPrefetchInterruptMeter  = metrics.NewRegisteredMeter("chain/prefetch/interrupts", nil)
 	blockPrefetchTxsInvalidMeter = metrics.NewRegisteredMeter("chain/prefetch/txs/invalid", nil)
@@ -117,6 +131,9 @@ var (
 
 var (
 	forkReadyInterval = 3 * time.Minute
+
+	// importProfileLabels are the pprof labels attached to block imports.
+	importProfileLabels = pprof.Labels("subsystem", "import")
 )
 
 const (
@@ -124,6 +141,7 @@ const (
 	blockCacheLimit    = 256
 	receiptsCacheLimit = 32
 	txLookupCacheLimit = 1024
+	stateStatsLimit    = 128
 
 	// BlockChainVersion ensures that an incompatible database forces a resync from scratch.
 	//
@@ -164,6 +182,7 @@ const (
 type BlockChainConfig struct {
 	// Trie database related options
 	TrieCleanLimit       int           // Memory allowance (MB) to use for caching trie nodes in memory
+	TrieHotLimit         int           // Memory allowance (MB) to use for caching hashed shallow trie nodes (path scheme only)
 	TrieDirtyLimit       int           // Memory limit (MB) at which to start flushing dirty trie nodes to disk
 	TrieTimeLimit        time.Duration // Time limit after which to flush the current in-memory trie to disk
 	TrieNoAsyncFlush     bool          // Whether the asynchronous buffer flushing is disallowed
@@ -186,6 +205,11 @@ type BlockChainConfig struct {
 	// Blocks before this number may be unavailable in the chain database.
 	ChainHistoryMode history.HistoryMode
 
+	// ChainHistoryStart is the first block whose body and receipts are stored,
+	// allowing a fleet of nodes to each keep a slice of the chain history. Zero
+	// means the history retention is governed by ChainHistoryMode alone.
+	ChainHistoryStart uint64
+
 	// Misc options
 	NoPrefetch bool            // Whether to disable heuristic state prefetching when processing blocks
 	Overrides  *ChainOverrides // Optional chain config overrides
@@ -204,6 +228,16 @@ type BlockChainConfig struct {
 	// SlowBlockThreshold is the block execution time threshold beyond which
 	// detailed statistics will be logged.
 	SlowBlockThreshold time.Duration
+
+	// MaxReorgDepth is the maximum number of canonical blocks a head update may
+	// drop. Deeper reorgs are rejected unless allowed via AllowDeepReorg. Zero
+	// means unlimited.
+	MaxReorgDepth uint64
+
+	// SenderCache enables persisting the recovered transaction senders, which
+	// are then loaded instead of recovering the signatures again when blocks
+	// are reprocessed, e.g. on reimport or state regeneration.
+	SenderCache bool
 }
 
 // DefaultConfig returns the default config.
@@ -257,6 +291,7 @@ func (cfg *BlockChainConfig) triedbConfig(isVerkle bool) *triedb.Config {
 			StateHistory:        cfg.StateHistory,
 			EnableStateIndexing: cfg.ArchiveMode,
 			TrieCleanSize:       cfg.TrieCleanLimit * 1024 * 1024,
+			TrieHotSize:         cfg.TrieHotLimit * 1024 * 1024,
 			StateCleanSize:      cfg.SnapshotLimit * 1024 * 1024,
 			JournalDirectory:    cfg.TrieJournalDirectory,
 
@@ -304,14 +339,21 @@ type BlockChain struct {
 	triedb        *triedb.Database                 // The database handler for maintaining trie nodes.
 	statedb       *state.CachingDB                 // State database to reuse between imports (contains state cache)
 	txIndexer     *txIndexer                       // Transaction indexer, might be nil if not enabled
+	indexes       []ChainIndex                     // Indexes following the chain head
+	indexesMu     sync.RWMutex                     // Lock protecting the registered indexes
 
 	hc               *HeaderChain
+	watchpoints      *storageWatchSet // Storage slots whose changes are reported on import
 	rmLogsFeed       event.Feed
 	chainFeed        event.Feed
 	chainHeadFeed    event.Feed
 	logsFeed         event.Feed
 	blockProcFeed    event.Feed
 	newPayloadFeed   event.Feed // Feed for engine API newPayload events
+	storageWatchFeed event.Feed // Feed for changes of watched storage slots
+	stateDiffFeed    event.Feed // Feed for the state diffs of imported blocks
+	reorgFeed        event.Feed // Feed for reorgs recorded in the reorg journal
+	stateDiffSubs    atomic.Int32
 	blockProcCounter int32
 	scope            event.SubscriptionScope
 	genesisBlock     *types.Block
@@ -323,6 +365,7 @@ type BlockChain struct {
 	currentBlock      atomic.Pointer[types.Header] // Current head of the chain
 	currentSnapBlock  atomic.Pointer[types.Header] // Current head of snap-sync
 	currentFinalBlock atomic.Pointer[types.Header] // Latest (consensus) finalized block
+	deepReorgHead     atomic.Pointer[common.Hash]  // Head allowed to reorg beyond MaxReorgDepth once
 	currentSafeBlock  atomic.Pointer[types.Header] // Latest (consensus) safe block
 	historyPrunePoint atomic.Pointer[history.PrunePoint]
 
@@ -333,6 +376,7 @@ type BlockChain struct {
 
 	txLookupLock  sync.RWMutex
 	txLookupCache *lru.Cache[common.Hash, txLookup]
+	stateStats    *lru.Cache[common.Hash, *StateReadStats] // State read statistics of the recently imported blocks
 
 	stopping      atomic.Bool // false if chain is running, true when stopped
 	procInterrupt atomic.Bool // interrupt signaler for block processing
@@ -391,7 +435,9 @@ func NewBlockChain(db ethdb.Database, genesis *Genesis, engine consensus.Engine,
 		receiptsCache:      lru.NewCache[common.Hash, []*types.Receipt](receiptsCacheLimit),
 		blockCache:         lru.NewCache[common.Hash, *types.Block](blockCacheLimit),
 		txLookupCache:      lru.NewCache[common.Hash, txLookup](txLookupCacheLimit),
+		stateStats:         lru.NewCache[common.Hash, *StateReadStats](stateStatsLimit),
 		engine:             engine,
+		watchpoints:        newStorageWatchSet(),
 		logger:             cfg.VmConfig.Tracer,
 		slowBlockThreshold: cfg.SlowBlockThreshold,
 	}
@@ -534,9 +580,11 @@ func NewBlockChain(db ethdb.Database, genesis *Genesis, engine consensus.Engine,
 		rawdb.WriteChainConfig(db, genesisHash, chainConfig)
 	}
 
-	// Start tx indexer if it's enabled.
+	// Start tracking the chain head for the indexes, and the tx indexer if it's enabled.
+	go bc.indexLoop()
 	if bc.cfg.TxLookupLimit >= 0 {
 		bc.txIndexer = newTxIndexer(uint64(bc.cfg.TxLookupLimit), bc)
+		bc.RegisterIndex(bc.txIndexer)
 	}
 
 	// Start state size tracker
@@ -698,6 +746,23 @@ func (bc *BlockChain) loadLastState() error {
 func (bc *BlockChain) initializeHistoryPruning(latest uint64) error {
 	freezerTail, _ := bc.db.Tail()
 
+	if start := bc.cfg.ChainHistoryStart; start != 0 {
+		if bc.cfg.ChainHistoryMode != history.KeepAll {
+			return fmt.Errorf("history start %d conflicts with history mode %q", start, bc.cfg.ChainHistoryMode)
+		}
+		if freezerTail != start && (freezerTail != 0 || latest != 0) {
+			log.Error("Chain history database does not match the configured history start", "tail", freezerTail, "start", start)
+			log.Error(fmt.Sprintf("Run 'geth prune-history --history.start %d' to prune the history before the start.", start))
+			return errors.New("unexpected database tail")
+		}
+		// The hash of the start block is unknown until the headers are synced,
+		// it is resolved lazily in HistoryPruningCutoff.
+		bc.historyPrunePoint.Store(&history.PrunePoint{
+			BlockNumber: start,
+			BlockHash:   rawdb.ReadCanonicalHash(bc.db, start),
+		})
+		return nil
+	}
 	switch bc.cfg.ChainHistoryMode {
 	case history.KeepAll:
 		if freezerTail == 0 {
@@ -1184,6 +1249,43 @@ func (bc *BlockChain) SnapSyncComplete(hash common.Hash) error {
 	return nil
 }
 
+// PurgeStorage deletes the storage left behind in the database by an account which
+// has no storage in the current state, such as a self-destructed contract whose
+// storage was never cleaned up.
+//
+// In the path scheme, the storage trie nodes are owned by the account and removed
+// along with the storage snapshot. In the hash scheme trie nodes might be shared
+// with other tries, so only the snapshot is purged and the nodes are left to the
+// offline state pruner.
+func (bc *BlockChain) PurgeStorage(address common.Address) error {
+	if !bc.chainmu.TryLock() {
+		return errChainStopped
+	}
+	defer bc.chainmu.Unlock()
+
+	statedb, err := bc.StateAt(bc.CurrentBlock().Root)
+	if err != nil {
+		return err
+	}
+	if root := statedb.GetStorageRoot(address); root != (common.Hash{}) && root != types.EmptyRootHash {
+		return fmt.Errorf("account %x has live storage", address)
+	}
+	var (
+		start       = time.Now()
+		accountHash = crypto.Keccak256Hash(address.Bytes())
+	)
+	if bc.triedb.Scheme() == rawdb.PathScheme {
+		if err := rawdb.DeleteStorageTrie(bc.db, accountHash); err != nil {
+			return err
+		}
+	}
+	if err := rawdb.DeleteStorageSnapshots(bc.db, accountHash); err != nil {
+		return err
+	}
+	log.Info("Purged orphaned account storage", "address", address, "scheme", bc.triedb.Scheme(), "elapsed", common.PrettyDuration(time.Since(start)))
+	return nil
+}
+
 // Reset purges the entire blockchain, restoring it to its genesis state.
 func (bc *BlockChain) Reset() error {
 	return bc.ResetWithGenesisBlock(bc.genesisBlock)
@@ -1628,6 +1730,73 @@ func (bc *BlockChain) writeKnownBlock(block *types.Block) error {
 	return nil
 }
 
+// reportWarmSlots measures the storage slots inherited by the block under the
+// warm slots rules and how many of them were accessed again. Every hit spares at
+// least one cold storage access.
+func (bc *BlockChain) reportWarmSlots(block *types.Block, slots types.AccessList) {
+	var accessed int
+	for _, el := range slots {
+		accessed += len(el.StorageKeys)
+	}
+	warmSlotsAccessedMeter.Mark(int64(accessed))
+
+	warm, err := WarmSlots(block.Header(), bc)
+	if err != nil || len(warm) == 0 {
+		return
+	}
+	inherited := make(map[common.Address]map[common.Hash]struct{}, len(warm))
+	for _, el := range warm {
+		keys := make(map[common.Hash]struct{}, len(el.StorageKeys))
+		for _, key := range el.StorageKeys {
+			keys[key] = struct{}{}
+		}
+		inherited[el.Address] = keys
+		warmSlotsInheritedMeter.Mark(int64(len(el.StorageKeys)))
+	}
+	var hits int
+	for _, el := range slots {
+		for _, key := range el.StorageKeys {
+			if _, ok := inherited[el.Address][key]; ok {
+				hits++
+			}
+		}
+	}
+	warmSlotsHitMeter.Mark(int64(hits))
+	warmSlotsGasSavedMeter.Mark(int64(hits) * int64(params.ColdSloadCostEIP2929-params.WarmStorageReadCostEIP2929))
+}
+
+// LoadSenders seeds the sender caches of the transactions in the given blocks
+// with the senders persisted during an earlier import, if the sender cache is
+// enabled. Transactions without a persisted sender are left untouched and will
+// be recovered as usual.
+func (bc *BlockChain) LoadSenders(blocks []*types.Block) {
+	if !bc.cfg.SenderCache {
+		return
+	}
+	for _, block := range blocks {
+		signer := types.MakeSigner(bc.chainConfig, block.Number(), block.Time())
+		for _, tx := range block.Transactions() {
+			if from, ok := rawdb.ReadTxSender(bc.db, tx.Hash()); ok {
+				types.CacheSender(signer, tx, from)
+			}
+		}
+	}
+}
+
+// writeSenders persists the senders of the transactions in the given block.
+// The senders were already derived during processing, so no signature is
+// recovered again here.
+func (bc *BlockChain) writeSenders(db ethdb.KeyValueWriter, block *types.Block) {
+	signer := types.MakeSigner(bc.chainConfig, block.Number(), block.Time())
+	for _, tx := range block.Transactions() {
+		from, err := types.Sender(signer, tx)
+		if err != nil {
+			continue
+		}
+		rawdb.WriteTxSender(db, tx.Hash(), from)
+	}
+}
+
 // writeBlockWithState writes block, metadata and corresponding state data to the
 // database.
 func (bc *BlockChain) writeBlockWithState(block *types.Block, receipts []*types.Receipt, statedb *state.StateDB) error {
@@ -1644,7 +1813,21 @@ func (bc *BlockChain) writeBlockWithState(block *types.Block, receipts []*types.
 	)
 	rawdb.WriteBlock(batch, block)
 	rawdb.WriteReceipts(batch, block.Hash(), block.NumberU64(), receipts)
+	if bc.cfg.VmConfig.RecordHaltReasons {
+		rawdb.WriteHaltReasons(batch, block.Hash(), block.NumberU64(), receipts)
+	}
+	if bc.cfg.VmConfig.RecordRevertReasons {
+		rawdb.WriteRevertReasons(batch, block.Hash(), block.NumberU64(), receipts)
+	}
 	rawdb.WritePreimages(batch, statedb.Preimages())
+	if bc.cfg.SenderCache {
+		bc.writeSenders(batch, block)
+	}
+	if bc.chainConfig.IsWarmSlots(block.Number(), block.Time()) {
+		slots := statedb.AccessedSlots()
+		rawdb.WriteWarmSlots(batch, block.Hash(), block.NumberU64(), slots)
+		bc.reportWarmSlots(block, slots)
+	}
 	if err := batch.Write(); err != nil {
 		log.Crit("Failed to write block into disk", "err", err)
 	}
@@ -1728,6 +1911,13 @@ func (bc *BlockChain) writeBlockWithState(block *types.Block, receipts []*types.
 // writeBlockAndSetHead is the internal implementation of WriteBlockAndSetHead.
 // This function expects the chain mutex to be held.
 func (bc *BlockChain) writeBlockAndSetHead(block *types.Block, receipts []*types.Receipt, logs []*types.Log, state *state.StateDB, emitHeadEvent bool) (status WriteStatus, err error) {
+	// Collect the changes of watched slots, the state loses track of them once committed
+	watched := bc.watchpoints.changes(state)
+
+	var diff *StateDiffEvent
+	if bc.stateDiffSubs.Load() > 0 {
+		diff = &StateDiffEvent{Header: block.Header(), Accounts: state.StateDiff()}
+	}
 	if err := bc.writeBlockWithState(block, receipts, state); err != nil {
 		return NonStatTy, err
 	}
@@ -1752,6 +1942,12 @@ func (bc *BlockChain) writeBlockAndSetHead(block *types.Block, receipts []*types
 	if len(logs) > 0 {
 		bc.logsFeed.Send(logs)
 	}
+	if len(watched) > 0 {
+		bc.storageWatchFeed.Send(StorageWatchEvent{Header: block.Header(), Changes: watched})
+	}
+	if diff != nil {
+		bc.stateDiffFeed.Send(*diff)
+	}
 	// In theory, we should fire a ChainHeadEvent when we inject
 	// a canonical block, but sometimes we can insert a batch of
 	// canonical blocks. Avoid firing too many ChainHeadEvents,
@@ -1811,7 +2007,23 @@ func (bc *BlockChain) insertChain(chain types.Blocks, setHead bool, makeWitness
 	if bc.insertStopped() {
 		return nil, 0, nil
 	}
+	// Attribute the profiling samples of the import (and of the goroutines spawned
+	// by it) to the block import subsystem. The labels only apply for the duration
+	// of the import, pprof.Do reinstates the ones of the parent context afterwards.
+	var (
+		witness *stateless.Witness
+		n       int
+		err     error
+	)
+	pprof.Do(context.Background(), importProfileLabels, func(context.Context) {
+		witness, n, err = bc.insertBlocks(chain, setHead, makeWitness)
+	})
+	return witness, n, err
+}
 
+// insertBlocks is the body of insertChain, running with the import profiling
+// labels attached.
+func (bc *BlockChain) insertBlocks(chain types.Blocks, setHead bool, makeWitness bool) (*stateless.Witness, int, error) {
 	if atomic.AddInt32(&bc.blockProcCounter, 1) == 1 {
 		bc.blockProcFeed.Send(true)
 	}
@@ -1822,6 +2034,7 @@ func (bc *BlockChain) insertChain(chain types.Blocks, setHead bool, makeWitness
 	}()
 
 	// Start a parallel signature recovery (signer will fluke on fork transition, minimal perf loss)
+	bc.LoadSenders(chain)
 	SenderCacher().RecoverFromBlocks(types.MakeSigner(bc.chainConfig, chain[0].Number(), chain[0].Time()), chain)
 
 	var (
@@ -1969,6 +2182,7 @@ func (bc *BlockChain) insertChain(chain types.Blocks, setHead bool, makeWitness
 			return nil, it.index, err
 		}
 		res.stats.reportMetrics()
+		bc.stateStats.Add(block.Hash(), newStateReadStats(block, res.stats))
 
 		// Log slow block only if a single block is inserted (usually after the
 		// initial sync) to not overwhelm the users.
@@ -2058,6 +2272,16 @@ func (bc *BlockChain) ProcessBlock(parentRoot common.Hash, block *types.Block, s
 	)
 	defer interrupt.Store(true) // terminate the prefetch at the end
 
+	_, span := otlp.StartSpan(context.Background(), "chain.processBlock")
+	span.SetAttribute("number", block.NumberU64())
+	span.SetAttribute("hash", block.Hash().Hex())
+	span.SetAttribute("txs", len(block.Transactions()))
+	span.SetAttribute("gas_used", block.GasUsed())
+	defer func() {
+		span.SetError(blockEndErr)
+		span.End()
+	}()
+
 	if bc.cfg.NoPrefetch {
 		statedb, err = state.New(parentRoot, bc.statedb)
 		if err != nil {
@@ -2216,6 +2440,8 @@ func (bc *BlockChain) ProcessBlock(parentRoot common.Hash, block *types.Block, s
 	stats.StorageUpdated = int(statedb.StorageUpdated.Load())
 	stats.StorageDeleted = int(statedb.StorageDeleted.Load())
 	stats.CodeLoaded = statedb.CodeLoaded
+	stats.AccountCached = statedb.AccountCached
+	stats.StorageCached = statedb.StorageCached
 
 	stats.Execution = ptime - (statedb.AccountReads + statedb.StorageReads + statedb.CodeReads)          // The time spent on EVM processing
 	stats.Validation = vtime - (statedb.AccountHashes + statedb.AccountUpdates + statedb.StorageUpdates) // The time spent on block validation
@@ -2459,6 +2685,7 @@ func (bc *BlockChain) reorg(oldHead *types.Header, newHead *types.Header) error
 		newChain    []*types.Header
 		oldChain    []*types.Header
 		commonBlock *types.Header
+		newHeadHash = newHead.Hash()
 	)
 	// Reduce the longer chain to the same number as the shorter one
 	if oldHead.Number.Uint64() > newHead.Number.Uint64() {
@@ -2500,6 +2727,16 @@ func (bc *BlockChain) reorg(oldHead *types.Header, newHead *types.Header) error
 			return errInvalidNewChain
 		}
 	}
+	// Refuse to drop more blocks than allowed, unless the operator explicitly
+	// permitted a deep reorg to this head.
+	if limit := bc.cfg.MaxReorgDepth; limit > 0 && uint64(len(oldChain)) > limit {
+		if allowed := bc.deepReorgHead.Load(); allowed == nil || *allowed != newHeadHash {
+			log.Warn("Rejected deep chain reorg", "number", commonBlock.Number, "hash", commonBlock.Hash(),
+				"drop", len(oldChain), "limit", limit, "head", newHeadHash)
+			return fmt.Errorf("%w: dropping %d blocks, limit %d", ErrReorgTooDeep, len(oldChain), limit)
+		}
+		bc.deepReorgHead.Store(nil)
+	}
 	// Ensure the user sees large reorgs
 	if len(oldChain) > 0 && len(newChain) > 0 {
 		logFn := log.Info
@@ -2630,9 +2867,53 @@ func (bc *BlockChain) reorg(oldHead *types.Header, newHead *types.Header) error
 	// Release the tx-lookup lock after mutation.
 	bc.txLookupLock.Unlock()
 
+	if len(oldChain) > 0 && len(newChain) > 0 {
+		bc.journalReorg(commonBlock, oldChain, newChain)
+	}
 	return nil
 }
 
+// AllowDeepReorg permits the next reorg to the given head to exceed the
+// configured maximum reorg depth.
+func (bc *BlockChain) AllowDeepReorg(head common.Hash) {
+	bc.deepReorgHead.Store(&head)
+}
+
+// journalReorg records a reorg in the persistent reorg journal and notifies
+// the subscribers of reorg events.
+func (bc *BlockChain) journalReorg(ancestor *types.Header, oldChain, newChain []*types.Header) {
+	record := &rawdb.ReorgRecord{
+		Time:         uint64(time.Now().Unix()),
+		Depth:        uint64(len(oldChain)),
+		AncestorHash: ancestor.Hash(),
+		Ancestor:     ancestor.Number.Uint64(),
+		OldChain:     make([]common.Hash, len(oldChain)),
+		NewChain:     make([]common.Hash, len(newChain)),
+	}
+	var oldTxs, newTxs []common.Hash
+	for i, header := range oldChain {
+		record.OldChain[i] = header.Hash()
+		if body := bc.GetBody(header.Hash()); body != nil {
+			for _, tx := range body.Transactions {
+				oldTxs = append(oldTxs, tx.Hash())
+			}
+		}
+	}
+	for i, header := range newChain {
+		record.NewChain[i] = header.Hash()
+		if body := bc.GetBody(header.Hash()); body != nil {
+			for _, tx := range body.Transactions {
+				newTxs = append(newTxs, tx.Hash())
+			}
+		}
+	}
+	record.DroppedTxs = types.HashDifference(oldTxs, newTxs)
+	record.AddedTxs = types.HashDifference(newTxs, oldTxs)
+
+	rawdb.WriteReorgRecord(bc.db, record)
+	bc.reorgFeed.Send(ReorgEvent{Reorg: record})
+}
+
 // InsertBlockWithoutSetHead executes the block, runs the necessary verification
 // upon it and then persist the block and the associate state into the database.
 // The key difference between the InsertChain is it won't do the canonical chain
@@ -24,6 +24,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/consensus"
 	"github.com/ethereum/go-ethereum/consensus/misc/eip4844"
+	"github.com/ethereum/go-ethereum/core/history"
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/state"
 	"github.com/ethereum/go-ethereum/core/state/snapshot"
@@ -77,6 +78,12 @@ func (bc *BlockChain) GetHeader(hash common.Hash, number uint64) *types.Header {
 	return bc.hc.GetHeader(hash, number)
 }
 
+// WarmSlots retrieves the storage slots accessed by the execution of a block,
+// used by the experimental warm slots rules.
+func (bc *BlockChain) WarmSlots(hash common.Hash, number uint64) (types.AccessList, bool) {
+	return bc.hc.WarmSlots(hash, number)
+}
+
 // GetHeaderByHash retrieves a block header from the database by hash, caching it if
 // found.
 func (bc *BlockChain) GetHeaderByHash(hash common.Hash) *types.Header {
@@ -242,6 +249,16 @@ func (bc *BlockChain) GetCanonicalReceipt(tx *types.Transaction, blockHash commo
 	if err != nil {
 		return nil, err
 	}
+	if bc.cfg.VmConfig.RecordHaltReasons {
+		if reasons := rawdb.ReadHaltReasons(bc.db, blockHash, blockNumber); int(txIndex) < len(reasons) {
+			receipt.HaltReason = reasons[txIndex]
+		}
+	}
+	if bc.cfg.VmConfig.RecordRevertReasons {
+		if reasons := rawdb.ReadRevertReasons(bc.db, blockHash, blockNumber); int(txIndex) < len(reasons) {
+			receipt.RevertReason = reasons[txIndex]
+		}
+	}
 	signer := types.MakeSigner(bc.chainConfig, new(big.Int).SetUint64(blockNumber), header.Time)
 	receipt.DeriveFields(signer, types.DeriveReceiptContext{
 		BlockHash:    blockHash,
@@ -257,6 +274,14 @@ func (bc *BlockChain) GetCanonicalReceipt(tx *types.Transaction, blockHash commo
 	return receipt, nil
 }
 
+// StateReadStats returns the state read statistics gathered while importing
+// the block with the given hash. Only the recently imported blocks are tracked,
+// nil is returned for anything else.
+func (bc *BlockChain) StateReadStats(hash common.Hash) *StateReadStats {
+	stats, _ := bc.stateStats.Get(hash)
+	return stats
+}
+
 // GetReceiptsByHash retrieves the receipts for all transactions in a given block.
 func (bc *BlockChain) GetReceiptsByHash(hash common.Hash) types.Receipts {
 	if receipts, ok := bc.receiptsCache.Get(hash); ok {
@@ -274,10 +299,60 @@ func (bc *BlockChain) GetReceiptsByHash(hash common.Hash) types.Receipts {
 	if receipts == nil {
 		return nil
 	}
+	bc.setHaltReasons(hash, number, receipts)
+	bc.setRevertReasons(hash, number, receipts)
 	bc.receiptsCache.Add(hash, receipts)
 	return receipts
 }
 
+// GetReceiptsByBlock retrieves the receipts for all transactions in the given
+// block. The receipt metadata is derived from the provided block, avoiding a
+// second database read of its body and header.
+func (bc *BlockChain) GetReceiptsByBlock(block *types.Block) types.Receipts {
+	if receipts, ok := bc.receiptsCache.Get(block.Hash()); ok {
+		return receipts
+	}
+	receipts := rawdb.ReadBlockReceipts(bc.db, block, bc.chainConfig)
+	if receipts == nil {
+		return nil
+	}
+	bc.setHaltReasons(block.Hash(), block.NumberU64(), receipts)
+	bc.setRevertReasons(block.Hash(), block.NumberU64(), receipts)
+	bc.receiptsCache.Add(block.Hash(), receipts)
+	return receipts
+}
+
+// setHaltReasons fills in the halt reasons recorded for the failed transactions
+// of a block at import, if the halt reason recording is enabled. They are
+// dropped once the block is frozen.
+func (bc *BlockChain) setHaltReasons(hash common.Hash, number uint64, receipts types.Receipts) {
+	if !bc.cfg.VmConfig.RecordHaltReasons {
+		return
+	}
+	reasons := rawdb.ReadHaltReasons(bc.db, hash, number)
+	if len(reasons) != len(receipts) {
+		return
+	}
+	for i, receipt := range receipts {
+		receipt.HaltReason = reasons[i]
+	}
+}
+
+// setRevertReasons fills in the revert reasons recorded for the reverted
+// transactions of a block at import, if the revert reason recording is enabled.
+func (bc *BlockChain) setRevertReasons(hash common.Hash, number uint64, receipts types.Receipts) {
+	if !bc.cfg.VmConfig.RecordRevertReasons {
+		return
+	}
+	reasons := rawdb.ReadRevertReasons(bc.db, hash, number)
+	if len(reasons) != len(receipts) {
+		return
+	}
+	for i, receipt := range receipts {
+		receipt.RevertReason = reasons[i]
+	}
+}
+
 // GetRawReceipts retrieves the receipts for all transactions in a given block
 // without deriving the internal fields and the Bloom.
 func (bc *BlockChain) GetRawReceipts(hash common.Hash, number uint64) types.Receipts {
@@ -484,6 +559,13 @@ func (bc *BlockChain) HistoryPruningCutoff() (uint64, common.Hash) {
 	if pt == nil {
 		return 0, bc.genesisBlock.Hash()
 	}
+	if pt.BlockHash == (common.Hash{}) {
+		// A configured history start is not known by hash until synced.
+		if hash := rawdb.ReadCanonicalHash(bc.db, pt.BlockNumber); hash != (common.Hash{}) {
+			pt = &history.PrunePoint{BlockNumber: pt.BlockNumber, BlockHash: hash}
+			bc.historyPrunePoint.Store(pt)
+		}
+	}
 	return pt.BlockNumber, pt.BlockHash
 }
 
@@ -528,6 +610,34 @@ func (bc *BlockChain) SubscribeNewPayloadEvent(ch chan<- NewPayloadEvent) event.
 	return bc.scope.Track(bc.newPayloadFeed.Subscribe(ch))
 }
 
+// SubscribeStorageWatchEvent registers a subscription for StorageWatchEvent.
+func (bc *BlockChain) SubscribeStorageWatchEvent(ch chan<- StorageWatchEvent) event.Subscription {
+	return bc.scope.Track(bc.storageWatchFeed.Subscribe(ch))
+}
+
+// SubscribeStateDiffEvent registers a subscription for StateDiffEvent. State
+// diffs are only computed while there is at least one subscriber.
+func (bc *BlockChain) SubscribeStateDiffEvent(ch chan<- StateDiffEvent) event.Subscription {
+	bc.stateDiffSubs.Add(1)
+	sub := bc.scope.Track(bc.stateDiffFeed.Subscribe(ch))
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer bc.stateDiffSubs.Add(-1)
+		defer sub.Unsubscribe()
+
+		select {
+		case err := <-sub.Err():
+			return err
+		case <-quit:
+			return nil
+		}
+	})
+}
+
+// SubscribeReorgEvent registers a subscription for ReorgEvent.
+func (bc *BlockChain) SubscribeReorgEvent(ch chan<- ReorgEvent) event.Subscription {
+	return bc.scope.Track(bc.reorgFeed.Subscribe(ch))
+}
+
 // SendNewPayloadEvent sends a NewPayloadEvent to subscribers.
 func (bc *BlockChain) SendNewPayloadEvent(ev NewPayloadEvent) {
 	bc.newPayloadFeed.Send(ev)
@@ -46,6 +46,8 @@ type ExecuteStats struct {
 	StorageUpdated int // Number of storage slots updated
 	StorageDeleted int // Number of storage slots deleted
 	CodeLoaded     int // Number of contract code loaded
+	AccountCached  int // Number of account reads served from the state objects already loaded
+	StorageCached  int // Number of storage reads served from the storage slots already loaded
 
 	Execution       time.Duration // Time spent on the EVM execution
 	Validation      time.Duration // Time spent on the block validation
@@ -100,6 +102,66 @@ func (s *ExecuteStats) reportMetrics() {
 	accountCacheMissMeter.Mark(s.StateReadCacheStats.AccountCacheMiss)
 	storageCacheHitMeter.Mark(s.StateReadCacheStats.StorageCacheHit)
 	storageCacheMissMeter.Mark(s.StateReadCacheStats.StorageCacheMiss)
+
+	// Cold and warm state reads
+	accountColdReadMeter.Mark(int64(s.AccountLoaded))
+	accountWarmReadMeter.Mark(int64(s.AccountCached))
+	storageColdReadMeter.Mark(int64(s.StorageLoaded))
+	storageWarmReadMeter.Mark(int64(s.StorageCached))
+}
+
+// StateReadStats summarizes the state accesses performed while importing a
+// block. Cold reads are the ones resolved by the state reader, warm reads the
+// ones served by the accounts and slots already loaded during the block. The
+// cache hit ratios are the ones of the state reader cache, shared between the
+// block processor and the prefetcher; they are zero if prefetching is disabled.
+type StateReadStats struct {
+	Number uint64      `json:"number"`
+	Hash   common.Hash `json:"hash"`
+
+	AccountColdReads int `json:"accountColdReads"`
+	AccountWarmReads int `json:"accountWarmReads"`
+	StorageColdReads int `json:"storageColdReads"`
+	StorageWarmReads int `json:"storageWarmReads"`
+	CodeReads        int `json:"codeReads"`
+
+	AccountCacheHit      int64   `json:"accountCacheHit"`
+	AccountCacheMiss     int64   `json:"accountCacheMiss"`
+	AccountCacheHitRatio float64 `json:"accountCacheHitRatio"`
+	StorageCacheHit      int64   `json:"storageCacheHit"`
+	StorageCacheMiss     int64   `json:"storageCacheMiss"`
+	StorageCacheHitRatio float64 `json:"storageCacheHitRatio"`
+
+	AccountReadTime time.Duration `json:"accountReadTime"`
+	StorageReadTime time.Duration `json:"storageReadTime"`
+}
+
+// newStateReadStats extracts the state read statistics of an imported block.
+func newStateReadStats(block *types.Block, s *ExecuteStats) *StateReadStats {
+	ratio := func(hit, miss int64) float64 {
+		if hit+miss == 0 {
+			return 0
+		}
+		return float64(hit) / float64(hit+miss)
+	}
+	cache := s.StateReadCacheStats
+	return &StateReadStats{
+		Number:               block.NumberU64(),
+		Hash:                 block.Hash(),
+		AccountColdReads:     s.AccountLoaded,
+		AccountWarmReads:     s.AccountCached,
+		StorageColdReads:     s.StorageLoaded,
+		StorageWarmReads:     s.StorageCached,
+		CodeReads:            s.CodeLoaded,
+		AccountCacheHit:      cache.AccountCacheHit,
+		AccountCacheMiss:     cache.AccountCacheMiss,
+		AccountCacheHitRatio: ratio(cache.AccountCacheHit, cache.AccountCacheMiss),
+		StorageCacheHit:      cache.StorageCacheHit,
+		StorageCacheMiss:     cache.StorageCacheMiss,
+		StorageCacheHitRatio: ratio(cache.StorageCacheHit, cache.StorageCacheMiss),
+		AccountReadTime:      s.AccountReads,
+		StorageReadTime:      s.StorageReads,
+	}
 }
 
 // logSlow prints the detailed execution statistics if the block is regarded as slow.
@@ -4333,6 +4333,59 @@ func TestInsertChainWithCutoff(t *testing.T) {
 	})
 }
 
+// Tests that a chain configured with a custom history start resolves the hash of
+// the start block once synced, and refuses databases pruned differently.
+func TestHistoryStart(t *testing.T) {
+	const start = 32
+	var (
+		gspec = &Genesis{
+			Config:  params.TestChainConfig,
+			BaseFee: big.NewInt(params.InitialBaseFee),
+		}
+		engine = beacon.New(ethash.NewFaker())
+	)
+	_, blocks, receipts := GenerateChainWithGenesis(gspec, engine, 64, nil)
+
+	db, _ := rawdb.Open(rawdb.NewMemoryDatabase(), rawdb.OpenOptions{})
+	defer db.Close()
+
+	config := DefaultConfig().WithStateScheme(rawdb.PathScheme)
+	config.ChainHistoryStart = start
+	chain, err := NewBlockChain(db, gspec, engine, config)
+	if err != nil {
+		t.Fatalf("failed to create chain: %v", err)
+	}
+	if number, hash := chain.HistoryPruningCutoff(); number != start || hash != (common.Hash{}) {
+		t.Fatalf("unexpected cutoff before sync: %d %x", number, hash)
+	}
+	var headers []*types.Header
+	for _, block := range blocks[:start-1] {
+		headers = append(headers, block.Header())
+	}
+	if n, err := chain.InsertHeadersBeforeCutoff(headers); err != nil {
+		t.Fatalf("failed to insert headers before cutoff %d: %v", n, err)
+	}
+	if n, err := chain.InsertReceiptChain(blocks[start-1:], types.EncodeBlockReceiptLists(receipts[start-1:]), 64); err != nil {
+		t.Fatalf("failed to insert receipts %d: %v", n, err)
+	}
+	if number, hash := chain.HistoryPruningCutoff(); number != start || hash != blocks[start-1].Hash() {
+		t.Fatalf("unexpected cutoff after sync: %d %x", number, hash)
+	}
+	if chain.GetBody(blocks[start-2].Hash()) != nil {
+		t.Fatal("body before history start is stored")
+	}
+	if chain.GetBody(blocks[start-1].Hash()) == nil {
+		t.Fatal("body at history start is missing")
+	}
+	chain.Stop()
+
+	// Reopening with a different history start must fail.
+	config.ChainHistoryStart = start + 1
+	if _, err := NewBlockChain(db, gspec, engine, config); err == nil {
+		t.Fatal("chain opened with mismatching history start")
+	}
+}
+
 func testInsertChainWithCutoff(t *testing.T, cutoff uint64, ancientLimit uint64, genesis *Genesis, blocks []*types.Block, receipts []types.Receipts) {
 	// log.SetDefault(log.NewLogger(log.NewTerminalHandlerWithLevel(os.Stderr, log.LevelDebug, true)))
 
@@ -4557,3 +4610,219 @@ func TestSetHeadBeyondRootFinalizedBug(t *testing.T) {
 			currentFinal.Number.Uint64())
 	}
 }
+
+func TestPurgeStorage(t *testing.T) {
+	testPurgeStorage(t, rawdb.HashScheme)
+	testPurgeStorage(t, rawdb.PathScheme)
+}
+
+func testPurgeStorage(t *testing.T, scheme string) {
+	var (
+		live   = common.HexToAddress("0xaaaa")
+		orphan = common.HexToAddress("0xbbbb")
+		slot   = common.Hash{0x01}
+		gspec  = &Genesis{
+			Config: params.TestChainConfig,
+			Alloc: types.GenesisAlloc{
+				live: {Balance: big.NewInt(1), Code: []byte{0x00}, Storage: map[common.Hash]common.Hash{slot: {0x01}}},
+			},
+		}
+		db = rawdb.NewMemoryDatabase()
+	)
+	chain, err := NewBlockChain(db, gspec, ethash.NewFaker(), DefaultConfig().WithStateScheme(scheme))
+	if err != nil {
+		t.Fatalf("failed to create chain: %v", err)
+	}
+	defer chain.Stop()
+
+	// Accounts with storage in the current state must not be purged
+	if err := chain.PurgeStorage(live); err == nil {
+		t.Fatalf("%s: purged live storage", scheme)
+	}
+	// Leave some storage behind for a non-existent account and purge it
+	orphanHash := crypto.Keccak256Hash(orphan.Bytes())
+	rawdb.WriteStorageTrieNode(db, orphanHash, []byte{0x01}, []byte{0xff})
+	rawdb.WriteStorageSnapshot(db, orphanHash, slot, []byte{0x01})
+
+	if err := chain.PurgeStorage(orphan); err != nil {
+		t.Fatalf("%s: failed to purge storage: %v", scheme, err)
+	}
+	if blob := rawdb.ReadStorageSnapshot(db, orphanHash, slot); len(blob) != 0 {
+		t.Errorf("%s: storage snapshot not purged", scheme)
+	}
+	if blob := rawdb.ReadStorageTrieNode(db, orphanHash, []byte{0x01}); scheme == rawdb.PathScheme && len(blob) != 0 {
+		t.Errorf("%s: storage trie not purged", scheme)
+	}
+	// The live storage must be untouched
+	statedb, err := chain.State()
+	if err != nil {
+		t.Fatalf("%s: failed to open state: %v", scheme, err)
+	}
+	if have := statedb.GetState(live, slot); have != (common.Hash{0x01}) {
+		t.Errorf("%s: live storage mismatch: have %x, want %x", scheme, have, common.Hash{0x01})
+	}
+}
+
+// Tests that reorgs are recorded in the reorg journal and announced to the
+// reorg event subscribers.
+func TestReorgJournal(t *testing.T) {
+	var (
+		engine  = ethash.NewFaker()
+		genesis = &Genesis{
+			BaseFee: big.NewInt(params.InitialBaseFee),
+			Config:  params.AllEthashProtocolChanges,
+		}
+		genDb, blocks = makeBlockChainWithGenesis(genesis, 5, engine, 1)
+		db            = rawdb.NewMemoryDatabase()
+	)
+	chain, err := NewBlockChain(db, genesis, engine, DefaultConfig())
+	if err != nil {
+		t.Fatalf("failed to create chain: %v", err)
+	}
+	defer chain.Stop()
+
+	reorgs := make(chan ReorgEvent, 1)
+	sub := chain.SubscribeReorgEvent(reorgs)
+	defer sub.Unsubscribe()
+
+	if _, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("failed to insert blocks: %v", err)
+	}
+	if journal := rawdb.ReadReorgJournal(db); len(journal) != 0 {
+		t.Fatalf("unexpected reorgs recorded: %d", len(journal))
+	}
+	// Reorg to a longer fork branching off at block #2
+	fork := makeBlockChain(genesis.Config, blocks[1], 4, engine, genDb, 2)
+	if _, err := chain.InsertChain(fork); err != nil {
+		t.Fatalf("failed to insert fork: %v", err)
+	}
+	journal := rawdb.ReadReorgJournal(db)
+	if len(journal) != 1 {
+		t.Fatalf("wrong number of recorded reorgs: have %d, want 1", len(journal))
+	}
+	reorg := journal[0]
+	if reorg.Ancestor != 2 || reorg.AncestorHash != blocks[1].Hash() || reorg.Depth != 3 {
+		t.Fatalf("wrong reorg record: ancestor #%d %x, depth %d", reorg.Ancestor, reorg.AncestorHash, reorg.Depth)
+	}
+	if len(reorg.OldChain) != 3 || reorg.OldChain[0] != blocks[4].Hash() {
+		t.Fatalf("wrong old chain: %v", reorg.OldChain)
+	}
+	if n := len(reorg.NewChain); n == 0 || reorg.NewChain[n-1] != fork[0].Hash() {
+		t.Fatalf("wrong new chain: %v", reorg.NewChain)
+	}
+	select {
+	case ev := <-reorgs:
+		if ev.Reorg.AncestorHash != reorg.AncestorHash {
+			t.Fatalf("wrong reorg event: %+v", ev.Reorg)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("no reorg event")
+	}
+}
+
+// Tests that reorgs deeper than the configured maximum are rejected unless
+// explicitly allowed.
+func TestMaxReorgDepth(t *testing.T) {
+	var (
+		engine  = ethash.NewFaker()
+		genesis = &Genesis{
+			BaseFee: big.NewInt(params.InitialBaseFee),
+			Config:  params.AllEthashProtocolChanges,
+		}
+		genDb, blocks = makeBlockChainWithGenesis(genesis, 5, engine, 1)
+		fork          = makeBlockChain(genesis.Config, blocks[1], 4, engine, genDb, 2)
+		options       = DefaultConfig()
+	)
+	options.MaxReorgDepth = 2
+	chain, err := NewBlockChain(rawdb.NewMemoryDatabase(), genesis, engine, options)
+	if err != nil {
+		t.Fatalf("failed to create chain: %v", err)
+	}
+	defer chain.Stop()
+
+	if _, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("failed to insert blocks: %v", err)
+	}
+	for _, block := range fork {
+		if _, err := chain.InsertBlockWithoutSetHead(block, false); err != nil {
+			t.Fatalf("failed to insert fork block %d: %v", block.NumberU64(), err)
+		}
+	}
+	head := fork[len(fork)-1]
+	if _, err := chain.SetCanonical(head); !errors.Is(err, ErrReorgTooDeep) {
+		t.Fatalf("deep reorg not rejected: %v", err)
+	}
+	if chain.CurrentBlock().Hash() != blocks[4].Hash() {
+		t.Fatal("head changed by rejected reorg")
+	}
+	chain.AllowDeepReorg(head.Hash())
+	if _, err := chain.SetCanonical(head); err != nil {
+		t.Fatalf("allowed reorg failed: %v", err)
+	}
+	if chain.CurrentBlock().Hash() != head.Hash() {
+		t.Fatal("head not updated by allowed reorg")
+	}
+}
+
+// Tests that transaction senders are persisted on import when the sender cache
+// is enabled, and that they are used instead of recovering the signatures when
+// blocks are reprocessed.
+func TestSenderCache(t *testing.T) {
+	var (
+		engine  = ethash.NewFaker()
+		key, _  = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		addr    = crypto.PubkeyToAddress(key.PublicKey)
+		genesis = &Genesis{
+			Config:  params.TestChainConfig,
+			BaseFee: big.NewInt(params.InitialBaseFee),
+			Alloc:   types.GenesisAlloc{addr: {Balance: big.NewInt(params.Ether)}},
+		}
+		signer = types.LatestSigner(genesis.Config)
+	)
+	_, blocks, _ := GenerateChainWithGenesis(genesis, engine, 3, func(i int, b *BlockGen) {
+		tx, _ := types.SignTx(types.NewTransaction(b.TxNonce(addr), common.Address{0x01}, big.NewInt(1000), params.TxGas, b.header.BaseFee, nil), signer, key)
+		b.AddTx(tx)
+	})
+	// Without the cache enabled, nothing is persisted
+	db := rawdb.NewMemoryDatabase()
+	chain, err := NewBlockChain(db, genesis, engine, DefaultConfig())
+	if err != nil {
+		t.Fatalf("failed to create chain: %v", err)
+	}
+	if _, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("failed to insert blocks: %v", err)
+	}
+	chain.Stop()
+	if _, ok := rawdb.ReadTxSender(db, blocks[0].Transactions()[0].Hash()); ok {
+		t.Fatal("sender persisted with the cache disabled")
+	}
+	// With the cache enabled, every sender is persisted
+	db = rawdb.NewMemoryDatabase()
+	config := DefaultConfig()
+	config.SenderCache = true
+	chain, err = NewBlockChain(db, genesis, engine, config)
+	if err != nil {
+		t.Fatalf("failed to create chain: %v", err)
+	}
+	defer chain.Stop()
+	if _, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("failed to insert blocks: %v", err)
+	}
+	for _, block := range blocks {
+		for _, tx := range block.Transactions() {
+			if from, ok := rawdb.ReadTxSender(db, tx.Hash()); !ok || from != addr {
+				t.Fatalf("tx %x: wrong persisted sender: have %x (%v), want %x", tx.Hash(), from, ok, addr)
+			}
+		}
+	}
+	// Reprocessing a freshly decoded block must use the persisted sender
+	block := rawdb.ReadBlock(db, blocks[1].Hash(), blocks[1].NumberU64())
+	tx := block.Transactions()[0]
+	bogus := common.Address{0xde, 0xad}
+	rawdb.WriteTxSender(db, tx.Hash(), bogus)
+
+	chain.LoadSenders([]*types.Block{block})
+	if from, _ := types.Sender(types.MakeSigner(genesis.Config, block.Number(), block.Time()), tx); from != bogus {
+		t.Fatalf("persisted sender not used: have %x, want %x", from, bogus)
+	}
+}
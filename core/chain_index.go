@@ -0,0 +1,152 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// indexRefreshInterval is the interval at which the head of the indexes is
+// refreshed even without head events, e.g. to pick up rewinds.
+const indexRefreshInterval = 10 * time.Second
+
+// ChainIndex is an index maintained alongside the chain, such as the transaction
+// or the log index. The indexes are registered with the blockchain, which tracks
+// the chain head for all of them and collects their progress.
+type ChainIndex interface {
+	// Name returns the identifier of the index in the status reports.
+	Name() string
+
+	// SetHead notifies the index about a new canonical chain head. Indexing
+	// happens in the background, so SetHead must not block.
+	SetHead(head *types.Header)
+
+	// Status returns the progress of the index.
+	Status() IndexStatus
+}
+
+// blockProcessingIndex is implemented by indexes suspending their work while
+// blocks are being processed.
+type blockProcessingIndex interface {
+	SetBlockProcessing(processing bool)
+}
+
+// IndexStatus is the progress of a chain index.
+type IndexStatus struct {
+	Head      uint64  `json:"head"`      // Chain head the index is tracking
+	Tail      *uint64 `json:"tail"`      // First indexed block, nil if nothing is indexed
+	Indexed   uint64  `json:"indexed"`   // Number of indexed blocks
+	Remaining uint64  `json:"remaining"` // Number of blocks left to index, or to backfill
+}
+
+// Done returns an indicator if the index caught up with the chain.
+func (s IndexStatus) Done() bool {
+	return s.Remaining == 0
+}
+
+// RegisterIndex adds an index to the ones following the chain head. The index
+// is notified about the current head right away.
+func (bc *BlockChain) RegisterIndex(index ChainIndex) {
+	bc.indexesMu.Lock()
+	bc.indexes = append(bc.indexes, index)
+	bc.indexesMu.Unlock()
+
+	if head := bc.CurrentBlock(); head != nil {
+		index.SetHead(head)
+	}
+}
+
+// IndexStatus returns the progress of the registered indexes by name.
+func (bc *BlockChain) IndexStatus() map[string]IndexStatus {
+	bc.indexesMu.RLock()
+	defer bc.indexesMu.RUnlock()
+
+	status := make(map[string]IndexStatus, len(bc.indexes))
+	for _, index := range bc.indexes {
+		status[index.Name()] = index.Status()
+	}
+	return status
+}
+
+// registeredIndexes returns the currently registered indexes.
+func (bc *BlockChain) registeredIndexes() []ChainIndex {
+	bc.indexesMu.RLock()
+	defer bc.indexesMu.RUnlock()
+
+	return bc.indexes
+}
+
+// indexLoop forwards the chain head and the block processing status to the
+// registered indexes, until the blockchain is stopped.
+func (bc *BlockChain) indexLoop() {
+	var (
+		headCh  = make(chan ChainHeadEvent, 10)
+		procCh  = make(chan bool, 10)
+		headSub = bc.SubscribeChainHeadEvent(headCh)
+		procSub = bc.SubscribeBlockProcessingEvent(procCh)
+	)
+	// The subscriptions are nil if the blockchain was stopped already.
+	if headSub == nil || procSub == nil {
+		if headSub != nil {
+			headSub.Unsubscribe()
+		}
+		if procSub != nil {
+			procSub.Unsubscribe()
+		}
+		return
+	}
+	defer headSub.Unsubscribe()
+	defer procSub.Unsubscribe()
+
+	refresh := time.NewTicker(indexRefreshInterval)
+	defer refresh.Stop()
+
+	var last common.Hash
+	setHead := func(head *types.Header) {
+		if head == nil || head.Hash() == last {
+			return
+		}
+		last = head.Hash()
+		for _, index := range bc.registeredIndexes() {
+			index.SetHead(head)
+		}
+	}
+	for {
+		select {
+		case ev := <-headCh:
+			setHead(ev.Header)
+
+		case processing := <-procCh:
+			for _, index := range bc.registeredIndexes() {
+				if index, ok := index.(blockProcessingIndex); ok {
+					index.SetBlockProcessing(processing)
+				}
+			}
+
+		case <-refresh.C:
+			setHead(bc.CurrentBlock())
+
+		case <-headSub.Err():
+			return
+		case <-procSub.Err():
+			return
+		}
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// testIndex is a chain index recording the heads it's notified about.
+type testIndex struct {
+	heads chan uint64
+}
+
+func (idx *testIndex) Name() string { return "test" }
+
+func (idx *testIndex) SetHead(head *types.Header) { idx.heads <- head.Number.Uint64() }
+
+func (idx *testIndex) Status() IndexStatus { return IndexStatus{Remaining: 1} }
+
+func TestChainIndexHeads(t *testing.T) {
+	gspec := &Genesis{Config: params.TestChainConfig}
+	_, blocks, _ := GenerateChainWithGenesis(gspec, ethash.NewFaker(), 8, nil)
+
+	options := DefaultConfig()
+	options.TxLookupLimit = 0
+	chain, err := NewBlockChain(rawdb.NewMemoryDatabase(), gspec, ethash.NewFaker(), options)
+	if err != nil {
+		t.Fatalf("failed to create chain: %v", err)
+	}
+	defer chain.Stop()
+
+	index := &testIndex{heads: make(chan uint64, 16)}
+	chain.RegisterIndex(index)
+	if head := <-index.heads; head != 0 {
+		t.Fatalf("wrong head at registration: have %d, want 0", head)
+	}
+	if _, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("failed to insert chain: %v", err)
+	}
+	select {
+	case head := <-index.heads:
+		if head != 8 {
+			t.Fatalf("wrong head after import: have %d, want 8", head)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("index not notified about the new head")
+	}
+	status := chain.IndexStatus()
+	if _, ok := status["transactions"]; !ok {
+		t.Error("missing transaction index status")
+	}
+	if s, ok := status["test"]; !ok || s.Done() {
+		t.Errorf("wrong test index status: %+v", s)
+	}
+}
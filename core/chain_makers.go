@@ -22,7 +22,6 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/consensus"
-	"github.com/ethereum/go-ethereum/consensus/misc"
 	"github.com/ethereum/go-ethereum/consensus/misc/eip1559"
 	"github.com/ethereum/go-ethereum/consensus/misc/eip4844"
 	"github.com/ethereum/go-ethereum/core/rawdb"
@@ -49,6 +48,7 @@ type BlockGen struct {
 	receipts    []*types.Receipt
 	uncles      []*types.Header
 	withdrawals []*types.Withdrawal
+	preBlock    bool // Whether the pre-block system actions were executed
 
 	engine consensus.Engine
 }
@@ -94,11 +94,37 @@ func (b *BlockGen) Difficulty() *big.Int {
 }
 
 // SetParentBeaconRoot sets the parent beacon root field of the generated
-// block.
+// block. It must be called before adding transactions.
 func (b *BlockGen) SetParentBeaconRoot(root common.Hash) {
+	if b.preBlock {
+		panic("parent beacon root must be set before adding transactions")
+	}
 	b.header.ParentBeaconRoot = &root
-	blockContext := NewEVMBlockContext(b.header, b.cm, &b.header.Coinbase)
-	ProcessBeaconBlockRoot(root, vm.NewEVM(blockContext, b.statedb, b.cm.config, vm.Config{}))
+}
+
+// evmBlockContext creates the EVM context of the generated block.
+func (b *BlockGen) evmBlockContext(chain ChainContext) vm.BlockContext {
+	blockContext, err := NewEVMBlockContext(b.header, chain, &b.header.Coinbase)
+	if err != nil {
+		panic(err)
+	}
+	return blockContext
+}
+
+// applyPreBlockActions executes the system actions due before the transactions
+// of the block, unless they were already executed. They're deferred until the
+// first transaction, so that they see the header fields set by the generator.
+func (b *BlockGen) applyPreBlockActions() {
+	if b.preBlock {
+		return
+	}
+	b.preBlock = true
+
+	blockContext := b.evmBlockContext(b.cm)
+	blockContext.Random = &common.Hash{} // enable post-merge instruction set
+	if err := ProcessPreBlockActions(b.header, vm.NewEVM(blockContext, b.statedb, b.cm.config, vm.Config{})); err != nil {
+		panic(err)
+	}
 }
 
 // addTx adds a transaction to the generated block. If no coinbase has
@@ -112,8 +138,15 @@ func (b *BlockGen) addTx(bc *BlockChain, vmConfig vm.Config, tx *types.Transacti
 	if b.gasPool == nil {
 		b.SetCoinbase(common.Address{})
 	}
+	b.applyPreBlockActions()
+
+	// Without a chain, BLOCKHASH and the warm slots resolve from the generated blocks
+	chain := ChainContext(b.cm)
+	if bc != nil {
+		chain = bc
+	}
 	var (
-		blockContext = NewEVMBlockContext(b.header, bc, &b.header.Coinbase)
+		blockContext = b.evmBlockContext(chain)
 		evm          = vm.NewEVM(blockContext, b.statedb, b.cm.config, vmConfig)
 	)
 	b.statedb.SetTxContext(tx.Hash(), len(b.txs))
@@ -305,6 +338,8 @@ func (b *BlockGen) ConsensusLayerRequests() [][]byte {
 }
 
 func (b *BlockGen) collectRequests(readonly bool) (requests [][]byte) {
+	b.applyPreBlockActions()
+
 	statedb := b.statedb
 	if readonly {
 		// The system contracts clear themselves on a system-initiated read.
@@ -313,27 +348,14 @@ func (b *BlockGen) collectRequests(readonly bool) (requests [][]byte) {
 		statedb = statedb.Copy()
 	}
 
-	if b.cm.config.IsPrague(b.header.Number, b.header.Time) {
-		requests = [][]byte{}
-		// EIP-6110 deposits
-		var blockLogs []*types.Log
-		for _, r := range b.receipts {
-			blockLogs = append(blockLogs, r.Logs...)
-		}
-		if err := ParseDepositLogs(&requests, blockLogs, b.cm.config); err != nil {
-			panic(fmt.Sprintf("failed to parse deposit log: %v", err))
-		}
-		// create EVM for system calls
-		blockContext := NewEVMBlockContext(b.header, b.cm, &b.header.Coinbase)
-		evm := vm.NewEVM(blockContext, statedb, b.cm.config, vm.Config{})
-		// EIP-7002
-		if err := ProcessWithdrawalQueue(&requests, evm); err != nil {
-			panic(fmt.Sprintf("could not process withdrawal requests: %v", err))
-		}
-		// EIP-7251
-		if err := ProcessConsolidationQueue(&requests, evm); err != nil {
-			panic(fmt.Sprintf("could not process consolidation requests: %v", err))
-		}
+	var blockLogs []*types.Log
+	for _, r := range b.receipts {
+		blockLogs = append(blockLogs, r.Logs...)
+	}
+	blockContext := b.evmBlockContext(b.cm)
+	requests, err := ProcessPostBlockActions(b.header, blockLogs, vm.NewEVM(blockContext, statedb, b.cm.config, vm.Config{}))
+	if err != nil {
+		panic(fmt.Sprintf("could not collect requests: %v", err))
 	}
 	return requests
 }
@@ -385,18 +407,6 @@ func GenerateChain(config *params.ChainConfig, parent *types.Block, engine conse
 				}
 			}
 		}
-		if config.DAOForkSupport && config.DAOForkBlock != nil && config.DAOForkBlock.Cmp(b.header.Number) == 0 {
-			misc.ApplyDAOHardFork(statedb)
-		}
-
-		if config.IsPrague(b.header.Number, b.header.Time) || config.IsVerkle(b.header.Number, b.header.Time) {
-			// EIP-2935
-			blockContext := NewEVMBlockContext(b.header, cm, &b.header.Coinbase)
-			blockContext.Random = &common.Hash{} // enable post-merge instruction set
-			evm := vm.NewEVM(blockContext, statedb, cm.config, vm.Config{})
-			ProcessParentBlockHash(b.header.ParentHash, evm)
-		}
-
 		// Execute any user modifications to the block
 		if gen != nil {
 			gen(i, b)
@@ -413,6 +423,9 @@ func GenerateChain(config *params.ChainConfig, parent *types.Block, engine conse
 		if err != nil {
 			panic(err)
 		}
+		if config.IsWarmSlots(b.header.Number, b.header.Time) {
+			cm.warmSlots[block.Hash()] = statedb.AccessedSlots()
+		}
 
 		// Write state changes to db
 		root, err := statedb.Commit(b.header.Number.Uint64(), config.IsEIP158(b.header.Number), config.IsCancun(b.header.Number, b.header.Time))
@@ -562,6 +575,7 @@ type chainMaker struct {
 	chain       []*types.Block
 	chainByHash map[common.Hash]*types.Block
 	receipts    []types.Receipts
+	warmSlots   map[common.Hash]types.AccessList
 }
 
 func newChainMaker(bottom *types.Block, config *params.ChainConfig, engine consensus.Engine) *chainMaker {
@@ -570,6 +584,7 @@ func newChainMaker(bottom *types.Block, config *params.ChainConfig, engine conse
 		config:      config,
 		engine:      engine,
 		chainByHash: make(map[common.Hash]*types.Block),
+		warmSlots:   make(map[common.Hash]types.AccessList),
 	}
 }
 
@@ -633,3 +648,9 @@ func (cm *chainMaker) GetHeader(hash common.Hash, number uint64) *types.Header {
 func (cm *chainMaker) GetBlock(hash common.Hash, number uint64) *types.Block {
 	return cm.blockByNumber(number)
 }
+
+// WarmSlots returns the storage slots accessed by a generated block.
+func (cm *chainMaker) WarmSlots(hash common.Hash, number uint64) (types.AccessList, bool) {
+	slots, ok := cm.warmSlots[hash]
+	return slots, ok
+}
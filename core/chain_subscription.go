@@ -0,0 +1,165 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"sync/atomic"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/metrics"
+)
+
+// ChainSubscription is a subscription to the canonical chain which delivers
+// every canonical header exactly once and in order, starting at a requested
+// block number.
+//
+// Unlike the plain event feeds, a slow consumer neither blocks block import nor
+// loses events: the subscription only remembers the last header it delivered
+// and reads anything newer from the database once the consumer is ready for
+// it. The memory used is therefore bounded regardless of how far the consumer
+// falls behind, and its lag is exported as a metric.
+type ChainSubscription struct {
+	bc  *BlockChain
+	ch  chan<- CanonicalChainEvent
+	sub event.Subscription
+
+	next     uint64      // Number of the next header to deliver
+	lastHash common.Hash // Hash of the last delivered header, used to detect reorgs
+
+	lag      atomic.Uint64
+	lagGauge *metrics.Gauge
+	lagName  string
+}
+
+// SubscribeCanonicalChain registers a subscription which replays the canonical
+// chain from the given block number and then follows the head. The name is used
+// to label the consumer lag metric and should be unique per consumer.
+func (bc *BlockChain) SubscribeCanonicalChain(name string, from uint64, ch chan<- CanonicalChainEvent) *ChainSubscription {
+	s := &ChainSubscription{
+		bc:      bc,
+		ch:      ch,
+		next:    from,
+		lagName: "chain/subscription/" + name + "/lag",
+	}
+	s.lagGauge = metrics.GetOrRegisterGauge(s.lagName, nil)
+
+	headCh := make(chan ChainHeadEvent, 1)
+	headSub := bc.SubscribeChainHeadEvent(headCh)
+	s.sub = bc.scope.Track(event.NewSubscription(func(quit <-chan struct{}) error {
+		defer metrics.Unregister(s.lagName)
+		defer headSub.Unsubscribe()
+		return s.loop(headCh, headSub, quit)
+	}))
+	return s
+}
+
+// Unsubscribe stops delivery of events. The events channel is not closed.
+func (s *ChainSubscription) Unsubscribe() {
+	s.sub.Unsubscribe()
+}
+
+// Err returns the subscription error channel, see event.Subscription.
+func (s *ChainSubscription) Err() <-chan error {
+	return s.sub.Err()
+}
+
+// Lag returns the number of canonical blocks the consumer is behind the head.
+func (s *ChainSubscription) Lag() uint64 {
+	return s.lag.Load()
+}
+
+// loop delivers canonical headers until the subscription is closed. Head events
+// are only used as a wakeup signal; the headers themselves are always read from
+// the database so nothing is lost if several heads arrive while the consumer is
+// busy.
+func (s *ChainSubscription) loop(heads <-chan ChainHeadEvent, headSub event.Subscription, quit <-chan struct{}) error {
+	for {
+		for {
+			ev, ok := s.pending()
+			if !ok {
+				break
+			}
+		deliver:
+			for {
+				select {
+				case s.ch <- ev:
+					break deliver
+				case <-heads:
+					// Drain head events so block import never waits on us,
+					// the new head is picked up after this delivery.
+				case err := <-headSub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			}
+			s.next = ev.Header.Number.Uint64() + 1
+			s.lastHash = ev.Header.Hash()
+			s.updateLag()
+		}
+		select {
+		case <-heads:
+		case err := <-headSub.Err():
+			return err
+		case <-quit:
+			return nil
+		}
+	}
+}
+
+// pending returns the next canonical header to deliver, rewinding to the
+// common ancestor first if the previously delivered header was reorged out.
+func (s *ChainSubscription) pending() (CanonicalChainEvent, bool) {
+	head := s.bc.CurrentBlock()
+	if head == nil || s.next > head.Number.Uint64() {
+		s.updateLag()
+		return CanonicalChainEvent{}, false
+	}
+	header := s.bc.GetHeaderByNumber(s.next)
+	if header == nil {
+		return CanonicalChainEvent{}, false
+	}
+	// Nothing delivered yet, or the chain simply extends what was delivered.
+	if s.lastHash == (common.Hash{}) || header.ParentHash == s.lastHash {
+		return CanonicalChainEvent{Header: header}, true
+	}
+	// The last delivered header is no longer canonical, walk back along the
+	// delivered side chain until it meets the canonical one.
+	number, hash := s.next-1, s.lastHash
+	for number > 0 && s.bc.GetCanonicalHash(number) != hash {
+		old := s.bc.GetHeader(hash, number)
+		if old == nil {
+			break
+		}
+		number, hash = number-1, old.ParentHash
+	}
+	header = s.bc.GetHeaderByNumber(number + 1)
+	if header == nil {
+		return CanonicalChainEvent{}, false
+	}
+	return CanonicalChainEvent{Header: header, Reorg: true}, true
+}
+
+func (s *ChainSubscription) updateLag() {
+	var lag uint64
+	if head := s.bc.CurrentBlock(); head != nil && head.Number.Uint64() >= s.next {
+		lag = head.Number.Uint64() - s.next + 1
+	}
+	s.lag.Store(lag)
+	s.lagGauge.Update(int64(lag))
+}
@@ -0,0 +1,94 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// Tests that a canonical chain subscription replays from the requested block,
+// does not block import while the consumer is idle, and rewinds on reorgs.
+func TestChainSubscription(t *testing.T) {
+	var (
+		engine  = ethash.NewFaker()
+		genesis = &Genesis{
+			BaseFee: big.NewInt(params.InitialBaseFee),
+			Config:  params.AllEthashProtocolChanges,
+		}
+		genDb, blocks = makeBlockChainWithGenesis(genesis, 8, engine, 1)
+	)
+	chain, err := NewBlockChain(rawdb.NewMemoryDatabase(), genesis, engine, DefaultConfig())
+	if err != nil {
+		t.Fatalf("failed to create chain: %v", err)
+	}
+	defer chain.Stop()
+
+	if _, err := chain.InsertChain(blocks[:5]); err != nil {
+		t.Fatalf("failed to insert blocks: %v", err)
+	}
+	ch := make(chan CanonicalChainEvent)
+	sub := chain.SubscribeCanonicalChain("test", 2, ch)
+	defer sub.Unsubscribe()
+
+	// Import more blocks while nobody reads from the subscription.
+	done := make(chan error)
+	go func() {
+		_, err := chain.InsertChain(blocks[5:])
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("failed to insert blocks: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("block import blocked on idle subscriber")
+	}
+	expect := func(want *types.Block, reorg bool) {
+		t.Helper()
+		select {
+		case ev := <-ch:
+			if ev.Header.Hash() != want.Hash() || ev.Reorg != reorg {
+				t.Fatalf("wrong event: have #%d reorg=%v, want #%d reorg=%v", ev.Header.Number, ev.Reorg, want.NumberU64(), reorg)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timeout waiting for block #%d", want.NumberU64())
+		}
+	}
+	for _, block := range blocks[1:] {
+		expect(block, false)
+	}
+	// Reorg to a longer fork branching off at block #4.
+	fork := makeBlockChain(genesis.Config, blocks[3], 6, engine, genDb, 2)
+	if _, err := chain.InsertChain(fork); err != nil {
+		t.Fatalf("failed to insert fork: %v", err)
+	}
+	expect(fork[0], true)
+	for _, block := range fork[1:] {
+		expect(block, false)
+	}
+	if lag := sub.Lag(); lag != 0 {
+		t.Fatalf("wrong lag: have %d, want 0", lag)
+	}
+}
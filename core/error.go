@@ -32,6 +32,14 @@ var (
 	// ErrBlockOversized is returned if the size of the RLP-encoded block
 	// exceeds the cap established by EIP 7934
 	ErrBlockOversized = errors.New("block RLP-encoded size exceeds maximum")
+
+	// ErrMissingWarmSlots is returned if a block is processed under the warm
+	// slots rules, but the storage slots accessed by its parent are unknown.
+	ErrMissingWarmSlots = errors.New("missing warm slots of parent block")
+
+	// ErrReorgTooDeep is returned if a head update would drop more canonical
+	// blocks than the configured maximum reorg depth.
+	ErrReorgTooDeep = errors.New("reorg exceeds maximum depth")
 )
 
 // List of evm-call-message pre-checking errors. All state transition messages will
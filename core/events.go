@@ -20,6 +20,8 @@ import (
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
 	"github.com/ethereum/go-ethereum/core/types"
 )
 
@@ -39,9 +41,37 @@ type ChainHeadEvent struct {
 	Header *types.Header
 }
 
+// StorageWatchEvent is posted when a canonical block modifies watched storage slots.
+type StorageWatchEvent struct {
+	Header  *types.Header
+	Changes []state.StorageChange
+}
+
+// StateDiffEvent is posted when a canonical block is imported, carrying the
+// accounts and storage slots it modified.
+type StateDiffEvent struct {
+	Header   *types.Header
+	Accounts []state.AccountDiff
+}
+
 // NewPayloadEvent is posted when engine_newPayloadVX processes a block.
 type NewPayloadEvent struct {
 	Hash           common.Hash
 	Number         uint64
 	ProcessingTime time.Duration
 }
+
+// CanonicalChainEvent is delivered by a ChainSubscription for every canonical
+// header in order. Reorg is set on the first header following a rewind to the
+// common ancestor of a reorg; consumers should discard anything they derived
+// from headers at or above its number.
+type CanonicalChainEvent struct {
+	Header *types.Header
+	Reorg  bool
+}
+
+// ReorgEvent is posted after a reorg of the canonical chain was recorded in the
+// reorg journal.
+type ReorgEvent struct {
+	Reorg *rawdb.ReorgRecord
+}
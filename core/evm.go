@@ -17,6 +17,7 @@
 package core
 
 import (
+	"fmt"
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -37,8 +38,11 @@ type ChainContext interface {
 	Engine() consensus.Engine
 }
 
-// NewEVMBlockContext creates a new context for use in the EVM.
-func NewEVMBlockContext(header *types.Header, chain ChainContext, author *common.Address) vm.BlockContext {
+// NewEVMBlockContext creates a new context for use in the EVM. It fails if the
+// block executes under the warm slots rules and the slots accessed by its parent
+// are unavailable, as executing it would then charge the wrong gas. Without a
+// chain, no slots are warm.
+func NewEVMBlockContext(header *types.Header, chain ChainContext, author *common.Address) (vm.BlockContext, error) {
 	var (
 		beneficiary common.Address
 		baseFee     *big.Int
@@ -61,6 +65,13 @@ func NewEVMBlockContext(header *types.Header, chain ChainContext, author *common
 	if header.Difficulty.Sign() == 0 {
 		random = &header.MixDigest
 	}
+	var warmSlots types.AccessList
+	if chain != nil {
+		var err error
+		if warmSlots, err = WarmSlots(header, chain); err != nil {
+			return vm.BlockContext{}, err
+		}
+	}
 	return vm.BlockContext{
 		CanTransfer: CanTransfer,
 		Transfer:    Transfer,
@@ -73,7 +84,38 @@ func NewEVMBlockContext(header *types.Header, chain ChainContext, author *common
 		BlobBaseFee: blobBaseFee,
 		GasLimit:    header.GasLimit,
 		Random:      random,
+		WarmSlots:   warmSlots,
+	}, nil
+}
+
+// warmSlotsReader is implemented by the chain contexts which can retrieve the
+// storage slots accessed by a block, for the experimental warm slots rules.
+type warmSlotsReader interface {
+	WarmSlots(hash common.Hash, number uint64) (types.AccessList, bool)
+}
+
+// WarmSlots returns the storage slots warm at the start of each transaction of
+// the block: the ones accessed by its parent block, if the parent was executed
+// under the warm slots rules too.
+func WarmSlots(header *types.Header, chain ChainContext) (types.AccessList, error) {
+	config := chain.Config()
+	if header.Number.Sign() == 0 || !config.IsWarmSlots(header.Number, header.Time) {
+		return nil, nil
+	}
+	parent := chain.GetHeader(header.ParentHash, header.Number.Uint64()-1)
+	if parent == nil {
+		return nil, consensus.ErrUnknownAncestor
+	}
+	// Nothing is warm in the first block of the fork, nor after the genesis
+	if parent.Number.Sign() == 0 || !config.IsWarmSlots(parent.Number, parent.Time) {
+		return nil, nil
+	}
+	if reader, ok := chain.(warmSlotsReader); ok {
+		if slots, ok := reader.WarmSlots(header.ParentHash, parent.Number.Uint64()); ok {
+			return slots, nil
+		}
 	}
+	return nil, fmt.Errorf("%w: number %d, hash %x", ErrMissingWarmSlots, parent.Number, header.ParentHash)
 }
 
 // NewEVMTxContext creates a new transaction context for a single transaction.
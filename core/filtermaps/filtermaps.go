@@ -292,6 +292,17 @@ func (f *FilterMaps) Start() {
 	go f.indexerLoop()
 }
 
+// IndexedBlocks returns the range of blocks whose logs are fully indexed.
+func (f *FilterMaps) IndexedBlocks() common.Range[uint64] {
+	f.indexLock.RLock()
+	defer f.indexLock.RUnlock()
+
+	if !f.indexedRange.hasIndexedBlocks() {
+		return common.Range[uint64]{}
+	}
+	return f.indexedRange.blocks
+}
+
 // Stop ensures that the indexer is fully stopped before returning.
 func (f *FilterMaps) Stop() {
 	close(f.closeCh)
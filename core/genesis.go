@@ -27,10 +27,12 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/consensus/misc"
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/state"
 	"github.com/ethereum/go-ethereum/core/tracing"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/log"
@@ -381,6 +383,9 @@ func SetupGenesisBlockWithOverride(db ethdb.Database, triedb *triedb.Database, g
 	if err := newCfg.CheckConfigForkOrder(); err != nil {
 		return nil, common.Hash{}, nil, err
 	}
+	if err := vm.ValidateGasSchedule(newCfg); err != nil {
+		return nil, common.Hash{}, nil, err
+	}
 
 	// TODO(rjl493456442) better to define the comparator of chain config
 	// and short circuit if the chain config is not changed.
@@ -457,9 +462,16 @@ func (g *Genesis) IsVerkle() bool {
 	return g.Config.IsVerkleGenesis()
 }
 
+// alloc returns the genesis allocation, with the system contracts installed by
+// the forks active at genesis.
+func (g *Genesis) alloc() types.GenesisAlloc {
+	return misc.HistoryStorageAlloc(g.Config, g.Timestamp, g.Alloc)
+}
+
 // ToBlock returns the genesis block according to genesis specification.
 func (g *Genesis) ToBlock() *types.Block {
-	root, err := hashAlloc(&g.Alloc, g.IsVerkle())
+	alloc := g.alloc()
+	root, err := hashAlloc(&alloc, g.IsVerkle())
 	if err != nil {
 		panic(err)
 	}
@@ -535,6 +547,39 @@ func (g *Genesis) toBlockWithRoot(root common.Hash) *types.Block {
 	return types.NewBlock(head, &types.Body{Withdrawals: withdrawals}, nil, trie.NewStackTrie(nil))
 }
 
+// CommitGenesisState loads the stored genesis state with the given block hash
+// and commits it into the provided trie database.
+func CommitGenesisState(db ethdb.Database, triedb *triedb.Database, blockhash common.Hash) error {
+	var alloc types.GenesisAlloc
+	blob := rawdb.ReadGenesisStateSpec(db, blockhash)
+	if len(blob) != 0 {
+		if err := alloc.UnmarshalJSON(blob); err != nil {
+			return err
+		}
+	} else {
+		// Genesis allocation is missing, the node is either legacy and doesn't
+		// persist the genesis allocation or the allocation is broken. Try to
+		// recover with the built-in genesis if it's a known network.
+		var genesis *Genesis
+		switch blockhash {
+		case params.MainnetGenesisHash:
+			genesis = DefaultGenesisBlock()
+		case params.SepoliaGenesisHash:
+			genesis = DefaultSepoliaGenesisBlock()
+		case params.HoleskyGenesisHash:
+			genesis = DefaultHoleskyGenesisBlock()
+		case params.HoodiGenesisHash:
+			genesis = DefaultHoodiGenesisBlock()
+		}
+		if genesis == nil {
+			return errors.New("genesis state specification not found")
+		}
+		alloc = genesis.Alloc
+	}
+	_, err := flushAlloc(&alloc, triedb)
+	return err
+}
+
 // Commit writes the block and state of a genesis specification to the database.
 // The block is committed as the canonical head block.
 func (g *Genesis) Commit(db ethdb.Database, triedb *triedb.Database) (*types.Block, error) {
@@ -548,18 +593,22 @@ func (g *Genesis) Commit(db ethdb.Database, triedb *triedb.Database) (*types.Blo
 	if err := config.CheckConfigForkOrder(); err != nil {
 		return nil, err
 	}
+	if err := vm.ValidateGasSchedule(config); err != nil {
+		return nil, err
+	}
 	if config.Clique != nil && len(g.ExtraData) < 32+crypto.SignatureLength {
 		return nil, errors.New("can't start clique chain without signers")
 	}
 	// flush the data to disk and compute the state root
-	root, err := flushAlloc(&g.Alloc, triedb)
+	alloc := g.alloc()
+	root, err := flushAlloc(&alloc, triedb)
 	if err != nil {
 		return nil, err
 	}
 	block := g.toBlockWithRoot(root)
 
 	// Marshal the genesis state specification and persist.
-	blob, err := json.Marshal(g.Alloc)
+	blob, err := json.Marshal(alloc)
 	if err != nil {
 		return nil, err
 	}
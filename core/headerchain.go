@@ -34,8 +34,9 @@ import (
 )
 
 const (
-	headerCacheLimit = 512
-	numberCacheLimit = 2048
+	headerCacheLimit    = 512
+	warmSlotsCacheLimit = 8
+	numberCacheLimit    = 2048
 )
 
 // HeaderChain implements the basic block header chain logic. It is not usable
@@ -65,6 +66,8 @@ type HeaderChain struct {
 	headerCache *lru.Cache[common.Hash, *types.Header]
 	numberCache *lru.Cache[common.Hash, uint64] // most recent block numbers
 
+	warmSlotsCache *lru.Cache[common.Hash, types.AccessList] // Storage slots accessed by recent blocks
+
 	procInterrupt func() bool
 	engine        consensus.Engine
 }
@@ -73,12 +76,13 @@ type HeaderChain struct {
 // to the parent's interrupt semaphore.
 func NewHeaderChain(chainDb ethdb.Database, config *params.ChainConfig, engine consensus.Engine, procInterrupt func() bool) (*HeaderChain, error) {
 	hc := &HeaderChain{
-		config:        config,
-		chainDb:       chainDb,
-		headerCache:   lru.NewCache[common.Hash, *types.Header](headerCacheLimit),
-		numberCache:   lru.NewCache[common.Hash, uint64](numberCacheLimit),
-		procInterrupt: procInterrupt,
-		engine:        engine,
+		config:         config,
+		chainDb:        chainDb,
+		headerCache:    lru.NewCache[common.Hash, *types.Header](headerCacheLimit),
+		numberCache:    lru.NewCache[common.Hash, uint64](numberCacheLimit),
+		warmSlotsCache: lru.NewCache[common.Hash, types.AccessList](warmSlotsCacheLimit),
+		procInterrupt:  procInterrupt,
+		engine:         engine,
 	}
 	hc.genesisHeader = hc.GetHeaderByNumber(0)
 	if hc.genesisHeader == nil {
@@ -95,6 +99,21 @@ func NewHeaderChain(chainDb ethdb.Database, config *params.ChainConfig, engine c
 	return hc, nil
 }
 
+// WarmSlots retrieves the storage slots accessed by the execution of a block,
+// caching them if found. They are only recorded for the blocks executed under
+// the experimental warm slots rules.
+func (hc *HeaderChain) WarmSlots(hash common.Hash, number uint64) (types.AccessList, bool) {
+	if slots, ok := hc.warmSlotsCache.Get(hash); ok {
+		return slots, true
+	}
+	slots, ok := rawdb.ReadWarmSlots(hc.chainDb, hash, number)
+	if !ok {
+		return nil, false
+	}
+	hc.warmSlotsCache.Add(hash, slots)
+	return slots, true
+}
+
 // GetBlockNumber retrieves the block number belonging to the given hash
 // from the cache or database
 func (hc *HeaderChain) GetBlockNumber(hash common.Hash) (uint64, bool) {
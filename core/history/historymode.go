@@ -92,7 +92,19 @@ var PrunePoints = map[common.Hash]*PrunePoint{
 }
 
 // PrunedHistoryError is returned by APIs when the requested history is pruned.
-type PrunedHistoryError struct{}
+// If other nodes are known to serve the requested block, their endpoints are
+// reported in the error data, allowing clients to route the request there.
+type PrunedHistoryError struct {
+	Endpoints []string
+}
 
 func (e *PrunedHistoryError) Error() string  { return "pruned history unavailable" }
 func (e *PrunedHistoryError) ErrorCode() int { return 4444 }
+
+// ErrorData returns the endpoints serving the requested history, if any.
+func (e *PrunedHistoryError) ErrorData() interface{} {
+	if len(e.Endpoints) == 0 {
+		return nil
+	}
+	return map[string]interface{}{"endpoints": e.Endpoints}
+}
@@ -0,0 +1,93 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package history
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ShardHint points to an RPC endpoint serving a range of chain history which is
+// not available locally. Nodes in a fleet can each store a slice of the history
+// and redirect requests for other blocks to their peers.
+type ShardHint struct {
+	Start uint64 // First block served by the endpoint
+	End   uint64 // First block not served by the endpoint, zero means unbounded
+	URL   string // RPC endpoint of the node serving the range
+}
+
+// Contains reports whether the given block falls in the hinted range.
+func (h ShardHint) Contains(number uint64) bool {
+	return number >= h.Start && (h.End == 0 || number < h.End)
+}
+
+// String implements fmt.Stringer, returning the hint in its textual form:
+// "start-end=url", with end omitted for unbounded ranges.
+func (h ShardHint) String() string {
+	var end string
+	if h.End != 0 {
+		end = strconv.FormatUint(h.End, 10)
+	}
+	return fmt.Sprintf("%d-%s=%s", h.Start, end, h.URL)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (h ShardHint) MarshalText() ([]byte, error) {
+	return []byte(h.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (h *ShardHint) UnmarshalText(text []byte) error {
+	spec, url, ok := strings.Cut(string(text), "=")
+	if !ok || url == "" {
+		return fmt.Errorf("invalid history shard hint %q, want start-end=url", text)
+	}
+	start, end, ok := strings.Cut(spec, "-")
+	if !ok {
+		return fmt.Errorf("invalid history shard range %q, want start-end", spec)
+	}
+	var hint = ShardHint{URL: url}
+	var err error
+	if hint.Start, err = strconv.ParseUint(start, 10, 64); err != nil {
+		return fmt.Errorf("invalid history shard start %q: %v", start, err)
+	}
+	if end != "" {
+		if hint.End, err = strconv.ParseUint(end, 10, 64); err != nil {
+			return fmt.Errorf("invalid history shard end %q: %v", end, err)
+		}
+		if hint.End <= hint.Start {
+			return fmt.Errorf("empty history shard range %q", spec)
+		}
+	}
+	*h = hint
+	return nil
+}
+
+// ShardHints is a list of endpoints serving history ranges.
+type ShardHints []ShardHint
+
+// Endpoints returns the URLs of all endpoints serving the given block.
+func (hints ShardHints) Endpoints(number uint64) []string {
+	var urls []string
+	for _, hint := range hints {
+		if hint.Contains(number) {
+			urls = append(urls, hint.URL)
+		}
+	}
+	return urls
+}
@@ -0,0 +1,54 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package history
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestShardHints(t *testing.T) {
+	var hints ShardHints
+	for _, spec := range []string{"0-100=http://a", "50-=http://b"} {
+		var hint ShardHint
+		if err := hint.UnmarshalText([]byte(spec)); err != nil {
+			t.Fatalf("failed to parse %q: %v", spec, err)
+		}
+		if hint.String() != spec {
+			t.Fatalf("hint roundtrip mismatch: have %q, want %q", hint.String(), spec)
+		}
+		hints = append(hints, hint)
+	}
+	for _, tt := range []struct {
+		number uint64
+		want   []string
+	}{
+		{0, []string{"http://a"}},
+		{75, []string{"http://a", "http://b"}},
+		{100, []string{"http://b"}},
+	} {
+		if have := hints.Endpoints(tt.number); !reflect.DeepEqual(have, tt.want) {
+			t.Errorf("block %d: endpoints mismatch: have %v, want %v", tt.number, have, tt.want)
+		}
+	}
+	for _, spec := range []string{"", "0-100", "100-50=http://a", "x-=http://a"} {
+		var hint ShardHint
+		if err := hint.UnmarshalText([]byte(spec)); err == nil {
+			t.Errorf("invalid hint %q accepted", spec)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package inclusion
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// API exposes the inclusion commitments under the eth namespace.
+type API struct {
+	committer *Committer
+	submit    func(ctx context.Context, tx *types.Transaction) (common.Hash, error)
+}
+
+// NewAPI creates the commitment API, adding the transactions to the pool with
+// the given submit function.
+func NewAPI(committer *Committer, submit func(ctx context.Context, tx *types.Transaction) (common.Hash, error)) *API {
+	return &API{committer: committer, submit: submit}
+}
+
+// CommitmentResult is a commitment along with its current status.
+type CommitmentResult struct {
+	*Commitment
+	Status string `json:"status"`
+}
+
+// SendRawTransactionWithCommitment adds the signed transaction to the pool like
+// eth_sendRawTransaction and returns the commitment of the block producer to
+// include it.
+func (api *API) SendRawTransactionWithCommitment(ctx context.Context, input hexutil.Bytes) (*Commitment, error) {
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(input); err != nil {
+		return nil, err
+	}
+	hash, err := api.submit(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+	return api.committer.Commit(hash)
+}
+
+// GetInclusionCommitment returns the commitment given for a transaction and
+// whether it was honored, or nil if no commitment is known.
+func (api *API) GetInclusionCommitment(hash common.Hash) *CommitmentResult {
+	commitment, status := api.committer.Get(hash)
+	if commitment == nil {
+		return nil
+	}
+	return &CommitmentResult{Commitment: commitment, Status: status}
+}
+
+// InclusionCommitmentSigner returns the address signing the commitments.
+func (api *API) InclusionCommitmentSigner() common.Address {
+	return api.committer.Address()
+}
@@ -0,0 +1,87 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package inclusion implements signed transaction inclusion commitments.
+//
+// A block producer accepting a transaction into its pool may promise to include
+// it by a given block, signing a commitment over the transaction hash, the
+// block number and the time of the promise. Users can verify the signature
+// against the published address of the producer, holding it accountable for
+// the soft confirmations it gives.
+package inclusion
+
+import (
+	"crypto/ecdsa"
+	"encoding/binary"
+	"errors"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// commitmentPrefix domain separates the commitment signatures from the other
+// messages signed by the same key.
+var commitmentPrefix = []byte("\x19Inclusion Commitment:\n")
+
+var errInvalidSignature = errors.New("invalid commitment signature")
+
+// Commitment is the promise of a block producer to include a transaction in a
+// block not above BlockNumber.
+type Commitment struct {
+	TxHash      common.Hash    `json:"transactionHash"`
+	BlockNumber hexutil.Uint64 `json:"blockNumber"` // Last block the transaction is to be included in
+	Timestamp   hexutil.Uint64 `json:"timestamp"`   // Unix time the commitment was given at
+	Signature   hexutil.Bytes  `json:"signature"`   // Signature of the producer over SigHash
+}
+
+// SigHash returns the hash signed by the block producer of the given chain:
+//
+//	keccak256(prefix || chainID (32 bytes) || txHash || blockNumber (8 bytes) || timestamp (8 bytes))
+func (c *Commitment) SigHash(chainID *big.Int) common.Hash {
+	var (
+		id     [32]byte
+		number [8]byte
+		time   [8]byte
+	)
+	chainID.FillBytes(id[:])
+	binary.BigEndian.PutUint64(number[:], uint64(c.BlockNumber))
+	binary.BigEndian.PutUint64(time[:], uint64(c.Timestamp))
+	return crypto.Keccak256Hash(commitmentPrefix, id[:], c.TxHash[:], number[:], time[:])
+}
+
+// Sign signs the commitment with the key of the block producer.
+func (c *Commitment) Sign(key *ecdsa.PrivateKey, chainID *big.Int) error {
+	sig, err := crypto.Sign(c.SigHash(chainID).Bytes(), key)
+	if err != nil {
+		return err
+	}
+	c.Signature = sig
+	return nil
+}
+
+// Signer recovers the address of the block producer which signed the commitment.
+func (c *Commitment) Signer(chainID *big.Int) (common.Address, error) {
+	if len(c.Signature) != crypto.SignatureLength {
+		return common.Address{}, errInvalidSignature
+	}
+	pub, err := crypto.SigToPub(c.SigHash(chainID).Bytes(), c.Signature)
+	if err != nil {
+		return common.Address{}, errInvalidSignature
+	}
+	return crypto.PubkeyToAddress(*pub), nil
+}
@@ -0,0 +1,232 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package inclusion
+
+import (
+	"crypto/ecdsa"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/lru"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+const (
+	// DefaultWindow is the default number of blocks after the head by which the
+	// committed transactions are to be included.
+	DefaultWindow = 2
+
+	// maxCommitments is the number of commitments kept for lookups.
+	maxCommitments = 16384
+
+	// chainEventChanSize is the size of channel listening to ChainEvent.
+	chainEventChanSize = 16
+)
+
+// Statuses of a commitment.
+const (
+	StatusPending  = "pending"  // Transaction not included yet, deadline not passed
+	StatusIncluded = "included" // Transaction included in time
+	StatusMissed   = "missed"   // Transaction not included by the committed block
+)
+
+var (
+	issuedCounter   = metrics.NewRegisteredCounter("inclusion/issued", nil)
+	includedCounter = metrics.NewRegisteredCounter("inclusion/included", nil)
+	missedCounter   = metrics.NewRegisteredCounter("inclusion/missed", nil)
+)
+
+// BlockChain defines the minimal set of methods needed to track the inclusion
+// of the committed transactions.
+type BlockChain interface {
+	// Config retrieves the chain's fork configuration.
+	Config() *params.ChainConfig
+
+	// CurrentBlock returns the current head of the chain.
+	CurrentBlock() *types.Header
+
+	// SubscribeChainEvent subscribes to new blocks being added to the chain.
+	SubscribeChainEvent(ch chan<- core.ChainEvent) event.Subscription
+}
+
+// Config are the settings of the inclusion commitments.
+type Config struct {
+	Key    *ecdsa.PrivateKey // Key of the block producer signing the commitments
+	Window uint64            // Number of blocks after the head the transactions are committed to
+}
+
+// entry is an issued commitment along with its status.
+type entry struct {
+	commitment *Commitment
+	status     string
+}
+
+// Committer issues the inclusion commitments of the block producer and tracks
+// whether they are honored.
+type Committer struct {
+	config Config
+	chain  BlockChain
+	signer common.Address
+
+	lock    sync.Mutex
+	issued  lru.BasicLRU[common.Hash, *entry] // Recently issued commitments
+	pending map[common.Hash]*entry            // Commitments waiting for inclusion
+
+	closed chan struct{}
+	wg     sync.WaitGroup
+}
+
+// New creates a committer signing with the given key.
+func New(chain BlockChain, config Config) *Committer {
+	if config.Window == 0 {
+		config.Window = DefaultWindow
+	}
+	return &Committer{
+		config:  config,
+		chain:   chain,
+		signer:  crypto.PubkeyToAddress(config.Key.PublicKey),
+		issued:  lru.NewBasicLRU[common.Hash, *entry](maxCommitments),
+		pending: make(map[common.Hash]*entry),
+		closed:  make(chan struct{}),
+	}
+}
+
+// Address returns the address signing the commitments.
+func (c *Committer) Address() common.Address {
+	return c.signer
+}
+
+// Start implements node.Lifecycle, starting the tracking of the commitments.
+func (c *Committer) Start() error {
+	events := make(chan core.ChainEvent, chainEventChanSize)
+	sub := c.chain.SubscribeChainEvent(events)
+
+	c.wg.Add(1)
+	go c.loop(events, sub)
+
+	log.Info("Enabled inclusion commitments", "signer", c.signer, "window", c.config.Window)
+	return nil
+}
+
+// Stop implements node.Lifecycle, terminating the tracking of the commitments.
+func (c *Committer) Stop() error {
+	close(c.closed)
+	c.wg.Wait()
+	return nil
+}
+
+// Commit signs the commitment to include the transaction within the window.
+// A transaction is only committed to once, later calls return the commitment
+// given first.
+func (c *Committer) Commit(hash common.Hash) (*Commitment, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if e, ok := c.issued.Get(hash); ok {
+		return e.commitment, nil
+	}
+	commitment := &Commitment{
+		TxHash:      hash,
+		BlockNumber: hexutil.Uint64(c.chain.CurrentBlock().Number.Uint64() + c.config.Window),
+		Timestamp:   hexutil.Uint64(time.Now().Unix()),
+	}
+	if err := commitment.Sign(c.config.Key, c.chain.Config().ChainID); err != nil {
+		return nil, err
+	}
+	e := &entry{commitment: commitment, status: StatusPending}
+	if evicted, old, ok := c.issued.Add3(hash, e); ok {
+		delete(c.pending, evicted)
+		if old.status == StatusPending {
+			log.Debug("Dropped untracked inclusion commitment", "tx", evicted, "block", uint64(old.commitment.BlockNumber))
+		}
+	}
+	c.pending[hash] = e
+	issuedCounter.Inc(1)
+	return commitment, nil
+}
+
+// Get returns the commitment issued for a transaction and its status.
+func (c *Committer) Get(hash common.Hash) (*Commitment, string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if e, ok := c.issued.Peek(hash); ok {
+		return e.commitment, e.status
+	}
+	return nil, ""
+}
+
+// loop tracks the inclusion of the committed transactions.
+func (c *Committer) loop(events chan core.ChainEvent, sub event.Subscription) {
+	defer c.wg.Done()
+	defer sub.Unsubscribe()
+
+	for {
+		select {
+		case ev := <-events:
+			c.update(ev.Header, ev.Transactions)
+		case <-sub.Err():
+			return
+		case <-c.closed:
+			return
+		}
+	}
+}
+
+// update resolves the commitments of the transactions included by a block and
+// the ones whose deadline passed with it.
+func (c *Committer) update(header *types.Header, txs []*types.Transaction) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	number := header.Number.Uint64()
+	for _, tx := range txs {
+		e, ok := c.pending[tx.Hash()]
+		if !ok {
+			continue
+		}
+		delete(c.pending, tx.Hash())
+		if uint64(e.commitment.BlockNumber) < number {
+			c.missed(tx.Hash(), e, number)
+			continue
+		}
+		e.status = StatusIncluded
+		includedCounter.Inc(1)
+	}
+	for hash, e := range c.pending {
+		if uint64(e.commitment.BlockNumber) >= number {
+			continue
+		}
+		delete(c.pending, hash)
+		c.missed(hash, e, number)
+	}
+}
+
+// missed marks a commitment as not honored.
+func (c *Committer) missed(hash common.Hash, e *entry, number uint64) {
+	e.status = StatusMissed
+	missedCounter.Inc(1)
+	log.Warn("Missed inclusion commitment", "tx", hash, "deadline", uint64(e.commitment.BlockNumber), "block", number)
+}
@@ -0,0 +1,99 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package inclusion
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// testChain is a fake chain with a settable head.
+type testChain struct {
+	head *types.Header
+	feed event.Feed
+}
+
+func (c *testChain) Config() *params.ChainConfig { return params.TestChainConfig }
+func (c *testChain) CurrentBlock() *types.Header { return c.head }
+func (c *testChain) SubscribeChainEvent(ch chan<- core.ChainEvent) event.Subscription {
+	return c.feed.Subscribe(ch)
+}
+
+func TestCommitmentSignature(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	commitment := &Commitment{TxHash: common.Hash{0x01}, BlockNumber: 10, Timestamp: 1000}
+	if err := commitment.Sign(key, big.NewInt(1)); err != nil {
+		t.Fatalf("failed to sign commitment: %v", err)
+	}
+	signer, err := commitment.Signer(big.NewInt(1))
+	if err != nil {
+		t.Fatalf("failed to recover signer: %v", err)
+	}
+	if want := crypto.PubkeyToAddress(key.PublicKey); signer != want {
+		t.Fatalf("signer mismatch: have %x, want %x", signer, want)
+	}
+	// The signature must not be valid on another chain or for another block.
+	if signer, _ := commitment.Signer(big.NewInt(2)); signer == crypto.PubkeyToAddress(key.PublicKey) {
+		t.Fatal("commitment valid on another chain")
+	}
+	commitment.BlockNumber++
+	if signer, _ := commitment.Signer(big.NewInt(1)); signer == crypto.PubkeyToAddress(key.PublicKey) {
+		t.Fatal("commitment valid for another block")
+	}
+}
+
+func TestCommitterTracking(t *testing.T) {
+	var (
+		key, _ = crypto.GenerateKey()
+		chain  = &testChain{head: &types.Header{Number: big.NewInt(100)}}
+		c      = New(chain, Config{Key: key, Window: 2})
+	)
+	included := types.NewTx(&types.LegacyTx{Nonce: 0})
+	missed := types.NewTx(&types.LegacyTx{Nonce: 1})
+
+	first, err := c.Commit(included.Hash())
+	if err != nil {
+		t.Fatalf("failed to commit: %v", err)
+	}
+	if first.BlockNumber != 102 {
+		t.Fatalf("wrong committed block: have %d, want %d", first.BlockNumber, 102)
+	}
+	if again, _ := c.Commit(included.Hash()); again != first {
+		t.Fatal("transaction committed to twice")
+	}
+	c.Commit(missed.Hash())
+
+	c.update(&types.Header{Number: big.NewInt(101)}, []*types.Transaction{included})
+	if _, status := c.Get(included.Hash()); status != StatusIncluded {
+		t.Errorf("wrong status of included transaction: have %q, want %q", status, StatusIncluded)
+	}
+	c.update(&types.Header{Number: big.NewInt(102)}, nil)
+	if _, status := c.Get(missed.Hash()); status != StatusPending {
+		t.Errorf("wrong status before deadline: have %q, want %q", status, StatusPending)
+	}
+	c.update(&types.Header{Number: big.NewInt(103)}, []*types.Transaction{missed})
+	if _, status := c.Get(missed.Hash()); status != StatusMissed {
+		t.Errorf("wrong status after deadline: have %q, want %q", status, StatusMissed)
+	}
+}
@@ -573,6 +573,29 @@ func ReadReceipts(db ethdb.Reader, hash common.Hash, number uint64, time uint64,
 	return receipts
 }
 
+// ReadBlockReceipts retrieves all the transaction receipts belonging to the given
+// block, including their corresponding metadata fields. Unlike ReadReceipts, the
+// metadata is derived from the provided block, so the block body and header are
+// not loaded from the database again.
+func ReadBlockReceipts(db ethdb.Reader, block *types.Block, config *params.ChainConfig) types.Receipts {
+	hash, number := block.Hash(), block.NumberU64()
+
+	receipts := ReadRawReceipts(db, hash, number)
+	if receipts == nil {
+		return nil
+	}
+	// Compute effective blob gas price.
+	var blobGasPrice *big.Int
+	if block.ExcessBlobGas() != nil {
+		blobGasPrice = eip4844.CalcBlobFee(config, block.Header())
+	}
+	if err := receipts.DeriveFields(config, hash, number, block.Time(), block.BaseFee(), blobGasPrice, block.Transactions()); err != nil {
+		log.Error("Failed to derive block receipts fields", "hash", hash, "number", number, "err", err)
+		return nil
+	}
+	return receipts
+}
+
 // WriteReceipts stores all the transaction receipts belonging to a block.
 func WriteReceipts(db ethdb.KeyValueWriter, hash common.Hash, number uint64, receipts types.Receipts) {
 	// Convert the receipts into their storage form and serialize them
@@ -605,6 +628,143 @@ func DeleteReceipts(db ethdb.KeyValueWriter, hash common.Hash, number uint64) {
 	}
 }
 
+// ReadHaltReasons retrieves the halt reasons of the transactions in a block,
+// indexed by their position. Successful transactions have an empty reason. Nil
+// is returned if no reasons were recorded for the block.
+//
+// Halt reasons are not part of the receipts and are not moved to the freezer:
+// they are deleted with the block once it is frozen.
+func ReadHaltReasons(db ethdb.KeyValueReader, hash common.Hash, number uint64) []string {
+	data, _ := db.Get(blockHaltReasonsKey(number, hash))
+	if len(data) == 0 {
+		return nil
+	}
+	var reasons []string
+	if err := rlp.DecodeBytes(data, &reasons); err != nil {
+		log.Error("Invalid halt reasons RLP", "hash", hash, "err", err)
+		return nil
+	}
+	return reasons
+}
+
+// WriteHaltReasons stores the halt reasons of the failed transactions in a
+// block. Nothing is stored if all transactions succeeded.
+func WriteHaltReasons(db ethdb.KeyValueWriter, hash common.Hash, number uint64, receipts types.Receipts) {
+	var (
+		reasons = make([]string, len(receipts))
+		failed  bool
+	)
+	for i, receipt := range receipts {
+		reasons[i] = receipt.HaltReason
+		failed = failed || receipt.HaltReason != ""
+	}
+	if !failed {
+		return
+	}
+	bytes, err := rlp.EncodeToBytes(reasons)
+	if err != nil {
+		log.Crit("Failed to encode halt reasons", "err", err)
+	}
+	if err := db.Put(blockHaltReasonsKey(number, hash), bytes); err != nil {
+		log.Crit("Failed to store halt reasons", "err", err)
+	}
+}
+
+// DeleteHaltReasons removes the halt reasons of the transactions in a block.
+func DeleteHaltReasons(db ethdb.KeyValueWriter, hash common.Hash, number uint64) {
+	if err := db.Delete(blockHaltReasonsKey(number, hash)); err != nil {
+		log.Crit("Failed to delete halt reasons", "err", err)
+	}
+}
+
+// ReadWarmSlots retrieves the storage slots accessed by the execution of a block,
+// which are warm in its children under the experimental warm slots rules. The
+// flag reports whether the slots were recorded for the block.
+//
+// Only the children of recent blocks are imported, so the slots are deleted with
+// the block once it is frozen.
+func ReadWarmSlots(db ethdb.KeyValueReader, hash common.Hash, number uint64) (types.AccessList, bool) {
+	data, _ := db.Get(blockWarmSlotsKey(number, hash))
+	if len(data) == 0 {
+		return nil, false
+	}
+	var slots types.AccessList
+	if err := rlp.DecodeBytes(data, &slots); err != nil {
+		log.Error("Invalid warm slots RLP", "hash", hash, "err", err)
+		return nil, false
+	}
+	return slots, true
+}
+
+// WriteWarmSlots stores the storage slots accessed by the execution of a block.
+func WriteWarmSlots(db ethdb.KeyValueWriter, hash common.Hash, number uint64, slots types.AccessList) {
+	if slots == nil {
+		slots = types.AccessList{}
+	}
+	bytes, err := rlp.EncodeToBytes(slots)
+	if err != nil {
+		log.Crit("Failed to encode warm slots", "err", err)
+	}
+	if err := db.Put(blockWarmSlotsKey(number, hash), bytes); err != nil {
+		log.Crit("Failed to store warm slots", "err", err)
+	}
+}
+
+// DeleteWarmSlots removes the storage slots accessed by a block.
+func DeleteWarmSlots(db ethdb.KeyValueWriter, hash common.Hash, number uint64) {
+	if err := db.Delete(blockWarmSlotsKey(number, hash)); err != nil {
+		log.Crit("Failed to delete warm slots", "err", err)
+	}
+}
+
+// ReadRevertReasons retrieves the decoded revert reasons of the transactions in
+// a block, indexed by their position. Transactions which succeeded or reverted
+// without a decodable reason have an empty entry. Nil is returned if no reasons
+// were recorded for the block. Like halt reasons, they are deleted with the
+// block once it is frozen.
+func ReadRevertReasons(db ethdb.KeyValueReader, hash common.Hash, number uint64) []string {
+	data, _ := db.Get(blockRevertReasonsKey(number, hash))
+	if len(data) == 0 {
+		return nil
+	}
+	var reasons []string
+	if err := rlp.DecodeBytes(data, &reasons); err != nil {
+		log.Error("Invalid revert reasons RLP", "hash", hash, "err", err)
+		return nil
+	}
+	return reasons
+}
+
+// WriteRevertReasons stores the decoded revert reasons of the reverted
+// transactions in a block. Nothing is stored if no transaction has a reason.
+func WriteRevertReasons(db ethdb.KeyValueWriter, hash common.Hash, number uint64, receipts types.Receipts) {
+	var (
+		reasons  = make([]string, len(receipts))
+		reverted bool
+	)
+	for i, receipt := range receipts {
+		reasons[i] = receipt.RevertReason
+		reverted = reverted || receipt.RevertReason != ""
+	}
+	if !reverted {
+		return
+	}
+	bytes, err := rlp.EncodeToBytes(reasons)
+	if err != nil {
+		log.Crit("Failed to encode revert reasons", "err", err)
+	}
+	if err := db.Put(blockRevertReasonsKey(number, hash), bytes); err != nil {
+		log.Crit("Failed to store revert reasons", "err", err)
+	}
+}
+
+// DeleteRevertReasons removes the revert reasons of the transactions in a block.
+func DeleteRevertReasons(db ethdb.KeyValueWriter, hash common.Hash, number uint64) {
+	if err := db.Delete(blockRevertReasonsKey(number, hash)); err != nil {
+		log.Crit("Failed to delete revert reasons", "err", err)
+	}
+}
+
 // ReceiptLogs is a barebone version of ReceiptForStorage which only keeps
 // the list of logs. When decoding a stored receipt into this object we
 // avoid creating the bloom filter.
@@ -725,6 +885,9 @@ func WriteAncientHeaderChain(db ethdb.AncientWriter, headers []*types.Header) (i
 // DeleteBlock removes all block data associated with a hash.
 func DeleteBlock(db ethdb.KeyValueWriter, hash common.Hash, number uint64) {
 	DeleteReceipts(db, hash, number)
+	DeleteHaltReasons(db, hash, number)
+	DeleteRevertReasons(db, hash, number)
+	DeleteWarmSlots(db, hash, number)
 	DeleteHeader(db, hash, number)
 	DeleteBody(db, hash, number)
 }
@@ -733,6 +896,9 @@ func DeleteBlock(db ethdb.KeyValueWriter, hash common.Hash, number uint64) {
 // the hash to number mapping.
 func DeleteBlockWithoutNumber(db ethdb.KeyValueWriter, hash common.Hash, number uint64) {
 	DeleteReceipts(db, hash, number)
+	DeleteHaltReasons(db, hash, number)
+	DeleteRevertReasons(db, hash, number)
+	DeleteWarmSlots(db, hash, number)
 	deleteHeaderWithoutNumber(db, hash, number)
 	DeleteBody(db, hash, number)
 }
@@ -23,6 +23,7 @@ import (
 	"math/big"
 	"math/rand"
 	"os"
+	"reflect"
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -385,6 +386,40 @@ func TestBlockReceiptStorage(t *testing.T) {
 	}
 }
 
+// Tests that receipts can be retrieved with their metadata derived from an
+// already loaded block, without the body being present in the database.
+func TestBlockReceiptsFromBlock(t *testing.T) {
+	db := NewMemoryDatabase()
+
+	tx1 := types.NewTransaction(1, common.HexToAddress("0x1"), big.NewInt(1), 1, big.NewInt(1), nil)
+	tx2 := types.NewTransaction(2, common.HexToAddress("0x2"), big.NewInt(2), 2, big.NewInt(2), nil)
+	block := types.NewBlockWithHeader(&types.Header{Number: big.NewInt(1)}).WithBody(types.Body{Transactions: types.Transactions{tx1, tx2}})
+
+	receipt1 := &types.Receipt{Status: types.ReceiptStatusSuccessful, CumulativeGasUsed: 1, GasUsed: 1}
+	receipt1.Bloom = types.CreateBloom(receipt1)
+	receipt2 := &types.Receipt{Status: types.ReceiptStatusFailed, CumulativeGasUsed: 3, GasUsed: 2}
+	receipt2.Bloom = types.CreateBloom(receipt2)
+	receipts := []*types.Receipt{receipt1, receipt2}
+
+	if rs := ReadBlockReceipts(db, block, params.TestChainConfig); rs != nil {
+		t.Fatalf("non existent receipts returned: %v", rs)
+	}
+	WriteReceipts(db, block.Hash(), block.NumberU64(), receipts)
+
+	rs := ReadBlockReceipts(db, block, params.TestChainConfig)
+	if len(rs) != 2 {
+		t.Fatalf("wrong number of receipts returned: have %d, want 2", len(rs))
+	}
+	if err := checkReceiptsRLP(rs, receipts); err != nil {
+		t.Fatal(err)
+	}
+	for i, r := range rs {
+		if r.BlockHash != block.Hash() || r.TxHash != block.Transactions()[i].Hash() || r.TransactionIndex != uint(i) {
+			t.Fatalf("receipt %d: metadata not derived from block", i)
+		}
+	}
+}
+
 func checkReceiptsRLP(have, want types.Receipts) error {
 	if len(have) != len(want) {
 		return fmt.Errorf("receipts sizes mismatch: have %d, want %d", len(have), len(want))
@@ -906,3 +941,91 @@ func TestHeadersRLPStorage(t *testing.T) {
 	checkSequence(1, 1)    // Only block 1
 	checkSequence(1, 2)    // Genesis + block 1
 }
+
+// Tests that halt reasons are only stored for blocks with failed transactions.
+func TestHaltReasonsStorage(t *testing.T) {
+	db := NewMemoryDatabase()
+
+	var (
+		hash     = common.Hash{0x01}
+		success  = &types.Receipt{Status: types.ReceiptStatusSuccessful}
+		reverted = &types.Receipt{Status: types.ReceiptStatusFailed, HaltReason: "Reverted"}
+	)
+	WriteHaltReasons(db, hash, 1, types.Receipts{success, success})
+	if reasons := ReadHaltReasons(db, hash, 1); reasons != nil {
+		t.Fatalf("halt reasons stored for successful block: %v", reasons)
+	}
+	WriteHaltReasons(db, hash, 1, types.Receipts{success, reverted})
+	if reasons := ReadHaltReasons(db, hash, 1); !reflect.DeepEqual(reasons, []string{"", "Reverted"}) {
+		t.Fatalf("wrong halt reasons: %v", reasons)
+	}
+	DeleteBlock(db, hash, 1)
+	if reasons := ReadHaltReasons(db, hash, 1); reasons != nil {
+		t.Fatalf("halt reasons not deleted: %v", reasons)
+	}
+	// Halt reasons are also dropped when the block is moved to the freezer.
+	WriteHaltReasons(db, hash, 1, types.Receipts{success, reverted})
+	DeleteBlockWithoutNumber(db, hash, 1)
+	if reasons := ReadHaltReasons(db, hash, 1); reasons != nil {
+		t.Fatalf("halt reasons not deleted with frozen block: %v", reasons)
+	}
+}
+
+// Tests that the warm slots of a block are deleted with it, including when the
+// block is moved to the freezer.
+func TestWarmSlotsStorage(t *testing.T) {
+	db := NewMemoryDatabase()
+
+	var (
+		hash  = common.Hash{0x01}
+		slots = types.AccessList{{Address: common.Address{0x02}, StorageKeys: []common.Hash{{0x03}}}}
+	)
+	if _, ok := ReadWarmSlots(db, hash, 1); ok {
+		t.Fatal("warm slots reported before being stored")
+	}
+	WriteWarmSlots(db, hash, 1, nil)
+	if have, ok := ReadWarmSlots(db, hash, 1); !ok || len(have) != 0 {
+		t.Fatalf("wrong empty warm slots: %v, %v", have, ok)
+	}
+	WriteWarmSlots(db, hash, 1, slots)
+	if have, ok := ReadWarmSlots(db, hash, 1); !ok || !reflect.DeepEqual(have, slots) {
+		t.Fatalf("wrong warm slots: %v", have)
+	}
+	DeleteBlock(db, hash, 1)
+	if _, ok := ReadWarmSlots(db, hash, 1); ok {
+		t.Fatal("warm slots not deleted")
+	}
+	WriteWarmSlots(db, hash, 1, slots)
+	DeleteBlockWithoutNumber(db, hash, 1)
+	if _, ok := ReadWarmSlots(db, hash, 1); ok {
+		t.Fatal("warm slots not deleted with frozen block")
+	}
+}
+
+// Tests that revert reasons are only stored for blocks with reverted transactions.
+func TestRevertReasonsStorage(t *testing.T) {
+	db := NewMemoryDatabase()
+
+	var (
+		hash     = common.Hash{0x01}
+		success  = &types.Receipt{Status: types.ReceiptStatusSuccessful}
+		reverted = &types.Receipt{Status: types.ReceiptStatusFailed, HaltReason: "Reverted", RevertReason: "insufficient balance"}
+	)
+	WriteRevertReasons(db, hash, 1, types.Receipts{success, success})
+	if reasons := ReadRevertReasons(db, hash, 1); reasons != nil {
+		t.Fatalf("revert reasons stored for successful block: %v", reasons)
+	}
+	WriteRevertReasons(db, hash, 1, types.Receipts{reverted, success})
+	if reasons := ReadRevertReasons(db, hash, 1); !reflect.DeepEqual(reasons, []string{"insufficient balance", ""}) {
+		t.Fatalf("wrong revert reasons: %v", reasons)
+	}
+	DeleteBlock(db, hash, 1)
+	if reasons := ReadRevertReasons(db, hash, 1); reasons != nil {
+		t.Fatalf("revert reasons not deleted: %v", reasons)
+	}
+	WriteRevertReasons(db, hash, 1, types.Receipts{reverted, success})
+	DeleteBlockWithoutNumber(db, hash, 1)
+	if reasons := ReadRevertReasons(db, hash, 1); reasons != nil {
+		t.Fatalf("revert reasons not deleted with frozen block: %v", reasons)
+	}
+}
@@ -134,6 +134,31 @@ func DeleteAllTxLookupEntries(db ethdb.KeyValueStore, condition func(common.Hash
 	}
 }
 
+// ReadTxSender retrieves the cached sender of the transaction with the given
+// hash, if it was recorded during an earlier import.
+func ReadTxSender(db ethdb.KeyValueReader, hash common.Hash) (common.Address, bool) {
+	data, _ := db.Get(txSenderKey(hash))
+	if len(data) != common.AddressLength {
+		return common.Address{}, false
+	}
+	return common.BytesToAddress(data), true
+}
+
+// WriteTxSender stores the recovered sender of the transaction with the given
+// hash, so later replays can skip the signature recovery.
+func WriteTxSender(db ethdb.KeyValueWriter, hash common.Hash, sender common.Address) {
+	if err := db.Put(txSenderKey(hash), sender.Bytes()); err != nil {
+		log.Crit("Failed to store transaction sender", "err", err)
+	}
+}
+
+// DeleteTxSender removes the cached sender of the transaction with the given hash.
+func DeleteTxSender(db ethdb.KeyValueWriter, hash common.Hash) {
+	if err := db.Delete(txSenderKey(hash)); err != nil {
+		log.Crit("Failed to delete transaction sender", "err", err)
+	}
+}
+
 // findTxInBlockBody traverses the given RLP-encoded block body, searching for
 // the transaction specified by its hash.
 func findTxInBlockBody(blockbody rlp.RawValue, target common.Hash) (*types.Transaction, uint64, error) {
@@ -53,6 +53,32 @@ func WriteDatabaseVersion(db ethdb.KeyValueWriter, version uint64) {
 	}
 }
 
+// ReadSchemaVersion retrieves the schema version of the database, i.e. the last
+// schema migration applied to it.
+func ReadSchemaVersion(db ethdb.KeyValueReader) *uint64 {
+	var version uint64
+
+	enc, _ := db.Get(schemaVersionKey)
+	if len(enc) == 0 {
+		return nil
+	}
+	if err := rlp.DecodeBytes(enc, &version); err != nil {
+		return nil
+	}
+	return &version
+}
+
+// WriteSchemaVersion stores the schema version of the database.
+func WriteSchemaVersion(db ethdb.KeyValueWriter, version uint64) {
+	enc, err := rlp.EncodeToBytes(version)
+	if err != nil {
+		log.Crit("Failed to encode schema version", "err", err)
+	}
+	if err = db.Put(schemaVersionKey, enc); err != nil {
+		log.Crit("Failed to store the schema version", "err", err)
+	}
+}
+
 // ReadChainConfig retrieves the consensus settings based on the given genesis hash.
 func ReadChainConfig(db ethdb.KeyValueReader, hash common.Hash) *params.ChainConfig {
 	data, _ := db.Get(configKey(hash))
@@ -174,3 +200,77 @@ func UpdateUncleanShutdownMarker(db ethdb.KeyValueStore) {
 		log.Warn("Failed to write unclean-shutdown marker", "err", err)
 	}
 }
+
+// ReadScheduledTxs retrieves the encoded transactions scheduled for a later
+// submission, keyed by transaction hash.
+func ReadScheduledTxs(db ethdb.Iteratee) map[common.Hash][]byte {
+	it := db.NewIterator(scheduledTxPrefix, nil)
+	defer it.Release()
+
+	txs := make(map[common.Hash][]byte)
+	for it.Next() {
+		if key := it.Key(); len(key) == len(scheduledTxPrefix)+common.HashLength {
+			txs[common.BytesToHash(key[len(scheduledTxPrefix):])] = common.CopyBytes(it.Value())
+		}
+	}
+	return txs
+}
+
+// WriteScheduledTx stores an encoded transaction scheduled for a later submission.
+func WriteScheduledTx(db ethdb.KeyValueWriter, hash common.Hash, blob []byte) {
+	if err := db.Put(scheduledTxKey(hash), blob); err != nil {
+		log.Crit("Failed to store scheduled transaction", "err", err)
+	}
+}
+
+// DeleteScheduledTx removes a scheduled transaction.
+func DeleteScheduledTx(db ethdb.KeyValueWriter, hash common.Hash) {
+	if err := db.Delete(scheduledTxKey(hash)); err != nil {
+		log.Crit("Failed to delete scheduled transaction", "err", err)
+	}
+}
+
+// reorgsToKeep is the number of most recent reorgs retained in the journal.
+const reorgsToKeep = 128
+
+// ReorgRecord describes a reorganisation of the canonical chain.
+type ReorgRecord struct {
+	Time         uint64        `json:"time"`         // Unix time the reorg was applied
+	Depth        uint64        `json:"depth"`        // Number of canonical blocks dropped
+	AncestorHash common.Hash   `json:"ancestorHash"` // Hash of the common ancestor
+	Ancestor     uint64        `json:"ancestor"`     // Number of the common ancestor
+	OldChain     []common.Hash `json:"oldChain"`     // Dropped blocks, old head first
+	NewChain     []common.Hash `json:"newChain"`     // Adopted blocks, new head first
+	DroppedTxs   []common.Hash `json:"droppedTxs"`   // Transactions only present in the old chain
+	AddedTxs     []common.Hash `json:"addedTxs"`     // Transactions only present in the new chain
+}
+
+// ReadReorgJournal retrieves the recorded reorgs, oldest first.
+func ReadReorgJournal(db ethdb.KeyValueReader) []*ReorgRecord {
+	blob, _ := db.Get(reorgJournalKey)
+	if len(blob) == 0 {
+		return nil
+	}
+	var reorgs []*ReorgRecord
+	if err := rlp.DecodeBytes(blob, &reorgs); err != nil {
+		log.Error("Failed to decode reorg journal", "err", err)
+		return nil
+	}
+	return reorgs
+}
+
+// WriteReorgRecord appends a reorg to the journal, discarding the oldest entries
+// beyond reorgsToKeep.
+func WriteReorgRecord(db ethdb.KeyValueStore, reorg *ReorgRecord) {
+	reorgs := append(ReadReorgJournal(db), reorg)
+	if len(reorgs) > reorgsToKeep {
+		reorgs = reorgs[len(reorgs)-reorgsToKeep:]
+	}
+	data, err := rlp.EncodeToBytes(reorgs)
+	if err != nil {
+		log.Crit("Failed to encode reorg journal", "err", err)
+	}
+	if err := db.Put(reorgJournalKey, data); err != nil {
+		log.Crit("Failed to write reorg journal", "err", err)
+	}
+}
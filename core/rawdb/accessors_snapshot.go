@@ -118,6 +118,11 @@ func IterateStorageSnapshots(db ethdb.Iteratee, accountHash common.Hash) ethdb.I
 	return NewKeyLengthIterator(db.NewIterator(storageSnapshotsKey(accountHash), nil), len(SnapshotStoragePrefix)+2*common.HashLength)
 }
 
+// DeleteStorageSnapshots removes all storage snapshot entries of the given account.
+func DeleteStorageSnapshots(db ethdb.KeyValueRangeDeleter, accountHash common.Hash) error {
+	return deletePrefix(db, storageSnapshotsKey(accountHash))
+}
+
 // ReadSnapshotJournal retrieves the serialized in-memory diff layers saved at
 // the last shutdown. The blob is expected to be max a few 10s of megabytes.
 func ReadSnapshotJournal(db ethdb.KeyValueReader) []byte {
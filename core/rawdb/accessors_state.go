@@ -23,6 +23,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
 )
 
 // ReadPreimage retrieves a single preimage of the provided hash.
@@ -135,6 +136,13 @@ func WritePersistentStateID(db ethdb.KeyValueWriter, number uint64) {
 	}
 }
 
+// DeletePersistentStateID deletes the id of the persistent state from database.
+func DeletePersistentStateID(db ethdb.KeyValueWriter) {
+	if err := db.Delete(persistentStateIDKey); err != nil {
+		log.Crit("Failed to remove the persistent state ID", "err", err)
+	}
+}
+
 // ReadTrieJournal retrieves the serialized in-memory trie nodes of layers saved at
 // the last shutdown.
 func ReadTrieJournal(db ethdb.KeyValueReader) []byte {
@@ -150,6 +158,56 @@ func WriteTrieJournal(db ethdb.KeyValueWriter, journal []byte) {
 	}
 }
 
+// DeleteTrieJournal deletes the serialized in-memory trie nodes of layers saved at
+// the last shutdown.
+func DeleteTrieJournal(db ethdb.KeyValueWriter) {
+	if err := db.Delete(trieJournalKey); err != nil {
+		log.Crit("Failed to remove tries journal", "err", err)
+	}
+}
+
+// SchemeConversion is the progress marker of an in-place conversion of the
+// persisted state to another state scheme.
+type SchemeConversion struct {
+	Scheme   string      // Target state scheme
+	Root     common.Hash // Root of the converted state
+	Number   uint64      // Number of the block of the converted state
+	Snapshot bool        // Whether the trie is regenerated from the flat state
+	Cleanup  bool        // Whether the state is written and the source scheme is being deleted
+}
+
+// ReadSchemeConversion retrieves the progress of the state scheme conversion,
+// nil if no conversion is in progress.
+func ReadSchemeConversion(db ethdb.KeyValueReader) *SchemeConversion {
+	data, _ := db.Get(schemeConversionKey)
+	if len(data) == 0 {
+		return nil
+	}
+	var conv SchemeConversion
+	if err := rlp.DecodeBytes(data, &conv); err != nil {
+		log.Crit("Failed to decode the state scheme conversion", "err", err)
+	}
+	return &conv
+}
+
+// WriteSchemeConversion stores the progress of the state scheme conversion.
+func WriteSchemeConversion(db ethdb.KeyValueWriter, conv *SchemeConversion) {
+	data, err := rlp.EncodeToBytes(conv)
+	if err != nil {
+		log.Crit("Failed to encode the state scheme conversion", "err", err)
+	}
+	if err := db.Put(schemeConversionKey, data); err != nil {
+		log.Crit("Failed to store the state scheme conversion", "err", err)
+	}
+}
+
+// DeleteSchemeConversion deletes the progress of the state scheme conversion.
+func DeleteSchemeConversion(db ethdb.KeyValueWriter) {
+	if err := db.Delete(schemeConversionKey); err != nil {
+		log.Crit("Failed to remove the state scheme conversion", "err", err)
+	}
+}
+
 // ReadStateHistoryMeta retrieves the metadata corresponding to the specified
 // state history. Compute the position of state history in freezer by minus
 // one since the id of first state history starts from one(zero for initial
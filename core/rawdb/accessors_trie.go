@@ -17,6 +17,8 @@
 package rawdb
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -104,6 +106,26 @@ func DeleteStorageTrieNode(db ethdb.KeyValueWriter, accountHash common.Hash, pat
 	}
 }
 
+// DeleteStorageTrie removes all nodes of the storage trie belonging to the given
+// account. This is only meaningful in the path scheme, where the nodes of a storage
+// trie are stored under a key prefix owned by the account.
+func DeleteStorageTrie(db ethdb.KeyValueRangeDeleter, accountHash common.Hash) error {
+	return deletePrefix(db, storageTrieNodeKey(accountHash, nil))
+}
+
+// deletePrefix removes all entries with the given key prefix. The deletion is
+// retried until the range is empty, as leveldb doesn't support native range
+// deletion and might only delete a part of the range at a time.
+func deletePrefix(db ethdb.KeyValueRangeDeleter, prefix []byte) error {
+	limit := increaseKey(bytes.Clone(prefix))
+	for {
+		err := db.DeleteRange(prefix, limit)
+		if !errors.Is(err, ethdb.ErrTooManyKeys) {
+			return err
+		}
+	}
+}
+
 // ReadLegacyTrieNode retrieves the legacy trie node with the given
 // associated node hash.
 func ReadLegacyTrieNode(db ethdb.KeyValueReader, hash common.Hash) []byte {
@@ -266,6 +288,10 @@ func ReadStateScheme(db ethdb.Database) string {
 //   - If the provided scheme is path: use path-based scheme or error out if not
 //     compatible with persistent state scheme.
 func ParseStateScheme(provided string, disk ethdb.Database) (string, error) {
+	// The state is unusable while it's converted to another scheme.
+	if conv := ReadSchemeConversion(disk); conv != nil {
+		return "", fmt.Errorf("conversion to %s state scheme is incomplete, rerun 'geth db convert-scheme %s'", conv.Scheme, conv.Scheme)
+	}
 	// If state scheme is not specified, use the scheme consistent
 	// with persistent state, or fallback to hash mode if database
 	// is empty.
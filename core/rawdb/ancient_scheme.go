@@ -51,6 +51,7 @@ var chainFreezerTableConfigs = map[string]freezerTableConfig{
 // freezerTableConfig contains the settings for a freezer table.
 type freezerTableConfig struct {
 	noSnappy bool // disables item compression
+	zstd     bool // compresses items with zstd instead of snappy, for new tables only
 	prunable bool // true for tables that can be pruned by TruncateTail
 }
 
@@ -0,0 +1,258 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rawdb
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// The kinds of inconsistencies detected by AuditChain.
+const (
+	AuditMissingCanonicalHash = "missing canonical hash"
+	AuditMissingHeaderNumber  = "missing hash to number mapping"
+	AuditMissingHeader        = "missing header"
+	AuditMissingBody          = "missing body"
+	AuditMissingReceipts      = "missing receipts"
+	AuditDanglingData         = "frozen data left in key-value store"
+	AuditMissingTxLookup      = "missing transaction lookups"
+)
+
+// AuditIssue is an inconsistency of the chain data of a block.
+type AuditIssue struct {
+	Kind     string
+	Number   uint64
+	Hash     common.Hash   // Hash of the block, zero if it couldn't be recovered
+	Frozen   bool          // Whether the block is expected in the freezer
+	TxHashes []common.Hash // Transactions lacking a lookup entry
+}
+
+// Repairable reports whether the issue can be fixed from the data available in
+// the database. Missing chain data can only be fixed by rewinding the chain.
+func (issue *AuditIssue) Repairable() bool {
+	switch issue.Kind {
+	case AuditMissingCanonicalHash:
+		return issue.Hash != (common.Hash{})
+	case AuditMissingHeaderNumber, AuditDanglingData, AuditMissingTxLookup:
+		return true
+	default:
+		return false
+	}
+}
+
+func (issue *AuditIssue) String() string {
+	store := "key-value store"
+	if issue.Frozen {
+		store = "freezer"
+	}
+	s := fmt.Sprintf("#%d [%x..] (%s): %s", issue.Number, issue.Hash.Bytes()[:4], store, issue.Kind)
+	if len(issue.TxHashes) > 0 {
+		s += fmt.Sprintf(" (%d txs)", len(issue.TxHashes))
+	}
+	return s
+}
+
+// AuditReport is the result of a chain data audit.
+type AuditReport struct {
+	Start  uint64 // First audited block
+	End    uint64 // Last audited block
+	Frozen uint64 // Number of blocks in the freezer
+	Tail   uint64 // First block with body and receipts in the freezer
+	Issues []*AuditIssue
+}
+
+// FirstUnrepairable returns the lowest issue which can't be repaired, or nil if
+// all of them can be.
+func (r *AuditReport) FirstUnrepairable() *AuditIssue {
+	for _, issue := range r.Issues {
+		if !issue.Repairable() {
+			return issue
+		}
+	}
+	return nil
+}
+
+// AuditChain cross-checks the canonical chain data of the given block range
+// between the key-value store and the freezer. Blocks below the freezer head
+// must have their header, body and receipts in the freezer and nothing left in
+// the key-value store, the ones above in the key-value store. The canonical
+// hash, the hash to number mapping and the transaction lookups (above the tx
+// index tail) are verified for every block.
+func AuditChain(db ethdb.Database, start, end uint64) (*AuditReport, error) {
+	if start > end {
+		return nil, fmt.Errorf("invalid range [%d, %d]", start, end)
+	}
+	report := &AuditReport{Start: start, End: end}
+	if frozen, err := db.Ancients(); err == nil {
+		report.Frozen = frozen
+	}
+	if tail, err := db.Tail(); err == nil {
+		report.Tail = tail
+	}
+	var (
+		txTail  = ReadTxIndexTail(db)
+		started = time.Now()
+		logged  = time.Now()
+	)
+	for number := start; number <= end; number++ {
+		if time.Since(logged) > 8*time.Second {
+			log.Info("Auditing chain data", "number", number, "end", end, "issues", len(report.Issues), "elapsed", common.PrettyDuration(time.Since(started)))
+			logged = time.Now()
+		}
+		frozen := number < report.Frozen
+		hash := ReadCanonicalHash(db, number)
+		if hash == (common.Hash{}) {
+			// Recover the hash from the parent reference of the next block, if any.
+			issue := &AuditIssue{Kind: AuditMissingCanonicalHash, Number: number, Frozen: frozen}
+			if next := ReadCanonicalHash(db, number+1); next != (common.Hash{}) {
+				if header := ReadHeader(db, next, number+1); header != nil && HasHeader(db, header.ParentHash, number) {
+					issue.Hash = header.ParentHash
+				}
+			}
+			report.Issues = append(report.Issues, issue)
+			if issue.Hash == (common.Hash{}) {
+				continue
+			}
+			hash = issue.Hash
+		}
+		if n, ok := ReadHeaderNumber(db, hash); !ok || n != number {
+			report.Issues = append(report.Issues, &AuditIssue{Kind: AuditMissingHeaderNumber, Number: number, Hash: hash, Frozen: frozen})
+		}
+		report.Issues = append(report.Issues, auditBlockData(db, number, hash, frozen, number >= report.Tail)...)
+
+		// Verify the transaction lookups if the block is within the indexed range.
+		if txTail == nil || number < *txTail {
+			continue
+		}
+		body := ReadBody(db, hash, number)
+		if body == nil {
+			continue // reported as missing body
+		}
+		var missing []common.Hash
+		for _, tx := range body.Transactions {
+			if n := ReadTxLookupEntry(db, tx.Hash()); n == nil || *n != number {
+				missing = append(missing, tx.Hash())
+			}
+		}
+		if len(missing) > 0 {
+			report.Issues = append(report.Issues, &AuditIssue{Kind: AuditMissingTxLookup, Number: number, Hash: hash, Frozen: frozen, TxHashes: missing})
+		}
+	}
+	return report, nil
+}
+
+// auditBlockData checks the presence of the header, body and receipts of a
+// block in the store it's expected in. The bodies and receipts are only checked
+// above the freezer tail, as the ones below are pruned.
+func auditBlockData(db ethdb.Database, number uint64, hash common.Hash, frozen bool, withBodies bool) []*AuditIssue {
+	var (
+		issues []*AuditIssue
+		checks = []struct {
+			kind  string
+			table string
+			key   []byte
+			skip  bool
+		}{
+			{AuditMissingHeader, ChainFreezerHeaderTable, headerKey(number, hash), false},
+			{AuditMissingBody, ChainFreezerBodiesTable, blockBodyKey(number, hash), !withBodies},
+			{AuditMissingReceipts, ChainFreezerReceiptTable, blockReceiptsKey(number, hash), !withBodies},
+		}
+		dangling bool
+	)
+	for _, check := range checks {
+		if check.skip {
+			continue
+		}
+		inStore, _ := db.Has(check.key)
+		if !frozen {
+			if !inStore {
+				issues = append(issues, &AuditIssue{Kind: check.kind, Number: number, Hash: hash})
+			}
+			continue
+		}
+		if blob, err := db.Ancient(check.table, number); err != nil || len(blob) == 0 {
+			issues = append(issues, &AuditIssue{Kind: check.kind, Number: number, Hash: hash, Frozen: true})
+			continue
+		}
+		dangling = dangling || inStore
+	}
+	if dangling {
+		issues = append(issues, &AuditIssue{Kind: AuditDanglingData, Number: number, Hash: hash, Frozen: true})
+	}
+	return issues
+}
+
+// RepairChain fixes the repairable issues found by AuditChain, returning the
+// number of issues repaired. Unrepairable issues are skipped.
+func RepairChain(db ethdb.Database, issues []*AuditIssue) (int, error) {
+	var (
+		batch    = db.NewBatch()
+		repaired int
+	)
+	for _, issue := range issues {
+		if !issue.Repairable() {
+			continue
+		}
+		switch issue.Kind {
+		case AuditMissingCanonicalHash:
+			WriteCanonicalHash(batch, issue.Hash, issue.Number)
+		case AuditMissingHeaderNumber:
+			WriteHeaderNumber(batch, issue.Hash, issue.Number)
+		case AuditDanglingData:
+			// The data was verified to be present in the freezer by the audit.
+			deleteHeaderWithoutNumber(batch, issue.Hash, issue.Number)
+			DeleteBody(batch, issue.Hash, issue.Number)
+			DeleteReceipts(batch, issue.Hash, issue.Number)
+		case AuditMissingTxLookup:
+			WriteTxLookupEntries(batch, issue.Number, issue.TxHashes)
+		}
+		repaired++
+
+		if batch.ValueSize() >= ethdb.IdealBatchSize {
+			if err := batch.Write(); err != nil {
+				return repaired, err
+			}
+			batch.Reset()
+		}
+	}
+	if err := batch.Write(); err != nil {
+		return repaired, err
+	}
+	return repaired, nil
+}
+
+// RewindChainHead sets the head markers of the chain to the given canonical
+// block, leaving it to the node to discard the chain data above on startup.
+func RewindChainHead(db ethdb.Database, number uint64) error {
+	hash := ReadCanonicalHash(db, number)
+	if hash == (common.Hash{}) {
+		return errors.New("rewind target is not a canonical block")
+	}
+	if !HasHeader(db, hash, number) || !HasBody(db, hash, number) {
+		return errors.New("rewind target block data is missing")
+	}
+	batch := db.NewBatch()
+	WriteHeadHeaderHash(batch, hash)
+	WriteHeadBlockHash(batch, hash)
+	WriteHeadFastBlockHash(batch, hash)
+	return batch.Write()
+}
@@ -0,0 +1,109 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rawdb
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func TestAuditChain(t *testing.T) {
+	db := NewMemoryDatabase()
+
+	// Write a small canonical chain with indexed transactions.
+	var blocks []*types.Block
+	parent := common.Hash{}
+	for i := 0; i < 5; i++ {
+		// The genesis block holds no transactions, lookups can't point at it.
+		var txs []*types.Transaction
+		if i > 0 {
+			txs = append(txs, types.NewTransaction(uint64(i), common.Address{0x11}, big.NewInt(1), 21000, big.NewInt(1), nil))
+		}
+		block := types.NewBlock(&types.Header{Number: big.NewInt(int64(i)), ParentHash: parent}, &types.Body{Transactions: txs}, nil, newTestHasher())
+		WriteBlock(db, block)
+		WriteReceipts(db, block.Hash(), block.NumberU64(), nil)
+		WriteCanonicalHash(db, block.Hash(), block.NumberU64())
+		WriteTxLookupEntriesByBlock(db, block)
+
+		blocks = append(blocks, block)
+		parent = block.Hash()
+	}
+	WriteTxIndexTail(db, 0)
+
+	report, err := AuditChain(db, 0, 4)
+	if err != nil {
+		t.Fatalf("failed to audit chain: %v", err)
+	}
+	if len(report.Issues) != 0 {
+		t.Fatalf("issues found in consistent chain: %v", report.Issues)
+	}
+	// Corrupt the chain data.
+	DeleteCanonicalHash(db, 1)
+	DeleteHeaderNumber(db, blocks[2].Hash())
+	DeleteTxLookupEntry(db, blocks[3].Transactions()[0].Hash())
+	DeleteBody(db, blocks[4].Hash(), 4)
+
+	report, err = AuditChain(db, 0, 4)
+	if err != nil {
+		t.Fatalf("failed to audit chain: %v", err)
+	}
+	want := []struct {
+		kind   string
+		number uint64
+	}{
+		{AuditMissingCanonicalHash, 1},
+		{AuditMissingHeaderNumber, 2},
+		{AuditMissingTxLookup, 3},
+		{AuditMissingBody, 4},
+	}
+	if len(report.Issues) != len(want) {
+		t.Fatalf("wrong number of issues: have %v, want %d", report.Issues, len(want))
+	}
+	for i, issue := range report.Issues {
+		if issue.Kind != want[i].kind || issue.Number != want[i].number {
+			t.Errorf("issue %d: have %q at %d, want %q at %d", i, issue.Kind, issue.Number, want[i].kind, want[i].number)
+		}
+	}
+	if issue := report.FirstUnrepairable(); issue == nil || issue.Number != 4 {
+		t.Fatalf("wrong first unrepairable issue: %v", issue)
+	}
+	// Repair and verify only the missing body is left.
+	repaired, err := RepairChain(db, report.Issues)
+	if err != nil {
+		t.Fatalf("failed to repair chain: %v", err)
+	}
+	if repaired != 3 {
+		t.Fatalf("wrong number of repaired issues: have %d, want 3", repaired)
+	}
+	report, err = AuditChain(db, 0, 4)
+	if err != nil {
+		t.Fatalf("failed to audit chain: %v", err)
+	}
+	if len(report.Issues) != 1 || report.Issues[0].Kind != AuditMissingBody {
+		t.Fatalf("unexpected issues after repair: %v", report.Issues)
+	}
+	// Rewind below the missing body.
+	if err := RewindChainHead(db, 3); err != nil {
+		t.Fatalf("failed to rewind chain head: %v", err)
+	}
+	if head := ReadHeadBlockHash(db); head != blocks[3].Hash() {
+		t.Fatalf("wrong head block after rewind: have %x, want %x", head, blocks[3].Hash())
+	}
+}
@@ -409,6 +409,9 @@ func InspectDatabase(db ethdb.Database, keyPrefix, keyStart []byte) error {
 		headers            stat
 		bodies             stat
 		receipts           stat
+		haltReasons        stat
+		revertReasons      stat
+		warmSlots          stat
 		tds                stat
 		numHashPairings    stat
 		hashNumPairings    stat
@@ -418,6 +421,7 @@ func InspectDatabase(db ethdb.Database, keyPrefix, keyStart []byte) error {
 		storageTries       stat
 		codes              stat
 		txLookups          stat
+		txSenders          stat
 		accountSnaps       stat
 		storageSnaps       stat
 		preimages          stat
@@ -476,6 +480,12 @@ func InspectDatabase(db ethdb.Database, keyPrefix, keyStart []byte) error {
 				bodies.add(size)
 			case bytes.HasPrefix(key, blockReceiptsPrefix) && len(key) == (len(blockReceiptsPrefix)+8+common.HashLength):
 				receipts.add(size)
+			case bytes.HasPrefix(key, blockHaltReasonsPrefix) && len(key) == (len(blockHaltReasonsPrefix)+8+common.HashLength):
+				haltReasons.add(size)
+			case bytes.HasPrefix(key, blockRevertReasonsPrefix) && len(key) == (len(blockRevertReasonsPrefix)+8+common.HashLength):
+				revertReasons.add(size)
+			case bytes.HasPrefix(key, blockWarmSlotsPrefix) && len(key) == (len(blockWarmSlotsPrefix)+8+common.HashLength):
+				warmSlots.add(size)
 			case bytes.HasPrefix(key, headerPrefix) && bytes.HasSuffix(key, headerTDSuffix):
 				tds.add(size)
 			case bytes.HasPrefix(key, headerPrefix) && bytes.HasSuffix(key, headerHashSuffix):
@@ -494,6 +504,8 @@ func InspectDatabase(db ethdb.Database, keyPrefix, keyStart []byte) error {
 				codes.add(size)
 			case bytes.HasPrefix(key, txLookupPrefix) && len(key) == (len(txLookupPrefix)+common.HashLength):
 				txLookups.add(size)
+			case bytes.HasPrefix(key, txSenderPrefix) && len(key) == (len(txSenderPrefix)+common.HashLength):
+				txSenders.add(size)
 			case bytes.HasPrefix(key, SnapshotAccountPrefix) && len(key) == (len(SnapshotAccountPrefix)+common.HashLength):
 				accountSnaps.add(size)
 			case bytes.HasPrefix(key, SnapshotStoragePrefix) && len(key) == (len(SnapshotStoragePrefix)+2*common.HashLength):
@@ -609,10 +621,14 @@ func InspectDatabase(db ethdb.Database, keyPrefix, keyStart []byte) error {
 		{"Key-Value store", "Headers", headers.sizeString(), headers.countString()},
 		{"Key-Value store", "Bodies", bodies.sizeString(), bodies.countString()},
 		{"Key-Value store", "Receipt lists", receipts.sizeString(), receipts.countString()},
+		{"Key-Value store", "Halt reasons", haltReasons.sizeString(), haltReasons.countString()},
+		{"Key-Value store", "Revert reasons", revertReasons.sizeString(), revertReasons.countString()},
+		{"Key-Value store", "Warm slots", warmSlots.sizeString(), warmSlots.countString()},
 		{"Key-Value store", "Difficulties (deprecated)", tds.sizeString(), tds.countString()},
 		{"Key-Value store", "Block number->hash", numHashPairings.sizeString(), numHashPairings.countString()},
 		{"Key-Value store", "Block hash->number", hashNumPairings.sizeString(), hashNumPairings.countString()},
 		{"Key-Value store", "Transaction index", txLookups.sizeString(), txLookups.countString()},
+		{"Key-Value store", "Transaction senders", txSenders.sizeString(), txSenders.countString()},
 		{"Key-Value store", "Log index filter-map rows", filterMapRows.sizeString(), filterMapRows.countString()},
 		{"Key-Value store", "Log index last-block-of-map", filterMapLastBlock.sizeString(), filterMapLastBlock.countString()},
 		{"Key-Value store", "Log index block-lv", filterMapBlockLV.sizeString(), filterMapBlockLV.countString()},
@@ -667,12 +683,12 @@ func InspectDatabase(db ethdb.Database, keyPrefix, keyStart []byte) error {
 
 // This is the list of known 'metadata' keys stored in the databasse.
 var knownMetadataKeys = [][]byte{
-	databaseVersionKey, headHeaderKey, headBlockKey, headFastBlockKey, headFinalizedBlockKey,
+	databaseVersionKey, schemaVersionKey, headHeaderKey, headBlockKey, headFastBlockKey, headFinalizedBlockKey,
 	lastPivotKey, fastTrieProgressKey, snapshotDisabledKey, SnapshotRootKey, snapshotJournalKey,
 	snapshotGeneratorKey, snapshotRecoveryKey, txIndexTailKey, fastTxLookupLimitKey,
-	uncleanShutdownKey, badBlockKey, transitionStatusKey, skeletonSyncStatusKey,
+	uncleanShutdownKey, badBlockKey, reorgJournalKey, transitionStatusKey, skeletonSyncStatusKey,
 	persistentStateIDKey, trieJournalKey, snapshotSyncStatusKey, snapSyncStatusFlagKey,
-	filterMapsRangeKey, headStateHistoryIndexKey, VerkleTransitionStatePrefix,
+	filterMapsRangeKey, headStateHistoryIndexKey, VerkleTransitionStatePrefix, schemeConversionKey,
 }
 
 // printChainMetadata prints out chain metadata to stderr.
@@ -697,6 +713,7 @@ func ReadChainMetadata(db ethdb.KeyValueStore) [][]string {
 
 	data := [][]string{
 		{"databaseVersion", pp(ReadDatabaseVersion(db))},
+		{"schemaVersion", pp(ReadSchemaVersion(db))},
 		{"headBlockHash", fmt.Sprintf("%v", ReadHeadBlockHash(db))},
 		{"headFastBlockHash", fmt.Sprintf("%v", ReadHeadFastBlockHash(db))},
 		{"headHeaderHash", fmt.Sprintf("%v", ReadHeadHeaderHash(db))},
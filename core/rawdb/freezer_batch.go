@@ -98,7 +98,7 @@ func (batch *freezerBatch) commit() (item uint64, writeSize int64, err error) {
 type freezerTableBatch struct {
 	t *freezerTable
 
-	sb          *snappyBuffer
+	compressor  itemCompressor
 	encBuffer   writeBuffer
 	dataBuffer  []byte
 	indexBuffer []byte
@@ -109,8 +109,11 @@ type freezerTableBatch struct {
 // newBatch creates a new batch for the freezer table.
 func (t *freezerTable) newBatch() *freezerTableBatch {
 	batch := &freezerTableBatch{t: t}
-	if !t.config.noSnappy {
-		batch.sb = new(snappyBuffer)
+	switch {
+	case t.zstd != nil:
+		batch.compressor = &zstdBuffer{codec: t.zstd}
+	case !t.config.noSnappy:
+		batch.compressor = new(snappyBuffer)
 	}
 	batch.reset()
 	return batch
@@ -138,8 +141,8 @@ func (batch *freezerTableBatch) Append(item uint64, data interface{}) error {
 		return err
 	}
 	encItem := batch.encBuffer.data
-	if batch.sb != nil {
-		encItem = batch.sb.compress(encItem)
+	if batch.compressor != nil {
+		encItem = batch.compressor.compress(encItem)
 	}
 	return batch.appendItem(encItem)
 }
@@ -153,8 +156,8 @@ func (batch *freezerTableBatch) AppendRaw(item uint64, blob []byte) error {
 	}
 
 	encItem := blob
-	if batch.sb != nil {
-		encItem = batch.sb.compress(blob)
+	if batch.compressor != nil {
+		encItem = batch.compressor.compress(blob)
 	}
 	return batch.appendItem(encItem)
 }
@@ -231,6 +234,12 @@ func (batch *freezerTableBatch) commit() error {
 	return nil
 }
 
+// itemCompressor compresses the items appended to a batch. The returned slice is
+// only valid until the next call.
+type itemCompressor interface {
+	compress(data []byte) []byte
+}
+
 // snappyBuffer writes snappy in block format, and can be reused. It is
 // reset when WriteTo is called.
 type snappyBuffer struct {
@@ -87,7 +87,7 @@ func (i *indexEntry) bounds(end *indexEntry) (startOffset, endOffset, fileId uin
 }
 
 // freezerTable represents a single chained data table within the freezer (e.g. blocks).
-// It consists of a data file (snappy encoded arbitrary data blobs) and an indexEntry
+// It consists of a data file (snappy or zstd encoded arbitrary data blobs) and an indexEntry
 // file (uncompressed 64 bit indices into the data file).
 type freezerTable struct {
 	items      atomic.Uint64 // Number of items stored in the table (including items removed from tail)
@@ -101,6 +101,7 @@ type freezerTable struct {
 	itemHidden atomic.Uint64
 
 	config      freezerTableConfig // table configuration (compression, prunability). Note: compression flag does not apply retroactively to existing files
+	zstd        *zstdCodec         // zstd compressor of the items, nil for snappy or uncompressed tables
 	readonly    bool
 	maxFileSize uint32 // Max file size for data-files
 	name        string
@@ -138,11 +139,20 @@ func newTable(path string, name string, readMeter, writeMeter *metrics.Meter, si
 	if err := os.MkdirAll(path, 0755); err != nil {
 		return nil, err
 	}
-	var idxName string
-	if config.noSnappy {
+	// Existing tables keep the compression they were created with
+	config = resolveCompression(path, name, config)
+
+	var idxName, metaName string
+	switch {
+	case config.noSnappy:
 		idxName = fmt.Sprintf("%s.ridx", name) // raw index file
-	} else {
+		metaName = fmt.Sprintf("%s.meta", name)
+	case config.zstd:
+		idxName = fmt.Sprintf("%s.zidx", name) // zstd compressed index file
+		metaName = fmt.Sprintf("%s.zmeta", name)
+	default:
 		idxName = fmt.Sprintf("%s.cidx", name) // compressed index file
+		metaName = fmt.Sprintf("%s.meta", name)
 	}
 	var (
 		err   error
@@ -155,7 +165,7 @@ func newTable(path string, name string, readMeter, writeMeter *metrics.Meter, si
 		if err != nil {
 			return nil, err
 		}
-		meta, err = openFreezerFileForReadOnly(filepath.Join(path, fmt.Sprintf("%s.meta", name)))
+		meta, err = openFreezerFileForReadOnly(filepath.Join(path, metaName))
 		if err != nil {
 			return nil, err
 		}
@@ -164,11 +174,19 @@ func newTable(path string, name string, readMeter, writeMeter *metrics.Meter, si
 		if err != nil {
 			return nil, err
 		}
-		meta, err = openFreezerFileForAppend(filepath.Join(path, fmt.Sprintf("%s.meta", name)))
+		meta, err = openFreezerFileForAppend(filepath.Join(path, metaName))
 		if err != nil {
 			return nil, err
 		}
 	}
+	var codec *zstdCodec
+	if config.zstd {
+		if codec, err = openZstdCodec(path, name); err != nil {
+			index.Close()
+			meta.Close()
+			return nil, err
+		}
+	}
 	// Load metadata from the file. The tag will be true if legacy metadata
 	// is detected.
 	metadata, err := newMetadata(meta)
@@ -188,6 +206,7 @@ func newTable(path string, name string, readMeter, writeMeter *metrics.Meter, si
 		path:        path,
 		logger:      log.New("database", path, "table", name),
 		config:      config,
+		zstd:        codec,
 		readonly:    readonly,
 		maxFileSize: maxFilesize,
 	}
@@ -861,6 +880,10 @@ func (t *freezerTable) Close() error {
 	t.head = nil
 	t.metadata.file = nil
 
+	if t.zstd != nil {
+		t.zstd.close()
+		t.zstd = nil
+	}
 	if errs != nil {
 		return fmt.Errorf("%v", errs)
 	}
@@ -872,9 +895,12 @@ func (t *freezerTable) openFile(num uint32, opener func(string) (*os.File, error
 	var exist bool
 	if f, exist = t.files[num]; !exist {
 		var name string
-		if t.config.noSnappy {
+		switch {
+		case t.config.noSnappy:
 			name = fmt.Sprintf("%s.%04d.rdat", t.name, num)
-		} else {
+		case t.config.zstd:
+			name = fmt.Sprintf("%s.%04d.zdat", t.name, num)
+		default:
 			name = fmt.Sprintf("%s.%04d.cdat", t.name, num)
 		}
 		f, err = opener(filepath.Join(t.path, name))
@@ -989,13 +1015,13 @@ func (t *freezerTable) RetrieveItems(start, count, maxBytes uint64) ([][]byte, e
 		offset += diskSize
 		decompressedSize := diskSize
 		if !t.config.noSnappy {
-			decompressedSize, _ = snappy.DecodedLen(item)
+			decompressedSize = t.decodedLen(item)
 		}
 		if i > 0 && maxBytes != 0 && uint64(outputSize+decompressedSize) > maxBytes {
 			break
 		}
 		if !t.config.noSnappy {
-			data, err := snappy.Decode(nil, item)
+			data, err := t.decompress(item)
 			if err != nil {
 				return nil, err
 			}
@@ -1161,7 +1187,7 @@ func (t *freezerTable) RetrieveBytes(item, offset, length uint64) ([]byte, error
 		}
 		t.readMeter.Mark(int64(itemSize))
 
-		data, err := snappy.Decode(nil, buf)
+		data, err := t.decompress(buf)
 		if err != nil {
 			return nil, err
 		}
@@ -1172,6 +1198,23 @@ func (t *freezerTable) RetrieveBytes(item, offset, length uint64) ([]byte, error
 	}
 }
 
+// decodedLen returns the length of a compressed item once decompressed.
+func (t *freezerTable) decodedLen(item []byte) int {
+	if t.zstd != nil {
+		return t.zstd.decodedLen(item)
+	}
+	n, _ := snappy.DecodedLen(item)
+	return n
+}
+
+// decompress decompresses an item of the table.
+func (t *freezerTable) decompress(item []byte) ([]byte, error) {
+	if t.zstd != nil {
+		return t.zstd.decompress(item)
+	}
+	return snappy.Decode(nil, item)
+}
+
 // size returns the total data size in the freezer table.
 func (t *freezerTable) size() (uint64, error) {
 	t.lock.RLock()
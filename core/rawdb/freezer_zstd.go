@@ -0,0 +1,346 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rawdb
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/gofrs/flock"
+	"github.com/klauspost/compress/dict"
+	"github.com/klauspost/compress/zstd"
+)
+
+const (
+	// zstdDictSamples is the maximum number of items sampled from a table for
+	// training the compression dictionary.
+	zstdDictSamples = 16384
+
+	// zstdDictSize is the maximum size of the trained compression dictionary.
+	zstdDictSize = 112 * 1024
+
+	// zstdMigrationDir is the name of the staging folder in which a table is
+	// rebuilt with zstd compression before being moved into place.
+	zstdMigrationDir = "zstd-migration"
+)
+
+// zstdCodec wraps the zstd encoder and decoder of a freezer table, both set up
+// with the table's dictionary if one has been trained.
+type zstdCodec struct {
+	enc *zstd.Encoder
+	dec *zstd.Decoder
+}
+
+// newZstdCodec creates a codec with the given (optional) dictionary.
+func newZstdCodec(dictionary []byte) (*zstdCodec, error) {
+	var (
+		encOpts = []zstd.EOption{zstd.WithEncoderLevel(zstd.SpeedBetterCompression), zstd.WithEncoderConcurrency(1)}
+		decOpts = []zstd.DOption{zstd.WithDecoderConcurrency(0)}
+	)
+	if len(dictionary) > 0 {
+		encOpts = append(encOpts, zstd.WithEncoderDict(dictionary))
+		decOpts = append(decOpts, zstd.WithDecoderDicts(dictionary))
+	}
+	enc, err := zstd.NewWriter(nil, encOpts...)
+	if err != nil {
+		return nil, err
+	}
+	dec, err := zstd.NewReader(nil, decOpts...)
+	if err != nil {
+		enc.Close()
+		return nil, err
+	}
+	return &zstdCodec{enc: enc, dec: dec}, nil
+}
+
+// openZstdCodec creates the codec of the named table, loading the dictionary
+// stored next to the table files if present.
+func openZstdCodec(path, name string) (*zstdCodec, error) {
+	dictionary, err := os.ReadFile(filepath.Join(path, fmt.Sprintf("%s.zdict", name)))
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	return newZstdCodec(dictionary)
+}
+
+// decodedLen returns the size of the item once decompressed, as recorded in
+// the zstd frame header. The encoder omits it for tiny items, which are then
+// decompressed to be measured.
+func (c *zstdCodec) decodedLen(item []byte) int {
+	var header zstd.Header
+	if err := header.Decode(item); err == nil && header.HasFCS {
+		return int(header.FrameContentSize)
+	}
+	data, err := c.decompress(item)
+	if err != nil {
+		return 0
+	}
+	return len(data)
+}
+
+// decompress decompresses a single item.
+func (c *zstdCodec) decompress(item []byte) ([]byte, error) {
+	return c.dec.DecodeAll(item, nil)
+}
+
+// close releases the resources held by the encoder and decoder.
+func (c *zstdCodec) close() {
+	c.enc.Close()
+	c.dec.Close()
+}
+
+// zstdBuffer writes zstd frames, and can be reused.
+type zstdBuffer struct {
+	codec *zstdCodec
+	dst   []byte
+}
+
+// compress zstd-compresses the data.
+func (s *zstdBuffer) compress(data []byte) []byte {
+	s.dst = s.codec.enc.EncodeAll(data, s.dst[:0])
+	return s.dst
+}
+
+// resolveCompression returns the table configuration matching the files already
+// present on disk. A table that exists keeps the compression it was created or
+// migrated with, new tables use the configured one.
+func resolveCompression(path, name string, config freezerTableConfig) freezerTableConfig {
+	if config.noSnappy {
+		return config
+	}
+	switch {
+	case common.FileExist(filepath.Join(path, fmt.Sprintf("%s.zidx", name))):
+		config.zstd = true
+	case common.FileExist(filepath.Join(path, fmt.Sprintf("%s.cidx", name))):
+		config.zstd = false
+	}
+	return config
+}
+
+// trainZstdDict builds a compression dictionary from items sampled evenly
+// across the table. Nil is returned if the table is too small to train on, or
+// if no dictionary can be derived from its items.
+func trainZstdDict(t *freezerTable) (dictionary []byte, err error) {
+	var (
+		tail  = t.itemHidden.Load()
+		head  = t.items.Load()
+		step  = max((head-tail)/zstdDictSamples, 1)
+		input [][]byte
+	)
+	for item := tail; item < head; item += step {
+		blob, err := t.Retrieve(item)
+		if err != nil {
+			return nil, err
+		}
+		if len(blob) > 0 {
+			input = append(input, blob)
+		}
+	}
+	if len(input) < 64 {
+		return nil, nil
+	}
+	// The builder panics if the samples share no content repeated often enough,
+	// the table is then compressed without a dictionary.
+	defer func() {
+		if r := recover(); r != nil {
+			log.Warn("Failed to train compression dictionary", "table", t.name, "err", r)
+			dictionary, err = nil, nil
+		}
+	}()
+	return dict.BuildZstdDict(input, dict.Options{
+		MaxDictSize: zstdDictSize,
+		HashBytes:   6,
+		ZstdLevel:   zstd.SpeedBetterCompression,
+	})
+}
+
+// CompressFreezerTable migrates a table of the chain freezer from snappy to zstd
+// compression, using a dictionary trained on the table's own items. The table is
+// rebuilt in a staging folder and only replaces the original once complete, so
+// an interrupted migration can simply be restarted. The ancient store must not
+// be in use by a running node.
+func CompressFreezerTable(ancient string, tableName string) error {
+	path := resolveChainFreezerDir(ancient)
+
+	config, exist := chainFreezerTableConfigs[tableName]
+	if !exist {
+		var names []string
+		for name := range chainFreezerTableConfigs {
+			names = append(names, name)
+		}
+		return fmt.Errorf("unknown table, supported ones: %v", names)
+	}
+	if config.noSnappy {
+		return fmt.Errorf("table %s is stored uncompressed", tableName)
+	}
+	lock := flock.New(filepath.Join(path, "FLOCK"))
+	if locked, err := lock.TryLock(); err != nil {
+		return err
+	} else if !locked {
+		return errors.New("locking failed, ancient store is in use")
+	}
+	defer lock.Unlock()
+
+	// If the migration already completed, only drop any leftover snappy files
+	if resolveCompression(path, tableName, config).zstd {
+		return removeSnappyTable(path, tableName)
+	}
+	staging := filepath.Join(path, zstdMigrationDir)
+	if err := os.RemoveAll(staging); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(staging, 0755); err != nil {
+		return err
+	}
+	src, err := newFreezerTable(path, tableName, config, true)
+	if err != nil {
+		return err
+	}
+	srcSize, _ := src.size()
+	err = buildZstdTable(src, staging, config)
+	src.Close()
+	if err != nil {
+		return err
+	}
+	// Move the new table into place. The index file goes last, as its presence
+	// marks the table as migrated.
+	files, err := os.ReadDir(staging)
+	if err != nil {
+		return err
+	}
+	var index string
+	for _, file := range files {
+		if strings.HasSuffix(file.Name(), ".zidx") {
+			index = file.Name()
+			continue
+		}
+		if err := os.Rename(filepath.Join(staging, file.Name()), filepath.Join(path, file.Name())); err != nil {
+			return err
+		}
+	}
+	if err := os.Rename(filepath.Join(staging, index), filepath.Join(path, index)); err != nil {
+		return err
+	}
+	if err := os.RemoveAll(staging); err != nil {
+		return err
+	}
+	if err := removeSnappyTable(path, tableName); err != nil {
+		return err
+	}
+	config.zstd = true
+	migrated, err := newFreezerTable(path, tableName, config, true)
+	if err != nil {
+		return err
+	}
+	defer migrated.Close()
+
+	dstSize, _ := migrated.size()
+	log.Info("Migrated freezer table to zstd", "table", tableName, "before", common.StorageSize(srcSize), "after", common.StorageSize(dstSize))
+	return nil
+}
+
+// buildZstdTable recreates the src table in the given folder with zstd compression.
+func buildZstdTable(src *freezerTable, path string, config freezerTableConfig) error {
+	log.Info("Training compression dictionary", "table", src.name)
+	dictionary, err := trainZstdDict(src)
+	if err != nil {
+		return err
+	}
+	if dictionary != nil {
+		if err := os.WriteFile(filepath.Join(path, fmt.Sprintf("%s.zdict", src.name)), dictionary, 0644); err != nil {
+			return err
+		}
+	}
+	config.zstd = true
+	dst, err := newFreezerTable(path, src.name, config, false)
+	if err != nil {
+		return err
+	}
+	if err := copyFreezerTable(src, dst); err != nil {
+		dst.Close()
+		return err
+	}
+	return dst.Close()
+}
+
+// copyFreezerTable appends all the visible items of src to the empty table dst.
+// Items hidden in src are written as empty placeholders and hidden in dst as well,
+// so that both tables share the same item numbering.
+func copyFreezerTable(src, dst *freezerTable) error {
+	var (
+		tail   = src.itemHidden.Load()
+		head   = src.items.Load()
+		batch  = dst.newBatch()
+		start  = time.Now()
+		logged = time.Now()
+	)
+	for item := uint64(0); item < tail; item++ {
+		if err := batch.appendItem(nil); err != nil {
+			return err
+		}
+	}
+	for item := tail; item < head; item++ {
+		blob, err := src.Retrieve(item)
+		if err != nil {
+			return err
+		}
+		if err := batch.AppendRaw(item, blob); err != nil {
+			return err
+		}
+		if time.Since(logged) > 8*time.Second {
+			log.Info("Migrating freezer table", "table", src.name, "item", item, "head", head, "elapsed", common.PrettyDuration(time.Since(start)))
+			logged = time.Now()
+		}
+	}
+	if err := batch.commit(); err != nil {
+		return err
+	}
+	if err := dst.truncateTail(tail); err != nil {
+		return err
+	}
+	return dst.Sync()
+}
+
+// removeSnappyTable deletes the index, metadata and data files of the snappy
+// version of a table.
+func removeSnappyTable(path, name string) error {
+	files, err := os.ReadDir(path)
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		var (
+			fname  = file.Name()
+			remove = fname == name+".cidx" || fname == name+".meta"
+		)
+		if strings.HasPrefix(fname, name+".") && strings.HasSuffix(fname, ".cdat") {
+			remove = true
+		}
+		if remove {
+			if err := os.Remove(filepath.Join(path, fname)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rawdb
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/stretchr/testify/require"
+)
+
+// TestZstdFreezerTable tests writing and reading back a zstd compressed table.
+func TestZstdFreezerTable(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	f, err := newTable(dir, "test", metrics.NewMeter(), metrics.NewMeter(), metrics.NewGauge(), 50, freezerTableConfig{zstd: true}, false)
+	require.NoError(t, err)
+	writeChunks(t, f, 255, 15)
+	f.Close()
+
+	// Reopen the table without requesting zstd, the files on disk must win
+	f, err = newTable(dir, "test", metrics.NewMeter(), metrics.NewMeter(), metrics.NewGauge(), 50, freezerTableConfig{}, true)
+	require.NoError(t, err)
+	defer f.Close()
+
+	require.True(t, f.config.zstd)
+	require.True(t, common.FileExist(filepath.Join(dir, "test.zidx")))
+	for y := 0; y < 255; y++ {
+		got, err := f.Retrieve(uint64(y))
+		require.NoError(t, err)
+		require.Equal(t, getChunk(15, y), got)
+	}
+	items, err := f.RetrieveItems(0, 255, 15*100)
+	require.NoError(t, err)
+	require.Len(t, items, 100)
+}
+
+// TestCompressFreezerTable tests migrating a snappy table of the chain freezer
+// to zstd, retaining the pruned tail and the table contents.
+func TestCompressFreezerTable(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ancient = t.TempDir()
+		path    = filepath.Join(ancient, ChainFreezerName)
+		config  = chainFreezerTableConfigs[ChainFreezerReceiptTable]
+		items   = 500
+		tail    = uint64(120)
+	)
+	f, err := newFreezerTable(path, ChainFreezerReceiptTable, config, false)
+	require.NoError(t, err)
+	batch := f.newBatch()
+	for i := 0; i < items; i++ {
+		blob := append(bytes.Repeat([]byte("receipt"), 20), byte(i), byte(i>>8))
+		require.NoError(t, batch.AppendRaw(uint64(i), blob))
+	}
+	require.NoError(t, batch.commit())
+	require.NoError(t, f.truncateTail(tail))
+	f.Close()
+
+	require.NoError(t, CompressFreezerTable(ancient, ChainFreezerReceiptTable))
+	require.False(t, common.FileExist(filepath.Join(path, ChainFreezerReceiptTable+".cidx")))
+	_, err = os.Stat(filepath.Join(path, zstdMigrationDir))
+	require.True(t, os.IsNotExist(err))
+
+	// Running the migration again is a noop
+	require.NoError(t, CompressFreezerTable(ancient, ChainFreezerReceiptTable))
+
+	f, err = newFreezerTable(path, ChainFreezerReceiptTable, config, true)
+	require.NoError(t, err)
+	defer f.Close()
+
+	require.True(t, f.config.zstd)
+	require.Equal(t, uint64(items), f.items.Load())
+	require.Equal(t, tail, f.itemHidden.Load())
+
+	_, err = f.Retrieve(tail - 1)
+	require.ErrorIs(t, err, errOutOfBounds)
+	for i := tail; i < uint64(items); i++ {
+		got, err := f.Retrieve(i)
+		require.NoError(t, err)
+		require.Equal(t, append(bytes.Repeat([]byte("receipt"), 20), byte(i), byte(i>>8)), got)
+	}
+}
@@ -31,6 +31,9 @@ var (
 	// databaseVersionKey tracks the current database version.
 	databaseVersionKey = []byte("DatabaseVersion")
 
+	// schemaVersionKey tracks the last schema migration applied to the database.
+	schemaVersionKey = []byte("SchemaVersion")
+
 	// headHeaderKey tracks the latest known header's hash.
 	headHeaderKey = []byte("LastHeader")
 
@@ -76,6 +79,9 @@ var (
 	// trieJournalKey tracks the in-memory trie node layers across restarts.
 	trieJournalKey = []byte("TrieJournal")
 
+	// schemeConversionKey tracks the progress of an in-place state scheme conversion.
+	schemeConversionKey = []byte("SchemeConversion")
+
 	// headStateHistoryIndexKey tracks the ID of the latest state history that has
 	// been indexed.
 	headStateHistoryIndexKey = []byte("LastStateHistoryIndex")
@@ -95,6 +101,9 @@ var (
 	// badBlockKey tracks the list of bad blocks seen by local
 	badBlockKey = []byte("InvalidBlock")
 
+	// reorgJournalKey tracks the list of recent canonical chain reorgs
+	reorgJournalKey = []byte("ReorgJournal")
+
 	// uncleanShutdownKey tracks the list of local crashes
 	uncleanShutdownKey = []byte("unclean-shutdown") // config prefix for the db
 
@@ -113,6 +122,10 @@ var (
 	blockBodyPrefix     = []byte("b") // blockBodyPrefix + num (uint64 big endian) + hash -> block body
 	blockReceiptsPrefix = []byte("r") // blockReceiptsPrefix + num (uint64 big endian) + hash -> block receipts
 
+	blockHaltReasonsPrefix   = []byte("R") // blockHaltReasonsPrefix + num (uint64 big endian) + hash -> halt reasons of failed transactions
+	blockWarmSlotsPrefix     = []byte("W") // blockWarmSlotsPrefix + num (uint64 big endian) + hash -> storage slots accessed by the block
+	blockRevertReasonsPrefix = []byte("E") // blockRevertReasonsPrefix + num (uint64 big endian) + hash -> decoded revert reasons of failed transactions
+
 	txLookupPrefix        = []byte("l") // txLookupPrefix + hash -> transaction/receipt lookup metadata
 	bloomBitsPrefix       = []byte("B") // bloomBitsPrefix + bit (uint16 big endian) + section (uint64 big endian) + hash -> bloom bits
 	SnapshotAccountPrefix = []byte("a") // SnapshotAccountPrefix + account hash -> account trie value
@@ -147,6 +160,9 @@ var (
 
 	CliqueSnapshotPrefix = []byte("clique-")
 
+	scheduledTxPrefix = []byte("scheduled-tx-") // scheduledTxPrefix + hash -> scheduled transaction
+	txSenderPrefix    = []byte("tx-sender-")    // txSenderPrefix + hash -> transaction sender address
+
 	BestUpdateKey         = []byte("update-")    // bigEndian64(syncPeriod) -> RLP(types.LightClientUpdate)  (nextCommittee only referenced by root hash)
 	FixedCommitteeRootKey = []byte("fixedRoot-") // bigEndian64(syncPeriod) -> committee root hash
 	SyncCommitteeKey      = []byte("committee-") // bigEndian64(syncPeriod) -> serialized committee
@@ -214,6 +230,21 @@ func blockReceiptsKey(number uint64, hash common.Hash) []byte {
 	return append(append(blockReceiptsPrefix, encodeBlockNumber(number)...), hash.Bytes()...)
 }
 
+// blockHaltReasonsKey = blockHaltReasonsPrefix + num (uint64 big endian) + hash
+func blockHaltReasonsKey(number uint64, hash common.Hash) []byte {
+	return append(append(blockHaltReasonsPrefix, encodeBlockNumber(number)...), hash.Bytes()...)
+}
+
+// blockWarmSlotsKey = blockWarmSlotsPrefix + num (uint64 big endian) + hash
+func blockWarmSlotsKey(number uint64, hash common.Hash) []byte {
+	return append(append(blockWarmSlotsPrefix, encodeBlockNumber(number)...), hash.Bytes()...)
+}
+
+// blockRevertReasonsKey = blockRevertReasonsPrefix + num (uint64 big endian) + hash
+func blockRevertReasonsKey(number uint64, hash common.Hash) []byte {
+	return append(append(blockRevertReasonsPrefix, encodeBlockNumber(number)...), hash.Bytes()...)
+}
+
 // txLookupKey = txLookupPrefix + hash
 func txLookupKey(hash common.Hash) []byte {
 	return append(txLookupPrefix, hash.Bytes()...)
@@ -267,6 +298,16 @@ func configKey(hash common.Hash) []byte {
 	return append(configPrefix, hash.Bytes()...)
 }
 
+// scheduledTxKey = scheduledTxPrefix + hash
+func scheduledTxKey(hash common.Hash) []byte {
+	return append(scheduledTxPrefix, hash.Bytes()...)
+}
+
+// txSenderKey = txSenderPrefix + hash
+func txSenderKey(hash common.Hash) []byte {
+	return append(txSenderPrefix, hash.Bytes()...)
+}
+
 // genesisStateSpecKey = genesisPrefix + hash
 func genesisStateSpecKey(hash common.Hash) []byte {
 	return append(genesisPrefix, hash.Bytes()...)
@@ -0,0 +1,187 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rawdb
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// SchemaMigration is a single upgrade of the database schema.
+type SchemaMigration struct {
+	Version  uint64   // Schema version reached once the migration is applied
+	Name     string   // Short description of the migration
+	Prefixes [][]byte // Key prefixes modified by the migration, backed up before running it
+	Apply    func(db ethdb.KeyValueStore) error
+}
+
+// schemaMigrations is the ordered list of schema migrations. Versions must be
+// consecutive, starting from one. New migrations are only ever appended.
+var schemaMigrations = []SchemaMigration{
+	{
+		Version:  1,
+		Name:     "Drop deprecated eth2 transition status",
+		Prefixes: [][]byte{transitionStatusKey},
+		Apply: func(db ethdb.KeyValueStore) error {
+			return db.Delete(transitionStatusKey)
+		},
+	},
+	{
+		Version:  2,
+		Name:     "Drop deprecated fast sync transaction lookup limit",
+		Prefixes: [][]byte{fastTxLookupLimitKey},
+		Apply: func(db ethdb.KeyValueStore) error {
+			return db.Delete(fastTxLookupLimitKey)
+		},
+	},
+}
+
+// SchemaMigrationConfig contains the options of a schema migration run.
+type SchemaMigrationConfig struct {
+	DryRun    bool   // Only report the pending migrations without applying them
+	BackupDir string // Directory to export the modified keys to before each migration, disabled if empty
+}
+
+// LatestSchemaVersion returns the schema version of a fully migrated database.
+func LatestSchemaVersion() uint64 {
+	return latestSchemaVersion(schemaMigrations)
+}
+
+func latestSchemaVersion(migrations []SchemaMigration) uint64 {
+	if len(migrations) == 0 {
+		return 0
+	}
+	return migrations[len(migrations)-1].Version
+}
+
+// MigrateSchema brings the database schema up to date, applying the pending
+// migrations in order. The schema version is persisted after each migration,
+// so an interrupted run resumes where it stopped. The migrations which were
+// (or in dry-run mode, would have been) applied are returned.
+func MigrateSchema(db ethdb.KeyValueStore, config SchemaMigrationConfig) ([]SchemaMigration, error) {
+	return migrateSchema(db, schemaMigrations, config)
+}
+
+func migrateSchema(db ethdb.KeyValueStore, migrations []SchemaMigration, config SchemaMigrationConfig) ([]SchemaMigration, error) {
+	for i, m := range migrations {
+		if m.Version != uint64(i+1) {
+			return nil, fmt.Errorf("schema migration %q has version %d, expected %d", m.Name, m.Version, i+1)
+		}
+	}
+	var (
+		latest  = latestSchemaVersion(migrations)
+		current uint64
+	)
+	if version := ReadSchemaVersion(db); version != nil {
+		current = *version
+	} else if ReadDatabaseVersion(db) == nil && ReadHeadHeaderHash(db) == (common.Hash{}) {
+		// Fresh database, there is nothing to migrate
+		if !config.DryRun {
+			WriteSchemaVersion(db, latest)
+		}
+		return nil, nil
+	}
+	if current > latest {
+		return nil, fmt.Errorf("database schema version is v%d, only v%d is supported", current, latest)
+	}
+	pending := migrations[current:]
+	if len(pending) == 0 || config.DryRun {
+		for _, m := range pending {
+			log.Info("Pending database schema migration", "version", m.Version, "name", m.Name)
+		}
+		return pending, nil
+	}
+	for _, m := range pending {
+		start := time.Now()
+		if config.BackupDir != "" {
+			if err := backupSchemaKeys(db, m, config.BackupDir); err != nil {
+				return nil, fmt.Errorf("failed to back up schema v%d: %w", m.Version, err)
+			}
+		}
+		log.Info("Migrating database schema", "version", m.Version, "name", m.Name)
+		if err := m.Apply(db); err != nil {
+			return nil, fmt.Errorf("schema migration v%d (%s) failed: %w", m.Version, m.Name, err)
+		}
+		WriteSchemaVersion(db, m.Version)
+		log.Info("Migrated database schema", "version", m.Version, "elapsed", common.PrettyDuration(time.Since(start)))
+	}
+	return pending, nil
+}
+
+// schemaBackupHeader mirrors the header of the database dumps created by the
+// 'geth db export' command, so that backups can be restored with 'geth db import'.
+type schemaBackupHeader struct {
+	Magic    string
+	Version  uint64
+	Kind     string
+	UnixTime uint64
+}
+
+// backupSchemaKeys exports the keys modified by the migration into a file of
+// the backup directory.
+func backupSchemaKeys(db ethdb.KeyValueStore, m SchemaMigration, dir string) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	name := filepath.Join(dir, fmt.Sprintf("schema-v%d.rlp", m.Version))
+	f, err := os.OpenFile(name, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	w := bufio.NewWriter(f)
+	if err := rlp.Encode(w, &schemaBackupHeader{
+		Magic:    "gethdbdump",
+		Kind:     fmt.Sprintf("schema-v%d", m.Version),
+		UnixTime: uint64(time.Now().Unix()),
+	}); err != nil {
+		return err
+	}
+	var count int
+	for _, prefix := range m.Prefixes {
+		it := db.NewIterator(prefix, nil)
+		for it.Next() {
+			// Entries are (op, key, value) triplets, op zero being an insertion
+			for _, item := range []interface{}{byte(0), it.Key(), it.Value()} {
+				if err := rlp.Encode(w, item); err != nil {
+					it.Release()
+					return err
+				}
+			}
+			count++
+		}
+		err := it.Error()
+		it.Release()
+		if err != nil {
+			return err
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	log.Info("Backed up database keys", "version", m.Version, "file", name, "count", count)
+	return f.Sync()
+}
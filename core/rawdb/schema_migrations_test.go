@@ -0,0 +1,116 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rawdb
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+func testMigrations(applied *[]uint64) []SchemaMigration {
+	migration := func(version uint64, key string) SchemaMigration {
+		return SchemaMigration{
+			Version:  version,
+			Name:     key,
+			Prefixes: [][]byte{[]byte(key)},
+			Apply: func(db ethdb.KeyValueStore) error {
+				*applied = append(*applied, version)
+				return db.Put([]byte(key), []byte("migrated"))
+			},
+		}
+	}
+	return []SchemaMigration{migration(1, "one"), migration(2, "two"), migration(3, "three")}
+}
+
+func TestSchemaMigrationFreshDatabase(t *testing.T) {
+	var (
+		db      = NewMemoryDatabase()
+		applied []uint64
+	)
+	done, err := migrateSchema(db, testMigrations(&applied), SchemaMigrationConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(done) != 0 || len(applied) != 0 {
+		t.Fatalf("migrations applied on fresh database: %v", applied)
+	}
+	if v := ReadSchemaVersion(db); v == nil || *v != 3 {
+		t.Fatalf("unexpected schema version: %v", v)
+	}
+}
+
+func TestSchemaMigrationOrder(t *testing.T) {
+	var (
+		db      = NewMemoryDatabase()
+		backup  = t.TempDir()
+		applied []uint64
+	)
+	WriteDatabaseVersion(db, 9)
+	WriteSchemaVersion(db, 1)
+	db.Put([]byte("two"), []byte("original"))
+
+	// A dry run must only report the pending migrations
+	pending, err := migrateSchema(db, testMigrations(&applied), SchemaMigrationConfig{DryRun: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pending) != 2 || len(applied) != 0 {
+		t.Fatalf("unexpected dry run: pending %d, applied %v", len(pending), applied)
+	}
+	// A real run applies them in order, backing up the keys first
+	if _, err := migrateSchema(db, testMigrations(&applied), SchemaMigrationConfig{BackupDir: backup}); err != nil {
+		t.Fatal(err)
+	}
+	if len(applied) != 2 || applied[0] != 2 || applied[1] != 3 {
+		t.Fatalf("unexpected migrations applied: %v", applied)
+	}
+	if v := ReadSchemaVersion(db); v == nil || *v != 3 {
+		t.Fatalf("unexpected schema version: %v", v)
+	}
+	f, err := os.Open(filepath.Join(backup, "schema-v2.rlp"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	var (
+		stream   = rlp.NewStream(f, 0)
+		header   schemaBackupHeader
+		op       byte
+		key, val []byte
+	)
+	if err := stream.Decode(&header); err != nil || header.Magic != "gethdbdump" {
+		t.Fatalf("invalid backup header: %v %v", header, err)
+	}
+	for _, item := range []interface{}{&op, &key, &val} {
+		if err := stream.Decode(item); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if string(key) != "two" || string(val) != "original" {
+		t.Fatalf("unexpected backup entry: %s=%s", key, val)
+	}
+	// Newer schema versions are rejected
+	WriteSchemaVersion(db, 4)
+	if _, err := migrateSchema(db, testMigrations(&applied), SchemaMigrationConfig{}); err == nil {
+		t.Fatal("expected newer schema version to be rejected")
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -112,6 +113,45 @@ func (d iterativeDump) OnRoot(root common.Hash) {
 	}{root})
 }
 
+// streamDump is a DumpCollector-implementation which writes the dump in the format
+// of Dump, without collecting the accounts in memory first.
+type streamDump struct {
+	w        io.Writer
+	accounts int
+	err      error
+}
+
+func (d *streamDump) write(format string, args ...any) {
+	if d.err == nil {
+		_, d.err = fmt.Fprintf(d.w, format, args...)
+	}
+}
+
+// OnRoot implements DumpCollector interface
+func (d *streamDump) OnRoot(root common.Hash) {
+	d.write(`{"root":"%x","accounts":{`, root)
+}
+
+// OnAccount implements DumpCollector interface
+func (d *streamDump) OnAccount(addr *common.Address, account DumpAccount) {
+	key := fmt.Sprintf("pre(%s)", account.AddressHash)
+	if addr != nil {
+		key = addr.String()
+	}
+	enc, err := json.Marshal(account)
+	if err != nil {
+		if d.err == nil {
+			d.err = err
+		}
+		return
+	}
+	if d.accounts > 0 {
+		d.write(",")
+	}
+	d.write("%q:%s", key, enc)
+	d.accounts++
+}
+
 // DumpToCollector iterates the state according to the given options and inserts
 // the items into a collector for aggregation or serialization.
 //
@@ -265,6 +305,21 @@ func (s *StateDB) Dump(opts *DumpConfig) []byte {
 	return json
 }
 
+// StreamDump writes the state to w in the JSON format of Dump, without holding all
+// accounts in memory. As with RawDump, the next key is included if the dump was
+// aborted early.
+func (s *StateDB) StreamDump(opts *DumpConfig, w io.Writer) error {
+	d := &streamDump{w: w}
+	next := s.DumpToCollector(d, opts)
+	d.write("}")
+	if next != nil {
+		enc, _ := json.Marshal(next)
+		d.write(`,"next":%s`, enc)
+	}
+	d.write("}")
+	return d.err
+}
+
 // IterativeDump dumps out accounts as json-objects, delimited by linebreaks on stdout
 func (s *StateDB) IterativeDump(opts *DumpConfig, output *json.Encoder) {
 	s.DumpToCollector(iterativeDump{output}, opts)
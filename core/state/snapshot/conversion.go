@@ -61,7 +61,28 @@ func GenerateTrie(snaptree *Tree, root common.Hash, src ethdb.Database, dst ethd
 	}
 	defer acctIt.Release()
 
-	scheme := snaptree.triedb.Scheme()
+	return generateTrie(acctIt, func(account common.Hash) (StorageIterator, error) {
+		return snaptree.StorageIterator(root, account, common.Hash{})
+	}, root, snaptree.triedb.Scheme(), src, dst)
+}
+
+// GenerateTrieFromDisk regenerates the whole state trie in the given scheme from
+// the flat state persisted on disk, ignoring the in-memory snapshot layers. The
+// flat state must be completely generated for the given root, which is verified
+// against the regenerated one.
+func GenerateTrieFromDisk(db ethdb.Database, root common.Hash, scheme string, dst ethdb.KeyValueWriter) error {
+	dl := &diskLayer{diskdb: db, root: root}
+	acctIt := dl.AccountIterator(common.Hash{})
+	defer acctIt.Release()
+
+	return generateTrie(acctIt, func(account common.Hash) (StorageIterator, error) {
+		return dl.StorageIterator(account, common.Hash{}), nil
+	}, root, scheme, db, dst)
+}
+
+// generateTrie regenerates the whole state trie from the given account iterator
+// and the storage iterators it opens, migrating the contract code from src.
+func generateTrie(acctIt AccountIterator, storageIt func(account common.Hash) (StorageIterator, error), root common.Hash, scheme string, src ethdb.Database, dst ethdb.KeyValueWriter) error {
 	got, err := generateTrieRoot(dst, scheme, acctIt, common.Hash{}, stackTrieGenerate, func(dst ethdb.KeyValueWriter, accountHash, codeHash common.Hash, stat *generateStats) (common.Hash, error) {
 		// Migrate the code first, commit the contract code into the tmp db.
 		if codeHash != types.EmptyCodeHash {
@@ -72,13 +93,13 @@ func GenerateTrie(snaptree *Tree, root common.Hash, src ethdb.Database, dst ethd
 			rawdb.WriteCode(dst, codeHash, code)
 		}
 		// Then migrate all storage trie nodes into the tmp db.
-		storageIt, err := snaptree.StorageIterator(root, accountHash, common.Hash{})
+		it, err := storageIt(accountHash)
 		if err != nil {
 			return common.Hash{}, err
 		}
-		defer storageIt.Release()
+		defer it.Release()
 
-		hash, err := generateTrieRoot(dst, scheme, storageIt, accountHash, stackTrieGenerate, nil, stat, false)
+		hash, err := generateTrieRoot(dst, scheme, it, accountHash, stackTrieGenerate, nil, stat, false)
 		if err != nil {
 			return common.Hash{}, err
 		}
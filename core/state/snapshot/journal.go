@@ -93,6 +93,23 @@ func ParseGeneratorStatus(generatorBlob []byte) string {
 		generator.Done, generator.Accounts, generator.Slots, generator.Storage, m)
 }
 
+// GeneratorDone reports whether the flat state persisted in the database has been
+// completely generated. It doesn't tell which state the flat state belongs to.
+func GeneratorDone(db ethdb.KeyValueReader) bool {
+	if rawdb.ReadSnapshotDisabled(db) {
+		return false
+	}
+	blob := rawdb.ReadSnapshotGenerator(db)
+	if len(blob) == 0 {
+		return false
+	}
+	var generator journalGenerator
+	if err := rlp.DecodeBytes(blob, &generator); err != nil {
+		return false
+	}
+	return generator.Done
+}
+
 // loadAndParseJournal tries to parse the snapshot journal in latest format.
 func loadAndParseJournal(db ethdb.KeyValueStore, base *diskLayer) (snapshot, journalGenerator, error) {
 	// Retrieve the disk layer generator. It must exist, no matter the
@@ -176,9 +176,11 @@ func (s *stateObject) getState(key common.Hash) (common.Hash, common.Hash) {
 func (s *stateObject) GetCommittedState(key common.Hash) common.Hash {
 	// If we have a pending write or clean cached, return that
 	if value, pending := s.pendingStorage[key]; pending {
+		s.db.StorageCached++
 		return value
 	}
 	if value, cached := s.originStorage[key]; cached {
+		s.db.StorageCached++
 		return value
 	}
 	// If the object was destructed in *this* block (and potentially resurrected),
@@ -189,6 +191,7 @@ func (s *stateObject) GetCommittedState(key common.Hash) common.Hash {
 	//   2) we don't have new values, and can deliver empty response back
 	if _, destructed := s.db.stateObjectsDestruct[s.address]; destructed {
 		s.originStorage[key] = common.Hash{} // track the empty slot as origin value
+		s.db.StorageCached++
 		return common.Hash{}
 	}
 	s.db.StorageLoaded++
@@ -19,6 +19,7 @@ package state
 import (
 	"bytes"
 	"encoding/json"
+	"reflect"
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -130,6 +131,41 @@ func TestIterativeDump(t *testing.T) {
 	}
 }
 
+func TestStreamDump(t *testing.T) {
+	s := newStateEnv()
+	for i := byte(0); i < 16; i++ {
+		obj := s.state.getOrNewStateObject(common.BytesToAddress([]byte{i}))
+		obj.AddBalance(uint256.NewInt(uint64(i)))
+		obj.SetCode(crypto.Keccak256Hash([]byte{i}), []byte{i})
+	}
+	root, _ := s.state.Commit(0, false, false)
+	s.state, _ = New(root, s.state.db)
+
+	// Both full and partial dumps must match the collected dump
+	for _, conf := range []*DumpConfig{nil, {Max: 5}} {
+		var (
+			buf  bytes.Buffer
+			have Dump
+		)
+		if err := s.state.StreamDump(conf, &buf); err != nil {
+			t.Fatalf("failed to stream dump: %v", err)
+		}
+		if err := json.Unmarshal(buf.Bytes(), &have); err != nil {
+			t.Fatalf("failed to decode streamed dump: %v", err)
+		}
+		// Round-trip the collected dump through JSON too, dropping the
+		// distinction between empty and missing fields.
+		var want Dump
+		blob, _ := json.Marshal(s.state.RawDump(conf))
+		if err := json.Unmarshal(blob, &want); err != nil {
+			t.Fatalf("failed to decode collected dump: %v", err)
+		}
+		if !reflect.DeepEqual(have, want) {
+			t.Errorf("streamed dump mismatch:\nhave: %+v\nwant: %+v", have, want)
+		}
+	}
+}
+
 func TestNull(t *testing.T) {
 	s := newStateEnv()
 	address := common.HexToAddress("0x823140710bf13990e4500136726d8b55")
@@ -159,6 +159,8 @@ type StateDB struct {
 	StorageUpdated atomic.Int64 // Number of storage slots updated during the state transition
 	StorageDeleted atomic.Int64 // Number of storage slots deleted during the state transition
 	CodeLoaded     int          // Number of contract code loaded during the state transition
+	AccountCached  int          // Number of account reads served without accessing the database
+	StorageCached  int          // Number of storage reads served without accessing the database
 }
 
 // New creates a new state from a given trie.
@@ -285,6 +287,42 @@ func (s *StateDB) Preimages() map[common.Hash][]byte {
 	return s.preimages
 }
 
+// StorageChange is a storage slot whose value was modified within the block.
+type StorageChange struct {
+	Address common.Address `json:"address"`
+	Slot    common.Hash    `json:"slot"`
+	Prev    common.Hash    `json:"prev"`
+	Value   common.Hash    `json:"value"`
+}
+
+// StorageChanges returns the changes made within the current block to the
+// given storage slots. Slots reset back to their original value are omitted.
+// It must be called after the state is finalised and before it is committed.
+//
+// Note, storage wiped by self-destruct is not reported.
+func (s *StateDB) StorageChanges(slots map[common.Address][]common.Hash) []StorageChange {
+	var changes []StorageChange
+	for addr, keys := range slots {
+		obj := s.stateObjects[addr]
+		if obj == nil {
+			continue
+		}
+		for _, key := range keys {
+			value, dirty := obj.pendingStorage[key]
+			if !dirty || value == obj.originStorage[key] {
+				continue
+			}
+			changes = append(changes, StorageChange{
+				Address: addr,
+				Slot:    key,
+				Prev:    obj.originStorage[key],
+				Value:   value,
+			})
+		}
+	}
+	return changes
+}
+
 // AddRefund adds gas to the refund counter
 func (s *StateDB) AddRefund(gas uint64) {
 	s.journal.refundChange(s.refund)
@@ -595,10 +633,12 @@ func (s *StateDB) deleteStateObject(addr common.Address) {
 func (s *StateDB) getStateObject(addr common.Address) *stateObject {
 	// Prefer live objects if any is available
 	if obj := s.stateObjects[addr]; obj != nil {
+		s.AccountCached++
 		return obj
 	}
 	// Short circuit if the account is already destructed in this block.
 	if _, ok := s.stateObjectsDestruct[addr]; ok {
+		s.AccountCached++
 		return nil
 	}
 	s.AccountLoaded++
@@ -1303,6 +1343,7 @@ func (s *StateDB) commit(deleteEmptyObjects bool, noStorageWiping bool, blockNum
 	// Clear the metric markers
 	s.AccountLoaded, s.AccountUpdated, s.AccountDeleted = 0, 0, 0
 	s.StorageLoaded = 0
+	s.AccountCached, s.StorageCached = 0, 0
 	s.StorageUpdated.Store(0)
 	s.StorageDeleted.Store(0)
 
@@ -1443,6 +1484,30 @@ func (s *StateDB) Prepare(rules params.Rules, sender, coinbase common.Address, d
 	s.transientStorage = newTransientStorage()
 }
 
+// AccessedSlots returns the storage slots read or written since the start of
+// the block, ordered by address and slot. Accounts destructed in the block are
+// not included.
+func (s *StateDB) AccessedSlots() types.AccessList {
+	var list types.AccessList
+	for _, addr := range slices.SortedFunc(maps.Keys(s.stateObjects), common.Address.Cmp) {
+		obj := s.stateObjects[addr]
+		keys := make(map[common.Hash]struct{}, len(obj.originStorage)+len(obj.pendingStorage))
+		for _, storage := range []Storage{obj.originStorage, obj.pendingStorage, obj.dirtyStorage} {
+			for key := range storage {
+				keys[key] = struct{}{}
+			}
+		}
+		if len(keys) == 0 {
+			continue
+		}
+		list = append(list, types.AccessTuple{
+			Address:     addr,
+			StorageKeys: slices.SortedFunc(maps.Keys(keys), common.Hash.Cmp),
+		})
+	}
+	return list
+}
+
 // AddAddressToAccessList adds the given address to the access list
 func (s *StateDB) AddAddressToAccessList(addr common.Address) {
 	if s.accessList.AddAddress(addr) {
@@ -31,6 +31,7 @@ import (
 	"testing/quick"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/state/snapshot"
 	"github.com/ethereum/go-ethereum/core/tracing"
@@ -1368,3 +1369,104 @@ func TestStorageDirtiness(t *testing.T) {
 	state.RevertToSnapshot(snap)
 	checkDirty(common.Hash{0x1}, common.Hash{0x1}, true)
 }
+
+// TestStorageChanges tests that the block-level changes of the requested slots
+// are reported, skipping the ones restored to their original value.
+func TestStorageChanges(t *testing.T) {
+	var (
+		addr  = common.HexToAddress("0x1")
+		slotA = common.HexToHash("0xa")
+		slotB = common.HexToHash("0xb")
+		slotC = common.HexToHash("0xc")
+		sdb   = NewDatabaseForTesting()
+	)
+	// The account needs a nonce, empty ones are deleted on finalisation.
+	state, _ := New(types.EmptyRootHash, sdb)
+	state.SetNonce(addr, 1, tracing.NonceChangeUnspecified)
+	state.SetState(addr, slotA, common.HexToHash("0x1"))
+	state.SetState(addr, slotB, common.HexToHash("0x1"))
+	root, _ := state.Commit(0, false, false)
+
+	state, _ = New(root, sdb)
+	state.SetState(addr, slotA, common.HexToHash("0x2"))
+	state.SetState(addr, slotB, common.HexToHash("0x3"))
+	state.Finalise(true)
+	state.SetState(addr, slotB, common.HexToHash("0x1"))
+	state.SetState(addr, slotC, common.HexToHash("0x4"))
+	state.IntermediateRoot(true)
+
+	changes := state.StorageChanges(map[common.Address][]common.Hash{addr: {slotA, slotB}})
+	want := []StorageChange{{Address: addr, Slot: slotA, Prev: common.HexToHash("0x1"), Value: common.HexToHash("0x2")}}
+	if !reflect.DeepEqual(changes, want) {
+		t.Fatalf("storage changes mismatch, got %v, want %v", changes, want)
+	}
+}
+
+// TestReadCounters tests that cold and warm state reads are told apart.
+func TestReadCounters(t *testing.T) {
+	var (
+		addr = common.HexToAddress("0x1")
+		slot = common.HexToHash("0xa")
+		sdb  = NewDatabaseForTesting()
+	)
+	state, _ := New(types.EmptyRootHash, sdb)
+	state.SetBalance(addr, uint256.NewInt(1), tracing.BalanceChangeUnspecified)
+	state.SetState(addr, slot, common.HexToHash("0x1"))
+	root, _ := state.Commit(0, false, false)
+
+	state, _ = New(root, sdb)
+	for i := 0; i < 3; i++ {
+		state.GetBalance(addr)
+		state.GetState(addr, slot)
+	}
+	if state.AccountLoaded != 1 || state.AccountCached != 5 {
+		t.Fatalf("account reads mismatch: cold %d, warm %d", state.AccountLoaded, state.AccountCached)
+	}
+	if state.StorageLoaded != 1 || state.StorageCached != 2 {
+		t.Fatalf("storage reads mismatch: cold %d, warm %d", state.StorageLoaded, state.StorageCached)
+	}
+}
+
+func TestStateDiff(t *testing.T) {
+	var (
+		alice   = common.HexToAddress("0x1")
+		bob     = common.HexToAddress("0x2")
+		carol   = common.HexToAddress("0x3")
+		slotA   = common.HexToHash("0xa")
+		slotB   = common.HexToHash("0xb")
+		sdb     = NewDatabaseForTesting()
+		balance = func(v uint64) *hexutil.U256 { return (*hexutil.U256)(uint256.NewInt(v)) }
+	)
+	state, _ := New(types.EmptyRootHash, sdb)
+	state.SetBalance(alice, uint256.NewInt(10), tracing.BalanceChangeUnspecified)
+	state.SetState(alice, slotA, common.HexToHash("0x1"))
+	state.SetState(alice, slotB, common.HexToHash("0x1"))
+	state.SetBalance(bob, uint256.NewInt(5), tracing.BalanceChangeUnspecified)
+	root, _ := state.Commit(0, false, false)
+
+	state, _ = New(root, sdb)
+	state.SubBalance(alice, uint256.NewInt(3), tracing.BalanceChangeUnspecified)
+	state.SetState(alice, slotA, common.HexToHash("0x2"))
+	state.SetState(alice, slotB, common.HexToHash("0x2"))
+	state.Finalise(true)
+	state.SetState(alice, slotB, common.HexToHash("0x1")) // reset to the original value
+	state.AddBalance(carol, uint256.NewInt(1), tracing.BalanceChangeUnspecified)
+	state.GetBalance(bob) // read only
+	state.IntermediateRoot(true)
+
+	want := []AccountDiff{
+		{
+			Address: alice,
+			Prev:    &AccountValues{Balance: balance(10), CodeHash: types.EmptyCodeHash},
+			Post:    &AccountValues{Balance: balance(7), CodeHash: types.EmptyCodeHash},
+			Storage: []SlotDiff{{Key: slotA, Prev: common.HexToHash("0x1"), Post: common.HexToHash("0x2")}},
+		},
+		{
+			Address: carol,
+			Post:    &AccountValues{Balance: balance(1), CodeHash: types.EmptyCodeHash},
+		},
+	}
+	if diff := state.StateDiff(); !reflect.DeepEqual(diff, want) {
+		t.Fatalf("state diff mismatch, got %+v, want %+v", diff, want)
+	}
+}
@@ -0,0 +1,111 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package state
+
+import (
+	"bytes"
+	"slices"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/holiman/uint256"
+)
+
+// AccountDiff is the change of an account within a block.
+type AccountDiff struct {
+	Address   common.Address `json:"address"`
+	Prev      *AccountValues `json:"prev"`              // Nil if the account did not exist before the block
+	Post      *AccountValues `json:"post"`              // Nil if the account was deleted by the block
+	Destroyed bool           `json:"destroyed"`         // Whether the original storage was wiped
+	Storage   []SlotDiff     `json:"storage,omitempty"` // Modified slots, ordered by key
+}
+
+// AccountValues are the fields of an account reported in a diff. The storage
+// root is omitted, the diff being computed before the state is committed.
+type AccountValues struct {
+	Nonce    hexutil.Uint64 `json:"nonce"`
+	Balance  *hexutil.U256  `json:"balance"`
+	CodeHash common.Hash    `json:"codeHash"`
+}
+
+// SlotDiff is the change of a storage slot within a block.
+type SlotDiff struct {
+	Key  common.Hash `json:"key"`
+	Prev common.Hash `json:"prev"`
+	Post common.Hash `json:"post"`
+}
+
+func newAccountValues(account *types.StateAccount) *AccountValues {
+	if account == nil {
+		return nil
+	}
+	return &AccountValues{
+		Nonce:    hexutil.Uint64(account.Nonce),
+		Balance:  (*hexutil.U256)(account.Balance.Clone()),
+		CodeHash: common.BytesToHash(account.CodeHash),
+	}
+}
+
+func (v *AccountValues) equal(other *AccountValues) bool {
+	if v == nil || other == nil {
+		return v == other
+	}
+	return v.Nonce == other.Nonce && v.CodeHash == other.CodeHash &&
+		(*uint256.Int)(v.Balance).Eq((*uint256.Int)(other.Balance))
+}
+
+// StateDiff returns the accounts and storage slots modified within the current
+// block along with their values before and after the block, ordered by address.
+// It must be called after the state is finalised and before it is committed.
+//
+// Note, the individual slots wiped by a self-destruct are not reported, the
+// account is flagged as destroyed instead.
+func (s *StateDB) StateDiff() []AccountDiff {
+	var diffs []AccountDiff
+	for addr := range s.mutations {
+		diff := AccountDiff{Address: addr}
+
+		// The original account is the one before the first destruction, if any
+		obj := s.stateObjects[addr]
+		if destructed, ok := s.stateObjectsDestruct[addr]; ok {
+			diff.Prev = newAccountValues(destructed.origin)
+			diff.Destroyed = destructed.origin != nil
+		} else if obj != nil {
+			diff.Prev = newAccountValues(obj.origin)
+		}
+		if obj != nil {
+			diff.Post = newAccountValues(&obj.data)
+			for key, value := range obj.pendingStorage {
+				if prev := obj.originStorage[key]; prev != value {
+					diff.Storage = append(diff.Storage, SlotDiff{Key: key, Prev: prev, Post: value})
+				}
+			}
+			slices.SortFunc(diff.Storage, func(a, b SlotDiff) int {
+				return bytes.Compare(a.Key[:], b.Key[:])
+			})
+		}
+		if !diff.Destroyed && len(diff.Storage) == 0 && diff.Prev.equal(diff.Post) {
+			continue
+		}
+		diffs = append(diffs, diff)
+	}
+	slices.SortFunc(diffs, func(a, b AccountDiff) int {
+		return bytes.Compare(a.Address[:], b.Address[:])
+	})
+	return diffs
+}
@@ -56,8 +56,15 @@ func (p *statePrefetcher) Prefetch(block *types.Block, statedb *state.StateDB, c
 		signer  = types.MakeSigner(p.config, header.Number, header.Time)
 		workers errgroup.Group
 		reader  = statedb.Reader()
+		limit   = max(1, 4*runtime.NumCPU()/5)
 	)
-	workers.SetLimit(max(1, 4*runtime.NumCPU()/5)) // Aggressively run the prefetching
+	workers.SetLimit(limit) // Aggressively run the prefetching
+
+	// Every worker slot owns a pool of EVMs, reused by the transactions it runs
+	evms := make(chan *vm.EVMPool, limit)
+	for i := 0; i < limit; i++ {
+		evms <- new(vm.EVMPool)
+	}
 
 	// Iterate over and process the individual transactions
 	for i, tx := range block.Transactions() {
@@ -92,7 +99,17 @@ func (p *statePrefetcher) Prefetch(block *types.Block, statedb *state.StateDB, c
 				}
 			}
 			// Execute the message to preload the implicit touched states
-			evm := vm.NewEVM(NewEVMBlockContext(header, p.chain, nil), stateCpy, p.config, cfg)
+			blockCtx, err := NewEVMBlockContext(header, p.chain, nil)
+			if err != nil {
+				fails.Add(1)
+				return nil // The block can't be processed either, bail out
+			}
+			pool := <-evms
+			evm := pool.Get(blockCtx, stateCpy, p.config, cfg)
+			defer func() {
+				pool.Put(evm)
+				evms <- pool
+			}()
 
 			// Convert the transaction into an executable message and pre-cache its sender
 			msg, err := TransactionToMessage(tx, signer, header.BaseFee)
@@ -30,6 +30,7 @@ import (
 	"github.com/ethereum/go-ethereum/consensus/misc/eip4844"
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/trie"
@@ -429,3 +430,107 @@ func GenerateBadBlock(parent *types.Block, engine consensus.Engine, txs types.Tr
 	}
 	return types.NewBlock(header, body, receipts, trie.NewStackTrie(nil))
 }
+
+// Tests that under the warm slots rules, the storage slots accessed by a block
+// are warm in the next one, but not across the fork boundary.
+func TestWarmSlots(t *testing.T) {
+	var (
+		config   = *params.TestChainConfig
+		key, _   = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		addr     = crypto.PubkeyToAddress(key.PublicKey)
+		contract = common.HexToAddress("0xc0de")
+		slot     = common.Hash{0x01}
+	)
+	config.WarmSlotsTime = u64(20) // Second block, the generated blocks are 10s apart
+
+	gspec := &Genesis{
+		Config: &config,
+		Alloc: types.GenesisAlloc{
+			addr: {Balance: big.NewInt(params.Ether)},
+			// PUSH32 slot, SLOAD, POP, STOP
+			contract: {Code: append(append([]byte{byte(vm.PUSH32)}, slot.Bytes()...), byte(vm.SLOAD), byte(vm.POP), byte(vm.STOP))},
+		},
+	}
+	signer := types.LatestSigner(&config)
+	_, blocks, receipts := GenerateChainWithGenesis(gspec, ethash.NewFaker(), 3, func(i int, b *BlockGen) {
+		tx := types.MustSignNewTx(key, signer, &types.LegacyTx{Nonce: uint64(i), To: &contract, Gas: 50000, GasPrice: b.BaseFee()})
+		b.AddTx(tx)
+	})
+	var (
+		cold = params.TxGas + 3 + params.ColdSloadCostEIP2929 + 2
+		warm = params.TxGas + 3 + params.WarmStorageReadCostEIP2929 + 2
+	)
+	for i, want := range []uint64{cold, cold, warm} {
+		if have := receipts[i][0].GasUsed; have != want {
+			t.Errorf("block %d: gas used mismatch: have %d, want %d", i+1, have, want)
+		}
+	}
+	// Import the chain, the execution must match the generated one
+	db := rawdb.NewMemoryDatabase()
+	chain, err := NewBlockChain(db, gspec, ethash.NewFaker(), nil)
+	if err != nil {
+		t.Fatalf("failed to create chain: %v", err)
+	}
+	defer chain.Stop()
+
+	if _, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("failed to insert chain: %v", err)
+	}
+	if _, ok := rawdb.ReadWarmSlots(db, blocks[0].Hash(), 1); ok {
+		t.Error("warm slots recorded before the fork")
+	}
+	slots, ok := rawdb.ReadWarmSlots(db, blocks[1].Hash(), 2)
+	if !ok {
+		t.Fatal("warm slots not recorded")
+	}
+	if len(slots) != 1 || slots[0].Address != contract || len(slots[0].StorageKeys) != 1 || slots[0].StorageKeys[0] != slot {
+		t.Errorf("wrong warm slots: %v", slots)
+	}
+}
+
+// Tests that activating the block hash history ahead of Prague installs the
+// history contract at the first block of the fork, or in the genesis state,
+// and stores the parent hashes from there on.
+func TestBlockHashHistoryFork(t *testing.T) {
+	for _, fork := range []uint64{0, 20} { // At genesis, at the second block
+		config := *params.TestChainConfig
+		config.BlockHashHistoryTime = u64(fork)
+
+		gspec := &Genesis{Config: &config}
+		_, blocks, _ := GenerateChainWithGenesis(gspec, ethash.NewFaker(), 3, nil)
+
+		chain, err := NewBlockChain(rawdb.NewMemoryDatabase(), gspec, ethash.NewFaker(), nil)
+		if err != nil {
+			t.Fatalf("failed to create chain: %v", err)
+		}
+		if _, err := chain.InsertChain(blocks); err != nil {
+			t.Fatalf("fork %d: failed to insert chain: %v", fork, err)
+		}
+		statedb, err := chain.StateAt(chain.Genesis().Root())
+		if err != nil {
+			t.Fatalf("failed to open genesis state: %v", err)
+		}
+		if installed := statedb.GetCodeSize(params.HistoryStorageAddress) != 0; installed != (fork == 0) {
+			t.Errorf("fork %d: contract installed in genesis state: %v", fork, installed)
+		}
+		for i, block := range blocks {
+			statedb, err := chain.StateAt(block.Root())
+			if err != nil {
+				t.Fatalf("failed to open state of block %d: %v", i+1, err)
+			}
+			active := config.IsBlockHashHistory(block.Number(), block.Time())
+			if installed := statedb.GetCodeSize(params.HistoryStorageAddress) != 0; installed != active {
+				t.Errorf("fork %d, block %d: contract installed: %v, want %v", fork, i+1, installed, active)
+			}
+			var want common.Hash
+			if active {
+				want = block.ParentHash()
+			}
+			slot := common.BigToHash(new(big.Int).Sub(block.Number(), common.Big1))
+			if have := statedb.GetState(params.HistoryStorageAddress, slot); have != want {
+				t.Errorf("fork %d, block %d: stored parent hash mismatch: have %x, want %x", fork, i+1, have, want)
+			}
+		}
+		chain.Stop()
+	}
+}
@@ -490,7 +490,15 @@ func (st *stateTransition) execute() (*ExecutionResult, error) {
 	// Execute the preparatory steps for state transition which includes:
 	// - prepare accessList(post-berlin)
 	// - reset transient storage(eip 1153)
+	// - warm the storage slots accessed by the parent block (experimental)
 	st.state.Prepare(rules, msg.From, st.evm.Context.Coinbase, msg.To, vm.ActivePrecompiles(rules), msg.AccessList)
+	if rules.IsWarmSlots {
+		for _, el := range st.evm.Context.WarmSlots {
+			for _, key := range el.StorageKeys {
+				st.state.AddSlotToAccessList(el.Address, key)
+			}
+		}
+	}
 
 	var (
 		ret   []byte
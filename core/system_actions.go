@@ -0,0 +1,201 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/ethereum/go-ethereum/consensus/misc"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// SystemEnv is the environment the system actions of a block are executed in.
+type SystemEnv struct {
+	Config   *params.ChainConfig
+	Header   *types.Header
+	EVM      *vm.EVM
+	Logs     []*types.Log // Logs of the block transactions, for the post-block actions
+	Requests [][]byte     // EIP-7685 requests collected by the post-block actions
+}
+
+// SystemAction is a protocol-level state transition executed outside of the
+// transactions of a block, such as the irregular state change of a hard fork
+// or a system call into a contract.
+type SystemAction struct {
+	Name   string                                                      // Unique name of the action
+	Active func(config *params.ChainConfig, header *types.Header) bool // Whether the action applies to a block
+	Apply  func(env *SystemEnv) error                                  // Executes the action
+}
+
+// SystemPhase is the point of block processing system actions are executed at.
+type SystemPhase int
+
+const (
+	PreBlock  SystemPhase = iota // Before the transactions of a block
+	PostBlock                    // After the transactions of a block
+)
+
+// RegisterSystemAction adds a system action executed in the given phase, right
+// after the action named after, or after all actions of the phase if after is
+// empty. Actions must be registered before any block is processed, e.g. from an
+// init function of the package introducing the fork.
+func RegisterSystemAction(phase SystemPhase, action SystemAction, after string) error {
+	if action.Name == "" || action.Active == nil || action.Apply == nil {
+		return errors.New("incomplete system action")
+	}
+	var actions *[]SystemAction
+	switch phase {
+	case PreBlock:
+		actions = &preBlockActions
+	case PostBlock:
+		actions = &postBlockActions
+	default:
+		return fmt.Errorf("unknown system action phase %d", phase)
+	}
+	// Names are unique across phases, so actions can be referred to unambiguously.
+	for _, registered := range slices.Concat(preBlockActions, postBlockActions) {
+		if registered.Name == action.Name {
+			return fmt.Errorf("duplicate system action %s", action.Name)
+		}
+	}
+	pos := len(*actions)
+	if after != "" {
+		index := slices.IndexFunc(*actions, func(a SystemAction) bool { return a.Name == after })
+		if index < 0 {
+			return fmt.Errorf("system action %s follows unknown action %s", action.Name, after)
+		}
+		pos = index + 1
+	}
+	*actions = slices.Insert(*actions, pos, action)
+	return nil
+}
+
+// SystemActions returns the names of the system actions of the given phase, in
+// execution order.
+func SystemActions(phase SystemPhase) []string {
+	actions := preBlockActions
+	if phase == PostBlock {
+		actions = postBlockActions
+	}
+	names := make([]string, len(actions))
+	for i, action := range actions {
+		names[i] = action.Name
+	}
+	return names
+}
+
+// preBlockActions are the system actions executed before the transactions of
+// a block, in order.
+var preBlockActions = []SystemAction{
+	{
+		Name: "DAO hard fork",
+		Active: func(config *params.ChainConfig, header *types.Header) bool {
+			return config.DAOForkSupport && config.DAOForkBlock != nil && config.DAOForkBlock.Cmp(header.Number) == 0
+		},
+		Apply: func(env *SystemEnv) error {
+			misc.ApplyDAOHardFork(env.EVM.StateDB)
+			return nil
+		},
+	},
+	{
+		Name: "beacon block root (EIP-4788)",
+		Active: func(config *params.ChainConfig, header *types.Header) bool {
+			return header.ParentBeaconRoot != nil
+		},
+		Apply: func(env *SystemEnv) error {
+			ProcessBeaconBlockRoot(*env.Header.ParentBeaconRoot, env.EVM)
+			return nil
+		},
+	},
+	{
+		Name: "parent block hash (EIP-2935)",
+		Active: func(config *params.ChainConfig, header *types.Header) bool {
+			return config.IsBlockHashHistory(header.Number, header.Time)
+		},
+		Apply: func(env *SystemEnv) error {
+			ProcessParentBlockHash(env.Header.ParentHash, env.EVM)
+			return nil
+		},
+	},
+}
+
+// postBlockActions are the system actions executed after the transactions of
+// a block, in order.
+var postBlockActions = []SystemAction{
+	{
+		Name:   "deposit requests (EIP-6110)",
+		Active: isPrague,
+		Apply: func(env *SystemEnv) error {
+			return ParseDepositLogs(&env.Requests, env.Logs, env.Config)
+		},
+	},
+	{
+		Name:   "withdrawal requests (EIP-7002)",
+		Active: isPrague,
+		Apply: func(env *SystemEnv) error {
+			return ProcessWithdrawalQueue(&env.Requests, env.EVM)
+		},
+	},
+	{
+		Name:   "consolidation requests (EIP-7251)",
+		Active: isPrague,
+		Apply: func(env *SystemEnv) error {
+			return ProcessConsolidationQueue(&env.Requests, env.EVM)
+		},
+	},
+}
+
+func isPrague(config *params.ChainConfig, header *types.Header) bool {
+	return config.IsPrague(header.Number, header.Time)
+}
+
+// applySystemActions executes the active actions of the list in order.
+func applySystemActions(actions []SystemAction, env *SystemEnv) error {
+	for _, action := range actions {
+		if !action.Active(env.Config, env.Header) {
+			continue
+		}
+		if err := action.Apply(env); err != nil {
+			return fmt.Errorf("system action %s failed: %w", action.Name, err)
+		}
+	}
+	return nil
+}
+
+// ProcessPreBlockActions executes the system actions due before the transactions
+// of the given block.
+func ProcessPreBlockActions(header *types.Header, evm *vm.EVM) error {
+	return applySystemActions(preBlockActions, &SystemEnv{Config: evm.ChainConfig(), Header: header, EVM: evm})
+}
+
+// ProcessPostBlockActions executes the system actions due after the transactions
+// of the given block, which emitted the given logs. It returns the EIP-7685
+// requests of the block, nil before Prague.
+func ProcessPostBlockActions(header *types.Header, logs []*types.Log, evm *vm.EVM) ([][]byte, error) {
+	env := &SystemEnv{Config: evm.ChainConfig(), Header: header, EVM: evm, Logs: logs}
+	if env.Config.IsPrague(header.Number, header.Time) {
+		env.Requests = [][]byte{}
+	}
+	if err := applySystemActions(postBlockActions, env); err != nil {
+		return nil, err
+	}
+	return env.Requests, nil
+}
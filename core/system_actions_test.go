@@ -0,0 +1,215 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"math/big"
+	"slices"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/beacon"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/holiman/uint256"
+)
+
+func TestSystemActions(t *testing.T) {
+	statedb, _ := state.New(types.EmptyRootHash, state.NewDatabaseForTesting())
+	for addr, code := range map[common.Address][]byte{
+		params.BeaconRootsAddress:        params.BeaconRootsCode,
+		params.HistoryStorageAddress:     params.HistoryStorageCode,
+		params.WithdrawalQueueAddress:    params.WithdrawalQueueCode,
+		params.ConsolidationQueueAddress: params.ConsolidationQueueCode,
+	} {
+		statedb.SetNonce(addr, 1, tracing.NonceChangeUnspecified)
+		statedb.SetCode(addr, code, tracing.CodeChangeUnspecified)
+	}
+	beaconRoot := common.Hash{0xbe}
+	header := &types.Header{
+		ParentHash:       common.Hash{0x01},
+		Number:           big.NewInt(1),
+		Time:             12,
+		Difficulty:       new(big.Int),
+		ParentBeaconRoot: &beaconRoot,
+	}
+	blockCtx, _ := NewEVMBlockContext(header, nil, new(common.Address))
+	evm := vm.NewEVM(blockCtx, statedb, params.MergedTestChainConfig, vm.Config{})
+	if err := ProcessPreBlockActions(header, evm); err != nil {
+		t.Fatalf("failed to process pre-block actions: %v", err)
+	}
+	const historyBufferLength = 8191 // EIP-4788 ring buffer size
+	rootSlot := common.BigToHash(new(big.Int).SetUint64(header.Time%historyBufferLength + historyBufferLength))
+	if have := statedb.GetState(params.BeaconRootsAddress, rootSlot); have != beaconRoot {
+		t.Errorf("beacon root not stored: have %x, want %x", have, beaconRoot)
+	}
+	if have := getContractStoredBlockHash(statedb, 0, false); have != header.ParentHash {
+		t.Errorf("parent hash not stored: have %x, want %x", have, header.ParentHash)
+	}
+	requests, err := ProcessPostBlockActions(header, nil, evm)
+	if err != nil {
+		t.Fatalf("failed to process post-block actions: %v", err)
+	}
+	if requests == nil {
+		t.Error("missing requests after Prague")
+	}
+	// Before Prague, there are no requests.
+	evm = vm.NewEVM(blockCtx, statedb, params.TestChainConfig, vm.Config{})
+	if requests, err := ProcessPostBlockActions(header, nil, evm); err != nil || requests != nil {
+		t.Errorf("unexpected requests before Prague: %v, %v", requests, err)
+	}
+}
+
+// This test checks that the system calls preceding the transactions are executed
+// in the same order by every caller, beacon root first.
+func TestPreBlockActionsOrder(t *testing.T) {
+	statedb, _ := state.New(types.EmptyRootHash, state.NewDatabaseForTesting())
+	statedb.SetCode(params.BeaconRootsAddress, params.BeaconRootsCode, tracing.CodeChangeUnspecified)
+	statedb.SetCode(params.HistoryStorageAddress, params.HistoryStorageCode, tracing.CodeChangeUnspecified)
+
+	var calls []common.Address
+	hooks := &tracing.Hooks{
+		OnEnter: func(depth int, typ byte, from common.Address, to common.Address, input []byte, gas uint64, value *big.Int) {
+			if depth == 0 {
+				calls = append(calls, to)
+			}
+		},
+	}
+	header := &types.Header{
+		ParentHash:       common.Hash{0x01},
+		Number:           big.NewInt(1),
+		Time:             12,
+		Difficulty:       new(big.Int),
+		ParentBeaconRoot: new(common.Hash),
+	}
+	blockCtx, _ := NewEVMBlockContext(header, nil, new(common.Address))
+	evm := vm.NewEVM(blockCtx, statedb, params.MergedTestChainConfig, vm.Config{Tracer: hooks})
+	if err := ProcessPreBlockActions(header, evm); err != nil {
+		t.Fatalf("failed to process pre-block actions: %v", err)
+	}
+	want := []common.Address{params.BeaconRootsAddress, params.HistoryStorageAddress}
+	if !slices.Equal(calls, want) {
+		t.Fatalf("wrong system call order: have %v, want %v", calls, want)
+	}
+}
+
+// This test checks that the DAO hard fork is applied by the pre-block actions.
+func TestPreBlockActionsDAOFork(t *testing.T) {
+	config := *params.NonActivatedConfig
+	config.DAOForkBlock = big.NewInt(2)
+	config.DAOForkSupport = true
+
+	statedb, _ := state.New(types.EmptyRootHash, state.NewDatabaseForTesting())
+	drained := params.DAODrainList()[0]
+	statedb.SetBalance(drained, uint256.NewInt(1000), tracing.BalanceChangeUnspecified)
+
+	for number, want := range []uint64{0, 0, 1000} {
+		header := &types.Header{Number: big.NewInt(int64(number)), Difficulty: new(big.Int)}
+		blockCtx, _ := NewEVMBlockContext(header, nil, new(common.Address))
+		evm := vm.NewEVM(blockCtx, statedb, &config, vm.Config{})
+		if err := ProcessPreBlockActions(header, evm); err != nil {
+			t.Fatalf("block %d: failed to process pre-block actions: %v", number, err)
+		}
+		if have := statedb.GetBalance(params.DAORefundContract).Uint64(); have != want {
+			t.Fatalf("block %d: wrong refund contract balance: have %d, want %d", number, have, want)
+		}
+	}
+	if have := statedb.GetBalance(drained); !have.IsZero() {
+		t.Fatalf("DAO account not drained: %d left", have)
+	}
+}
+
+// This test checks that chains generated without setting the parent beacon roots
+// can be imported, i.e. the zero beacon roots of the generated headers are also
+// stored by the generator.
+func TestGenerateChainSystemActions(t *testing.T) {
+	var (
+		config = *params.MergedTestChainConfig
+		gspec  = &Genesis{
+			Config: &config,
+			Alloc: types.GenesisAlloc{
+				params.BeaconRootsAddress:        {Nonce: 1, Code: params.BeaconRootsCode},
+				params.HistoryStorageAddress:     {Nonce: 1, Code: params.HistoryStorageCode},
+				params.WithdrawalQueueAddress:    {Nonce: 1, Code: params.WithdrawalQueueCode},
+				params.ConsolidationQueueAddress: {Nonce: 1, Code: params.ConsolidationQueueCode},
+			},
+			BaseFee:    big.NewInt(params.InitialBaseFee),
+			Difficulty: common.Big1,
+			GasLimit:   5_000_000,
+		}
+		engine = beacon.New(ethash.NewFaker())
+	)
+	_, blocks, _ := GenerateChainWithGenesis(gspec, engine, 3, func(i int, gen *BlockGen) {})
+
+	chain, err := NewBlockChain(rawdb.NewMemoryDatabase(), gspec, engine, nil)
+	if err != nil {
+		t.Fatalf("failed to create blockchain: %v", err)
+	}
+	defer chain.Stop()
+
+	if i, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("insert error (block %d): %v", blocks[i].NumberU64(), err)
+	}
+	statedb, _ := chain.State()
+	head := blocks[len(blocks)-1]
+	slot := common.BigToHash(new(big.Int).SetUint64(head.Time() % 8191))
+	if have := statedb.GetState(params.BeaconRootsAddress, slot); have != common.BigToHash(new(big.Int).SetUint64(head.Time())) {
+		t.Fatalf("beacon root timestamp not stored: have %x", have)
+	}
+}
+
+// This test checks that system actions are registered at the requested position,
+// and that duplicate and dangling registrations are rejected.
+func TestRegisterSystemAction(t *testing.T) {
+	pre, post := preBlockActions, postBlockActions
+	t.Cleanup(func() { preBlockActions, postBlockActions = pre, post })
+
+	newAction := func(name string) SystemAction {
+		return SystemAction{
+			Name:   name,
+			Active: func(*params.ChainConfig, *types.Header) bool { return true },
+			Apply:  func(*SystemEnv) error { return nil },
+		}
+	}
+	if err := RegisterSystemAction(PreBlock, newAction("first"), ""); err != nil {
+		t.Fatalf("failed to register action: %v", err)
+	}
+	if err := RegisterSystemAction(PreBlock, newAction("second"), "DAO hard fork"); err != nil {
+		t.Fatalf("failed to register action: %v", err)
+	}
+	want := []string{"DAO hard fork", "second", "beacon block root (EIP-4788)", "parent block hash (EIP-2935)", "first"}
+	if have := SystemActions(PreBlock); !slices.Equal(have, want) {
+		t.Fatalf("wrong action order: have %v, want %v", have, want)
+	}
+	if err := RegisterSystemAction(PostBlock, newAction("first"), ""); err == nil {
+		t.Error("duplicate action registered")
+	}
+	if err := RegisterSystemAction(PostBlock, newAction("third"), "second"); err == nil {
+		t.Error("action registered after an action of another phase")
+	}
+	if err := RegisterSystemAction(PostBlock, SystemAction{Name: "incomplete"}, ""); err == nil {
+		t.Error("incomplete action registered")
+	}
+	if have := SystemActions(PostBlock); len(have) != len(post) {
+		t.Fatalf("rejected actions registered: %v", have)
+	}
+}
@@ -22,6 +22,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/log"
 )
@@ -62,6 +63,7 @@ type txIndexer struct {
 	// be pruned and not available locally.
 	cutoff uint64
 	db     ethdb.Database
+	heads  chan uint64 // Latest chain head, set by the blockchain
 	term   chan chan struct{}
 	closed chan struct{}
 }
@@ -73,13 +75,14 @@ func newTxIndexer(limit uint64, chain *BlockChain) *txIndexer {
 		limit:  limit,
 		cutoff: cutoff,
 		db:     chain.db,
+		heads:  make(chan uint64, 1),
 		term:   make(chan chan struct{}),
 		closed: make(chan struct{}),
 	}
 	indexer.head.Store(indexer.resolveHead())
 	indexer.tail.Store(rawdb.ReadTxIndexTail(chain.db))
 
-	go indexer.loop(chain)
+	go indexer.loop()
 
 	var msg string
 	if limit == 0 {
@@ -225,18 +228,15 @@ func (indexer *txIndexer) resolveHead() uint64 {
 }
 
 // loop is the scheduler of the indexer, assigning indexing/unindexing tasks depending
-// on the received chain event.
-func (indexer *txIndexer) loop(chain *BlockChain) {
+// on the received chain head.
+func (indexer *txIndexer) loop() {
 	defer close(indexer.closed)
 
-	// Listening to chain events and manipulate the transaction indexes.
+	// Listening to chain heads and manipulate the transaction indexes.
 	var (
-		stop   chan struct{} // Non-nil if background routine is active
-		done   chan struct{} // Non-nil if background routine is active
-		headCh = make(chan ChainHeadEvent)
-		sub    = chain.SubscribeChainHeadEvent(headCh)
+		stop chan struct{} // Non-nil if background routine is active
+		done chan struct{} // Non-nil if background routine is active
 	)
-	defer sub.Unsubscribe()
 
 	// Validate the transaction indexes and repair if necessary
 	head := indexer.head.Load()
@@ -251,12 +251,12 @@ func (indexer *txIndexer) loop(chain *BlockChain) {
 	}
 	for {
 		select {
-		case h := <-headCh:
-			indexer.head.Store(h.Header.Number.Uint64())
+		case head := <-indexer.heads:
+			indexer.head.Store(head)
 			if done == nil {
 				stop = make(chan struct{})
 				done = make(chan struct{})
-				go indexer.run(h.Header.Number.Uint64(), stop, done)
+				go indexer.run(head, stop, done)
 			}
 
 		case <-done:
@@ -322,6 +322,37 @@ func (indexer *txIndexer) txIndexProgress() TxIndexProgress {
 	return indexer.report(indexer.head.Load(), indexer.tail.Load())
 }
 
+// Name implements ChainIndex, returning the identifier of the transaction index.
+func (indexer *txIndexer) Name() string {
+	return "transactions"
+}
+
+// SetHead implements ChainIndex, scheduling the indexing up to the new head.
+func (indexer *txIndexer) SetHead(head *types.Header) {
+	for {
+		select {
+		case <-indexer.heads:
+		case indexer.heads <- head.Number.Uint64():
+			return
+		}
+	}
+}
+
+// Status implements ChainIndex, returning the progress of the transaction index.
+func (indexer *txIndexer) Status() IndexStatus {
+	var (
+		head     = indexer.head.Load()
+		tail     = indexer.tail.Load()
+		progress = indexer.report(head, tail)
+	)
+	return IndexStatus{
+		Head:      head,
+		Tail:      tail,
+		Indexed:   progress.Indexed,
+		Remaining: progress.Remaining,
+	}
+}
+
 // close shutdown the indexer. Safe to be called for multiple times.
 func (indexer *txIndexer) close() {
 	ch := make(chan struct{})
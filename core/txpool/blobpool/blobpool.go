@@ -344,6 +344,9 @@ type BlobPool struct {
 
 	discoverFeed event.Feed // Event feed to send out new tx events on pool discovery (reorg excluded)
 	insertFeed   event.Feed // Event feed to send out new tx events on pool inclusion (reorg included)
+	dropFeed     event.Feed // Event feed to send out dropped tx events
+
+	dropped []txpool.DroppedTx // Transactions dropped since the last notification
 
 	lock sync.RWMutex // Mutex protecting the pool during reorg handling
 }
@@ -457,7 +460,7 @@ func (p *BlobPool) Init(gasTip uint64, head *types.Header, reserver txpool.Reser
 
 	// Pool initialized, attach the blob limbo to it to track blobs included
 	// recently but not yet finalized
-	p.limbo, err = newLimbo(p.chain.Config(), limbodir)
+	p.limbo, err = newLimbo(p.chain.Config(), limbodir, p.config.Retention)
 	if err != nil {
 		p.Close()
 		return err
@@ -473,6 +476,8 @@ func (p *BlobPool) Init(gasTip uint64, head *types.Header, reserver txpool.Reser
 	for p.stored > p.config.Datacap {
 		p.drop()
 	}
+	p.dropped = nil // nobody is subscribed yet
+
 	// Update the metrics and return the constructed pool
 	datacapGauge.Update(int64(p.config.Datacap))
 	p.updateStorageMetrics()
@@ -574,6 +579,9 @@ func (p *BlobPool) recheck(addr common.Address, inclusions map[common.Hash]uint6
 		for i := 0; i < len(txs); i++ {
 			ids = append(ids, txs[i].id)
 			nonces = append(nonces, txs[i].nonce)
+			if gapped {
+				p.trackDropped(addr, txs[i], txpool.DropNonceGap)
+			}
 
 			p.stored -= uint64(txs[i].storageSize)
 			p.lookup.untrack(txs[i])
@@ -692,6 +700,7 @@ func (p *BlobPool) recheck(addr common.Address, inclusions map[common.Hash]uint6
 		for j := i; j < len(txs); j++ {
 			ids = append(ids, txs[j].id)
 			nonces = append(nonces, txs[j].nonce)
+			p.trackDropped(addr, txs[j], txpool.DropNonceGap)
 
 			p.spent[addr] = new(uint256.Int).Sub(p.spent[addr], txs[j].costCap)
 			p.stored -= uint64(txs[j].storageSize)
@@ -730,6 +739,7 @@ func (p *BlobPool) recheck(addr common.Address, inclusions map[common.Hash]uint6
 
 			ids = append(ids, last.id)
 			nonces = append(nonces, last.nonce)
+			p.trackDropped(addr, last, txpool.DropUnpayable)
 
 			p.spent[addr] = new(uint256.Int).Sub(p.spent[addr], last.costCap)
 			p.stored -= uint64(last.storageSize)
@@ -770,6 +780,7 @@ func (p *BlobPool) recheck(addr common.Address, inclusions map[common.Hash]uint6
 
 			ids = append(ids, last.id)
 			nonces = append(nonces, last.nonce)
+			p.trackDropped(addr, last, txpool.DropOverflow)
 
 			p.spent[addr] = new(uint256.Int).Sub(p.spent[addr], last.costCap)
 			p.stored -= uint64(last.storageSize)
@@ -860,10 +871,11 @@ func (p *BlobPool) Reset(oldHead, newHead *types.Header) {
 		if len(adds) > 0 {
 			p.insertFeed.Send(core.NewTxsEvent{Txs: adds})
 		}
+		p.notifyDropped()
 	}
 	// Flush out any blobs from limbo that are older than the latest finality
 	if p.chain.Config().IsCancun(newHead.Number, newHead.Time) {
-		p.limbo.finalize(p.chain.CurrentFinalBlock())
+		p.limbo.finalize(p.chain.CurrentFinalBlock(), newHead.Number.Uint64())
 	}
 	// Reset the price heap for the new set of basefee/blobfee pairs
 	var (
@@ -1095,12 +1107,14 @@ func (p *BlobPool) SetGasTip(tip *big.Int) {
 					p.spent[addr] = new(uint256.Int).Sub(p.spent[addr], txs[i].costCap)
 					p.stored -= uint64(tx.storageSize)
 					p.lookup.untrack(tx)
+					p.trackDropped(addr, tx, txpool.DropUnderpriced)
 					txs[i] = nil
 
 					// Drop everything afterwards, no gaps allowed
 					for j, tx := range txs[i+1:] {
 						ids = append(ids, tx.id)
 						nonces = append(nonces, tx.nonce)
+						p.trackDropped(addr, tx, txpool.DropNonceGap)
 
 						p.spent[addr] = new(uint256.Int).Sub(p.spent[addr], tx.costCap)
 						p.stored -= uint64(tx.storageSize)
@@ -1132,6 +1146,8 @@ func (p *BlobPool) SetGasTip(tip *big.Int) {
 			}
 		}
 	}
+	p.notifyDropped()
+
 	log.Debug("Blobpool tip threshold updated", "tip", tip)
 	pooltipGauge.Update(tip.Int64())
 	p.updateStorageMetrics()
@@ -1436,6 +1452,38 @@ func (p *BlobPool) GetBlobs(vhashes []common.Hash, version byte) ([]*kzg4844.Blo
 	return blobs, commitments, proofs, nil
 }
 
+// IncludedTx returns a blob transaction included in a recent block, along with
+// its sidecar, or nil if the pool doesn't retain it. The blobs of included
+// transactions are retained until finality and the configured retention window.
+func (p *BlobPool) IncludedTx(hash common.Hash) *types.Transaction {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	tx, err := p.limbo.get(hash)
+	if err != nil {
+		return nil
+	}
+	return tx
+}
+
+// IncludedBlobTx returns the retained included transaction carrying the blob
+// with the given versioned hash, or nil if there is none.
+func (p *BlobPool) IncludedBlobTx(vhash common.Hash) *types.Transaction {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	owner, ok := p.limbo.owner(vhash)
+	if !ok {
+		return nil
+	}
+	tx, err := p.limbo.get(owner)
+	if err != nil {
+		log.Error("Tracked blob transaction missing from limbo", "tx", owner, "err", err)
+		return nil
+	}
+	return tx
+}
+
 // AvailableBlobs returns the number of blobs that are available in the subpool.
 func (p *BlobPool) AvailableBlobs(vhashes []common.Hash) int {
 	available := 0
@@ -1470,6 +1518,10 @@ func (p *BlobPool) Add(txs []*types.Transaction, sync bool) []error {
 		p.discoverFeed.Send(core.NewTxsEvent{Txs: adds})
 		p.insertFeed.Send(core.NewTxsEvent{Txs: adds})
 	}
+	p.lock.Lock()
+	p.notifyDropped()
+	p.lock.Unlock()
+
 	return errs
 }
 
@@ -1563,6 +1615,7 @@ func (p *BlobPool) add(tx *types.Transaction) (err error) {
 			// Shitty situation, but try to recover gracefully instead of going boom
 			log.Error("Failed to delete replaced transaction", "id", prev.id, "err", err)
 		}
+		p.trackDropped(from, prev, txpool.DropReplaced)
 		// Update the transaction index
 		p.index[from][offset] = meta
 		p.spent[from] = new(uint256.Int).Sub(p.spent[from], prev.costCap)
@@ -1669,6 +1722,7 @@ func (p *BlobPool) drop() {
 	}
 	p.stored -= uint64(drop.storageSize)
 	p.lookup.untrack(drop)
+	p.trackDropped(from, drop, txpool.DropOverflow)
 
 	// Remove the transaction from the pool's eviction heap:
 	//   - If the entire account was dropped, pop off the address
@@ -1855,6 +1909,31 @@ func (p *BlobPool) SubscribeTransactions(ch chan<- core.NewTxsEvent, reorgs bool
 	}
 }
 
+// SubscribeDropped registers a subscription for the transactions dropped from
+// the pool without being included.
+func (p *BlobPool) SubscribeDropped(ch chan<- txpool.DroppedTxsEvent) event.Subscription {
+	return p.dropFeed.Subscribe(ch)
+}
+
+// trackDropped records a transaction dropped from the pool, to be announced by
+// the next notifyDropped.
+//
+// Note, this method assumes the pool lock is held!
+func (p *BlobPool) trackDropped(from common.Address, meta *blobTxMeta, reason txpool.DropReason) {
+	p.dropped = append(p.dropped, txpool.DroppedTx{Hash: meta.hash, From: from, Reason: reason})
+}
+
+// notifyDropped announces the transactions dropped since the last call to the
+// subscribers.
+//
+// Note, this method assumes the pool lock is held!
+func (p *BlobPool) notifyDropped() {
+	if len(p.dropped) > 0 {
+		p.dropFeed.Send(txpool.DroppedTxsEvent{Txs: p.dropped})
+		p.dropped = nil
+	}
+}
+
 // Nonce returns the next nonce of an account, with all transactions executable
 // by the pool already applied on top.
 func (p *BlobPool) Nonce(addr common.Address) uint64 {
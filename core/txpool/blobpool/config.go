@@ -25,6 +25,7 @@ type Config struct {
 	Datadir   string // Data directory containing the currently executable blobs
 	Datacap   uint64 // Soft-cap of database storage (hard cap is larger due to overhead)
 	PriceBump uint64 // Minimum price bump percentage to replace an already existing nonce
+	Retention uint64 // Number of recent blocks to retain the blobs of included transactions for, beyond finality
 }
 
 // DefaultConfig contains the default configurations for the transaction pool.
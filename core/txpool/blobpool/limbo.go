@@ -39,20 +39,29 @@ type limboBlob struct {
 // limbo is a light, indexed database to temporarily store recently included
 // blobs until they are finalized. The purpose is to support small reorgs, which
 // would require pulling back up old blobs (which aren't part of the chain).
+// Optionally, the blobs are retained for a number of blocks beyond finality to
+// serve them to data availability consumers.
 //
 // TODO(karalabe): Currently updating the inclusion block of a blob needs a full db rewrite. Can we do without?
 type limbo struct {
 	store billy.Database // Persistent data store for limboed blobs
 
-	index  map[common.Hash]uint64            // Mappings from tx hashes to datastore ids
-	groups map[uint64]map[uint64]common.Hash // Set of txs included in past blocks
+	index   map[common.Hash]uint64            // Mappings from tx hashes to datastore ids
+	groups  map[uint64]map[uint64]common.Hash // Set of txs included in past blocks
+	blobs   map[common.Hash]common.Hash       // Mappings from blob versioned hashes to owner tx hashes
+	vhashes map[common.Hash][]common.Hash     // Blob versioned hashes of each tx
+
+	retention uint64 // Number of recent blocks to retain the blobs of beyond finality
 }
 
 // newLimbo opens and indexes a set of limboed blob transactions.
-func newLimbo(config *params.ChainConfig, datadir string) (*limbo, error) {
+func newLimbo(config *params.ChainConfig, datadir string, retention uint64) (*limbo, error) {
 	l := &limbo{
-		index:  make(map[common.Hash]uint64),
-		groups: make(map[uint64]map[uint64]common.Hash),
+		index:     make(map[common.Hash]uint64),
+		groups:    make(map[uint64]map[uint64]common.Hash),
+		blobs:     make(map[common.Hash]common.Hash),
+		vhashes:   make(map[common.Hash][]common.Hash),
+		retention: retention,
 	}
 
 	// Create new slotter for pre-Osaka blob configuration.
@@ -113,6 +122,7 @@ func (l *limbo) parseBlob(id uint64, data []byte) error {
 		return errors.New("duplicate blob")
 	}
 	l.index[item.TxHash] = id
+	l.indexBlobs(item.TxHash, item.Tx)
 
 	if _, ok := l.groups[item.Block]; !ok {
 		l.groups[item.Block] = make(map[uint64]common.Hash)
@@ -122,8 +132,29 @@ func (l *limbo) parseBlob(id uint64, data []byte) error {
 	return nil
 }
 
-// finalize evicts all blobs belonging to a recently finalized block or older.
-func (l *limbo) finalize(final *types.Header) {
+// indexBlobs tracks the versioned hashes of the blobs of a limboed transaction.
+func (l *limbo) indexBlobs(txhash common.Hash, tx *types.Transaction) {
+	vhashes := tx.BlobHashes()
+	for _, vhash := range vhashes {
+		l.blobs[vhash] = txhash
+	}
+	l.vhashes[txhash] = vhashes
+}
+
+// unindexBlobs stops tracking the versioned hashes of the blobs of a limboed
+// transaction.
+func (l *limbo) unindexBlobs(txhash common.Hash) {
+	for _, vhash := range l.vhashes[txhash] {
+		if l.blobs[vhash] == txhash {
+			delete(l.blobs, vhash)
+		}
+	}
+	delete(l.vhashes, txhash)
+}
+
+// finalize evicts all blobs belonging to a recently finalized block or older,
+// unless they are within the retention window below the head.
+func (l *limbo) finalize(final *types.Header, head uint64) {
 	// Just in case there's no final block yet (network not yet merged, weird
 	// restart, sethead, etc), fail gracefully.
 	if final == nil {
@@ -131,7 +162,7 @@ func (l *limbo) finalize(final *types.Header) {
 		return
 	}
 	for block, ids := range l.groups {
-		if block > final.Number.Uint64() {
+		if block > final.Number.Uint64() || block+l.retention > head {
 			continue
 		}
 		for id, owner := range ids {
@@ -139,6 +170,7 @@ func (l *limbo) finalize(final *types.Header) {
 				log.Error("Failed to drop finalized blob", "block", block, "id", id, "err", err)
 			}
 			delete(l.index, owner)
+			l.unindexBlobs(owner)
 		}
 		delete(l.groups, block)
 	}
@@ -180,6 +212,31 @@ func (l *limbo) pull(tx common.Hash) (*types.Transaction, error) {
 	return item.Tx, nil
 }
 
+// get retrieves a previously pushed blob transaction from the limbo, without
+// removing it.
+func (l *limbo) get(tx common.Hash) (*types.Transaction, error) {
+	id, ok := l.index[tx]
+	if !ok {
+		return nil, errors.New("unseen blob transaction")
+	}
+	data, err := l.store.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	item := new(limboBlob)
+	if err = rlp.DecodeBytes(data, item); err != nil {
+		return nil, err
+	}
+	return item.Tx, nil
+}
+
+// owner returns the hash of the limboed transaction carrying the blob with the
+// given versioned hash.
+func (l *limbo) owner(vhash common.Hash) (common.Hash, bool) {
+	tx, ok := l.blobs[vhash]
+	return tx, ok
+}
+
 // update changes the block number under which a blob transaction is tracked. This
 // method should be used when a reorg changes a transaction's inclusion block.
 //
@@ -228,6 +285,7 @@ func (l *limbo) getAndDrop(id uint64) (*limboBlob, error) {
 		return nil, err
 	}
 	delete(l.index, item.TxHash)
+	l.unindexBlobs(item.TxHash)
 	delete(l.groups[item.Block], id)
 	if len(l.groups[item.Block]) == 0 {
 		delete(l.groups, item.Block)
@@ -256,6 +314,7 @@ func (l *limbo) setAndIndex(tx *types.Transaction, block uint64) error {
 		return err
 	}
 	l.index[txhash] = id
+	l.indexBlobs(txhash, tx)
 	if _, ok := l.groups[block]; !ok {
 		l.groups[block] = make(map[uint64]common.Hash)
 	}
@@ -0,0 +1,66 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package blobpool
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// Tests that the blobs of included transactions are retained beyond finality
+// for the configured number of blocks.
+func TestLimboRetention(t *testing.T) {
+	l, err := newLimbo(params.MainnetChainConfig, t.TempDir(), 2)
+	if err != nil {
+		t.Fatalf("failed to create limbo: %v", err)
+	}
+	defer l.Close()
+
+	key, _ := crypto.GenerateKey()
+	tx := makeMultiBlobTx(0, 1, 1, 1, 2, 0, key, types.BlobSidecarVersion0)
+	if err := l.push(tx, 1); err != nil {
+		t.Fatalf("failed to push transaction: %v", err)
+	}
+	final := &types.Header{Number: big.NewInt(1)}
+
+	// Finalized but within the retention window, the blobs are served.
+	l.finalize(final, 2)
+	have, err := l.get(tx.Hash())
+	if err != nil {
+		t.Fatalf("retained transaction missing: %v", err)
+	}
+	if have.Hash() != tx.Hash() || have.BlobTxSidecar() == nil {
+		t.Fatalf("wrong retained transaction")
+	}
+	for _, vhash := range tx.BlobHashes() {
+		if owner, ok := l.owner(vhash); !ok || owner != tx.Hash() {
+			t.Fatalf("wrong owner of blob %x: have %x, want %x", vhash, owner, tx.Hash())
+		}
+	}
+	// Out of the retention window, the blobs are evicted.
+	l.finalize(final, 3)
+	if _, err := l.get(tx.Hash()); err == nil {
+		t.Fatalf("evicted transaction still retained")
+	}
+	if _, ok := l.owner(tx.BlobHashes()[0]); ok {
+		t.Fatalf("evicted blob still indexed")
+	}
+}
@@ -0,0 +1,37 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package encrypted
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// API exposes the encrypted pool under the eth namespace.
+type API struct {
+	pool *Pool
+}
+
+// NewAPI creates the API of the encrypted pool.
+func NewAPI(pool *Pool) *API {
+	return &API{pool: pool}
+}
+
+// SendEncryptedTransaction adds an encrypted transaction to the pool, returning
+// the hash of its envelope.
+func (api *API) SendEncryptedTransaction(envelope Envelope) (common.Hash, error) {
+	return api.pool.Add(&envelope)
+}
@@ -0,0 +1,87 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package encrypted implements a pool of encrypted transactions, revealed only
+// when they are ordered into a block.
+//
+// Senders submit their transactions in ciphertext form along with the gas limit
+// they declare for them. The pool orders the envelopes by arrival and, while a
+// block is built, hands them to a pluggable decrypter, such as an external
+// threshold decryption key service. The decrypted transactions are included
+// ahead of the transactions of the regular pool, so their order is fixed before
+// their content is known to the builder.
+package encrypted
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// Envelope is an encrypted transaction. Only the declared gas limit is known to
+// the pool, the transaction itself is revealed by the decrypter.
+type Envelope struct {
+	KeyID      hexutil.Bytes  `json:"keyId"`      // Identifier of the decryption key, defined by the decrypter
+	Ciphertext hexutil.Bytes  `json:"ciphertext"` // Encrypted binary encoding of the transaction
+	GasLimit   hexutil.Uint64 `json:"gasLimit"`   // Upper bound of the gas limit of the transaction
+}
+
+// Hash returns the hash identifying the envelope.
+func (e *Envelope) Hash() common.Hash {
+	enc, _ := rlp.EncodeToBytes(e)
+	return crypto.Keccak256Hash(enc)
+}
+
+// Decrypter reveals the transactions of the envelopes ordered into a block.
+type Decrypter interface {
+	// Decrypt returns the plaintexts of the envelopes included in the block with
+	// the given header, in the same order. The plaintext of the envelopes which
+	// cannot be decrypted is nil.
+	Decrypt(ctx context.Context, header *types.Header, envelopes []*Envelope) ([][]byte, error)
+}
+
+// rpcDecrypter is a decrypter backed by an external key service reachable over
+// JSON-RPC.
+type rpcDecrypter struct {
+	client *rpc.Client
+}
+
+// NewRPCDecrypter creates a decrypter calling the decryption_decrypt method of
+// an external key service with the block number and the envelopes.
+func NewRPCDecrypter(client *rpc.Client) Decrypter {
+	return &rpcDecrypter{client: client}
+}
+
+func (d *rpcDecrypter) Decrypt(ctx context.Context, header *types.Header, envelopes []*Envelope) ([][]byte, error) {
+	var result []hexutil.Bytes
+	if err := d.client.CallContext(ctx, &result, "decryption_decrypt", hexutil.Uint64(header.Number.Uint64()), envelopes); err != nil {
+		return nil, err
+	}
+	if len(result) != len(envelopes) {
+		return nil, fmt.Errorf("%w: have %d plaintexts, want %d", errDecryptMismatch, len(result), len(envelopes))
+	}
+	plaintexts := make([][]byte, len(result))
+	for i, p := range result {
+		plaintexts[i] = p
+	}
+	return plaintexts, nil
+}
@@ -0,0 +1,320 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package encrypted
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+const (
+	// decryptTimeout is the maximum time the block building waits for the
+	// decrypter.
+	decryptTimeout = time.Second
+
+	// chainEventChanSize is the size of channel listening to ChainEvent.
+	chainEventChanSize = 16
+)
+
+var (
+	errKnownEnvelope   = errors.New("already known")
+	errPoolFull        = errors.New("encrypted pool is full")
+	errOversized       = errors.New("oversized envelope")
+	errGasLimit        = errors.New("invalid envelope gas limit")
+	errDecryptMismatch = errors.New("decrypter result mismatch")
+)
+
+// Config are the settings of the encrypted transaction pool.
+type Config struct {
+	MaxEnvelopes int           // Maximum number of envelopes held by the pool
+	MaxSize      int           // Maximum size of the ciphertext of an envelope
+	MaxBlockGas  uint64        // Maximum total declared gas of the envelopes of a block
+	Lifetime     time.Duration // Time after which envelopes not included are dropped
+}
+
+// DefaultConfig contains the default settings of the encrypted pool.
+var DefaultConfig = Config{
+	MaxEnvelopes: 1024,
+	MaxSize:      128 * 1024,
+	MaxBlockGas:  10_000_000,
+	Lifetime:     5 * time.Minute,
+}
+
+// BlockChain defines the minimal set of methods needed to back an encrypted
+// pool with a chain.
+type BlockChain interface {
+	// Config retrieves the chain's fork configuration.
+	Config() *params.ChainConfig
+
+	// SubscribeChainEvent subscribes to new blocks being added to the chain.
+	SubscribeChainEvent(ch chan<- core.ChainEvent) event.Subscription
+}
+
+// pooledEnvelope is an envelope held by the pool.
+type pooledEnvelope struct {
+	envelope *Envelope
+	hash     common.Hash
+	seq      uint64             // Arrival order of the envelope
+	added    time.Time          // Time the envelope was added to the pool
+	tx       *types.Transaction // Transaction revealed by the decrypter, nil if not decrypted yet
+}
+
+// Pool holds the encrypted transactions until their inclusion. It implements
+// miner.BundleSource, revealing the transactions while blocks are built.
+type Pool struct {
+	config    Config
+	chain     BlockChain
+	decrypter Decrypter
+	signer    types.Signer
+
+	lock      sync.Mutex
+	envelopes map[common.Hash]*pooledEnvelope
+	included  map[common.Hash]common.Hash // Envelope hashes by decrypted transaction hash
+	seq       uint64
+
+	closed chan struct{}
+	wg     sync.WaitGroup
+}
+
+// New creates an encrypted pool revealing the transactions with the given decrypter.
+func New(config Config, chain BlockChain, decrypter Decrypter) *Pool {
+	return &Pool{
+		config:    config,
+		chain:     chain,
+		decrypter: decrypter,
+		signer:    types.LatestSigner(chain.Config()),
+		envelopes: make(map[common.Hash]*pooledEnvelope),
+		included:  make(map[common.Hash]common.Hash),
+		closed:    make(chan struct{}),
+	}
+}
+
+// Start implements node.Lifecycle, starting the tracking of included transactions.
+func (p *Pool) Start() error {
+	events := make(chan core.ChainEvent, chainEventChanSize)
+	sub := p.chain.SubscribeChainEvent(events)
+
+	p.wg.Add(1)
+	go p.loop(events, sub)
+	return nil
+}
+
+// Stop implements node.Lifecycle, terminating the tracking of included transactions.
+func (p *Pool) Stop() error {
+	close(p.closed)
+	p.wg.Wait()
+	return nil
+}
+
+// loop drops the envelopes whose transactions were included in canonical blocks.
+func (p *Pool) loop(events chan core.ChainEvent, sub event.Subscription) {
+	defer p.wg.Done()
+	defer sub.Unsubscribe()
+
+	for {
+		select {
+		case ev := <-events:
+			p.lock.Lock()
+			for _, tx := range ev.Transactions {
+				if hash, ok := p.included[tx.Hash()]; ok {
+					p.remove(hash)
+				}
+			}
+			p.lock.Unlock()
+
+		case <-sub.Err():
+			return
+		case <-p.closed:
+			return
+		}
+	}
+}
+
+// Add inserts an envelope into the pool.
+func (p *Pool) Add(envelope *Envelope) (common.Hash, error) {
+	if len(envelope.Ciphertext) > p.config.MaxSize {
+		return common.Hash{}, errOversized
+	}
+	if gas := uint64(envelope.GasLimit); gas < params.TxGas || gas > p.config.MaxBlockGas {
+		return common.Hash{}, errGasLimit
+	}
+	hash := envelope.Hash()
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if _, ok := p.envelopes[hash]; ok {
+		return common.Hash{}, errKnownEnvelope
+	}
+	p.expire(time.Now())
+	if len(p.envelopes) >= p.config.MaxEnvelopes {
+		return common.Hash{}, errPoolFull
+	}
+	p.seq++
+	p.envelopes[hash] = &pooledEnvelope{envelope: envelope, hash: hash, seq: p.seq, added: time.Now()}
+	return hash, nil
+}
+
+// Has reports whether the pool holds the envelope with the given hash.
+func (p *Pool) Has(hash common.Hash) bool {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	_, ok := p.envelopes[hash]
+	return ok
+}
+
+// remove drops an envelope from the pool, the lock must be held.
+func (p *Pool) remove(hash common.Hash) {
+	entry, ok := p.envelopes[hash]
+	if !ok {
+		return
+	}
+	delete(p.envelopes, hash)
+	if entry.tx != nil {
+		delete(p.included, entry.tx.Hash())
+	}
+}
+
+// expire drops the envelopes older than the lifetime, the lock must be held.
+func (p *Pool) expire(now time.Time) {
+	for hash, entry := range p.envelopes {
+		if now.Sub(entry.added) > p.config.Lifetime {
+			log.Debug("Dropping expired encrypted transaction", "envelope", hash)
+			p.remove(hash)
+		}
+	}
+}
+
+// Bundle implements miner.BundleSource, returning the transactions of the
+// envelopes ordered into the block with the given header, in arrival order.
+// The envelopes not decrypted yet are revealed by the decrypter, the ones it
+// fails to decrypt or which don't match their declared gas limit are dropped.
+func (p *Pool) Bundle(header *types.Header, statedb *state.StateDB) []*types.Transaction {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.expire(time.Now())
+
+	// Order the envelopes by arrival, up to the block gas allowance.
+	var (
+		entries []*pooledEnvelope
+		gas     uint64
+	)
+	for _, entry := range p.envelopes {
+		entries = append(entries, entry)
+	}
+	slices.SortFunc(entries, func(a, b *pooledEnvelope) int {
+		return cmp.Compare(a.seq, b.seq)
+	})
+	for i, entry := range entries {
+		if gas+uint64(entry.envelope.GasLimit) > p.config.MaxBlockGas {
+			entries = entries[:i]
+			break
+		}
+		gas += uint64(entry.envelope.GasLimit)
+	}
+	p.decrypt(header, entries)
+
+	var txs []*types.Transaction
+	for _, entry := range entries {
+		if entry.tx == nil {
+			continue
+		}
+		from, _ := types.Sender(p.signer, entry.tx)
+		if entry.tx.Nonce() < statedb.GetNonce(from) {
+			log.Debug("Dropping stale encrypted transaction", "envelope", entry.hash, "tx", entry.tx.Hash())
+			p.remove(entry.hash)
+			continue
+		}
+		txs = append(txs, entry.tx)
+	}
+	return txs
+}
+
+// decrypt reveals the transactions of the given envelopes which were not
+// decrypted yet, the lock must be held.
+func (p *Pool) decrypt(header *types.Header, entries []*pooledEnvelope) {
+	var (
+		pending   []*pooledEnvelope
+		envelopes []*Envelope
+	)
+	for _, entry := range entries {
+		if entry.tx == nil {
+			pending = append(pending, entry)
+			envelopes = append(envelopes, entry.envelope)
+		}
+	}
+	if len(pending) == 0 {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), decryptTimeout)
+	defer cancel()
+
+	plaintexts, err := p.decrypter.Decrypt(ctx, types.CopyHeader(header), envelopes)
+	if err == nil && len(plaintexts) != len(envelopes) {
+		err = errDecryptMismatch
+	}
+	if err != nil {
+		// Keep the envelopes, the decryption is retried with the next block.
+		log.Warn("Failed to decrypt transactions", "envelopes", len(envelopes), "err", err)
+		return
+	}
+	for i, entry := range pending {
+		tx, err := p.reveal(entry.envelope, plaintexts[i])
+		if err != nil {
+			log.Debug("Dropping invalid encrypted transaction", "envelope", entry.hash, "err", err)
+			p.remove(entry.hash)
+			continue
+		}
+		entry.tx = tx
+		p.included[tx.Hash()] = entry.hash
+	}
+}
+
+// reveal decodes and checks the transaction of a decrypted envelope.
+func (p *Pool) reveal(envelope *Envelope, plaintext []byte) (*types.Transaction, error) {
+	if plaintext == nil {
+		return nil, errors.New("decryption failed")
+	}
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(plaintext); err != nil {
+		return nil, err
+	}
+	if tx.Type() == types.BlobTxType {
+		return nil, errors.New("blob transactions not supported")
+	}
+	if tx.Gas() > uint64(envelope.GasLimit) {
+		return nil, errors.New("gas limit above declared one")
+	}
+	if _, err := types.Sender(p.signer, tx); err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
@@ -0,0 +1,143 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package encrypted
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+type testChain struct {
+	feed event.Feed
+}
+
+func (c *testChain) Config() *params.ChainConfig { return params.MergedTestChainConfig }
+func (c *testChain) SubscribeChainEvent(ch chan<- core.ChainEvent) event.Subscription {
+	return c.feed.Subscribe(ch)
+}
+
+// testDecrypter "decrypts" the envelopes by returning their ciphertext, failing
+// for the ones with an empty key identifier.
+type testDecrypter struct {
+	err   error
+	calls int
+}
+
+func (d *testDecrypter) Decrypt(ctx context.Context, header *types.Header, envelopes []*Envelope) ([][]byte, error) {
+	d.calls++
+	if d.err != nil {
+		return nil, d.err
+	}
+	plaintexts := make([][]byte, len(envelopes))
+	for i, e := range envelopes {
+		if len(e.KeyID) > 0 {
+			plaintexts[i] = e.Ciphertext
+		}
+	}
+	return plaintexts, nil
+}
+
+func makeEnvelope(t *testing.T, key []byte, nonce uint64, gas uint64, declared uint64) *Envelope {
+	t.Helper()
+	signer := types.LatestSigner(params.MergedTestChainConfig)
+	tx := types.MustSignNewTx(testKey, signer, &types.DynamicFeeTx{
+		ChainID:   params.MergedTestChainConfig.ChainID,
+		Nonce:     nonce,
+		Gas:       gas,
+		GasFeeCap: big.NewInt(1),
+		GasTipCap: big.NewInt(1),
+	})
+	enc, err := tx.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &Envelope{KeyID: key, Ciphertext: enc, GasLimit: hexutil.Uint64(declared)}
+}
+
+var testKey, _ = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+
+func TestPoolBundle(t *testing.T) {
+	var (
+		decrypter = new(testDecrypter)
+		pool      = New(DefaultConfig, new(testChain), decrypter)
+		header    = &types.Header{Number: big.NewInt(1)}
+	)
+	statedb, _ := state.New(types.EmptyRootHash, state.NewDatabaseForTesting())
+	statedb.SetNonce(crypto.PubkeyToAddress(testKey.PublicKey), 1, 0)
+
+	envelopes := []*Envelope{
+		makeEnvelope(t, []byte{1}, 2, 21000, 21000), // Valid, arrives first
+		makeEnvelope(t, []byte{1}, 1, 21000, 21000), // Valid, arrives second
+		makeEnvelope(t, []byte{1}, 3, 50000, 21000), // Gas above the declared one
+		makeEnvelope(t, nil, 4, 21000, 21000),       // Undecryptable
+		makeEnvelope(t, []byte{1}, 0, 21000, 21000), // Stale nonce
+	}
+	for i, e := range envelopes {
+		if _, err := pool.Add(e); err != nil {
+			t.Fatalf("failed to add envelope %d: %v", i, err)
+		}
+	}
+	if _, err := pool.Add(envelopes[0]); !errors.Is(err, errKnownEnvelope) {
+		t.Fatalf("duplicate envelope: have %v, want %v", err, errKnownEnvelope)
+	}
+	txs := pool.Bundle(header, statedb)
+	if len(txs) != 2 || txs[0].Nonce() != 2 || txs[1].Nonce() != 1 {
+		t.Fatalf("unexpected bundle: %v", txs)
+	}
+	for i, want := range []bool{true, true, false, false, false} {
+		if have := pool.Has(envelopes[i].Hash()); have != want {
+			t.Errorf("envelope %d: pooled %v, want %v", i, have, want)
+		}
+	}
+	// Decrypted transactions are not sent to the decrypter again.
+	pool.Bundle(header, statedb)
+	if decrypter.calls != 1 {
+		t.Errorf("decrypter called %d times, want 1", decrypter.calls)
+	}
+}
+
+func TestPoolDecrypterFailure(t *testing.T) {
+	var (
+		decrypter = &testDecrypter{err: errors.New("key service unavailable")}
+		pool      = New(DefaultConfig, new(testChain), decrypter)
+		header    = &types.Header{Number: big.NewInt(1)}
+		envelope  = makeEnvelope(t, []byte{1}, 0, 21000, 21000)
+	)
+	statedb, _ := state.New(types.EmptyRootHash, state.NewDatabaseForTesting())
+	pool.Add(envelope)
+
+	if txs := pool.Bundle(header, statedb); len(txs) != 0 {
+		t.Fatalf("unexpected transactions without decryption: %v", txs)
+	}
+	if !pool.Has(envelope.Hash()) {
+		t.Fatal("envelope dropped on decrypter failure")
+	}
+	decrypter.err = nil
+	if txs := pool.Bundle(header, statedb); len(txs) != 1 {
+		t.Fatalf("decryption not retried: have %d transactions, want 1", len(txs))
+	}
+}
@@ -71,4 +71,8 @@ var (
 	// ErrInflightTxLimitReached is returned when the maximum number of in-flight
 	// transactions is reached for specific accounts.
 	ErrInflightTxLimitReached = errors.New("in-flight transaction limit reached for delegated accounts")
+
+	// ErrIngressPaused is returned if a transaction is submitted while the pool
+	// does not accept new transactions, e.g. because the node is draining.
+	ErrIngressPaused = errors.New("transaction pool ingress paused")
 )
@@ -135,10 +135,14 @@ type BlockChain interface {
 
 // Config are the configuration parameters of the transaction pool.
 type Config struct {
-	Locals    []common.Address // Addresses that should be treated by default as local
-	NoLocals  bool             // Whether local transaction handling should be disabled
-	Journal   string           // Journal of local transactions to survive node restarts
-	Rejournal time.Duration    // Time interval to regenerate the local transaction journal
+	Locals        []common.Address // Addresses that should be treated by default as local
+	LocalAccounts string           // File persisting the local accounts added at runtime
+	NoLocals      bool             // Whether local transaction handling should be disabled
+	Journal       string           // Journal of local transactions to survive node restarts
+	Rejournal     time.Duration    // Time interval to regenerate the local transaction journal
+
+	Snapshot         string        // File persisting the pool contents to warm up restarted or standby nodes
+	SnapshotInterval time.Duration // Time interval to regenerate the pool snapshot
 
 	PriceLimit uint64 // Minimum gas price to enforce for acceptance into the pool
 	PriceBump  uint64 // Minimum price bump percentage to replace an already existing transaction (nonce)
@@ -153,8 +157,11 @@ type Config struct {
 
 // DefaultConfig contains the default configurations for the transaction pool.
 var DefaultConfig = Config{
-	Journal:   "transactions.rlp",
-	Rejournal: time.Hour,
+	LocalAccounts: "locals.json",
+	Journal:       "transactions.rlp",
+	Rejournal:     time.Hour,
+
+	SnapshotInterval: time.Minute,
 
 	PriceLimit: 1,
 	PriceBump:  10,
@@ -229,9 +236,12 @@ type LegacyPool struct {
 	chain       BlockChain
 	gasTip      atomic.Pointer[uint256.Int]
 	txFeed      event.Feed
+	dropFeed    event.Feed
 	signer      types.Signer
 	mu          sync.RWMutex
 
+	dropped []txpool.DroppedTx // Transactions dropped since the last notification
+
 	currentHead   atomic.Pointer[types.Header] // Current head of the blockchain
 	currentState  *state.StateDB               // Current state in the blockchain head
 	pendingNonces *noncer                      // Pending state tracking virtual nonces
@@ -373,9 +383,15 @@ func (pool *LegacyPool) loop() {
 		case <-evict.C:
 			pool.mu.Lock()
 			for _, hash := range pool.queue.evictList() {
+				if tx := pool.all.Get(hash); tx != nil {
+					pool.trackDropped(tx, txpool.DropExpired)
+				}
 				pool.removeTx(hash, true, true)
 			}
+			dropped := pool.takeDropped()
 			pool.mu.Unlock()
+
+			pool.notifyDropped(dropped)
 		}
 	}
 }
@@ -407,9 +423,44 @@ func (pool *LegacyPool) SubscribeTransactions(ch chan<- core.NewTxsEvent, reorgs
 	return pool.txFeed.Subscribe(ch)
 }
 
+// SubscribeDropped registers a subscription for the transactions dropped from
+// the pool without being included.
+func (pool *LegacyPool) SubscribeDropped(ch chan<- txpool.DroppedTxsEvent) event.Subscription {
+	return pool.dropFeed.Subscribe(ch)
+}
+
+// trackDropped records a transaction dropped from the pool, to be announced once
+// the pool lock is released.
+//
+// Note, this method assumes the pool lock is held!
+func (pool *LegacyPool) trackDropped(tx *types.Transaction, reason txpool.DropReason) {
+	from, _ := types.Sender(pool.signer, tx) // already validated during insertion
+	pool.dropped = append(pool.dropped, txpool.DroppedTx{Hash: tx.Hash(), From: from, Reason: reason})
+}
+
+// takeDropped returns and clears the transactions dropped since the last call.
+//
+// Note, this method assumes the pool lock is held!
+func (pool *LegacyPool) takeDropped() []txpool.DroppedTx {
+	dropped := pool.dropped
+	pool.dropped = nil
+	return dropped
+}
+
+// notifyDropped announces the dropped transactions to the subscribers. It must
+// not be called with the pool lock held.
+func (pool *LegacyPool) notifyDropped(dropped []txpool.DroppedTx) {
+	if len(dropped) > 0 {
+		pool.dropFeed.Send(txpool.DroppedTxsEvent{Txs: dropped})
+	}
+}
+
 // SetGasTip updates the minimum gas tip required by the transaction pool for a
 // new transaction, and drops all transactions below this threshold.
 func (pool *LegacyPool) SetGasTip(tip *big.Int) {
+	var dropped []txpool.DroppedTx
+	defer func() { pool.notifyDropped(dropped) }()
+
 	pool.mu.Lock()
 	defer pool.mu.Unlock()
 
@@ -423,13 +474,56 @@ func (pool *LegacyPool) SetGasTip(tip *big.Int) {
 		// pool.priced is sorted by GasFeeCap, so we have to iterate through pool.all instead
 		drop := pool.all.TxsBelowTip(tip)
 		for _, tx := range drop {
+			pool.trackDropped(tx, txpool.DropUnderpriced)
 			pool.removeTx(tx.Hash(), false, true)
 		}
 		pool.priced.Removed(len(drop))
+		dropped = pool.takeDropped()
 	}
 	log.Info("Legacy pool tip threshold updated", "tip", newTip)
 }
 
+// Limits are the transaction slot limits of the pool.
+type Limits struct {
+	AccountSlots uint64 `json:"accountSlots"` // Number of executable transaction slots guaranteed per account
+	GlobalSlots  uint64 `json:"globalSlots"`  // Maximum number of executable transaction slots for all accounts
+	AccountQueue uint64 `json:"accountQueue"` // Maximum number of non-executable transaction slots permitted per account
+	GlobalQueue  uint64 `json:"globalQueue"`  // Maximum number of non-executable transaction slots for all accounts
+}
+
+// Limits returns the current transaction slot limits of the pool.
+func (pool *LegacyPool) Limits() Limits {
+	pool.mu.RLock()
+	defer pool.mu.RUnlock()
+
+	return Limits{
+		AccountSlots: pool.config.AccountSlots,
+		GlobalSlots:  pool.config.GlobalSlots,
+		AccountQueue: pool.config.AccountQueue,
+		GlobalQueue:  pool.config.GlobalQueue,
+	}
+}
+
+// SetLimits changes the transaction slot limits of the pool. If any limit is
+// lowered, the excess transactions are evicted by a pool reorganisation which
+// completes before returning.
+func (pool *LegacyPool) SetLimits(limits Limits) error {
+	if limits.AccountSlots < 1 || limits.GlobalSlots < 1 || limits.AccountQueue < 1 || limits.GlobalQueue < 1 {
+		return errors.New("transaction slot limits must be positive")
+	}
+	pool.mu.Lock()
+	pool.config.AccountSlots, pool.queue.config.AccountSlots = limits.AccountSlots, limits.AccountSlots
+	pool.config.GlobalSlots, pool.queue.config.GlobalSlots = limits.GlobalSlots, limits.GlobalSlots
+	pool.config.AccountQueue, pool.queue.config.AccountQueue = limits.AccountQueue, limits.AccountQueue
+	pool.config.GlobalQueue, pool.queue.config.GlobalQueue = limits.GlobalQueue, limits.GlobalQueue
+	queued := pool.queue.addresses()
+	pool.mu.Unlock()
+
+	log.Info("Legacy pool slot limits updated", "accountslots", limits.AccountSlots, "globalslots", limits.GlobalSlots, "accountqueue", limits.AccountQueue, "globalqueue", limits.GlobalQueue)
+	<-pool.requestPromoteExecutables(newAccountSet(pool.signer, queued...))
+	return nil
+}
+
 // Nonce returns the next nonce of an account, with all transactions executable
 // by the pool already applied on top.
 func (pool *LegacyPool) Nonce(addr common.Address) uint64 {
@@ -748,6 +842,7 @@ func (pool *LegacyPool) add(tx *types.Transaction) (replaced bool, err error) {
 		for _, tx := range drop {
 			log.Trace("Discarding freshly underpriced transaction", "hash", tx.Hash(), "gasTipCap", tx.GasTipCap(), "gasFeeCap", tx.GasFeeCap())
 			underpricedTxMeter.Mark(1)
+			pool.trackDropped(tx, txpool.DropUnderpriced)
 
 			sender, _ := types.Sender(pool.signer, tx)
 			dropped := pool.removeTx(tx.Hash(), false, sender != from) // Don't unreserve the sender of the tx being added if last from the acc
@@ -769,6 +864,7 @@ func (pool *LegacyPool) add(tx *types.Transaction) (replaced bool, err error) {
 			pool.all.Remove(old.Hash())
 			pool.priced.Removed(1)
 			pendingReplaceMeter.Mark(1)
+			pool.trackDropped(old, txpool.DropReplaced)
 		}
 		pool.all.Add(tx)
 		pool.priced.Put(tx)
@@ -822,6 +918,9 @@ func (pool *LegacyPool) enqueueTx(hash common.Hash, tx *types.Transaction, addAl
 		return false, err
 	}
 	if replaced != nil {
+		if old := pool.all.Get(*replaced); old != nil {
+			pool.trackDropped(old, txpool.DropReplaced)
+		}
 		pool.removeTx(*replaced, true, true)
 	}
 	// If the transaction isn't in lookup set but it's expected to be there,
@@ -853,6 +952,7 @@ func (pool *LegacyPool) promoteTx(addr common.Address, hash common.Hash, tx *typ
 		pool.all.Remove(hash)
 		pool.priced.Removed(1)
 		pendingDiscardMeter.Mark(1)
+		pool.trackDropped(tx, txpool.DropUnderpriced)
 		return false
 	}
 	// Otherwise discard any previous transaction and mark this
@@ -860,6 +960,7 @@ func (pool *LegacyPool) promoteTx(addr common.Address, hash common.Hash, tx *typ
 		pool.all.Remove(old.Hash())
 		pool.priced.Removed(1)
 		pendingReplaceMeter.Mark(1)
+		pool.trackDropped(old, txpool.DropReplaced)
 	} else {
 		// Nothing was replaced, bump the pending counter
 		pendingGauge.Inc(1)
@@ -904,9 +1005,19 @@ func (pool *LegacyPool) addRemoteSync(tx *types.Transaction) error {
 func (pool *LegacyPool) Add(txs []*types.Transaction, sync bool) []error {
 	// Filter out known ones without obtaining the pool lock or recovering signatures
 	var (
-		errs = make([]error, len(txs))
-		news = make([]*types.Transaction, 0, len(txs))
+		errs    = make([]error, len(txs))
+		news    = make([]*types.Transaction, 0, len(txs))
+		unknown = make([]*types.Transaction, 0, len(txs))
 	)
+	// Recover the senders of unknown transactions in parallel up front, so the
+	// basic validation below finds them cached
+	for _, tx := range txs {
+		if pool.all.Get(tx.Hash()) == nil {
+			unknown = append(unknown, tx)
+		}
+	}
+	types.RecoverSenders(pool.signer, unknown)
+
 	for i, tx := range txs {
 		// If the transaction is known, pre-set the error slot
 		if pool.all.Get(tx.Hash()) != nil {
@@ -933,8 +1044,11 @@ func (pool *LegacyPool) Add(txs []*types.Transaction, sync bool) []error {
 	// Process all the new transaction and merge any errors into the original slice
 	pool.mu.Lock()
 	newErrs, dirtyAddrs := pool.addTxsLocked(news)
+	dropped := pool.takeDropped()
 	pool.mu.Unlock()
 
+	pool.notifyDropped(dropped)
+
 	var nilSlot = 0
 	for _, err := range newErrs {
 		for errs[nilSlot] != nil {
@@ -1226,6 +1340,7 @@ func (pool *LegacyPool) runReorg(done chan struct{}, reset *txpoolResetRequest,
 				pool.all.Range(func(hash common.Hash, tx *types.Transaction) bool {
 					if tx.Gas() > params.MaxTxGas {
 						hashes = append(hashes, hash)
+						pool.trackDropped(tx, txpool.DropGasLimit)
 					}
 					return true
 				})
@@ -1277,8 +1392,11 @@ func (pool *LegacyPool) runReorg(done chan struct{}, reset *txpoolResetRequest,
 
 	dropBetweenReorgHistogram.Update(int64(pool.changesSinceReorg))
 	pool.changesSinceReorg = 0 // Reset change counter
+	dropped := pool.takeDropped()
 	pool.mu.Unlock()
 
+	pool.notifyDropped(dropped)
+
 	// Notify subsystems for newly added transactions
 	for _, tx := range promoted {
 		addr, _ := types.Sender(pool.signer, tx)
@@ -1413,6 +1531,19 @@ func (pool *LegacyPool) promoteExecutables(accounts []common.Address) []*types.T
 
 	// remove all removable transactions
 	for _, hash := range dropped {
+		if tx := pool.all.Get(hash); tx != nil {
+			from, _ := types.Sender(pool.signer, tx)
+			switch {
+			case tx.Nonce() < pool.currentState.GetNonce(from):
+				// Nonce used on chain, not reported
+			case tx.Gas() > gasLimit:
+				pool.trackDropped(tx, txpool.DropGasLimit)
+			case tx.Cost().Cmp(pool.currentState.GetBalance(from).ToBig()) > 0:
+				pool.trackDropped(tx, txpool.DropUnpayable)
+			default:
+				pool.trackDropped(tx, txpool.DropOverflow)
+			}
+		}
 		pool.all.Remove(hash)
 	}
 	pool.priced.Removed(len(dropped))
@@ -1470,6 +1601,7 @@ func (pool *LegacyPool) truncatePending() {
 						// Drop the transaction from the global pools too
 						hash := tx.Hash()
 						pool.all.Remove(hash)
+						pool.trackDropped(tx, txpool.DropOverflow)
 
 						// Update the account nonce to the dropped transaction
 						pool.pendingNonces.setIfLower(offenders[i], tx.Nonce())
@@ -1495,6 +1627,7 @@ func (pool *LegacyPool) truncatePending() {
 					// Drop the transaction from the global pools too
 					hash := tx.Hash()
 					pool.all.Remove(hash)
+					pool.trackDropped(tx, txpool.DropOverflow)
 
 					// Update the account nonce to the dropped transaction
 					pool.pendingNonces.setIfLower(addr, tx.Nonce())
@@ -1515,6 +1648,9 @@ func (pool *LegacyPool) truncateQueue() {
 
 	// Remove all removable transactions from the lookup and global price list
 	for _, hash := range removed {
+		if tx := pool.all.Get(hash); tx != nil {
+			pool.trackDropped(tx, txpool.DropOverflow)
+		}
 		pool.all.Remove(hash)
 	}
 	pool.priced.Removed(len(removed))
@@ -1553,6 +1689,12 @@ func (pool *LegacyPool) demoteUnexecutables() {
 			hash := tx.Hash()
 			pool.all.Remove(hash)
 			log.Trace("Removed unpayable pending transaction", "hash", hash)
+
+			if tx.Gas() > gasLimit {
+				pool.trackDropped(tx, txpool.DropGasLimit)
+			} else {
+				pool.trackDropped(tx, txpool.DropUnpayable)
+			}
 		}
 		pendingNofundsMeter.Mark(int64(len(drops)))
 
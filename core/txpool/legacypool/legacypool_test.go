@@ -21,8 +21,10 @@ import (
 	crand "crypto/rand"
 	"errors"
 	"fmt"
+	"maps"
 	"math/big"
 	"math/rand"
+	"path/filepath"
 	"slices"
 	"sync"
 	"sync/atomic"
@@ -1036,6 +1038,51 @@ func TestQueueGlobalLimiting(t *testing.T) {
 	}
 }
 
+// Tests that lowering the slot limits at runtime evicts the excess transactions.
+func TestSetLimits(t *testing.T) {
+	t.Parallel()
+
+	statedb, _ := state.New(types.EmptyRootHash, state.NewDatabaseForTesting())
+	blockchain := newTestBlockChain(params.TestChainConfig, 1000000, statedb, new(event.Feed))
+
+	config := testTxPoolConfig
+	config.NoLocals = true
+
+	pool := New(config, blockchain)
+	pool.Init(testTxPoolConfig.PriceLimit, blockchain.CurrentBlock(), newReserver())
+	defer pool.Close()
+
+	key, _ := crypto.GenerateKey()
+	testAddBalance(pool, crypto.PubkeyToAddress(key.PublicKey), big.NewInt(1000000))
+
+	// Queue up a batch of gapped transactions
+	txs := make(types.Transactions, 10)
+	for i := range txs {
+		txs[i] = transaction(uint64(i+1), 100000, key)
+	}
+	pool.addRemotesSync(txs)
+	if _, queued := pool.Stats(); queued != len(txs) {
+		t.Fatalf("queued transactions mismatch: have %d, want %d", queued, len(txs))
+	}
+	if err := pool.SetLimits(Limits{}); err == nil {
+		t.Fatal("zero limits accepted")
+	}
+	limits := pool.Limits()
+	limits.AccountQueue = 4
+	if err := pool.SetLimits(limits); err != nil {
+		t.Fatalf("failed to set limits: %v", err)
+	}
+	if have := pool.Limits(); have != limits {
+		t.Fatalf("limits mismatch: have %+v, want %+v", have, limits)
+	}
+	if _, queued := pool.Stats(); queued != 4 {
+		t.Fatalf("queued transactions not evicted: have %d, want 4", queued)
+	}
+	if err := validatePoolInternals(pool); err != nil {
+		t.Fatalf("pool internal state corrupted: %v", err)
+	}
+}
+
 // Tests that if an account remains idle for a prolonged amount of time, any
 // non-executable transactions queued up are dropped to prevent wasting resources
 // on shuffling them around.
@@ -2185,6 +2232,69 @@ func TestReplacementDynamicFee(t *testing.T) {
 	}
 }
 
+// Tests that transactions dropped from the pool are announced along with the
+// reason of the drop.
+func TestDroppedEvents(t *testing.T) {
+	t.Parallel()
+
+	pool, key := setupPool()
+	defer pool.Close()
+
+	events := make(chan txpool.DroppedTxsEvent, 32)
+	sub := pool.SubscribeDropped(events)
+	defer sub.Unsubscribe()
+
+	from := crypto.PubkeyToAddress(key.PublicKey)
+	testAddBalance(pool, from, big.NewInt(1000000000))
+
+	waitDropped := func(want map[common.Hash]txpool.DropReason) {
+		t.Helper()
+
+		have := make(map[common.Hash]txpool.DropReason)
+		for len(have) < len(want) {
+			select {
+			case ev := <-events:
+				for _, tx := range ev.Txs {
+					if tx.From != from {
+						t.Fatalf("dropped transaction %x sender mismatch: have %v, want %v", tx.Hash, tx.From, from)
+					}
+					have[tx.Hash] = tx.Reason
+				}
+			case <-time.After(time.Second):
+				t.Fatalf("dropped event not fired: have %d, want %d", len(have), len(want))
+			}
+		}
+		if !maps.Equal(have, want) {
+			t.Fatalf("dropped transactions mismatch: have %v, want %v", have, want)
+		}
+	}
+	// Replace a pending transaction and ensure the original is reported
+	original := pricedTransaction(0, 100000, big.NewInt(1), key)
+	if err := pool.addRemoteSync(original); err != nil {
+		t.Fatalf("failed to add original pending transaction: %v", err)
+	}
+	pending := pricedTransaction(0, 100000, big.NewInt(2), key)
+	if err := pool.addRemoteSync(pending); err != nil {
+		t.Fatalf("failed to replace pending transaction: %v", err)
+	}
+	waitDropped(map[common.Hash]txpool.DropReason{original.Hash(): txpool.DropReplaced})
+
+	// Raise the minimum tip and ensure both the pending and queued transactions
+	// are reported as underpriced
+	queued := pricedTransaction(2, 100000, big.NewInt(1), key)
+	if err := pool.addRemoteSync(queued); err != nil {
+		t.Fatalf("failed to add queued transaction: %v", err)
+	}
+	pool.SetGasTip(big.NewInt(5))
+	waitDropped(map[common.Hash]txpool.DropReason{
+		pending.Hash(): txpool.DropUnderpriced,
+		queued.Hash():  txpool.DropUnderpriced,
+	})
+	if err := validatePoolInternals(pool); err != nil {
+		t.Fatalf("pool internal state corrupted: %v", err)
+	}
+}
+
 // TestStatusCheck tests that the pool can correctly retrieve the
 // pending status of individual transactions.
 func TestStatusCheck(t *testing.T) {
@@ -2714,3 +2824,62 @@ func BenchmarkMultiAccountBatchInsert(b *testing.B) {
 		pool.addRemotesSync([]*types.Transaction{tx})
 	}
 }
+
+// baseFeeBlockChain is a test chain whose head carries a base fee.
+type baseFeeBlockChain struct {
+	*testBlockChain
+}
+
+func (bc *baseFeeBlockChain) CurrentBlock() *types.Header {
+	head := bc.testBlockChain.CurrentBlock()
+	head.BaseFee = new(big.Int)
+	return head
+}
+
+// Tests that the contents of the pool can be exported and imported into another
+// one, retaining the pending and queued transactions.
+func TestPoolSnapshot(t *testing.T) {
+	t.Parallel()
+
+	var (
+		key, _     = crypto.GenerateKey()
+		statedb, _ = state.New(types.EmptyRootHash, state.NewDatabaseForTesting())
+	)
+	statedb.AddBalance(crypto.PubkeyToAddress(key.PublicKey), uint256.NewInt(params.Ether), tracing.BalanceChangeUnspecified)
+	// Syncing the pool resets it to the head, which needs a base fee post-London
+	blockchain := &baseFeeBlockChain{newTestBlockChain(params.TestChainConfig, 10000000, statedb, new(event.Feed))}
+
+	newPool := func() *txpool.TxPool {
+		pool, err := txpool.New(testTxPoolConfig.PriceLimit, blockchain, []txpool.SubPool{New(testTxPoolConfig, blockchain)})
+		if err != nil {
+			t.Fatalf("failed to create pool: %v", err)
+		}
+		return pool
+	}
+	source := newPool()
+	defer source.Close()
+
+	txs := []*types.Transaction{transaction(0, 100000, key), transaction(1, 100000, key), transaction(3, 100000, key)}
+	for i, err := range source.Add(txs, true) {
+		if err != nil {
+			t.Fatalf("failed to add transaction %d: %v", i, err)
+		}
+	}
+	path := filepath.Join(t.TempDir(), "txpool.rlp.gz")
+	if exported, err := source.ExportFile(path); err != nil || exported != len(txs) {
+		t.Fatalf("export mismatch: have %d, %v, want %d", exported, err, len(txs))
+	}
+	target := newPool()
+	defer target.Close()
+
+	added, dropped, err := target.ImportFile(path)
+	if err != nil || added != len(txs) || dropped != 0 {
+		t.Fatalf("import mismatch: have %d added, %d dropped, %v, want %d added", added, dropped, err, len(txs))
+	}
+	if err := target.Sync(); err != nil {
+		t.Fatalf("failed to sync pool: %v", err)
+	}
+	if pending, queued := target.Stats(); pending != 2 || queued != 1 {
+		t.Fatalf("pool content mismatch: have %d pending, %d queued, want 2, 1", pending, queued)
+	}
+}
@@ -0,0 +1,162 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package locals
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"slices"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// errConfiguredAccount is returned when attempting to remove a local account
+// which is set in the node configuration.
+var errConfiguredAccount = errors.New("local account set in the configuration")
+
+// Accounts is the registry of the local accounts, whose transactions are given
+// priority inclusion by the miner. Besides the accounts set in the configuration,
+// accounts can be added and removed at runtime, which are persisted to disk to
+// survive node restarts.
+type Accounts struct {
+	path       string                      // File the runtime accounts are persisted to
+	configured map[common.Address]struct{} // Accounts set in the configuration
+	added      map[common.Address]struct{} // Accounts added at runtime
+	mu         sync.RWMutex
+}
+
+// NewAccounts creates the registry of the local accounts, loading the accounts
+// added at runtime from the given file if it exists.
+func NewAccounts(path string, configured []common.Address) (*Accounts, error) {
+	accounts := &Accounts{
+		path:       path,
+		configured: make(map[common.Address]struct{}),
+		added:      make(map[common.Address]struct{}),
+	}
+	for _, addr := range configured {
+		accounts.configured[addr] = struct{}{}
+	}
+	if path == "" {
+		return accounts, nil
+	}
+	blob, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return accounts, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var added []common.Address
+	if err := json.Unmarshal(blob, &added); err != nil {
+		return nil, err
+	}
+	for _, addr := range added {
+		accounts.added[addr] = struct{}{}
+	}
+	return accounts, nil
+}
+
+// List returns the local accounts, sorted by address.
+func (a *Accounts) List() []common.Address {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	list := make([]common.Address, 0, len(a.configured)+len(a.added))
+	for addr := range a.configured {
+		list = append(list, addr)
+	}
+	for addr := range a.added {
+		if _, ok := a.configured[addr]; !ok {
+			list = append(list, addr)
+		}
+	}
+	slices.SortFunc(list, func(a, b common.Address) int { return a.Cmp(b) })
+	return list
+}
+
+// Contains reports whether the account is local.
+func (a *Accounts) Contains(addr common.Address) bool {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	_, configured := a.configured[addr]
+	_, added := a.added[addr]
+	return configured || added
+}
+
+// Add marks the account as local, reporting whether it wasn't already.
+func (a *Accounts) Add(addr common.Address) (bool, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if _, ok := a.configured[addr]; ok {
+		return false, nil
+	}
+	if _, ok := a.added[addr]; ok {
+		return false, nil
+	}
+	a.added[addr] = struct{}{}
+	if err := a.persist(); err != nil {
+		delete(a.added, addr)
+		return false, err
+	}
+	return true, nil
+}
+
+// Remove unmarks an account added at runtime as local, reporting whether it
+// was local. Accounts set in the configuration cannot be removed.
+func (a *Accounts) Remove(addr common.Address) (bool, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if _, ok := a.configured[addr]; ok {
+		return false, errConfiguredAccount
+	}
+	if _, ok := a.added[addr]; !ok {
+		return false, nil
+	}
+	delete(a.added, addr)
+	if err := a.persist(); err != nil {
+		a.added[addr] = struct{}{}
+		return false, err
+	}
+	return true, nil
+}
+
+// persist writes the accounts added at runtime to disk, replacing the previous
+// file atomically.
+func (a *Accounts) persist() error {
+	if a.path == "" {
+		return nil
+	}
+	added := make([]common.Address, 0, len(a.added))
+	for addr := range a.added {
+		added = append(added, addr)
+	}
+	slices.SortFunc(added, func(a, b common.Address) int { return a.Cmp(b) })
+
+	blob, err := json.MarshalIndent(added, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(a.path+".new", blob, 0644); err != nil {
+		return err
+	}
+	return os.Rename(a.path+".new", a.path)
+}
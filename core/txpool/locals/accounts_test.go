@@ -0,0 +1,65 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package locals
+
+import (
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestAccountsPersistence(t *testing.T) {
+	var (
+		path       = filepath.Join(t.TempDir(), "locals.json")
+		configured = common.Address{0x01}
+		added      = common.Address{0x02}
+	)
+	accounts, err := NewAccounts(path, []common.Address{configured})
+	if err != nil {
+		t.Fatalf("failed to create registry: %v", err)
+	}
+	if ok, err := accounts.Add(added); !ok || err != nil {
+		t.Fatalf("failed to add account: %v %v", ok, err)
+	}
+	if ok, _ := accounts.Add(configured); ok {
+		t.Fatalf("configured account added again")
+	}
+	if _, err := accounts.Remove(configured); !errors.Is(err, errConfiguredAccount) {
+		t.Fatalf("wrong error removing configured account: have %v, want %v", err, errConfiguredAccount)
+	}
+	// Reload the registry, only the added account should be persisted.
+	accounts, err = NewAccounts(path, nil)
+	if err != nil {
+		t.Fatalf("failed to reload registry: %v", err)
+	}
+	if have, want := accounts.List(), []common.Address{added}; !reflect.DeepEqual(have, want) {
+		t.Fatalf("wrong accounts after reload: have %v, want %v", have, want)
+	}
+	if ok, err := accounts.Remove(added); !ok || err != nil {
+		t.Fatalf("failed to remove account: %v %v", ok, err)
+	}
+	accounts, err = NewAccounts(path, nil)
+	if err != nil {
+		t.Fatalf("failed to reload registry: %v", err)
+	}
+	if accounts.Contains(added) {
+		t.Fatalf("removed account still local")
+	}
+}
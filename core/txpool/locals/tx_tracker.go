@@ -34,6 +34,10 @@ import (
 var (
 	recheckInterval = time.Minute
 	localGauge      = metrics.GetOrRegisterGauge("txpool/local", nil)
+
+	// localLatencyHist tracks the time in milliseconds from the submission of a
+	// local transaction to its inclusion, as observed by the periodic recheck.
+	localLatencyHist = metrics.NewRegisteredHistogram("txpool/local/latency", nil, metrics.NewExpDecaySample(1028, 0.015))
 )
 
 // TxTracker is a struct used to track priority transactions; it will check from
@@ -128,6 +132,7 @@ func (tracker *TxTracker) recheck(journalCheck bool) []*types.Transaction {
 		stales := txs.Forward(tracker.pool.Nonce(sender))
 		for _, tx := range stales {
 			delete(tracker.all, tx.Hash())
+			localLatencyHist.Update(time.Since(tx.Time()).Milliseconds())
 		}
 		numStales += len(stales)
 
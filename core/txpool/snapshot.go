@@ -0,0 +1,225 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package txpool
+
+import (
+	"compress/gzip"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// snapshotBatchSize is the number of transactions added to the pool at once when
+// importing a snapshot.
+const snapshotBatchSize = 1024
+
+// Export writes the pending and queued transactions of the pool into w as a
+// stream of RLP encoded transactions, ordered by account and nonce. It returns
+// the number of exported transactions.
+//
+// Note, blob transactions are not exported as the blob pool persists them on its
+// own already.
+func (p *TxPool) Export(w io.Writer) (int, error) {
+	pending, queued := p.Content()
+
+	addrs := make([]common.Address, 0, len(pending)+len(queued))
+	for addr := range pending {
+		addrs = append(addrs, addr)
+	}
+	for addr := range queued {
+		if _, ok := pending[addr]; !ok {
+			addrs = append(addrs, addr)
+		}
+	}
+	slices.SortFunc(addrs, common.Address.Cmp)
+
+	var exported int
+	for _, addr := range addrs {
+		for _, txs := range [][]*types.Transaction{pending[addr], queued[addr]} {
+			for _, tx := range txs {
+				if err := rlp.Encode(w, tx); err != nil {
+					return exported, err
+				}
+				exported++
+			}
+		}
+	}
+	return exported, nil
+}
+
+// Import adds the transactions of a stream written by Export to the pool. It
+// returns the number of added and dropped transactions.
+func (p *TxPool) Import(r io.Reader) (int, int, error) {
+	var (
+		stream         = rlp.NewStream(r, 0)
+		batch          = make([]*types.Transaction, 0, snapshotBatchSize)
+		added, dropped int
+	)
+	flush := func() {
+		for _, err := range p.Add(batch, false) {
+			if err != nil {
+				log.Trace("Failed to import transaction", "err", err)
+				dropped++
+			} else {
+				added++
+			}
+		}
+		batch = batch[:0]
+	}
+	for {
+		tx := new(types.Transaction)
+		if err := stream.Decode(tx); err != nil {
+			flush()
+			if err == io.EOF {
+				return added, dropped, nil
+			}
+			return added, dropped, err
+		}
+		if batch = append(batch, tx); len(batch) == snapshotBatchSize {
+			flush()
+		}
+	}
+}
+
+// ExportFile writes the transactions of the pool into the given file, replacing
+// it atomically if it exists. The file is compressed if its name ends in ".gz".
+func (p *TxPool) ExportFile(path string) (int, error) {
+	out, err := os.OpenFile(path+".new", os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return 0, err
+	}
+	var (
+		writer io.Writer = out
+		gz     *gzip.Writer
+	)
+	if strings.HasSuffix(path, ".gz") {
+		gz = gzip.NewWriter(out)
+		writer = gz
+	}
+	exported, err := p.Export(writer)
+	if err == nil && gz != nil {
+		err = gz.Close()
+	}
+	if cerr := out.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(path + ".new")
+		return 0, err
+	}
+	return exported, os.Rename(path+".new", path)
+}
+
+// ImportFile adds the transactions of a file written by ExportFile to the pool.
+func (p *TxPool) ImportFile(path string) (int, int, error) {
+	in, err := os.Open(path)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer in.Close()
+
+	var reader io.Reader = in
+	if strings.HasSuffix(path, ".gz") {
+		if reader, err = gzip.NewReader(reader); err != nil {
+			return 0, 0, err
+		}
+	}
+	return p.Import(reader)
+}
+
+// Snapshotter periodically writes the contents of the pool to disk and loads
+// them back on startup, allowing a restarted or standby node to start with a
+// warm pool instead of waiting for the transactions to be gossiped again.
+type Snapshotter struct {
+	pool     *TxPool
+	path     string
+	interval time.Duration
+
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+// NewSnapshotter creates a snapshotter of the pool into the given file.
+func NewSnapshotter(pool *TxPool, path string, interval time.Duration) *Snapshotter {
+	return &Snapshotter{
+		pool:     pool,
+		path:     path,
+		interval: interval,
+		quit:     make(chan struct{}),
+	}
+}
+
+// Start implements node.Lifecycle, loading the last snapshot into the pool and
+// starting the periodic snapshots.
+func (s *Snapshotter) Start() error {
+	added, dropped, err := s.pool.ImportFile(s.path)
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+	case err != nil:
+		log.Warn("Failed to load transaction pool snapshot", "path", s.path, "err", err)
+	default:
+		log.Info("Loaded transaction pool snapshot", "added", added, "dropped", dropped)
+	}
+	s.wg.Add(1)
+	go s.loop()
+	return nil
+}
+
+// Stop implements node.Lifecycle, writing a final snapshot of the pool.
+func (s *Snapshotter) Stop() error {
+	close(s.quit)
+	s.wg.Wait()
+	return s.snapshot()
+}
+
+func (s *Snapshotter) loop() {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := s.snapshot(); err != nil {
+				log.Warn("Failed to snapshot transaction pool", "path", s.path, "err", err)
+			}
+		case <-s.quit:
+			return
+		}
+	}
+}
+
+func (s *Snapshotter) snapshot() error {
+	start := time.Now()
+	exported, err := s.pool.ExportFile(s.path)
+	if err != nil {
+		return err
+	}
+	log.Debug("Snapshotted transaction pool", "transactions", exported, "elapsed", time.Since(start))
+	return nil
+}
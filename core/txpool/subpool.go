@@ -58,6 +58,33 @@ func (ltx *LazyTransaction) Resolve() *types.Transaction {
 	return ltx.Pool.Get(ltx.Hash)
 }
 
+// DropReason describes why a transaction was dropped from the pool.
+type DropReason string
+
+const (
+	DropUnderpriced DropReason = "underpriced" // Evicted by better priced transactions or the min tip
+	DropReplaced    DropReason = "replaced"    // Replaced by a transaction with the same nonce
+	DropOverflow    DropReason = "overflow"    // Pool or account capacity exceeded
+	DropExpired     DropReason = "expired"     // Queued for longer than the pool lifetime
+	DropNonceGap    DropReason = "noncegap"    // Not executable anymore due to a nonce gap
+	DropUnpayable   DropReason = "unpayable"   // Sender balance insufficient for the cost
+	DropGasLimit    DropReason = "gaslimit"    // Gas above the block or transaction gas cap
+)
+
+// DroppedTx is a transaction dropped from the pool without being included.
+type DroppedTx struct {
+	Hash   common.Hash    `json:"hash"`
+	From   common.Address `json:"from"`
+	Reason DropReason     `json:"reason"`
+}
+
+// DroppedTxsEvent is posted when transactions are dropped from the pool. The
+// transactions leaving the pool because their nonce was used on chain are not
+// reported.
+type DroppedTxsEvent struct {
+	Txs []DroppedTx
+}
+
 // LazyResolver is a minimal interface needed for a transaction pool to satisfy
 // resolving lazy transactions. It's mostly a helper to avoid the entire sub-
 // pool being injected into the lazy transaction.
@@ -161,6 +188,10 @@ type SubPool interface {
 	// or also for reorged out ones.
 	SubscribeTransactions(ch chan<- core.NewTxsEvent, reorgs bool) event.Subscription
 
+	// SubscribeDropped subscribes to the transactions dropped from the pool
+	// without being included.
+	SubscribeDropped(ch chan<- DroppedTxsEvent) event.Subscription
+
 	// Nonce returns the next nonce of an account, with all transactions executable
 	// by the pool already applied on top.
 	Nonce(addr common.Address) uint64
@@ -28,6 +28,7 @@ import (
 	"github.com/ethereum/go-ethereum/crypto/kzg4844"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/params/forks"
 )
 
 var (
@@ -76,14 +77,17 @@ func ValidateTransaction(tx *types.Transaction, head *types.Header, signer types
 	if !rules.IsBerlin && tx.Type() != types.LegacyTxType {
 		return fmt.Errorf("%w: type %d rejected, pool not yet in Berlin", core.ErrTxTypeNotSupported, tx.Type())
 	}
-	if !rules.IsLondon && tx.Type() == types.DynamicFeeTxType {
-		return fmt.Errorf("%w: type %d rejected, pool not yet in London", core.ErrTxTypeNotSupported, tx.Type())
-	}
-	if !rules.IsCancun && tx.Type() == types.BlobTxType {
-		return fmt.Errorf("%w: type %d rejected, pool not yet in Cancun", core.ErrTxTypeNotSupported, tx.Type())
-	}
-	if !rules.IsPrague && tx.Type() == types.SetCodeTxType {
-		return fmt.Errorf("%w: type %d rejected, pool not yet in Prague", core.ErrTxTypeNotSupported, tx.Type())
+	spec := types.LookupTxType(tx.Type())
+	if tx.Type() != types.LegacyTxType {
+		if spec == nil {
+			return fmt.Errorf("%w: type %d rejected, unknown transaction type", core.ErrTxTypeNotSupported, tx.Type())
+		}
+		if !spec.Active(opts.Config, head.Number, head.Time) {
+			if spec.Fork != forks.Frontier {
+				return fmt.Errorf("%w: type %d rejected, pool not yet in %v", core.ErrTxTypeNotSupported, tx.Type(), spec.Fork)
+			}
+			return fmt.Errorf("%w: type %d rejected, %s transactions not yet enabled", core.ErrTxTypeNotSupported, tx.Type(), spec.Name)
+		}
 	}
 	// Check whether the init code size has been exceeded
 	if rules.IsShanghai && tx.To() == nil && len(tx.Data()) > params.MaxInitCodeSize {
@@ -143,14 +147,14 @@ func ValidateTransaction(tx *types.Transaction, head *types.Header, signer types
 	if tx.GasTipCapIntCmp(opts.MinTip) < 0 {
 		return fmt.Errorf("%w: gas tip cap %v, minimum needed %v", ErrTxGasPriceTooLow, tx.GasTipCap(), opts.MinTip)
 	}
+	if spec != nil && spec.Validate != nil {
+		if err := spec.Validate(tx, head, opts.Config); err != nil {
+			return err
+		}
+	}
 	if tx.Type() == types.BlobTxType {
 		return validateBlobTx(tx, head, opts)
 	}
-	if tx.Type() == types.SetCodeTxType {
-		if len(tx.SetCodeAuthorizations()) == 0 {
-			return errors.New("set code tx must have at least one authorization tuple")
-		}
-	}
 	return nil
 }
 
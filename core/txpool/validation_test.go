@@ -28,6 +28,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/params"
+	"github.com/holiman/uint256"
 )
 
 func TestValidateTransactionEIP2681(t *testing.T) {
@@ -96,6 +97,54 @@ func TestValidateTransactionEIP2681(t *testing.T) {
 	}
 }
 
+// Tests that typed transactions are only accepted once the fork introducing
+// them, as recorded in the transaction type registry, is active.
+func TestValidateTransactionTypeActivation(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+
+	config := *params.MergedTestChainConfig
+	config.PragueTime = newUint64(10)
+	config.OsakaTime = nil
+
+	var (
+		to     = common.HexToAddress("0x0000000000000000000000000000000000000001")
+		signer = types.LatestSigner(&config)
+		tx     = types.MustSignNewTx(key, signer, &types.SetCodeTx{
+			ChainID:   uint256.MustFromBig(config.ChainID),
+			To:        to,
+			Gas:       100000,
+			GasFeeCap: uint256.NewInt(1),
+			AuthList:  []types.SetCodeAuthorization{{Address: to}},
+		})
+		opts = &ValidationOptions{
+			Config:       &config,
+			Accept:       0xFF,
+			MaxSize:      32 * 1024,
+			MaxBlobCount: 6,
+			MinTip:       big.NewInt(0),
+		}
+	)
+	for _, tt := range []struct {
+		time    uint64
+		wantErr error
+	}{
+		{time: 9, wantErr: core.ErrTxTypeNotSupported},
+		{time: 10, wantErr: nil},
+	} {
+		head := &types.Header{
+			Number:     big.NewInt(1),
+			GasLimit:   5000000,
+			Time:       tt.time,
+			Difficulty: common.Big0,
+		}
+		if err := ValidateTransaction(tx, head, signer, opts); !errors.Is(err, tt.wantErr) {
+			t.Errorf("time %d: error mismatch: have %v, want %v", tt.time, err, tt.wantErr)
+		}
+	}
+}
+
+func newUint64(val uint64) *uint64 { return &val }
+
 // createTestTransaction creates a basic transaction for testing
 func createTestTransaction(key *ecdsa.PrivateKey, nonce uint64) *types.Transaction {
 	to := common.HexToAddress("0x0000000000000000000000000000000000000001")
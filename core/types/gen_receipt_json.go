@@ -28,6 +28,8 @@ func (r Receipt) MarshalJSON() ([]byte, error) {
 		EffectiveGasPrice *hexutil.Big   `json:"effectiveGasPrice"`
 		BlobGasUsed       hexutil.Uint64 `json:"blobGasUsed,omitempty"`
 		BlobGasPrice      *hexutil.Big   `json:"blobGasPrice,omitempty"`
+		HaltReason        string         `json:"-"`
+		RevertReason      string         `json:"-"`
 		BlockHash         common.Hash    `json:"blockHash,omitempty"`
 		BlockNumber       *hexutil.Big   `json:"blockNumber,omitempty"`
 		TransactionIndex  hexutil.Uint   `json:"transactionIndex"`
@@ -45,6 +47,8 @@ func (r Receipt) MarshalJSON() ([]byte, error) {
 	enc.EffectiveGasPrice = (*hexutil.Big)(r.EffectiveGasPrice)
 	enc.BlobGasUsed = hexutil.Uint64(r.BlobGasUsed)
 	enc.BlobGasPrice = (*hexutil.Big)(r.BlobGasPrice)
+	enc.HaltReason = r.HaltReason
+	enc.RevertReason = r.RevertReason
 	enc.BlockHash = r.BlockHash
 	enc.BlockNumber = (*hexutil.Big)(r.BlockNumber)
 	enc.TransactionIndex = hexutil.Uint(r.TransactionIndex)
@@ -66,6 +70,8 @@ func (r *Receipt) UnmarshalJSON(input []byte) error {
 		EffectiveGasPrice *hexutil.Big    `json:"effectiveGasPrice"`
 		BlobGasUsed       *hexutil.Uint64 `json:"blobGasUsed,omitempty"`
 		BlobGasPrice      *hexutil.Big    `json:"blobGasPrice,omitempty"`
+		HaltReason        *string         `json:"-"`
+		RevertReason      *string         `json:"-"`
 		BlockHash         *common.Hash    `json:"blockHash,omitempty"`
 		BlockNumber       *hexutil.Big    `json:"blockNumber,omitempty"`
 		TransactionIndex  *hexutil.Uint   `json:"transactionIndex"`
@@ -115,6 +121,12 @@ func (r *Receipt) UnmarshalJSON(input []byte) error {
 	if dec.BlobGasPrice != nil {
 		r.BlobGasPrice = (*big.Int)(dec.BlobGasPrice)
 	}
+	if dec.HaltReason != nil {
+		r.HaltReason = *dec.HaltReason
+	}
+	if dec.RevertReason != nil {
+		r.RevertReason = *dec.RevertReason
+	}
 	if dec.BlockHash != nil {
 		r.BlockHash = *dec.BlockHash
 	}
@@ -66,6 +66,8 @@ type Receipt struct {
 	EffectiveGasPrice *big.Int       `json:"effectiveGasPrice"` // required, but tag omitted for backwards compatibility
 	BlobGasUsed       uint64         `json:"blobGasUsed,omitempty"`
 	BlobGasPrice      *big.Int       `json:"blobGasPrice,omitempty"`
+	HaltReason        string         `json:"-"` // Class of the error that halted a failed transaction, if recorded
+	RevertReason      string         `json:"-"` // Decoded revert reason of a reverted transaction, if recorded
 
 	// Inclusion information: These fields provide information about the inclusion of the
 	// transaction corresponding to this receipt.
@@ -204,18 +206,16 @@ func (r *Receipt) decodeTyped(b []byte) error {
 	if len(b) <= 1 {
 		return errShortTypedReceipt
 	}
-	switch b[0] {
-	case DynamicFeeTxType, AccessListTxType, BlobTxType, SetCodeTxType:
-		var data receiptRLP
-		err := rlp.DecodeBytes(b[1:], &data)
-		if err != nil {
-			return err
-		}
-		r.Type = b[0]
-		return r.setFromRLP(data)
-	default:
+	if txTypes[b[0]] == nil {
 		return ErrTxTypeNotSupported
 	}
+	var data receiptRLP
+	err := rlp.DecodeBytes(b[1:], &data)
+	if err != nil {
+		return err
+	}
+	r.Type = b[0]
+	return r.setFromRLP(data)
 }
 
 func (r *Receipt) setFromRLP(data receiptRLP) error {
@@ -367,13 +367,10 @@ func (rs Receipts) EncodeIndex(i int, w *bytes.Buffer) {
 		return
 	}
 	w.WriteByte(r.Type)
-	switch r.Type {
-	case AccessListTxType, DynamicFeeTxType, BlobTxType, SetCodeTxType:
+	// For unsupported types, write nothing. Since this is for DeriveSha,
+	// the error will be caught matching the derived hash to the block.
+	if txTypes[r.Type] != nil {
 		rlp.Encode(w, data)
-	default:
-		// For unsupported types, write nothing. Since this is for
-		// DeriveSha, the error will be caught matching the derived hash
-		// to the block.
 	}
 }
 
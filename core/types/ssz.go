@@ -0,0 +1,775 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+
+	"github.com/ethereum/go-ethereum/common"
+	ssz "github.com/ferranbt/fastssz"
+)
+
+// SSZCodec is implemented by the types which have an SSZ encoding next to their
+// RLP one.
+//
+// Optional fields are encoded as lists of at most one element, transactions as
+// byte lists of their canonical binary encoding, as done by the engine API.
+type SSZCodec interface {
+	MarshalSSZ() ([]byte, error)
+	UnmarshalSSZ(buf []byte) error
+	HashTreeRoot() ([32]byte, error)
+}
+
+var (
+	_ SSZCodec = (*Header)(nil)
+	_ SSZCodec = (*Transaction)(nil)
+	_ SSZCodec = (*Receipt)(nil)
+	_ SSZCodec = (*Log)(nil)
+	_ SSZCodec = (*Withdrawal)(nil)
+	_ SSZCodec = (*Block)(nil)
+)
+
+// Limits of the SSZ lists, which are part of the hash tree roots.
+const (
+	sszMaxExtraDataSize   = 1 << 16
+	sszMaxTransactionSize = 1 << 30
+	sszMaxTransactions    = 1 << 20
+	sszMaxUncles          = 1 << 4
+	sszMaxWithdrawals     = 1 << 16
+	sszMaxLogs            = 1 << 20
+	sszMaxLogTopics       = 4
+	sszMaxLogDataSize     = 1 << 24
+)
+
+// withdrawalSSZSize is the size of the fixed-size SSZ encoding of a withdrawal.
+const withdrawalSSZSize = 8 + 8 + common.AddressLength + 8
+
+var (
+	errSSZShort    = errors.New("ssz: buffer too short")
+	errSSZSize     = errors.New("ssz: invalid size")
+	errSSZOffset   = errors.New("ssz: invalid offset")
+	errSSZTooLarge = errors.New("ssz: list too large")
+)
+
+// MarshalSSZ encodes the header as SSZ.
+func (h *Header) MarshalSSZ() ([]byte, error) {
+	if len(h.Extra) > sszMaxExtraDataSize {
+		return nil, fmt.Errorf("%w: extra data of %d bytes", errSSZTooLarge, len(h.Extra))
+	}
+	difficulty, err := sszUint256(h.Difficulty)
+	if err != nil {
+		return nil, fmt.Errorf("invalid difficulty: %v", err)
+	}
+	var baseFee []byte
+	if h.BaseFee != nil {
+		if baseFee, err = sszUint256(h.BaseFee); err != nil {
+			return nil, fmt.Errorf("invalid base fee: %v", err)
+		}
+	}
+	return sszEncodeContainer(
+		sszFixed(h.ParentHash[:]),
+		sszFixed(h.UncleHash[:]),
+		sszFixed(h.Coinbase[:]),
+		sszFixed(h.Root[:]),
+		sszFixed(h.TxHash[:]),
+		sszFixed(h.ReceiptHash[:]),
+		sszFixed(h.Bloom[:]),
+		sszFixed(difficulty),
+		sszFixed(sszUint64(h.Number.Uint64())),
+		sszFixed(sszUint64(h.GasLimit)),
+		sszFixed(sszUint64(h.GasUsed)),
+		sszFixed(sszUint64(h.Time)),
+		sszVariable(h.Extra),
+		sszFixed(h.MixDigest[:]),
+		sszFixed(h.Nonce[:]),
+		sszVariable(baseFee),
+		sszVariable(sszOptionalHash(h.WithdrawalsHash)),
+		sszVariable(sszOptionalUint64(h.BlobGasUsed)),
+		sszVariable(sszOptionalUint64(h.ExcessBlobGas)),
+		sszVariable(sszOptionalHash(h.ParentBeaconRoot)),
+		sszVariable(sszOptionalHash(h.RequestsHash)),
+	), nil
+}
+
+// UnmarshalSSZ decodes an SSZ encoded header.
+func (h *Header) UnmarshalSSZ(buf []byte) error {
+	fields, err := sszDecodeContainer(buf, 32, 32, 20, 32, 32, 32, BloomByteLength, 32, 8, 8, 8, 8, 0, 32, 8, 0, 0, 0, 0, 0, 0)
+	if err != nil {
+		return err
+	}
+	if len(fields[12]) > sszMaxExtraDataSize {
+		return fmt.Errorf("%w: extra data of %d bytes", errSSZTooLarge, len(fields[12]))
+	}
+	var dec Header
+	copy(dec.ParentHash[:], fields[0])
+	copy(dec.UncleHash[:], fields[1])
+	copy(dec.Coinbase[:], fields[2])
+	copy(dec.Root[:], fields[3])
+	copy(dec.TxHash[:], fields[4])
+	copy(dec.ReceiptHash[:], fields[5])
+	copy(dec.Bloom[:], fields[6])
+	dec.Difficulty = sszToBig(fields[7])
+	dec.Number = new(big.Int).SetUint64(binary.LittleEndian.Uint64(fields[8]))
+	dec.GasLimit = binary.LittleEndian.Uint64(fields[9])
+	dec.GasUsed = binary.LittleEndian.Uint64(fields[10])
+	dec.Time = binary.LittleEndian.Uint64(fields[11])
+	dec.Extra = common.CopyBytes(fields[12])
+	copy(dec.MixDigest[:], fields[13])
+	copy(dec.Nonce[:], fields[14])
+
+	switch len(fields[15]) {
+	case 0:
+	case 32:
+		dec.BaseFee = sszToBig(fields[15])
+	default:
+		return fmt.Errorf("%w: base fee of %d bytes", errSSZSize, len(fields[15]))
+	}
+	if dec.WithdrawalsHash, err = sszToOptionalHash(fields[16]); err != nil {
+		return err
+	}
+	if dec.BlobGasUsed, err = sszToOptionalUint64(fields[17]); err != nil {
+		return err
+	}
+	if dec.ExcessBlobGas, err = sszToOptionalUint64(fields[18]); err != nil {
+		return err
+	}
+	if dec.ParentBeaconRoot, err = sszToOptionalHash(fields[19]); err != nil {
+		return err
+	}
+	if dec.RequestsHash, err = sszToOptionalHash(fields[20]); err != nil {
+		return err
+	}
+	*h = dec
+	return nil
+}
+
+// HashTreeRoot computes the SSZ hash tree root of the header.
+func (h *Header) HashTreeRoot() ([32]byte, error) {
+	return sszHashTreeRoot(h.HashTreeRootWith)
+}
+
+// HashTreeRootWith merkleizes the header with the given hasher.
+func (h *Header) HashTreeRootWith(hh ssz.HashWalker) error {
+	difficulty, err := sszUint256(h.Difficulty)
+	if err != nil {
+		return fmt.Errorf("invalid difficulty: %v", err)
+	}
+	indx := hh.Index()
+
+	hh.PutBytes(h.ParentHash[:])
+	hh.PutBytes(h.UncleHash[:])
+	hh.PutBytes(h.Coinbase[:])
+	hh.PutBytes(h.Root[:])
+	hh.PutBytes(h.TxHash[:])
+	hh.PutBytes(h.ReceiptHash[:])
+	hh.PutBytes(h.Bloom[:])
+	hh.PutBytes(difficulty)
+	hh.PutUint64(h.Number.Uint64())
+	hh.PutUint64(h.GasLimit)
+	hh.PutUint64(h.GasUsed)
+	hh.PutUint64(h.Time)
+	sszHashBytes(hh, h.Extra, sszMaxExtraDataSize)
+	hh.PutBytes(h.MixDigest[:])
+	hh.PutBytes(h.Nonce[:])
+
+	var baseFee []byte
+	if h.BaseFee != nil {
+		if baseFee, err = sszUint256(h.BaseFee); err != nil {
+			return fmt.Errorf("invalid base fee: %v", err)
+		}
+	}
+	sszHashOptional(hh, baseFee)
+	sszHashOptional(hh, sszOptionalHash(h.WithdrawalsHash))
+	sszHashOptional(hh, sszOptionalUint64(h.BlobGasUsed))
+	sszHashOptional(hh, sszOptionalUint64(h.ExcessBlobGas))
+	sszHashOptional(hh, sszOptionalHash(h.ParentBeaconRoot))
+	sszHashOptional(hh, sszOptionalHash(h.RequestsHash))
+
+	hh.Merkleize(indx)
+	return nil
+}
+
+// MarshalSSZ encodes the transaction as SSZ, which is the byte list of its
+// canonical binary encoding, without any blob sidecar.
+func (tx *Transaction) MarshalSSZ() ([]byte, error) {
+	return tx.WithoutBlobTxSidecar().MarshalBinary()
+}
+
+// UnmarshalSSZ decodes an SSZ encoded transaction.
+func (tx *Transaction) UnmarshalSSZ(buf []byte) error {
+	if len(buf) > sszMaxTransactionSize {
+		return fmt.Errorf("%w: transaction of %d bytes", errSSZTooLarge, len(buf))
+	}
+	return tx.UnmarshalBinary(buf)
+}
+
+// HashTreeRoot computes the SSZ hash tree root of the transaction.
+func (tx *Transaction) HashTreeRoot() ([32]byte, error) {
+	return sszHashTreeRoot(tx.HashTreeRootWith)
+}
+
+// HashTreeRootWith merkleizes the transaction with the given hasher.
+func (tx *Transaction) HashTreeRootWith(hh ssz.HashWalker) error {
+	enc, err := tx.MarshalSSZ()
+	if err != nil {
+		return err
+	}
+	sszHashBytes(hh, enc, sszMaxTransactionSize)
+	return nil
+}
+
+// MarshalSSZ encodes the consensus fields of the log as SSZ.
+func (l *Log) MarshalSSZ() ([]byte, error) {
+	if len(l.Topics) > sszMaxLogTopics {
+		return nil, fmt.Errorf("%w: %d log topics", errSSZTooLarge, len(l.Topics))
+	}
+	if len(l.Data) > sszMaxLogDataSize {
+		return nil, fmt.Errorf("%w: log data of %d bytes", errSSZTooLarge, len(l.Data))
+	}
+	topics := make([]byte, 0, len(l.Topics)*common.HashLength)
+	for _, topic := range l.Topics {
+		topics = append(topics, topic[:]...)
+	}
+	return sszEncodeContainer(sszFixed(l.Address[:]), sszVariable(topics), sszVariable(l.Data)), nil
+}
+
+// UnmarshalSSZ decodes the consensus fields of an SSZ encoded log.
+func (l *Log) UnmarshalSSZ(buf []byte) error {
+	fields, err := sszDecodeContainer(buf, common.AddressLength, 0, 0)
+	if err != nil {
+		return err
+	}
+	topics, err := sszDecodeFixedList(fields[1], common.HashLength, sszMaxLogTopics)
+	if err != nil {
+		return err
+	}
+	if len(fields[2]) > sszMaxLogDataSize {
+		return fmt.Errorf("%w: log data of %d bytes", errSSZTooLarge, len(fields[2]))
+	}
+	var dec Log
+	copy(dec.Address[:], fields[0])
+	dec.Topics = make([]common.Hash, len(topics))
+	for i, topic := range topics {
+		copy(dec.Topics[i][:], topic)
+	}
+	dec.Data = common.CopyBytes(fields[2])
+	*l = dec
+	return nil
+}
+
+// HashTreeRoot computes the SSZ hash tree root of the log.
+func (l *Log) HashTreeRoot() ([32]byte, error) {
+	return sszHashTreeRoot(l.HashTreeRootWith)
+}
+
+// HashTreeRootWith merkleizes the log with the given hasher.
+func (l *Log) HashTreeRootWith(hh ssz.HashWalker) error {
+	if len(l.Topics) > sszMaxLogTopics {
+		return fmt.Errorf("%w: %d log topics", errSSZTooLarge, len(l.Topics))
+	}
+	indx := hh.Index()
+	hh.PutBytes(l.Address[:])
+	{
+		subIndx := hh.Index()
+		for _, topic := range l.Topics {
+			hh.Append(topic[:])
+		}
+		hh.MerkleizeWithMixin(subIndx, uint64(len(l.Topics)), sszMaxLogTopics)
+	}
+	sszHashBytes(hh, l.Data, sszMaxLogDataSize)
+	hh.Merkleize(indx)
+	return nil
+}
+
+// MarshalSSZ encodes the consensus fields of the receipt as SSZ.
+func (r *Receipt) MarshalSSZ() ([]byte, error) {
+	if len(r.Logs) > sszMaxLogs {
+		return nil, fmt.Errorf("%w: %d logs", errSSZTooLarge, len(r.Logs))
+	}
+	logs := make([][]byte, len(r.Logs))
+	for i, log := range r.Logs {
+		enc, err := log.MarshalSSZ()
+		if err != nil {
+			return nil, err
+		}
+		logs[i] = enc
+	}
+	return sszEncodeContainer(
+		sszFixed([]byte{r.Type}),
+		sszVariable(r.statusEncoding()),
+		sszFixed(sszUint64(r.CumulativeGasUsed)),
+		sszVariable(sszEncodeList(logs)),
+	), nil
+}
+
+// UnmarshalSSZ decodes the consensus fields of an SSZ encoded receipt. The
+// bloom filter is derived from the logs.
+func (r *Receipt) UnmarshalSSZ(buf []byte) error {
+	fields, err := sszDecodeContainer(buf, 1, 0, 8, 0)
+	if err != nil {
+		return err
+	}
+	logs, err := sszDecodeList(fields[3], sszMaxLogs)
+	if err != nil {
+		return err
+	}
+	dec := Receipt{
+		Type:              fields[0][0],
+		CumulativeGasUsed: binary.LittleEndian.Uint64(fields[2]),
+		Logs:              make([]*Log, len(logs)),
+	}
+	if err := dec.setStatus(common.CopyBytes(fields[1])); err != nil {
+		return err
+	}
+	for i, enc := range logs {
+		dec.Logs[i] = new(Log)
+		if err := dec.Logs[i].UnmarshalSSZ(enc); err != nil {
+			return err
+		}
+	}
+	dec.Bloom = CreateBloom(&dec)
+	*r = dec
+	return nil
+}
+
+// HashTreeRoot computes the SSZ hash tree root of the receipt.
+func (r *Receipt) HashTreeRoot() ([32]byte, error) {
+	return sszHashTreeRoot(r.HashTreeRootWith)
+}
+
+// HashTreeRootWith merkleizes the receipt with the given hasher.
+func (r *Receipt) HashTreeRootWith(hh ssz.HashWalker) error {
+	if len(r.Logs) > sszMaxLogs {
+		return fmt.Errorf("%w: %d logs", errSSZTooLarge, len(r.Logs))
+	}
+	indx := hh.Index()
+	hh.PutUint8(r.Type)
+	sszHashBytes(hh, r.statusEncoding(), common.HashLength)
+	hh.PutUint64(r.CumulativeGasUsed)
+	{
+		subIndx := hh.Index()
+		for _, log := range r.Logs {
+			if err := log.HashTreeRootWith(hh); err != nil {
+				return err
+			}
+		}
+		hh.MerkleizeWithMixin(subIndx, uint64(len(r.Logs)), sszMaxLogs)
+	}
+	hh.Merkleize(indx)
+	return nil
+}
+
+// MarshalSSZ encodes the withdrawal as SSZ.
+func (w *Withdrawal) MarshalSSZ() ([]byte, error) {
+	enc := make([]byte, 0, withdrawalSSZSize)
+	enc = binary.LittleEndian.AppendUint64(enc, w.Index)
+	enc = binary.LittleEndian.AppendUint64(enc, w.Validator)
+	enc = append(enc, w.Address[:]...)
+	enc = binary.LittleEndian.AppendUint64(enc, w.Amount)
+	return enc, nil
+}
+
+// UnmarshalSSZ decodes an SSZ encoded withdrawal.
+func (w *Withdrawal) UnmarshalSSZ(buf []byte) error {
+	if len(buf) != withdrawalSSZSize {
+		return fmt.Errorf("%w: withdrawal of %d bytes", errSSZSize, len(buf))
+	}
+	w.Index = binary.LittleEndian.Uint64(buf[0:8])
+	w.Validator = binary.LittleEndian.Uint64(buf[8:16])
+	copy(w.Address[:], buf[16:36])
+	w.Amount = binary.LittleEndian.Uint64(buf[36:44])
+	return nil
+}
+
+// HashTreeRoot computes the SSZ hash tree root of the withdrawal.
+func (w *Withdrawal) HashTreeRoot() ([32]byte, error) {
+	return sszHashTreeRoot(w.HashTreeRootWith)
+}
+
+// HashTreeRootWith merkleizes the withdrawal with the given hasher.
+func (w *Withdrawal) HashTreeRootWith(hh ssz.HashWalker) error {
+	indx := hh.Index()
+	hh.PutUint64(w.Index)
+	hh.PutUint64(w.Validator)
+	hh.PutBytes(w.Address[:])
+	hh.PutUint64(w.Amount)
+	hh.Merkleize(indx)
+	return nil
+}
+
+// MarshalSSZ encodes the block as SSZ. The withdrawals are encoded as an empty
+// list before Shanghai.
+func (b *Block) MarshalSSZ() ([]byte, error) {
+	if len(b.transactions) > sszMaxTransactions {
+		return nil, fmt.Errorf("%w: %d transactions", errSSZTooLarge, len(b.transactions))
+	}
+	if len(b.uncles) > sszMaxUncles {
+		return nil, fmt.Errorf("%w: %d uncles", errSSZTooLarge, len(b.uncles))
+	}
+	if len(b.withdrawals) > sszMaxWithdrawals {
+		return nil, fmt.Errorf("%w: %d withdrawals", errSSZTooLarge, len(b.withdrawals))
+	}
+	header, err := b.header.MarshalSSZ()
+	if err != nil {
+		return nil, err
+	}
+	txs := make([][]byte, len(b.transactions))
+	for i, tx := range b.transactions {
+		if txs[i], err = tx.MarshalSSZ(); err != nil {
+			return nil, err
+		}
+	}
+	uncles := make([][]byte, len(b.uncles))
+	for i, uncle := range b.uncles {
+		if uncles[i], err = uncle.MarshalSSZ(); err != nil {
+			return nil, err
+		}
+	}
+	withdrawals := make([]byte, 0, len(b.withdrawals)*withdrawalSSZSize)
+	for _, w := range b.withdrawals {
+		enc, _ := w.MarshalSSZ()
+		withdrawals = append(withdrawals, enc...)
+	}
+	return sszEncodeContainer(
+		sszVariable(header),
+		sszVariable(sszEncodeList(txs)),
+		sszVariable(sszEncodeList(uncles)),
+		sszVariable(withdrawals),
+	), nil
+}
+
+// UnmarshalSSZ decodes an SSZ encoded block. The withdrawals are only set if
+// the header commits to them.
+func (b *Block) UnmarshalSSZ(buf []byte) error {
+	fields, err := sszDecodeContainer(buf, 0, 0, 0, 0)
+	if err != nil {
+		return err
+	}
+	header := new(Header)
+	if err := header.UnmarshalSSZ(fields[0]); err != nil {
+		return err
+	}
+	encTxs, err := sszDecodeList(fields[1], sszMaxTransactions)
+	if err != nil {
+		return err
+	}
+	txs := make([]*Transaction, len(encTxs))
+	for i, enc := range encTxs {
+		txs[i] = new(Transaction)
+		if err := txs[i].UnmarshalSSZ(enc); err != nil {
+			return fmt.Errorf("transaction %d: %v", i, err)
+		}
+	}
+	encUncles, err := sszDecodeList(fields[2], sszMaxUncles)
+	if err != nil {
+		return err
+	}
+	uncles := make([]*Header, len(encUncles))
+	for i, enc := range encUncles {
+		uncles[i] = new(Header)
+		if err := uncles[i].UnmarshalSSZ(enc); err != nil {
+			return fmt.Errorf("uncle %d: %v", i, err)
+		}
+	}
+	encWithdrawals, err := sszDecodeFixedList(fields[3], withdrawalSSZSize, sszMaxWithdrawals)
+	if err != nil {
+		return err
+	}
+	var withdrawals []*Withdrawal
+	if header.WithdrawalsHash != nil {
+		withdrawals = make([]*Withdrawal, len(encWithdrawals))
+		for i, enc := range encWithdrawals {
+			withdrawals[i] = new(Withdrawal)
+			withdrawals[i].UnmarshalSSZ(enc)
+		}
+	} else if len(encWithdrawals) > 0 {
+		return errors.New("ssz: withdrawals in block without withdrawals root")
+	}
+	b.header, b.uncles, b.transactions, b.withdrawals = header, uncles, txs, withdrawals
+	return nil
+}
+
+// HashTreeRoot computes the SSZ hash tree root of the block.
+func (b *Block) HashTreeRoot() ([32]byte, error) {
+	return sszHashTreeRoot(b.HashTreeRootWith)
+}
+
+// HashTreeRootWith merkleizes the block with the given hasher.
+func (b *Block) HashTreeRootWith(hh ssz.HashWalker) error {
+	indx := hh.Index()
+	if err := b.header.HashTreeRootWith(hh); err != nil {
+		return err
+	}
+	{
+		subIndx := hh.Index()
+		for _, tx := range b.transactions {
+			if err := tx.HashTreeRootWith(hh); err != nil {
+				return err
+			}
+		}
+		hh.MerkleizeWithMixin(subIndx, uint64(len(b.transactions)), sszMaxTransactions)
+	}
+	{
+		subIndx := hh.Index()
+		for _, uncle := range b.uncles {
+			if err := uncle.HashTreeRootWith(hh); err != nil {
+				return err
+			}
+		}
+		hh.MerkleizeWithMixin(subIndx, uint64(len(b.uncles)), sszMaxUncles)
+	}
+	{
+		subIndx := hh.Index()
+		for _, w := range b.withdrawals {
+			w.HashTreeRootWith(hh)
+		}
+		hh.MerkleizeWithMixin(subIndx, uint64(len(b.withdrawals)), sszMaxWithdrawals)
+	}
+	hh.Merkleize(indx)
+	return nil
+}
+
+// sszField is the encoding of a container field, either of fixed or variable
+// size.
+type sszField struct {
+	data  []byte
+	fixed bool
+}
+
+func sszFixed(data []byte) sszField    { return sszField{data: data, fixed: true} }
+func sszVariable(data []byte) sszField { return sszField{data: data} }
+
+// sszEncodeContainer serializes the fields of a container: the fixed-size fields
+// and the offsets of the variable-size ones first, followed by the data of the
+// variable-size fields.
+func sszEncodeContainer(fields ...sszField) []byte {
+	var fixed, size int
+	for _, field := range fields {
+		if field.fixed {
+			fixed += len(field.data)
+		} else {
+			fixed += 4
+			size += len(field.data)
+		}
+	}
+	var (
+		enc    = make([]byte, 0, fixed+size)
+		offset = fixed
+	)
+	for _, field := range fields {
+		if field.fixed {
+			enc = append(enc, field.data...)
+		} else {
+			enc = binary.LittleEndian.AppendUint32(enc, uint32(offset))
+			offset += len(field.data)
+		}
+	}
+	for _, field := range fields {
+		if !field.fixed {
+			enc = append(enc, field.data...)
+		}
+	}
+	return enc
+}
+
+// sszDecodeContainer splits an SSZ container into its fields. The sizes are the
+// ones of the fixed-size fields, zero for the variable-size ones.
+func sszDecodeContainer(buf []byte, sizes ...int) ([][]byte, error) {
+	var fixed int
+	for _, size := range sizes {
+		if size == 0 {
+			fixed += 4
+		} else {
+			fixed += size
+		}
+	}
+	if len(buf) < fixed {
+		return nil, errSSZShort
+	}
+	var (
+		fields  = make([][]byte, len(sizes))
+		offsets []int
+		indices []int
+		pos     int
+	)
+	for i, size := range sizes {
+		if size > 0 {
+			fields[i] = buf[pos : pos+size]
+			pos += size
+			continue
+		}
+		offsets = append(offsets, int(binary.LittleEndian.Uint32(buf[pos:])))
+		indices = append(indices, i)
+		pos += 4
+	}
+	if len(offsets) == 0 {
+		if len(buf) != fixed {
+			return nil, errSSZSize
+		}
+		return fields, nil
+	}
+	if offsets[0] != fixed {
+		return nil, errSSZOffset
+	}
+	for i, offset := range offsets {
+		end := len(buf)
+		if i+1 < len(offsets) {
+			end = offsets[i+1]
+		}
+		if offset > end || end > len(buf) {
+			return nil, errSSZOffset
+		}
+		fields[indices[i]] = buf[offset:end]
+	}
+	return fields, nil
+}
+
+// sszEncodeList serializes a list of variable-size elements.
+func sszEncodeList(items [][]byte) []byte {
+	fields := make([]sszField, len(items))
+	for i, item := range items {
+		fields[i] = sszVariable(item)
+	}
+	return sszEncodeContainer(fields...)
+}
+
+// sszDecodeList splits an SSZ list of variable-size elements.
+func sszDecodeList(buf []byte, limit int) ([][]byte, error) {
+	if len(buf) == 0 {
+		return nil, nil
+	}
+	if len(buf) < 4 {
+		return nil, errSSZShort
+	}
+	first := int(binary.LittleEndian.Uint32(buf))
+	if first == 0 || first%4 != 0 {
+		return nil, errSSZOffset
+	}
+	if first/4 > limit {
+		return nil, fmt.Errorf("%w: %d elements, limit %d", errSSZTooLarge, first/4, limit)
+	}
+	return sszDecodeContainer(buf, make([]int, first/4)...)
+}
+
+// sszDecodeFixedList splits an SSZ list of fixed-size elements.
+func sszDecodeFixedList(buf []byte, size int, limit int) ([][]byte, error) {
+	if len(buf)%size != 0 {
+		return nil, errSSZSize
+	}
+	if len(buf)/size > limit {
+		return nil, fmt.Errorf("%w: %d elements, limit %d", errSSZTooLarge, len(buf)/size, limit)
+	}
+	return slices.Collect(slices.Chunk(buf, size)), nil
+}
+
+func sszUint64(v uint64) []byte {
+	return binary.LittleEndian.AppendUint64(nil, v)
+}
+
+// sszUint256 encodes a big integer as a little-endian uint256.
+func sszUint256(v *big.Int) ([]byte, error) {
+	if v == nil {
+		v = new(big.Int)
+	}
+	if v.Sign() < 0 || v.BitLen() > 256 {
+		return nil, fmt.Errorf("value %v out of uint256 range", v)
+	}
+	enc := v.FillBytes(make([]byte, 32))
+	slices.Reverse(enc)
+	return enc, nil
+}
+
+// sszToBig decodes a little-endian uint256.
+func sszToBig(enc []byte) *big.Int {
+	be := slices.Clone(enc)
+	slices.Reverse(be)
+	return new(big.Int).SetBytes(be)
+}
+
+// sszOptionalHash encodes an optional hash as a list of at most one element.
+func sszOptionalHash(h *common.Hash) []byte {
+	if h == nil {
+		return nil
+	}
+	return h[:]
+}
+
+// sszOptionalUint64 encodes an optional integer as a list of at most one element.
+func sszOptionalUint64(v *uint64) []byte {
+	if v == nil {
+		return nil
+	}
+	return sszUint64(*v)
+}
+
+func sszToOptionalHash(enc []byte) (*common.Hash, error) {
+	switch len(enc) {
+	case 0:
+		return nil, nil
+	case common.HashLength:
+		h := common.BytesToHash(enc)
+		return &h, nil
+	default:
+		return nil, fmt.Errorf("%w: optional hash of %d bytes", errSSZSize, len(enc))
+	}
+}
+
+func sszToOptionalUint64(enc []byte) (*uint64, error) {
+	switch len(enc) {
+	case 0:
+		return nil, nil
+	case 8:
+		v := binary.LittleEndian.Uint64(enc)
+		return &v, nil
+	default:
+		return nil, fmt.Errorf("%w: optional integer of %d bytes", errSSZSize, len(enc))
+	}
+}
+
+// sszHashTreeRoot computes the hash tree root of an object merkleized by fn.
+func sszHashTreeRoot(fn func(ssz.HashWalker) error) ([32]byte, error) {
+	hh := ssz.NewHasher()
+	if err := fn(hh); err != nil {
+		return [32]byte{}, err
+	}
+	return hh.HashRoot()
+}
+
+// sszHashBytes merkleizes a byte list with the given limit.
+func sszHashBytes(hh ssz.HashWalker, b []byte, limit uint64) {
+	indx := hh.Index()
+	hh.Append(b)
+	hh.FillUpTo32()
+	hh.MerkleizeWithMixin(indx, uint64(len(b)), (limit+31)/32)
+}
+
+// sszHashOptional merkleizes an optional basic value of at most 32 bytes, which
+// is a list of at most one element.
+func sszHashOptional(hh ssz.HashWalker, enc []byte) {
+	var n uint64
+	indx := hh.Index()
+	if enc != nil {
+		hh.Append(enc)
+		hh.FillUpTo32()
+		n = 1
+	}
+	hh.MerkleizeWithMixin(indx, n, 1)
+}
@@ -0,0 +1,196 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	"errors"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/internal/blocktest"
+)
+
+func TestHeaderSSZ(t *testing.T) {
+	var (
+		hash   = common.HexToHash("0x1234")
+		number = uint64(7)
+	)
+	headers := []*Header{
+		// Pre-London header without any optional field
+		{
+			ParentHash: common.HexToHash("0x01"),
+			Coinbase:   testAddr,
+			Difficulty: big.NewInt(131072),
+			Number:     big.NewInt(1),
+			GasLimit:   3141592,
+			GasUsed:    21000,
+			Time:       1426516743,
+			Extra:      []byte("extra"),
+			Nonce:      EncodeNonce(0xa13a5a8c8f2bb1c4),
+		},
+		// Prague header with all optional fields
+		{
+			ParentHash:       common.HexToHash("0x02"),
+			Difficulty:       new(big.Int),
+			Number:           big.NewInt(2),
+			GasLimit:         30_000_000,
+			BaseFee:          big.NewInt(875000000),
+			WithdrawalsHash:  &EmptyWithdrawalsHash,
+			BlobGasUsed:      &number,
+			ExcessBlobGas:    &number,
+			ParentBeaconRoot: &hash,
+			RequestsHash:     &EmptyRequestsHash,
+		},
+	}
+	for i, header := range headers {
+		enc, err := header.MarshalSSZ()
+		if err != nil {
+			t.Fatalf("header %d: failed to encode: %v", i, err)
+		}
+		dec := new(Header)
+		if err := dec.UnmarshalSSZ(enc); err != nil {
+			t.Fatalf("header %d: failed to decode: %v", i, err)
+		}
+		if dec.Hash() != header.Hash() {
+			t.Errorf("header %d: hash mismatch after roundtrip: have %x, want %x", i, dec.Hash(), header.Hash())
+		}
+		// Truncated encodings must be rejected
+		if err := new(Header).UnmarshalSSZ(enc[:len(enc)-1]); err == nil {
+			t.Errorf("header %d: truncated encoding accepted", i)
+		}
+		if err := new(Header).UnmarshalSSZ(enc[:100]); !errors.Is(err, errSSZShort) {
+			t.Errorf("header %d: short encoding error mismatch: have %v, want %v", i, err, errSSZShort)
+		}
+	}
+	// The hash tree roots of different headers must differ
+	root0, err := headers[0].HashTreeRoot()
+	if err != nil {
+		t.Fatalf("failed to hash header: %v", err)
+	}
+	root1, err := headers[1].HashTreeRoot()
+	if err != nil {
+		t.Fatalf("failed to hash header: %v", err)
+	}
+	if root0 == root1 {
+		t.Errorf("hash tree roots of different headers collide: %x", root0)
+	}
+}
+
+func TestTransactionSSZ(t *testing.T) {
+	for i, tx := range []*Transaction{emptyTx, rightvrsTx, signedEip2718Tx} {
+		enc, err := tx.MarshalSSZ()
+		if err != nil {
+			t.Fatalf("tx %d: failed to encode: %v", i, err)
+		}
+		dec := new(Transaction)
+		if err := dec.UnmarshalSSZ(enc); err != nil {
+			t.Fatalf("tx %d: failed to decode: %v", i, err)
+		}
+		if dec.Hash() != tx.Hash() {
+			t.Errorf("tx %d: hash mismatch after roundtrip: have %x, want %x", i, dec.Hash(), tx.Hash())
+		}
+		if _, err := tx.HashTreeRoot(); err != nil {
+			t.Errorf("tx %d: failed to hash: %v", i, err)
+		}
+	}
+}
+
+func TestReceiptSSZ(t *testing.T) {
+	success := &Receipt{Status: ReceiptStatusSuccessful, CumulativeGasUsed: 21000, Type: DynamicFeeTxType}
+	postState := &Receipt{PostState: common.HexToHash("0xabcd").Bytes(), CumulativeGasUsed: 42000}
+
+	for i, receipt := range []*Receipt{legacyReceipt, accessListReceipt, eip1559Receipt, success, postState} {
+		enc, err := receipt.MarshalSSZ()
+		if err != nil {
+			t.Fatalf("receipt %d: failed to encode: %v", i, err)
+		}
+		dec := new(Receipt)
+		if err := dec.UnmarshalSSZ(enc); err != nil {
+			t.Fatalf("receipt %d: failed to decode: %v", i, err)
+		}
+		if dec.Type != receipt.Type || dec.Status != receipt.Status || dec.CumulativeGasUsed != receipt.CumulativeGasUsed {
+			t.Errorf("receipt %d: consensus fields mismatch: have %+v, want %+v", i, dec, receipt)
+		}
+		if !reflect.DeepEqual(dec.PostState, receipt.PostState) && len(receipt.PostState) > 0 {
+			t.Errorf("receipt %d: post state mismatch: have %x, want %x", i, dec.PostState, receipt.PostState)
+		}
+		if len(dec.Logs) != len(receipt.Logs) {
+			t.Fatalf("receipt %d: log count mismatch: have %d, want %d", i, len(dec.Logs), len(receipt.Logs))
+		}
+		for j, log := range receipt.Logs {
+			if dec.Logs[j].Address != log.Address || !reflect.DeepEqual(dec.Logs[j].Topics, log.Topics) || !reflect.DeepEqual(dec.Logs[j].Data, log.Data) {
+				t.Errorf("receipt %d: log %d mismatch: have %+v, want %+v", i, j, dec.Logs[j], log)
+			}
+		}
+		if dec.Bloom != CreateBloom(receipt) {
+			t.Errorf("receipt %d: bloom mismatch", i)
+		}
+		if _, err := receipt.HashTreeRoot(); err != nil {
+			t.Errorf("receipt %d: failed to hash: %v", i, err)
+		}
+	}
+	// Logs with too many topics can't be encoded
+	log := &Log{Topics: make([]common.Hash, sszMaxLogTopics+1)}
+	if _, err := log.MarshalSSZ(); !errors.Is(err, errSSZTooLarge) {
+		t.Errorf("oversized log error mismatch: have %v, want %v", err, errSSZTooLarge)
+	}
+}
+
+func TestBlockSSZ(t *testing.T) {
+	header := &Header{
+		Difficulty:      new(big.Int),
+		Number:          big.NewInt(1),
+		GasLimit:        30_000_000,
+		BaseFee:         big.NewInt(1_000_000_000),
+		WithdrawalsHash: &EmptyWithdrawalsHash,
+	}
+	withdrawals := []*Withdrawal{
+		{Index: 1, Validator: 2, Address: testAddr, Amount: 3},
+		{Index: 4, Validator: 5, Address: common.Address{0x01}, Amount: 6},
+	}
+	blocks := []*Block{
+		NewBlock(header, &Body{Transactions: []*Transaction{rightvrsTx, signedEip2718Tx}, Withdrawals: withdrawals}, nil, blocktest.NewHasher()),
+		NewBlock(&Header{Difficulty: big.NewInt(1), Number: big.NewInt(2)}, &Body{Uncles: []*Header{{Difficulty: big.NewInt(1), Number: big.NewInt(1)}}}, nil, blocktest.NewHasher()),
+	}
+	for i, block := range blocks {
+		enc, err := block.MarshalSSZ()
+		if err != nil {
+			t.Fatalf("block %d: failed to encode: %v", i, err)
+		}
+		dec := new(Block)
+		if err := dec.UnmarshalSSZ(enc); err != nil {
+			t.Fatalf("block %d: failed to decode: %v", i, err)
+		}
+		if dec.Hash() != block.Hash() {
+			t.Errorf("block %d: hash mismatch after roundtrip: have %x, want %x", i, dec.Hash(), block.Hash())
+		}
+		if DeriveSha(dec.Transactions(), blocktest.NewHasher()) != DeriveSha(block.Transactions(), blocktest.NewHasher()) {
+			t.Errorf("block %d: transactions mismatch after roundtrip", i)
+		}
+		if CalcUncleHash(dec.Uncles()) != block.UncleHash() {
+			t.Errorf("block %d: uncles mismatch after roundtrip", i)
+		}
+		if !reflect.DeepEqual(dec.Withdrawals(), block.Withdrawals()) {
+			t.Errorf("block %d: withdrawals mismatch: have %v, want %v", i, dec.Withdrawals(), block.Withdrawals())
+		}
+		if _, err := block.HashTreeRoot(); err != nil {
+			t.Errorf("block %d: failed to hash: %v", i, err)
+		}
+	}
+}
@@ -202,19 +202,11 @@ func (tx *Transaction) decodeTyped(b []byte) (TxData, error) {
 	if len(b) <= 1 {
 		return nil, errShortTypedTx
 	}
-	var inner TxData
-	switch b[0] {
-	case AccessListTxType:
-		inner = new(AccessListTx)
-	case DynamicFeeTxType:
-		inner = new(DynamicFeeTx)
-	case BlobTxType:
-		inner = new(BlobTx)
-	case SetCodeTxType:
-		inner = new(SetCodeTx)
-	default:
+	spec := txTypes[b[0]]
+	if spec == nil {
 		return nil, ErrTxTypeNotSupported
 	}
+	inner := spec.newData()
 	err := inner.decode(b[1:])
 	return inner, err
 }
@@ -170,6 +170,11 @@ func (tx *Transaction) MarshalJSON() ([]byte, error) {
 		enc.S = (*hexutil.Big)(itx.S.ToBig())
 		yparity := itx.V.Uint64()
 		enc.YParity = (*hexutil.Uint64)(&yparity)
+
+	default:
+		if spec := txTypes[tx.Type()]; spec != nil && spec.marshalJSON != nil {
+			spec.marshalJSON(tx, &enc)
+		}
 	}
 	return json.Marshal(&enc)
 }
@@ -508,7 +513,13 @@ func (tx *Transaction) UnmarshalJSON(input []byte) error {
 		}
 
 	default:
-		return ErrTxTypeNotSupported
+		spec := txTypes[byte(dec.Type)]
+		if dec.Type > 0xff || spec == nil || spec.unmarshalJSON == nil {
+			return ErrTxTypeNotSupported
+		}
+		if inner, err = spec.unmarshalJSON(&dec); err != nil {
+			return err
+		}
 	}
 
 	// Now set the inner transaction.
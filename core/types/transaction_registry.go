@@ -0,0 +1,161 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/params/forks"
+)
+
+// TxTypeSpec describes an EIP-2718 typed transaction envelope along with the
+// behaviour attached to it throughout the codebase: decoding, signer support,
+// transaction pool validation and RPC marshalling.
+//
+// Adding a new transaction type only requires defining its TxData
+// implementation in this package and registering a spec for it via
+// registerTxType from an init function of the defining file.
+type TxTypeSpec struct {
+	Type byte   // EIP-2718 type identifier
+	Name string // Human readable name, used in error messages
+
+	// Fork is the protocol fork which introduced the type. Signers created for
+	// a specific fork (e.g. NewCancunSigner) accept every type introduced at
+	// or before that fork. Types which are not part of the Ethereum fork
+	// schedule must leave this as zero and are then only enabled by the chain
+	// config aware constructors (MakeSigner, LatestSigner) through Active and
+	// Scheduled.
+	Fork forks.Fork
+
+	// Active reports whether the type is enabled at the given block.
+	Active func(config *params.ChainConfig, num *big.Int, time uint64) bool
+
+	// Scheduled reports whether the type is enabled at any point in the
+	// given chain configuration.
+	Scheduled func(config *params.ChainConfig) bool
+
+	// DynamicFee marks types carrying EIP-1559 style fee fields, which are
+	// reported as maxFeePerGas/maxPriorityFeePerGas over RPC.
+	DynamicFee bool
+
+	// Validate optionally performs type specific stateless checks when the
+	// transaction pool admits a transaction of this type.
+	Validate func(tx *Transaction, head *Header, config *params.ChainConfig) error
+
+	newData       func() TxData                      // Allocates an empty payload for decoding
+	marshalJSON   func(tx *Transaction, enc *txJSON) // Fills the JSON fields of types not handled by MarshalJSON
+	unmarshalJSON func(dec *txJSON) (TxData, error)  // Decodes the JSON fields of types not handled by UnmarshalJSON
+}
+
+var errEmptyAuthList = errors.New("set code tx must have at least one authorization tuple")
+
+// txTypes is the registry of all known typed transactions, indexed by type. The
+// protocol types are set up in the variable initializer rather than an init
+// function, so that signers created during package initialization see them.
+var txTypes = [256]*TxTypeSpec{
+	AccessListTxType: {
+		Type:      AccessListTxType,
+		Name:      "access list",
+		Fork:      forks.Berlin,
+		Active:    func(c *params.ChainConfig, num *big.Int, _ uint64) bool { return c.IsBerlin(num) },
+		Scheduled: func(c *params.ChainConfig) bool { return c.BerlinBlock != nil },
+		newData:   func() TxData { return new(AccessListTx) },
+	},
+	DynamicFeeTxType: {
+		Type:       DynamicFeeTxType,
+		Name:       "dynamic fee",
+		Fork:       forks.London,
+		Active:     func(c *params.ChainConfig, num *big.Int, _ uint64) bool { return c.IsLondon(num) },
+		Scheduled:  func(c *params.ChainConfig) bool { return c.LondonBlock != nil },
+		DynamicFee: true,
+		newData:    func() TxData { return new(DynamicFeeTx) },
+	},
+	BlobTxType: {
+		Type:       BlobTxType,
+		Name:       "blob",
+		Fork:       forks.Cancun,
+		Active:     func(c *params.ChainConfig, num *big.Int, time uint64) bool { return c.IsCancun(num, time) },
+		Scheduled:  func(c *params.ChainConfig) bool { return c.CancunTime != nil },
+		DynamicFee: true,
+		newData:    func() TxData { return new(BlobTx) },
+	},
+	SetCodeTxType: {
+		Type:       SetCodeTxType,
+		Name:       "set code",
+		Fork:       forks.Prague,
+		Active:     func(c *params.ChainConfig, num *big.Int, time uint64) bool { return c.IsPrague(num, time) },
+		Scheduled:  func(c *params.ChainConfig) bool { return c.PragueTime != nil },
+		DynamicFee: true,
+		Validate: func(tx *Transaction, head *Header, config *params.ChainConfig) error {
+			if len(tx.SetCodeAuthorizations()) == 0 {
+				return errEmptyAuthList
+			}
+			return nil
+		},
+		newData: func() TxData { return new(SetCodeTx) },
+	},
+}
+
+// registerTxType adds a typed transaction to the registry. It panics if the
+// type is already registered or the spec is incomplete, as registration is
+// expected to happen during package initialization.
+func registerTxType(spec *TxTypeSpec) {
+	switch {
+	case spec.Type == LegacyTxType || spec.Type >= 0x80:
+		panic(fmt.Sprintf("invalid typed transaction identifier %#x", spec.Type))
+	case txTypes[spec.Type] != nil:
+		panic(fmt.Sprintf("transaction type %#x already registered as %s", spec.Type, txTypes[spec.Type].Name))
+	case spec.newData == nil:
+		panic(fmt.Sprintf("transaction type %#x has no payload constructor", spec.Type))
+	case spec.Active == nil || spec.Scheduled == nil:
+		panic(fmt.Sprintf("transaction type %#x has no activation rules", spec.Type))
+	}
+	txTypes[spec.Type] = spec
+}
+
+// LookupTxType returns the spec of the given typed transaction, or nil if the
+// type is unknown.
+func LookupTxType(txType byte) *TxTypeSpec {
+	return txTypes[txType]
+}
+
+// TxTypes returns the specs of all registered typed transactions, ordered by
+// their type identifier.
+func TxTypes() []*TxTypeSpec {
+	var specs []*TxTypeSpec
+	for _, spec := range txTypes {
+		if spec != nil {
+			specs = append(specs, spec)
+		}
+	}
+	sort.Slice(specs, func(i, j int) bool { return specs[i].Type < specs[j].Type })
+	return specs
+}
+
+// IsTxTypeActive reports whether the given transaction type is enabled at the
+// specified block according to the chain configuration.
+func IsTxTypeActive(config *params.ChainConfig, txType byte, num *big.Int, time uint64) bool {
+	if txType == LegacyTxType {
+		return true
+	}
+	spec := txTypes[txType]
+	return spec != nil && spec.Active(config, num, time)
+}
@@ -0,0 +1,93 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/params/forks"
+)
+
+// Tests that the builtin transaction types are registered and ordered.
+func TestTxTypeRegistry(t *testing.T) {
+	want := []byte{AccessListTxType, DynamicFeeTxType, BlobTxType, SetCodeTxType}
+	specs := TxTypes()
+	if len(specs) != len(want) {
+		t.Fatalf("registered type count mismatch: have %d, want %d", len(specs), len(want))
+	}
+	for i, spec := range specs {
+		if spec.Type != want[i] {
+			t.Errorf("spec %d: type mismatch: have %d, want %d", i, spec.Type, want[i])
+		}
+		if LookupTxType(spec.Type) != spec {
+			t.Errorf("spec %d: lookup mismatch", i)
+		}
+		if inner := spec.newData(); inner.txType() != spec.Type {
+			t.Errorf("spec %d: payload type mismatch: have %d, want %d", i, inner.txType(), spec.Type)
+		}
+	}
+	if LookupTxType(0x7f) != nil {
+		t.Error("unknown type found in registry")
+	}
+}
+
+// Tests that registering an already known type is rejected.
+func TestTxTypeRegistryDuplicate(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("duplicate registration did not panic")
+		}
+	}()
+	registerTxType(&TxTypeSpec{
+		Type:      DynamicFeeTxType,
+		Fork:      forks.London,
+		Active:    func(*params.ChainConfig, *big.Int, uint64) bool { return true },
+		Scheduled: func(*params.ChainConfig) bool { return true },
+		newData:   func() TxData { return new(DynamicFeeTx) },
+	})
+}
+
+// Tests that the type activation follows the chain configuration.
+func TestTxTypeActivation(t *testing.T) {
+	config := *params.MergedTestChainConfig
+	config.PragueTime = nil
+	config.OsakaTime = nil
+
+	for _, tt := range []struct {
+		txType byte
+		num    int64
+		time   uint64
+		want   bool
+	}{
+		{LegacyTxType, 0, 0, true},
+		{DynamicFeeTxType, 0, 0, true},
+		{BlobTxType, 0, 0, true},
+		{SetCodeTxType, 0, 0, false},
+		{0x7f, 0, 0, false},
+	} {
+		if have := IsTxTypeActive(&config, tt.txType, big.NewInt(tt.num), tt.time); have != tt.want {
+			t.Errorf("type %d: activation mismatch: have %v, want %v", tt.txType, have, tt.want)
+		}
+	}
+	// Signers derived from the config must agree with the registry
+	signer := MakeSigner(&config, big.NewInt(0), 0)
+	if !signer.Equal(NewCancunSigner(config.ChainID)) {
+		t.Error("signer mismatch for config without Prague")
+	}
+}
@@ -21,6 +21,9 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"runtime"
+	"sync"
+	"sync/atomic"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
@@ -56,7 +59,9 @@ func MakeSigner(config *params.ChainConfig, blockNumber *big.Int, blockTime uint
 	default:
 		signer = FrontierSigner{}
 	}
-	return signer
+	return withConfigTypes(signer, func(spec *TxTypeSpec) bool {
+		return spec.Active(config, blockNumber, blockTime)
+	})
 }
 
 // LatestSigner returns the 'most permissive' Signer available for the given chain
@@ -86,7 +91,9 @@ func LatestSigner(config *params.ChainConfig) Signer {
 	} else {
 		signer = HomesteadSigner{}
 	}
-	return signer
+	return withConfigTypes(signer, func(spec *TxTypeSpec) bool {
+		return spec.Scheduled(config)
+	})
 }
 
 // LatestSignerForChainID returns the 'most permissive' Signer available. Specifically,
@@ -156,6 +163,45 @@ func Sender(signer Signer, tx *Transaction) (common.Address, error) {
 	return addr, nil
 }
 
+// CacheSender seeds the sender cache of a transaction with an address derived
+// earlier, e.g. one persisted from a previous import. The caller is responsible
+// for the address being the one the signer would recover.
+func CacheSender(signer Signer, tx *Transaction, from common.Address) {
+	tx.from.Store(&sigCache{signer: signer, from: from})
+}
+
+// minRecoveryBatch is the smallest number of transactions worth handing to a
+// separate goroutine for sender recovery.
+const minRecoveryBatch = 8
+
+// RecoverSenders derives and caches the senders of a batch of transactions,
+// spreading the signature recoveries over up to GOMAXPROCS goroutines. It
+// returns once all senders are cached. Invalid signatures are not reported;
+// calling Sender on such a transaction returns the error.
+func RecoverSenders(signer Signer, txs []*Transaction) {
+	workers := min(runtime.GOMAXPROCS(0), len(txs)/minRecoveryBatch)
+	if workers <= 1 {
+		for _, tx := range txs {
+			Sender(signer, tx)
+		}
+		return
+	}
+	var (
+		next atomic.Int64
+		wg   sync.WaitGroup
+	)
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := next.Add(1) - 1; i < int64(len(txs)); i = next.Add(1) - 1 {
+				Sender(signer, txs[i])
+			}
+		}()
+	}
+	wg.Wait()
+}
+
 // Signer encapsulates transaction signature handling. The name of this type is slightly
 // misleading because Signers don't actually sign, they're just for validating and
 // processing of signatures.
@@ -218,18 +264,26 @@ func newModernSigner(chainID *big.Int, fork forks.Fork) Signer {
 		s.legacy = FrontierSigner{}
 	}
 	s.txtypes.set(LegacyTxType)
-	// configure tx types
-	if fork >= forks.Berlin {
-		s.txtypes.set(AccessListTxType)
-	}
-	if fork >= forks.London {
-		s.txtypes.set(DynamicFeeTxType)
+	// configure tx types introduced by the protocol forks
+	for _, spec := range txTypes {
+		if spec != nil && spec.Fork != forks.Frontier && fork >= spec.Fork {
+			s.txtypes.set(spec.Type)
+		}
 	}
-	if fork >= forks.Cancun {
-		s.txtypes.set(BlobTxType)
+	return s
+}
+
+// withConfigTypes extends the signer with the transaction types which are not
+// part of the protocol fork schedule, but are enabled by the chain config.
+func withConfigTypes(signer Signer, enabled func(spec *TxTypeSpec) bool) Signer {
+	s, ok := signer.(*modernSigner)
+	if !ok {
+		return signer
 	}
-	if fork >= forks.Prague {
-		s.txtypes.set(SetCodeTxType)
+	for _, spec := range txTypes {
+		if spec != nil && spec.Fork == forks.Frontier && enabled(spec) {
+			s.txtypes.set(spec.Type)
+		}
 	}
 	return s
 }
@@ -17,6 +17,7 @@
 package types
 
 import (
+	"crypto/ecdsa"
 	"errors"
 	"fmt"
 	"math/big"
@@ -200,3 +201,62 @@ func Benchmark_modernSigner_Equal(b *testing.B) {
 		}
 	}
 }
+
+func TestRecoverSenders(t *testing.T) {
+	var (
+		signer = LatestSignerForChainID(big.NewInt(1))
+		keys   = make([]*ecdsa.PrivateKey, 100)
+		txs    = make([]*Transaction, 100)
+	)
+	for i := range txs {
+		keys[i], _ = crypto.GenerateKey()
+		txs[i] = MustSignNewTx(keys[i], signer, &DynamicFeeTx{ChainID: big.NewInt(1), Nonce: uint64(i)})
+	}
+	RecoverSenders(signer, txs)
+	for i, tx := range txs {
+		cache := tx.from.Load()
+		if cache == nil {
+			t.Fatalf("tx %d: sender not cached", i)
+		}
+		if want := crypto.PubkeyToAddress(keys[i].PublicKey); cache.from != want {
+			t.Fatalf("tx %d: wrong sender: have %x, want %x", i, cache.from, want)
+		}
+	}
+}
+
+func BenchmarkRecoverSenders(b *testing.B) {
+	var (
+		signer = LatestSignerForChainID(big.NewInt(1))
+		key, _ = crypto.GenerateKey()
+		txs    = make([]*Transaction, 256)
+	)
+	for i := range txs {
+		txs[i] = MustSignNewTx(key, signer, &DynamicFeeTx{ChainID: big.NewInt(1), Nonce: uint64(i)})
+	}
+	// Copy the transactions each round to drop the cached senders
+	fresh := func() []*Transaction {
+		batch := make([]*Transaction, len(txs))
+		for i, tx := range txs {
+			batch[i] = NewTx(tx.inner.copy())
+		}
+		return batch
+	}
+	b.Run("sequential", func(b *testing.B) {
+		for b.Loop() {
+			b.StopTimer()
+			batch := fresh()
+			b.StartTimer()
+			for _, tx := range batch {
+				Sender(signer, tx)
+			}
+		}
+	})
+	b.Run("batch", func(b *testing.B) {
+		for b.Loop() {
+			b.StopTimer()
+			batch := fresh()
+			b.StartTimer()
+			RecoverSenders(signer, batch)
+		}
+	})
+}
@@ -199,3 +199,36 @@ func vmErrorCodeFromErr(err error) int {
 		return VMErrorCodeUnknown
 	}
 }
+
+// haltReasons maps the VM error codes to the names reported by HaltReason.
+var haltReasons = map[int]string{
+	VMErrorCodeOutOfGas:                 "OutOfGas",
+	VMErrorCodeCodeStoreOutOfGas:        "CodeStoreOutOfGas",
+	VMErrorCodeDepth:                    "CallDepthExceeded",
+	VMErrorCodeInsufficientBalance:      "InsufficientBalance",
+	VMErrorCodeContractAddressCollision: "ContractAddressCollision",
+	VMErrorCodeExecutionReverted:        "Reverted",
+	VMErrorCodeMaxCodeSizeExceeded:      "MaxCodeSizeExceeded",
+	VMErrorCodeInvalidJump:              "InvalidJump",
+	VMErrorCodeWriteProtection:          "WriteProtection",
+	VMErrorCodeReturnDataOutOfBounds:    "ReturnDataOutOfBounds",
+	VMErrorCodeGasUintOverflow:          "GasUintOverflow",
+	VMErrorCodeInvalidCode:              "InvalidCode",
+	VMErrorCodeNonceUintOverflow:        "NonceUintOverflow",
+	VMErrorCodeStackUnderflow:           "StackUnderflow",
+	VMErrorCodeStackOverflow:            "StackOverflow",
+	VMErrorCodeInvalidOpCode:            "InvalidOpcode",
+}
+
+// HaltReason returns a short and stable name for the class of an error which
+// halted execution, e.g. "OutOfGas" or "Reverted". It returns an empty string
+// for a nil error and "Unknown" for errors not originating from the VM.
+func HaltReason(err error) string {
+	if err == nil {
+		return ""
+	}
+	if reason, ok := haltReasons[vmErrorCodeFromErr(err)]; ok {
+		return reason
+	}
+	return "Unknown"
+}
@@ -18,6 +18,7 @@ package vm
 
 import (
 	"errors"
+	"maps"
 	"math/big"
 	"sync/atomic"
 
@@ -66,6 +67,10 @@ type BlockContext struct {
 	BaseFee     *big.Int       // Provides information for BASEFEE (0 if vm runs with NoBaseFee flag and 0 gas price)
 	BlobBaseFee *big.Int       // Provides information for BLOBBASEFEE (0 if vm runs with NoBaseFee flag and 0 blob gas price)
 	Random      *common.Hash   // Provides information for PREVRANDAO
+
+	// WarmSlots are the storage slots accessed by the parent block, added to the
+	// access list of each transaction under the experimental warm slots rules.
+	WarmSlots types.AccessList
 }
 
 // TxContext provides the EVM with information about a transaction.
@@ -87,7 +92,8 @@ type TxContext struct {
 // specific errors should ever be performed. The interpreter makes
 // sure that any errors generated are to be considered faulty code.
 //
-// The EVM should never be reused and is not thread safe.
+// The EVM should never be reused, other than by returning it to an EVMPool, and
+// is not thread safe.
 type EVM struct {
 	// Context provides auxiliary blockchain related information
 	Context BlockContext
@@ -122,6 +128,11 @@ type EVM struct {
 	// precompiles holds the precompiled contracts for the current epoch
 	precompiles map[common.Address]PrecompiledContract
 
+	// precompileGas holds the fixed gas costs of precompiles overriding their
+	// input dependent costs, as set by the gas schedule of the chain or by RPC
+	// overrides. The affected precompiles are wrapped in precompiles.
+	precompileGas map[common.Address]uint64
+
 	// jumpDests stores results of JUMPDEST analysis.
 	jumpDests JumpDestCache
 
@@ -138,15 +149,65 @@ type EVM struct {
 // needed by calling evm.SetTxContext.
 func NewEVM(blockCtx BlockContext, statedb StateDB, chainConfig *params.ChainConfig, config Config) *EVM {
 	evm := &EVM{
-		Context:     blockCtx,
-		StateDB:     statedb,
-		Config:      config,
-		chainConfig: chainConfig,
-		chainRules:  chainConfig.Rules(blockCtx.BlockNumber, blockCtx.Random != nil, blockCtx.Time),
-		jumpDests:   newMapJumpDests(),
-		hasher:      crypto.NewKeccakState(),
+		jumpDests: newMapJumpDests(),
+		hasher:    crypto.NewKeccakState(),
+	}
+	return evm.init(blockCtx, statedb, chainConfig, config)
+}
+
+// maxPooledJumpDests is the number of jump destination analyses an EVM keeps
+// when returned to a pool. Analyses are keyed by code hash and thus remain
+// valid across blocks, but the cache must not grow without bounds.
+const maxPooledJumpDests = 4096
+
+// EVMPool holds EVM instances for reuse by a single worker, such as the
+// goroutine building a payload or a slot of the prefetcher. Besides the EVM
+// itself, the keccak hasher and the jump destination analyses are retained.
+//
+// The pool is not thread safe, every worker is meant to own its pool. A nil
+// pool is valid and constructs fresh EVMs.
+type EVMPool struct {
+	free []*EVM
+}
+
+// Get is like NewEVM, but reuses an EVM instance previously returned to the
+// pool with Put.
+func (p *EVMPool) Get(blockCtx BlockContext, statedb StateDB, chainConfig *params.ChainConfig, config Config) *EVM {
+	if p == nil || len(p.free) == 0 {
+		return NewEVM(blockCtx, statedb, chainConfig, config)
 	}
+	evm := p.free[len(p.free)-1]
+	p.free = p.free[:len(p.free)-1]
+	return evm.init(blockCtx, statedb, chainConfig, config)
+}
+
+// Put resets the EVM and returns it to the pool. The EVM must not be used
+// afterwards.
+func (p *EVMPool) Put(evm *EVM) {
+	if p == nil {
+		return
+	}
+	jumpDests, ok := evm.jumpDests.(mapJumpDests)
+	if !ok || len(jumpDests) > maxPooledJumpDests {
+		jumpDests = make(mapJumpDests)
+	}
+	*evm = EVM{
+		jumpDests: jumpDests,
+		hasher:    evm.hasher,
+	}
+	p.free = append(p.free, evm)
+}
+
+// init sets up the EVM for the given block, reusing the hasher and the jump
+// destination cache already present.
+func (evm *EVM) init(blockCtx BlockContext, statedb StateDB, chainConfig *params.ChainConfig, config Config) *EVM {
+	evm.Context = blockCtx
+	evm.StateDB = statedb
+	evm.Config = config
+	evm.chainConfig = chainConfig
+	evm.chainRules = chainConfig.Rules(blockCtx.BlockNumber, blockCtx.Random != nil, blockCtx.Time)
 	evm.precompiles = activePrecompiledContracts(evm.chainRules)
+	evm.precompileGas = nil
 
 	switch {
 	case evm.chainRules.IsOsaka:
@@ -181,8 +242,11 @@ func NewEVM(blockCtx BlockContext, statedb StateDB, chainConfig *params.ChainCon
 	default:
 		evm.table = &frontierInstructionSet
 	}
-	var extraEips []int
-	if len(evm.Config.ExtraEips) > 0 {
+	var (
+		extraEips []int
+		private   = len(evm.Config.ExtraEips) > 0
+	)
+	if private {
 		// Deep-copy jumptable to prevent modification of opcodes in other tables
 		evm.table = copyJumpTable(evm.table)
 	}
@@ -195,6 +259,13 @@ func NewEVM(blockCtx BlockContext, statedb StateDB, chainConfig *params.ChainCon
 		}
 	}
 	evm.Config.ExtraEips = extraEips
+
+	// Apply the gas cost overrides of custom chains
+	if schedule := chainConfig.ActiveGasSchedule(evm.chainRules); schedule != nil {
+		evm.table = scheduledJumpTable(evm.table, schedule, private)
+		evm.precompileGas = schedule.Precompiles
+		evm.pricePrecompiles()
+	}
 	return evm
 }
 
@@ -203,6 +274,60 @@ func NewEVM(blockCtx BlockContext, statedb StateDB, chainConfig *params.ChainCon
 // It is not thread-safe.
 func (evm *EVM) SetPrecompiles(precompiles PrecompiledContracts) {
 	evm.precompiles = precompiles
+	evm.pricePrecompiles()
+}
+
+// SetConstantGas overrides the constant gas costs of the given opcodes. The
+// dynamic part of the costs, e.g. memory expansion or state access, is not
+// affected.
+// This method is only used through RPC calls.
+// It is not thread-safe.
+func (evm *EVM) SetConstantGas(costs map[OpCode]uint64) {
+	if len(costs) == 0 {
+		return
+	}
+	// Deep-copy jumptable to prevent modification of opcodes in other tables
+	evm.table = copyJumpTable(evm.table)
+	for op, gas := range costs {
+		evm.table[op].constantGas = gas
+	}
+}
+
+// SetPrecompileGas overrides the gas costs of the given precompiles, replacing
+// their input dependent costs.
+// This method is only used through RPC calls.
+// It is not thread-safe.
+func (evm *EVM) SetPrecompileGas(costs map[common.Address]uint64) {
+	if len(costs) == 0 {
+		return
+	}
+	// Copy the costs to prevent modification of the chain's gas schedule
+	merged := maps.Clone(evm.precompileGas)
+	if merged == nil {
+		merged = make(map[common.Address]uint64, len(costs))
+	}
+	maps.Copy(merged, costs)
+	evm.precompileGas = merged
+	evm.pricePrecompiles()
+}
+
+// pricePrecompiles wraps the precompiles whose gas costs are overridden, once
+// when the costs or the precompiles change rather than on every call.
+func (evm *EVM) pricePrecompiles() {
+	if len(evm.precompileGas) == 0 {
+		return
+	}
+	priced := make(PrecompiledContracts, len(evm.precompiles))
+	for addr, p := range evm.precompiles {
+		if wrapped, ok := p.(*pricedPrecompile); ok {
+			p = wrapped.PrecompiledContract
+		}
+		if gas, ok := evm.precompileGas[addr]; ok {
+			p = &pricedPrecompile{PrecompiledContract: p, gas: gas}
+		}
+		priced[addr] = p
+	}
+	evm.precompiles = priced
 }
 
 // SetJumpDestCache configures the analysis cache.
@@ -0,0 +1,111 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package vm
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// gasScheduleKey identifies a jump table with the constant gas costs of a gas
+// schedule applied.
+type gasScheduleKey struct {
+	schedule *params.GasSchedule
+	table    *JumpTable
+}
+
+// gasScheduleTables caches the jump tables of the gas schedules, so that custom
+// chains don't need to copy the jump table for every EVM.
+var gasScheduleTables sync.Map // gasScheduleKey -> *JumpTable
+
+// scheduledJumpTable returns the jump table with the constant gas costs of the
+// schedule applied. If the table is a private copy already, it's modified in
+// place, otherwise a cached copy is returned.
+func scheduledJumpTable(table *JumpTable, schedule *params.GasSchedule, private bool) *JumpTable {
+	if len(schedule.Opcodes) == 0 {
+		return table
+	}
+	if private {
+		setScheduledGas(table, schedule)
+		return table
+	}
+	key := gasScheduleKey{schedule: schedule, table: table}
+	if cached, ok := gasScheduleTables.Load(key); ok {
+		return cached.(*JumpTable)
+	}
+	scheduled := copyJumpTable(table)
+	setScheduledGas(scheduled, schedule)
+	gasScheduleTables.Store(key, scheduled)
+	return scheduled
+}
+
+func setScheduledGas(table *JumpTable, schedule *params.GasSchedule) {
+	for name, gas := range schedule.Opcodes {
+		if op := StringToOp(name); op.String() == name {
+			table[op].constantGas = gas
+		}
+	}
+}
+
+// pricedPrecompile is a precompiled contract with an overridden, fixed gas cost.
+type pricedPrecompile struct {
+	PrecompiledContract
+	gas uint64
+}
+
+func (p *pricedPrecompile) RequiredGas(input []byte) uint64 {
+	return p.gas
+}
+
+// ValidateGasSchedule checks that the gas schedules of the chain config only
+// override opcodes and precompiles existing in their forks.
+func ValidateGasSchedule(config *params.ChainConfig) error {
+	gs := config.GasSchedule
+	if gs == nil {
+		return nil
+	}
+	for _, cur := range []struct {
+		name        string
+		schedule    *params.GasSchedule
+		table       *JumpTable
+		precompiles PrecompiledContracts
+	}{
+		{"london", gs.London, &londonInstructionSet, PrecompiledContractsBerlin},
+		{"shanghai", gs.Shanghai, &shanghaiInstructionSet, PrecompiledContractsBerlin},
+		{"cancun", gs.Cancun, &cancunInstructionSet, PrecompiledContractsCancun},
+		{"prague", gs.Prague, &pragueInstructionSet, PrecompiledContractsPrague},
+		{"osaka", gs.Osaka, &osakaInstructionSet, PrecompiledContractsOsaka},
+	} {
+		if cur.schedule == nil {
+			continue
+		}
+		for name := range cur.schedule.Opcodes {
+			op := StringToOp(name)
+			if op.String() != name || cur.table[op].undefined {
+				return fmt.Errorf("invalid gas schedule for fork %q: unknown opcode %q", cur.name, name)
+			}
+		}
+		for addr := range cur.schedule.Precompiles {
+			if _, ok := cur.precompiles[addr]; !ok {
+				return fmt.Errorf("invalid gas schedule for fork %q: account %s is not a precompile", cur.name, addr.Hex())
+			}
+		}
+	}
+	return nil
+}
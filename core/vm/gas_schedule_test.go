@@ -0,0 +1,70 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package vm
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func TestGasSchedule(t *testing.T) {
+	var (
+		config = *params.MergedTestChainConfig
+		ecrec  = common.BytesToAddress([]byte{0x01})
+	)
+	config.GasSchedule = &params.GasScheduleConfig{
+		Cancun: &params.GasSchedule{
+			Opcodes:     map[string]uint64{"SLOAD": 100},
+			Precompiles: map[common.Address]uint64{ecrec: 5},
+		},
+	}
+	if err := ValidateGasSchedule(&config); err != nil {
+		t.Fatalf("valid gas schedule rejected: %v", err)
+	}
+	// Osaka has no schedule of its own and inherits the one of Cancun.
+	vmctx := BlockContext{BlockNumber: big.NewInt(0), Random: &common.Hash{}}
+	evm := NewEVM(vmctx, nil, &config, Config{})
+	if gas := evm.table[SLOAD].constantGas; gas != 100 {
+		t.Errorf("wrong SLOAD gas: have %d, want %d", gas, 100)
+	}
+	if gas := osakaInstructionSet[SLOAD].constantGas; gas != 0 {
+		t.Errorf("shared jump table modified: SLOAD gas %d", gas)
+	}
+	p, ok := evm.precompile(ecrec)
+	if !ok {
+		t.Fatal("ecrecover precompile missing")
+	}
+	if gas := p.RequiredGas(nil); gas != 5 {
+		t.Errorf("wrong ecrecover gas: have %d, want %d", gas, 5)
+	}
+	if again, _ := evm.precompile(ecrec); again != p {
+		t.Error("priced precompile not cached")
+	}
+	// Schedules with unknown entries are rejected.
+	config.GasSchedule.Cancun.Opcodes["CLZ"] = 1
+	if err := ValidateGasSchedule(&config); err == nil {
+		t.Error("opcode undefined in Cancun accepted")
+	}
+	delete(config.GasSchedule.Cancun.Opcodes, "CLZ")
+	config.GasSchedule.Cancun.Precompiles[common.BytesToAddress([]byte{0x11})] = 1
+	if err := ValidateGasSchedule(&config); err == nil {
+		t.Error("Prague precompile accepted in Cancun")
+	}
+}
@@ -34,6 +34,8 @@ type Config struct {
 
 	StatelessSelfValidation bool // Generate execution witnesses and self-check against them (testing purpose)
 	EnableWitnessStats      bool // Whether trie access statistics collection is enabled
+	RecordHaltReasons       bool // Records why failed transactions halted in their receipts
+	RecordRevertReasons     bool // Records the decoded revert reasons of reverted transactions in their receipts
 }
 
 // ScopeContext contains the things that are per-call, such as stack and memory,
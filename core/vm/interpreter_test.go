@@ -94,3 +94,38 @@ func BenchmarkInterpreter(b *testing.B) {
 		gasSStoreEIP3529(evm, contract, stack, mem, 1234)
 	}
 }
+
+// Tests that an EVM returned to a pool is reset, even if it was aborted or left
+// in a nested state, and that the instances handed out are fully initialised.
+func TestEVMPoolReuse(t *testing.T) {
+	var (
+		statedb, _ = state.New(types.EmptyRootHash, state.NewDatabaseForTesting())
+		vmctx      = BlockContext{BlockNumber: big.NewInt(1)}
+		pool       = new(EVMPool)
+	)
+	evm := pool.Get(vmctx, statedb, params.AllEthashProtocolChanges, Config{})
+	evm.Cancel()
+	evm.depth, evm.readOnly, evm.returnData = 3, true, []byte{1}
+	evm.jumpDests.Store(common.Hash{1}, BitVec{1})
+	hasher := evm.hasher
+
+	pool.Put(evm)
+	if evm.Cancelled() || evm.depth != 0 || evm.readOnly || evm.returnData != nil {
+		t.Fatal("execution state not reset")
+	}
+	if evm.StateDB != nil || evm.chainConfig != nil || evm.table != nil || evm.precompiles != nil {
+		t.Fatal("block state not reset")
+	}
+	if evm.hasher != hasher {
+		t.Fatal("hasher not retained")
+	}
+	if _, ok := evm.jumpDests.Load(common.Hash{1}); !ok {
+		t.Fatal("jump destination analysis not retained")
+	}
+	for i := 0; i < 2; i++ {
+		evm = pool.Get(vmctx, statedb, params.AllEthashProtocolChanges, Config{})
+		if evm.StateDB != statedb || evm.table == nil || evm.precompiles == nil || evm.hasher == nil || evm.jumpDests == nil {
+			t.Fatalf("EVM %d not initialised", i)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/state"
+)
+
+// storageWatchSet is the reference counted set of storage slots whose changes
+// are tracked during block import.
+type storageWatchSet struct {
+	slots map[common.Address]map[common.Hash]int
+	lock  sync.RWMutex
+}
+
+func newStorageWatchSet() *storageWatchSet {
+	return &storageWatchSet{slots: make(map[common.Address]map[common.Hash]int)}
+}
+
+// add starts tracking the given slot.
+func (w *storageWatchSet) add(addr common.Address, slot common.Hash) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	if w.slots[addr] == nil {
+		w.slots[addr] = make(map[common.Hash]int)
+	}
+	w.slots[addr][slot]++
+}
+
+// remove stops tracking the given slot once all its watchers are gone.
+func (w *storageWatchSet) remove(addr common.Address, slot common.Hash) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	slots := w.slots[addr]
+	if slots == nil || slots[slot] == 0 {
+		return
+	}
+	if slots[slot]--; slots[slot] == 0 {
+		delete(slots, slot)
+	}
+	if len(slots) == 0 {
+		delete(w.slots, addr)
+	}
+}
+
+// changes returns the modifications of the watched slots made by the block
+// whose post-state is given.
+func (w *storageWatchSet) changes(statedb *state.StateDB) []state.StorageChange {
+	w.lock.RLock()
+	defer w.lock.RUnlock()
+
+	if len(w.slots) == 0 {
+		return nil
+	}
+	watched := make(map[common.Address][]common.Hash, len(w.slots))
+	for addr, slots := range w.slots {
+		for slot := range slots {
+			watched[addr] = append(watched[addr], slot)
+		}
+	}
+	return statedb.StorageChanges(watched)
+}
+
+// WatchStorage starts tracking the changes of the given storage slot made by
+// imported canonical blocks. They are delivered as StorageWatchEvents. Each call
+// must be paired with an UnwatchStorage once the slot is no longer of interest.
+func (bc *BlockChain) WatchStorage(addr common.Address, slot common.Hash) {
+	bc.watchpoints.add(addr, slot)
+}
+
+// UnwatchStorage releases a storage slot previously registered by WatchStorage.
+func (bc *BlockChain) UnwatchStorage(addr common.Address, slot common.Hash) {
+	bc.watchpoints.remove(addr, slot)
+}
@@ -18,26 +18,32 @@ package eth
 
 import (
 	"compress/gzip"
+	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"os"
 	"strings"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/p2p/msgrate"
 	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/rpc"
 )
 
 // AdminAPI is the collection of Ethereum full node related APIs for node
 // administration.
 type AdminAPI struct {
-	eth *Ethereum
+	eth      *Ethereum
+	settings *settingsRegistry
 }
 
 // NewAdminAPI creates a new instance of AdminAPI.
 func NewAdminAPI(eth *Ethereum) *AdminAPI {
-	return &AdminAPI{eth: eth}
+	return &AdminAPI{eth: eth, settings: eth.settings}
 }
 
 // ExportChain exports the current blockchain into a local file,
@@ -141,3 +147,130 @@ func (api *AdminAPI) ImportChain(file string) (bool, error) {
 	}
 	return true, nil
 }
+
+// txGossipStatus reports which directions of transaction gossip are enabled.
+type txGossipStatus struct {
+	Ingress bool `json:"ingress"`
+	Egress  bool `json:"egress"`
+}
+
+// SetTxGossip enables or disables accepting transactions gossiped by peers
+// (ingress) and gossiping transactions to peers (egress).
+func (api *AdminAPI) SetTxGossip(ingress bool, egress bool) txGossipStatus {
+	api.eth.handler.SetTxGossip(ingress, egress)
+	return api.TxGossip()
+}
+
+// TxGossip reports which directions of transaction gossip are enabled.
+func (api *AdminAPI) TxGossip() txGossipStatus {
+	ingress, egress := api.eth.handler.TxGossip()
+	return txGossipStatus{Ingress: ingress, Egress: egress}
+}
+
+// ExportTxPool writes the pending and queued transactions of the pool into a
+// local file, compressed if its name ends in ".gz". It returns the number of
+// exported transactions.
+func (api *AdminAPI) ExportTxPool(file string) (int, error) {
+	if _, err := os.Stat(file); err == nil {
+		// File already exists. Allowing overwrite could be a DoS vector,
+		// since the 'file' may point to arbitrary paths on the drive.
+		return 0, errors.New("location would overwrite an existing file")
+	}
+	return api.eth.txPool.ExportFile(file)
+}
+
+// txPoolImport is the result of an import of transactions into the pool.
+type txPoolImport struct {
+	Added   int `json:"added"`
+	Dropped int `json:"dropped"`
+}
+
+// ImportTxPool adds the transactions of a file written by ExportTxPool to the
+// pool, e.g. to warm up a standby node on failover.
+func (api *AdminAPI) ImportTxPool(file string) (*txPoolImport, error) {
+	added, dropped, err := api.eth.txPool.ImportFile(file)
+	if err != nil {
+		return nil, err
+	}
+	return &txPoolImport{Added: added, Dropped: dropped}, nil
+}
+
+// AllowDeepReorg permits the next reorg to the given head to drop more blocks
+// than the configured maximum reorg depth.
+func (api *AdminAPI) AllowDeepReorg(head common.Hash) bool {
+	api.eth.blockchain.AllowDeepReorg(head)
+	return true
+}
+
+// PeerStats returns the quality of the data served by the connected peers, as
+// used by the sync and the transaction fetcher to prioritize them.
+func (api *AdminAPI) PeerStats() map[string]msgrate.PeerStats {
+	return api.eth.handler.peerScores.All()
+}
+
+// errNoLocals is returned by the local account management methods if local
+// transaction handling is disabled.
+var errNoLocals = errors.New("local transaction handling disabled")
+
+// Locals returns the accounts whose transactions are treated as local.
+func (api *AdminAPI) Locals() ([]common.Address, error) {
+	if api.eth.localAccounts == nil {
+		return nil, errNoLocals
+	}
+	return api.eth.localAccounts.List(), nil
+}
+
+// AddLocal marks the account as local, giving its transactions priority
+// inclusion. The change is persisted across restarts.
+func (api *AdminAPI) AddLocal(addr common.Address) (bool, error) {
+	if api.eth.localAccounts == nil {
+		return false, errNoLocals
+	}
+	added, err := api.eth.localAccounts.Add(addr)
+	if added {
+		api.eth.miner.SetPrioAddresses(api.eth.localAccounts.List())
+	}
+	return added, err
+}
+
+// RemoveLocal unmarks an account added with AddLocal as local.
+func (api *AdminAPI) RemoveLocal(addr common.Address) (bool, error) {
+	if api.eth.localAccounts == nil {
+		return false, errNoLocals
+	}
+	removed, err := api.eth.localAccounts.Remove(addr)
+	if removed {
+		api.eth.miner.SetPrioAddresses(api.eth.localAccounts.List())
+	}
+	return removed, err
+}
+
+// GetConfig returns the current value of the named runtime setting, or of all
+// runtime settings if no name is given.
+func (api *AdminAPI) GetConfig(name *string) ([]*RuntimeSetting, error) {
+	names := api.settings.names()
+	if name != nil {
+		names = []string{*name}
+	}
+	settings := make([]*RuntimeSetting, 0, len(names))
+	for _, name := range names {
+		s, err := api.settings.get(name)
+		if err != nil {
+			return nil, err
+		}
+		settings = append(settings, s)
+	}
+	return settings, nil
+}
+
+// SetConfig changes the value of a runtime setting without restarting the node.
+// The value is validated before being applied, and every change is logged along
+// with the origin of the request.
+func (api *AdminAPI) SetConfig(ctx context.Context, name string, value json.RawMessage) (*RuntimeSetting, error) {
+	info := rpc.PeerInfoFromContext(ctx)
+	origin := info.Transport
+	if info.RemoteAddr != "" {
+		origin += "://" + info.RemoteAddr
+	}
+	return api.settings.set(name, value, origin)
+}
@@ -0,0 +1,139 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// maxBlobsRequest is the maximum number of blobs retrievable in one request.
+const maxBlobsRequest = 128
+
+// BlobAPI serves the blobs of recently included transactions, as retained by
+// the blob pool.
+type BlobAPI struct {
+	eth *Ethereum
+}
+
+// NewBlobAPI creates a new BlobAPI instance.
+func NewBlobAPI(eth *Ethereum) *BlobAPI {
+	return &BlobAPI{eth: eth}
+}
+
+// BlobSidecar is the sidecar of a blob transaction included in a block.
+type BlobSidecar struct {
+	TransactionHash  common.Hash     `json:"transactionHash"`
+	TransactionIndex hexutil.Uint64  `json:"transactionIndex"`
+	Version          hexutil.Uint    `json:"version"`
+	BlobHashes       []common.Hash   `json:"blobVersionedHashes"`
+	Blobs            []hexutil.Bytes `json:"blobs"`
+	Commitments      []hexutil.Bytes `json:"commitments"`
+	Proofs           []hexutil.Bytes `json:"proofs"` // Blob proofs in version 0, cell proofs in version 1
+}
+
+// BlobAndProofs is a blob along with its proofs.
+type BlobAndProofs struct {
+	Blob   hexutil.Bytes   `json:"blob"`
+	Proofs []hexutil.Bytes `json:"proofs"` // Blob proof in version 0, cell proofs in version 1
+}
+
+// GetBlobSidecars returns the sidecars of the blob transactions of a block.
+// Only the sidecars retained by the node are returned, which excludes the ones
+// of transactions not seen before their inclusion.
+func (api *BlobAPI) GetBlobSidecars(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) ([]*BlobSidecar, error) {
+	block, err := api.eth.APIBackend.BlockByNumberOrHash(ctx, blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+	if block == nil {
+		return nil, errors.New("block not found")
+	}
+	sidecars := make([]*BlobSidecar, 0)
+	for i, tx := range block.Transactions() {
+		if tx.Type() != types.BlobTxType {
+			continue
+		}
+		included := api.eth.blobTxPool.IncludedTx(tx.Hash())
+		if included == nil || included.BlobTxSidecar() == nil {
+			continue
+		}
+		sc := included.BlobTxSidecar()
+		sidecar := &BlobSidecar{
+			TransactionHash:  tx.Hash(),
+			TransactionIndex: hexutil.Uint64(i),
+			Version:          hexutil.Uint(sc.Version),
+			BlobHashes:       tx.BlobHashes(),
+		}
+		for j := range sc.Blobs {
+			sidecar.Blobs = append(sidecar.Blobs, sc.Blobs[j][:])
+		}
+		for j := range sc.Commitments {
+			sidecar.Commitments = append(sidecar.Commitments, sc.Commitments[j][:])
+		}
+		for j := range sc.Proofs {
+			sidecar.Proofs = append(sidecar.Proofs, sc.Proofs[j][:])
+		}
+		sidecars = append(sidecars, sidecar)
+	}
+	return sidecars, nil
+}
+
+// GetBlobs returns the blobs with the given versioned hashes among the ones of
+// the recently included transactions, in the order requested. Missing blobs are
+// returned as null.
+func (api *BlobAPI) GetBlobs(vhashes []common.Hash) ([]*BlobAndProofs, error) {
+	if len(vhashes) > maxBlobsRequest {
+		return nil, fmt.Errorf("requested blob count too large: %d > %d", len(vhashes), maxBlobsRequest)
+	}
+	res := make([]*BlobAndProofs, len(vhashes))
+	for i, vhash := range vhashes {
+		tx := api.eth.blobTxPool.IncludedBlobTx(vhash)
+		if tx == nil || tx.BlobTxSidecar() == nil {
+			continue
+		}
+		sc := tx.BlobTxSidecar()
+		for j, hash := range tx.BlobHashes() {
+			if hash != vhash {
+				continue
+			}
+			var proofs []kzg4844.Proof
+			if sc.Version == types.BlobSidecarVersion0 {
+				proofs = []kzg4844.Proof{sc.Proofs[j]}
+			} else {
+				var err error
+				if proofs, err = sc.CellProofsAt(j); err != nil {
+					return nil, err
+				}
+			}
+			blob := &BlobAndProofs{Blob: sc.Blobs[j][:]}
+			for _, proof := range proofs {
+				blob.Proofs = append(blob.Proofs, proof[:])
+			}
+			res[i] = blob
+			break
+		}
+	}
+	return res, nil
+}
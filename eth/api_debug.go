@@ -20,10 +20,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"time"
 
+	"github.com/ethereum/go-ethereum/beacon/engine"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/state"
 	"github.com/ethereum/go-ethereum/core/stateless"
@@ -31,6 +34,7 @@ import (
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/internal/ethapi"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/miner"
 	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/ethereum/go-ethereum/trie"
@@ -47,8 +51,9 @@ func NewDebugAPI(eth *Ethereum) *DebugAPI {
 	return &DebugAPI{eth: eth}
 }
 
-// DumpBlock retrieves the entire state of the database at a given block.
-func (api *DebugAPI) DumpBlock(blockNr rpc.BlockNumber) (state.Dump, error) {
+// DumpBlock retrieves the entire state of the database at a given block. The dump is
+// streamed to the client while iterating the state.
+func (api *DebugAPI) DumpBlock(blockNr rpc.BlockNumber) (rpc.StreamedResult, error) {
 	opts := &state.DumpConfig{
 		OnlyWithAddresses: true,
 		Max:               AccountRangeMaxResults, // Sanity limit over RPC
@@ -59,9 +64,9 @@ func (api *DebugAPI) DumpBlock(blockNr rpc.BlockNumber) (state.Dump, error) {
 		// the miner and operate on those
 		_, _, stateDb := api.eth.miner.Pending()
 		if stateDb == nil {
-			return state.Dump{}, errors.New("pending state is not available")
+			return nil, errors.New("pending state is not available")
 		}
-		return stateDb.RawDump(opts), nil
+		return streamDump(stateDb, opts), nil
 	}
 	var header *types.Header
 	switch blockNr {
@@ -74,18 +79,25 @@ func (api *DebugAPI) DumpBlock(blockNr rpc.BlockNumber) (state.Dump, error) {
 	default:
 		block := api.eth.blockchain.GetBlockByNumber(uint64(blockNr))
 		if block == nil {
-			return state.Dump{}, fmt.Errorf("block #%d not found", blockNr)
+			return nil, fmt.Errorf("block #%d not found", blockNr)
 		}
 		header = block.Header()
 	}
 	if header == nil {
-		return state.Dump{}, fmt.Errorf("block #%d not found", blockNr)
+		return nil, fmt.Errorf("block #%d not found", blockNr)
 	}
 	stateDb, err := api.eth.BlockChain().StateAt(header.Root)
 	if err != nil {
-		return state.Dump{}, err
+		return nil, err
+	}
+	return streamDump(stateDb, opts), nil
+}
+
+// streamDump returns a streamed RPC result dumping the given state.
+func streamDump(stateDb *state.StateDB, opts *state.DumpConfig) rpc.StreamedResult {
+	return func(w io.Writer) error {
+		return stateDb.StreamDump(opts, w)
 	}
-	return stateDb.RawDump(opts), nil
 }
 
 // Preimage is a debug API function that returns the preimage for a sha3 hash, if known.
@@ -130,6 +142,72 @@ func (api *DebugAPI) GetBadBlocks(ctx context.Context) ([]*BadBlockArgs, error)
 	return results, nil
 }
 
+// StateStats returns the account and storage read statistics gathered while
+// importing the given block, defaulting to the chain head. Statistics are only
+// retained for the recently imported blocks.
+func (api *DebugAPI) StateStats(ctx context.Context, blockNrOrHash *rpc.BlockNumberOrHash) (*core.StateReadStats, error) {
+	target := rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+	if blockNrOrHash != nil {
+		target = *blockNrOrHash
+	}
+	header, err := api.eth.APIBackend.HeaderByNumberOrHash(ctx, target)
+	if err != nil {
+		return nil, err
+	}
+	if header == nil {
+		return nil, errors.New("block not found")
+	}
+	stats := api.eth.blockchain.StateReadStats(header.Hash())
+	if stats == nil {
+		return nil, fmt.Errorf("no state statistics for block %d, it was not recently imported", header.Number.Uint64())
+	}
+	return stats, nil
+}
+
+// GetStateDiff returns the accounts, storage slots and contract codes changed
+// by the given block, along with their values before and after it. The block is
+// re-executed on top of the state of its parent, which must be available or
+// regenerable.
+func (api *DebugAPI) GetStateDiff(ctx context.Context, hash common.Hash) (*BlockStateDiff, error) {
+	block := api.eth.blockchain.GetBlockByHash(hash)
+	if block == nil {
+		return nil, fmt.Errorf("block %#x not found", hash)
+	}
+	return api.eth.StateDiff(ctx, block)
+}
+
+// VerifyRange re-executes the canonical blocks from start to end (inclusive) and
+// cross-checks the computed state roots, receipts roots and blob gas accounting
+// against the headers and the stored receipts. Every block is additionally
+// executed statelessly from a witness of its execution. The results are
+// streamed as the blocks are verified, reporting the mismatches of each block.
+func (api *DebugAPI) VerifyRange(start, end uint64) (rpc.StreamedResult, error) {
+	if start == 0 {
+		return nil, errors.New("genesis is not executed")
+	}
+	if end < start {
+		return nil, fmt.Errorf("end block (#%d) needs to come after start block (#%d)", end, start)
+	}
+	if head := api.eth.blockchain.CurrentBlock().Number.Uint64(); end > head {
+		return nil, fmt.Errorf("end block #%d is beyond the current head #%d", end, head)
+	}
+	return api.eth.verifyRange(start, end), nil
+}
+
+// ReplayPayloadBuild re-executes the delivered build of a recently built payload
+// with its recorded attributes and transaction pool snapshot. It returns the
+// recorded decision for every transaction considered, the decisions of the
+// replay and whether the replay produced the same block.
+func (api *DebugAPI) ReplayPayloadBuild(id engine.PayloadID) (*miner.BuildReplay, error) {
+	return api.eth.Miner().ReplayPayloadBuild(id)
+}
+
+// IndexerStatus returns the progress of the chain indexes, such as the transaction
+// and the log index, by name.
+func (api *DebugAPI) IndexerStatus() map[string]core.IndexStatus {
+	return api.eth.blockchain.IndexStatus()
+}
+
 // AccountRangeMaxResults is the maximum number of results to be returned per call
 const AccountRangeMaxResults = 256
 
@@ -445,6 +523,16 @@ func (api *DebugAPI) GetTrieFlushInterval() (string, error) {
 	return api.eth.blockchain.GetTrieFlushInterval().String(), nil
 }
 
+// PurgeContractStorage deletes the storage left behind in the database by a contract
+// which no longer has storage in the current state, such as a self-destructed one.
+// The method is only available if dangerous debug operations are enabled.
+func (api *DebugAPI) PurgeContractStorage(address common.Address) error {
+	if !api.eth.config.DebugDangerousOps {
+		return errors.New("dangerous debug operations are disabled")
+	}
+	return api.eth.blockchain.PurgeStorage(address)
+}
+
 // StateSize returns the current state size statistics from the state size tracker.
 // Returns an error if the state size tracker is not initialized or if stats are not ready.
 func (api *DebugAPI) StateSize(blockHashOrNumber *rpc.BlockNumberOrHash) (interface{}, error) {
@@ -18,7 +18,9 @@ package eth
 
 import (
 	"bytes"
+	"context"
 	"crypto/ecdsa"
+	"encoding/json"
 	"fmt"
 	"math/big"
 	"reflect"
@@ -335,3 +337,106 @@ func TestGetModifiedAccounts(t *testing.T) {
 		}
 	})
 }
+
+func TestGetStateDiff(t *testing.T) {
+	t.Parallel()
+
+	accounts := newAccounts(2)
+	genesis := &core.Genesis{
+		Config: params.TestChainConfig,
+		Alloc: types.GenesisAlloc{
+			accounts[0].addr: {Balance: big.NewInt(params.Ether)},
+		},
+	}
+	// Deploy a contract storing 42 in slot 0 and returning the code 0x00, and
+	// transfer some funds to a new account.
+	initcode := common.FromHex("602a60005560016000f3")
+	signer := types.HomesteadSigner{}
+	blockChain := newTestBlockChain(t, 1, genesis, func(_ int, b *core.BlockGen) {
+		tx, _ := types.SignTx(types.NewContractCreation(0, nil, 100000, b.BaseFee(), initcode), signer, accounts[0].key)
+		b.AddTx(tx)
+		tx, _ = types.SignTx(types.NewTransaction(1, accounts[1].addr, big.NewInt(1000), params.TxGas, b.BaseFee(), nil), signer, accounts[0].key)
+		b.AddTx(tx)
+	})
+	defer blockChain.Stop()
+
+	api := NewDebugAPI(&Ethereum{blockchain: blockChain})
+	diff, err := api.GetStateDiff(context.Background(), blockChain.CurrentBlock().Hash())
+	if err != nil {
+		t.Fatal(err)
+	}
+	contract := crypto.CreateAddress(accounts[0].addr, 0)
+	diffs := make(map[common.Address]state.AccountDiff)
+	for _, account := range diff.Accounts {
+		diffs[account.Address] = account
+	}
+	if len(diffs) != 4 { // sender, recipient, contract and coinbase
+		t.Fatalf("have %d changed accounts, want 4", len(diffs))
+	}
+	if d := diffs[accounts[0].addr]; d.Prev == nil || d.Post == nil || d.Prev.Nonce != 0 || d.Post.Nonce != 2 {
+		t.Errorf("wrong sender diff: %v", dumper.Sdump(d))
+	}
+	if d := diffs[accounts[1].addr]; d.Prev != nil || d.Post == nil || (*uint256.Int)(d.Post.Balance).Uint64() != 1000 {
+		t.Errorf("wrong recipient diff: %v", dumper.Sdump(d))
+	}
+	d := diffs[contract]
+	if d.Prev != nil || d.Post == nil {
+		t.Fatalf("wrong contract diff: %v", dumper.Sdump(d))
+	}
+	want := []state.SlotDiff{{Key: common.Hash{}, Post: common.BigToHash(big.NewInt(42))}}
+	if !reflect.DeepEqual(d.Storage, want) {
+		t.Errorf("wrong contract storage diff: %v", dumper.Sdump(d.Storage))
+	}
+	if code := diff.Codes[d.Post.CodeHash]; !bytes.Equal(code, []byte{0x00}) {
+		t.Errorf("wrong deployed code %x", code)
+	}
+}
+
+func TestVerifyRange(t *testing.T) {
+	t.Parallel()
+
+	accounts := newAccounts(2)
+	genesis := &core.Genesis{
+		Config: params.TestChainConfig,
+		Alloc: types.GenesisAlloc{
+			accounts[0].addr: {Balance: big.NewInt(params.Ether)},
+		},
+	}
+	signer := types.HomesteadSigner{}
+	blockChain := newTestBlockChain(t, 3, genesis, func(i int, b *core.BlockGen) {
+		tx, _ := types.SignTx(types.NewTransaction(uint64(i), accounts[1].addr, big.NewInt(1000), params.TxGas, b.BaseFee(), nil), signer, accounts[0].key)
+		b.AddTx(tx)
+	})
+	defer blockChain.Stop()
+
+	api := NewDebugAPI(&Ethereum{blockchain: blockChain})
+	if _, err := api.VerifyRange(0, 1); err == nil {
+		t.Error("expected error verifying genesis")
+	}
+	if _, err := api.VerifyRange(2, 4); err == nil {
+		t.Error("expected error verifying beyond the head")
+	}
+	stream, err := api.VerifyRange(1, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := stream(&buf); err != nil {
+		t.Fatal(err)
+	}
+	var results []BlockVerification
+	if err := json.Unmarshal(buf.Bytes(), &results); err != nil {
+		t.Fatalf("invalid output %q: %v", buf.String(), err)
+	}
+	if len(results) != 3 {
+		t.Fatalf("have %d results, want 3", len(results))
+	}
+	for i, result := range results {
+		if uint64(result.Number) != uint64(i+1) || result.Hash != blockChain.GetCanonicalHash(uint64(i+1)) {
+			t.Errorf("result %d: wrong block %d %x", i, result.Number, result.Hash)
+		}
+		if result.Error != "" || len(result.Mismatches) > 0 {
+			t.Errorf("result %d: verification failed: %v %v", i, result.Error, result.Mismatches)
+		}
+	}
+}
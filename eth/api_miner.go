@@ -20,6 +20,7 @@ import (
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/miner"
 )
 
 // MinerAPI provides an API to control the miner.
@@ -51,8 +52,72 @@ func (api *MinerAPI) SetGasPrice(gasPrice hexutil.Big) bool {
 	return true
 }
 
-// SetGasLimit sets the gaslimit to target towards during mining.
-func (api *MinerAPI) SetGasLimit(gasLimit hexutil.Uint64) bool {
-	api.e.Miner().SetGasCeil(uint64(gasLimit))
+// SetGasLimit sets the gaslimit to target towards during mining. Targets outside
+// the configured gas limit bounds are rejected.
+func (api *MinerAPI) SetGasLimit(gasLimit hexutil.Uint64) (bool, error) {
+	if err := api.e.Miner().SetGasCeil(uint64(gasLimit)); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// TxPolicy returns the policy restricting the transactions included in blocks.
+func (api *MinerAPI) TxPolicy() miner.TxPolicy {
+	return api.e.Miner().TxPolicy()
+}
+
+// SetTxPolicy replaces the policy restricting the transactions included in
+// blocks. It is not persisted, the policy file is loaded again on restart.
+func (api *MinerAPI) SetTxPolicy(policy miner.TxPolicy) bool {
+	api.e.Miner().SetTxPolicy(policy)
 	return true
 }
+
+// ReloadTxPolicy reloads the transaction policy from the file set on startup.
+func (api *MinerAPI) ReloadTxPolicy() (bool, error) {
+	if err := api.e.Miner().ReloadTxPolicy(); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// InclusionAPI advertises the requirements transactions have to meet to be
+// included in blocks built by this node.
+type InclusionAPI struct {
+	e *Ethereum
+}
+
+// NewInclusionAPI creates a new InclusionAPI instance.
+func NewInclusionAPI(e *Ethereum) *InclusionAPI {
+	return &InclusionAPI{e}
+}
+
+// tipFloor is the RPC representation of a miner.TipFloor.
+type tipFloor struct {
+	Types   []hexutil.Uint64 `json:"types,omitempty"`
+	MinSize hexutil.Uint64   `json:"minSize"`
+	MinTip  *hexutil.Big     `json:"minTip"`
+}
+
+type inclusionRequirements struct {
+	MinTip    *hexutil.Big `json:"minTip"`
+	TipFloors []tipFloor   `json:"tipFloors"`
+}
+
+// InclusionRequirements returns the minimum effective tip of transactions and
+// the higher floors applying to particular transaction types and sizes.
+func (api *InclusionAPI) InclusionRequirements() inclusionRequirements {
+	tip, floors := api.e.Miner().InclusionRequirements()
+	res := inclusionRequirements{
+		MinTip:    (*hexutil.Big)(tip),
+		TipFloors: make([]tipFloor, 0, len(floors)),
+	}
+	for _, f := range floors {
+		floor := tipFloor{MinSize: hexutil.Uint64(f.MinSize), MinTip: (*hexutil.Big)(f.MinTip)}
+		for _, typ := range f.Types {
+			floor.Types = append(floor.Types, hexutil.Uint64(typ))
+		}
+		res.TipFloors = append(res.TipFloors, floor)
+	}
+	return res
+}
@@ -0,0 +1,80 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// reorgChanSize is the size of the channel buffering the reorgs of a
+// subscription.
+const reorgChanSize = 16
+
+// ReorgAPI exposes the reorg journal of the canonical chain.
+type ReorgAPI struct {
+	chain *core.BlockChain
+	db    ethdb.Database
+}
+
+// NewReorgAPI creates a new ReorgAPI instance.
+func NewReorgAPI(chain *core.BlockChain, db ethdb.Database) *ReorgAPI {
+	return &ReorgAPI{chain: chain, db: db}
+}
+
+// ReorgHistory returns the recently recorded reorgs of the canonical chain,
+// oldest first. The journal is persisted, so it survives restarts.
+func (api *ReorgAPI) ReorgHistory() []*rawdb.ReorgRecord {
+	reorgs := rawdb.ReadReorgJournal(api.db)
+	if reorgs == nil {
+		reorgs = []*rawdb.ReorgRecord{}
+	}
+	return reorgs
+}
+
+// Reorg creates a subscription that is notified of every reorg of the canonical
+// chain as it is recorded in the journal.
+func (api *ReorgAPI) Reorg(ctx context.Context) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+	rpcSub := notifier.CreateSubscription()
+
+	go func() {
+		reorgs := make(chan core.ReorgEvent, reorgChanSize)
+		reorgsSub := api.chain.SubscribeReorgEvent(reorgs)
+		defer reorgsSub.Unsubscribe()
+
+		for {
+			select {
+			case ev := <-reorgs:
+				notifier.Notify(rpcSub.ID, ev.Reorg)
+			case <-reorgsSub.Err():
+				return
+			case <-rpcSub.Err():
+				return
+			}
+		}
+	}()
+
+	return rpcSub, nil
+}
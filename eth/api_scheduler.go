@@ -0,0 +1,66 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// SchedulerAPI schedules signed transactions for a later submission. It is only
+// served on the authenticated endpoint.
+type SchedulerAPI struct {
+	scheduler *txScheduler
+}
+
+// NewSchedulerAPI creates a new SchedulerAPI instance.
+func NewSchedulerAPI(scheduler *txScheduler) *SchedulerAPI {
+	return &SchedulerAPI{scheduler}
+}
+
+// TxScheduleArgs is the chain head block number and timestamp a scheduled
+// transaction waits for. When both are set, both have to be reached.
+type TxScheduleArgs struct {
+	BlockNumber *hexutil.Uint64 `json:"blockNumber,omitempty"`
+	Timestamp   *hexutil.Uint64 `json:"timestamp,omitempty"`
+}
+
+// ScheduleRawTransaction persists a signed transaction to be submitted to the
+// pool once the chain head reaches the given block number or timestamp, so it
+// can be included in the blocks after it. Blob transactions are not supported.
+func (api *SchedulerAPI) ScheduleRawTransaction(input hexutil.Bytes, notBefore TxScheduleArgs) (common.Hash, error) {
+	var number, timestamp uint64
+	if notBefore.BlockNumber != nil {
+		number = uint64(*notBefore.BlockNumber)
+	}
+	if notBefore.Timestamp != nil {
+		timestamp = uint64(*notBefore.Timestamp)
+	}
+	return api.scheduler.schedule(input, number, timestamp)
+}
+
+// ScheduledTransactions returns the transactions waiting for their schedule,
+// followed by the outcome of the recent submissions.
+func (api *SchedulerAPI) ScheduledTransactions() []*scheduledTxInfo {
+	return api.scheduler.list()
+}
+
+// CancelScheduledTransaction drops a transaction waiting for its schedule. It
+// returns false if the transaction is unknown or already submitted.
+func (api *SchedulerAPI) CancelScheduledTransaction(hash common.Hash) bool {
+	return api.scheduler.cancel(hash)
+}
@@ -0,0 +1,166 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+const (
+	// stateDiffChanSize is the size of the channel buffering the state diffs
+	// of a subscription.
+	stateDiffChanSize = 16
+
+	// stateDiffReexec is the number of blocks re-executed at most to
+	// regenerate the parent state of a block whose state diff is requested.
+	stateDiffReexec = 128
+)
+
+// StateDiffAPI streams the state changes of the imported blocks.
+type StateDiffAPI struct {
+	chain *core.BlockChain
+}
+
+// NewStateDiffAPI creates a new StateDiffAPI instance.
+func NewStateDiffAPI(chain *core.BlockChain) *StateDiffAPI {
+	return &StateDiffAPI{chain: chain}
+}
+
+// StateDiffFilter restricts the accounts reported by a state diff subscription.
+type StateDiffFilter struct {
+	Addresses []common.Address `json:"addresses"` // Accounts to report, all if empty
+}
+
+// BlockStateDiff is the set of state changes made by a block.
+type BlockStateDiff struct {
+	BlockNumber hexutil.Uint64      `json:"blockNumber"`
+	BlockHash   common.Hash         `json:"blockHash"`
+	ParentHash  common.Hash         `json:"parentHash"`
+	Accounts    []state.AccountDiff `json:"accounts"`
+
+	// Codes are the contract codes deployed by the block, keyed by hash. They
+	// are only reported for re-executed blocks.
+	Codes map[common.Hash]hexutil.Bytes `json:"codes,omitempty"`
+}
+
+// StateDiffs creates a subscription that is notified of the accounts and storage
+// slots modified by each canonical block, with their values before and after the
+// block. Blocks are reported as they are imported, including the ones of a new
+// canonical chain after a reorg.
+func (api *StateDiffAPI) StateDiffs(ctx context.Context, filter *StateDiffFilter) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+	var addresses map[common.Address]struct{}
+	if filter != nil && len(filter.Addresses) > 0 {
+		addresses = make(map[common.Address]struct{}, len(filter.Addresses))
+		for _, addr := range filter.Addresses {
+			addresses[addr] = struct{}{}
+		}
+	}
+	rpcSub := notifier.CreateSubscription()
+
+	go func() {
+		diffs := make(chan core.StateDiffEvent, stateDiffChanSize)
+		diffsSub := api.chain.SubscribeStateDiffEvent(diffs)
+		defer diffsSub.Unsubscribe()
+
+		for {
+			select {
+			case ev := <-diffs:
+				accounts := ev.Accounts
+				if addresses != nil {
+					accounts = nil
+					for _, diff := range ev.Accounts {
+						if _, ok := addresses[diff.Address]; ok {
+							accounts = append(accounts, diff)
+						}
+					}
+				}
+				if accounts == nil {
+					accounts = []state.AccountDiff{}
+				}
+				notifier.Notify(rpcSub.ID, &BlockStateDiff{
+					BlockNumber: hexutil.Uint64(ev.Header.Number.Uint64()),
+					BlockHash:   ev.Header.Hash(),
+					ParentHash:  ev.Header.ParentHash,
+					Accounts:    accounts,
+				})
+			case <-diffsSub.Err():
+				return
+			case <-rpcSub.Err():
+				return
+			}
+		}
+	}()
+
+	return rpcSub, nil
+}
+
+// StateDiff re-executes a block on top of the state of its parent and returns
+// the accounts, storage slots and contract codes changed by it.
+func (eth *Ethereum) StateDiff(ctx context.Context, block *types.Block) (*BlockStateDiff, error) {
+	if block.NumberU64() == 0 {
+		return nil, errors.New("genesis is not executed")
+	}
+	parent := eth.blockchain.GetBlock(block.ParentHash(), block.NumberU64()-1)
+	if parent == nil {
+		return nil, fmt.Errorf("parent %#x not found", block.ParentHash())
+	}
+	statedb, release, err := eth.stateAtBlock(ctx, parent, stateDiffReexec, nil, true, false)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	eth.blockchain.LoadSenders([]*types.Block{block})
+	if _, err := eth.blockchain.Processor().Process(block, statedb, vm.Config{}); err != nil {
+		return nil, fmt.Errorf("processing block %d failed: %v", block.NumberU64(), err)
+	}
+	statedb.Finalise(eth.blockchain.Config().IsEIP158(block.Number()))
+
+	diff := &BlockStateDiff{
+		BlockNumber: hexutil.Uint64(block.NumberU64()),
+		BlockHash:   block.Hash(),
+		ParentHash:  block.ParentHash(),
+		Accounts:    statedb.StateDiff(),
+		Codes:       make(map[common.Hash]hexutil.Bytes),
+	}
+	for _, account := range diff.Accounts {
+		if account.Post == nil || account.Post.CodeHash == types.EmptyCodeHash {
+			continue
+		}
+		if account.Prev == nil || account.Prev.CodeHash != account.Post.CodeHash {
+			diff.Codes[account.Post.CodeHash] = statedb.GetCode(account.Address)
+		}
+	}
+	if diff.Accounts == nil {
+		diff.Accounts = []state.AccountDiff{}
+	}
+	return diff, nil
+}
@@ -24,6 +24,7 @@ import (
 	"math"
 	"math/big"
 	"runtime"
+	"slices"
 	"sync"
 	"time"
 
@@ -44,8 +45,10 @@ import (
 	"github.com/ethereum/go-ethereum/eth/downloader"
 	"github.com/ethereum/go-ethereum/eth/ethconfig"
 	"github.com/ethereum/go-ethereum/eth/gasprice"
+	"github.com/ethereum/go-ethereum/eth/protocols/bpush"
 	"github.com/ethereum/go-ethereum/eth/protocols/eth"
 	"github.com/ethereum/go-ethereum/eth/protocols/snap"
+	"github.com/ethereum/go-ethereum/eth/protocols/txr"
 	"github.com/ethereum/go-ethereum/eth/tracers"
 	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/event"
@@ -58,6 +61,7 @@ import (
 	"github.com/ethereum/go-ethereum/p2p"
 	"github.com/ethereum/go-ethereum/p2p/dnsdisc"
 	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/ethereum/go-ethereum/p2p/msgrate"
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/ethereum/go-ethereum/rpc"
@@ -93,8 +97,11 @@ type Ethereum struct {
 	// core protocol objects
 	config         *ethconfig.Config
 	txPool         *txpool.TxPool
+	legacyTxPool   *legacypool.LegacyPool
 	blobTxPool     *blobpool.BlobPool
 	localTxTracker *locals.TxTracker
+	localAccounts  *locals.Accounts
+	txScheduler    *txScheduler
 	blockchain     *core.BlockChain
 
 	handler *handler
@@ -108,13 +115,13 @@ type Ethereum struct {
 	engine         consensus.Engine
 	accountManager *accounts.Manager
 
-	filterMaps      *filtermaps.FilterMaps
-	closeFilterMaps chan chan struct{}
+	filterMaps *filtermaps.FilterMaps
 
 	APIBackend *EthAPIBackend
 
 	miner    *miner.Miner
 	gasPrice *big.Int
+	settings *settingsRegistry // runtime-tunable settings
 
 	networkID     uint64
 	netRPCService *ethapi.NetAPI
@@ -140,6 +147,18 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 		log.Warn("Sanitizing invalid miner gas price", "provided", config.Miner.GasPrice, "updated", ethconfig.Defaults.Miner.GasPrice)
 		config.Miner.GasPrice = new(big.Int).Set(ethconfig.Defaults.Miner.GasPrice)
 	}
+	for i := range config.Miner.TipFloors {
+		if err := config.Miner.TipFloors[i].Validate(); err != nil {
+			return nil, fmt.Errorf("invalid miner tip floor %d: %v", i, err)
+		}
+	}
+	if config.Miner.PolicyFile != "" {
+		policy, err := miner.LoadTxPolicy(config.Miner.PolicyFile)
+		if err != nil {
+			return nil, err
+		}
+		config.Miner.Policy = policy
+	}
 	if config.NoPruning && config.TrieDirtyCache > 0 && config.StateScheme == rawdb.HashScheme {
 		if config.SnapshotCache > 0 {
 			config.TrieCleanCache += config.TrieDirtyCache * 3 / 5
@@ -202,6 +221,14 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 		discmix:         enode.NewFairMix(discmixTimeout),
 		shutdownTracker: shutdowncheck.NewShutdownTracker(chainDb),
 	}
+	// Bring the database schema up to date before anything else reads it
+	var migration rawdb.SchemaMigrationConfig
+	if config.DatabaseMigrationBackup != "" {
+		migration.BackupDir = stack.ResolvePath(config.DatabaseMigrationBackup)
+	}
+	if _, err := rawdb.MigrateSchema(chainDb, migration); err != nil {
+		return nil, err
+	}
 	bcVersion := rawdb.ReadDatabaseVersion(chainDb)
 	var dbVer = "<nil>"
 	if bcVersion != nil {
@@ -222,21 +249,25 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 	}
 	var (
 		options = &core.BlockChainConfig{
-			TrieCleanLimit:   config.TrieCleanCache,
-			NoPrefetch:       config.NoPrefetch,
-			TrieDirtyLimit:   config.TrieDirtyCache,
-			ArchiveMode:      config.NoPruning,
-			TrieTimeLimit:    config.TrieTimeout,
-			SnapshotLimit:    config.SnapshotCache,
-			Preimages:        config.Preimages,
-			StateHistory:     config.StateHistory,
-			StateScheme:      scheme,
-			ChainHistoryMode: config.HistoryMode,
-			TxLookupLimit:    int64(min(config.TransactionHistory, math.MaxInt64)),
+			TrieCleanLimit:    config.TrieCleanCache,
+			TrieHotLimit:      config.TrieHotCache,
+			NoPrefetch:        config.NoPrefetch,
+			TrieDirtyLimit:    config.TrieDirtyCache,
+			ArchiveMode:       config.NoPruning,
+			TrieTimeLimit:     config.TrieTimeout,
+			SnapshotLimit:     config.SnapshotCache,
+			Preimages:         config.Preimages,
+			StateHistory:      config.StateHistory,
+			StateScheme:       scheme,
+			ChainHistoryMode:  config.HistoryMode,
+			ChainHistoryStart: config.HistoryStart,
+			TxLookupLimit:     int64(min(config.TransactionHistory, math.MaxInt64)),
 			VmConfig: vm.Config{
 				EnablePreimageRecording: config.EnablePreimageRecording,
 				EnableWitnessStats:      config.EnableWitnessStats,
 				StatelessSelfValidation: config.StatelessSelfValidation,
+				RecordHaltReasons:       config.HaltReasons,
+				RecordRevertReasons:     config.RevertReasons,
 			},
 			// Enables file journaling for the trie database. The journal files will be stored
 			// within the data directory. The corresponding paths will be either:
@@ -245,6 +276,8 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 			TrieJournalDirectory: stack.ResolvePath("triedb"),
 			StateSizeTracking:    config.EnableStateSizeTracking,
 			SlowBlockThreshold:   config.SlowBlockThreshold,
+			MaxReorgDepth:        config.MaxReorgDepth,
+			SenderCache:          config.SenderCache,
 		}
 	)
 	if config.VMTrace != "" {
@@ -297,13 +330,13 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 		return nil, err
 	}
 	eth.filterMaps = filterMaps
-	eth.closeFilterMaps = make(chan chan struct{})
 
 	// TxPool
 	if config.TxPool.Journal != "" {
 		config.TxPool.Journal = stack.ResolvePath(config.TxPool.Journal)
 	}
 	legacyPool := legacypool.New(config.TxPool, eth.blockchain)
+	eth.legacyTxPool = legacyPool
 
 	if config.BlobPool.Datadir != "" {
 		config.BlobPool.Datadir = stack.ResolvePath(config.BlobPool.Datadir)
@@ -323,6 +356,22 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 		}
 		eth.localTxTracker = locals.New(config.TxPool.Journal, rejournal, eth.blockchain.Config(), eth.txPool)
 		stack.RegisterLifecycle(eth.localTxTracker)
+
+		var path string
+		if config.TxPool.LocalAccounts != "" {
+			path = stack.ResolvePath(config.TxPool.LocalAccounts)
+		}
+		if eth.localAccounts, err = locals.NewAccounts(path, config.TxPool.Locals); err != nil {
+			return nil, fmt.Errorf("failed to load local accounts: %w", err)
+		}
+	}
+	if config.TxPool.Snapshot != "" {
+		interval := config.TxPool.SnapshotInterval
+		if interval < time.Second {
+			log.Warn("Sanitizing invalid txpool snapshot interval", "provided", interval, "updated", time.Second)
+			interval = time.Second
+		}
+		stack.RegisterLifecycle(txpool.NewSnapshotter(eth.txPool, stack.ResolvePath(config.TxPool.Snapshot), interval))
 	}
 
 	// Permit the downloader to use the trie cache allowance during fast sync
@@ -334,18 +383,28 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 		TxPool:         eth.txPool,
 		Network:        networkID,
 		Sync:           config.SyncMode,
+		SyncBandwidth:  msgrate.NewLimiter(config.SyncBandwidth, config.SyncPeerBandwidth),
 		BloomCache:     uint64(cacheLimit),
 		EventMux:       eth.eventMux,
 		RequiredBlocks: config.RequiredBlocks,
+		NoTxIngress:    config.TxGossipNoIngress,
+		NoTxEgress:     config.TxGossipNoEgress,
+		PushReplicas:   config.PushReplicas,
+		PushSequencers: config.PushSequencers,
 	}); err != nil {
 		return nil, err
 	}
 
-	eth.dropper = newDropper(eth.p2pServer.MaxDialedConns(), eth.p2pServer.MaxInboundConns())
+	eth.dropper = newDropper()
 
 	eth.miner = miner.New(eth, config.Miner, eth.engine)
 	eth.miner.SetExtra(makeExtraData(config.Miner.ExtraData))
-	eth.miner.SetPrioAddresses(config.TxPool.Locals)
+	eth.settings = newSettingsRegistry(eth)
+	if eth.localAccounts != nil {
+		eth.miner.SetPrioAddresses(eth.localAccounts.List())
+	} else {
+		eth.miner.SetPrioAddresses(config.TxPool.Locals)
+	}
 
 	eth.APIBackend = &EthAPIBackend{stack.Config().ExtRPCEnabled(), stack.Config().AllowUnprotectedTxs, eth, nil}
 	if eth.APIBackend.allowUnprotectedTxs {
@@ -353,6 +412,11 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 	}
 	eth.APIBackend.gpo = gasprice.NewOracle(eth.APIBackend, config.GPO, config.Miner.GasPrice)
 
+	eth.txScheduler = newTxScheduler(chainDb, eth.blockchain, func(ctx context.Context, tx *types.Transaction) error {
+		_, err := ethapi.SubmitTransaction(ctx, eth.APIBackend, tx)
+		return err
+	})
+
 	// Start the RPC service
 	eth.netRPCService = ethapi.NewNetAPI(eth.p2pServer, networkID)
 
@@ -360,6 +424,9 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 	stack.RegisterAPIs(eth.APIs())
 	stack.RegisterProtocols(eth.Protocols())
 	stack.RegisterLifecycle(eth)
+	stack.RegisterDrainHook(func(draining bool, pauseIngress bool) {
+		eth.txPool.SetIngressPaused(draining && pauseIngress)
+	})
 
 	// Successful startup; push a marker and check previous unclean shutdowns.
 	eth.shutdownTracker.MarkStartup()
@@ -394,6 +461,19 @@ func (s *Ethereum) APIs() []rpc.API {
 		{
 			Namespace: "miner",
 			Service:   NewMinerAPI(s),
+		}, {
+			Namespace: "eth",
+			Service:   NewInclusionAPI(s),
+		}, {
+			Namespace: "eth",
+			Service:   NewStateDiffAPI(s.blockchain),
+		}, {
+			Namespace: "eth",
+			Service:   NewBlobAPI(s),
+		}, {
+			Namespace:     "eth",
+			Service:       NewSchedulerAPI(s.txScheduler),
+			Authenticated: true,
 		}, {
 			Namespace: "eth",
 			Service:   downloader.NewDownloaderAPI(s.handler.downloader, s.blockchain, s.eventMux),
@@ -403,6 +483,9 @@ func (s *Ethereum) APIs() []rpc.API {
 		}, {
 			Namespace: "debug",
 			Service:   NewDebugAPI(s),
+		}, {
+			Namespace: "debug",
+			Service:   NewReorgAPI(s.blockchain, s.chainDb),
 		}, {
 			Namespace: "net",
 			Service:   s.netRPCService,
@@ -432,9 +515,15 @@ func (s *Ethereum) ArchiveMode() bool                  { return s.config.NoPruni
 // network protocols to start.
 func (s *Ethereum) Protocols() []p2p.Protocol {
 	protos := eth.MakeProtocols((*ethHandler)(s.handler), s.networkID, s.discmix)
-	if s.config.SnapshotCache > 0 {
+	// The path database maintains its flat state regardless of the snapshot
+	// cache and can serve from the tries while that is being generated.
+	if s.config.SnapshotCache > 0 || s.blockchain.TrieDB().Scheme() == rawdb.PathScheme {
 		protos = append(protos, snap.MakeProtocols((*snapHandler)(s.handler))...)
 	}
+	protos = append(protos, txr.MakeProtocols((*txrHandler)(s.handler))...)
+	if s.handler.pushEnabled() {
+		protos = append(protos, bpush.MakeProtocols((*bpushHandler)(s.handler))...)
+	}
 	return protos
 }
 
@@ -449,14 +538,29 @@ func (s *Ethereum) Start() error {
 	s.shutdownTracker.Start()
 
 	// Start the networking layer
-	s.handler.Start(s.p2pServer.MaxPeers)
+	s.handler.Start(s.p2pServer.PeerLimit)
 
 	// Start the connection manager
 	s.dropper.Start(s.p2pServer, func() bool { return !s.Synced() })
 
-	// start log indexer
+	// Keep connected to the block push counterparts
+	for _, n := range append(slices.Clone(s.config.PushReplicas), s.config.PushSequencers...) {
+		s.p2pServer.AddTrustedPeer(n)
+		s.p2pServer.AddPeer(n)
+	}
+
+	// start log indexer, following the chain head along with the other indexes
 	s.filterMaps.Start()
-	go s.updateFilterMapsHeads()
+	if !s.config.LogNoHistory {
+		s.blockchain.RegisterIndex(&logIndex{
+			maps:    s.filterMaps,
+			chain:   s.blockchain,
+			history: s.config.LogHistory,
+		})
+	}
+
+	// start submitting scheduled transactions
+	s.txScheduler.start()
 	return nil
 }
 
@@ -467,57 +571,6 @@ func (s *Ethereum) newChainView(head *types.Header) *filtermaps.ChainView {
 	return filtermaps.NewChainView(s.blockchain, head.Number.Uint64(), head.Hash())
 }
 
-func (s *Ethereum) updateFilterMapsHeads() {
-	headEventCh := make(chan core.ChainEvent, 10)
-	blockProcCh := make(chan bool, 10)
-	sub := s.blockchain.SubscribeChainEvent(headEventCh)
-	sub2 := s.blockchain.SubscribeBlockProcessingEvent(blockProcCh)
-	defer func() {
-		sub.Unsubscribe()
-		sub2.Unsubscribe()
-		for {
-			select {
-			case <-headEventCh:
-			case <-blockProcCh:
-			default:
-				return
-			}
-		}
-	}()
-
-	var head *types.Header
-	setHead := func(newHead *types.Header) {
-		if newHead == nil {
-			return
-		}
-		if head == nil || newHead.Hash() != head.Hash() {
-			head = newHead
-			chainView := s.newChainView(head)
-			historyCutoff, _ := s.blockchain.HistoryPruningCutoff()
-			var finalBlock uint64
-			if fb := s.blockchain.CurrentFinalBlock(); fb != nil {
-				finalBlock = fb.Number.Uint64()
-			}
-			s.filterMaps.SetTarget(chainView, historyCutoff, finalBlock)
-		}
-	}
-	setHead(s.blockchain.CurrentBlock())
-
-	for {
-		select {
-		case ev := <-headEventCh:
-			setHead(ev.Header)
-		case blockProc := <-blockProcCh:
-			s.filterMaps.SetBlockProcessing(blockProc)
-		case <-time.After(time.Second * 10):
-			setHead(s.blockchain.CurrentBlock())
-		case ch := <-s.closeFilterMaps:
-			close(ch)
-			return
-		}
-	}
-}
-
 func (s *Ethereum) setupDiscovery() error {
 	eth.StartENRUpdater(s.blockchain, s.p2pServer.LocalNode())
 
@@ -576,10 +629,8 @@ func (s *Ethereum) Stop() error {
 	s.handler.Stop()
 
 	// Then stop everything else.
-	ch := make(chan struct{})
-	s.closeFilterMaps <- ch
-	<-ch
 	s.filterMaps.Stop()
+	s.txScheduler.stop()
 	s.txPool.Close()
 	s.blockchain.Stop()
 	s.engine.Close()
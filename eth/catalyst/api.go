@@ -47,12 +47,17 @@ import (
 
 // Register adds the engine API to the full node.
 func Register(stack *node.Node, backend *eth.Ethereum) error {
+	api := NewConsensusAPI(backend)
 	stack.RegisterAPIs([]rpc.API{
 		{
 			Namespace:     "engine",
-			Service:       NewConsensusAPI(backend),
+			Service:       api,
 			Authenticated: true,
 		},
+		{
+			Namespace: "rollup",
+			Service:   &RollupAPI{api},
+		},
 	})
 	return nil
 }
@@ -63,6 +68,14 @@ const (
 	// to be reprocessed again.
 	invalidBlockHitEviction = 128
 
+	// maxBodiesRange is the maximum number of bodies which can be requested
+	// in a single range request.
+	maxBodiesRange = 1024
+
+	// maxBodiesBatchSize is the maximum amount of ancient body data read from
+	// the freezer at once while serving a range request.
+	maxBodiesBatchSize = 2 * 1024 * 1024
+
 	// invalidTipsetsCap is the max number of recent block hashes tracked that
 	// have lead to some bad ancestor block. It's just an OOM protection.
 	invalidTipsetsCap = 512
@@ -119,6 +132,8 @@ type ConsensusAPI struct {
 
 	forkchoiceLock sync.Mutex // Lock for the forkChoiceUpdated method
 	newPayloadLock sync.Mutex // Lock for the NewPayload method
+
+	syncStatus syncTracker // Recent head labels, exposed via rollup_syncStatus
 }
 
 // NewConsensusAPI creates a new consensus api for the given backend.
@@ -331,6 +346,9 @@ func (api *ConsensusAPI) forkchoiceUpdated(update engine.ForkchoiceStateV1, payl
 		// Set the safe block
 		api.eth.BlockChain().SetSafe(safeBlock.Header())
 	}
+	chain := api.eth.BlockChain()
+	api.syncStatus.record(chain.CurrentBlock(), chain.CurrentSafeBlock(), chain.CurrentFinalBlock())
+
 	// If payload generation was requested, create a new block to be potentially
 	// sealed by the beacon client. The payload will be requested later, and we
 	// will replace it arbitrarily many times in between.
@@ -346,8 +364,12 @@ func (api *ConsensusAPI) forkchoiceUpdated(update engine.ForkchoiceStateV1, payl
 		}
 		id := args.Id()
 		// If we already are busy generating this work, then we do not need
-		// to start a second process.
+		// to start a second process. The payload id is derived from the
+		// attributes, so retried updates resolve to the existing build.
+		payloadAttributesMeter.Mark(1)
 		if api.localBlocks.has(id) {
+			payloadDuplicateMeter.Mark(1)
+			log.Debug("Reusing payload build for duplicate attributes", "id", id, "parent", update.HeadBlockHash)
 			return valid(&id), nil
 		}
 		payload, err := api.eth.Miner().BuildPayload(args, payloadWitness)
@@ -1033,23 +1055,13 @@ func (api *ConsensusAPI) GetClientVersionV1(info engine.ClientVersionV1) []engin
 // GetPayloadBodiesByHashV1 implements engine_getPayloadBodiesByHashV1 which allows for retrieval of a list
 // of block bodies by the engine api.
 func (api *ConsensusAPI) GetPayloadBodiesByHashV1(hashes []common.Hash) []*engine.ExecutionPayloadBody {
-	bodies := make([]*engine.ExecutionPayloadBody, len(hashes))
-	for i, hash := range hashes {
-		block := api.eth.BlockChain().GetBlockByHash(hash)
-		bodies[i] = getBody(block)
-	}
-	return bodies
+	return api.getBodiesByHash(hashes)
 }
 
 // GetPayloadBodiesByHashV2 implements engine_getPayloadBodiesByHashV1 which allows for retrieval of a list
 // of block bodies by the engine api.
 func (api *ConsensusAPI) GetPayloadBodiesByHashV2(hashes []common.Hash) []*engine.ExecutionPayloadBody {
-	bodies := make([]*engine.ExecutionPayloadBody, len(hashes))
-	for i, hash := range hashes {
-		block := api.eth.BlockChain().GetBlockByHash(hash)
-		bodies[i] = getBody(block)
-	}
-	return bodies
+	return api.getBodiesByHash(hashes)
 }
 
 // GetPayloadBodiesByRangeV1 implements engine_getPayloadBodiesByRangeV1 which allows for retrieval of a range
@@ -1064,11 +1076,23 @@ func (api *ConsensusAPI) GetPayloadBodiesByRangeV2(start, count hexutil.Uint64)
 	return api.getBodiesByRange(start, count)
 }
 
+func (api *ConsensusAPI) getBodiesByHash(hashes []common.Hash) []*engine.ExecutionPayloadBody {
+	bodies := make([]*engine.ExecutionPayloadBody, len(hashes))
+	for i, hash := range hashes {
+		header := api.eth.BlockChain().GetHeaderByHash(hash)
+		if header == nil {
+			continue
+		}
+		bodies[i] = getBody(header, api.eth.BlockChain().GetBodyRLP(hash))
+	}
+	return bodies
+}
+
 func (api *ConsensusAPI) getBodiesByRange(start, count hexutil.Uint64) ([]*engine.ExecutionPayloadBody, error) {
 	if start == 0 || count == 0 {
 		return nil, engine.InvalidParams.With(fmt.Errorf("invalid start or count, start: %v count: %v", start, count))
 	}
-	if count > 1024 {
+	if count > maxBodiesRange {
 		return nil, engine.TooLargeRequest.With(fmt.Errorf("requested count too large: %v", count))
 	}
 	// limit count up until current
@@ -1077,33 +1101,115 @@ func (api *ConsensusAPI) getBodiesByRange(start, count hexutil.Uint64) ([]*engin
 	if last > current {
 		last = current
 	}
-	bodies := make([]*engine.ExecutionPayloadBody, 0, uint64(count))
-	for i := uint64(start); i <= last; i++ {
-		block := api.eth.BlockChain().GetBlockByNumber(i)
-		bodies = append(bodies, getBody(block))
+	var (
+		db     = api.eth.ChainDb()
+		bodies = make([]*engine.ExecutionPayloadBody, 0, uint64(count))
+		number = uint64(start)
+	)
+	// Stream the ancient part of the range straight from the freezer in batches
+	// of limited size, to not thrash the caches of the recent blocks with old
+	// history requested by backfilling consensus clients.
+	frozen, _ := db.Ancients()
+	tail, _ := db.Tail()
+	for number <= last && number < frozen {
+		if number < tail {
+			bodies = append(bodies, nil) // Pruned history
+			number++
+			continue
+		}
+		headers, err := db.AncientRange(rawdb.ChainFreezerHeaderTable, number, min(last+1, frozen)-number, maxBodiesBatchSize)
+		if err != nil || len(headers) == 0 {
+			break // Fall back to the individual lookups below
+		}
+		blobs, err := db.AncientRange(rawdb.ChainFreezerBodiesTable, number, uint64(len(headers)), maxBodiesBatchSize)
+		if err != nil || len(blobs) == 0 {
+			break
+		}
+		for i, blob := range blobs {
+			header := new(types.Header)
+			if err := rlp.DecodeBytes(headers[i], header); err != nil {
+				log.Error("Invalid block header in database", "number", number+uint64(i), "err", err)
+				header = nil
+			}
+			bodies = append(bodies, getBody(header, blob))
+		}
+		number += uint64(len(blobs))
+	}
+	for ; number <= last; number++ {
+		hash := api.eth.BlockChain().GetCanonicalHash(number)
+		if hash == (common.Hash{}) {
+			bodies = append(bodies, nil)
+			continue
+		}
+		header := api.eth.BlockChain().GetHeader(hash, number)
+		if header == nil {
+			bodies = append(bodies, nil)
+			continue
+		}
+		bodies = append(bodies, getBody(header, api.eth.BlockChain().GetBodyRLP(hash)))
 	}
 	return bodies, nil
 }
 
-func getBody(block *types.Block) *engine.ExecutionPayloadBody {
-	if block == nil {
+// getBody converts an RLP encoded block body into its engine API representation.
+// The transactions are sliced out of the encoding without being decoded, as they
+// are returned in their binary encoding anyway.
+func getBody(header *types.Header, data rlp.RawValue) *engine.ExecutionPayloadBody {
+	if header == nil || len(data) == 0 {
 		return nil
 	}
+	body, err := splitBody(header, data)
+	if err != nil {
+		log.Error("Invalid block body in database", "err", err)
+		return nil
+	}
+	return body
+}
 
+func splitBody(header *types.Header, data rlp.RawValue) (*engine.ExecutionPayloadBody, error) {
+	fields, _, err := rlp.SplitList(data)
+	if err != nil {
+		return nil, err
+	}
+	txs, rest, err := rlp.SplitList(fields)
+	if err != nil {
+		return nil, err
+	}
 	var result engine.ExecutionPayloadBody
 
-	result.TransactionData = make([]hexutil.Bytes, len(block.Transactions()))
-	for j, tx := range block.Transactions() {
-		result.TransactionData[j], _ = tx.MarshalBinary()
+	result.TransactionData = make([]hexutil.Bytes, 0)
+	for len(txs) > 0 {
+		kind, content, next, err := rlp.Split(txs)
+		if err != nil {
+			return nil, err
+		}
+		// Legacy transactions are encoded as lists, typed ones as strings
+		// wrapping their binary encoding.
+		if kind == rlp.List {
+			result.TransactionData = append(result.TransactionData, hexutil.Bytes(txs[:len(txs)-len(next)]))
+		} else {
+			result.TransactionData = append(result.TransactionData, hexutil.Bytes(content))
+		}
+		txs = next
+	}
+	// Skip the uncles and decode the withdrawals if present.
+	if _, _, rest, err = rlp.Split(rest); err != nil {
+		return nil, err
+	}
+	if len(rest) > 0 {
+		_, _, next, err := rlp.Split(rest)
+		if err != nil {
+			return nil, err
+		}
+		if err := rlp.DecodeBytes(rest[:len(rest)-len(next)], &result.Withdrawals); err != nil {
+			return nil, err
+		}
 	}
-
 	// Post-shanghai withdrawals MUST be set to empty slice instead of nil
-	result.Withdrawals = block.Withdrawals()
-	if block.Withdrawals() == nil && block.Header().WithdrawalsHash != nil {
+	if result.Withdrawals == nil && header.WithdrawalsHash != nil {
 		result.Withdrawals = []*types.Withdrawal{}
 	}
-
-	return &result
+	return &result, nil
 }
 
 // convertRequests converts a hex requests slice to plain [][]byte.
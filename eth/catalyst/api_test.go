@@ -37,6 +37,7 @@ import (
 	"github.com/ethereum/go-ethereum/consensus/beacon"
 	"github.com/ethereum/go-ethereum/consensus/ethash"
 	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/crypto/kzg4844"
@@ -48,6 +49,7 @@ import (
 	"github.com/ethereum/go-ethereum/node"
 	"github.com/ethereum/go-ethereum/p2p"
 	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/ethereum/go-ethereum/trie"
 	"github.com/holiman/uint256"
@@ -220,6 +222,33 @@ func TestEth2PrepareAndGetPayload(t *testing.T) {
 	}
 }
 
+// Tests that retried forkchoice updates with identical payload attributes are
+// served from the payload build already in progress.
+func TestDuplicatePayloadAttributes(t *testing.T) {
+	genesis, blocks := generateMergeChain(10, false)
+	n, ethservice := startEthService(t, genesis, blocks)
+	defer n.Close()
+
+	api := newConsensusAPIWithoutHeartbeat(ethservice)
+	fcState := engine.ForkchoiceStateV1{HeadBlockHash: blocks[9].Hash()}
+	blockParams := engine.PayloadAttributes{Timestamp: blocks[9].Time() + 5}
+
+	first, err := api.ForkchoiceUpdatedV1(fcState, &blockParams)
+	if err != nil {
+		t.Fatalf("error preparing payload: %v", err)
+	}
+	second, err := api.ForkchoiceUpdatedV1(fcState, &blockParams)
+	if err != nil {
+		t.Fatalf("error preparing duplicate payload: %v", err)
+	}
+	if first.PayloadID == nil || second.PayloadID == nil || *first.PayloadID != *second.PayloadID {
+		t.Fatalf("payload ids differ: %v != %v", first.PayloadID, second.PayloadID)
+	}
+	if api.localBlocks.payloads[1] != nil {
+		t.Fatal("duplicate attributes started a second payload build")
+	}
+}
+
 func checkLogEvents(t *testing.T, logsCh <-chan []*types.Log, rmLogsCh <-chan core.RemovedLogsEvent, wantNew, wantRemoved int) {
 	t.Helper()
 
@@ -1493,6 +1522,161 @@ func TestGetBlockBodiesByRangeInvalidParams(t *testing.T) {
 	}
 }
 
+// freezeBlocks moves the genesis and the given blocks into the freezer, dropping
+// their bodies from the key-value store.
+func freezeBlocks(t *testing.T, ethservice *eth.Ethereum, blocks []*types.Block) {
+	var (
+		db       = ethservice.ChainDb()
+		chain    = append([]*types.Block{ethservice.BlockChain().Genesis()}, blocks...)
+		receipts = make([]rlp.RawValue, len(chain))
+	)
+	for i, block := range chain {
+		receipts[i] = rawdb.ReadReceiptsRLP(db, block.Hash(), block.NumberU64())
+		if len(receipts[i]) == 0 {
+			receipts[i] = rlp.EmptyList
+		}
+	}
+	if _, err := rawdb.WriteAncientBlocks(db, chain, receipts); err != nil {
+		t.Fatal("can't freeze blocks:", err)
+	}
+	for _, block := range chain[1:] {
+		rawdb.DeleteBody(db, block.Hash(), block.NumberU64())
+	}
+}
+
+func TestGetBlockBodiesByRangeAncient(t *testing.T) {
+	node, eth, blocks := setupBodies(t)
+	api := newConsensusAPIWithoutHeartbeat(eth)
+	defer node.Close()
+
+	// Freeze the first post-shanghai blocks, to have withdrawals in the freezer.
+	freezeBlocks(t, eth, blocks[:13])
+
+	tests := []struct {
+		results []*types.Body
+		start   hexutil.Uint64
+		count   hexutil.Uint64
+	}{
+		// Fully ancient range
+		{
+			results: []*types.Body{blocks[1].Body(), blocks[2].Body(), blocks[3].Body()},
+			start:   2,
+			count:   3,
+		},
+		// Fully ancient post-shanghai range
+		{
+			results: []*types.Body{blocks[10].Body(), blocks[11].Body(), blocks[12].Body()},
+			start:   11,
+			count:   3,
+		},
+		// Mixed range
+		{
+			results: []*types.Body{blocks[11].Body(), blocks[12].Body(), blocks[13].Body(), blocks[14].Body()},
+			start:   12,
+			count:   4,
+		},
+		// Fully recent range
+		{
+			results: []*types.Body{blocks[15].Body(), blocks[16].Body()},
+			start:   16,
+			count:   2,
+		},
+		// All blocks
+		{
+			results: allBodies(blocks),
+			start:   1,
+			count:   hexutil.Uint64(len(blocks)),
+		},
+	}
+	for k, test := range tests {
+		result, err := api.GetPayloadBodiesByRangeV2(test.start, test.count)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(result) != len(test.results) {
+			t.Fatalf("test %d: invalid length want %v got %v", k, len(test.results), len(result))
+		}
+		for i, r := range result {
+			if err := checkEqualBody(test.results[i], r); err != nil {
+				t.Fatalf("test %d: invalid response: %v\nexpected %+v\ngot %+v", k, err, test.results[i], r)
+			}
+		}
+	}
+	// Ancient bodies are served by hash too.
+	result := api.GetPayloadBodiesByHashV2(allHashes(blocks))
+	for i, r := range result {
+		if err := checkEqualBody(blocks[i].Body(), r); err != nil {
+			t.Fatalf("block %d: invalid response: %v", blocks[i].NumberU64(), err)
+		}
+	}
+}
+
+func TestSplitBody(t *testing.T) {
+	var (
+		signer = types.LatestSigner(params.MergedTestChainConfig)
+		legacy = types.MustSignNewTx(testKey, signer, &types.LegacyTx{
+			Nonce:    0,
+			GasPrice: big.NewInt(params.InitialBaseFee),
+			Gas:      params.TxGas,
+			To:       &common.Address{0x01},
+		})
+		dynamic = types.MustSignNewTx(testKey, signer, &types.DynamicFeeTx{
+			ChainID:   params.MergedTestChainConfig.ChainID,
+			Nonce:     1,
+			GasTipCap: big.NewInt(1),
+			GasFeeCap: big.NewInt(params.InitialBaseFee),
+			Gas:       params.TxGas,
+			To:        &common.Address{0x02},
+			Data:      []byte{0xc0, 0xff, 0xee},
+		})
+		withdrawals = []*types.Withdrawal{{Index: 1, Validator: 2, Address: common.Address{0x03}, Amount: 4}}
+		preShanghai = &types.Header{Number: big.NewInt(1)}
+		shanghai    = &types.Header{Number: big.NewInt(1), WithdrawalsHash: &types.EmptyWithdrawalsHash}
+	)
+	tests := []struct {
+		header *types.Header
+		body   *types.Body
+		want   *types.Body
+	}{
+		// Empty body
+		{header: preShanghai, body: &types.Body{}, want: &types.Body{}},
+		// Legacy and typed transactions
+		{
+			header: preShanghai,
+			body:   &types.Body{Transactions: types.Transactions{legacy, dynamic}},
+			want:   &types.Body{Transactions: types.Transactions{legacy, dynamic}},
+		},
+		// Withdrawals
+		{
+			header: shanghai,
+			body:   &types.Body{Transactions: types.Transactions{dynamic}, Withdrawals: withdrawals},
+			want:   &types.Body{Transactions: types.Transactions{dynamic}, Withdrawals: withdrawals},
+		},
+		// Post-shanghai body stored without withdrawals
+		{
+			header: shanghai,
+			body:   &types.Body{Transactions: types.Transactions{legacy}},
+			want:   &types.Body{Transactions: types.Transactions{legacy}, Withdrawals: []*types.Withdrawal{}},
+		},
+	}
+	for i, test := range tests {
+		data, err := rlp.EncodeToBytes(test.body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, err := splitBody(test.header, data)
+		if err != nil {
+			t.Fatalf("test %d: failed to split body: %v", i, err)
+		}
+		if err := checkEqualBody(test.want, body); err != nil {
+			t.Fatalf("test %d: invalid body: %v", i, err)
+		}
+	}
+	if _, err := splitBody(preShanghai, []byte{0xc2, 0xc0}); err == nil {
+		t.Fatal("truncated body split without error")
+	}
+}
+
 func checkEqualBody(a *types.Body, b *engine.ExecutionPayloadBody) error {
 	if a == nil && b == nil {
 		return nil
@@ -34,4 +34,11 @@ var (
 
 	// Number of times getBlobsV3 responded with some, but not all, blobs
 	getBlobsRequestPartialHit = metrics.NewRegisteredCounter("engine/getblobs/partial", nil)
+
+	// Rate of forkchoice updates requesting a payload build
+	payloadAttributesMeter = metrics.NewRegisteredMeter("engine/payload/attributes", nil)
+
+	// Rate of payload build requests with attributes identical to a build
+	// already in progress, which are served from the existing build
+	payloadDuplicateMeter = metrics.NewRegisteredMeter("engine/payload/duplicate", nil)
 )
@@ -0,0 +1,131 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package payloadattr constructs engine API payload attributes which are valid
+// for the fork active at their timestamp.
+//
+// The rules enforced here match the checks of engine_forkchoiceUpdated, so that
+// alternative consensus drivers and test harnesses can catch invalid attributes
+// before sending them.
+package payloadattr
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/beacon/engine"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/params/forks"
+)
+
+var (
+	errUnexpectedWithdrawals = errors.New("withdrawals before shanghai")
+	errMissingWithdrawals    = errors.New("missing withdrawals")
+	errUnexpectedBeaconRoot  = errors.New("beacon root before cancun")
+	errMissingBeaconRoot     = errors.New("missing beacon root")
+)
+
+// Version returns the payload attributes version required for a payload built
+// on top of the given timestamp. The version also selects the method used to
+// submit the attributes, e.g. engine_forkchoiceUpdatedV3 for PayloadV3.
+func Version(config *params.ChainConfig, timestamp uint64) (engine.PayloadVersion, error) {
+	switch fork := config.LatestFork(timestamp); fork {
+	case forks.Paris:
+		return engine.PayloadV1, nil
+	case forks.Shanghai:
+		return engine.PayloadV2, nil
+	case forks.Cancun, forks.Prague, forks.Osaka, forks.BPO1, forks.BPO2, forks.BPO3, forks.BPO4, forks.BPO5:
+		return engine.PayloadV3, nil
+	default:
+		return 0, fmt.Errorf("payload attributes not supported for fork %v", fork)
+	}
+}
+
+// Validate checks the attributes against the rules of the fork active at their
+// timestamp and returns the version to submit them with.
+func Validate(config *params.ChainConfig, attr *engine.PayloadAttributes) (engine.PayloadVersion, error) {
+	version, err := Version(config, attr.Timestamp)
+	if err != nil {
+		return 0, err
+	}
+	switch {
+	case version == engine.PayloadV1 && attr.Withdrawals != nil:
+		return 0, errUnexpectedWithdrawals
+	case version >= engine.PayloadV2 && attr.Withdrawals == nil:
+		return 0, errMissingWithdrawals
+	case version < engine.PayloadV3 && attr.BeaconRoot != nil:
+		return 0, errUnexpectedBeaconRoot
+	case version >= engine.PayloadV3 && attr.BeaconRoot == nil:
+		return 0, errMissingBeaconRoot
+	}
+	return version, nil
+}
+
+// Builder assembles payload attributes for a given chain configuration.
+type Builder struct {
+	config *params.ChainConfig
+	attr   engine.PayloadAttributes
+}
+
+// New creates a builder for attributes of a payload with the given timestamp.
+func New(config *params.ChainConfig, timestamp uint64) *Builder {
+	return &Builder{
+		config: config,
+		attr:   engine.PayloadAttributes{Timestamp: timestamp},
+	}
+}
+
+// Random sets the prevRandao value of the payload.
+func (b *Builder) Random(random common.Hash) *Builder {
+	b.attr.Random = random
+	return b
+}
+
+// FeeRecipient sets the suggested fee recipient of the payload.
+func (b *Builder) FeeRecipient(addr common.Address) *Builder {
+	b.attr.SuggestedFeeRecipient = addr
+	return b
+}
+
+// Withdrawals sets the withdrawals to include in the payload. From Shanghai on,
+// attributes without withdrawals carry an empty list.
+func (b *Builder) Withdrawals(withdrawals []*types.Withdrawal) *Builder {
+	b.attr.Withdrawals = withdrawals
+	return b
+}
+
+// BeaconRoot sets the parent beacon block root, which is required from Cancun on.
+func (b *Builder) BeaconRoot(root common.Hash) *Builder {
+	b.attr.BeaconRoot = &root
+	return b
+}
+
+// Build validates the attributes and returns them along with the version to
+// submit them with. Fields which are mandatory for the fork but have an obvious
+// default, like an empty withdrawal list, are filled in.
+func (b *Builder) Build() (*engine.PayloadAttributes, engine.PayloadVersion, error) {
+	attr := b.attr
+	if version, err := Version(b.config, attr.Timestamp); err == nil && version >= engine.PayloadV2 && attr.Withdrawals == nil {
+		attr.Withdrawals = make([]*types.Withdrawal, 0)
+	}
+	version, err := Validate(b.config, &attr)
+	if err != nil {
+		return nil, 0, err
+	}
+	return &attr, version, nil
+}
@@ -0,0 +1,83 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package payloadattr
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/beacon/engine"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func testConfig() *params.ChainConfig {
+	var (
+		config   = *params.MergedTestChainConfig
+		shanghai = uint64(10)
+		cancun   = uint64(20)
+		amst     = uint64(30)
+	)
+	config.ShanghaiTime = &shanghai
+	config.CancunTime = &cancun
+	config.PragueTime = nil
+	config.OsakaTime = nil
+	config.BPO1Time, config.BPO2Time, config.BPO3Time, config.BPO4Time, config.BPO5Time = nil, nil, nil, nil, nil
+	config.AmsterdamTime = &amst
+	return &config
+}
+
+func TestBuild(t *testing.T) {
+	config := testConfig()
+	root := common.Hash{0x01}
+
+	tests := []struct {
+		name    string
+		builder *Builder
+		version engine.PayloadVersion
+		err     error
+	}{
+		{name: "paris", builder: New(config, 5), version: engine.PayloadV1},
+		{name: "paris withdrawals", builder: New(config, 5).Withdrawals([]*types.Withdrawal{}), err: errUnexpectedWithdrawals},
+		{name: "paris beacon root", builder: New(config, 5).BeaconRoot(root), err: errUnexpectedBeaconRoot},
+		{name: "shanghai", builder: New(config, 10), version: engine.PayloadV2},
+		{name: "shanghai beacon root", builder: New(config, 15).BeaconRoot(root), err: errUnexpectedBeaconRoot},
+		{name: "cancun", builder: New(config, 20).BeaconRoot(root), version: engine.PayloadV3},
+		{name: "cancun no beacon root", builder: New(config, 25), err: errMissingBeaconRoot},
+	}
+	for _, test := range tests {
+		attr, version, err := test.builder.Build()
+		if !errors.Is(err, test.err) {
+			t.Errorf("%s: wrong error: have %v, want %v", test.name, err, test.err)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if version != test.version {
+			t.Errorf("%s: wrong version: have %d, want %d", test.name, version, test.version)
+		}
+		if haveWithdrawals := attr.Withdrawals != nil; haveWithdrawals != (version >= engine.PayloadV2) {
+			t.Errorf("%s: wrong withdrawals presence: %v", test.name, haveWithdrawals)
+		}
+	}
+	// Forks without forkchoiceUpdated support are rejected.
+	if _, _, err := New(config, 30).BeaconRoot(root).Build(); err == nil {
+		t.Error("expected error for unsupported fork")
+	}
+}
@@ -23,6 +23,7 @@ import (
 	"fmt"
 	"math"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/ethereum/go-ethereum/beacon/engine"
@@ -96,6 +97,7 @@ type SimulatedBeacon struct {
 	engineAPI          *ConsensusAPI
 	curForkchoiceState engine.ForkchoiceStateV1
 	lastBlockTime      uint64
+	timeOffset         atomic.Uint64 // seconds added to the wall clock, see WarpTime
 }
 
 func payloadVersion(config *params.ChainConfig, time uint64) engine.PayloadVersion {
@@ -278,7 +280,7 @@ func (c *SimulatedBeacon) loop() {
 		case <-c.shutdownCh:
 			return
 		case <-timer.C:
-			if err := c.sealBlock(c.withdrawals.pop(10), uint64(time.Now().Unix())); err != nil {
+			if err := c.sealBlock(c.withdrawals.pop(10), c.now()); err != nil {
 				log.Warn("Error performing sealing work", "err", err)
 			}
 			timer.Reset(time.Second * time.Duration(c.period))
@@ -314,7 +316,7 @@ func (c *SimulatedBeacon) setCurrentState(headHash, finalizedHash common.Hash) {
 // Commit seals a block on demand.
 func (c *SimulatedBeacon) Commit() common.Hash {
 	withdrawals := c.withdrawals.pop(10)
-	if err := c.sealBlock(withdrawals, uint64(time.Now().Unix())); err != nil {
+	if err := c.sealBlock(withdrawals, c.now()); err != nil {
 		log.Warn("Error performing sealing work", "err", err)
 	}
 	return c.eth.BlockChain().CurrentBlock().Hash()
@@ -354,6 +356,21 @@ func (c *SimulatedBeacon) AdjustTime(adjustment time.Duration) error {
 	return c.sealBlock(withdrawals, parent.Time+uint64(adjustment/time.Second))
 }
 
+// now returns the timestamp to use for new blocks: the wall clock shifted by all
+// previous time warps.
+func (c *SimulatedBeacon) now() uint64 {
+	return uint64(time.Now().Unix()) + c.timeOffset.Load()
+}
+
+// WarpTime permanently moves the clock of the simulated chain forward by the given
+// number of seconds and returns the resulting time. Unlike AdjustTime, it doesn't
+// create a block, but all subsequently sealed blocks use the shifted clock. This
+// allows testing timestamp-gated logic such as fork activations.
+func (c *SimulatedBeacon) WarpTime(delta uint64) uint64 {
+	c.timeOffset.Add(delta)
+	return c.now()
+}
+
 // RegisterSimulatedBeaconAPIs registers the simulated beacon's API with the
 // stack.
 func RegisterSimulatedBeaconAPIs(stack *node.Node, sim *SimulatedBeacon) {
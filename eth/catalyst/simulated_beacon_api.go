@@ -20,6 +20,7 @@ import (
 	"context"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/types"
 )
@@ -108,3 +109,9 @@ func (a *simulatedBeaconAPI) AddWithdrawal(ctx context.Context, withdrawal *type
 func (a *simulatedBeaconAPI) SetFeeRecipient(ctx context.Context, feeRecipient common.Address) {
 	a.sim.setFeeRecipient(feeRecipient)
 }
+
+// WarpTime moves the clock of the dev chain forward by the given number of seconds
+// and returns the new timestamp which will be used for the next block.
+func (a *simulatedBeaconAPI) WarpTime(ctx context.Context, delta hexutil.Uint64) hexutil.Uint64 {
+	return hexutil.Uint64(a.sim.WarpTime(uint64(delta)))
+}
@@ -208,3 +208,27 @@ func TestOnDemandSpam(t *testing.T) {
 		}
 	}
 }
+
+// Tests that warping the time of the simulated chain shifts the timestamps of
+// all subsequently sealed blocks.
+func TestSimulatedBeaconWarpTime(t *testing.T) {
+	var (
+		testKey, _ = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		testAddr   = crypto.PubkeyToAddress(testKey.PublicKey)
+		genesis    = core.DeveloperGenesisBlock(10_000_000, &testAddr)
+	)
+	node, ethService, mock := startSimulatedBeaconEthService(t, genesis, 0)
+	defer node.Close()
+
+	const delta = 3600
+	start := uint64(time.Now().Unix())
+	if warped := mock.WarpTime(delta); warped < start+delta {
+		t.Fatalf("warped time too low: have %d, want >= %d", warped, start+delta)
+	}
+	for i := 0; i < 2; i++ {
+		mock.Commit()
+		if head := ethService.BlockChain().CurrentBlock(); head.Time < start+delta {
+			t.Fatalf("block %d: timestamp not warped: have %d, want >= %d", head.Number, head.Time, start+delta)
+		}
+	}
+}
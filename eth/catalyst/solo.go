@@ -0,0 +1,177 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package catalyst
+
+import (
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/eth"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/node"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// publicNetworks are the built-in networks, the validators of which finalize
+// the chain. A node producing blocks locally on them finalizes its own chain and
+// can't follow the canonical one anymore.
+var publicNetworks = map[common.Hash]string{
+	params.MainnetGenesisHash: "mainnet",
+	params.SepoliaGenesisHash: "sepolia",
+	params.HoleskyGenesisHash: "holesky",
+	params.HoodiGenesisHash:   "hoodi",
+}
+
+// checkSoloNetwork returns an error if the chain with the given genesis is one
+// of the built-in networks.
+func checkSoloNetwork(genesis common.Hash) error {
+	if name, ok := publicNetworks[genesis]; ok {
+		return fmt.Errorf("solo sequencer is not allowed on %s, it requires a custom genesis", name)
+	}
+	return nil
+}
+
+// SoloConfig configures local block production while no consensus client is
+// driving the engine API.
+type SoloConfig struct {
+	Timeout      time.Duration  // Time without consensus updates before producing blocks locally
+	Period       uint64         // Seconds between locally produced blocks
+	FeeRecipient common.Address // Fee recipient of locally produced blocks
+}
+
+// SoloSequencer serves the engine API to a consensus client, and takes over
+// block production with synthetic payload attributes whenever the client has
+// not sent any forkchoice update or payload for a while. Once the client comes
+// back, local production stops and the client is followed again.
+//
+// Locally produced blocks are built the same way as in developer mode: every
+// block is marked safe and every epoch boundary finalized. It's meant for
+// devnets and for keeping a chain alive while its consensus client is being
+// recovered, not for networks with independent validators.
+type SoloSequencer struct {
+	api     *ConsensusAPI    // Engine API served to the consensus client, watched for activity
+	sim     *SimulatedBeacon // Local block producer used while the client is absent
+	timeout time.Duration
+	period  time.Duration
+	started time.Time
+
+	active     atomic.Bool
+	shutdownCh chan struct{}
+}
+
+// NewSoloSequencer creates a solo sequencer watching the given engine API.
+func NewSoloSequencer(api *ConsensusAPI, eth *eth.Ethereum, config SoloConfig) (*SoloSequencer, error) {
+	if config.Timeout <= 0 {
+		return nil, errors.New("solo sequencer timeout must be positive")
+	}
+	if config.Period == 0 {
+		return nil, errors.New("solo sequencer period must be positive")
+	}
+	if err := checkSoloNetwork(eth.BlockChain().Genesis().Hash()); err != nil {
+		return nil, err
+	}
+	sim, err := NewSimulatedBeacon(config.Period, config.FeeRecipient, eth)
+	if err != nil {
+		return nil, err
+	}
+	return &SoloSequencer{
+		api:        api,
+		sim:        sim,
+		timeout:    config.Timeout,
+		period:     time.Duration(sim.period) * time.Second,
+		shutdownCh: make(chan struct{}),
+	}, nil
+}
+
+// Start implements node.Lifecycle, starting to watch the consensus client.
+func (s *SoloSequencer) Start() error {
+	s.started = time.Now()
+	go s.loop()
+	return nil
+}
+
+// Stop implements node.Lifecycle, terminating local block production.
+func (s *SoloSequencer) Stop() error {
+	close(s.shutdownCh)
+	return nil
+}
+
+// Active reports whether blocks are currently produced locally.
+func (s *SoloSequencer) Active() bool {
+	return s.active.Load()
+}
+
+// lastUpdate returns the time the consensus client was last seen, or the
+// startup time if it was never seen.
+func (s *SoloSequencer) lastUpdate() time.Time {
+	last := max(s.api.lastForkchoiceUpdate.Load(), s.api.lastNewPayloadUpdate.Load())
+	if last == 0 {
+		return s.started
+	}
+	return time.Unix(last, 0)
+}
+
+// loop checks for consensus client activity every block period, and seals a
+// block whenever the client has been absent for longer than the timeout.
+func (s *SoloSequencer) loop() {
+	ticker := time.NewTicker(s.period)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.shutdownCh:
+			return
+		case <-ticker.C:
+			last := s.lastUpdate()
+			if time.Since(last) < s.timeout {
+				if s.active.CompareAndSwap(true, false) {
+					log.Info("Consensus client is back, stopping local block production")
+				}
+				continue
+			}
+			if s.active.CompareAndSwap(false, true) {
+				log.Warn("No consensus client updates, producing blocks locally", "last", common.PrettyAge(last), "period", s.period)
+			}
+			if err := s.sim.sealBlock(s.sim.withdrawals.pop(10), s.sim.now()); err != nil {
+				log.Warn("Error performing local sealing work", "err", err)
+			}
+		}
+	}
+}
+
+// RegisterSolo adds the engine API to the full node like Register, along with a
+// solo sequencer producing blocks whenever the consensus client is absent.
+func RegisterSolo(stack *node.Node, backend *eth.Ethereum, config SoloConfig) error {
+	api := NewConsensusAPI(backend)
+	solo, err := NewSoloSequencer(api, backend, config)
+	if err != nil {
+		return err
+	}
+	stack.RegisterAPIs([]rpc.API{
+		{
+			Namespace:     "engine",
+			Service:       api,
+			Authenticated: true,
+		},
+	})
+	stack.RegisterLifecycle(solo)
+	return nil
+}
@@ -0,0 +1,104 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package catalyst
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/eth"
+	"github.com/ethereum/go-ethereum/eth/ethconfig"
+	"github.com/ethereum/go-ethereum/miner"
+	"github.com/ethereum/go-ethereum/node"
+	"github.com/ethereum/go-ethereum/p2p"
+)
+
+// Tests that the solo sequencer produces blocks while no consensus client
+// updates arrive, and stops once they do.
+func TestSoloSequencer(t *testing.T) {
+	n, err := node.New(&node.Config{
+		P2P: p2p.Config{
+			ListenAddr:  "127.0.0.1:0",
+			NoDiscovery: true,
+			MaxPeers:    0,
+		},
+	})
+	if err != nil {
+		t.Fatal("can't create node:", err)
+	}
+	defer n.Close()
+
+	genesis := core.DeveloperGenesisBlock(10_000_000, &common.Address{})
+	ethcfg := &ethconfig.Config{Genesis: genesis, SyncMode: ethconfig.FullSync, TrieTimeout: time.Minute, TrieDirtyCache: 256, TrieCleanCache: 256, Miner: miner.DefaultConfig}
+	ethservice, err := eth.New(n, ethcfg)
+	if err != nil {
+		t.Fatal("can't create eth service:", err)
+	}
+	api := newConsensusAPIWithoutHeartbeat(ethservice)
+	solo, err := NewSoloSequencer(api, ethservice, SoloConfig{Timeout: 2 * time.Second, Period: 1})
+	if err != nil {
+		t.Fatal("can't create solo sequencer:", err)
+	}
+	n.RegisterLifecycle(solo)
+	if err := n.Start(); err != nil {
+		t.Fatal("can't start node:", err)
+	}
+	ethservice.SetSynced()
+
+	chainHeadCh := make(chan core.ChainHeadEvent, 10)
+	sub := ethservice.BlockChain().SubscribeChainHeadEvent(chainHeadCh)
+	defer sub.Unsubscribe()
+
+	select {
+	case <-chainHeadCh:
+	case <-time.After(10 * time.Second):
+		t.Fatal("no block produced without consensus client")
+	}
+	if !solo.Active() {
+		t.Fatal("solo sequencer not reported active while producing blocks")
+	}
+	// Simulate a consensus client coming back online
+	api.lastForkchoiceUpdate.Store(time.Now().Add(time.Minute).Unix())
+
+	deadline := time.Now().Add(5 * time.Second)
+	for solo.Active() {
+		if time.Now().After(deadline) {
+			t.Fatal("solo sequencer still active with consensus client online")
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	head := ethservice.BlockChain().CurrentBlock().Number.Uint64()
+	time.Sleep(2 * time.Second)
+	if have := ethservice.BlockChain().CurrentBlock().Number.Uint64(); have != head {
+		t.Fatalf("blocks produced with consensus client online: head %d -> %d", head, have)
+	}
+}
+
+// Tests that the solo sequencer refuses to run on the built-in networks.
+func TestSoloNetworks(t *testing.T) {
+	for hash, name := range publicNetworks {
+		if err := checkSoloNetwork(hash); err == nil {
+			t.Errorf("%s: solo sequencer allowed", name)
+		}
+	}
+	genesis := core.DeveloperGenesisBlock(10_000_000, &common.Address{})
+	if err := checkSoloNetwork(genesis.ToBlock().Hash()); err != nil {
+		t.Errorf("custom network: %v", err)
+	}
+}
@@ -0,0 +1,150 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package catalyst
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/metrics"
+)
+
+// maxTrackedForkchoices is the number of recent forkchoice updates retained for
+// introspection via rollup_syncStatus.
+const maxTrackedForkchoices = 64
+
+var (
+	// Number of blocks the safe and finalized labels trail the unsafe head
+	safeLagGauge      = metrics.NewRegisteredGauge("rollup/safe/lag", nil)
+	finalizedLagGauge = metrics.NewRegisteredGauge("rollup/finalized/lag", nil)
+
+	// Unix time of the last forkchoice update which moved any of the labels
+	forkchoiceTimeGauge = metrics.NewRegisteredGauge("rollup/forkchoice/time", nil)
+)
+
+// BlockLabel describes the block a head label points to.
+type BlockLabel struct {
+	Number    hexutil.Uint64 `json:"number"`
+	Hash      common.Hash    `json:"hash"`
+	Timestamp hexutil.Uint64 `json:"timestamp"`
+	Lag       hexutil.Uint64 `json:"lag"` // Blocks behind the unsafe head
+}
+
+// ForkchoiceRecord is a forkchoice update as applied to the local chain.
+type ForkchoiceRecord struct {
+	Time      hexutil.Uint64 `json:"time"`
+	Unsafe    *BlockLabel    `json:"unsafe"`
+	Safe      *BlockLabel    `json:"safe"`
+	Finalized *BlockLabel    `json:"finalized"`
+}
+
+// SyncStatus is the result of rollup_syncStatus.
+type SyncStatus struct {
+	ForkchoiceRecord
+	History []*ForkchoiceRecord `json:"history"` // Most recent update first
+}
+
+// syncTracker keeps a short history of the head labels set via forkchoice
+// updates.
+type syncTracker struct {
+	history []*ForkchoiceRecord
+	lock    sync.RWMutex
+}
+
+// record stores the labels resulting from a forkchoice update if any of them
+// changed since the previous one.
+func (t *syncTracker) record(unsafe, safe, finalized *types.Header) {
+	rec := &ForkchoiceRecord{
+		Time:      hexutil.Uint64(time.Now().Unix()),
+		Unsafe:    newBlockLabel(unsafe, unsafe),
+		Safe:      newBlockLabel(safe, unsafe),
+		Finalized: newBlockLabel(finalized, unsafe),
+	}
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if len(t.history) > 0 && sameLabels(t.history[0], rec) {
+		return
+	}
+	if len(t.history) < maxTrackedForkchoices {
+		t.history = append(t.history, nil)
+	}
+	copy(t.history[1:], t.history)
+	t.history[0] = rec
+
+	forkchoiceTimeGauge.Update(int64(rec.Time))
+	if rec.Safe != nil {
+		safeLagGauge.Update(int64(rec.Safe.Lag))
+	}
+	if rec.Finalized != nil {
+		finalizedLagGauge.Update(int64(rec.Finalized.Lag))
+	}
+}
+
+// status returns the latest labels along with the recent history.
+func (t *syncTracker) status() *SyncStatus {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	status := &SyncStatus{History: make([]*ForkchoiceRecord, len(t.history))}
+	copy(status.History, t.history)
+	if len(t.history) > 0 {
+		status.ForkchoiceRecord = *t.history[0]
+	}
+	return status
+}
+
+func newBlockLabel(header, head *types.Header) *BlockLabel {
+	if header == nil {
+		return nil
+	}
+	label := &BlockLabel{
+		Number:    hexutil.Uint64(header.Number.Uint64()),
+		Hash:      header.Hash(),
+		Timestamp: hexutil.Uint64(header.Time),
+	}
+	if head != nil && head.Number.Uint64() > header.Number.Uint64() {
+		label.Lag = hexutil.Uint64(head.Number.Uint64() - header.Number.Uint64())
+	}
+	return label
+}
+
+func sameLabels(a, b *ForkchoiceRecord) bool {
+	same := func(x, y *BlockLabel) bool {
+		if x == nil || y == nil {
+			return x == y
+		}
+		return x.Hash == y.Hash
+	}
+	return same(a.Unsafe, b.Unsafe) && same(a.Safe, b.Safe) && same(a.Finalized, b.Finalized)
+}
+
+// RollupAPI exposes the head labels set by the consensus client, allowing
+// operators to detect stalls of the safe and finalized heads from the execution
+// layer side.
+type RollupAPI struct {
+	api *ConsensusAPI
+}
+
+// SyncStatus returns the unsafe, safe and finalized heads as last set via
+// forkchoice updates, together with a history of recent updates.
+func (r *RollupAPI) SyncStatus() *SyncStatus {
+	return r.api.syncStatus.status()
+}
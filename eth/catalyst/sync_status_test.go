@@ -0,0 +1,54 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package catalyst
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func TestSyncTracker(t *testing.T) {
+	header := func(n int64) *types.Header {
+		return &types.Header{Number: big.NewInt(n), Time: uint64(n) * 12}
+	}
+	var tracker syncTracker
+	if status := tracker.status(); status.Unsafe != nil || len(status.History) != 0 {
+		t.Fatalf("unexpected status before any update: %+v", status)
+	}
+	tracker.record(header(10), header(8), header(4))
+	tracker.record(header(10), header(8), header(4)) // duplicate, ignored
+	tracker.record(header(11), header(8), nil)
+
+	status := tracker.status()
+	if len(status.History) != 2 {
+		t.Fatalf("wrong history length: have %d, want 2", len(status.History))
+	}
+	if status.Unsafe.Number != 11 || status.Safe.Lag != 3 || status.Finalized != nil {
+		t.Fatalf("wrong latest labels: %+v", status.ForkchoiceRecord)
+	}
+	if prev := status.History[1]; prev.Unsafe.Number != 10 || prev.Finalized.Lag != 6 || prev.Finalized.Timestamp != 48 {
+		t.Fatalf("wrong previous labels: %+v", prev)
+	}
+	for i := 0; i < 2*maxTrackedForkchoices; i++ {
+		tracker.record(header(int64(12+i)), nil, nil)
+	}
+	if have := len(tracker.status().History); have != maxTrackedForkchoices {
+		t.Fatalf("history not bounded: have %d, want %d", have, maxTrackedForkchoices)
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package catalyst
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/ethereum/go-ethereum/trie"
+)
+
+// WithdrawalsRoot is the result of rollup_withdrawalsRoot.
+type WithdrawalsRoot struct {
+	Number      hexutil.Uint64 `json:"number"`
+	Hash        common.Hash    `json:"hash"`
+	Withdrawals hexutil.Uint64 `json:"withdrawals"`     // Number of withdrawals in the block body
+	Computed    *common.Hash   `json:"computed"`        // Root derived from the block body, nil if it has no withdrawals list
+	Header      *common.Hash   `json:"header"`          // Root committed to in the header, nil before Shanghai
+	Valid       bool           `json:"valid"`           // Whether the body matches the header
+	Error       string         `json:"error,omitempty"` // Reason of the mismatch
+}
+
+// WithdrawalsRoot derives the withdrawals root of the given block from its body
+// and validates it against the root committed to in the header, allowing
+// bridges and verifiers to cross-check roots without reimplementing the trie.
+func (r *RollupAPI) WithdrawalsRoot(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) (*WithdrawalsRoot, error) {
+	block, err := r.api.eth.APIBackend.BlockByNumberOrHash(ctx, blockNrOrHash)
+	if block == nil || err != nil {
+		return nil, err
+	}
+	var (
+		header      = block.Header()
+		withdrawals = block.Withdrawals()
+	)
+	res := &WithdrawalsRoot{
+		Number:      hexutil.Uint64(block.NumberU64()),
+		Hash:        block.Hash(),
+		Withdrawals: hexutil.Uint64(len(withdrawals)),
+		Header:      header.WithdrawalsHash,
+		Valid:       true,
+	}
+	if withdrawals != nil {
+		root := types.DeriveSha(withdrawals, trie.NewStackTrie(nil))
+		res.Computed = &root
+	}
+	if err := core.ValidateWithdrawals(header, withdrawals); err != nil {
+		res.Valid, res.Error = false, err.Error()
+	}
+	return res, nil
+}
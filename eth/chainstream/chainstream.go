@@ -0,0 +1,340 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package chainstream serves canonical chain data to heavy consumers, such as
+// indexers and analytics pipelines, as gRPC server streams.
+//
+// The service is defined in chainstream.proto and speaks gRPC over cleartext
+// HTTP/2. Streams are flow controlled by HTTP/2: blocks are only read from the
+// database as fast as the consumer receives them, so a slow consumer neither
+// holds the chain up nor accumulates messages in memory.
+package chainstream
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/eth"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/ethereum/go-ethereum/node"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+)
+
+const (
+	// DefaultPort is the default port of the chain stream service.
+	DefaultPort = 8552
+
+	// servicePath is the path prefix of the methods of the gRPC service.
+	servicePath = "/geth.chainstream.v1.ChainStream/"
+
+	// maxRequestSize is the maximum size of a request message.
+	maxRequestSize = 1024
+
+	// recentHashes is the number of streamed block hashes remembered to find
+	// the fork point after a reorg.
+	recentHashes = 128
+
+	// shutdownTimeout is the time allowed for streams to end on shutdown.
+	shutdownTimeout = 5 * time.Second
+)
+
+// gRPC status codes.
+const (
+	codeOK              = 0
+	codeCanceled        = 1
+	codeInvalidArgument = 3
+	codeUnimplemented   = 12
+	codeInternal        = 13
+	codeUnavailable     = 14
+)
+
+var (
+	streamsGauge  = metrics.NewRegisteredGauge("chainstream/streams", nil)
+	messagesMeter = metrics.NewRegisteredMeter("chainstream/messages", nil)
+)
+
+// Chain is the subset of the blockchain served by the streams.
+type Chain interface {
+	CurrentBlock() *types.Header
+	GetBlockByNumber(number uint64) *types.Block
+	GetCanonicalHash(number uint64) common.Hash
+	GetReceiptsByHash(hash common.Hash) types.Receipts
+	SubscribeChainHeadEvent(ch chan<- core.ChainHeadEvent) event.Subscription
+}
+
+// status is the outcome of a gRPC call.
+type status struct {
+	code    int
+	message string
+}
+
+// encoder creates the stream message of a block.
+type encoder func(ctx context.Context, block *types.Block) ([]byte, error)
+
+// StateDiffFunc computes the state changes made by a block.
+type StateDiffFunc func(ctx context.Context, block *types.Block) (*eth.BlockStateDiff, error)
+
+// Service serves the chain data streams.
+type Service struct {
+	chain   Chain
+	addr    string
+	methods map[string]encoder
+
+	server   *http.Server
+	listener net.Listener
+	closed   chan struct{}
+}
+
+// New creates the chain stream service on top of the given chain, listening on
+// the given address once started. State diffs are only served if a function
+// computing them is given.
+func New(chain Chain, stateDiff StateDiffFunc, addr string) *Service {
+	s := &Service{
+		chain:  chain,
+		addr:   addr,
+		closed: make(chan struct{}),
+	}
+	s.methods = map[string]encoder{
+		servicePath + "Blocks": func(ctx context.Context, block *types.Block) ([]byte, error) {
+			return encodeBlock(block)
+		},
+		servicePath + "Receipts": func(ctx context.Context, block *types.Block) ([]byte, error) {
+			return encodeBlockReceipts(block.Header(), s.chain.GetReceiptsByHash(block.Hash()))
+		},
+	}
+	if stateDiff != nil {
+		s.methods[servicePath+"StateDiffs"] = func(ctx context.Context, block *types.Block) ([]byte, error) {
+			diff, err := stateDiff(ctx, block)
+			if err != nil {
+				return nil, err
+			}
+			return encodeStateDiff(block.Header(), diff), nil
+		}
+	}
+	return s
+}
+
+// Register registers the chain stream service into the node stack.
+func Register(stack *node.Node, backend *eth.Ethereum, addr string) *Service {
+	s := New(backend.BlockChain(), backend.StateDiff, addr)
+	stack.RegisterLifecycle(s)
+	return s
+}
+
+// Start implements node.Lifecycle, starting to accept streams.
+func (s *Service) Start() error {
+	listener, err := net.Listen("tcp", s.addr)
+	if err != nil {
+		return err
+	}
+	s.listener = listener
+	s.server = &http.Server{Handler: h2c.NewHandler(s, &http2.Server{})}
+	go s.server.Serve(listener)
+
+	log.Info("Chain stream service started", "endpoint", listener.Addr())
+	return nil
+}
+
+// Stop implements node.Lifecycle, ending all streams.
+func (s *Service) Stop() error {
+	close(s.closed)
+	if s.server == nil {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := s.server.Shutdown(ctx); err != nil {
+		s.server.Close()
+	}
+	log.Info("Chain stream service stopped", "endpoint", s.listener.Addr())
+	return nil
+}
+
+// ServeHTTP serves a gRPC call.
+func (s *Service) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost || r.ProtoMajor != 2 || !strings.HasPrefix(r.Header.Get("Content-Type"), "application/grpc") {
+		http.Error(w, "only gRPC requests are supported", http.StatusUnsupportedMediaType)
+		return
+	}
+	w.Header().Set("Content-Type", "application/grpc")
+	w.Header().Set("Grpc-Accept-Encoding", "identity")
+
+	st := s.serve(w, r)
+	w.Header().Set(http.TrailerPrefix+"Grpc-Status", strconv.Itoa(st.code))
+	if st.message != "" {
+		w.Header().Set(http.TrailerPrefix+"Grpc-Message", url.PathEscape(st.message))
+	}
+}
+
+func (s *Service) serve(w http.ResponseWriter, r *http.Request) status {
+	encode, ok := s.methods[r.URL.Path]
+	if !ok {
+		return status{codeUnimplemented, fmt.Sprintf("unknown method %s", r.URL.Path)}
+	}
+	msg, err := readMessage(r.Body)
+	if err != nil {
+		return status{codeInvalidArgument, err.Error()}
+	}
+	req, err := decodeStreamRequest(msg)
+	if err != nil {
+		return status{codeInvalidArgument, err.Error()}
+	}
+	// Headers need to go out before the first message, as the stream may
+	// have to wait for new blocks.
+	w.WriteHeader(http.StatusOK)
+	if err := http.NewResponseController(w).Flush(); err != nil {
+		return status{codeInternal, err.Error()}
+	}
+	return s.stream(r.Context(), w, req, encode)
+}
+
+// stream sends the requested blocks to the consumer, encoded by the given
+// method.
+func (s *Service) stream(ctx context.Context, w http.ResponseWriter, req *StreamRequest, encode encoder) status {
+	streamsGauge.Inc(1)
+	defer streamsGauge.Dec(1)
+
+	// Chain head events only wake the stream up. They are forwarded without
+	// blocking, a slow consumer must not hold up block import.
+	var (
+		heads  = make(chan core.ChainHeadEvent, 16)
+		wakeup = make(chan struct{}, 1)
+		sub    = s.chain.SubscribeChainHeadEvent(heads)
+	)
+	defer func() {
+		sub.Unsubscribe()
+		close(heads)
+	}()
+	go func() {
+		for range heads {
+			select {
+			case wakeup <- struct{}{}:
+			default:
+			}
+		}
+	}()
+
+	var (
+		number  = req.Start
+		last    = req.End
+		bounded = req.End != 0 || !req.Follow
+		recent  = make(map[uint64]common.Hash)
+	)
+	if last == 0 && bounded {
+		last = s.chain.CurrentBlock().Number.Uint64()
+	}
+	for !bounded || number <= last {
+		block := s.chain.GetBlockByNumber(number)
+		if block == nil {
+			select {
+			case <-wakeup:
+				continue
+			case err := <-sub.Err():
+				return status{codeUnavailable, fmt.Sprintf("chain subscription failed: %v", err)}
+			case <-ctx.Done():
+				return status{codeCanceled, ctx.Err().Error()}
+			case <-s.closed:
+				return status{codeUnavailable, "server shutting down"}
+			}
+		}
+		// If the chain reorganised below the cursor, continue from the fork
+		// point. Consumers notice from the parent hash.
+		if prev, ok := recent[number-1]; ok && block.ParentHash() != prev {
+			number = s.forkPoint(recent, number-1)
+			continue
+		}
+		msg, err := encode(ctx, block)
+		if err != nil {
+			return status{codeInternal, err.Error()}
+		}
+		if err := writeMessage(w, msg); err != nil {
+			return status{codeCanceled, err.Error()}
+		}
+		messagesMeter.Mark(1)
+
+		recent[number] = block.Hash()
+		delete(recent, number-recentHashes)
+		number++
+	}
+	return status{code: codeOK}
+}
+
+// forkPoint returns the first block after the last streamed block which is
+// still canonical. If the reorg is deeper than the remembered hashes, the
+// oldest remembered block is returned.
+func (s *Service) forkPoint(recent map[uint64]common.Hash, number uint64) uint64 {
+	for {
+		hash, ok := recent[number]
+		if !ok || s.chain.GetCanonicalHash(number) == hash {
+			return number + 1
+		}
+		delete(recent, number)
+		if number == 0 {
+			return 0
+		}
+		number--
+	}
+}
+
+// readMessage reads a length-prefixed gRPC message.
+func readMessage(r io.Reader) ([]byte, error) {
+	var prefix [5]byte
+	if _, err := io.ReadFull(r, prefix[:]); err != nil {
+		return nil, fmt.Errorf("failed to read request: %v", err)
+	}
+	if prefix[0] != 0 {
+		return nil, errors.New("compressed requests are not supported")
+	}
+	size := binary.BigEndian.Uint32(prefix[1:])
+	if size > maxRequestSize {
+		return nil, fmt.Errorf("request too large: %d bytes", size)
+	}
+	msg := make([]byte, size)
+	if _, err := io.ReadFull(r, msg); err != nil {
+		return nil, fmt.Errorf("failed to read request: %v", err)
+	}
+	return msg, nil
+}
+
+// writeMessage writes a length-prefixed gRPC message and flushes it to the
+// consumer. It blocks while the HTTP/2 flow control window of the stream is
+// exhausted.
+func writeMessage(w http.ResponseWriter, msg []byte) error {
+	var prefix [5]byte
+	binary.BigEndian.PutUint32(prefix[1:], uint32(len(msg)))
+	if _, err := w.Write(prefix[:]); err != nil {
+		return err
+	}
+	if _, err := w.Write(msg); err != nil {
+		return err
+	}
+	return http.NewResponseController(w).Flush()
+}
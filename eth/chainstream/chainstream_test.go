@@ -0,0 +1,222 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package chainstream
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/binary"
+	"io"
+	"math/big"
+	"net"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+	"golang.org/x/net/http2"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// testChain is an in-memory chain of empty blocks.
+type testChain struct {
+	blocks []*types.Block
+	feed   event.Feed
+	lock   sync.Mutex
+}
+
+func newTestChain(n int) *testChain {
+	c := new(testChain)
+	for i := 0; i < n; i++ {
+		c.extend(0)
+	}
+	return c
+}
+
+// extend appends a block to the chain. The extra data distinguishes forks.
+func (c *testChain) extend(fork byte) {
+	c.lock.Lock()
+	header := &types.Header{Number: big.NewInt(int64(len(c.blocks))), Extra: []byte{fork}}
+	if len(c.blocks) > 0 {
+		header.ParentHash = c.blocks[len(c.blocks)-1].Hash()
+	}
+	block := types.NewBlockWithHeader(header)
+	c.blocks = append(c.blocks, block)
+	c.lock.Unlock()
+
+	c.feed.Send(core.ChainHeadEvent{Header: header})
+}
+
+func (c *testChain) CurrentBlock() *types.Header {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.blocks[len(c.blocks)-1].Header()
+}
+
+func (c *testChain) GetBlockByNumber(number uint64) *types.Block {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if number >= uint64(len(c.blocks)) {
+		return nil
+	}
+	return c.blocks[number]
+}
+
+func (c *testChain) GetCanonicalHash(number uint64) common.Hash {
+	if block := c.GetBlockByNumber(number); block != nil {
+		return block.Hash()
+	}
+	return common.Hash{}
+}
+
+func (c *testChain) GetReceiptsByHash(hash common.Hash) types.Receipts {
+	return types.Receipts{{Type: types.LegacyTxType, Status: types.ReceiptStatusSuccessful, Logs: []*types.Log{}}}
+}
+
+func (c *testChain) SubscribeChainHeadEvent(ch chan<- core.ChainHeadEvent) event.Subscription {
+	return c.feed.Subscribe(ch)
+}
+
+// startTestService starts the service on a random local port.
+func startTestService(t *testing.T, chain Chain) (*Service, *http.Client) {
+	s := New(chain, nil, "127.0.0.1:0")
+	if err := s.Start(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { s.Stop() })
+
+	client := &http.Client{Transport: &http2.Transport{
+		AllowHTTP: true,
+		DialTLSContext: func(ctx context.Context, network, addr string, _ *tls.Config) (net.Conn, error) {
+			return new(net.Dialer).DialContext(ctx, network, addr)
+		},
+	}}
+	return s, client
+}
+
+// call starts a streaming call, returning the response.
+func call(t *testing.T, ctx context.Context, s *Service, client *http.Client, method string, req StreamRequest) *http.Response {
+	var msg []byte
+	msg = protowire.AppendTag(msg, 1, protowire.VarintType)
+	msg = protowire.AppendVarint(msg, req.Start)
+	msg = protowire.AppendTag(msg, 2, protowire.VarintType)
+	msg = protowire.AppendVarint(msg, req.End)
+	if req.Follow {
+		msg = protowire.AppendTag(msg, 3, protowire.VarintType)
+		msg = protowire.AppendVarint(msg, 1)
+	}
+	body := make([]byte, 5, 5+len(msg))
+	binary.BigEndian.PutUint32(body[1:], uint32(len(msg)))
+	body = append(body, msg...)
+
+	url := "http://" + s.listener.Addr().String() + servicePath + method
+	hreq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	hreq.Header.Set("Content-Type", "application/grpc")
+	resp, err := client.Do(hreq)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp
+}
+
+// readNumbers reads n stream messages, returning their block numbers.
+func readNumbers(t *testing.T, r io.Reader, n int) []uint64 {
+	var numbers []uint64
+	for i := 0; i < n; i++ {
+		msg, err := readMessage(r)
+		if err != nil {
+			t.Fatalf("message %d: %v", i, err)
+		}
+		num, typ, l := protowire.ConsumeTag(msg)
+		if num != 1 || typ != protowire.VarintType {
+			t.Fatalf("message %d: unexpected first field %d", i, num)
+		}
+		v, _ := protowire.ConsumeVarint(msg[l:])
+		numbers = append(numbers, v)
+	}
+	return numbers
+}
+
+func TestStreamRange(t *testing.T) {
+	chain := newTestChain(10)
+	s, client := startTestService(t, chain)
+
+	for _, method := range []string{"Blocks", "Receipts"} {
+		resp := call(t, context.Background(), s, client, method, StreamRequest{Start: 2, End: 5})
+		numbers := readNumbers(t, resp.Body, 4)
+		for i, n := range numbers {
+			if n != uint64(2+i) {
+				t.Fatalf("%s: message %d has block %d", method, i, n)
+			}
+		}
+		if _, err := io.ReadAll(resp.Body); err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if status := resp.Trailer.Get("Grpc-Status"); status != "0" {
+			t.Fatalf("%s: status %q, message %q", method, status, resp.Trailer.Get("Grpc-Message"))
+		}
+	}
+}
+
+func TestStreamUnknownMethod(t *testing.T) {
+	s, client := startTestService(t, newTestChain(1))
+
+	resp := call(t, context.Background(), s, client, "Nonexistent", StreamRequest{})
+	io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if status := resp.Trailer.Get("Grpc-Status"); status != "12" {
+		t.Fatalf("status %q, want 12", status)
+	}
+}
+
+func TestStreamFollow(t *testing.T) {
+	chain := newTestChain(5)
+	s, client := startTestService(t, chain)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	resp := call(t, ctx, s, client, "Blocks", StreamRequest{Start: 3, Follow: true})
+	defer resp.Body.Close()
+
+	if numbers := readNumbers(t, resp.Body, 2); numbers[0] != 3 || numbers[1] != 4 {
+		t.Fatalf("unexpected blocks %v", numbers)
+	}
+	chain.extend(0)
+	chain.extend(0)
+	if numbers := readNumbers(t, resp.Body, 2); numbers[0] != 5 || numbers[1] != 6 {
+		t.Fatalf("unexpected blocks %v", numbers)
+	}
+	// Replace the last two blocks, the stream continues from the fork point.
+	chain.lock.Lock()
+	chain.blocks = chain.blocks[:5]
+	chain.lock.Unlock()
+	chain.extend(1)
+	chain.extend(1)
+	chain.extend(1)
+	if numbers := readNumbers(t, resp.Body, 3); numbers[0] != 5 || numbers[2] != 7 {
+		t.Fatalf("unexpected blocks after reorg %v", numbers)
+	}
+}
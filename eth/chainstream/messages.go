@@ -0,0 +1,186 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package chainstream
+
+import (
+	"bytes"
+	"errors"
+	"slices"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/eth"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/holiman/uint256"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// This file contains the protobuf encoding of the messages in chainstream.proto.
+
+// StreamRequest selects the blocks of a stream.
+type StreamRequest struct {
+	Start  uint64 // First block to stream
+	End    uint64 // Last block to stream, the current head if zero
+	Follow bool   // Keep streaming new blocks once the head is reached
+}
+
+// decodeStreamRequest decodes a StreamRequest message.
+func decodeStreamRequest(b []byte) (*StreamRequest, error) {
+	var req StreamRequest
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return nil, protowire.ParseError(n)
+		}
+		b = b[n:]
+		if typ != protowire.VarintType {
+			n = protowire.ConsumeFieldValue(num, typ, b)
+			if n < 0 {
+				return nil, protowire.ParseError(n)
+			}
+			b = b[n:]
+			continue
+		}
+		v, n := protowire.ConsumeVarint(b)
+		if n < 0 {
+			return nil, protowire.ParseError(n)
+		}
+		b = b[n:]
+		switch num {
+		case 1:
+			req.Start = v
+		case 2:
+			req.End = v
+		case 3:
+			req.Follow = v != 0
+		}
+	}
+	if req.End != 0 && req.End < req.Start {
+		return nil, errors.New("end before start")
+	}
+	return &req, nil
+}
+
+// appendHeaderFields appends the fields identifying the block a message
+// belongs to, which all streamed messages start with.
+func appendHeaderFields(b []byte, header *types.Header) []byte {
+	hash := header.Hash()
+	b = protowire.AppendTag(b, 1, protowire.VarintType)
+	b = protowire.AppendVarint(b, header.Number.Uint64())
+	b = protowire.AppendTag(b, 2, protowire.BytesType)
+	b = protowire.AppendBytes(b, hash[:])
+	b = protowire.AppendTag(b, 3, protowire.BytesType)
+	b = protowire.AppendBytes(b, header.ParentHash[:])
+	return b
+}
+
+// encodeBlock encodes a Block message.
+func encodeBlock(block *types.Block) ([]byte, error) {
+	enc, err := rlp.EncodeToBytes(block)
+	if err != nil {
+		return nil, err
+	}
+	b := appendHeaderFields(nil, block.Header())
+	b = protowire.AppendTag(b, 4, protowire.BytesType)
+	b = protowire.AppendBytes(b, enc)
+	return b, nil
+}
+
+// encodeBlockReceipts encodes a BlockReceipts message.
+func encodeBlockReceipts(header *types.Header, receipts types.Receipts) ([]byte, error) {
+	b := appendHeaderFields(nil, header)
+	for _, receipt := range receipts {
+		enc, err := receipt.MarshalBinary()
+		if err != nil {
+			return nil, err
+		}
+		b = protowire.AppendTag(b, 4, protowire.BytesType)
+		b = protowire.AppendBytes(b, enc)
+	}
+	return b, nil
+}
+
+// encodeStateDiff encodes a StateDiff message.
+func encodeStateDiff(header *types.Header, diff *eth.BlockStateDiff) []byte {
+	b := appendHeaderFields(nil, header)
+	for _, account := range diff.Accounts {
+		b = protowire.AppendTag(b, 4, protowire.BytesType)
+		b = protowire.AppendBytes(b, encodeAccountDiff(account))
+	}
+	// Codes are sorted for deterministic output
+	hashes := make([]common.Hash, 0, len(diff.Codes))
+	for hash := range diff.Codes {
+		hashes = append(hashes, hash)
+	}
+	slices.SortFunc(hashes, func(a, b common.Hash) int { return bytes.Compare(a[:], b[:]) })
+	for _, hash := range hashes {
+		var code []byte
+		code = protowire.AppendTag(code, 1, protowire.BytesType)
+		code = protowire.AppendBytes(code, hash[:])
+		code = protowire.AppendTag(code, 2, protowire.BytesType)
+		code = protowire.AppendBytes(code, diff.Codes[hash])
+
+		b = protowire.AppendTag(b, 5, protowire.BytesType)
+		b = protowire.AppendBytes(b, code)
+	}
+	return b
+}
+
+// encodeAccountDiff encodes an AccountDiff message.
+func encodeAccountDiff(diff state.AccountDiff) []byte {
+	var b []byte
+	b = protowire.AppendTag(b, 1, protowire.BytesType)
+	b = protowire.AppendBytes(b, diff.Address[:])
+	if diff.Prev != nil {
+		b = protowire.AppendTag(b, 2, protowire.BytesType)
+		b = protowire.AppendBytes(b, encodeAccount(diff.Prev))
+	}
+	if diff.Post != nil {
+		b = protowire.AppendTag(b, 3, protowire.BytesType)
+		b = protowire.AppendBytes(b, encodeAccount(diff.Post))
+	}
+	if diff.Destroyed {
+		b = protowire.AppendTag(b, 4, protowire.VarintType)
+		b = protowire.AppendVarint(b, 1)
+	}
+	for _, slot := range diff.Storage {
+		var s []byte
+		s = protowire.AppendTag(s, 1, protowire.BytesType)
+		s = protowire.AppendBytes(s, slot.Key[:])
+		s = protowire.AppendTag(s, 2, protowire.BytesType)
+		s = protowire.AppendBytes(s, slot.Prev[:])
+		s = protowire.AppendTag(s, 3, protowire.BytesType)
+		s = protowire.AppendBytes(s, slot.Post[:])
+
+		b = protowire.AppendTag(b, 5, protowire.BytesType)
+		b = protowire.AppendBytes(b, s)
+	}
+	return b
+}
+
+// encodeAccount encodes an Account message.
+func encodeAccount(account *state.AccountValues) []byte {
+	var b []byte
+	b = protowire.AppendTag(b, 1, protowire.VarintType)
+	b = protowire.AppendVarint(b, uint64(account.Nonce))
+	b = protowire.AppendTag(b, 2, protowire.BytesType)
+	b = protowire.AppendBytes(b, (*uint256.Int)(account.Balance).Bytes())
+	b = protowire.AppendTag(b, 3, protowire.BytesType)
+	b = protowire.AppendBytes(b, account.CodeHash[:])
+	return b
+}
@@ -43,6 +43,15 @@ func (d *Downloader) BeaconDevSync(header *types.Header) error {
 	return d.BeaconSync(header, header)
 }
 
+// CheckpointSync synchronizes the chain to a trusted checkpoint header, which
+// was retrieved from the network by its hash. Unlike BeaconDevSync, it is meant
+// to bootstrap a fresh node before its consensus client takes over, e.g. from a
+// governance checkpoint, and supports snap sync.
+func (d *Downloader) CheckpointSync(header *types.Header) error {
+	log.Info("Syncing to trusted checkpoint", "number", header.Number, "hash", header.Hash(), "mode", d.ConfigSyncMode())
+	return d.BeaconSync(header, header)
+}
+
 // GetHeader tries to retrieve the header with a given hash from a random peer.
 func (d *Downloader) GetHeader(hash common.Hash) (*types.Header, error) {
 	// Pick a random peer to sync from and keep retrying if none are yet
@@ -270,8 +270,9 @@ func (d *Downloader) fetchHeaders(from uint64) error {
 
 	// Verify the header at configured chain cutoff, ensuring it's matched with
 	// the configured hash. Skip the check if the configured cutoff is even higher
-	// than the sync target, which is definitely not a common case.
-	if d.chainCutoffNumber != 0 && d.chainCutoffNumber >= from && d.chainCutoffNumber <= head.Number.Uint64() {
+	// than the sync target, which is definitely not a common case, or if the
+	// cutoff is a custom history start which is not known by hash.
+	if d.chainCutoffNumber != 0 && d.chainCutoffHash != (common.Hash{}) && d.chainCutoffNumber >= from && d.chainCutoffNumber <= head.Number.Uint64() {
 		h := d.skeleton.Header(d.chainCutoffNumber)
 		if h == nil {
 			if d.chainCutoffNumber < tail.Number.Uint64() {
@@ -35,6 +35,7 @@ import (
 	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/p2p/msgrate"
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/ethereum/go-ethereum/triedb"
@@ -104,6 +105,9 @@ type Downloader struct {
 	queue *queue   // Scheduler for selecting the hashes to download
 	peers *peerSet // Set of active peers from which download can proceed
 
+	limiter *msgrate.Limiter // Bandwidth limiter of the sync traffic, nil if unlimited
+	scores  *msgrate.Scores  // Quality tracker of the sync peers, nil if not tracked
+
 	stateDB ethdb.Database // Database to state sync into (and deduplicate via)
 
 	// Statistics
@@ -333,10 +337,25 @@ func (d *Downloader) UnregisterPeer(id string) error {
 		return err
 	}
 	d.queue.Revoke(id)
+	d.limiter.Remove(id)
+	d.scores.Remove(id)
 
 	return nil
 }
 
+// SetBandwidthLimiter caps the bandwidth used for syncing, including the snap
+// state sync. It must be called before the first sync cycle.
+func (d *Downloader) SetBandwidthLimiter(limiter *msgrate.Limiter) {
+	d.limiter = limiter
+	d.SnapSyncer.SetBandwidthLimiter(limiter)
+}
+
+// SetPeerScores sets the tracker used to rate the quality of the sync peers,
+// shared with the fetchers. It must be called before the first sync cycle.
+func (d *Downloader) SetPeerScores(scores *msgrate.Scores) {
+	d.scores = scores
+}
+
 // synchronise will select the peer and use it for synchronising. If an empty string is given
 // it will use the best peer possible and synchronize if its TD is higher than our own. If any of the
 // checks fail an error will be returned. This method is synchronous
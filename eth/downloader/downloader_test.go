@@ -415,6 +415,37 @@ func testCanonSync(t *testing.T, protocol uint, mode SyncMode) {
 	}
 }
 
+// Tests that a chain can be synced to a checkpoint given only by its hash.
+func TestCheckpointSync68Full(t *testing.T) { testCheckpointSync(t, eth.ETH68, FullSync) }
+func TestCheckpointSync68Snap(t *testing.T) { testCheckpointSync(t, eth.ETH68, SnapSync) }
+
+func testCheckpointSync(t *testing.T, protocol uint, mode SyncMode) {
+	success := make(chan struct{})
+	tester := newTesterWithNotification(t, mode, func() {
+		close(success)
+	})
+	defer tester.terminate()
+
+	chain := testChainBase.shorten(blockCacheMaxItems - 15)
+	tester.newPeer("peer", protocol, chain.blocks[1:])
+
+	// Retrieve the checkpoint header from the network and sync to it
+	checkpoint := chain.blocks[len(chain.blocks)-1].Hash()
+	header, err := tester.downloader.GetHeader(checkpoint)
+	if err != nil {
+		t.Fatalf("failed to retrieve checkpoint header: %v", err)
+	}
+	if err := tester.downloader.CheckpointSync(header); err != nil {
+		t.Fatalf("failed to sync to checkpoint: %v", err)
+	}
+	select {
+	case <-success:
+		assertOwnChain(t, tester, len(chain.blocks))
+	case <-time.NewTimer(time.Second * 3).C:
+		t.Fatalf("Failed to sync chain in three seconds")
+	}
+}
+
 // Tests that if a large batch of blocks are being downloaded, it is throttled
 // until the cached blocks are retrieved.
 func TestThrottling68Full(t *testing.T) { testThrottling(t, eth.ETH68, FullSync) }
@@ -33,6 +33,9 @@ import (
 // to each request. Failing to do so is considered a protocol violation.
 var timeoutGracePeriod = 2 * time.Minute
 
+// withdrawalSize is the approximate encoded size of a withdrawal.
+const withdrawalSize = 8 + 8 + common.AddressLength + 8
+
 // typedQueue is an interface defining the adaptor needed to translate the type
 // specific downloader/queue schedulers into the type-agnostic general concurrent
 // fetcher algorithm calls.
@@ -126,6 +129,10 @@ func (d *Downloader) concurrentFetch(queue typedQueue) error {
 	// Prepare the queue and fetch block parts until the block header fetcher's done
 	finished := false
 	for {
+		// If peers are throttled by the bandwidth limiter, wake up when the first
+		// one may be sent a request again
+		var wake <-chan time.Time
+
 		// If there's nothing more to fetch, wait or terminate
 		if queue.pending() == 0 {
 			if len(pending) == 0 && finished {
@@ -136,12 +143,21 @@ func (d *Downloader) concurrentFetch(queue typedQueue) error {
 			var (
 				idles []*peerConnection
 				caps  []int
+				delay time.Duration
 			)
 			for _, peer := range d.peers.AllPeers() {
 				pending, stale := pending[peer.id], stales[peer.id]
 				if pending == nil && stale == nil {
+					if wait := d.limiter.Delay(peer.id); wait > 0 {
+						if delay == 0 || wait < delay {
+							delay = wait
+						}
+						continue
+					}
+					// Weight the capacity by the quality of the peer, so that
+					// responsive peers serving useful data are asked first.
 					idles = append(idles, peer)
-					caps = append(caps, queue.capacity(peer, time.Second))
+					caps = append(caps, int(float64(queue.capacity(peer, time.Second))*d.scores.Score(peer.id)))
 				} else if stale != nil {
 					if waited := time.Since(stale.Sent); waited > timeoutGracePeriod {
 						// Request has been in flight longer than the grace period
@@ -152,6 +168,9 @@ func (d *Downloader) concurrentFetch(queue typedQueue) error {
 					}
 				}
 			}
+			if delay > 0 {
+				wake = time.After(delay)
+			}
 			sort.Sort(&peerCapacitySort{idles, caps})
 
 			var throttled bool
@@ -205,6 +224,10 @@ func (d *Downloader) concurrentFetch(queue typedQueue) error {
 			// be dropped when they arrive
 			return errCanceled
 
+		case <-wake:
+			// A throttled peer may be sent requests again, loop back to the
+			// entry point for task assignment
+
 		case event := <-peering:
 			// A peer joined or left, the tasks queue and allocations need to be
 			// checked for potential assignment or reassignment
@@ -264,6 +287,7 @@ func (d *Downloader) concurrentFetch(queue typedQueue) error {
 			// overloading it further.
 			delete(pending, req.Peer)
 			stales[req.Peer] = req
+			d.scores.TimedOut(req.Peer)
 
 			timeouts.Pop() // Popping an item will reorder indices in `ordering`, delete after, otherwise will resurrect!
 			if timeouts.Size() > 0 {
@@ -318,6 +342,7 @@ func (d *Downloader) concurrentFetch(queue typedQueue) error {
 					}
 				}
 				delete(ordering, res.Req)
+				d.scores.Delivered(res.Req.Peer, res.Time)
 			}
 			// Delete the pending request (if it still exists) and mark the peer idle
 			delete(pending, res.Req.Peer)
@@ -328,6 +353,10 @@ func (d *Downloader) concurrentFetch(queue typedQueue) error {
 			res.Done <- nil
 			res.Req.Close()
 
+			// Account the delivered data, even if it turns out to be junk, as
+			// it consumed bandwidth all the same.
+			d.limiter.Charge(res.Req.Peer, responseSize(res))
+
 			// If the peer was previously banned and failed to deliver its pack
 			// in a reasonable time frame, ignore its message.
 			if peer := d.peers.Peer(res.Req.Peer); peer != nil {
@@ -336,6 +365,14 @@ func (d *Downloader) concurrentFetch(queue typedQueue) error {
 				if errors.Is(err, errInvalidChain) {
 					return err
 				}
+				// Track how much of the delivery was useful, a response that
+				// couldn't be delivered counts as a single useless item.
+				useless := 0
+				if err != nil {
+					useless = 1
+				}
+				d.scores.Served(peer.id, accepted, useless)
+
 				// Unless a peer delivered something completely else than requested (usually
 				// caused by a timed out request which came through in the end), set it to
 				// idle. If the delivery's stale, the peer should have already been idled.
@@ -352,3 +389,26 @@ func (d *Downloader) concurrentFetch(queue typedQueue) error {
 		}
 	}
 }
+
+// responseSize estimates the size of the data in a response packet, for the
+// purpose of bandwidth accounting.
+func responseSize(res *eth.Response) int {
+	var size common.StorageSize
+	switch packet := res.Res.(type) {
+	case *eth.BlockBodiesResponse:
+		for _, body := range *packet {
+			for _, tx := range body.Transactions {
+				size += common.StorageSize(tx.Size())
+			}
+			for _, uncle := range body.Uncles {
+				size += uncle.Size()
+			}
+			size += common.StorageSize(len(body.Withdrawals) * withdrawalSize)
+		}
+	case *eth.ReceiptsRLPResponse:
+		for _, receipts := range *packet {
+			size += common.StorageSize(len(receipts))
+		}
+	}
+	return int(size)
+}
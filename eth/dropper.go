@@ -55,8 +55,8 @@ var (
 //     randomly every peerDropInterval to make space for new peers
 //   - peers are dropped separately from the inboud pool and from the dialed pool
 type dropper struct {
-	maxDialPeers    int // maximum number of dialed peers
-	maxInboundPeers int // maximum number of inbound peers
+	maxDialPeers    func() int // maximum number of dialed peers
+	maxInboundPeers func() int // maximum number of inbound peers
 	peersFunc       getPeersFunc
 	syncingFunc     getSyncingFunc
 
@@ -75,12 +75,10 @@ type getPeersFunc func() []*p2p.Peer
 // Returns true while syncing, false when synced.
 type getSyncingFunc func() bool
 
-func newDropper(maxDialPeers, maxInboundPeers int) *dropper {
+func newDropper() *dropper {
 	cm := &dropper{
-		maxDialPeers:    maxDialPeers,
-		maxInboundPeers: maxInboundPeers,
-		peerDropTimer:   time.NewTimer(randomDuration(peerDropIntervalMin, peerDropIntervalMax)),
-		shutdownCh:      make(chan struct{}),
+		peerDropTimer: time.NewTimer(randomDuration(peerDropIntervalMin, peerDropIntervalMax)),
+		shutdownCh:    make(chan struct{}),
 	}
 	if peerDropIntervalMin > peerDropIntervalMax {
 		panic("peerDropIntervalMin duration must be less than or equal to peerDropIntervalMax duration")
@@ -90,6 +88,8 @@ func newDropper(maxDialPeers, maxInboundPeers int) *dropper {
 
 // Start the dropper.
 func (cm *dropper) Start(srv *p2p.Server, syncingFunc getSyncingFunc) {
+	cm.maxDialPeers = srv.MaxDialedConns
+	cm.maxInboundPeers = srv.MaxInboundConns
 	cm.peersFunc = srv.Peers
 	cm.syncingFunc = syncingFunc
 	cm.wg.Add(1)
@@ -112,7 +112,11 @@ func (cm *dropper) dropRandomPeer() bool {
 			numInbound++
 		}
 	}
-	numDialed := len(peers) - numInbound
+	var (
+		numDialed       = len(peers) - numInbound
+		maxDialPeers    = cm.maxDialPeers()
+		maxInboundPeers = cm.maxInboundPeers()
+	)
 
 	selectDoNotDrop := func(p *p2p.Peer) bool {
 		// Avoid dropping trusted and static peers, or recent peers.
@@ -120,8 +124,8 @@ func (cm *dropper) dropRandomPeer() bool {
 		// is close to limit capacity.
 		return p.Trusted() || p.StaticDialed() ||
 			p.Lifetime() < mclock.AbsTime(doNotDropBefore) ||
-			(p.DynDialed() && cm.maxDialPeers-numDialed > peerDropThreshold) ||
-			(p.Inbound() && cm.maxInboundPeers-numInbound > peerDropThreshold)
+			(p.DynDialed() && maxDialPeers-numDialed > peerDropThreshold) ||
+			(p.Inbound() && maxInboundPeers-numInbound > peerDropThreshold)
 	}
 
 	droppable := slices.DeleteFunc(peers, selectDoNotDrop)
@@ -19,6 +19,7 @@ package ethconfig
 
 import (
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -34,6 +35,7 @@ import (
 	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/miner"
+	"github.com/ethereum/go-ethereum/p2p/enode"
 	"github.com/ethereum/go-ethereum/params"
 )
 
@@ -58,6 +60,7 @@ var Defaults = Config{
 	StateHistory:         params.FullImmutabilityThreshold,
 	DatabaseCache:        512,
 	TrieCleanCache:       154,
+	TrieHotCache:         8,
 	TrieDirtyCache:       256,
 	TrieTimeout:          60 * time.Minute,
 	SnapshotCache:        102,
@@ -88,9 +91,19 @@ type Config struct {
 	NetworkId uint64
 	SyncMode  SyncMode
 
+	// Bandwidth caps of the sync traffic in bytes per second, in total and per
+	// peer. Zero means unlimited.
+	SyncBandwidth     uint64 `toml:",omitempty"`
+	SyncPeerBandwidth uint64 `toml:",omitempty"`
+
 	// HistoryMode configures chain history retention.
 	HistoryMode history.HistoryMode
 
+	// HistoryStart is the first block whose body and receipts are stored. Requests
+	// for earlier blocks are answered with routing hints from HistoryShardHints.
+	HistoryStart      uint64             `toml:",omitempty"`
+	HistoryShardHints history.ShardHints `toml:",omitempty"`
+
 	// This can be set to list of enrtree:// URLs which will be queried for
 	// nodes to connect to.
 	EthDiscoveryURLs  []string
@@ -123,6 +136,10 @@ type Config struct {
 	// below which detailed statistics are logged.
 	SlowBlockThreshold time.Duration `toml:",omitempty"`
 
+	// MaxReorgDepth is the maximum number of canonical blocks a head update may
+	// drop without an explicit admin override (0 = unlimited).
+	MaxReorgDepth uint64 `toml:",omitempty"`
+
 	// Database options
 	SkipBcVersionCheck bool `toml:"-"`
 	DatabaseHandles    int  `toml:"-"`
@@ -130,11 +147,19 @@ type Config struct {
 	DatabaseFreezer    string
 	DatabaseEra        string
 
+	// DatabaseMigrationBackup is the directory where the keys modified by schema
+	// migrations are exported before being migrated. Empty disables the backups.
+	DatabaseMigrationBackup string `toml:",omitempty"`
+
 	TrieCleanCache int
+	TrieHotCache   int // Memory allowance (MB) of the path database cache of hashed shallow trie nodes
 	TrieDirtyCache int
 	TrieTimeout    time.Duration
 	SnapshotCache  int
 	Preimages      bool
+	SenderCache    bool // Whether to persist recovered transaction senders for later replays
+	HaltReasons    bool // Whether to record why failed transactions halted
+	RevertReasons  bool // Whether to record the decoded revert reasons of reverted transactions
 
 	// This is the number of blocks for which logs will be cached in the filter system.
 	FilterLogCacheSize int
@@ -150,6 +175,17 @@ type Config struct {
 	TxPool   legacypool.Config
 	BlobPool blobpool.Config
 
+	// Transaction gossip options, allowing replicas which receive transactions via
+	// other channels to stop exchanging them with peers.
+	TxGossipNoIngress bool `toml:",omitempty"` // Ignore transactions gossiped by peers
+	TxGossipNoEgress  bool `toml:",omitempty"` // Don't gossip transactions to peers
+
+	// Block push options, allowing a block producer to stream its new head blocks
+	// to its replicas over the bpush protocol. Pushed blocks are only imported if
+	// sent by one of the configured sequencers.
+	PushReplicas   []*enode.Node `toml:",omitempty"` // Nodes to push the new head blocks to
+	PushSequencers []*enode.Node `toml:",omitempty"` // Nodes whose pushed blocks are imported
+
 	// Gas Price Oracle options
 	GPO gasprice.Config
 
@@ -179,6 +215,10 @@ type Config struct {
 	// send-transaction variants. The unit is ether.
 	RPCTxFeeCap float64
 
+	// DebugDangerousOps enables debug RPC methods which irreversibly delete data
+	// from the database, such as purging orphaned contract storage.
+	DebugDangerousOps bool `toml:",omitempty"`
+
 	// OverrideOsaka (TODO: remove after the fork)
 	OverrideOsaka *uint64 `toml:",omitempty"`
 
@@ -199,7 +239,18 @@ type Config struct {
 // CreateConsensusEngine creates a consensus engine for the given chain config.
 // Clique is allowed for now to live standalone, but ethash is forbidden and can
 // only exist on already merged networks.
+//
+// Chains selecting an engine registered through consensus.RegisterEngine get
+// that engine as returned by its constructor. Wrapping it into the beacon engine
+// for merged networks is left to the constructor.
 func CreateConsensusEngine(config *params.ChainConfig, db ethdb.Database) (consensus.Engine, error) {
+	if config.Engine != "" {
+		ctor, ok := consensus.LookupEngine(config.Engine)
+		if !ok {
+			return nil, fmt.Errorf("unknown consensus engine %q (registered: %v)", config.Engine, consensus.RegisteredEngines())
+		}
+		return ctor(config, db)
+	}
 	if config.TerminalTotalDifficulty == nil {
 		log.Error("Geth only supports PoS networks. Please transition legacy networks using Geth v1.13.x.")
 		return nil, errors.New("'terminalTotalDifficulty' is not set in genesis block")
@@ -0,0 +1,50 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package ethconfig
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func TestCreateRegisteredEngine(t *testing.T) {
+	engine := ethash.NewFaker()
+	consensus.RegisterEngine("test-faker", func(config *params.ChainConfig, db ethdb.Database) (consensus.Engine, error) {
+		return engine, nil
+	})
+	db := rawdb.NewMemoryDatabase()
+
+	// Registered engines are used as returned, even without a merge config
+	config := &params.ChainConfig{Engine: "test-faker"}
+	have, err := CreateConsensusEngine(config, db)
+	if err != nil {
+		t.Fatalf("failed to create registered engine: %v", err)
+	}
+	if have != engine {
+		t.Fatalf("wrong engine returned: have %T, want %T", have, engine)
+	}
+	// Unknown engines are rejected
+	config.Engine = "test-missing"
+	if _, err := CreateConsensusEngine(config, db); err == nil {
+		t.Fatal("expected error for unknown engine")
+	}
+}
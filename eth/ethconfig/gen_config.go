@@ -12,6 +12,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/txpool/legacypool"
 	"github.com/ethereum/go-ethereum/eth/gasprice"
 	"github.com/ethereum/go-ethereum/miner"
+	"github.com/ethereum/go-ethereum/p2p/enode"
 )
 
 // MarshalTOML marshals as TOML.
@@ -20,7 +21,11 @@ func (c Config) MarshalTOML() (interface{}, error) {
 		Genesis                 *core.Genesis `toml:",omitempty"`
 		NetworkId               uint64
 		SyncMode                SyncMode
+		SyncBandwidth           uint64 `toml:",omitempty"`
+		SyncPeerBandwidth       uint64 `toml:",omitempty"`
 		HistoryMode             history.HistoryMode
+		HistoryStart            uint64             `toml:",omitempty"`
+		HistoryShardHints       history.ShardHints `toml:",omitempty"`
 		EthDiscoveryURLs        []string
 		SnapDiscoveryURLs       []string
 		NoPruning               bool
@@ -34,21 +39,31 @@ func (c Config) MarshalTOML() (interface{}, error) {
 		StateScheme             string                 `toml:",omitempty"`
 		RequiredBlocks          map[uint64]common.Hash `toml:"-"`
 		SlowBlockThreshold      time.Duration          `toml:",omitempty"`
+		MaxReorgDepth           uint64                 `toml:",omitempty"`
 		SkipBcVersionCheck      bool                   `toml:"-"`
 		DatabaseHandles         int                    `toml:"-"`
 		DatabaseCache           int
 		DatabaseFreezer         string
 		DatabaseEra             string
+		DatabaseMigrationBackup string `toml:",omitempty"`
 		TrieCleanCache          int
+		TrieHotCache            int
 		TrieDirtyCache          int
 		TrieTimeout             time.Duration
 		SnapshotCache           int
 		Preimages               bool
+		SenderCache             bool
+		HaltReasons             bool
+		RevertReasons           bool
 		FilterLogCacheSize      int
 		LogQueryLimit           int
 		Miner                   miner.Config
 		TxPool                  legacypool.Config
 		BlobPool                blobpool.Config
+		TxGossipNoIngress       bool          `toml:",omitempty"`
+		TxGossipNoEgress        bool          `toml:",omitempty"`
+		PushReplicas            []*enode.Node `toml:",omitempty"`
+		PushSequencers          []*enode.Node `toml:",omitempty"`
 		GPO                     gasprice.Config
 		EnablePreimageRecording bool
 		EnableWitnessStats      bool
@@ -59,6 +74,7 @@ func (c Config) MarshalTOML() (interface{}, error) {
 		RPCGasCap               uint64
 		RPCEVMTimeout           time.Duration
 		RPCTxFeeCap             float64
+		DebugDangerousOps       bool          `toml:",omitempty"`
 		OverrideOsaka           *uint64       `toml:",omitempty"`
 		OverrideBPO1            *uint64       `toml:",omitempty"`
 		OverrideBPO2            *uint64       `toml:",omitempty"`
@@ -70,7 +86,11 @@ func (c Config) MarshalTOML() (interface{}, error) {
 	enc.Genesis = c.Genesis
 	enc.NetworkId = c.NetworkId
 	enc.SyncMode = c.SyncMode
+	enc.SyncBandwidth = c.SyncBandwidth
+	enc.SyncPeerBandwidth = c.SyncPeerBandwidth
 	enc.HistoryMode = c.HistoryMode
+	enc.HistoryStart = c.HistoryStart
+	enc.HistoryShardHints = c.HistoryShardHints
 	enc.EthDiscoveryURLs = c.EthDiscoveryURLs
 	enc.SnapDiscoveryURLs = c.SnapDiscoveryURLs
 	enc.NoPruning = c.NoPruning
@@ -84,21 +104,31 @@ func (c Config) MarshalTOML() (interface{}, error) {
 	enc.StateScheme = c.StateScheme
 	enc.RequiredBlocks = c.RequiredBlocks
 	enc.SlowBlockThreshold = c.SlowBlockThreshold
+	enc.MaxReorgDepth = c.MaxReorgDepth
 	enc.SkipBcVersionCheck = c.SkipBcVersionCheck
 	enc.DatabaseHandles = c.DatabaseHandles
 	enc.DatabaseCache = c.DatabaseCache
 	enc.DatabaseFreezer = c.DatabaseFreezer
 	enc.DatabaseEra = c.DatabaseEra
+	enc.DatabaseMigrationBackup = c.DatabaseMigrationBackup
 	enc.TrieCleanCache = c.TrieCleanCache
+	enc.TrieHotCache = c.TrieHotCache
 	enc.TrieDirtyCache = c.TrieDirtyCache
 	enc.TrieTimeout = c.TrieTimeout
 	enc.SnapshotCache = c.SnapshotCache
 	enc.Preimages = c.Preimages
+	enc.SenderCache = c.SenderCache
+	enc.HaltReasons = c.HaltReasons
+	enc.RevertReasons = c.RevertReasons
 	enc.FilterLogCacheSize = c.FilterLogCacheSize
 	enc.LogQueryLimit = c.LogQueryLimit
 	enc.Miner = c.Miner
 	enc.TxPool = c.TxPool
 	enc.BlobPool = c.BlobPool
+	enc.TxGossipNoIngress = c.TxGossipNoIngress
+	enc.TxGossipNoEgress = c.TxGossipNoEgress
+	enc.PushReplicas = c.PushReplicas
+	enc.PushSequencers = c.PushSequencers
 	enc.GPO = c.GPO
 	enc.EnablePreimageRecording = c.EnablePreimageRecording
 	enc.EnableWitnessStats = c.EnableWitnessStats
@@ -109,6 +139,7 @@ func (c Config) MarshalTOML() (interface{}, error) {
 	enc.RPCGasCap = c.RPCGasCap
 	enc.RPCEVMTimeout = c.RPCEVMTimeout
 	enc.RPCTxFeeCap = c.RPCTxFeeCap
+	enc.DebugDangerousOps = c.DebugDangerousOps
 	enc.OverrideOsaka = c.OverrideOsaka
 	enc.OverrideBPO1 = c.OverrideBPO1
 	enc.OverrideBPO2 = c.OverrideBPO2
@@ -124,7 +155,11 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 		Genesis                 *core.Genesis `toml:",omitempty"`
 		NetworkId               *uint64
 		SyncMode                *SyncMode
+		SyncBandwidth           *uint64 `toml:",omitempty"`
+		SyncPeerBandwidth       *uint64 `toml:",omitempty"`
 		HistoryMode             *history.HistoryMode
+		HistoryStart            *uint64            `toml:",omitempty"`
+		HistoryShardHints       history.ShardHints `toml:",omitempty"`
 		EthDiscoveryURLs        []string
 		SnapDiscoveryURLs       []string
 		NoPruning               *bool
@@ -138,21 +173,31 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 		StateScheme             *string                `toml:",omitempty"`
 		RequiredBlocks          map[uint64]common.Hash `toml:"-"`
 		SlowBlockThreshold      *time.Duration         `toml:",omitempty"`
+		MaxReorgDepth           *uint64                `toml:",omitempty"`
 		SkipBcVersionCheck      *bool                  `toml:"-"`
 		DatabaseHandles         *int                   `toml:"-"`
 		DatabaseCache           *int
 		DatabaseFreezer         *string
 		DatabaseEra             *string
+		DatabaseMigrationBackup *string `toml:",omitempty"`
 		TrieCleanCache          *int
+		TrieHotCache            *int
 		TrieDirtyCache          *int
 		TrieTimeout             *time.Duration
 		SnapshotCache           *int
 		Preimages               *bool
+		SenderCache             *bool
+		HaltReasons             *bool
+		RevertReasons           *bool
 		FilterLogCacheSize      *int
 		LogQueryLimit           *int
 		Miner                   *miner.Config
 		TxPool                  *legacypool.Config
 		BlobPool                *blobpool.Config
+		TxGossipNoIngress       *bool         `toml:",omitempty"`
+		TxGossipNoEgress        *bool         `toml:",omitempty"`
+		PushReplicas            []*enode.Node `toml:",omitempty"`
+		PushSequencers          []*enode.Node `toml:",omitempty"`
 		GPO                     *gasprice.Config
 		EnablePreimageRecording *bool
 		EnableWitnessStats      *bool
@@ -163,6 +208,7 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 		RPCGasCap               *uint64
 		RPCEVMTimeout           *time.Duration
 		RPCTxFeeCap             *float64
+		DebugDangerousOps       *bool          `toml:",omitempty"`
 		OverrideOsaka           *uint64        `toml:",omitempty"`
 		OverrideBPO1            *uint64        `toml:",omitempty"`
 		OverrideBPO2            *uint64        `toml:",omitempty"`
@@ -183,9 +229,21 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 	if dec.SyncMode != nil {
 		c.SyncMode = *dec.SyncMode
 	}
+	if dec.SyncBandwidth != nil {
+		c.SyncBandwidth = *dec.SyncBandwidth
+	}
+	if dec.SyncPeerBandwidth != nil {
+		c.SyncPeerBandwidth = *dec.SyncPeerBandwidth
+	}
 	if dec.HistoryMode != nil {
 		c.HistoryMode = *dec.HistoryMode
 	}
+	if dec.HistoryStart != nil {
+		c.HistoryStart = *dec.HistoryStart
+	}
+	if dec.HistoryShardHints != nil {
+		c.HistoryShardHints = dec.HistoryShardHints
+	}
 	if dec.EthDiscoveryURLs != nil {
 		c.EthDiscoveryURLs = dec.EthDiscoveryURLs
 	}
@@ -225,6 +283,9 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 	if dec.SlowBlockThreshold != nil {
 		c.SlowBlockThreshold = *dec.SlowBlockThreshold
 	}
+	if dec.MaxReorgDepth != nil {
+		c.MaxReorgDepth = *dec.MaxReorgDepth
+	}
 	if dec.SkipBcVersionCheck != nil {
 		c.SkipBcVersionCheck = *dec.SkipBcVersionCheck
 	}
@@ -240,9 +301,15 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 	if dec.DatabaseEra != nil {
 		c.DatabaseEra = *dec.DatabaseEra
 	}
+	if dec.DatabaseMigrationBackup != nil {
+		c.DatabaseMigrationBackup = *dec.DatabaseMigrationBackup
+	}
 	if dec.TrieCleanCache != nil {
 		c.TrieCleanCache = *dec.TrieCleanCache
 	}
+	if dec.TrieHotCache != nil {
+		c.TrieHotCache = *dec.TrieHotCache
+	}
 	if dec.TrieDirtyCache != nil {
 		c.TrieDirtyCache = *dec.TrieDirtyCache
 	}
@@ -255,6 +322,15 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 	if dec.Preimages != nil {
 		c.Preimages = *dec.Preimages
 	}
+	if dec.SenderCache != nil {
+		c.SenderCache = *dec.SenderCache
+	}
+	if dec.HaltReasons != nil {
+		c.HaltReasons = *dec.HaltReasons
+	}
+	if dec.RevertReasons != nil {
+		c.RevertReasons = *dec.RevertReasons
+	}
 	if dec.FilterLogCacheSize != nil {
 		c.FilterLogCacheSize = *dec.FilterLogCacheSize
 	}
@@ -270,6 +346,18 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 	if dec.BlobPool != nil {
 		c.BlobPool = *dec.BlobPool
 	}
+	if dec.TxGossipNoIngress != nil {
+		c.TxGossipNoIngress = *dec.TxGossipNoIngress
+	}
+	if dec.TxGossipNoEgress != nil {
+		c.TxGossipNoEgress = *dec.TxGossipNoEgress
+	}
+	if dec.PushReplicas != nil {
+		c.PushReplicas = dec.PushReplicas
+	}
+	if dec.PushSequencers != nil {
+		c.PushSequencers = dec.PushSequencers
+	}
 	if dec.GPO != nil {
 		c.GPO = *dec.GPO
 	}
@@ -300,6 +388,9 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 	if dec.RPCTxFeeCap != nil {
 		c.RPCTxFeeCap = *dec.RPCTxFeeCap
 	}
+	if dec.DebugDangerousOps != nil {
+		c.DebugDangerousOps = *dec.DebugDangerousOps
+	}
 	if dec.OverrideOsaka != nil {
 		c.OverrideOsaka = dec.OverrideOsaka
 	}
@@ -0,0 +1,151 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package fetcher
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+const (
+	// blockPushChanSize is the size of the channel queuing the pushed blocks.
+	blockPushChanSize = 64
+
+	// maxPendingBlocks is the maximum number of pushed blocks held back waiting
+	// for their parent to be imported.
+	maxPendingBlocks = 64
+)
+
+// blockHasFn is a callback type for checking whether a block is known locally.
+type blockHasFn func(hash common.Hash, number uint64) bool
+
+// blockInsertFn is a callback type for importing a block into the chain and
+// making it the head.
+type blockInsertFn func(block *types.Block) error
+
+// BlockImporter imports the blocks pushed by trusted block producers. Blocks
+// arriving before their parent are held back until the parent is imported, as
+// the pushes are not guaranteed to be delivered in order.
+type BlockImporter struct {
+	hasBlock blockHasFn
+	insert   blockInsertFn
+
+	inject chan *types.Block
+	quit   chan struct{}
+
+	// Only accessed by the loop
+	pending map[common.Hash][]*types.Block // Blocks waiting for a parent, keyed by parent hash
+	queued  int                            // Number of blocks in pending
+}
+
+// NewBlockImporter creates a block importer for the pushed blocks.
+func NewBlockImporter(hasBlock blockHasFn, insert blockInsertFn) *BlockImporter {
+	return &BlockImporter{
+		hasBlock: hasBlock,
+		insert:   insert,
+		inject:   make(chan *types.Block, blockPushChanSize),
+		quit:     make(chan struct{}),
+		pending:  make(map[common.Hash][]*types.Block),
+	}
+}
+
+// Start boots up the import loop.
+func (i *BlockImporter) Start() {
+	go i.loop()
+}
+
+// Stop terminates the import loop, dropping the pending blocks.
+func (i *BlockImporter) Stop() {
+	close(i.quit)
+}
+
+// Enqueue schedules a pushed block for import. The block is dropped if the
+// import queue is full.
+func (i *BlockImporter) Enqueue(peer string, block *types.Block) {
+	select {
+	case i.inject <- block:
+	case <-i.quit:
+	default:
+		log.Debug("Dropping pushed block, import queue full", "peer", peer, "number", block.Number(), "hash", block.Hash())
+	}
+}
+
+func (i *BlockImporter) loop() {
+	for {
+		select {
+		case block := <-i.inject:
+			i.process(block)
+		case <-i.quit:
+			return
+		}
+	}
+}
+
+// process imports the block if its parent is known, followed by the held back
+// descendants. Otherwise the block is held back.
+func (i *BlockImporter) process(block *types.Block) {
+	number := block.NumberU64()
+	if number == 0 || i.hasBlock(block.Hash(), number) {
+		return
+	}
+	if !i.hasBlock(block.ParentHash(), number-1) {
+		i.hold(block)
+		return
+	}
+	queue := []*types.Block{block}
+	for len(queue) > 0 {
+		block, queue = queue[0], queue[1:]
+		if err := i.insert(block); err != nil {
+			log.Warn("Pushed block import failed", "number", block.Number(), "hash", block.Hash(), "err", err)
+			continue
+		}
+		log.Debug("Imported pushed block", "number", block.Number(), "hash", block.Hash())
+
+		children := i.pending[block.Hash()]
+		delete(i.pending, block.Hash())
+		i.queued -= len(children)
+		queue = append(queue, children...)
+	}
+	i.prune(number)
+}
+
+// hold queues a block until its parent is imported.
+func (i *BlockImporter) hold(block *types.Block) {
+	for _, b := range i.pending[block.ParentHash()] {
+		if b.Hash() == block.Hash() {
+			return
+		}
+	}
+	if i.queued >= maxPendingBlocks {
+		log.Debug("Dropping pushed block, too many pending", "number", block.Number(), "hash", block.Hash())
+		return
+	}
+	i.pending[block.ParentHash()] = append(i.pending[block.ParentHash()], block)
+	i.queued++
+}
+
+// prune drops the held back blocks not above the given number, their parent
+// being superseded by the imported chain.
+func (i *BlockImporter) prune(number uint64) {
+	for parent, blocks := range i.pending {
+		if blocks[0].NumberU64() <= number {
+			delete(i.pending, parent)
+			i.queued -= len(blocks)
+		}
+	}
+}
@@ -0,0 +1,124 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package fetcher
+
+import (
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// importerTester is a fake chain the pushed blocks are imported into.
+type importerTester struct {
+	blocks   map[common.Hash]*types.Block
+	imported []uint64
+	lock     sync.Mutex
+}
+
+func newImporterTester(genesis *types.Block) *importerTester {
+	return &importerTester{blocks: map[common.Hash]*types.Block{genesis.Hash(): genesis}}
+}
+
+func (t *importerTester) hasBlock(hash common.Hash, number uint64) bool {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	_, ok := t.blocks[hash]
+	return ok
+}
+
+func (t *importerTester) insert(block *types.Block) error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.blocks[block.Hash()] = block
+	t.imported = append(t.imported, block.NumberU64())
+	return nil
+}
+
+func (t *importerTester) importedNumbers() []uint64 {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	return append([]uint64{}, t.imported...)
+}
+
+// makeBlockChain creates a chain of n blocks on top of the parent.
+func makeBlockChain(parent *types.Block, n int) []*types.Block {
+	blocks := make([]*types.Block, n)
+	for i := range blocks {
+		blocks[i] = types.NewBlockWithHeader(&types.Header{
+			ParentHash: parent.Hash(),
+			Number:     new(big.Int).Add(parent.Number(), common.Big1),
+		})
+		parent = blocks[i]
+	}
+	return blocks
+}
+
+// Tests that pushed blocks arriving out of order are imported once their parent is.
+func TestBlockImporterReorder(t *testing.T) {
+	var (
+		genesis = types.NewBlockWithHeader(&types.Header{Number: common.Big0})
+		tester  = newImporterTester(genesis)
+		blocks  = makeBlockChain(genesis, 4)
+	)
+	importer := NewBlockImporter(tester.hasBlock, tester.insert)
+	importer.Start()
+	defer importer.Stop()
+
+	for _, i := range []int{3, 1, 2, 0, 2} {
+		importer.Enqueue("peer", blocks[i])
+	}
+	deadline := time.Now().Add(time.Second)
+	for len(tester.importedNumbers()) < len(blocks) && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	imported := tester.importedNumbers()
+	if len(imported) != len(blocks) {
+		t.Fatalf("imported blocks mismatch: have %v, want %d blocks", imported, len(blocks))
+	}
+	for i, number := range imported {
+		if number != uint64(i+1) {
+			t.Fatalf("blocks imported out of order: %v", imported)
+		}
+	}
+}
+
+// Tests that the number of blocks held back waiting for their parent is capped.
+func TestBlockImporterPendingLimit(t *testing.T) {
+	var (
+		genesis = types.NewBlockWithHeader(&types.Header{Number: common.Big0})
+		tester  = newImporterTester(genesis)
+		blocks  = makeBlockChain(genesis, maxPendingBlocks+2)
+	)
+	importer := NewBlockImporter(tester.hasBlock, tester.insert)
+	for _, block := range blocks[1:] {
+		importer.process(block)
+	}
+	if importer.queued != maxPendingBlocks {
+		t.Fatalf("pending blocks mismatch: have %d, want %d", importer.queued, maxPendingBlocks)
+	}
+	importer.process(blocks[0])
+	if imported := tester.importedNumbers(); len(imported) != maxPendingBlocks+1 {
+		t.Fatalf("imported blocks mismatch: have %d, want %d", len(imported), maxPendingBlocks+1)
+	}
+	if importer.queued != 0 || len(importer.pending) != 0 {
+		t.Fatalf("pending blocks left: %d", importer.queued)
+	}
+}
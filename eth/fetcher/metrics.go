@@ -25,6 +25,11 @@ var (
 	txAnnounceKnownMeter       = metrics.NewRegisteredMeter("eth/fetcher/transaction/announces/known", nil)
 	txAnnounceUnderpricedMeter = metrics.NewRegisteredMeter("eth/fetcher/transaction/announces/underpriced", nil)
 	txAnnounceDOSMeter         = metrics.NewRegisteredMeter("eth/fetcher/transaction/announces/dos", nil)
+	txAnnounceRejectedMeter    = metrics.NewRegisteredMeter("eth/fetcher/transaction/announces/rejected", nil)
+	txRejectionInMeter         = metrics.NewRegisteredMeter("eth/fetcher/transaction/rejections/in", nil)
+
+	txAnnounceDeprioritizedMeter  = metrics.NewRegisteredMeter("eth/fetcher/transaction/announces/deprioritized", nil)
+	txBroadcastDeprioritizedMeter = metrics.NewRegisteredMeter("eth/fetcher/transaction/broadcasts/deprioritized", nil)
 
 	txBroadcastInMeter          = metrics.NewRegisteredMeter("eth/fetcher/transaction/broadcasts/in", nil)
 	txBroadcastKnownMeter       = metrics.NewRegisteredMeter("eth/fetcher/transaction/broadcasts/known", nil)
@@ -29,7 +29,9 @@ import (
 	"github.com/ethereum/go-ethereum/common/mclock"
 	"github.com/ethereum/go-ethereum/core/txpool"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/p2p/msgrate"
 )
 
 const (
@@ -71,6 +73,11 @@ const (
 
 	// addTxsBatchSize it the max number of transactions to add in a single batch from a peer.
 	addTxsBatchSize = 128
+
+	// txRejectThreshold is the number of rejected transactions after which a peer
+	// that never delivered an acceptable one gets its announcements and broadcasts
+	// ignored. Replies to our own requests are still processed.
+	txRejectThreshold = 256
 )
 
 var (
@@ -150,6 +157,10 @@ type TxFetcher struct {
 
 	txSeq       uint64                             // Unique transaction sequence number
 	underpriced *lru.Cache[common.Hash, time.Time] // Transactions discarded as too cheap (don't re-fetch)
+	reports     *txRejectReports                   // Transactions rejected by the remote peers
+	rejectFeed  event.Feed                         // Transactions rejected by the local pool
+
+	scores *msgrate.Scores // Delivery statistics of the peers, used to deprioritize useless ones
 
 	// Stage 1: Waiting lists for newly discovered transactions that might be
 	// broadcast without needing explicit request/reply round trips.
@@ -206,6 +217,8 @@ func NewTxFetcherForTests(
 		requests:     make(map[string]*txRequest),
 		alternates:   make(map[common.Hash]map[string]struct{}),
 		underpriced:  lru.NewCache[common.Hash, time.Time](maxTxUnderpricedSetSize),
+		reports:      newTxRejectReports(),
+		scores:       msgrate.NewScores(),
 		validateMeta: validateMeta,
 		addTxs:       addTxs,
 		fetchTxs:     fetchTxs,
@@ -222,6 +235,12 @@ func (f *TxFetcher) Notify(peer string, types []byte, sizes []uint32, hashes []c
 	// Keep track of all the announced transactions
 	txAnnounceInMeter.Mark(int64(len(hashes)))
 
+	// Ignore peers which only ever delivered transactions we reject.
+	if f.deprioritized(peer) {
+		txAnnounceDeprioritizedMeter.Mark(int64(len(hashes)))
+		return nil
+	}
+
 	// Skip any transaction announcements that we already know of, or that we've
 	// previously marked as cheap and discarded. This check is of course racy,
 	// because multiple concurrent notifies will still manage to pass it, but it's
@@ -233,6 +252,7 @@ func (f *TxFetcher) Notify(peer string, types []byte, sizes []uint32, hashes []c
 
 		duplicate   int64
 		underpriced int64
+		rejected    int64
 	)
 	for i, hash := range hashes {
 		err := f.validateMeta(hash, types[i])
@@ -248,6 +268,10 @@ func (f *TxFetcher) Notify(peer string, types []byte, sizes []uint32, hashes []c
 			underpriced++
 			continue
 		}
+		if f.reports.rejected(hash, f.realTime()) {
+			rejected++
+			continue
+		}
 
 		unknownHashes = append(unknownHashes, hash)
 
@@ -258,6 +282,7 @@ func (f *TxFetcher) Notify(peer string, types []byte, sizes []uint32, hashes []c
 	}
 	txAnnounceKnownMeter.Mark(duplicate)
 	txAnnounceUnderpricedMeter.Mark(underpriced)
+	txAnnounceRejectedMeter.Mark(rejected)
 
 	// If anything's left to announce, push it into the internal loop
 	if len(unknownHashes) == 0 {
@@ -302,11 +327,19 @@ func (f *TxFetcher) Enqueue(peer string, txs []*types.Transaction, direct bool)
 	// Keep track of all the propagated transactions
 	inMeter.Mark(int64(len(txs)))
 
+	// Ignore unsolicited transactions from peers which only ever delivered
+	// transactions we reject.
+	if !direct && f.deprioritized(peer) {
+		txBroadcastDeprioritizedMeter.Mark(int64(len(txs)))
+		return nil
+	}
+
 	// Push all the transactions into the pool, tracking underpriced ones to avoid
 	// re-requesting them and dropping the peer in case of malicious transfers.
 	var (
-		added = make([]common.Hash, 0, len(txs))
-		metas = make([]txMetadata, 0, len(txs))
+		added    = make([]common.Hash, 0, len(txs))
+		metas    = make([]txMetadata, 0, len(txs))
+		rejected []TxRejection
 	)
 	// proceed in batches
 	for i := 0; i < len(txs); i += addTxsBatchSize {
@@ -315,6 +348,7 @@ func (f *TxFetcher) Enqueue(peer string, txs []*types.Transaction, direct bool)
 			end = len(txs)
 		}
 		var (
+			accepted    int64
 			duplicate   int64
 			underpriced int64
 			otherreject int64
@@ -328,9 +362,14 @@ func (f *TxFetcher) Enqueue(peer string, txs []*types.Transaction, direct bool)
 			if errors.Is(err, txpool.ErrUnderpriced) || errors.Is(err, txpool.ErrReplaceUnderpriced) || errors.Is(err, txpool.ErrTxGasPriceTooLow) {
 				f.underpriced.Add(batch[j].Hash(), batch[j].Time())
 			}
+			// Collect the rejections worth sharing with the other peers
+			if reason, ok := rejectReason(err); ok {
+				rejected = append(rejected, TxRejection{Hash: batch[j].Hash(), Reason: reason})
+			}
 			// Track a few interesting failure types
 			switch {
-			case err == nil: // Noop, but need to handle to not count these
+			case err == nil:
+				accepted++
 
 			case errors.Is(err, txpool.ErrAlreadyKnown):
 				duplicate++
@@ -350,6 +389,7 @@ func (f *TxFetcher) Enqueue(peer string, txs []*types.Transaction, direct bool)
 		knownMeter.Mark(duplicate)
 		underpricedMeter.Mark(underpriced)
 		otherRejectMeter.Mark(otherreject)
+		f.scores.Served(peer, int(accepted), int(otherreject))
 
 		// If 'other reject' is >25% of the deliveries in any batch, sleep a bit.
 		if otherreject > int64((len(batch)+3)/4) {
@@ -357,6 +397,9 @@ func (f *TxFetcher) Enqueue(peer string, txs []*types.Transaction, direct bool)
 			time.Sleep(200 * time.Millisecond)
 		}
 	}
+	if len(rejected) > 0 {
+		f.rejectFeed.Send(rejected)
+	}
 	select {
 	case f.cleanup <- &txDelivery{origin: peer, hashes: added, metas: metas, direct: direct}:
 		return nil
@@ -365,9 +408,24 @@ func (f *TxFetcher) Enqueue(peer string, txs []*types.Transaction, direct bool)
 	}
 }
 
+// SetPeerScores replaces the peer quality tracker of the fetcher with one shared
+// with the other sync components. It must be called before Start.
+func (f *TxFetcher) SetPeerScores(scores *msgrate.Scores) {
+	f.scores = scores
+}
+
+// deprioritized reports whether the peer only ever delivered data which failed
+// validation, and enough of it to not be a coincidence.
+func (f *TxFetcher) deprioritized(peer string) bool {
+	stats := f.scores.Stats(peer)
+	return stats.Useful == 0 && stats.Useless >= txRejectThreshold
+}
+
 // Drop should be called when a peer disconnects. It cleans up all the internal
 // data structures of the given node.
 func (f *TxFetcher) Drop(peer string) error {
+	f.scores.Remove(peer)
+
 	select {
 	case f.drop <- &txDrop{peer: peer}:
 		return nil
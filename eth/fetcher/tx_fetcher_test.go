@@ -2364,3 +2364,70 @@ func TestTransactionForgotten(t *testing.T) {
 		t.Errorf("wrong final underpriced cache size: got %d, want 1", size)
 	}
 }
+
+// Tests that peers only delivering transactions which fail validation get their
+// announcements and broadcasts ignored, while useful peers are unaffected.
+func TestTransactionFetcherDeprioritizeRejectingPeers(t *testing.T) {
+	var added int
+	f := NewTxFetcher(
+		func(common.Hash, byte) error { return nil },
+		func(txs []*types.Transaction) []error {
+			errs := make([]error, len(txs))
+			for i, tx := range txs {
+				if tx.Nonce() == 0 {
+					added++
+				} else {
+					errs[i] = errors.New("invalid")
+				}
+			}
+			return errs
+		},
+		func(string, []common.Hash) error { return nil },
+		nil,
+	)
+	f.Start()
+	defer f.Stop()
+
+	rejected := make([]*types.Transaction, txRejectThreshold)
+	for i := range rejected {
+		rejected[i] = types.NewTransaction(uint64(i+1), common.Address{}, new(big.Int), 0, new(big.Int), nil)
+	}
+	valid := types.NewTransaction(0, common.Address{0x01}, new(big.Int), 0, new(big.Int), nil)
+
+	// A peer which delivered a valid transaction stays prioritized.
+	if err := f.Enqueue("good", []*types.Transaction{valid}, false); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Enqueue("good", rejected, false); err != nil {
+		t.Fatal(err)
+	}
+	if f.deprioritized("good") {
+		t.Fatal("useful peer deprioritized")
+	}
+	// A peer which only delivered rejected transactions is ignored.
+	if err := f.Enqueue("bad", rejected, false); err != nil {
+		t.Fatal(err)
+	}
+	if !f.deprioritized("bad") {
+		t.Fatal("rejecting peer not deprioritized")
+	}
+	if err := f.Enqueue("bad", []*types.Transaction{valid}, false); err != nil {
+		t.Fatal(err)
+	}
+	if added != 1 {
+		t.Fatalf("broadcast of deprioritized peer processed: added %d transactions", added)
+	}
+	// Requested transactions are still accepted, and the score is reset on drop.
+	if err := f.Enqueue("bad", []*types.Transaction{valid}, true); err != nil {
+		t.Fatal(err)
+	}
+	if added != 2 {
+		t.Fatalf("reply of deprioritized peer not processed: added %d transactions", added)
+	}
+	if err := f.Drop("bad"); err != nil {
+		t.Fatal(err)
+	}
+	if f.deprioritized("bad") {
+		t.Fatal("score not reset after drop")
+	}
+}
@@ -0,0 +1,140 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package fetcher
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/lru"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/txpool"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+const (
+	// maxTxRejectionSetSize is the number of transactions for which the rejection
+	// reports of the peers are tracked.
+	maxTxRejectionSetSize = 32768
+
+	// maxTxRejectionTimeout is the max time a rejection report is considered.
+	maxTxRejectionTimeout = 5 * time.Minute
+
+	// txRejectionQuorum is the number of distinct peers which have to report a
+	// transaction as rejected for it not to be fetched anymore. A single peer
+	// cannot censor transactions this way.
+	txRejectionQuorum = 2
+)
+
+// TxRejectReason is the reason a transaction was rejected by the local pool.
+// Only the reasons which are likely to apply to other nodes too are tracked.
+type TxRejectReason uint8
+
+const (
+	TxRejectUnderpriced TxRejectReason = iota + 1 // Fee too low, or too low to replace a pooled transaction
+	TxRejectNonceTooLow                           // Nonce already used on chain
+)
+
+// TxRejection is a transaction rejected by the pool of a node.
+type TxRejection struct {
+	Hash   common.Hash
+	Reason TxRejectReason
+}
+
+// rejectReason maps a pool error to the reason shared with the peers.
+func rejectReason(err error) (TxRejectReason, bool) {
+	switch {
+	case errors.Is(err, txpool.ErrUnderpriced) || errors.Is(err, txpool.ErrReplaceUnderpriced) || errors.Is(err, txpool.ErrTxGasPriceTooLow):
+		return TxRejectUnderpriced, true
+	case errors.Is(err, core.ErrNonceTooLow):
+		return TxRejectNonceTooLow, true
+	default:
+		return 0, false
+	}
+}
+
+// txRejectReport is the set of peers having rejected a transaction.
+type txRejectReport struct {
+	peers map[string]struct{}
+	time  time.Time // Time of the first report
+}
+
+// txRejectReports tracks the transactions reported as rejected by remote peers.
+type txRejectReports struct {
+	reports lru.BasicLRU[common.Hash, *txRejectReport]
+	lock    sync.Mutex
+}
+
+func newTxRejectReports() *txRejectReports {
+	return &txRejectReports{reports: lru.NewBasicLRU[common.Hash, *txRejectReport](maxTxRejectionSetSize)}
+}
+
+// add records the rejection of the given transactions by a peer.
+func (r *txRejectReports) add(peer string, hashes []common.Hash, now time.Time) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	for _, hash := range hashes {
+		report, ok := r.reports.Get(hash)
+		if !ok || report.time.Before(now.Add(-maxTxRejectionTimeout)) {
+			report = &txRejectReport{peers: make(map[string]struct{}), time: now}
+			r.reports.Add(hash, report)
+		}
+		if len(report.peers) < txRejectionQuorum {
+			report.peers[peer] = struct{}{}
+		}
+	}
+}
+
+// rejected reports whether enough peers recently rejected the transaction.
+func (r *txRejectReports) rejected(hash common.Hash, now time.Time) bool {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	report, ok := r.reports.Peek(hash)
+	if !ok {
+		return false
+	}
+	if report.time.Before(now.Add(-maxTxRejectionTimeout)) {
+		r.reports.Remove(hash)
+		return false
+	}
+	return len(report.peers) >= txRejectionQuorum
+}
+
+// SubscribeRejections subscribes to the batches of transactions delivered by
+// the peers and rejected by the local pool, for sharing with the network.
+func (f *TxFetcher) SubscribeRejections(ch chan<- []TxRejection) event.Subscription {
+	return f.rejectFeed.Subscribe(ch)
+}
+
+// NotifyRejections records the transactions a peer reported as rejected by its
+// pool. Announcements of transactions rejected by enough peers are ignored.
+func (f *TxFetcher) NotifyRejections(peer string, rejections []TxRejection) {
+	txRejectionInMeter.Mark(int64(len(rejections)))
+
+	hashes := make([]common.Hash, 0, len(rejections))
+	for _, rejection := range rejections {
+		switch rejection.Reason {
+		case TxRejectUnderpriced, TxRejectNonceTooLow:
+			hashes = append(hashes, rejection.Hash)
+		}
+	}
+	f.reports.add(peer, hashes, f.realTime())
+}
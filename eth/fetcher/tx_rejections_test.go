@@ -0,0 +1,103 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package fetcher
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/txpool"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Tests that announcements are only ignored once enough distinct peers reported
+// the transaction as rejected, and only for a limited time.
+func TestTransactionFetcherRejectionReports(t *testing.T) {
+	var (
+		now    = time.Unix(1700000000, 0)
+		hash   = common.Hash{0x01}
+		other  = common.Hash{0x02}
+		notify = make(chan *txAnnounce, 1)
+	)
+	f := NewTxFetcherForTests(
+		func(common.Hash, byte) error { return nil },
+		func(txs []*types.Transaction) []error { return make([]error, len(txs)) },
+		func(string, []common.Hash) error { return nil },
+		nil,
+		nil, func() time.Time { return now }, nil,
+	)
+	f.notify = notify
+
+	announced := func() bool {
+		if err := f.Notify("announcer", []byte{types.LegacyTxType}, []uint32{100}, []common.Hash{hash}); err != nil {
+			t.Fatalf("failed to notify: %v", err)
+		}
+		select {
+		case <-notify:
+			return true
+		default:
+			return false
+		}
+	}
+	// A single report, or repeated reports of the same peer, don't suffice
+	f.NotifyRejections("A", []TxRejection{{Hash: hash, Reason: TxRejectUnderpriced}})
+	f.NotifyRejections("A", []TxRejection{{Hash: hash, Reason: TxRejectUnderpriced}})
+	if !announced() {
+		t.Fatal("transaction ignored after a single peer report")
+	}
+	// Unknown reasons are ignored
+	f.NotifyRejections("B", []TxRejection{{Hash: hash, Reason: 0xff}})
+	if !announced() {
+		t.Fatal("transaction ignored after an unknown rejection reason")
+	}
+	// A second peer reaches the quorum
+	f.NotifyRejections("B", []TxRejection{{Hash: hash, Reason: TxRejectNonceTooLow}, {Hash: other, Reason: TxRejectNonceTooLow}})
+	if announced() {
+		t.Fatal("transaction announced after reaching the rejection quorum")
+	}
+	// The reports expire
+	now = now.Add(maxTxRejectionTimeout + time.Second)
+	if !announced() {
+		t.Fatal("transaction ignored after the reports expired")
+	}
+}
+
+func TestTransactionRejectReason(t *testing.T) {
+	tests := []struct {
+		err    error
+		reason TxRejectReason
+		shared bool
+	}{
+		{nil, 0, false},
+		{txpool.ErrAlreadyKnown, 0, false},
+		{txpool.ErrUnderpriced, TxRejectUnderpriced, true},
+		{fmt.Errorf("%w: tip too low", txpool.ErrTxGasPriceTooLow), TxRejectUnderpriced, true},
+		{txpool.ErrReplaceUnderpriced, TxRejectUnderpriced, true},
+		{fmt.Errorf("%w: next nonce 2, tx nonce 1", core.ErrNonceTooLow), TxRejectNonceTooLow, true},
+		{errors.New("invalid sender"), 0, false},
+	}
+	for i, test := range tests {
+		reason, shared := rejectReason(test.err)
+		if reason != test.reason || shared != test.shared {
+			t.Errorf("test %d: have %v/%v, want %v/%v", i, reason, shared, test.reason, test.shared)
+		}
+	}
+}
@@ -31,6 +31,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/history"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/internal/ethapi"
+	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/rpc"
 )
 
@@ -45,6 +46,7 @@ var (
 	errExceedMaxTopics        = errors.New("exceed max topics")
 	errExceedLogQueryLimit    = errors.New("exceed max addresses or topics per search position")
 	errExceedMaxTxHashes      = errors.New("exceed max number of transaction hashes allowed per transactionReceipts subscription")
+	errBackfillOverflow       = errors.New("too many live logs buffered during backfill")
 )
 
 type invalidParamsError struct {
@@ -65,6 +67,10 @@ const (
 	maxSubTopics = 1000
 	// The maximum number of transaction hash criteria allowed in a single subscription
 	maxTxHashes = 200
+	// The number of blocks queried at once when backfilling a logs subscription
+	backfillChunkSize = 1000
+	// The maximum number of live logs buffered while a logs subscription backfills
+	maxBackfillPending = 10000
 )
 
 // filter is a helper struct that holds meta information over the filter type
@@ -285,6 +291,22 @@ func (api *FilterAPI) Logs(ctx context.Context, crit FilterCriteria) (*rpc.Subsc
 		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
 	}
 
+	// If the subscription starts in the past, the historical logs up to the
+	// current head are backfilled before switching over to live logs.
+	var (
+		backfill bool
+		from, to uint64
+	)
+	if crit.FromBlock != nil && crit.FromBlock.Sign() >= 0 {
+		from, to = crit.FromBlock.Uint64(), api.sys.backend.CurrentHeader().Number.Uint64()
+		if crit.ToBlock != nil && crit.ToBlock.Sign() >= 0 && crit.ToBlock.Uint64() < to {
+			to = crit.ToBlock.Uint64()
+		}
+		backfill = from <= to
+	}
+	if backfill && from < api.sys.backend.HistoryPruningCutoff() {
+		return nil, &history.PrunedHistoryError{}
+	}
 	var (
 		rpcSub      = notifier.CreateSubscription()
 		matchedLogs = make(chan []*types.Log)
@@ -297,13 +319,38 @@ func (api *FilterAPI) Logs(ctx context.Context, crit FilterCriteria) (*rpc.Subsc
 
 	go func() {
 		defer logsSub.Unsubscribe()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go func() {
+			select {
+			case <-rpcSub.Err(): // client send an unsubscribe request
+				cancel()
+			case <-ctx.Done():
+			}
+		}()
+		send := func(log *types.Log) { notifier.Notify(rpcSub.ID, log) }
+
+		if backfill {
+			pending, err := api.backfillLogs(ctx, crit, from, to, matchedLogs, send)
+			if err != nil {
+				if ctx.Err() == nil {
+					log.Warn("Dropping logs subscription", "id", rpcSub.ID, "err", err)
+					notifier.Close(err)
+				}
+				return
+			}
+			for _, log := range pending {
+				send(log)
+			}
+		}
 		for {
 			select {
 			case logs := <-matchedLogs:
 				for _, log := range logs {
-					notifier.Notify(rpcSub.ID, &log)
+					send(log)
 				}
-			case <-rpcSub.Err(): // client send an unsubscribe request
+			case <-ctx.Done():
 				return
 			}
 		}
@@ -312,6 +359,87 @@ func (api *FilterAPI) Logs(ctx context.Context, crit FilterCriteria) (*rpc.Subsc
 	return rpcSub, nil
 }
 
+// backfillLogs delivers the historical logs matching crit within [from, to] to
+// send, querying backfillChunkSize blocks at a time so a large range does not
+// flood the connection. Live logs arriving in the meantime are buffered and
+// returned once the backfill is done, minus those already covered by it. If
+// the buffer exceeds maxBackfillPending, or the history of the range is pruned
+// meanwhile, the backfill is aborted.
+func (api *FilterAPI) backfillLogs(ctx context.Context, crit FilterCriteria, from, to uint64, live <-chan []*types.Log, send func(*types.Log)) ([]*types.Log, error) {
+	type result struct {
+		logs []*types.Log
+		err  error
+	}
+	// The chunk being queried is cancelled and waited for if the backfill is
+	// aborted, so no query outlives the call.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var pending []*types.Log
+	for begin := from; begin <= to; begin += backfillChunkSize {
+		end := min(begin+backfillChunkSize-1, to)
+		if begin < api.sys.backend.HistoryPruningCutoff() {
+			return nil, &history.PrunedHistoryError{}
+		}
+		filter := api.sys.NewRangeFilter(int64(begin), int64(end), crit.Addresses, crit.Topics)
+
+		done := make(chan result, 1)
+		go func() {
+			logs, err := filter.Logs(ctx)
+			done <- result{logs, err}
+		}()
+		var res result
+	wait:
+		for {
+			select {
+			case res = <-done:
+				break wait
+			case logs := <-live:
+				if len(pending)+len(logs) > maxBackfillPending {
+					cancel()
+					<-done
+					return nil, errBackfillOverflow
+				}
+				pending = append(pending, logs...)
+			case <-ctx.Done():
+				<-done
+				return nil, ctx.Err()
+			}
+		}
+		if res.err != nil {
+			return nil, res.err
+		}
+		for _, log := range res.logs {
+			send(log)
+		}
+		if end == to {
+			break
+		}
+	}
+	// Pick up whatever live logs arrived while delivering the last chunk. Those
+	// at or below the backfilled range were already delivered, unless they are
+	// removals caused by a reorg.
+drain:
+	for {
+		select {
+		case logs := <-live:
+			if len(pending)+len(logs) > maxBackfillPending {
+				return nil, errBackfillOverflow
+			}
+			pending = append(pending, logs...)
+		default:
+			break drain
+		}
+	}
+	remaining := pending[:0]
+	for _, log := range pending {
+		if log.Removed || log.BlockNumber > to {
+			remaining = append(remaining, log)
+		}
+	}
+	return remaining, nil
+}
+
 // TransactionReceiptsQuery defines criteria for transaction receipts subscription.
 // Same as ethereum.TransactionReceiptsQuery but with UnmarshalJSON() method.
 type TransactionReceiptsQuery ethereum.TransactionReceiptsQuery
@@ -29,6 +29,7 @@ import (
 	"github.com/ethereum/go-ethereum/consensus/ethash"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/filtermaps"
+	"github.com/ethereum/go-ethereum/core/history"
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
@@ -49,6 +50,7 @@ type testBackend struct {
 	chainFeed       event.Feed
 	pendingBlock    *types.Block
 	pendingReceipts types.Receipts
+	historyCutoff   uint64
 }
 
 func (b *testBackend) ChainConfig() *params.ChainConfig {
@@ -193,7 +195,7 @@ func (b *testBackend) setPending(block *types.Block, receipts types.Receipts) {
 }
 
 func (b *testBackend) HistoryPruningCutoff() uint64 {
-	return 0
+	return b.historyCutoff
 }
 
 func newTestFilterSystem(db ethdb.Database, cfg Config) (*testBackend, *FilterSystem) {
@@ -922,3 +924,111 @@ func TestTransactionReceiptsSubscription(t *testing.T) {
 		})
 	}
 }
+
+// newBackfillTestAPI creates a filter API over a chain of 20 blocks, every fifth
+// of which, starting at block 3, holds a log emitted by addr.
+func newBackfillTestAPI(t *testing.T, addr common.Address) (*FilterAPI, *testBackend) {
+	t.Helper()
+
+	var (
+		db           = rawdb.NewMemoryDatabase()
+		backend, sys = newTestFilterSystem(db, Config{})
+		gspec        = &core.Genesis{
+			BaseFee: big.NewInt(params.InitialBaseFee),
+			Config:  params.TestChainConfig,
+		}
+	)
+	t.Cleanup(func() { db.Close() })
+
+	_, chain, receipts := core.GenerateChainWithGenesis(gspec, ethash.NewFaker(), 20, func(i int, gen *core.BlockGen) {
+		if i%5 == 2 {
+			gen.AddUncheckedReceipt(makeReceipt(addr))
+			gen.AddUncheckedTx(types.NewTransaction(999, common.HexToAddress("0x999"), big.NewInt(999), 999, gen.BaseFee(), nil))
+		}
+	})
+	gspec.MustCommit(db, triedb.NewDatabase(db, triedb.HashDefaults))
+	for i, block := range chain {
+		rawdb.WriteBlock(db, block)
+		rawdb.WriteCanonicalHash(db, block.Hash(), block.NumberU64())
+		rawdb.WriteHeadBlockHash(db, block.Hash())
+		rawdb.WriteReceipts(db, block.Hash(), block.NumberU64(), receipts[i])
+	}
+	backend.startFilterMaps(0, false, filtermaps.DefaultParams)
+	t.Cleanup(backend.stopFilterMaps)
+
+	return NewFilterAPI(sys), backend
+}
+
+// Tests that a logs subscription starting in the past backfills the historical
+// logs and only keeps the live logs not covered by the backfill.
+func TestLogsBackfill(t *testing.T) {
+	t.Parallel()
+
+	var (
+		addr   = common.BytesToAddress([]byte("jeff"))
+		api, _ = newBackfillTestAPI(t, addr)
+	)
+
+	live := make(chan []*types.Log, 3)
+	live <- []*types.Log{{Address: addr, BlockNumber: 13}}               // covered by the backfill
+	live <- []*types.Log{{Address: addr, BlockNumber: 8, Removed: true}} // reorged out
+	live <- []*types.Log{{Address: addr, BlockNumber: 18}}               // beyond the backfill
+
+	var sent []uint64
+	pending, err := api.backfillLogs(context.Background(), FilterCriteria{Addresses: []common.Address{addr}}, 2, 15, live, func(log *types.Log) {
+		sent = append(sent, log.BlockNumber)
+	})
+	if err != nil {
+		t.Fatalf("backfill failed: %v", err)
+	}
+	if want := []uint64{3, 8, 13}; !reflect.DeepEqual(sent, want) {
+		t.Fatalf("wrong backfilled logs: have %v, want %v", sent, want)
+	}
+	var remaining []uint64
+	for _, log := range pending {
+		remaining = append(remaining, log.BlockNumber)
+	}
+	if want := []uint64{8, 18}; !reflect.DeepEqual(remaining, want) {
+		t.Fatalf("wrong pending live logs: have %v, want %v", remaining, want)
+	}
+}
+
+// Tests that the backfill is aborted if the history of the range is pruned.
+func TestLogsBackfillPruned(t *testing.T) {
+	t.Parallel()
+
+	var (
+		addr         = common.BytesToAddress([]byte("jeff"))
+		api, backend = newBackfillTestAPI(t, addr)
+		crit         = FilterCriteria{Addresses: []common.Address{addr}}
+		send         = func(*types.Log) {}
+	)
+	backend.historyCutoff = 5
+
+	_, err := api.backfillLogs(context.Background(), crit, 2, 15, make(chan []*types.Log), send)
+	var pruned *history.PrunedHistoryError
+	if !errors.As(err, &pruned) {
+		t.Fatalf("wrong error backfilling pruned range: %v", err)
+	}
+	if _, err := api.backfillLogs(context.Background(), crit, 5, 15, make(chan []*types.Log), send); err != nil {
+		t.Fatalf("failed to backfill available range: %v", err)
+	}
+}
+
+// Tests that the backfill is aborted if too many live logs are buffered.
+func TestLogsBackfillOverflow(t *testing.T) {
+	t.Parallel()
+
+	var (
+		addr   = common.BytesToAddress([]byte("jeff"))
+		api, _ = newBackfillTestAPI(t, addr)
+		crit   = FilterCriteria{Addresses: []common.Address{addr}}
+		live   = make(chan []*types.Log, 1)
+	)
+	live <- make([]*types.Log, maxBackfillPending+1)
+
+	_, err := api.backfillLogs(context.Background(), crit, 2, 15, live, func(*types.Log) {})
+	if !errors.Is(err, errBackfillOverflow) {
+		t.Fatalf("wrong error on live log overflow: %v", err)
+	}
+}
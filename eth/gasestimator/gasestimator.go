@@ -26,6 +26,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/tracing"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/internal/ethapi/override"
@@ -133,15 +134,17 @@ func Estimate(ctx context.Context, call *core.Message, opts *Options, gasCap uin
 	// unused access list items). Ever so slightly wasteful, but safer overall.
 	if len(call.Data) == 0 {
 		if call.To != nil && opts.State.GetCodeSize(*call.To) == 0 {
-			failed, _, err := execute(ctx, call, opts, params.TxGas)
+			failed, _, err := execute(ctx, call, opts, params.TxGas, nil)
 			if !failed && err == nil {
 				return params.TxGas, nil, nil
 			}
 		}
 	}
 	// We first execute the transaction at the highest allowable gas limit, since if this fails we
-	// can return error immediately.
-	failed, result, err := execute(ctx, call, opts, hi)
+	// can return error immediately. The execution is traced to track the gas each call frame
+	// needs to succeed.
+	tracker := newGasTracker()
+	failed, result, err := execute(ctx, call, opts, hi, tracker.Hooks())
 	if err != nil {
 		return 0, nil, err
 	}
@@ -158,12 +161,13 @@ func Estimate(ctx context.Context, call *core.Message, opts *Options, gasCap uin
 	// limit for these cases anyway.
 	lo = result.UsedGas - 1
 
-	// There's a fairly high chance for the transaction to execute successfully
-	// with gasLimit set to the first execution's usedGas + gasRefund. Explicitly
-	// check that gas amount and use as a limit for the binary search.
-	optimisticGasLimit := (result.MaxUsedGas + params.CallStipend) * 64 / 63
-	if optimisticGasLimit < hi {
-		failed, _, err = execute(ctx, call, opts, optimisticGasLimit)
+	// The gas tracked during the first execution yields the gas limit the transaction
+	// needs, unless its execution depends on the gas available. Check it with a second
+	// execution, and confirm it is the lowest one with a third at the gas limit right
+	// below, leaving the binary search only for the executions the tracking misses.
+	estimate := max(tracker.estimate(hi), result.UsedGas)
+	if estimate < hi {
+		failed, _, err = execute(ctx, call, opts, estimate, nil)
 		if err != nil {
 			// This should not happen under normal conditions since if we make it this far the
 			// transaction had run without error at least once before.
@@ -171,9 +175,23 @@ func Estimate(ctx context.Context, call *core.Message, opts *Options, gasCap uin
 			return 0, nil, err
 		}
 		if failed {
-			lo = optimisticGasLimit
+			log.Debug("Tracked gas estimate insufficient", "estimate", estimate, "used", result.UsedGas)
+			lo = estimate
 		} else {
-			hi = optimisticGasLimit
+			hi = estimate
+		}
+	}
+	if hi == estimate && lo+1 < hi {
+		failed, _, err = execute(ctx, call, opts, hi-1, nil)
+		if err != nil {
+			log.Error("Execution error in estimate gas", "err", err)
+			return 0, nil, err
+		}
+		if failed {
+			lo = hi - 1
+		} else {
+			log.Debug("Tracked gas estimate excessive", "estimate", estimate, "used", result.UsedGas)
+			hi--
 		}
 	}
 	// Binary search for the smallest gas limit that allows the tx to execute successfully.
@@ -194,7 +212,7 @@ func Estimate(ctx context.Context, call *core.Message, opts *Options, gasCap uin
 			// range here is skewed to favor the low side.
 			mid = lo * 2
 		}
-		failed, _, err = execute(ctx, call, opts, mid)
+		failed, _, err = execute(ctx, call, opts, mid, nil)
 		if err != nil {
 			// This should not happen under normal conditions since if we make it this far the
 			// transaction had run without error at least once before.
@@ -213,15 +231,15 @@ func Estimate(ctx context.Context, call *core.Message, opts *Options, gasCap uin
 // execute is a helper that executes the transaction under a given gas limit and
 // returns true if the transaction fails for a reason that might be related to
 // not enough gas. A non-nil error means execution failed due to reasons unrelated
-// to the gas limit.
-func execute(ctx context.Context, call *core.Message, opts *Options, gasLimit uint64) (bool, *core.ExecutionResult, error) {
+// to the gas limit. The optional tracer is attached to the EVM during execution.
+func execute(ctx context.Context, call *core.Message, opts *Options, gasLimit uint64, tracer *tracing.Hooks) (bool, *core.ExecutionResult, error) {
 	// Configure the call for this specific execution (and revert the change after)
 	defer func(gas uint64) { call.GasLimit = gas }(call.GasLimit)
 	call.GasLimit = gasLimit
 
 	// Execute the call and separate execution faults caused by a lack of gas or
 	// other non-fixable conditions
-	result, err := run(ctx, call, opts)
+	result, err := run(ctx, call, opts, tracer)
 	if err != nil {
 		if errors.Is(err, core.ErrIntrinsicGas) {
 			return true, nil, nil // Special case, raise gas limit
@@ -236,12 +254,13 @@ func execute(ctx context.Context, call *core.Message, opts *Options, gasLimit ui
 
 // run assembles the EVM as defined by the consensus rules and runs the requested
 // call invocation.
-func run(ctx context.Context, call *core.Message, opts *Options) (*core.ExecutionResult, error) {
+func run(ctx context.Context, call *core.Message, opts *Options, tracer *tracing.Hooks) (*core.ExecutionResult, error) {
 	// Assemble the call and the call context
-	var (
-		evmContext = core.NewEVMBlockContext(opts.Header, opts.Chain, nil)
-		dirtyState = opts.State.Copy()
-	)
+	evmContext, err := core.NewEVMBlockContext(opts.Header, opts.Chain, nil)
+	if err != nil {
+		return nil, err
+	}
+	dirtyState := opts.State.Copy()
 	if opts.BlockOverrides != nil {
 		if err := opts.BlockOverrides.Apply(&evmContext); err != nil {
 			return nil, err
@@ -255,7 +274,7 @@ func run(ctx context.Context, call *core.Message, opts *Options) (*core.Executio
 	if call.BlobGasFeeCap != nil && call.BlobGasFeeCap.BitLen() == 0 {
 		evmContext.BlobBaseFee = new(big.Int)
 	}
-	evm := vm.NewEVM(evmContext, dirtyState, opts.Config, vm.Config{NoBaseFee: true})
+	evm := vm.NewEVM(evmContext, dirtyState, opts.Config, vm.Config{NoBaseFee: true, Tracer: tracer})
 
 	// Monitor the outer context and interrupt the EVM upon cancellation. To avoid
 	// a dangling goroutine until the outer estimation finishes, create an internal
@@ -0,0 +1,142 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package gasestimator
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// gasFrame is the gas accounting of a single call frame.
+type gasFrame struct {
+	gas     uint64 // gas provided to the frame
+	stipend uint64 // part of the provided gas given for free by the caller
+	base    uint64 // gas consumed by the caller up to the call, excluding the forwarded gas
+	need    uint64 // lowest gas the frame needs to be provided to succeed
+
+	lastGas  uint64 // gas available before the last executed opcode
+	lastCost uint64 // cost of the last executed opcode
+}
+
+// gasTracker tracks the gas each call frame of an execution requires to succeed,
+// accounting for the 63/64 rule of forwarded gas, the call stipend and the
+// SSTORE sentry, which make the gas limit required by a transaction higher than
+// the gas it uses in the end.
+type gasTracker struct {
+	frames []*gasFrame
+	need   uint64 // gas the outermost frame needs, set once it exits
+	gas    uint64 // gas provided to the outermost frame
+}
+
+func newGasTracker() *gasTracker {
+	return new(gasTracker)
+}
+
+// Hooks returns the tracing hooks feeding the tracker.
+func (t *gasTracker) Hooks() *tracing.Hooks {
+	return &tracing.Hooks{
+		OnEnter:  t.onEnter,
+		OnExit:   t.onExit,
+		OnOpcode: t.onOpcode,
+	}
+}
+
+// estimate returns the gas limit the traced transaction needs to succeed, given
+// the gas limit it was executed with.
+func (t *gasTracker) estimate(gasLimit uint64) uint64 {
+	return gasLimit - t.gas + t.need
+}
+
+func (t *gasTracker) onEnter(depth int, typ byte, from common.Address, to common.Address, input []byte, gas uint64, value *big.Int) {
+	frame := &gasFrame{gas: gas}
+	if len(t.frames) == 0 {
+		t.gas = gas
+		t.frames = append(t.frames, frame)
+		return
+	}
+	parent := t.frames[len(t.frames)-1]
+	frame.base = parent.gas - parent.lastGas + parent.lastCost
+	switch vm.OpCode(typ) {
+	case vm.CREATE, vm.CREATE2:
+		// The forwarded gas is deducted from the caller after the opcode cost.
+	default:
+		if (vm.OpCode(typ) == vm.CALL || vm.OpCode(typ) == vm.CALLCODE) && value != nil && value.Sign() != 0 {
+			frame.stipend = params.CallStipend
+		}
+		// The opcode cost includes the gas forwarded to the callee. Avoid an
+		// underflow should the traced cost not account for it.
+		frame.base -= min(frame.base, gas-min(gas, frame.stipend))
+	}
+	t.frames = append(t.frames, frame)
+}
+
+func (t *gasTracker) onExit(depth int, output []byte, gasUsed uint64, err error, reverted bool) {
+	if len(t.frames) == 0 {
+		return
+	}
+	frame := t.frames[len(t.frames)-1]
+	t.frames = t.frames[:len(t.frames)-1]
+	frame.need = max(frame.need, gasUsed)
+
+	if len(t.frames) == 0 {
+		t.need = frame.need
+		return
+	}
+	// The caller only forwards 63/64 of its remaining gas, so it needs to hold
+	// more than the callee needs at the time of the call.
+	var forward uint64
+	if frame.need > frame.stipend {
+		forward = allButOne64thInverse(frame.need - frame.stipend)
+	}
+	parent := t.frames[len(t.frames)-1]
+	parent.need = max(parent.need, frame.base+forward)
+}
+
+func (t *gasTracker) onOpcode(pc uint64, op byte, gas, cost uint64, scope tracing.OpContext, rData []byte, depth int, err error) {
+	if len(t.frames) == 0 {
+		return
+	}
+	frame := t.frames[len(t.frames)-1]
+	frame.lastGas, frame.lastCost = gas, cost
+
+	switch vm.OpCode(op) {
+	case vm.CALL, vm.CALLCODE, vm.DELEGATECALL, vm.STATICCALL:
+		// The cost includes the forwarded gas, accounted for when the callee exits.
+	case vm.SSTORE:
+		// SSTORE fails unless more than the sentry gas is available (EIP-2200).
+		frame.need = max(frame.need, frame.gas-gas+max(cost, params.SstoreSentryGasEIP2200+1))
+	default:
+		frame.need = max(frame.need, frame.gas-gas+cost)
+	}
+}
+
+// allButOne64thInverse returns the lowest gas whose all but one 64th, the part
+// forwarded to a callee as per EIP-150, is at least the given gas.
+func allButOne64thInverse(gas uint64) uint64 {
+	held := gas * 64 / 63
+	for held > 0 && (held-1)-(held-1)/64 >= gas {
+		held--
+	}
+	for held-held/64 < gas {
+		held++
+	}
+	return held
+}
@@ -0,0 +1,200 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package gasestimator
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/core/vm/program"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/holiman/uint256"
+)
+
+type testChain struct {
+	config *params.ChainConfig
+}
+
+func (c *testChain) Engine() consensus.Engine                    { return ethash.NewFaker() }
+func (c *testChain) Config() *params.ChainConfig                 { return c.config }
+func (c *testChain) CurrentHeader() *types.Header                { return nil }
+func (c *testChain) GetHeader(common.Hash, uint64) *types.Header { return nil }
+func (c *testChain) GetHeaderByNumber(uint64) *types.Header      { return nil }
+func (c *testChain) GetHeaderByHash(common.Hash) *types.Header   { return nil }
+
+var (
+	testCaller = common.HexToAddress("0xc0ffee")
+	testCallee = common.HexToAddress("0xca11ee")
+)
+
+// revertOnFailure appends code reverting the execution if the call whose success
+// flag is on the stack failed.
+func revertOnFailure(p *program.Program) *program.Program {
+	// PUSH1 dest, JUMPI, PUSH0, DUP1, REVERT, JUMPDEST
+	p.Push(p.Label() + 6).Op(vm.JUMPI)
+	p.Op(vm.PUSH0, vm.DUP1, vm.REVERT)
+	p.Jumpdest()
+	return p
+}
+
+// newTestOptions creates the options to estimate a call to the caller contract,
+// which may call into the callee contract.
+func newTestOptions(t *testing.T, caller, callee []byte) *Options {
+	statedb, err := state.New(types.EmptyRootHash, state.NewDatabaseForTesting())
+	if err != nil {
+		t.Fatal(err)
+	}
+	statedb.SetCode(testCaller, caller, tracing.CodeChangeUnspecified)
+	statedb.SetBalance(testCaller, uint256.NewInt(params.Ether), tracing.BalanceChangeUnspecified)
+	statedb.SetCode(testCallee, callee, tracing.CodeChangeUnspecified)
+
+	config := params.MergedTestChainConfig
+	return &Options{
+		Config: config,
+		Chain:  &testChain{config: config},
+		Header: &types.Header{
+			Number:     big.NewInt(1),
+			GasLimit:   30_000_000,
+			BaseFee:    new(big.Int),
+			Difficulty: new(big.Int),
+		},
+		State: statedb,
+	}
+}
+
+func newTestCall() *core.Message {
+	return &core.Message{
+		From:            common.HexToAddress("0xfeed"),
+		To:              &testCaller,
+		Value:           new(big.Int),
+		GasPrice:        new(big.Int),
+		GasFeeCap:       new(big.Int),
+		GasTipCap:       new(big.Int),
+		SkipNonceChecks: true,
+	}
+}
+
+// This test checks that the gas tracked during a single execution yields the gas
+// limit needed by transactions whose gas limit exceeds the gas they use.
+func TestGasTrackerEstimate(t *testing.T) {
+	tests := []struct {
+		name   string
+		caller []byte
+		callee []byte
+	}{
+		{
+			// The caller forwards all its gas, of which the callee only gets 63/64.
+			name:   "call-63/64",
+			caller: revertOnFailure(program.New().Call(nil, testCallee, 0, 0, 0, 0, 0)).Bytes(),
+			callee: program.New().Push(0x10000).Op(vm.MLOAD, vm.POP).Bytes(),
+		},
+		{
+			// The caller pays for the value transfer in full, but the callee gets
+			// the call stipend for free and returns what it doesn't use.
+			name:   "call-stipend",
+			caller: revertOnFailure(program.New().Call(uint256.NewInt(0), testCallee, 1, 0, 0, 0, 0)).Bytes(),
+			callee: program.New().Push(0).Push(0).Op(vm.LOG0).Bytes(),
+		},
+		{
+			// The second store costs little, but requires more than the sentry gas
+			// to be available.
+			name:   "sstore-sentry",
+			caller: program.New().Sstore(0, 1).Sstore(0, 2).Bytes(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				ctx  = context.Background()
+				opts = newTestOptions(t, tt.caller, tt.callee)
+				call = newTestCall()
+				hi   = min(opts.Header.GasLimit, params.MaxTxGas) // Osaka transaction gas cap
+			)
+			tracker := newGasTracker()
+			failed, result, err := execute(ctx, call, opts, hi, tracker.Hooks())
+			if failed || err != nil {
+				t.Fatalf("execution failed: %v", err)
+			}
+			// The tracked estimate is the exact gas limit.
+			have := tracker.estimate(hi)
+			if have <= result.UsedGas {
+				t.Fatalf("gas limit %d doesn't exceed gas used %d", have, result.UsedGas)
+			}
+			if failed, _, err := execute(ctx, newTestCall(), opts, have, nil); failed || err != nil {
+				t.Fatalf("execution with tracked estimate %d failed: %v", have, err)
+			}
+			if failed, _, _ := execute(ctx, newTestCall(), opts, have-1, nil); !failed {
+				t.Fatalf("execution below tracked estimate %d succeeded", have)
+			}
+			// The estimation yields it without an error ratio.
+			est, _, err := Estimate(ctx, newTestCall(), opts, 0)
+			if err != nil {
+				t.Fatalf("estimation failed: %v", err)
+			}
+			if est != have {
+				t.Fatalf("wrong estimate: have %d, want %d", est, have)
+			}
+			// Allowing an error ratio keeps the estimate within it.
+			opts.ErrorRatio = 0.015
+			approx, _, err := Estimate(ctx, newTestCall(), opts, 0)
+			if err != nil {
+				t.Fatalf("estimation failed: %v", err)
+			}
+			if approx < have || float64(approx-have)/float64(approx) > opts.ErrorRatio {
+				t.Fatalf("estimate %d exceeds error ratio, want %d", approx, have)
+			}
+		})
+	}
+}
+
+// This test checks that calls whose traced opcode cost doesn't include the gas
+// forwarded to the callee don't underflow the gas accounting.
+func TestGasTrackerForwardedGasUnderflow(t *testing.T) {
+	tracker := newGasTracker()
+	tracker.onEnter(0, byte(vm.CALL), common.Address{}, testCaller, nil, 100_000, nil)
+	tracker.onOpcode(0, byte(vm.PUSH1), 100_000, 3, nil, nil, 1, nil)
+	tracker.onEnter(1, byte(vm.CALL), testCaller, testCallee, nil, 50_000, nil)
+	tracker.onExit(2, nil, 1_000, nil, false)
+	tracker.onExit(1, nil, 52_000, nil, false)
+
+	if need := tracker.estimate(121_000); need > 121_000 {
+		t.Fatalf("gas accounting underflowed: estimate %d", need)
+	}
+}
+
+// This test checks that the gas a caller needs to forward a given gas to its
+// callee is the lowest one.
+func TestAllButOne64thInverse(t *testing.T) {
+	for gas := uint64(1); gas < 100_000; gas++ {
+		held := allButOne64thInverse(gas)
+		if held-held/64 < gas {
+			t.Fatalf("gas %d: %d forwards too little", gas, held)
+		}
+		if (held-1)-(held-1)/64 >= gas {
+			t.Fatalf("gas %d: %d is not the lowest", gas, held)
+		}
+	}
+}
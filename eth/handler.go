@@ -36,6 +36,7 @@ import (
 	"github.com/ethereum/go-ethereum/eth/downloader"
 	"github.com/ethereum/go-ethereum/eth/ethconfig"
 	"github.com/ethereum/go-ethereum/eth/fetcher"
+	"github.com/ethereum/go-ethereum/eth/protocols/bpush"
 	"github.com/ethereum/go-ethereum/eth/protocols/eth"
 	"github.com/ethereum/go-ethereum/eth/protocols/snap"
 	"github.com/ethereum/go-ethereum/ethdb"
@@ -44,6 +45,7 @@ import (
 	"github.com/ethereum/go-ethereum/metrics"
 	"github.com/ethereum/go-ethereum/p2p"
 	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/ethereum/go-ethereum/p2p/msgrate"
 )
 
 const (
@@ -106,9 +108,14 @@ type handlerConfig struct {
 	TxPool         txPool                 // Transaction pool to propagate from
 	Network        uint64                 // Network identifier to advertise
 	Sync           ethconfig.SyncMode     // Whether to snap or full sync
+	SyncBandwidth  *msgrate.Limiter       // Bandwidth limiter of the sync traffic, nil if unlimited
 	BloomCache     uint64                 // Megabytes to alloc for snap sync bloom
 	EventMux       *event.TypeMux         // Legacy event mux, deprecate for `feed`
 	RequiredBlocks map[uint64]common.Hash // Hard coded map of required block hashes for sync challenges
+	NoTxIngress    bool                   // Whether to ignore transactions gossiped by peers
+	NoTxEgress     bool                   // Whether to stop gossiping transactions to peers
+	PushReplicas   []*enode.Node          // Nodes to push the new head blocks to
+	PushSequencers []*enode.Node          // Nodes whose pushed blocks are imported
 }
 
 type handler struct {
@@ -119,18 +126,31 @@ type handler struct {
 	database ethdb.Database
 	txpool   txPool
 	chain    *core.BlockChain
-	maxPeers int
+	maxPeers func() int // peer limit, which may change at runtime
 
 	downloader     *downloader.Downloader
 	txFetcher      *fetcher.TxFetcher
+	peerScores     *msgrate.Scores // Quality of the data served by the peers, shared by the downloader and fetchers
 	peers          *peerSet
 	txBroadcastKey [16]byte
+	txGossip       txGossipPolicy
 
 	eventMux   *event.TypeMux
 	txsCh      chan core.NewTxsEvent
 	txsSub     event.Subscription
 	blockRange *blockRangeState
 
+	txrPeers    txrPeerSet                 // Peers sharing their transaction rejections
+	txRejectCh  chan []fetcher.TxRejection // Transactions rejected by the local pool
+	txRejectSub event.Subscription         // Subscription to the local rejections
+
+	pushReplicas   map[enode.ID]struct{}    // Nodes to push the new head blocks to
+	pushSequencers map[enode.ID]struct{}    // Nodes whose pushed blocks are imported
+	bpushPeers     bpushPeerSet             // Connected replicas
+	blockImporter  *fetcher.BlockImporter   // Importer of the pushed blocks
+	pushHeadCh     chan core.ChainHeadEvent // Head blocks to push to the replicas
+	pushHeadSub    event.Subscription       // Subscription to the head blocks
+
 	requiredBlocks map[uint64]common.Hash
 
 	// channels for fetcher, syncer, txsyncLoop
@@ -156,14 +176,23 @@ func newHandler(config *handlerConfig) (*handler, error) {
 		txpool:         config.TxPool,
 		chain:          config.Chain,
 		peers:          newPeerSet(),
+		txrPeers:       txrPeerSet{peers: make(map[string]*txrPeer)},
+		pushReplicas:   make(map[enode.ID]struct{}),
+		pushSequencers: make(map[enode.ID]struct{}),
+		bpushPeers:     bpushPeerSet{peers: make(map[string]*bpush.Peer)},
 		txBroadcastKey: newBroadcastChoiceKey(),
 		requiredBlocks: config.RequiredBlocks,
 		quitSync:       make(chan struct{}),
 		handlerDoneCh:  make(chan struct{}),
 		handlerStartCh: make(chan struct{}),
+		peerScores:     msgrate.NewScores(),
 	}
+	h.txGossip.noIngress.Store(config.NoTxIngress)
+	h.txGossip.noEgress.Store(config.NoTxEgress)
 	// Construct the downloader (long sync)
 	h.downloader = downloader.New(config.Database, config.Sync, h.eventMux, h.chain, h.removePeer, h.enableSyncedFeatures)
+	h.downloader.SetBandwidthLimiter(config.SyncBandwidth)
+	h.downloader.SetPeerScores(h.peerScores)
 
 	// If snap sync is requested but snapshots are disabled, fail loudly
 	if h.downloader.ConfigSyncMode() == ethconfig.SnapSync && (config.Chain.Snapshots() == nil && config.Chain.TrieDB().Scheme() == rawdb.HashScheme) {
@@ -191,6 +220,16 @@ func newHandler(config *handlerConfig) (*handler, error) {
 	}
 
 	h.txFetcher = fetcher.NewTxFetcher(validateMeta, addTxs, fetchTx, h.removePeer)
+	h.txFetcher.SetPeerScores(h.peerScores)
+
+	// Construct the importer of the blocks pushed by the configured producers
+	for _, n := range config.PushReplicas {
+		h.pushReplicas[n.ID()] = struct{}{}
+	}
+	for _, n := range config.PushSequencers {
+		h.pushSequencers[n.ID()] = struct{}{}
+	}
+	h.blockImporter = fetcher.NewBlockImporter(h.chain.HasBlock, h.insertPushedBlock)
 	return h, nil
 }
 
@@ -264,7 +303,7 @@ func (h *handler) runEthPeer(peer *eth.Peer, handler eth.Handler) error {
 	}
 	// Ignore maxPeers if this is a trusted peer
 	if !peer.Peer.Info().Network.Trusted {
-		if reject || h.peers.len() >= h.maxPeers {
+		if reject || h.peers.len() >= h.maxPeers() {
 			return p2p.DiscTooManyPeers
 		}
 	}
@@ -411,7 +450,7 @@ func (h *handler) unregisterPeer(id string) {
 	}
 }
 
-func (h *handler) Start(maxPeers int) {
+func (h *handler) Start(maxPeers func() int) {
 	h.maxPeers = maxPeers
 
 	// broadcast and announce transactions (only new ones, not resurrected ones)
@@ -420,13 +459,28 @@ func (h *handler) Start(maxPeers int) {
 	h.txsSub = h.txpool.SubscribeTransactions(h.txsCh, false)
 	go h.txBroadcastLoop()
 
+	// share the transactions rejected by the pool
+	h.wg.Add(1)
+	h.txRejectCh = make(chan []fetcher.TxRejection, txRejectChanSize)
+	h.txRejectSub = h.txFetcher.SubscribeRejections(h.txRejectCh)
+	go h.txRejectLoop()
+
 	// broadcast block range
 	h.wg.Add(1)
 	h.blockRange = newBlockRangeState(h.chain, h.eventMux)
 	go h.blockRangeLoop(h.blockRange)
 
+	// push the new head blocks to the replicas
+	if len(h.pushReplicas) > 0 {
+		h.wg.Add(1)
+		h.pushHeadCh = make(chan core.ChainHeadEvent, chainHeadChanSize)
+		h.pushHeadSub = h.chain.SubscribeChainHeadEvent(h.pushHeadCh)
+		go h.blockPushLoop()
+	}
+
 	// start sync handlers
 	h.txFetcher.Start()
+	h.blockImporter.Start()
 
 	// start peer handler tracker
 	h.wg.Add(1)
@@ -434,9 +488,14 @@ func (h *handler) Start(maxPeers int) {
 }
 
 func (h *handler) Stop() {
-	h.txsSub.Unsubscribe() // quits txBroadcastLoop
+	h.txsSub.Unsubscribe()      // quits txBroadcastLoop
+	h.txRejectSub.Unsubscribe() // quits txRejectLoop
 	h.blockRange.stop()
+	if h.pushHeadSub != nil {
+		h.pushHeadSub.Unsubscribe() // quits blockPushLoop
+	}
 	h.txFetcher.Stop()
+	h.blockImporter.Stop()
 	h.downloader.Terminate()
 
 	// Quit chainSync and txsync64.
@@ -458,6 +517,9 @@ func (h *handler) Stop() {
 // - And, separately, as announcements to all peers which are not known to
 // already have the given transaction.
 func (h *handler) BroadcastTransactions(txs types.Transactions) {
+	if h.txGossip.noEgress.Load() {
+		return
+	}
 	var (
 		blobTxs  int // Number of blob transactions to announce only
 		largeTxs int // Number of large transactions to announce only
@@ -513,6 +575,26 @@ func (h *handler) BroadcastTransactions(txs types.Transactions) {
 		"bcastpeers", len(txset), "bcastcount", directCount, "annpeers", len(annos), "anncount", annCount)
 }
 
+// txGossipPolicy controls the exchange of transactions with peers. Replicas which
+// receive their transactions from elsewhere (e.g. forwarded over RPC) can stop
+// accepting or propagating them, or both.
+type txGossipPolicy struct {
+	noIngress atomic.Bool // Ignore transactions announced or broadcast by peers
+	noEgress  atomic.Bool // Don't announce or broadcast transactions to peers
+}
+
+// SetTxGossip enables or disables the ingress and egress of gossiped transactions.
+func (h *handler) SetTxGossip(ingress, egress bool) {
+	h.txGossip.noIngress.Store(!ingress)
+	h.txGossip.noEgress.Store(!egress)
+	log.Info("Updated transaction gossip policy", "ingress", ingress, "egress", egress)
+}
+
+// TxGossip reports whether the ingress and egress of gossiped transactions are enabled.
+func (h *handler) TxGossip() (ingress, egress bool) {
+	return !h.txGossip.noIngress.Load(), !h.txGossip.noEgress.Load()
+}
+
 // txBroadcastLoop announces new transactions to connected peers.
 func (h *handler) txBroadcastLoop() {
 	defer h.wg.Done()
@@ -0,0 +1,149 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/eth/protocols/bpush"
+	"github.com/ethereum/go-ethereum/p2p"
+	"github.com/ethereum/go-ethereum/p2p/enode"
+)
+
+// errUntrustedPush is returned if a peer not configured as a block producer
+// pushes a block.
+var errUntrustedPush = errors.New("block pushed by untrusted peer")
+
+// bpushInfo represents a short summary of the `bpush` sub-protocol metadata
+// known about a connected peer.
+type bpushInfo struct {
+	Version   uint `json:"version"`   // Bpush protocol version negotiated
+	Replica   bool `json:"replica"`   // Whether blocks are pushed to the peer
+	Sequencer bool `json:"sequencer"` // Whether blocks pushed by the peer are imported
+}
+
+// bpushPeerSet is the set of replicas connected on the `bpush` protocol.
+type bpushPeerSet struct {
+	peers map[string]*bpush.Peer
+	lock  sync.RWMutex
+}
+
+// bpushHandler implements the bpush.Backend interface to import the blocks
+// pushed by the configured block producers.
+type bpushHandler handler
+
+// RunPeer is invoked when a peer joins on the `bpush` protocol.
+func (h *bpushHandler) RunPeer(peer *bpush.Peer, hand bpush.Handler) error {
+	if !(*handler)(h).incHandlers() {
+		return p2p.DiscQuitting
+	}
+	defer (*handler)(h).decHandlers()
+
+	// Only track the replicas, the other peers may only push blocks to us.
+	if _, ok := h.pushReplicas[peer.Peer.ID()]; ok {
+		h.bpushPeers.lock.Lock()
+		if _, ok := h.bpushPeers.peers[peer.ID()]; ok {
+			h.bpushPeers.lock.Unlock()
+			return errPeerAlreadyRegistered
+		}
+		h.bpushPeers.peers[peer.ID()] = peer
+		h.bpushPeers.lock.Unlock()
+
+		defer func() {
+			h.bpushPeers.lock.Lock()
+			delete(h.bpushPeers.peers, peer.ID())
+			h.bpushPeers.lock.Unlock()
+		}()
+	}
+	return hand(peer)
+}
+
+// PeerInfo retrieves all known `bpush` information about a peer.
+func (h *bpushHandler) PeerInfo(id enode.ID) interface{} {
+	_, replica := h.pushReplicas[id]
+	_, sequencer := h.pushSequencers[id]
+	if !replica && !sequencer {
+		return nil
+	}
+	return &bpushInfo{Version: bpush.BPUSH1, Replica: replica, Sequencer: sequencer}
+}
+
+// Handle is invoked from a peer's message handler when it receives a new remote
+// message that the handler couldn't consume and serve itself.
+func (h *bpushHandler) Handle(peer *bpush.Peer, packet bpush.Packet) error {
+	switch packet := packet.(type) {
+	case *bpush.BlockPacket:
+		if _, ok := h.pushSequencers[peer.Peer.ID()]; !ok {
+			return errUntrustedPush
+		}
+		if packet.Block == nil {
+			return errors.New("empty block push")
+		}
+		h.blockImporter.Enqueue(peer.ID(), packet.Block)
+		return nil
+
+	default:
+		return fmt.Errorf("unexpected bpush packet type: %T", packet)
+	}
+}
+
+// blockPushLoop pushes the new head blocks to the connected replicas.
+func (h *handler) blockPushLoop() {
+	defer h.wg.Done()
+
+	for {
+		select {
+		case ev := <-h.pushHeadCh:
+			block := h.chain.GetBlock(ev.Header.Hash(), ev.Header.Number.Uint64())
+			if block == nil {
+				continue
+			}
+			h.pushBlock(block)
+
+		case <-h.pushHeadSub.Err():
+			return
+		}
+	}
+}
+
+// pushBlock sends a block to all the connected replicas.
+func (h *handler) pushBlock(block *types.Block) {
+	h.bpushPeers.lock.RLock()
+	defer h.bpushPeers.lock.RUnlock()
+
+	for _, peer := range h.bpushPeers.peers {
+		go func(peer *bpush.Peer) {
+			if err := peer.SendBlock(block); err != nil {
+				peer.Log().Debug("Failed to push block", "number", block.Number(), "hash", block.Hash(), "err", err)
+			}
+		}(peer)
+	}
+}
+
+// insertPushedBlock imports a pushed block and makes it the head of the chain.
+func (h *handler) insertPushedBlock(block *types.Block) error {
+	_, err := h.chain.InsertChain(types.Blocks{block})
+	return err
+}
+
+// pushEnabled reports whether blocks are pushed to or imported from peers.
+func (h *handler) pushEnabled() bool {
+	return len(h.pushReplicas) > 0 || len(h.pushSequencers) > 0
+}
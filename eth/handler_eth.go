@@ -49,7 +49,7 @@ func (h *ethHandler) PeerInfo(id enode.ID) interface{} {
 // AcceptTxs retrieves whether transaction processing is enabled on the node
 // or if inbound transactions should simply be dropped.
 func (h *ethHandler) AcceptTxs() bool {
-	return h.synced.Load()
+	return h.synced.Load() && !h.txGossip.noIngress.Load()
 }
 
 // Handle is invoked from a peer's message handler when it receives a new remote
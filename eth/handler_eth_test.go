@@ -119,8 +119,9 @@ func testForkIDSplit(t *testing.T, protocol uint) {
 			BloomCache: 1,
 		})
 	)
-	ethNoFork.Start(1000)
-	ethProFork.Start(1000)
+	maxPeers := func() int { return 1000 }
+	ethNoFork.Start(maxPeers)
+	ethProFork.Start(maxPeers)
 
 	// Clean up everything after ourselves
 	defer chainNoFork.Stop()
@@ -277,6 +278,49 @@ func testRecvTransactions(t *testing.T, protocol uint) {
 	}
 }
 
+// This test checks that transactions gossiped by peers are dropped if tx ingress
+// is disabled.
+func TestRecvTransactionsIngressDisabled(t *testing.T) {
+	t.Parallel()
+
+	handler := newTestHandler(ethconfig.FullSync)
+	defer handler.close()
+
+	handler.handler.synced.Store(true) // mark synced to accept transactions
+	handler.handler.SetTxGossip(false, true)
+
+	txs := make(chan core.NewTxsEvent)
+	sub := handler.txpool.SubscribeTransactions(txs, false)
+	defer sub.Unsubscribe()
+
+	p2pSrc, p2pSink := p2p.MsgPipe()
+	defer p2pSrc.Close()
+	defer p2pSink.Close()
+
+	src := eth.NewPeer(eth.ETH68, p2p.NewPeerPipe(enode.ID{1}, "", nil, p2pSrc), p2pSrc, handler.txpool)
+	sink := eth.NewPeer(eth.ETH68, p2p.NewPeerPipe(enode.ID{2}, "", nil, p2pSink), p2pSink, handler.txpool)
+	defer src.Close()
+	defer sink.Close()
+
+	go handler.handler.runEthPeer(sink, func(peer *eth.Peer) error {
+		return eth.Handle((*ethHandler)(handler.handler), peer)
+	})
+	if err := src.Handshake(1, handler.chain, eth.BlockRangeUpdatePacket{}); err != nil {
+		t.Fatalf("failed to run protocol handshake")
+	}
+	tx := types.NewTransaction(0, common.Address{}, big.NewInt(0), 100000, big.NewInt(0), nil)
+	tx, _ = types.SignTx(tx, types.HomesteadSigner{}, testKey)
+
+	if err := src.SendTransactions([]*types.Transaction{tx}); err != nil {
+		t.Fatalf("failed to send transaction: %v", err)
+	}
+	select {
+	case event := <-txs:
+		t.Errorf("transactions added despite disabled ingress: %d", len(event.Txs))
+	case <-time.After(500 * time.Millisecond):
+	}
+}
+
 // This test checks that pending transactions are sent.
 func TestSendTransactions68(t *testing.T) { testSendTransactions(t, eth.ETH68) }
 
@@ -212,7 +212,7 @@ func newTestHandlerWithBlocks(blocks int, mode ethconfig.SyncMode) *testHandler
 		Sync:       mode,
 		BloomCache: 1,
 	})
-	handler.Start(1000)
+	handler.Start(func() int { return 1000 })
 
 	return &testHandler{
 		db:      db,
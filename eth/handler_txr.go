@@ -0,0 +1,188 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/eth/fetcher"
+	"github.com/ethereum/go-ethereum/eth/protocols/eth"
+	"github.com/ethereum/go-ethereum/eth/protocols/txr"
+	"github.com/ethereum/go-ethereum/p2p"
+	"github.com/ethereum/go-ethereum/p2p/enode"
+)
+
+const (
+	// txRejectChanSize is the size of channel listening to the transactions
+	// rejected by the local pool.
+	txRejectChanSize = 64
+
+	// txRejectInterval is the interval at which the rejections are shared.
+	txRejectInterval = time.Second
+)
+
+// errTxrWithoutEth is returned if a peer attempts to connect only on the txr
+// protocol without advertising the eth main protocol.
+var errTxrWithoutEth = errors.New("peer connected on txr without compatible eth support")
+
+// txrPeer is a wrapper around txr.Peer tracking the in-flight sends.
+type txrPeer struct {
+	*txr.Peer
+	sending atomic.Bool // Whether a batch is being sent, the peer is skipped if so
+}
+
+// txrInfo represents a short summary of the `txr` sub-protocol metadata known
+// about a connected peer.
+type txrInfo struct {
+	Version uint `json:"version"` // Txr protocol version negotiated
+}
+
+// txrPeerSet is the set of peers sharing their transaction rejections.
+type txrPeerSet struct {
+	peers map[string]*txrPeer
+	lock  sync.RWMutex
+}
+
+// txrHandler implements the txr.Backend interface to handle the rejections
+// shared by the remote peers.
+type txrHandler handler
+
+// RunPeer is invoked when a peer joins on the `txr` protocol.
+func (h *txrHandler) RunPeer(peer *txr.Peer, hand txr.Handler) error {
+	if !(*handler)(h).incHandlers() {
+		return p2p.DiscQuitting
+	}
+	defer (*handler)(h).decHandlers()
+
+	// Reject the peer if it advertises `txr` without `eth`, the rejections only
+	// being meaningful for the transactions announced over `eth`
+	if !peer.RunningCap(eth.ProtocolName, eth.ProtocolVersions) {
+		return fmt.Errorf("%w: have %v", errTxrWithoutEth, peer.Caps())
+	}
+	h.txrPeers.lock.Lock()
+	if _, ok := h.txrPeers.peers[peer.ID()]; ok {
+		h.txrPeers.lock.Unlock()
+		return errPeerAlreadyRegistered
+	}
+	h.txrPeers.peers[peer.ID()] = &txrPeer{Peer: peer}
+	h.txrPeers.lock.Unlock()
+
+	defer func() {
+		h.txrPeers.lock.Lock()
+		delete(h.txrPeers.peers, peer.ID())
+		h.txrPeers.lock.Unlock()
+	}()
+	return hand(peer)
+}
+
+// PeerInfo retrieves all known `txr` information about a peer.
+func (h *txrHandler) PeerInfo(id enode.ID) interface{} {
+	h.txrPeers.lock.RLock()
+	defer h.txrPeers.lock.RUnlock()
+
+	if p := h.txrPeers.peers[id.String()]; p != nil {
+		return &txrInfo{Version: p.Version()}
+	}
+	return nil
+}
+
+// Handle is invoked from a peer's message handler when it receives a new remote
+// message that the handler couldn't consume and serve itself.
+func (h *txrHandler) Handle(peer *txr.Peer, packet txr.Packet) error {
+	switch packet := packet.(type) {
+	case *txr.RejectionsPacket:
+		// Ignore the rejections if we don't fetch transactions anyway
+		if !h.synced.Load() || h.txGossip.noIngress.Load() {
+			return nil
+		}
+		rejections := make([]fetcher.TxRejection, 0, len(*packet))
+		for _, r := range *packet {
+			switch r.Reason {
+			case txr.ReasonUnderpriced:
+				rejections = append(rejections, fetcher.TxRejection{Hash: r.Hash, Reason: fetcher.TxRejectUnderpriced})
+			case txr.ReasonNonceTooLow:
+				rejections = append(rejections, fetcher.TxRejection{Hash: r.Hash, Reason: fetcher.TxRejectNonceTooLow})
+			}
+		}
+		h.txFetcher.NotifyRejections(peer.ID(), rejections)
+		return nil
+
+	default:
+		return fmt.Errorf("unexpected txr packet type: %T", packet)
+	}
+}
+
+// txRejectLoop shares the transactions rejected by the local pool with the
+// peers running the `txr` protocol, batched at regular intervals.
+func (h *handler) txRejectLoop() {
+	defer h.wg.Done()
+
+	var (
+		pending []txr.Rejection
+		ticker  = time.NewTicker(txRejectInterval)
+	)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case batch := <-h.txRejectCh:
+			for _, r := range batch {
+				if len(pending) >= txr.MaxRejections {
+					break
+				}
+				switch r.Reason {
+				case fetcher.TxRejectUnderpriced:
+					pending = append(pending, txr.Rejection{Hash: r.Hash, Reason: txr.ReasonUnderpriced})
+				case fetcher.TxRejectNonceTooLow:
+					pending = append(pending, txr.Rejection{Hash: r.Hash, Reason: txr.ReasonNonceTooLow})
+				}
+			}
+		case <-ticker.C:
+			if len(pending) == 0 {
+				continue
+			}
+			h.broadcastRejections(pending)
+			pending = nil
+
+		case <-h.txRejectSub.Err():
+			return
+		}
+	}
+}
+
+// broadcastRejections sends a batch of rejections to all the `txr` peers which
+// aren't still busy with the previous batch.
+func (h *handler) broadcastRejections(rejections []txr.Rejection) {
+	h.txrPeers.lock.RLock()
+	defer h.txrPeers.lock.RUnlock()
+
+	for _, peer := range h.txrPeers.peers {
+		if !peer.sending.CompareAndSwap(false, true) {
+			continue
+		}
+		go func(peer *txrPeer) {
+			defer peer.sending.Store(false)
+			if err := peer.SendRejections(rejections); err != nil {
+				peer.Log().Debug("Failed to share transaction rejections", "err", err)
+			}
+		}(peer)
+	}
+}
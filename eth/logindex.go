@@ -0,0 +1,88 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"sync/atomic"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/filtermaps"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// logIndex registers the filtermaps log index with the blockchain, following
+// the chain head tracked for all the chain indexes.
+type logIndex struct {
+	maps    *filtermaps.FilterMaps
+	chain   *core.BlockChain
+	history uint64 // Number of recent blocks to index, 0 for the entire chain
+	head    atomic.Pointer[types.Header]
+}
+
+// Name implements core.ChainIndex, returning the identifier of the log index.
+func (idx *logIndex) Name() string {
+	return "logs"
+}
+
+// SetHead implements core.ChainIndex, setting the new target of the log index.
+func (idx *logIndex) SetHead(head *types.Header) {
+	idx.head.Store(head)
+
+	historyCutoff, _ := idx.chain.HistoryPruningCutoff()
+	var finalBlock uint64
+	if fb := idx.chain.CurrentFinalBlock(); fb != nil {
+		finalBlock = fb.Number.Uint64()
+	}
+	view := filtermaps.NewChainView(idx.chain, head.Number.Uint64(), head.Hash())
+	idx.maps.SetTarget(view, historyCutoff, finalBlock)
+}
+
+// SetBlockProcessing suspends the log index rendering while blocks are processed.
+func (idx *logIndex) SetBlockProcessing(processing bool) {
+	idx.maps.SetBlockProcessing(processing)
+}
+
+// Status implements core.ChainIndex, returning the progress of the log index.
+func (idx *logIndex) Status() core.IndexStatus {
+	head := idx.head.Load()
+	if head == nil {
+		return core.IndexStatus{}
+	}
+	var (
+		number   = head.Number.Uint64()
+		first, _ = idx.chain.HistoryPruningCutoff()
+		indexed  = idx.maps.IndexedBlocks()
+	)
+	if idx.history != 0 && number+1 > idx.history {
+		first = max(first, number+1-idx.history)
+	}
+	var target common.Range[uint64]
+	if first <= number {
+		target = common.NewRange(first, number+1-first)
+	}
+	status := core.IndexStatus{
+		Head:      number,
+		Indexed:   indexed.Count(),
+		Remaining: target.Count() - target.Intersection(indexed).Count(),
+	}
+	if !indexed.IsEmpty() {
+		tail := indexed.First()
+		status.Tail = &tail
+	}
+	return status
+}
@@ -0,0 +1,70 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package bpush implements the `bpush` satellite protocol, through which a block
+// producer pushes its new head blocks to its replicas as soon as they are
+// imported, without waiting for the replicas' consensus clients.
+package bpush
+
+import (
+	"errors"
+
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Constants to match up protocol versions and messages
+const (
+	BPUSH1 = 1
+)
+
+// ProtocolName is the official short name of the `bpush` protocol used during
+// devp2p capability negotiation.
+const ProtocolName = "bpush"
+
+// ProtocolVersions are the supported versions of the `bpush` protocol (first
+// is primary).
+var ProtocolVersions = []uint{BPUSH1}
+
+// protocolLengths are the number of implemented message corresponding to
+// different protocol versions.
+var protocolLengths = map[uint]uint64{BPUSH1: 1}
+
+// maxMessageSize is the maximum cap on the size of a protocol message.
+const maxMessageSize = 10 * 1024 * 1024
+
+const (
+	BlockMsg = 0x00
+)
+
+var (
+	errMsgTooLarge    = errors.New("message too long")
+	errDecode         = errors.New("invalid message")
+	errInvalidMsgCode = errors.New("invalid message code")
+)
+
+// Packet represents a p2p message in the `bpush` protocol.
+type Packet interface {
+	Name() string // Name returns a string corresponding to the message type.
+	Kind() byte   // Kind returns the message type.
+}
+
+// BlockPacket is the network packet pushing a new head block.
+type BlockPacket struct {
+	Block *types.Block
+}
+
+func (*BlockPacket) Name() string { return "Block" }
+func (*BlockPacket) Kind() byte   { return BlockMsg }
@@ -0,0 +1,162 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package snap
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state/snapshot"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
+)
+
+// flatAccountIterator returns an iterator over the flat state of the accounts,
+// which is the snapshot in hash mode and the state of the path database.
+func flatAccountIterator(chain *core.BlockChain, root common.Hash, origin common.Hash) (snapshot.AccountIterator, error) {
+	if chain.TrieDB().Scheme() == rawdb.HashScheme {
+		if chain.Snapshots() == nil {
+			return nil, errNoFlatState
+		}
+		return chain.Snapshots().AccountIterator(root, origin)
+	}
+	return chain.TrieDB().AccountIterator(root, origin)
+}
+
+// flatStorageIterator returns an iterator over the flat state of the storage
+// slots of an account.
+func flatStorageIterator(chain *core.BlockChain, root common.Hash, account common.Hash, origin common.Hash) (snapshot.StorageIterator, error) {
+	if chain.TrieDB().Scheme() == rawdb.HashScheme {
+		if chain.Snapshots() == nil {
+			return nil, errNoFlatState
+		}
+		return chain.Snapshots().StorageIterator(root, account, origin)
+	}
+	return chain.TrieDB().StorageIterator(root, account, origin)
+}
+
+// newAccountIterator returns an iterator over the accounts of the given state.
+// The flat state is preferred, but it may be unavailable, e.g. while it is
+// (re)generated after path database pruning or a rewind. In that case the
+// accounts are read from the trie, which is slower but available for every state
+// the database retains.
+func newAccountIterator(chain *core.BlockChain, root common.Hash, origin common.Hash) (snapshot.AccountIterator, error) {
+	it, err := flatAccountIterator(chain, root, origin)
+	if err == nil {
+		return it, nil
+	}
+	tr, trieErr := trie.New(trie.StateTrieID(root), chain.TrieDB())
+	if trieErr != nil {
+		staleStateMeter.Mark(1)
+		log.Debug("Requested state unavailable", "root", root, "err", trieErr)
+		return nil, trieErr
+	}
+	nodeIt, trieErr := tr.NodeIterator(origin[:])
+	if trieErr != nil {
+		return nil, trieErr
+	}
+	fallbackServeMeter.Mark(1)
+	log.Trace("Serving accounts from trie", "root", root, "reason", err)
+	return &trieAccountIterator{it: trie.NewIterator(nodeIt)}, nil
+}
+
+// newStorageIterator returns an iterator over the storage slots of an account,
+// falling back to the storage trie if the flat state is unavailable.
+func newStorageIterator(chain *core.BlockChain, root common.Hash, account common.Hash, origin common.Hash) (snapshot.StorageIterator, error) {
+	it, err := flatStorageIterator(chain, root, account, origin)
+	if err == nil {
+		return it, nil
+	}
+	accTrie, trieErr := trie.NewStateTrie(trie.StateTrieID(root), chain.TrieDB())
+	if trieErr != nil {
+		staleStateMeter.Mark(1)
+		log.Debug("Requested state unavailable", "root", root, "err", trieErr)
+		return nil, trieErr
+	}
+	acc, trieErr := accTrie.GetAccountByHash(account)
+	if trieErr != nil {
+		return nil, trieErr
+	}
+	if acc == nil || acc.Root == types.EmptyRootHash {
+		return &trieStorageIterator{}, nil
+	}
+	stTrie, trieErr := trie.New(trie.StorageTrieID(root, account, acc.Root), chain.TrieDB())
+	if trieErr != nil {
+		return nil, trieErr
+	}
+	nodeIt, trieErr := stTrie.NodeIterator(origin[:])
+	if trieErr != nil {
+		return nil, trieErr
+	}
+	fallbackServeMeter.Mark(1)
+	log.Trace("Serving storage from trie", "root", root, "account", account, "reason", err)
+	return &trieStorageIterator{it: trie.NewIterator(nodeIt)}, nil
+}
+
+// trieAccountIterator is a snapshot.AccountIterator over a state trie.
+type trieAccountIterator struct {
+	it      *trie.Iterator
+	account []byte // slim RLP encoding of the current account
+	err     error
+}
+
+func (it *trieAccountIterator) Next() bool {
+	if it.err != nil || !it.it.Next() {
+		return false
+	}
+	var account types.StateAccount
+	if err := rlp.DecodeBytes(it.it.Value, &account); err != nil {
+		it.err = err
+		return false
+	}
+	it.account = types.SlimAccountRLP(account)
+	return true
+}
+
+func (it *trieAccountIterator) Error() error {
+	if it.err != nil {
+		return it.err
+	}
+	return it.it.Err
+}
+
+func (it *trieAccountIterator) Hash() common.Hash { return common.BytesToHash(it.it.Key) }
+func (it *trieAccountIterator) Account() []byte   { return it.account }
+func (it *trieAccountIterator) Release()          {}
+
+// trieStorageIterator is a snapshot.StorageIterator over a storage trie. The
+// iterator is empty if it has no underlying trie.
+type trieStorageIterator struct {
+	it *trie.Iterator
+}
+
+func (it *trieStorageIterator) Next() bool {
+	return it.it != nil && it.it.Next()
+}
+
+func (it *trieStorageIterator) Error() error {
+	if it.it == nil {
+		return nil
+	}
+	return it.it.Err
+}
+
+func (it *trieStorageIterator) Hash() common.Hash { return common.BytesToHash(it.it.Key) }
+func (it *trieStorageIterator) Slot() []byte      { return it.it.Value }
+func (it *trieStorageIterator) Release()          {}
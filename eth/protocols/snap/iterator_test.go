@@ -0,0 +1,109 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package snap
+
+import (
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func newServingChain(t *testing.T, scheme string, snapshots bool) (*core.BlockChain, common.Hash) {
+	alloc := make(types.GenesisAlloc)
+	for i := 0; i < 100; i++ {
+		acc := types.Account{Balance: big.NewInt(int64(i + 1))}
+		if i%10 == 0 {
+			acc.Storage = make(map[common.Hash]common.Hash)
+			for j := 0; j < 50; j++ {
+				acc.Storage[common.BigToHash(big.NewInt(int64(j)))] = common.BigToHash(big.NewInt(int64(j + 1)))
+			}
+		}
+		alloc[common.BigToAddress(big.NewInt(int64(i+0x100)))] = acc
+	}
+	gspec := &core.Genesis{Config: params.TestChainConfig, Alloc: alloc}
+	_, blocks, _ := core.GenerateChainWithGenesis(gspec, ethash.NewFaker(), 2, func(i int, gen *core.BlockGen) {})
+
+	options := core.DefaultConfig().WithStateScheme(scheme)
+	options.SnapshotWait = true
+	if !snapshots {
+		options.SnapshotLimit = 0
+	}
+	chain, err := core.NewBlockChain(rawdb.NewMemoryDatabase(), gspec, ethash.NewFaker(), options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(chain.Stop)
+	if _, err := chain.InsertChain(blocks); err != nil {
+		t.Fatal(err)
+	}
+	return chain, blocks[len(blocks)-1].Root()
+}
+
+// Tests that state ranges are served from the tries if the flat state is not
+// available, with the same result as from the flat state.
+func TestServeRangesWithoutFlatState(t *testing.T) {
+	var (
+		flat, root = newServingChain(t, rawdb.HashScheme, true)
+		tries, _   = newServingChain(t, rawdb.HashScheme, false)
+	)
+	accReq := &GetAccountRangePacket{Root: root, Limit: common.MaxHash, Bytes: 2000}
+	wantAccs, wantProof := ServiceGetAccountRangeQuery(flat, accReq)
+	if len(wantAccs) == 0 {
+		t.Fatal("no accounts served from flat state")
+	}
+	haveAccs, haveProof := ServiceGetAccountRangeQuery(tries, accReq)
+	if !reflect.DeepEqual(haveAccs, wantAccs) || !reflect.DeepEqual(haveProof, wantProof) {
+		t.Fatalf("account ranges mismatch: have %d accounts, want %d", len(haveAccs), len(wantAccs))
+	}
+
+	// Request the storage of all accounts with storage, starting mid-range.
+	all, _ := ServiceGetAccountRangeQuery(flat, &GetAccountRangePacket{Root: root, Limit: common.MaxHash, Bytes: softResponseLimit})
+	var accounts []common.Hash
+	for _, acc := range all {
+		if full, _ := types.FullAccount(acc.Body); full.Root != types.EmptyRootHash {
+			accounts = append(accounts, acc.Hash)
+		}
+	}
+	stReq := &GetStorageRangesPacket{Root: root, Accounts: accounts, Origin: common.Hash{0x01}.Bytes(), Bytes: 1000}
+	wantSlots, wantProof := ServiceGetStorageRangesQuery(flat, stReq)
+	if len(wantSlots) == 0 {
+		t.Fatal("no storage served from flat state")
+	}
+	stReq = &GetStorageRangesPacket{Root: root, Accounts: accounts, Origin: common.Hash{0x01}.Bytes(), Bytes: 1000}
+	haveSlots, haveProof := ServiceGetStorageRangesQuery(tries, stReq)
+	if !reflect.DeepEqual(haveSlots, wantSlots) || !reflect.DeepEqual(haveProof, wantProof) {
+		t.Fatalf("storage ranges mismatch: have %d, want %d", len(haveSlots), len(wantSlots))
+	}
+}
+
+// Tests that ranges of unavailable states are answered with an empty response.
+func TestServeRangesStaleState(t *testing.T) {
+	for _, scheme := range []string{rawdb.HashScheme, rawdb.PathScheme} {
+		chain, _ := newServingChain(t, scheme, true)
+		accs, proof := ServiceGetAccountRangeQuery(chain, &GetAccountRangePacket{Root: common.Hash{0x01}, Limit: common.MaxHash, Bytes: 2000})
+		if len(accs) != 0 || len(proof) != 0 {
+			t.Errorf("%s: served %d accounts of unknown state", scheme, len(accs))
+		}
+	}
+}
@@ -58,6 +58,14 @@ var (
 	// to retrieved concurrently.
 	largeStorageGauge = metrics.NewRegisteredGauge("eth/protocols/snap/sync/storage/large", nil)
 
+	// fallbackServeMeter counts the range requests served from the tries, as the
+	// flat state was not available.
+	fallbackServeMeter = metrics.NewRegisteredMeter("eth/protocols/snap/serve/fallback", nil)
+
+	// staleStateMeter counts the range requests for states which are no longer
+	// (or not yet) available locally, answered with an empty response.
+	staleStateMeter = metrics.NewRegisteredMeter("eth/protocols/snap/serve/stale", nil)
+
 	// skipStorageHealingGauge is the metric to track how many storages are retrieved
 	// in multiple requests but healing is not necessary.
 	skipStorageHealingGauge = metrics.NewRegisteredGauge("eth/protocols/snap/sync/storage/noheal", nil)
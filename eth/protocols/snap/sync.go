@@ -455,6 +455,7 @@ type Syncer struct {
 	peerJoin *event.Feed         // Event feed to react to peers joining
 	peerDrop *event.Feed         // Event feed to react to peers dropping
 	rates    *msgrate.Trackers   // Message throughput rates for peers
+	limiter  *msgrate.Limiter    // Bandwidth limiter of the retrievals, nil if unlimited
 
 	// Request tracking during syncing phase
 	statelessPeers map[string]struct{} // Peers that failed to deliver state data
@@ -600,6 +601,30 @@ func (s *Syncer) Unregister(id string) error {
 	return nil
 }
 
+// SetBandwidthLimiter caps the bandwidth used for retrieving state. It must be
+// called before the first sync cycle.
+func (s *Syncer) SetBandwidthLimiter(limiter *msgrate.Limiter) {
+	s.limiter = limiter
+}
+
+// throttleDelay returns the time until the first peer throttled by the
+// bandwidth limiter may be sent requests again, or zero if none is throttled.
+func (s *Syncer) throttleDelay() time.Duration {
+	if s.limiter == nil {
+		return 0
+	}
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	var delay time.Duration
+	for id := range s.peers {
+		if wait := s.limiter.Delay(id); wait > 0 && (delay == 0 || wait < delay) {
+			delay = wait
+		}
+	}
+	return delay
+}
+
 // Sync starts (or resumes a previous) sync cycle to iterate over a state trie
 // with the given root and reconstruct the nodes based on the snapshot leaves.
 // Previously downloaded segments will not be redownloaded of fixed, rather any
@@ -732,10 +757,19 @@ func (s *Syncer) Sync(root common.Hash, cancel chan struct{}) error {
 			BytecodeHealBytes:  s.bytecodeHealBytes,
 		}
 		s.lock.Unlock()
+
+		// If peers are throttled by the bandwidth limiter, wake up when the
+		// first one may be sent a request again
+		var wake <-chan time.Time
+		if delay := s.throttleDelay(); delay > 0 {
+			wake = time.After(delay)
+		}
 		// Wait for something to happen
 		select {
 		case <-s.update:
 			// Something happened (new peer, delivery, timeout), recheck tasks
+		case <-wake:
+			// A throttled peer may be sent requests again, recheck tasks
 		case <-peerJoin:
 			// A new peer joined, try to schedule it new tasks
 		case id := <-peerDrop:
@@ -1031,6 +1065,9 @@ func (s *Syncer) assignAccountTasks(success chan *accountResponse, fail chan *ac
 		if _, ok := s.statelessPeers[id]; ok {
 			continue
 		}
+		if s.limiter.Delay(id) > 0 {
+			continue
+		}
 		idlers.ids = append(idlers.ids, id)
 		idlers.caps = append(idlers.caps, s.rates.Capacity(id, AccountRangeMsg, targetTTL))
 	}
@@ -1128,6 +1165,9 @@ func (s *Syncer) assignBytecodeTasks(success chan *bytecodeResponse, fail chan *
 		if _, ok := s.statelessPeers[id]; ok {
 			continue
 		}
+		if s.limiter.Delay(id) > 0 {
+			continue
+		}
 		idlers.ids = append(idlers.ids, id)
 		idlers.caps = append(idlers.caps, s.rates.Capacity(id, ByteCodesMsg, targetTTL))
 	}
@@ -1231,6 +1271,9 @@ func (s *Syncer) assignStorageTasks(success chan *storageResponse, fail chan *st
 		if _, ok := s.statelessPeers[id]; ok {
 			continue
 		}
+		if s.limiter.Delay(id) > 0 {
+			continue
+		}
 		idlers.ids = append(idlers.ids, id)
 		idlers.caps = append(idlers.caps, s.rates.Capacity(id, StorageRangesMsg, targetTTL))
 	}
@@ -1388,6 +1431,9 @@ func (s *Syncer) assignTrienodeHealTasks(success chan *trienodeHealResponse, fai
 		if _, ok := s.statelessPeers[id]; ok {
 			continue
 		}
+		if s.limiter.Delay(id) > 0 {
+			continue
+		}
 		idlers.ids = append(idlers.ids, id)
 		idlers.caps = append(idlers.caps, s.rates.Capacity(id, TrieNodesMsg, targetTTL))
 	}
@@ -1516,6 +1562,9 @@ func (s *Syncer) assignBytecodeHealTasks(success chan *bytecodeHealResponse, fai
 		if _, ok := s.statelessPeers[id]; ok {
 			continue
 		}
+		if s.limiter.Delay(id) > 0 {
+			continue
+		}
 		idlers.ids = append(idlers.ids, id)
 		idlers.caps = append(idlers.caps, s.rates.Capacity(id, ByteCodesMsg, targetTTL))
 	}
@@ -2509,6 +2558,8 @@ func (s *Syncer) OnAccounts(peer SyncPeer, id uint64, hashes []common.Hash, acco
 	for _, node := range proof {
 		size += common.StorageSize(len(node))
 	}
+	s.limiter.Charge(peer.ID(), int(size))
+
 	logger := peer.Log().New("reqid", id)
 	logger.Trace("Delivering range of accounts", "hashes", len(hashes), "accounts", len(accounts), "proofs", len(proof), "bytes", size)
 
@@ -2619,6 +2670,8 @@ func (s *Syncer) onByteCodes(peer SyncPeer, id uint64, bytecodes [][]byte) error
 	for _, code := range bytecodes {
 		size += common.StorageSize(len(code))
 	}
+	s.limiter.Charge(peer.ID(), int(size))
+
 	logger := peer.Log().New("reqid", id)
 	logger.Trace("Delivering set of bytecodes", "bytecodes", len(bytecodes), "bytes", size)
 
@@ -2732,6 +2785,8 @@ func (s *Syncer) OnStorage(peer SyncPeer, id uint64, hashes [][]common.Hash, slo
 	for _, node := range proof {
 		size += common.StorageSize(len(node))
 	}
+	s.limiter.Charge(peer.ID(), int(size))
+
 	logger := peer.Log().New("reqid", id)
 	logger.Trace("Delivering ranges of storage slots", "accounts", len(hashes), "hashes", hashCount, "slots", slotCount, "proofs", len(proof), "size", size)
 
@@ -2866,6 +2921,8 @@ func (s *Syncer) OnTrieNodes(peer SyncPeer, id uint64, trienodes [][]byte) error
 	for _, node := range trienodes {
 		size += common.StorageSize(len(node))
 	}
+	s.limiter.Charge(peer.ID(), int(size))
+
 	logger := peer.Log().New("reqid", id)
 	logger.Trace("Delivering set of healing trienodes", "trienodes", len(trienodes), "bytes", size)
 
@@ -2973,6 +3030,8 @@ func (s *Syncer) onHealByteCodes(peer SyncPeer, id uint64, bytecodes [][]byte) e
 	for _, code := range bytecodes {
 		size += common.StorageSize(len(code))
 	}
+	s.limiter.Charge(peer.ID(), int(size))
+
 	logger := peer.Log().New("reqid", id)
 	logger.Trace("Delivering set of healing bytecodes", "bytecodes", len(bytecodes), "bytes", size)
 
@@ -0,0 +1,85 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package txr implements the `txr` satellite protocol, through which peers share
+// the reasons their pools rejected transactions, so the other nodes can avoid
+// fetching transactions the network keeps rejecting.
+package txr
+
+import (
+	"errors"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Constants to match up protocol versions and messages
+const (
+	TXR1 = 1
+)
+
+// ProtocolName is the official short name of the `txr` protocol used during
+// devp2p capability negotiation.
+const ProtocolName = "txr"
+
+// ProtocolVersions are the supported versions of the `txr` protocol (first
+// is primary).
+var ProtocolVersions = []uint{TXR1}
+
+// protocolLengths are the number of implemented message corresponding to
+// different protocol versions.
+var protocolLengths = map[uint]uint64{TXR1: 1}
+
+// maxMessageSize is the maximum cap on the size of a protocol message.
+const maxMessageSize = 1024 * 1024
+
+// MaxRejections is the maximum number of rejections in a single message.
+const MaxRejections = 4096
+
+const (
+	RejectionsMsg = 0x00
+)
+
+// Rejection reasons, only the ones likely to apply to other nodes are shared.
+const (
+	ReasonUnderpriced = 0x01 // Fee too low, or too low to replace a pooled transaction
+	ReasonNonceTooLow = 0x02 // Nonce already used on chain
+)
+
+var (
+	errMsgTooLarge      = errors.New("message too long")
+	errDecode           = errors.New("invalid message")
+	errInvalidMsgCode   = errors.New("invalid message code")
+	errTooManyRejection = errors.New("too many rejections")
+)
+
+// Packet represents a p2p message in the `txr` protocol.
+type Packet interface {
+	Name() string // Name returns a string corresponding to the message type.
+	Kind() byte   // Kind returns the message type.
+}
+
+// Rejection is a transaction rejected by the pool of the sender.
+type Rejection struct {
+	Hash   common.Hash
+	Reason uint8
+}
+
+// RejectionsPacket is the network packet sharing a batch of recent rejections.
+// Unknown reasons must be ignored for forward compatibility.
+type RejectionsPacket []Rejection
+
+func (*RejectionsPacket) Name() string { return "Rejections" }
+func (*RejectionsPacket) Kind() byte   { return RejectionsMsg }
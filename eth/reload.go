@@ -0,0 +1,92 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/eth/ethconfig"
+)
+
+// reloadOrigin is the origin of setting changes recorded by Reload.
+const reloadOrigin = "config reload"
+
+// reloadableField is a field of ethconfig.Config which can be applied to a
+// running node through a runtime setting.
+type reloadableField struct {
+	name    string                      // path of the field in ethconfig.Config
+	setting string                      // runtime setting changing the field
+	value   func(*ethconfig.Config) any // value of the setting, as encoded in JSON
+}
+
+var reloadableFields = []reloadableField{
+	{"Miner.GasCeil", "miner.gaslimit", func(c *ethconfig.Config) any { return c.Miner.GasCeil }},
+	{"Miner.Recommit", "miner.recommit", func(c *ethconfig.Config) any { return settingDuration(c.Miner.Recommit) }},
+	{"Miner.Policy", "miner.policy", func(c *ethconfig.Config) any { return c.Miner.Policy }},
+	{"TxPool.AccountSlots", "txpool.accountslots", func(c *ethconfig.Config) any { return c.TxPool.AccountSlots }},
+	{"TxPool.GlobalSlots", "txpool.globalslots", func(c *ethconfig.Config) any { return c.TxPool.GlobalSlots }},
+	{"TxPool.AccountQueue", "txpool.accountqueue", func(c *ethconfig.Config) any { return c.TxPool.AccountQueue }},
+	{"TxPool.GlobalQueue", "txpool.globalqueue", func(c *ethconfig.Config) any { return c.TxPool.GlobalQueue }},
+	{"TrieTimeout", "cache.trie.flushinterval", func(c *ethconfig.Config) any { return settingDuration(c.TrieTimeout) }},
+}
+
+// ReloadableConfig lists the fields of ethconfig.Config which Reload can apply
+// to a running node. Changes to any other field only take effect after a restart.
+var ReloadableConfig = func() []string {
+	names := make([]string, len(reloadableFields))
+	for i, field := range reloadableFields {
+		names[i] = field.name
+	}
+	return names
+}()
+
+// Reload applies the given fields of config to the running service. The fields
+// must be listed in ReloadableConfig. Changes are made through the runtime
+// settings also changed by admin_setConfig, so they are validated and logged
+// alike, and settings which are not listed keep any value set at runtime. It
+// returns the names of the fields which were applied.
+func (s *Ethereum) Reload(config *ethconfig.Config, fields []string) ([]string, error) {
+	var (
+		applied []string
+		errs    []error
+	)
+	for _, name := range fields {
+		var field *reloadableField
+		for i := range reloadableFields {
+			if reloadableFields[i].name == name {
+				field = &reloadableFields[i]
+				break
+			}
+		}
+		if field == nil {
+			errs = append(errs, fmt.Errorf("%s: not reloadable", name))
+			continue
+		}
+		value, err := json.Marshal(field.value(config))
+		if err == nil {
+			_, err = s.settings.set(field.setting, value, reloadOrigin)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %v", name, err))
+			continue
+		}
+		applied = append(applied, name)
+	}
+	return applied, errors.Join(errs...)
+}
@@ -0,0 +1,213 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/txpool/legacypool"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/ethereum/go-ethereum/miner"
+)
+
+var settingChangeMeter = metrics.NewRegisteredMeter("eth/settings/changes", nil)
+
+// RuntimeSetting is a node setting which can be inspected and changed without
+// restarting the node.
+type RuntimeSetting struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Value       any    `json:"value"`
+}
+
+// setting is the registry entry of a runtime-tunable setting.
+type setting struct {
+	name        string
+	description string
+	get         func() any
+	set         func(raw json.RawMessage) error
+}
+
+// newSetting creates a setting whose value is JSON encoded as type T. The
+// setter is responsible for validating the value.
+func newSetting[T any](name, description string, get func() T, set func(T) error) *setting {
+	return &setting{
+		name:        name,
+		description: description,
+		get:         func() any { return get() },
+		set: func(raw json.RawMessage) error {
+			var value T
+			if err := json.Unmarshal(raw, &value); err != nil {
+				return fmt.Errorf("invalid value for %s: %v", name, err)
+			}
+			return set(value)
+		},
+	}
+}
+
+// settingDuration is a duration encoded as a Go duration string, e.g. "2s".
+type settingDuration time.Duration
+
+func (d settingDuration) String() string {
+	return time.Duration(d).String()
+}
+
+func (d settingDuration) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+func (d *settingDuration) UnmarshalText(input []byte) error {
+	v, err := time.ParseDuration(string(input))
+	if err != nil {
+		return err
+	}
+	*d = settingDuration(v)
+	return nil
+}
+
+// settingsRegistry is the set of runtime-tunable settings of a node. Changes
+// are serialised and logged, so the log holds an audit trail of all
+// adjustments made to a running node.
+type settingsRegistry struct {
+	settings map[string]*setting
+	lock     sync.Mutex // Serialises changes, keeping the audit log ordered
+}
+
+// newSettingsRegistry creates the registry of runtime-tunable settings of the
+// given node.
+func newSettingsRegistry(eth *Ethereum) *settingsRegistry {
+	r := &settingsRegistry{settings: make(map[string]*setting)}
+
+	// Transaction gossip toggles
+	r.register(newSetting("txgossip.ingress", "Accept transactions gossiped by peers",
+		func() bool { ingress, _ := eth.handler.TxGossip(); return ingress },
+		func(on bool) error {
+			_, egress := eth.handler.TxGossip()
+			eth.handler.SetTxGossip(on, egress)
+			return nil
+		},
+	))
+	r.register(newSetting("txgossip.egress", "Gossip transactions to peers",
+		func() bool { _, egress := eth.handler.TxGossip(); return egress },
+		func(on bool) error {
+			ingress, _ := eth.handler.TxGossip()
+			eth.handler.SetTxGossip(ingress, on)
+			return nil
+		},
+	))
+	// Transaction pool limits
+	limit := func(field func(*legacypool.Limits) *uint64) (func() uint64, func(uint64) error) {
+		get := func() uint64 {
+			limits := eth.legacyTxPool.Limits()
+			return *field(&limits)
+		}
+		set := func(v uint64) error {
+			limits := eth.legacyTxPool.Limits()
+			*field(&limits) = v
+			return eth.legacyTxPool.SetLimits(limits)
+		}
+		return get, set
+	}
+	get, set := limit(func(l *legacypool.Limits) *uint64 { return &l.AccountSlots })
+	r.register(newSetting("txpool.accountslots", "Executable transaction slots guaranteed per account", get, set))
+	get, set = limit(func(l *legacypool.Limits) *uint64 { return &l.GlobalSlots })
+	r.register(newSetting("txpool.globalslots", "Maximum executable transaction slots for all accounts", get, set))
+	get, set = limit(func(l *legacypool.Limits) *uint64 { return &l.AccountQueue })
+	r.register(newSetting("txpool.accountqueue", "Maximum non-executable transaction slots per account", get, set))
+	get, set = limit(func(l *legacypool.Limits) *uint64 { return &l.GlobalQueue })
+	r.register(newSetting("txpool.globalqueue", "Maximum non-executable transaction slots for all accounts", get, set))
+
+	// Block building
+	r.register(newSetting("miner.gaslimit", "Gas limit target of built blocks, within the configured bounds",
+		func() uint64 { return eth.miner.GasCeil() },
+		func(ceil uint64) error { return eth.miner.SetGasCeil(ceil) },
+	))
+	r.register(newSetting("miner.policy", "Addresses and selectors denied or allowed in built blocks",
+		func() miner.TxPolicy { return eth.miner.TxPolicy() },
+		func(policy miner.TxPolicy) error { eth.miner.SetTxPolicy(policy); return nil },
+	))
+	r.register(newSetting("miner.recommit", "Interval at which payloads are rebuilt, bounding the time spent filling each",
+		func() settingDuration { return settingDuration(eth.miner.Recommit()) },
+		func(d settingDuration) error { return eth.miner.SetRecommit(time.Duration(d)) },
+	))
+	// Caches
+	r.register(newSetting("cache.trie.flushinterval", "Block processing time after which in-memory tries are flushed to disk",
+		func() settingDuration { return settingDuration(eth.blockchain.GetTrieFlushInterval()) },
+		func(d settingDuration) error {
+			if d <= 0 {
+				return errors.New("flush interval must be positive")
+			}
+			eth.blockchain.SetTrieFlushInterval(time.Duration(d))
+			return nil
+		},
+	))
+	return r
+}
+
+func (r *settingsRegistry) register(s *setting) {
+	if _, ok := r.settings[s.name]; ok {
+		panic("duplicate runtime setting " + s.name)
+	}
+	r.settings[s.name] = s
+}
+
+// names returns the sorted names of all settings.
+func (r *settingsRegistry) names() []string {
+	names := make([]string, 0, len(r.settings))
+	for name := range r.settings {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// get returns the current value of the named setting.
+func (r *settingsRegistry) get(name string) (*RuntimeSetting, error) {
+	s, ok := r.settings[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown setting %q", name)
+	}
+	return &RuntimeSetting{Name: s.name, Description: s.description, Value: s.get()}, nil
+}
+
+// set validates and applies a new value for the named setting, recording the
+// change along with the origin of the request in the log.
+func (r *settingsRegistry) set(name string, value json.RawMessage, origin string) (*RuntimeSetting, error) {
+	s, ok := r.settings[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown setting %q", name)
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	old := s.get()
+	if err := s.set(value); err != nil {
+		log.Warn("Rejected runtime setting change", "name", name, "value", string(value), "origin", origin, "err", err)
+		return nil, err
+	}
+	settingChangeMeter.Mark(1)
+	current := s.get()
+	log.Info("Changed runtime setting", "name", name, "old", old, "new", current, "origin", origin)
+
+	return &RuntimeSetting{Name: s.name, Description: s.description, Value: current}, nil
+}
@@ -0,0 +1,81 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSettingsRegistry(t *testing.T) {
+	var (
+		limit    uint64 = 10
+		interval        = time.Second
+	)
+	r := &settingsRegistry{settings: make(map[string]*setting)}
+	r.register(newSetting("test.limit", "A limit",
+		func() uint64 { return limit },
+		func(v uint64) error {
+			if v == 0 {
+				return errors.New("zero limit")
+			}
+			limit = v
+			return nil
+		},
+	))
+	r.register(newSetting("test.interval", "An interval",
+		func() settingDuration { return settingDuration(interval) },
+		func(d settingDuration) error { interval = time.Duration(d); return nil },
+	))
+	if names := r.names(); len(names) != 2 || names[0] != "test.interval" || names[1] != "test.limit" {
+		t.Fatalf("wrong setting names: %v", names)
+	}
+	// Valid changes are applied
+	s, err := r.set("test.limit", json.RawMessage(`20`), "test")
+	if err != nil {
+		t.Fatalf("failed to set limit: %v", err)
+	}
+	if limit != 20 || s.Value != uint64(20) {
+		t.Fatalf("limit not applied: have %d, reported %v", limit, s.Value)
+	}
+	if _, err := r.set("test.interval", json.RawMessage(`"1m30s"`), "test"); err != nil {
+		t.Fatalf("failed to set interval: %v", err)
+	}
+	if interval != 90*time.Second {
+		t.Fatalf("interval not applied: have %v", interval)
+	}
+	// Invalid changes are rejected without side effects
+	for name, value := range map[string]string{
+		"test.limit":    `0`,
+		"test.interval": `"soon"`,
+		"test.unknown":  `1`,
+	} {
+		if _, err := r.set(name, json.RawMessage(value), "test"); err == nil {
+			t.Errorf("%s: invalid value %s accepted", name, value)
+		}
+	}
+	if limit != 20 || interval != 90*time.Second {
+		t.Fatalf("rejected change applied: limit %d, interval %v", limit, interval)
+	}
+	// Values are reported in their JSON form
+	s, _ = r.get("test.interval")
+	if blob, _ := json.Marshal(s); string(blob) != `{"name":"test.interval","description":"An interval","value":"1m30s"}` {
+		t.Fatalf("wrong encoding: %s", blob)
+	}
+}
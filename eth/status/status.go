@@ -0,0 +1,349 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package status implements a node status page served on the HTTP server,
+// summarizing the state of the node for operators at a glance.
+package status
+
+import (
+	"encoding/json"
+	"html/template"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/misc/eip4844"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/txpool"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/eth"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/ethereum/go-ethereum/node"
+	"github.com/ethereum/go-ethereum/p2p"
+)
+
+// utilizationBlocks is the number of recent blocks the gas and blob utilization
+// is averaged over.
+const utilizationBlocks = 32
+
+// Register mounts the status page on the /status path of the HTTP server.
+func Register(stack *node.Node, backend *eth.Ethereum) error {
+	h := &handler{
+		chain:    backend.BlockChain(),
+		pool:     backend.TxPool(),
+		server:   stack.Server(),
+		synced:   backend.Synced,
+		progress: backend.Downloader().Progress,
+		registry: metrics.DefaultRegistry,
+		started:  time.Now(),
+	}
+	stack.RegisterHandler("Node status", "/status", h)
+	return nil
+}
+
+// handler renders the status report either as HTML or as JSON.
+type handler struct {
+	chain    *core.BlockChain
+	pool     *txpool.TxPool // Optional, nil if not available
+	server   *p2p.Server    // Optional, nil if not available
+	synced   func() bool
+	progress func() ethereum.SyncProgress
+	registry metrics.Registry
+	started  time.Time
+}
+
+// report is the status of the node at a point in time.
+type report struct {
+	Time      time.Time      `json:"time"`
+	Uptime    string         `json:"uptime"`
+	Sync      syncStatus     `json:"sync"`
+	Head      *headStatus    `json:"head"`
+	Safe      *headStatus    `json:"safe"`
+	Finalized *headStatus    `json:"finalized"`
+	Peers     peerStatus     `json:"peers"`
+	TxPool    poolStatus     `json:"txpool"`
+	Usage     usageStatus    `json:"utilization"`
+	Payload   payloadStatus  `json:"payload"`
+	Resources resourceStatus `json:"resources"`
+}
+
+type syncStatus struct {
+	Synced        bool   `json:"synced"`
+	Syncing       bool   `json:"syncing"`
+	StartingBlock uint64 `json:"startingBlock"`
+	CurrentBlock  uint64 `json:"currentBlock"`
+	HighestBlock  uint64 `json:"highestBlock"`
+}
+
+type headStatus struct {
+	Number uint64      `json:"number"`
+	Hash   common.Hash `json:"hash"`
+	Time   uint64      `json:"timestamp"`
+	Age    string      `json:"age"`
+}
+
+type peerStatus struct {
+	Count    int   `json:"count"`
+	Max      int   `json:"max"`
+	Inbound  int64 `json:"inbound"`
+	Outbound int64 `json:"outbound"`
+}
+
+type poolStatus struct {
+	Pending      int   `json:"pending"`
+	Queued       int   `json:"queued"`
+	BlobDataUsed int64 `json:"blobDataUsed"` // Bytes of blob transactions stored
+	BlobDataCap  int64 `json:"blobDataCap"`  // Bytes allowed for blob transactions
+}
+
+// usageStatus is the block space utilization averaged over the recent blocks.
+type usageStatus struct {
+	Blocks      int     `json:"blocks"`
+	GasUsed     uint64  `json:"gasUsed"`
+	GasLimit    uint64  `json:"gasLimit"`
+	Gas         float64 `json:"gas"` // Percentage of the gas limit used
+	BlobGasUsed uint64  `json:"blobGasUsed"`
+	BlobGasMax  uint64  `json:"blobGasMax"`
+	BlobGas     float64 `json:"blobGas"` // Percentage of the blob gas limit used
+}
+
+// payloadStatus is the fee recipient revenue of the latest locally built
+// payload, in gwei.
+type payloadStatus struct {
+	Total     int64 `json:"total"`
+	Priority  int64 `json:"priority"`
+	Transfers int64 `json:"transfers"`
+	Burnt     int64 `json:"burnt"`
+}
+
+type resourceStatus struct {
+	MetricsEnabled bool  `json:"metricsEnabled"`
+	CPUSystem      int64 `json:"cpuSystem"`  // Percentage of system CPU load
+	CPUProcess     int64 `json:"cpuProcess"` // Percentage of process CPU load
+	Goroutines     int64 `json:"goroutines"`
+	MemoryUsed     int64 `json:"memoryUsed"`
+	MemoryHeld     int64 `json:"memoryHeld"`
+	DiskRead       int64 `json:"diskRead"`  // Bytes read since startup
+	DiskWrite      int64 `json:"diskWrite"` // Bytes written since startup
+}
+
+// ServeHTTP implements http.Handler, rendering JSON if requested through the
+// Accept header or the format query parameter, HTML otherwise.
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	rep := h.report(time.Now())
+
+	if r.URL.Query().Get("format") == "json" || strings.Contains(r.Header.Get("Accept"), "application/json") {
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(rep); err != nil {
+			log.Debug("Failed to write status report", "err", err)
+		}
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := page.Execute(w, rep); err != nil {
+		log.Debug("Failed to render status page", "err", err)
+	}
+}
+
+// report assembles the current status of the node.
+func (h *handler) report(now time.Time) *report {
+	rep := &report{
+		Time:   now,
+		Uptime: now.Sub(h.started).Truncate(time.Second).String(),
+	}
+	// Sync state and head labels
+	progress := h.progress()
+	rep.Sync = syncStatus{
+		Synced:        h.synced(),
+		Syncing:       !progress.Done(),
+		StartingBlock: progress.StartingBlock,
+		CurrentBlock:  progress.CurrentBlock,
+		HighestBlock:  progress.HighestBlock,
+	}
+	rep.Head = newHeadStatus(h.chain.CurrentBlock(), now)
+	rep.Safe = newHeadStatus(h.chain.CurrentSafeBlock(), now)
+	rep.Finalized = newHeadStatus(h.chain.CurrentFinalBlock(), now)
+
+	// Network and transaction pool
+	if h.server != nil {
+		rep.Peers.Count = h.server.PeerCount()
+		rep.Peers.Max = h.server.PeerLimit()
+	}
+	rep.Peers.Inbound = h.gauge("p2p/peers/inbound")
+	rep.Peers.Outbound = h.gauge("p2p/peers/outbound")
+
+	if h.pool != nil {
+		rep.TxPool.Pending, rep.TxPool.Queued = h.pool.Stats()
+	}
+	rep.TxPool.BlobDataUsed = h.gauge("blobpool/dataused")
+	rep.TxPool.BlobDataCap = h.gauge("blobpool/datacap")
+
+	// Block space utilization over the recent blocks
+	rep.Usage = h.usage()
+
+	// Block building and resource usage, as tracked by the metrics
+	rep.Payload = payloadStatus{
+		Total:     h.gauge("miner/payload/revenue/total"),
+		Priority:  h.gauge("miner/payload/revenue/priority"),
+		Transfers: h.gauge("miner/payload/revenue/transfers"),
+		Burnt:     h.gauge("miner/payload/revenue/burnt"),
+	}
+	rep.Resources = resourceStatus{
+		MetricsEnabled: metrics.Enabled(),
+		CPUSystem:      h.gauge("system/cpu/sysload"),
+		CPUProcess:     h.gauge("system/cpu/procload"),
+		Goroutines:     h.gauge("system/cpu/goroutines"),
+		MemoryUsed:     h.gauge("system/memory/used"),
+		MemoryHeld:     h.gauge("system/memory/held"),
+		DiskRead:       h.counter("system/disk/readbytes"),
+		DiskWrite:      h.counter("system/disk/writebytes"),
+	}
+	return rep
+}
+
+// usage averages the gas and blob gas utilization of the recent blocks.
+func (h *handler) usage() usageStatus {
+	var (
+		res    usageStatus
+		config = h.chain.Config()
+		header = h.chain.CurrentBlock()
+	)
+	for header != nil && header.Number.Sign() > 0 && res.Blocks < utilizationBlocks {
+		res.Blocks++
+		res.GasUsed += header.GasUsed
+		res.GasLimit += header.GasLimit
+		if header.BlobGasUsed != nil {
+			res.BlobGasUsed += *header.BlobGasUsed
+			res.BlobGasMax += eip4844.MaxBlobGasPerBlock(config, header.Time)
+		}
+		header = h.chain.GetHeader(header.ParentHash, header.Number.Uint64()-1)
+	}
+	if res.GasLimit > 0 {
+		res.Gas = float64(res.GasUsed) * 100 / float64(res.GasLimit)
+	}
+	if res.BlobGasMax > 0 {
+		res.BlobGas = float64(res.BlobGasUsed) * 100 / float64(res.BlobGasMax)
+	}
+	return res
+}
+
+// gauge returns the value of a registered gauge, or zero if it doesn't exist.
+func (h *handler) gauge(name string) int64 {
+	if g, ok := h.registry.Get(name).(*metrics.Gauge); ok {
+		return g.Snapshot().Value()
+	}
+	return 0
+}
+
+// counter returns the value of a registered counter, or zero if it doesn't exist.
+func (h *handler) counter(name string) int64 {
+	if c, ok := h.registry.Get(name).(*metrics.Counter); ok {
+		return c.Snapshot().Count()
+	}
+	return 0
+}
+
+func newHeadStatus(header *types.Header, now time.Time) *headStatus {
+	if header == nil {
+		return nil
+	}
+	return &headStatus{
+		Number: header.Number.Uint64(),
+		Hash:   header.Hash(),
+		Time:   header.Time,
+		Age:    now.Sub(time.Unix(int64(header.Time), 0)).Truncate(time.Second).String(),
+	}
+}
+
+var page = template.Must(template.New("status").Funcs(template.FuncMap{
+	"bytes": func(n int64) string { return common.StorageSize(n).String() },
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<meta http-equiv="refresh" content="10">
+<title>Node status</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+table { border-collapse: collapse; margin-bottom: 1.5em; }
+th { text-align: left; padding-right: 2em; font-weight: normal; color: #555; }
+td { font-family: monospace; }
+h2 { font-size: 1.1em; margin-bottom: 0.3em; }
+</style>
+</head>
+<body>
+<h1>Node status</h1>
+<p>{{.Time.Format "2006-01-02 15:04:05 MST"}}, up {{.Uptime}}</p>
+
+<h2>Sync</h2>
+<table>
+<tr><th>Synced</th><td>{{.Sync.Synced}}</td></tr>
+<tr><th>Syncing</th><td>{{.Sync.Syncing}}</td></tr>
+{{if .Sync.Syncing}}<tr><th>Progress</th><td>{{.Sync.CurrentBlock}} / {{.Sync.HighestBlock}}</td></tr>{{end}}
+</table>
+
+<h2>Chain</h2>
+<table>
+{{with .Head}}<tr><th>Head</th><td>#{{.Number}} {{.Hash.Hex}} ({{.Age}} ago)</td></tr>{{end}}
+{{with .Safe}}<tr><th>Safe</th><td>#{{.Number}} {{.Hash.Hex}} ({{.Age}} ago)</td></tr>{{else}}<tr><th>Safe</th><td>unknown</td></tr>{{end}}
+{{with .Finalized}}<tr><th>Finalized</th><td>#{{.Number}} {{.Hash.Hex}} ({{.Age}} ago)</td></tr>{{else}}<tr><th>Finalized</th><td>unknown</td></tr>{{end}}
+</table>
+
+<h2>Peers</h2>
+<table>
+<tr><th>Connected</th><td>{{.Peers.Count}} / {{.Peers.Max}}</td></tr>
+<tr><th>Inbound</th><td>{{.Peers.Inbound}}</td></tr>
+<tr><th>Outbound</th><td>{{.Peers.Outbound}}</td></tr>
+</table>
+
+<h2>Transaction pool</h2>
+<table>
+<tr><th>Pending</th><td>{{.TxPool.Pending}}</td></tr>
+<tr><th>Queued</th><td>{{.TxPool.Queued}}</td></tr>
+<tr><th>Blob data</th><td>{{bytes .TxPool.BlobDataUsed}} / {{bytes .TxPool.BlobDataCap}}</td></tr>
+</table>
+
+<h2>Block space (last {{.Usage.Blocks}} blocks)</h2>
+<table>
+<tr><th>Gas</th><td>{{printf "%.1f" .Usage.Gas}}%</td></tr>
+<tr><th>Blob gas</th><td>{{printf "%.1f" .Usage.BlobGas}}%</td></tr>
+</table>
+
+<h2>Latest built payload (gwei)</h2>
+<table>
+<tr><th>Total</th><td>{{.Payload.Total}}</td></tr>
+<tr><th>Priority fees</th><td>{{.Payload.Priority}}</td></tr>
+<tr><th>Transfers</th><td>{{.Payload.Transfers}}</td></tr>
+<tr><th>Burnt</th><td>{{.Payload.Burnt}}</td></tr>
+</table>
+
+<h2>Resources</h2>
+{{if .Resources.MetricsEnabled}}<table>
+<tr><th>CPU (system / process)</th><td>{{.Resources.CPUSystem}}% / {{.Resources.CPUProcess}}%</td></tr>
+<tr><th>Goroutines</th><td>{{.Resources.Goroutines}}</td></tr>
+<tr><th>Memory (used / held)</th><td>{{bytes .Resources.MemoryUsed}} / {{bytes .Resources.MemoryHeld}}</td></tr>
+<tr><th>Disk (read / written)</th><td>{{bytes .Resources.DiskRead}} / {{bytes .Resources.DiskWrite}}</td></tr>
+</table>{{else}}<p>Metrics collection is disabled, run with --metrics.</p>{{end}}
+</body>
+</html>
+`))
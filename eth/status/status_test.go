@@ -0,0 +1,103 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package status
+
+import (
+	"encoding/json"
+	"math/big"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func TestStatusPage(t *testing.T) {
+	var (
+		key, _ = crypto.GenerateKey()
+		addr   = crypto.PubkeyToAddress(key.PublicKey)
+		gspec  = &core.Genesis{
+			Config: params.TestChainConfig,
+			Alloc:  types.GenesisAlloc{addr: {Balance: big.NewInt(params.Ether)}},
+		}
+		signer = types.LatestSigner(gspec.Config)
+	)
+	_, blocks, _ := core.GenerateChainWithGenesis(gspec, ethash.NewFaker(), 4, func(i int, b *core.BlockGen) {
+		tx := types.MustSignNewTx(key, signer, &types.LegacyTx{Nonce: uint64(i), To: &common.Address{}, Gas: params.TxGas, GasPrice: b.BaseFee()})
+		b.AddTx(tx)
+	})
+	chain, err := core.NewBlockChain(rawdb.NewMemoryDatabase(), gspec, ethash.NewFaker(), nil)
+	if err != nil {
+		t.Fatalf("failed to create chain: %v", err)
+	}
+	defer chain.Stop()
+	if _, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("failed to insert chain: %v", err)
+	}
+	registry := metrics.NewRegistry()
+	metrics.NewRegisteredGauge("miner/payload/revenue/total", registry).Update(42)
+
+	h := &handler{
+		chain:    chain,
+		synced:   func() bool { return true },
+		progress: func() ethereum.SyncProgress { return ethereum.SyncProgress{CurrentBlock: 4, HighestBlock: 4} },
+		registry: registry,
+		started:  time.Now(),
+	}
+	// Request the JSON report and check its content
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest("GET", "/status?format=json", nil))
+
+	var rep report
+	if err := json.Unmarshal(rec.Body.Bytes(), &rep); err != nil {
+		t.Fatalf("failed to decode report: %v", err)
+	}
+	if !rep.Sync.Synced || rep.Sync.Syncing {
+		t.Errorf("wrong sync status: %+v", rep.Sync)
+	}
+	if rep.Head == nil || rep.Head.Number != 4 || rep.Head.Hash != blocks[3].Hash() {
+		t.Errorf("wrong head: %+v", rep.Head)
+	}
+	if rep.Finalized != nil {
+		t.Errorf("unexpected finalized block: %+v", rep.Finalized)
+	}
+	if rep.Usage.Blocks != 4 || rep.Usage.GasUsed != 4*params.TxGas {
+		t.Errorf("wrong utilization: %+v", rep.Usage)
+	}
+	if rep.Payload.Total != 42 {
+		t.Errorf("wrong payload revenue: have %d, want 42", rep.Payload.Total)
+	}
+	// Request the HTML page and check it's rendered
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest("GET", "/status", nil))
+
+	if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/html") {
+		t.Fatalf("wrong content type: %s", ct)
+	}
+	if body := rec.Body.String(); !strings.Contains(body, blocks[3].Hash().Hex()) {
+		t.Errorf("head missing from status page")
+	}
+}
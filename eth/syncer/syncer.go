@@ -33,8 +33,9 @@ import (
 )
 
 type syncReq struct {
-	hash common.Hash
-	errc chan error
+	hash       common.Hash
+	checkpoint bool // whether the target is a trusted checkpoint
+	errc       chan error
 }
 
 // Syncer is an auxiliary service that allows Geth to perform full sync
@@ -43,10 +44,15 @@ type syncReq struct {
 //
 // This tool can be applied to different networks, no matter it's pre-merge or
 // post-merge, but only for full-sync.
+//
+// Alternatively, the syncer can bootstrap a fresh node from a trusted checkpoint
+// hash, in either full or snap sync mode. Once the checkpoint is reached, it is
+// marked as finalized and the consensus client is expected to take over.
 type Syncer struct {
 	stack          *node.Node
 	backend        *eth.Ethereum
 	target         common.Hash
+	checkpoint     common.Hash
 	request        chan *syncReq
 	closed         chan struct{}
 	wg             sync.WaitGroup
@@ -55,11 +61,15 @@ type Syncer struct {
 
 // Register registers the synchronization override service into the node
 // stack for launching and stopping the service controlled by node.
-func Register(stack *node.Node, backend *eth.Ethereum, target common.Hash, exitWhenSynced bool) (*Syncer, error) {
+func Register(stack *node.Node, backend *eth.Ethereum, target common.Hash, checkpoint common.Hash, exitWhenSynced bool) (*Syncer, error) {
+	if target != (common.Hash{}) && checkpoint != (common.Hash{}) {
+		return nil, errors.New("sync target and checkpoint are mutually exclusive")
+	}
 	s := &Syncer{
 		stack:          stack,
 		backend:        backend,
 		target:         target,
+		checkpoint:     checkpoint,
 		request:        make(chan *syncReq),
 		closed:         make(chan struct{}),
 		exitWhenSynced: exitWhenSynced,
@@ -87,8 +97,9 @@ func (s *Syncer) run() {
 	defer s.wg.Done()
 
 	var (
-		target *types.Header
-		ticker = time.NewTicker(time.Second * 5)
+		target     *types.Header
+		checkpoint bool // whether the target is a trusted checkpoint
+		ticker     = time.NewTicker(time.Second * 5)
 	)
 	defer ticker.Stop()
 	for {
@@ -125,14 +136,18 @@ func (s *Syncer) run() {
 					req.errc <- fmt.Errorf("stale sync target, current: %d, received: %d", target.Number, header.Number)
 					break
 				}
-				target = header
+				target, checkpoint = header, req.checkpoint
 				resync = true
 				break
 			}
 			if resync {
-				if mode := s.backend.Downloader().ConfigSyncMode(); mode != ethconfig.FullSync {
+				mode := s.backend.Downloader().ConfigSyncMode()
+				switch {
+				case checkpoint:
+					req.errc <- s.backend.Downloader().CheckpointSync(target)
+				case mode != ethconfig.FullSync:
 					req.errc <- fmt.Errorf("unsupported syncmode %v, please relaunch geth with --syncmode full", mode)
-				} else {
+				default:
 					req.errc <- s.backend.Downloader().BeaconDevSync(target)
 				}
 			}
@@ -150,6 +165,19 @@ func (s *Syncer) run() {
 					return
 				}
 			}
+			// A trusted checkpoint is final. Once it's reached, the consensus
+			// client maintains the markers.
+			if checkpoint {
+				if s.backend.BlockChain().CurrentBlock().Number.Cmp(target.Number) >= 0 {
+					if header := s.backend.BlockChain().GetHeaderByHash(target.Hash()); header != nil {
+						log.Info("Sync checkpoint reached", "number", header.Number, "hash", header.Hash())
+						s.backend.BlockChain().SetFinalized(header)
+						s.backend.BlockChain().SetSafe(header)
+						target, checkpoint = nil, false
+					}
+				}
+				continue
+			}
 
 			// Set the finalized and safe markers relative to the current head.
 			// The finalized marker is set two epochs behind the target,
@@ -179,6 +207,9 @@ func (s *Syncer) run() {
 func (s *Syncer) Start() error {
 	s.wg.Add(1)
 	go s.run()
+	if s.checkpoint != (common.Hash{}) {
+		return s.SyncCheckpoint(s.checkpoint)
+	}
 	if s.target == (common.Hash{}) {
 		return nil
 	}
@@ -196,10 +227,16 @@ func (s *Syncer) Stop() error {
 // Sync sets the synchronization target. Notably, setting a target lower than the
 // previous one is not allowed, as backward synchronization is not supported.
 func (s *Syncer) Sync(hash common.Hash) error {
-	req := &syncReq{
-		hash: hash,
-		errc: make(chan error, 1),
-	}
+	return s.sync(&syncReq{hash: hash, errc: make(chan error, 1)})
+}
+
+// SyncCheckpoint sets a trusted checkpoint as the synchronization target. Unlike
+// Sync, the checkpoint can be reached by snap sync.
+func (s *Syncer) SyncCheckpoint(hash common.Hash) error {
+	return s.sync(&syncReq{hash: hash, checkpoint: true, errc: make(chan error, 1)})
+}
+
+func (s *Syncer) sync(req *syncReq) error {
 	select {
 	case s.request <- req:
 		return <-req.errc
@@ -67,6 +67,10 @@ const (
 	// for tracing. The creation of trace state will be paused if the unused
 	// trace states exceed this limit.
 	maximumPendingTraceStates = 128
+
+	// parallelTraceGasThreshold is the amount of gas used by a block above which
+	// its transactions are traced concurrently, regardless of the tracer used.
+	parallelTraceGasThreshold = 5_000_000
 )
 
 var errTxNotFound = errors.New("transaction not found")
@@ -168,6 +172,7 @@ type TraceCallConfig struct {
 	TraceConfig
 	StateOverrides *override.StateOverride
 	BlockOverrides *override.BlockOverrides
+	GasOverrides   *override.GasOverrides
 	TxIndex        *hexutil.Uint
 }
 
@@ -188,10 +193,11 @@ type txTraceResult struct {
 // blockTraceTask represents a single block trace task when an entire chain is
 // being traced.
 type blockTraceTask struct {
-	statedb *state.StateDB   // Intermediate state prepped for tracing
-	block   *types.Block     // Block to trace the transactions from
-	release StateReleaseFunc // The function to release the held resource for this task
-	results []*txTraceResult // Trace results produced by the task
+	statedb  *state.StateDB   // Intermediate state prepped for tracing
+	block    *types.Block     // Block to trace the transactions from
+	blockCtx vm.BlockContext  // Block context to execute the transactions in
+	release  StateReleaseFunc // The function to release the held resource for this task
+	results  []*txTraceResult // Trace results produced by the task
 }
 
 // blockTraceResult represents the results of tracing a single block when an entire
@@ -268,10 +274,8 @@ func (api *API) traceChain(start, end *types.Block, config *TraceConfig, closed
 
 			// Fetch and execute the block trace taskCh
 			for task := range taskCh {
-				var (
-					signer   = types.MakeSigner(api.backend.ChainConfig(), task.block.Number(), task.block.Time())
-					blockCtx = core.NewEVMBlockContext(task.block.Header(), api.chainContext(ctx), nil)
-				)
+				signer := types.MakeSigner(api.backend.ChainConfig(), task.block.Number(), task.block.Time())
+
 				// Trace all the transactions contained within
 				for i, tx := range task.block.Transactions() {
 					msg, _ := core.TransactionToMessage(tx, signer, task.block.BaseFee())
@@ -281,7 +285,7 @@ func (api *API) traceChain(start, end *types.Block, config *TraceConfig, closed
 						TxIndex:     i,
 						TxHash:      tx.Hash(),
 					}
-					res, err := api.traceTx(ctx, tx, msg, txctx, blockCtx, task.statedb, config, nil)
+					res, err := api.traceTx(ctx, tx, msg, txctx, task.blockCtx, task.statedb, config, nil, nil)
 					if err != nil {
 						task.results[i] = &txTraceResult{TxHash: tx.Hash(), Error: err.Error()}
 						log.Warn("Tracing failed", "hash", tx.Hash(), "block", task.block.NumberU64(), "err", err)
@@ -379,16 +383,19 @@ func (api *API) traceChain(start, end *types.Block, config *TraceConfig, closed
 				failed = err
 				break
 			}
-			// Insert block's parent beacon block root in the state
-			// as per EIP-4788.
-			context := core.NewEVMBlockContext(next.Header(), api.chainContext(ctx), nil)
-			evm := vm.NewEVM(context, statedb, api.backend.ChainConfig(), vm.Config{})
-			if beaconRoot := next.BeaconRoot(); beaconRoot != nil {
-				core.ProcessBeaconBlockRoot(*beaconRoot, evm)
+			// Apply the system actions preceding the transactions, such as
+			// inserting the parent beacon block root as per EIP-4788.
+			context, err := core.NewEVMBlockContext(next.Header(), api.chainContext(ctx), nil)
+			if err != nil {
+				release()
+				failed = err
+				break
 			}
-			// Insert parent hash in history contract.
-			if api.backend.ChainConfig().IsPrague(next.Number(), next.Time()) {
-				core.ProcessParentBlockHash(next.ParentHash(), evm)
+			evm := vm.NewEVM(context, statedb, api.backend.ChainConfig(), vm.Config{})
+			if err := core.ProcessPreBlockActions(next.Header(), evm); err != nil {
+				release()
+				failed = err
+				break
 			}
 			// Clean out any pending release functions of trace state. Note this
 			// step must be done after constructing tracing state, because the
@@ -399,7 +406,7 @@ func (api *API) traceChain(start, end *types.Block, config *TraceConfig, closed
 			// Send the block over to the concurrent tracers (if not in the fast-forward phase)
 			txs := next.Transactions()
 			select {
-			case taskCh <- &blockTraceTask{statedb: statedb.Copy(), block: next, release: release, results: make([]*txTraceResult, len(txs))}:
+			case taskCh <- &blockTraceTask{statedb: statedb.Copy(), block: next, blockCtx: context, release: release, results: make([]*txTraceResult, len(txs))}:
 			case <-closed:
 				tracker.releaseState(number, release)
 				return
@@ -531,19 +538,19 @@ func (api *API) IntermediateRoots(ctx context.Context, hash common.Hash, config
 		return nil, err
 	}
 	defer release()
+	vmctx, err := core.NewEVMBlockContext(block.Header(), api.chainContext(ctx), nil)
+	if err != nil {
+		return nil, err
+	}
 	var (
 		roots              []common.Hash
 		signer             = types.MakeSigner(api.backend.ChainConfig(), block.Number(), block.Time())
 		chainConfig        = api.backend.ChainConfig()
-		vmctx              = core.NewEVMBlockContext(block.Header(), api.chainContext(ctx), nil)
 		deleteEmptyObjects = chainConfig.IsEIP158(block.Number())
 	)
 	evm := vm.NewEVM(vmctx, statedb, chainConfig, vm.Config{})
-	if beaconRoot := block.BeaconRoot(); beaconRoot != nil {
-		core.ProcessBeaconBlockRoot(*beaconRoot, evm)
-	}
-	if chainConfig.IsPrague(block.Number(), block.Time()) {
-		core.ProcessParentBlockHash(block.ParentHash(), evm)
+	if err := core.ProcessPreBlockActions(block.Header(), evm); err != nil {
+		return nil, err
 	}
 	for i, tx := range block.Transactions() {
 		if err := ctx.Err(); err != nil {
@@ -601,24 +608,27 @@ func (api *API) traceBlock(ctx context.Context, block *types.Block, config *Trac
 	}
 	defer release()
 
-	blockCtx := core.NewEVMBlockContext(block.Header(), api.chainContext(ctx), nil)
-	evm := vm.NewEVM(blockCtx, statedb, api.backend.ChainConfig(), vm.Config{})
-	if beaconRoot := block.BeaconRoot(); beaconRoot != nil {
-		core.ProcessBeaconBlockRoot(*beaconRoot, evm)
+	blockCtx, err := core.NewEVMBlockContext(block.Header(), api.chainContext(ctx), nil)
+	if err != nil {
+		return nil, err
 	}
-	if api.backend.ChainConfig().IsPrague(block.Number(), block.Time()) {
-		core.ProcessParentBlockHash(block.ParentHash(), evm)
+	evm := vm.NewEVM(blockCtx, statedb, api.backend.ChainConfig(), vm.Config{})
+	if err := core.ProcessPreBlockActions(block.Header(), evm); err != nil {
+		return nil, err
 	}
 
-	// JS tracers have high overhead. In this case run a parallel
-	// process that generates states in one thread and traces txes
-	// in separate worker threads.
+	// JS tracers have high overhead, as do all tracers on blocks with heavy
+	// execution. In this case run a parallel process that generates states
+	// in one thread and traces txes in separate worker threads.
+	if len(block.Transactions()) > 1 && block.GasUsed() >= parallelTraceGasThreshold {
+		return api.traceBlockParallel(ctx, block, statedb, config)
+	}
 	if config != nil && config.Tracer != nil && *config.Tracer != "" {
 		if isJS := DefaultDirectory.IsJS(*config.Tracer); isJS {
 			return api.traceBlockParallel(ctx, block, statedb, config)
 		}
 	}
-	// Native tracers have low overhead
+	// Native tracers have low overhead on light blocks
 	var (
 		txs       = block.Transactions()
 		blockHash = block.Hash()
@@ -634,7 +644,7 @@ func (api *API) traceBlock(ctx context.Context, block *types.Block, config *Trac
 			TxIndex:     i,
 			TxHash:      tx.Hash(),
 		}
-		res, err := api.traceTx(ctx, tx, msg, txctx, blockCtx, statedb, config, nil)
+		res, err := api.traceTx(ctx, tx, msg, txctx, blockCtx, statedb, config, nil, nil)
 		if err != nil {
 			return nil, err
 		}
@@ -643,9 +653,10 @@ func (api *API) traceBlock(ctx context.Context, block *types.Block, config *Trac
 	return results, nil
 }
 
-// traceBlockParallel is for tracers that have a high overhead (read JS tracers). One thread
-// runs along and executes txes without tracing enabled to generate their prestate.
-// Worker threads take the tasks and the prestate and trace them.
+// traceBlockParallel is for tracers that have a high overhead (read JS tracers) and
+// blocks with heavy execution. One thread runs along and executes txes without
+// tracing enabled to generate their prestate. Worker threads take the tasks and
+// the prestate and trace them.
 func (api *API) traceBlockParallel(ctx context.Context, block *types.Block, statedb *state.StateDB, config *TraceConfig) ([]*txTraceResult, error) {
 	// Execute all the transaction contained within the block concurrently
 	var (
@@ -655,6 +666,10 @@ func (api *API) traceBlockParallel(ctx context.Context, block *types.Block, stat
 		results   = make([]*txTraceResult, len(txs))
 		pend      sync.WaitGroup
 	)
+	blockCtx, err := core.NewEVMBlockContext(block.Header(), api.chainContext(ctx), nil)
+	if err != nil {
+		return nil, err
+	}
 	threads := runtime.NumCPU()
 	if threads > len(txs) {
 		threads = len(txs)
@@ -677,8 +692,12 @@ func (api *API) traceBlockParallel(ctx context.Context, block *types.Block, stat
 				// as the GetHash function of BlockContext is not safe for
 				// concurrent use.
 				// See: https://github.com/ethereum/go-ethereum/issues/29114
-				blockCtx := core.NewEVMBlockContext(block.Header(), api.chainContext(ctx), nil)
-				res, err := api.traceTx(ctx, txs[task.index], msg, txctx, blockCtx, task.statedb, config, nil)
+				blockCtx, err := core.NewEVMBlockContext(block.Header(), api.chainContext(ctx), nil)
+				if err != nil {
+					results[task.index] = &txTraceResult{TxHash: txs[task.index].Hash(), Error: err.Error()}
+					continue
+				}
+				res, err := api.traceTx(ctx, txs[task.index], msg, txctx, blockCtx, task.statedb, config, nil, nil)
 				if err != nil {
 					results[task.index] = &txTraceResult{TxHash: txs[task.index].Hash(), Error: err.Error()}
 					continue
@@ -690,7 +709,6 @@ func (api *API) traceBlockParallel(ctx context.Context, block *types.Block, stat
 
 	// Feed the transactions into the tracers and return
 	var failed error
-	blockCtx := core.NewEVMBlockContext(block.Header(), api.chainContext(ctx), nil)
 	evm := vm.NewEVM(blockCtx, statedb, api.backend.ChainConfig(), vm.Config{})
 
 txloop:
@@ -763,11 +781,14 @@ func (api *API) standardTraceBlockToFile(ctx context.Context, block *types.Block
 	}
 
 	// Execute transaction, either tracing all or just the requested one
+	vmctx, err := core.NewEVMBlockContext(block.Header(), api.chainContext(ctx), nil)
+	if err != nil {
+		return nil, err
+	}
 	var (
 		dumps       []string
 		signer      = types.MakeSigner(api.backend.ChainConfig(), block.Number(), block.Time())
 		chainConfig = api.backend.ChainConfig()
-		vmctx       = core.NewEVMBlockContext(block.Header(), api.chainContext(ctx), nil)
 		canon       = true
 	)
 	// Check if there are any overrides: the caller may wish to enable a future
@@ -781,11 +802,8 @@ func (api *API) standardTraceBlockToFile(ctx context.Context, block *types.Block
 	}
 
 	evm := vm.NewEVM(vmctx, statedb, chainConfig, vm.Config{})
-	if beaconRoot := block.BeaconRoot(); beaconRoot != nil {
-		core.ProcessBeaconBlockRoot(*beaconRoot, evm)
-	}
-	if chainConfig.IsPrague(block.Number(), block.Time()) {
-		core.ProcessParentBlockHash(block.ParentHash(), evm)
+	if err := core.ProcessPreBlockActions(block.Header(), evm); err != nil {
+		return nil, err
 	}
 	for i, tx := range block.Transactions() {
 		// Prepare the transaction for un-traced execution
@@ -901,7 +919,7 @@ func (api *API) TraceTransaction(ctx context.Context, hash common.Hash, config *
 		TxIndex:     int(index),
 		TxHash:      hash,
 	}
-	return api.traceTx(ctx, tx, msg, txctx, vmctx, statedb, config, nil)
+	return api.traceTx(ctx, tx, msg, txctx, vmctx, statedb, config, nil, nil)
 }
 
 // TraceCall lets you trace a given eth_call. It collects the structured logs
@@ -955,7 +973,10 @@ func (api *API) TraceCall(ctx context.Context, args ethapi.TransactionArgs, bloc
 	defer release()
 
 	h := block.Header()
-	blockContext := core.NewEVMBlockContext(h, api.chainContext(ctx), nil)
+	blockContext, err := core.NewEVMBlockContext(h, api.chainContext(ctx), nil)
+	if err != nil {
+		return nil, err
+	}
 
 	// Apply the customization rules if required.
 	if config != nil {
@@ -985,9 +1006,10 @@ func (api *API) TraceCall(ctx context.Context, args ethapi.TransactionArgs, bloc
 		return nil, err
 	}
 	var (
-		msg         = args.ToMessage(blockContext.BaseFee, true)
-		tx          = args.ToTransaction(types.DynamicFeeTxType)
-		traceConfig *TraceConfig
+		msg          = args.ToMessage(blockContext.BaseFee, true)
+		tx           = args.ToTransaction(types.DynamicFeeTxType)
+		traceConfig  *TraceConfig
+		gasOverrides *override.GasOverrides
 	)
 	// Lower the basefee to 0 to avoid breaking EVM
 	// invariants (basefee < feecap).
@@ -999,14 +1021,15 @@ func (api *API) TraceCall(ctx context.Context, args ethapi.TransactionArgs, bloc
 	}
 	if config != nil {
 		traceConfig = &config.TraceConfig
+		gasOverrides = config.GasOverrides
 	}
-	return api.traceTx(ctx, tx, msg, new(Context), blockContext, statedb, traceConfig, precompiles)
+	return api.traceTx(ctx, tx, msg, new(Context), blockContext, statedb, traceConfig, precompiles, gasOverrides)
 }
 
 // traceTx configures a new tracer according to the provided configuration, and
 // executes the given message in the provided environment. The return value will
 // be tracer dependent.
-func (api *API) traceTx(ctx context.Context, tx *types.Transaction, message *core.Message, txctx *Context, vmctx vm.BlockContext, statedb *state.StateDB, config *TraceConfig, precompiles vm.PrecompiledContracts) (interface{}, error) {
+func (api *API) traceTx(ctx context.Context, tx *types.Transaction, message *core.Message, txctx *Context, vmctx vm.BlockContext, statedb *state.StateDB, config *TraceConfig, precompiles vm.PrecompiledContracts, gasOverrides *override.GasOverrides) (interface{}, error) {
 	var (
 		tracer  *Tracer
 		err     error
@@ -1035,6 +1058,9 @@ func (api *API) traceTx(ctx context.Context, tx *types.Transaction, message *cor
 	if precompiles != nil {
 		evm.SetPrecompiles(precompiles)
 	}
+	if err := gasOverrides.Apply(evm, precompiles); err != nil {
+		return nil, err
+	}
 
 	// Define a meaningful timeout of a single transaction trace
 	if config.Timeout != nil {
@@ -1045,11 +1071,17 @@ func (api *API) traceTx(ctx context.Context, tx *types.Transaction, message *cor
 	deadlineCtx, cancel := context.WithTimeout(ctx, timeout)
 	go func() {
 		<-deadlineCtx.Done()
-		if errors.Is(deadlineCtx.Err(), context.DeadlineExceeded) {
+		switch {
+		case errors.Is(deadlineCtx.Err(), context.DeadlineExceeded):
 			tracer.Stop(errors.New("execution timeout"))
-			// Stop evm execution. Note cancellation is not necessarily immediate.
-			evm.Cancel()
+		case ctx.Err() != nil:
+			// The request was abandoned, e.g. the client disconnected.
+			tracer.Stop(errors.New("execution canceled"))
+		default:
+			return
 		}
+		// Stop evm execution. Note cancellation is not necessarily immediate.
+		evm.Cancel()
 	}()
 	defer cancel()
 
@@ -17,6 +17,7 @@
 package tracers
 
 import (
+	"bytes"
 	"context"
 	"crypto/ecdsa"
 	"encoding/json"
@@ -181,7 +182,10 @@ func (b *testBackend) StateAtTransaction(ctx context.Context, block *types.Block
 	}
 	// Recompute transactions up to the target index.
 	signer := types.MakeSigner(b.chainConfig, block.Number(), block.Time())
-	context := core.NewEVMBlockContext(block.Header(), b.chain, nil)
+	context, err := core.NewEVMBlockContext(block.Header(), b.chain, nil)
+	if err != nil {
+		return nil, vm.BlockContext{}, nil, nil, err
+	}
 	evm := vm.NewEVM(context, statedb, b.chainConfig, vm.Config{})
 	for idx, tx := range block.Transactions() {
 		if idx == txIndex {
@@ -343,6 +347,7 @@ func TestTraceCall(t *testing.T) {
 	})
 
 	uintPtr := func(i int) *hexutil.Uint { x := hexutil.Uint(i); return &x }
+	identity := common.BytesToAddress([]byte{0x4})
 
 	defer backend.teardown()
 	api := NewAPI(backend)
@@ -446,6 +451,21 @@ func TestTraceCall(t *testing.T) {
 			expectErr: nil,
 			expect:    `{"gas":21000,"failed":false,"returnValue":"0x","structLogs":[]}`,
 		},
+		// Standard JSON trace of a precompile call with an overridden gas cost
+		{
+			blockNumber: rpc.LatestBlockNumber,
+			call: ethapi.TransactionArgs{
+				From: &accounts[0].addr,
+				To:   &identity,
+			},
+			config: &TraceCallConfig{
+				GasOverrides: &override.GasOverrides{
+					Precompiles: map[common.Address]hexutil.Uint64{identity: 100},
+				},
+			},
+			expectErr: nil,
+			expect:    `{"gas":21100,"failed":false,"returnValue":"0x","structLogs":[]}`,
+		},
 		// Tracing on 'pending' should fail:
 		{
 			blockNumber: rpc.PendingBlockNumber,
@@ -661,6 +681,64 @@ func TestTraceBlock(t *testing.T) {
 	}
 }
 
+func TestTraceBlockParallel(t *testing.T) {
+	// Initialize test accounts
+	accounts := newAccounts(2)
+	genesis := &core.Genesis{
+		Config:   params.TestChainConfig,
+		GasLimit: 30_000_000,
+		Alloc: types.GenesisAlloc{
+			accounts[0].addr: {Balance: big.NewInt(params.Ether)},
+		},
+	}
+	// Fill the block beyond the threshold for concurrent tracing
+	txCount := parallelTraceGasThreshold/params.TxGas + 1
+	signer := types.HomesteadSigner{}
+	backend := newTestBackend(t, 1, genesis, func(i int, b *core.BlockGen) {
+		for nonce := uint64(0); nonce < txCount; nonce++ {
+			tx, _ := types.SignTx(types.NewTx(&types.LegacyTx{
+				Nonce:    nonce,
+				To:       &accounts[1].addr,
+				Value:    big.NewInt(1000),
+				Gas:      params.TxGas,
+				GasPrice: b.BaseFee(),
+			}), signer, accounts[0].key)
+			b.AddTx(tx)
+		}
+	})
+	defer backend.teardown()
+	DefaultDirectory.Register("stateTracer", newStateTracer, false)
+	api := NewAPI(backend)
+
+	tracer := "stateTracer"
+	results, err := api.TraceBlockByNumber(context.Background(), 1, &TraceConfig{Tracer: &tracer})
+	if err != nil {
+		t.Fatalf("failed to trace block: %v", err)
+	}
+	if uint64(len(results)) != txCount {
+		t.Fatalf("result count mismatch: have %d, want %d", len(results), txCount)
+	}
+	// Every transaction must be traced on top of the state left by the
+	// preceding ones, same as when traced individually.
+	for i, result := range results {
+		if result.Error != "" {
+			t.Fatalf("tx %d: trace failed: %v", i, result.Error)
+		}
+		if i%50 != 0 {
+			continue
+		}
+		want, err := api.TraceTransaction(context.Background(), result.TxHash, &TraceConfig{Tracer: &tracer})
+		if err != nil {
+			t.Fatalf("tx %d: failed to trace transaction: %v", i, err)
+		}
+		have, _ := json.Marshal(result.Result)
+		wantJSON, _ := json.Marshal(want)
+		if !bytes.Equal(have, wantJSON) {
+			t.Errorf("tx %d: result mismatch, have\n%s\nwant\n%s", i, have, wantJSON)
+		}
+	}
+}
+
 func TestTracingWithOverrides(t *testing.T) {
 	t.Parallel()
 	// Initialize test accounts
@@ -0,0 +1,173 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package tracetest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/beacon"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/eth/tracers"
+	"github.com/ethereum/go-ethereum/eth/tracers/live"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func TestStateDiffTracer(t *testing.T) {
+	var (
+		config = *params.AllEthashProtocolChanges
+
+		// A contract storing 1 in slot 0 and emitting an empty log
+		aa   = common.HexToAddress("0x000000000000000000000000000000000000aaaa")
+		code = []byte{
+			byte(vm.PUSH1), 0x01, byte(vm.PUSH1), 0x00, byte(vm.SSTORE),
+			byte(vm.PUSH1), 0x00, byte(vm.PUSH1), 0x00, byte(vm.LOG0),
+			byte(vm.STOP),
+		}
+		key1, _ = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		addr1   = crypto.PubkeyToAddress(key1.PublicKey)
+		eth1    = new(big.Int).Mul(common.Big1, big.NewInt(params.Ether))
+
+		gspec = &core.Genesis{
+			Config:  &config,
+			BaseFee: big.NewInt(params.InitialBaseFee),
+			Alloc: types.GenesisAlloc{
+				addr1: {Balance: eth1},
+				aa:    {Code: code, Balance: common.Big0},
+			},
+		}
+		signer = types.LatestSigner(gspec.Config)
+	)
+	gen := func(b *core.BlockGen) {
+		tx, _ := types.SignNewTx(key1, signer, &types.DynamicFeeTx{
+			ChainID:   gspec.Config.ChainID,
+			Nonce:     0,
+			To:        &aa,
+			Value:     big.NewInt(100),
+			Gas:       100000,
+			GasFeeCap: b.BaseFee(),
+			GasTipCap: big.NewInt(0),
+		})
+		b.AddTx(tx)
+	}
+	records, err := testStateDiffTracer(t, gspec, gen, 1)
+	if err != nil {
+		t.Fatalf("failed to test statediff tracer: %v", err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("expected 2 records, got %d", len(records))
+	}
+	// The genesis record holds the allocation.
+	genesis := records[0]
+	if genesis.Number != 0 || len(genesis.Accounts) != 2 {
+		t.Fatalf("unexpected genesis record: number %d, %d accounts", genesis.Number, len(genesis.Accounts))
+	}
+	// The block record holds the transaction's changes.
+	block := records[1]
+	if block.Number != 1 || block.ParentHash != genesis.Hash {
+		t.Fatalf("unexpected block record: number %d, parent %x", block.Number, block.ParentHash)
+	}
+	changes := make(map[common.Address]live.AccountChange)
+	for _, change := range block.Accounts {
+		changes[change.Address] = change
+	}
+	sender := changes[addr1]
+	if len(sender.Nonce) != 2 || sender.Nonce[0] != 0 || sender.Nonce[1] != 1 {
+		t.Errorf("unexpected sender nonce change %v", sender.Nonce)
+	}
+	if len(sender.Balance) != 2 || sender.Balance[0].Cmp(eth1) != 0 || sender.Balance[1].Cmp(eth1) >= 0 {
+		t.Errorf("unexpected sender balance change %v", sender.Balance)
+	}
+	contract := changes[aa]
+	if len(contract.Balance) != 2 || contract.Balance[1].Int64() != 100 {
+		t.Errorf("unexpected contract balance change %v", contract.Balance)
+	}
+	if len(contract.Storage) != 1 || contract.Storage[0].New != common.BytesToHash([]byte{1}) {
+		t.Errorf("unexpected contract storage change %v", contract.Storage)
+	}
+	if len(contract.Nonce) != 0 || len(contract.CodeHash) != 0 {
+		t.Errorf("unexpected contract changes: nonce %v, code hash %v", contract.Nonce, contract.CodeHash)
+	}
+	if len(block.Logs) != 1 || block.Logs[0].Address != aa || block.Logs[0].Tx != 0 {
+		t.Errorf("unexpected logs %+v", block.Logs)
+	}
+	var transfer bool
+	for _, change := range block.Balances {
+		if change.Address == aa && change.Tx == 1 && change.Reason == uint64(tracing.BalanceChangeTransfer) {
+			transfer = true
+		}
+	}
+	if !transfer {
+		t.Errorf("missing transfer balance change in %+v", block.Balances)
+	}
+}
+
+func testStateDiffTracer(t *testing.T, genesis *core.Genesis, gen func(b *core.BlockGen), numBlocks int) ([]*live.StateDiffRecord, error) {
+	engine := beacon.New(ethash.NewFaker())
+
+	traceOutputPath := filepath.ToSlash(t.TempDir())
+	tracer, err := tracers.LiveDirectory.New("statediff", json.RawMessage(fmt.Sprintf(`{"path":"%s"}`, traceOutputPath)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create statediff tracer: %v", err)
+	}
+	options := core.DefaultConfig().WithStateScheme(rawdb.PathScheme)
+	options.VmConfig = vm.Config{Tracer: tracer}
+	chain, err := core.NewBlockChain(rawdb.NewMemoryDatabase(), genesis, engine, options)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create tester chain: %v", err)
+	}
+	defer chain.Stop()
+
+	_, blocks, _ := core.GenerateChainWithGenesis(genesis, engine, numBlocks, func(i int, b *core.BlockGen) {
+		b.SetCoinbase(common.Address{1})
+		gen(b)
+	})
+	if n, err := chain.InsertChain(blocks); err != nil {
+		return nil, fmt.Errorf("block %d: failed to insert into chain: %v", n, err)
+	}
+
+	file, err := os.Open(filepath.Join(traceOutputPath, "statediff.bin"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open output file: %v", err)
+	}
+	defer file.Close()
+
+	var records []*live.StateDiffRecord
+	for {
+		record, err := live.ReadStateDiffRecord(file)
+		if errors.Is(err, io.EOF) {
+			return records, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read record %d: %v", len(records), err)
+		}
+		records = append(records, record)
+	}
+}
@@ -57,7 +57,13 @@ func init() {
 	for name, code := range assetTracers {
 		tracers.DefaultDirectory.Register(name, lookup(code), true)
 	}
-	tracers.DefaultDirectory.RegisterJSEval(newJsTracer)
+	tracers.DefaultDirectory.RegisterJSEval(func(code string, ctx *tracers.Context, cfg json.RawMessage, chainConfig *params.ChainConfig) (*tracers.Tracer, error) {
+		limits, err := parseLimits(cfg)
+		if err != nil {
+			return nil, err
+		}
+		return newLimitedJsTracer(code, ctx, cfg, chainConfig, limits)
+	})
 }
 
 var compiledBigInt *goja.Program
@@ -122,6 +128,7 @@ type jsTracer struct {
 	traceFrame        bool                  // True if tracer object exposes the `enter()` and `exit()` methods
 	err               error                 // Any error that should stop tracing
 	obj               *goja.Object          // Trace object
+	budget            *budget               // Resource usage of the tracer functions
 
 	// Methods exposed by tracer
 	result goja.Callable
@@ -150,6 +157,12 @@ type jsTracer struct {
 // The methods `step`, `enter`, and `exit` are optional, but note that
 // `enter` and `exit` always go together.
 func newJsTracer(code string, ctx *tracers.Context, cfg json.RawMessage, chainConfig *params.ChainConfig) (*tracers.Tracer, error) {
+	return newLimitedJsTracer(code, ctx, cfg, chainConfig, Limits{})
+}
+
+// newLimitedJsTracer instantiates a new JS tracer instance whose functions
+// are bounded by the given resource limits.
+func newLimitedJsTracer(code string, ctx *tracers.Context, cfg json.RawMessage, chainConfig *params.ChainConfig, limits Limits) (*tracers.Tracer, error) {
 	vm := goja.New()
 	// By default field names are exported to JS as is, i.e. capitalized.
 	vm.SetFieldNameMapper(goja.UncapFieldNameMapper())
@@ -157,10 +170,14 @@ func newJsTracer(code string, ctx *tracers.Context, cfg json.RawMessage, chainCo
 		vm:          vm,
 		ctx:         make(map[string]goja.Value),
 		chainConfig: chainConfig,
+		budget:      newBudget(vm, limits),
 	}
 
 	t.setTypeConverters()
 	t.setBuiltinFunctions()
+	if err := t.budget.trackMemory(); err != nil {
+		return nil, err
+	}
 
 	if ctx == nil {
 		ctx = new(tracers.Context)
@@ -181,7 +198,11 @@ func newJsTracer(code string, ctx *tracers.Context, cfg json.RawMessage, chainCo
 		}
 	}
 
-	ret, err := vm.RunString("(" + code + ")")
+	var ret goja.Value
+	err := t.budget.run(func() (err error) {
+		ret, err = vm.RunString("(" + code + ")")
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -204,6 +225,7 @@ func newJsTracer(code string, ctx *tracers.Context, cfg json.RawMessage, chainCo
 	}
 	t.traceFrame = hasEnter
 	t.obj = obj
+	t.budget.setRoot(obj)
 	t.step = step
 	t.enter = enter
 	t.exit = exit
@@ -216,7 +238,7 @@ func newJsTracer(code string, ctx *tracers.Context, cfg json.RawMessage, chainCo
 		if cfg != nil {
 			cfgStr = string(cfg)
 		}
-		if _, err := setup(obj, vm.ToValue(cfgStr)); err != nil {
+		if err := t.call(setup, vm.ToValue(cfgStr)); err != nil {
 			return nil, err
 		}
 	}
@@ -349,7 +371,7 @@ func (t *jsTracer) OnOpcode(pc uint64, op byte, gas, cost uint64, scope tracing.
 	log.refund = t.env.StateDB.GetRefund()
 	log.depth = depth
 	log.err = err
-	if _, err := t.step(t.obj, t.logValue, t.dbValue); err != nil {
+	if err := t.call(t.step, t.logValue, t.dbValue); err != nil {
 		t.onError("step", err)
 	}
 }
@@ -361,7 +383,7 @@ func (t *jsTracer) OnFault(pc uint64, op byte, gas, cost uint64, scope tracing.O
 	}
 	// Other log fields have been already set as part of the last OnOpcode.
 	t.log.err = err
-	if _, err := t.fault(t.obj, t.logValue, t.dbValue); err != nil {
+	if err := t.call(t.fault, t.logValue, t.dbValue); err != nil {
 		t.onError("fault", err)
 	}
 }
@@ -405,7 +427,7 @@ func (t *jsTracer) OnEnter(depth int, typ byte, from common.Address, to common.A
 		t.frame.value = new(big.Int).SetBytes(value.Bytes())
 	}
 
-	if _, err := t.enter(t.obj, t.frameValue); err != nil {
+	if err := t.call(t.enter, t.frameValue); err != nil {
 		t.onError("enter", err)
 	}
 }
@@ -428,7 +450,7 @@ func (t *jsTracer) OnExit(depth int, output []byte, gasUsed uint64, err error, r
 	t.frameResult.output = common.CopyBytes(output)
 	t.frameResult.err = err
 
-	if _, err := t.exit(t.obj, t.frameResultValue); err != nil {
+	if err := t.call(t.exit, t.frameResultValue); err != nil {
 		t.onError("exit", err)
 	}
 }
@@ -438,8 +460,14 @@ func (t *jsTracer) GetResult() (json.RawMessage, error) {
 	if t.err != nil {
 		return nil, t.err
 	}
-	ctx := t.vm.ToValue(t.ctx)
-	res, err := t.result(t.obj, ctx, t.dbValue)
+	var (
+		ctx = t.vm.ToValue(t.ctx)
+		res goja.Value
+	)
+	err := t.budget.step(func() (err error) {
+		res, err = t.result(t.obj, ctx, t.dbValue)
+		return err
+	})
 	if err != nil {
 		return nil, wrapError("result", err)
 	}
@@ -447,9 +475,21 @@ func (t *jsTracer) GetResult() (json.RawMessage, error) {
 	if err != nil {
 		return nil, err
 	}
+	if err := t.budget.checkResult(encoded); err != nil {
+		return nil, err
+	}
 	return encoded, t.err
 }
 
+// call invokes a tracer function on the trace object within the resource
+// limits of the tracer.
+func (t *jsTracer) call(fn goja.Callable, args ...goja.Value) error {
+	return t.budget.step(func() error {
+		_, err := fn(t.obj, args...)
+		return err
+	})
+}
+
 // Stop terminates execution of the tracer at the first opportune moment.
 func (t *jsTracer) Stop(err error) {
 	t.vm.Interrupt(err)
@@ -0,0 +1,376 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package js
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/dop251/goja"
+)
+
+var (
+	errStepLimit       = errors.New("tracer step limit exceeded")
+	errCPUTimeLimit    = errors.New("tracer CPU time limit exceeded")
+	errCallStackLimit  = errors.New("tracer call stack limit exceeded")
+	errResultSizeLimit = errors.New("tracer result size limit exceeded")
+	errMemoryLimit     = errors.New("tracer memory limit exceeded")
+)
+
+// Limits bounds the resources used by tracers evaluating user-provided JS code.
+// Zero values disable the respective limit. The tracers bundled with geth are
+// not limited.
+//
+// The memory limit is best-effort: it applies to an estimate which misses the
+// values only reachable from closures, so a tracer keeping its state in them is
+// not held to it. It catches runaway tracers, not malicious ones.
+type Limits struct {
+	MaxSteps      uint64        // Maximum number of tracer function invocations
+	MaxCPUTime    time.Duration // Maximum time spent executing tracer functions
+	MaxCallStack  int           // Maximum depth of the JS call stack
+	MaxResultSize uint64        // Maximum size of the JSON encoded result in bytes
+	MaxMemory     uint64        // Maximum estimated size of the JS values retained by the tracer (best-effort)
+}
+
+// DefaultLimits are the limits of user-provided JS tracers. They are large
+// enough to trace a full transaction step by step, and bound the time a single
+// tracer may take from a node serving untrusted requests. The memory a tracer
+// allocates is only bounded through its steps and CPU time, the best-effort
+// memory limit is left for tracers to opt into.
+var DefaultLimits = Limits{
+	MaxSteps:      25_000_000,
+	MaxCPUTime:    10 * time.Second,
+	MaxCallStack:  1024,
+	MaxResultSize: 64 * 1024 * 1024,
+}
+
+// limitsConfig is the JSON form of the limits in the tracer config, e.g.
+//
+//	{"limits": {"maxSteps": 1000, "maxCpuTime": "1s"}}
+type limitsConfig struct {
+	MaxSteps      uint64 `json:"maxSteps"`
+	MaxCPUTime    string `json:"maxCpuTime"`
+	MaxCallStack  int    `json:"maxCallStack"`
+	MaxResultSize uint64 `json:"maxResultSize"`
+	MaxMemory     uint64 `json:"maxMemory"`
+}
+
+// parseLimits returns the limits of a tracer given its config. The config may
+// only lower the default limits, omitted or higher values keep the default. The
+// memory limit, disabled by default, may be set to any value.
+// Configs that are not JSON objects are left to the tracer and yield the
+// default limits.
+func parseLimits(cfg json.RawMessage) (Limits, error) {
+	var outer struct {
+		Limits json.RawMessage `json:"limits"`
+	}
+	if len(cfg) == 0 || json.Unmarshal(cfg, &outer) != nil || len(outer.Limits) == 0 {
+		return DefaultLimits, nil
+	}
+	var dec limitsConfig
+	if err := json.Unmarshal(outer.Limits, &dec); err != nil {
+		return Limits{}, fmt.Errorf("invalid tracer limits: %v", err)
+	}
+	req := Limits{
+		MaxSteps:      dec.MaxSteps,
+		MaxCallStack:  dec.MaxCallStack,
+		MaxResultSize: dec.MaxResultSize,
+		MaxMemory:     dec.MaxMemory,
+	}
+	if dec.MaxCPUTime != "" {
+		var err error
+		if req.MaxCPUTime, err = time.ParseDuration(dec.MaxCPUTime); err != nil {
+			return Limits{}, fmt.Errorf("invalid tracer CPU time limit: %v", err)
+		}
+	}
+	return DefaultLimits.lower(req), nil
+}
+
+// lower returns the limits with every positive limit of req below the current
+// one applied.
+func (l Limits) lower(req Limits) Limits {
+	if req.MaxSteps > 0 && (l.MaxSteps == 0 || req.MaxSteps < l.MaxSteps) {
+		l.MaxSteps = req.MaxSteps
+	}
+	if req.MaxCPUTime > 0 && (l.MaxCPUTime == 0 || req.MaxCPUTime < l.MaxCPUTime) {
+		l.MaxCPUTime = req.MaxCPUTime
+	}
+	if req.MaxCallStack > 0 && (l.MaxCallStack == 0 || req.MaxCallStack < l.MaxCallStack) {
+		l.MaxCallStack = req.MaxCallStack
+	}
+	if req.MaxResultSize > 0 && (l.MaxResultSize == 0 || req.MaxResultSize < l.MaxResultSize) {
+		l.MaxResultSize = req.MaxResultSize
+	}
+	if req.MaxMemory > 0 && (l.MaxMemory == 0 || req.MaxMemory < l.MaxMemory) {
+		l.MaxMemory = req.MaxMemory
+	}
+	return l
+}
+
+// Memory usage is estimated at most every memoryCheckInterval tracer function
+// invocations, and only once the tracer ran memoryCheckRatio times as long as
+// the previous estimation took, to keep its overhead bounded.
+const (
+	memoryCheckInterval = 1024
+	memoryCheckRatio    = 10
+)
+
+// budget tracks the resource usage of a tracer against its limits.
+type budget struct {
+	limits  Limits
+	steps   uint64
+	cpuTime time.Duration
+	timer   *time.Timer // Interrupts a tracer function running out of CPU time
+
+	vm        *goja.Runtime
+	sizeOf    goja.Callable // Estimates the size of the values reachable from the tracer
+	shared    goja.Value    // Objects shared with the builtins holding tracer state
+	roots     []goja.Value  // Objects holding the state of the tracer
+	baseline  uint64        // Size of the shared objects before the tracer code ran
+	calls     uint64        // Number of tracer function invocations
+	nextCheck uint64        // Invocation count from which to estimate the memory usage
+	nextTime  time.Duration // Execution time from which to estimate the memory usage
+}
+
+func newBudget(vm *goja.Runtime, l Limits) *budget {
+	b := &budget{vm: vm, limits: l}
+	if l.MaxCallStack > 0 {
+		vm.SetMaxCallStackSize(l.MaxCallStack)
+	}
+	if l.MaxCPUTime > 0 {
+		b.timer = time.AfterFunc(math.MaxInt64, func() { vm.Interrupt(errCPUTimeLimit) })
+		b.timer.Stop()
+	}
+	return b
+}
+
+// trackMemory starts estimating the memory retained by the tracer, which is
+// held by the tracer object and the global variables. It must be called before
+// the code of the tracer is evaluated, the size of the values reachable at that
+// point is not charged to the tracer.
+func (b *budget) trackMemory() error {
+	if b.limits.MaxMemory == 0 {
+		return nil
+	}
+	fn, err := b.vm.RunProgram(getSizeOfProgram())
+	if err != nil {
+		return err
+	}
+	b.sizeOf, _ = goja.AssertFunction(fn)
+	if b.shared, err = b.vm.RunString("[globalThis, Object.prototype, Function.prototype, Array.prototype]"); err != nil {
+		return err
+	}
+	b.baseline, err = b.memoryUsage()
+	return err
+}
+
+// setRoot adds the tracer object to the values accounted for memory usage.
+func (b *budget) setRoot(obj goja.Value) {
+	if b.sizeOf != nil {
+		b.roots = append(b.roots, obj)
+	}
+}
+
+// memoryUsage estimates the size of the values reachable from the tracer.
+func (b *budget) memoryUsage() (uint64, error) {
+	res, err := b.sizeOf(goja.Undefined(), b.shared, b.vm.ToValue(b.roots))
+	if err != nil {
+		return 0, err
+	}
+	return uint64(res.ToInteger()), nil
+}
+
+// step invokes a tracer function, charging it against the budget.
+func (b *budget) step(fn func() error) error {
+	if b.limits.MaxSteps > 0 {
+		if b.steps >= b.limits.MaxSteps {
+			return errStepLimit
+		}
+		b.steps++
+	}
+	if err := b.run(fn); err != nil {
+		return err
+	}
+	b.calls++
+	if b.sizeOf == nil || b.calls < b.nextCheck || b.cpuTime < b.nextTime {
+		return nil
+	}
+	var (
+		size  uint64
+		start = b.cpuTime
+	)
+	err := b.run(func() (err error) {
+		size, err = b.memoryUsage()
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	if size > b.baseline && size-b.baseline > b.limits.MaxMemory {
+		return errMemoryLimit
+	}
+	b.nextCheck = b.calls + memoryCheckInterval
+	b.nextTime = b.cpuTime + memoryCheckRatio*(b.cpuTime-start)
+	return nil
+}
+
+// run executes JS code, charging its execution time against the budget.
+func (b *budget) run(fn func() error) error {
+	var err error
+	if b.timer == nil && b.sizeOf == nil {
+		err = fn()
+	} else {
+		remaining := b.limits.MaxCPUTime - b.cpuTime
+		if b.timer != nil {
+			if remaining <= 0 {
+				return errCPUTimeLimit
+			}
+			b.timer.Reset(remaining)
+		}
+		start := time.Now()
+		err = fn()
+		b.cpuTime += time.Since(start)
+		if b.timer != nil {
+			b.timer.Stop()
+		}
+	}
+	// Stack overflows carry no message, replace them with a descriptive error.
+	var overflow *goja.StackOverflowError
+	if errors.As(err, &overflow) {
+		return errCallStackLimit
+	}
+	return err
+}
+
+// checkResult verifies the size of an encoded result.
+func (b *budget) checkResult(result []byte) error {
+	if b.limits.MaxResultSize > 0 && uint64(len(result)) > b.limits.MaxResultSize {
+		return errResultSizeLimit
+	}
+	return nil
+}
+
+// sizeOfCode estimates the memory held by JS values. Goja does not account the
+// memory of JS values, so the estimate walks the data properties of objects,
+// their prototypes, the entries of maps and sets, and the contents of binary
+// buffers. Accessors are not invoked, and values only captured by closures are
+// not accounted.
+//
+// The returned function takes the shared objects, whose enumerable properties
+// are walked, and the roots, which are walked entirely. The shared objects are
+// the global object and the common prototypes, whose builtin properties are not
+// enumerable and are thus left out. The builtins used by the walk are captured
+// before any tracer code runs, so the tracer can't hide its state by redefining
+// them.
+const sizeOfCode = `(function(apply, Map, ownKeys, ownNames, ownDesc, getProto, mapGet, mapSet, mapHas, mapSize, mapForEach, setSize, setForEach, bufferLength, viewLength, dataViewLength) {
+	function length(getter, v) {
+		try {
+			return apply(getter, v, []);
+		} catch (e) {
+			return -1;
+		}
+	}
+	return function(shared, roots) {
+		var seen = new Map(), stack = new Map(), n = 0, size = 0;
+		function push(value) {
+			apply(mapSet, stack, [n++, value]);
+		}
+		function walk(v, keys) {
+			for (var i = 0; i < keys.length; i++) {
+				var desc = ownDesc(v, keys[i]);
+				size += 16 + 2 * keys[i].length;
+				if (desc !== undefined && 'value' in desc) {
+					push(desc.value);
+				}
+			}
+		}
+		for (var i = 0; i < shared.length; i++) {
+			apply(mapSet, seen, [shared[i], true]);
+		}
+		for (var i = 0; i < shared.length; i++) {
+			walk(shared[i], ownKeys(shared[i]));
+		}
+		for (var i = 0; i < roots.length; i++) {
+			push(roots[i]);
+		}
+		while (n > 0) {
+			var v = apply(mapGet, stack, [--n]);
+			switch (typeof v) {
+			case 'string':
+				size += 16 + 2 * v.length;
+				break;
+			case 'object':
+			case 'function':
+				if (v === null || apply(mapHas, seen, [v])) {
+					size += 8;
+					break;
+				}
+				apply(mapSet, seen, [v, true]);
+				size += 64;
+
+				var bytes = length(bufferLength, v);
+				if (bytes < 0) {
+					bytes = length(viewLength, v);
+				}
+				if (bytes < 0) {
+					bytes = length(dataViewLength, v);
+				}
+				if (bytes >= 0) {
+					size += bytes;
+					break;
+				}
+				if (length(mapSize, v) >= 0) {
+					apply(mapForEach, v, [function(value, key) { push(key); push(value); }]);
+				} else if (length(setSize, v) >= 0) {
+					apply(setForEach, v, [push]);
+				}
+				walk(v, ownNames(v));
+				push(getProto(v));
+				break;
+			default:
+				size += 8;
+			}
+		}
+		return size;
+	};
+})(
+	Reflect.apply, Map, Object.keys, Object.getOwnPropertyNames, Object.getOwnPropertyDescriptor, Object.getPrototypeOf,
+	Map.prototype.get, Map.prototype.set, Map.prototype.has,
+	Object.getOwnPropertyDescriptor(Map.prototype, 'size').get, Map.prototype.forEach,
+	Object.getOwnPropertyDescriptor(Set.prototype, 'size').get, Set.prototype.forEach,
+	Object.getOwnPropertyDescriptor(ArrayBuffer.prototype, 'byteLength').get,
+	Object.getOwnPropertyDescriptor(Object.getPrototypeOf(Uint8Array.prototype), 'byteLength').get,
+	Object.getOwnPropertyDescriptor(DataView.prototype, 'byteLength').get
+)`
+
+var (
+	compiledSizeOf *goja.Program
+	sizeOfOnce     sync.Once
+)
+
+// getSizeOfProgram compiles the memory estimation function, if needed, and
+// returns the compiled goja program.
+func getSizeOfProgram() *goja.Program {
+	sizeOfOnce.Do(func() {
+		compiledSizeOf = goja.MustCompile("sizeof", sizeOfCode, false)
+	})
+	return compiledSizeOf
+}
@@ -199,6 +199,91 @@ func TestHaltBetweenSteps(t *testing.T) {
 	}
 }
 
+func TestLimits(t *testing.T) {
+	chainConfig := params.TestChainConfig
+	tests := []struct {
+		code   string
+		limits Limits
+		err    error
+	}{
+		// Default program runs 3 steps
+		{"{step: function() {}, fault: function() {}, result: function() { return null; }}", Limits{MaxSteps: 2}, errStepLimit},
+		{"{step: function() {}, fault: function() {}, result: function() { return null; }}", Limits{MaxSteps: 100}, nil},
+		{"{step: function() { while(1); }, fault: function() {}, result: function() { return null; }}", Limits{MaxCPUTime: 100 * time.Millisecond}, errCPUTimeLimit},
+		{"{result: function() { return 'a'.repeat(100); }, fault: function() {}}", Limits{MaxResultSize: 64}, errResultSizeLimit},
+		{"{result: function() { return 'a'.repeat(10); }, fault: function() {}}", Limits{MaxResultSize: 64}, nil},
+		{"{data: [], step: function() { for (var i = 0; i < 1000; i++) this.data.push('a'.repeat(100)); }, fault: function() {}, result: function() { return null; }}", Limits{MaxMemory: 64 * 1024}, errMemoryLimit},
+		{"{data: new Map(), step: function() { for (var i = 0; i < 1000; i++) this.data.set(i, new ArrayBuffer(100)); }, fault: function() {}, result: function() { return null; }}", Limits{MaxMemory: 64 * 1024}, errMemoryLimit},
+		{"{step: function() { for (var i = 0; i < 1000; i++) globalThis.leaked = (globalThis.leaked || '') + 'aaaa'; }, fault: function() {}, result: function() { return null; }}", Limits{MaxMemory: 1024}, errMemoryLimit},
+		{"{data: [], step: function() { this.data.push(1); }, fault: function() {}, result: function() { return null; }}", Limits{MaxMemory: 64 * 1024}, nil},
+		// The memory limit is best-effort, values held by closures are not accounted
+		{"(function() { var data = []; return {step: function() { for (var i = 0; i < 1000; i++) data.push('a'.repeat(100)); }, fault: function() {}, result: function() { return null; }}; })()", Limits{MaxMemory: 64 * 1024}, nil},
+	}
+	for i, test := range tests {
+		tracer, err := newLimitedJsTracer(test.code, nil, nil, chainConfig, test.limits)
+		if err != nil {
+			t.Fatalf("test %d: %v", i, err)
+		}
+		_, err = runTrace(tracer, testCtx(), chainConfig, nil)
+		switch {
+		case test.err == nil && err != nil:
+			t.Errorf("test %d: unexpected error %v", i, err)
+		case test.err != nil && (err == nil || !strings.Contains(err.Error(), test.err.Error())):
+			t.Errorf("test %d: expected error %v, got %v", i, test.err, err)
+		}
+	}
+}
+
+func TestParseLimits(t *testing.T) {
+	tests := []struct {
+		cfg    string
+		limits Limits
+		fail   bool
+	}{
+		{cfg: "", limits: DefaultLimits},
+		{cfg: `"opaque"`, limits: DefaultLimits},
+		{cfg: `{"other": 1}`, limits: DefaultLimits},
+		{cfg: `{"limits": {"maxSteps": 10, "maxCpuTime": "1s", "maxMemory": 1024}}`, limits: Limits{
+			MaxSteps:      10,
+			MaxCPUTime:    time.Second,
+			MaxCallStack:  DefaultLimits.MaxCallStack,
+			MaxResultSize: DefaultLimits.MaxResultSize,
+			MaxMemory:     1024,
+		}},
+		// Limits can't be raised above the defaults
+		{cfg: `{"limits": {"maxSteps": 1000000000, "maxCpuTime": "1h", "maxCallStack": 100000}}`, limits: DefaultLimits},
+		{cfg: `{"limits": {"maxCpuTime": "forever"}}`, fail: true},
+		{cfg: `{"limits": 1}`, fail: true},
+	}
+	for i, tt := range tests {
+		limits, err := parseLimits(json.RawMessage(tt.cfg))
+		if tt.fail {
+			if err == nil {
+				t.Errorf("test %d: expected error", i)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("test %d: unexpected error: %v", i, err)
+			continue
+		}
+		if limits != tt.limits {
+			t.Errorf("test %d: limits mismatch: have %+v, want %+v", i, limits, tt.limits)
+		}
+	}
+}
+
+func TestCallStackLimit(t *testing.T) {
+	code := "{step: function() {}, fault: function() {}, result: function() { var f = function(n) { return f(n+1); }; return f(0); }}"
+	tracer, err := newLimitedJsTracer(code, nil, nil, params.TestChainConfig, Limits{MaxCallStack: 100})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := runTrace(tracer, testCtx(), params.TestChainConfig, nil); err == nil || !strings.Contains(err.Error(), errCallStackLimit.Error()) {
+		t.Errorf("expected call stack error, got %v", err)
+	}
+}
+
 // TestNoStepExec tests a regular value transfer (no exec), and accessing the statedb
 // in 'result'
 func TestNoStepExec(t *testing.T) {
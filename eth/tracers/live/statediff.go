@@ -0,0 +1,410 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package live
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/eth/tracers"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+func init() {
+	tracers.LiveDirectory.Register("statediff", newStateDiffTracer)
+}
+
+// The statediff tracer writes a record for every processed block, holding the
+// net state changes of the block, all balance changes in execution order and
+// the logs of its transactions. It is meant for downstream ingestion pipelines.
+//
+// Records are written to rotating files or streamed to a socket, in the same
+// format:
+//
+//	record  = length || version || payload
+//	length  = 4 byte big-endian size of version and payload
+//	version = 1 byte, currently StateDiffRecordVersion
+//	payload = RLP encoding of StateDiffRecord
+//
+// Changed fields are encoded as [prev, new] pairs and left empty if unchanged.
+// Records of blocks which fail processing are not written, a reorg shows up as
+// a record whose parent hash differs from the hash of the previous one.
+
+// StateDiffRecordVersion is the version of the record format.
+const StateDiffRecordVersion = 1
+
+// StateDiffRecord holds the changes of a processed block.
+type StateDiffRecord struct {
+	Number     uint64
+	Hash       common.Hash
+	ParentHash common.Hash
+	Accounts   []AccountChange // Net changes, ordered by address
+	Balances   []BalanceChange // All balance changes, in execution order
+	Logs       []LogRecord     // Logs of the transactions, in execution order
+}
+
+// AccountChange is the net change of an account within a block.
+type AccountChange struct {
+	Address  common.Address
+	Nonce    []uint64      // [prev, new] if changed
+	Balance  []*big.Int    // [prev, new] if changed
+	CodeHash []common.Hash // [prev, new] if changed
+	Code     []byte        // New code if changed
+	Storage  []SlotChange  // Changed slots, ordered by key
+}
+
+// SlotChange is the net change of a storage slot within a block.
+type SlotChange struct {
+	Key  common.Hash
+	Prev common.Hash
+	New  common.Hash
+}
+
+// BalanceChange is a single balance change. Tx is the index of the transaction
+// plus one, zero for changes made outside of transactions (e.g. block rewards
+// and withdrawals). Changes undone by a revert are followed by a change with
+// the BalanceChangeRevert reason.
+type BalanceChange struct {
+	Tx      uint64
+	Address common.Address
+	Prev    *big.Int
+	New     *big.Int
+	Reason  uint64 // tracing.BalanceChangeReason
+}
+
+// LogRecord is a log emitted by a transaction.
+type LogRecord struct {
+	Tx      uint64 // Index of the transaction
+	Address common.Address
+	Topics  []common.Hash
+	Data    []byte
+}
+
+// ReadStateDiffRecord reads the next record written by the statediff tracer.
+func ReadStateDiffRecord(r io.Reader) (*StateDiffRecord, error) {
+	var prefix [5]byte
+	if _, err := io.ReadFull(r, prefix[:]); err != nil {
+		return nil, err
+	}
+	size := binary.BigEndian.Uint32(prefix[:4])
+	if size == 0 {
+		return nil, errors.New("empty record")
+	}
+	if prefix[4] != StateDiffRecordVersion {
+		return nil, fmt.Errorf("unsupported record version %d", prefix[4])
+	}
+	payload := make([]byte, size-1)
+	if _, err := io.ReadFull(r, payload); err != nil {
+		return nil, err
+	}
+	record := new(StateDiffRecord)
+	if err := rlp.DecodeBytes(payload, record); err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+type stateDiffTracerConfig struct {
+	Path    string `json:"path"`    // Directory where the output files are stored
+	MaxSize int    `json:"maxSize"` // Maximum size in megabytes of an output file before it gets rotated, 100 megabytes by default
+	Socket  string `json:"socket"`  // Socket to stream records to instead, as "unix:<path>" or "tcp:<host:port>"
+}
+
+// accountState tracks the changes of an account within a block.
+type accountState struct {
+	nonce    []uint64
+	balance  []*big.Int
+	codeHash []common.Hash
+	code     []byte
+	storage  map[common.Hash][2]common.Hash
+}
+
+type stateDiffTracer struct {
+	sink io.WriteCloser
+
+	record   StateDiffRecord
+	accounts map[common.Address]*accountState
+	tx       uint64 // Index of the current transaction plus one, zero outside of transactions
+	txCount  uint64
+}
+
+func newStateDiffTracer(cfg json.RawMessage) (*tracing.Hooks, error) {
+	var config stateDiffTracerConfig
+	if err := json.Unmarshal(cfg, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse config: %v", err)
+	}
+	var sink io.WriteCloser
+	switch {
+	case config.Path != "" && config.Socket != "":
+		return nil, errors.New("statediff tracer output path and socket are exclusive")
+	case config.Path != "":
+		logger := &lumberjack.Logger{Filename: filepath.Join(config.Path, "statediff.bin")}
+		if config.MaxSize > 0 {
+			logger.MaxSize = config.MaxSize
+		}
+		sink = logger
+	case config.Socket != "":
+		network, addr, ok := strings.Cut(config.Socket, ":")
+		if !ok || (network != "unix" && network != "tcp") {
+			return nil, fmt.Errorf("invalid statediff tracer socket %q", config.Socket)
+		}
+		sink = &socketSink{network: network, addr: addr}
+	default:
+		return nil, errors.New("statediff tracer output path or socket is required")
+	}
+	t := &stateDiffTracer{sink: sink}
+	return tracing.WrapWithJournal(&tracing.Hooks{
+		OnBlockStart:    t.onBlockStart,
+		OnBlockEnd:      t.onBlockEnd,
+		OnGenesisBlock:  t.onGenesisBlock,
+		OnTxStart:       t.onTxStart,
+		OnTxEnd:         t.onTxEnd,
+		OnBalanceChange: t.onBalanceChange,
+		OnNonceChange:   t.onNonceChange,
+		OnCodeChange:    t.onCodeChange,
+		OnStorageChange: t.onStorageChange,
+		OnClose:         t.onClose,
+	})
+}
+
+func (t *stateDiffTracer) reset(block *types.Block) {
+	t.record = StateDiffRecord{
+		Number:     block.NumberU64(),
+		Hash:       block.Hash(),
+		ParentHash: block.ParentHash(),
+	}
+	t.accounts = make(map[common.Address]*accountState)
+	t.tx, t.txCount = 0, 0
+}
+
+func (t *stateDiffTracer) account(addr common.Address) *accountState {
+	acct := t.accounts[addr]
+	if acct == nil {
+		acct = &accountState{storage: make(map[common.Hash][2]common.Hash)}
+		t.accounts[addr] = acct
+	}
+	return acct
+}
+
+func (t *stateDiffTracer) onBlockStart(ev tracing.BlockEvent) {
+	t.reset(ev.Block)
+}
+
+func (t *stateDiffTracer) onBlockEnd(err error) {
+	if err != nil {
+		return
+	}
+	t.write()
+}
+
+func (t *stateDiffTracer) onGenesisBlock(block *types.Block, alloc types.GenesisAlloc) {
+	t.reset(block)
+	for addr, account := range alloc {
+		acct := t.account(addr)
+		if account.Nonce != 0 {
+			acct.nonce = []uint64{0, account.Nonce}
+		}
+		if account.Balance != nil && account.Balance.Sign() != 0 {
+			acct.balance = []*big.Int{new(big.Int), new(big.Int).Set(account.Balance)}
+		}
+		if len(account.Code) > 0 {
+			acct.codeHash = []common.Hash{types.EmptyCodeHash, crypto.Keccak256Hash(account.Code)}
+			acct.code = account.Code
+		}
+		for key, value := range account.Storage {
+			acct.storage[key] = [2]common.Hash{{}, value}
+		}
+	}
+	t.write()
+}
+
+func (t *stateDiffTracer) onTxStart(vm *tracing.VMContext, tx *types.Transaction, from common.Address) {
+	t.txCount++
+	t.tx = t.txCount
+}
+
+func (t *stateDiffTracer) onTxEnd(receipt *types.Receipt, err error) {
+	if receipt != nil {
+		for _, l := range receipt.Logs {
+			t.record.Logs = append(t.record.Logs, LogRecord{
+				Tx:      t.tx - 1,
+				Address: l.Address,
+				Topics:  l.Topics,
+				Data:    l.Data,
+			})
+		}
+	}
+	t.tx = 0
+}
+
+func (t *stateDiffTracer) onBalanceChange(addr common.Address, prev, post *big.Int, reason tracing.BalanceChangeReason) {
+	t.record.Balances = append(t.record.Balances, BalanceChange{
+		Tx:      t.tx,
+		Address: addr,
+		Prev:    new(big.Int).Set(prev),
+		New:     new(big.Int).Set(post),
+		Reason:  uint64(reason),
+	})
+	acct := t.account(addr)
+	if acct.balance == nil {
+		acct.balance = []*big.Int{new(big.Int).Set(prev), nil}
+	}
+	acct.balance[1] = new(big.Int).Set(post)
+}
+
+func (t *stateDiffTracer) onNonceChange(addr common.Address, prev, post uint64) {
+	acct := t.account(addr)
+	if acct.nonce == nil {
+		acct.nonce = []uint64{prev, 0}
+	}
+	acct.nonce[1] = post
+}
+
+func (t *stateDiffTracer) onCodeChange(addr common.Address, prevCodeHash common.Hash, prevCode []byte, codeHash common.Hash, code []byte) {
+	acct := t.account(addr)
+	if acct.codeHash == nil {
+		acct.codeHash = []common.Hash{prevCodeHash, {}}
+	}
+	acct.codeHash[1] = codeHash
+	acct.code = code
+}
+
+func (t *stateDiffTracer) onStorageChange(addr common.Address, slot common.Hash, prev, post common.Hash) {
+	acct := t.account(addr)
+	change, ok := acct.storage[slot]
+	if !ok {
+		change[0] = prev
+	}
+	change[1] = post
+	acct.storage[slot] = change
+}
+
+// write assembles the net changes of the block and writes the record out.
+func (t *stateDiffTracer) write() {
+	for addr, acct := range t.accounts {
+		change := AccountChange{Address: addr}
+		if acct.nonce != nil && acct.nonce[0] != acct.nonce[1] {
+			change.Nonce = acct.nonce
+		}
+		if acct.balance != nil && acct.balance[0].Cmp(acct.balance[1]) != 0 {
+			change.Balance = acct.balance
+		}
+		if acct.codeHash != nil && acct.codeHash[0] != acct.codeHash[1] {
+			change.CodeHash = acct.codeHash
+			change.Code = acct.code
+		}
+		for key, values := range acct.storage {
+			if values[0] != values[1] {
+				change.Storage = append(change.Storage, SlotChange{Key: key, Prev: values[0], New: values[1]})
+			}
+		}
+		if change.Nonce == nil && change.Balance == nil && change.CodeHash == nil && change.Storage == nil {
+			continue
+		}
+		slices.SortFunc(change.Storage, func(a, b SlotChange) int {
+			return bytes.Compare(a.Key[:], b.Key[:])
+		})
+		t.record.Accounts = append(t.record.Accounts, change)
+	}
+	slices.SortFunc(t.record.Accounts, func(a, b AccountChange) int {
+		return bytes.Compare(a.Address[:], b.Address[:])
+	})
+	payload, err := rlp.EncodeToBytes(&t.record)
+	if err != nil {
+		log.Warn("Failed to encode state diff record", "number", t.record.Number, "err", err)
+		return
+	}
+	buf := make([]byte, 5, 5+len(payload))
+	binary.BigEndian.PutUint32(buf, uint32(1+len(payload)))
+	buf[4] = StateDiffRecordVersion
+	buf = append(buf, payload...)
+
+	if _, err := t.sink.Write(buf); err != nil {
+		log.Warn("Failed to write state diff record", "number", t.record.Number, "err", err)
+	}
+}
+
+func (t *stateDiffTracer) onClose() {
+	if err := t.sink.Close(); err != nil {
+		log.Warn("Failed to close state diff tracer output", "err", err)
+	}
+}
+
+// socketRetryInterval is the time between attempts to (re)connect the socket.
+const socketRetryInterval = time.Second
+
+// socketSink streams records to a socket. Writes block until the record is
+// delivered, reconnecting as needed, so no record is lost while the consumer
+// is unavailable. Note this holds up block processing in the meantime.
+type socketSink struct {
+	network string
+	addr    string
+	conn    net.Conn
+	closed  bool
+}
+
+func (s *socketSink) Write(record []byte) (int, error) {
+	for attempt := 0; ; attempt++ {
+		if s.closed {
+			return 0, errors.New("socket closed")
+		}
+		if s.conn == nil {
+			conn, err := net.Dial(s.network, s.addr)
+			if err != nil {
+				if attempt == 0 {
+					log.Warn("State diff consumer unavailable, retrying", "socket", s.network+":"+s.addr, "err", err)
+				}
+				time.Sleep(socketRetryInterval)
+				continue
+			}
+			s.conn = conn
+		}
+		n, err := s.conn.Write(record)
+		if err == nil {
+			return n, nil
+		}
+		// A partially written record can't be resumed on a new connection,
+		// the consumer discards it when the connection drops.
+		log.Warn("State diff consumer disconnected", "socket", s.network+":"+s.addr, "err", err)
+		s.conn.Close()
+		s.conn = nil
+	}
+}
+
+func (s *socketSink) Close() error {
+	s.closed = true
+	if s.conn != nil {
+		return s.conn.Close()
+	}
+	return nil
+}
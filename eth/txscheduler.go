@@ -0,0 +1,326 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+const (
+	// maxScheduledTxs is the maximum number of transactions waiting for their
+	// submission.
+	maxScheduledTxs = 4096
+
+	// maxScheduledResults is the number of recent submissions kept for reporting.
+	maxScheduledResults = 256
+)
+
+var (
+	errScheduleReached  = errors.New("schedule already reached")
+	errScheduleMissing  = errors.New("missing block number or timestamp")
+	errScheduledTxKnown = errors.New("transaction already scheduled")
+	errScheduleFull     = errors.New("too many scheduled transactions")
+	errScheduledBlobTx  = errors.New("blob transactions cannot be scheduled")
+)
+
+// scheduledTx is a transaction waiting for the chain to reach a block number
+// and timestamp before being submitted to the pool. A zero condition is unset.
+type scheduledTx struct {
+	Tx     []byte // Binary encoding of the transaction
+	Number uint64 // Minimum chain head number
+	Time   uint64 // Minimum chain head timestamp
+	Added  uint64 // Time the transaction was scheduled at
+
+	tx   *types.Transaction
+	from common.Address
+}
+
+// due reports whether the transaction can be submitted on top of the head.
+func (s *scheduledTx) due(head *types.Header) bool {
+	return head.Number.Uint64() >= s.Number && head.Time >= s.Time
+}
+
+// scheduledResult is the outcome of the submission of a scheduled transaction.
+type scheduledResult struct {
+	tx     *scheduledTx
+	number uint64 // Chain head number at the submission
+	err    error
+}
+
+// txScheduler holds locally signed transactions until the chain reaches the
+// block number or timestamp they are scheduled for, and submits them to the
+// transaction pool then. Pending transactions are persisted in the database
+// and survive restarts.
+type txScheduler struct {
+	db     ethdb.KeyValueStore
+	chain  *core.BlockChain
+	signer types.Signer
+	submit func(context.Context, *types.Transaction) error
+
+	pending map[common.Hash]*scheduledTx
+	results []*scheduledResult // Recent submissions, oldest first
+	lock    sync.Mutex
+
+	headCh  chan core.ChainHeadEvent
+	headSub event.Subscription
+	wg      sync.WaitGroup
+}
+
+// newTxScheduler creates a scheduler, loading the transactions persisted by a
+// previous run.
+func newTxScheduler(db ethdb.KeyValueStore, chain *core.BlockChain, submit func(context.Context, *types.Transaction) error) *txScheduler {
+	s := &txScheduler{
+		db:      db,
+		chain:   chain,
+		signer:  types.LatestSigner(chain.Config()),
+		submit:  submit,
+		pending: make(map[common.Hash]*scheduledTx),
+	}
+	for hash, blob := range rawdb.ReadScheduledTxs(db) {
+		entry := new(scheduledTx)
+		if err := rlp.DecodeBytes(blob, entry); err != nil {
+			log.Error("Dropping undecodable scheduled transaction", "hash", hash, "err", err)
+			rawdb.DeleteScheduledTx(db, hash)
+			continue
+		}
+		if err := s.decode(entry); err != nil {
+			log.Error("Dropping invalid scheduled transaction", "hash", hash, "err", err)
+			rawdb.DeleteScheduledTx(db, hash)
+			continue
+		}
+		s.pending[hash] = entry
+	}
+	if len(s.pending) > 0 {
+		log.Info("Loaded scheduled transactions", "count", len(s.pending))
+	}
+	return s
+}
+
+// decode resolves the transaction and its sender from the binary encoding.
+func (s *txScheduler) decode(entry *scheduledTx) error {
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(entry.Tx); err != nil {
+		return err
+	}
+	if tx.Type() == types.BlobTxType {
+		return errScheduledBlobTx
+	}
+	from, err := types.Sender(s.signer, tx)
+	if err != nil {
+		return fmt.Errorf("invalid sender: %v", err)
+	}
+	entry.tx, entry.from = tx, from
+	return nil
+}
+
+// start begins submitting the due transactions on each new chain head.
+func (s *txScheduler) start() {
+	s.headCh = make(chan core.ChainHeadEvent, chainHeadChanSize)
+	s.headSub = s.chain.SubscribeChainHeadEvent(s.headCh)
+
+	s.wg.Add(1)
+	go s.loop()
+}
+
+// stop terminates the submission loop. Pending transactions stay persisted.
+func (s *txScheduler) stop() {
+	s.headSub.Unsubscribe()
+	s.wg.Wait()
+}
+
+func (s *txScheduler) loop() {
+	defer s.wg.Done()
+
+	s.process(s.chain.CurrentHeader())
+	for {
+		select {
+		case ev := <-s.headCh:
+			s.process(ev.Header)
+		case <-s.headSub.Err():
+			return
+		}
+	}
+}
+
+// schedule validates and persists a signed transaction to be submitted once the
+// chain head reaches the given number and timestamp.
+func (s *txScheduler) schedule(blob []byte, number, timestamp uint64) (common.Hash, error) {
+	if number == 0 && timestamp == 0 {
+		return common.Hash{}, errScheduleMissing
+	}
+	entry := &scheduledTx{Tx: blob, Number: number, Time: timestamp}
+	if err := s.decode(entry); err != nil {
+		return common.Hash{}, err
+	}
+	if entry.due(s.chain.CurrentHeader()) {
+		return common.Hash{}, errScheduleReached
+	}
+	entry.Added = uint64(time.Now().Unix())
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	hash := entry.tx.Hash()
+	if _, ok := s.pending[hash]; ok {
+		return common.Hash{}, errScheduledTxKnown
+	}
+	if len(s.pending) >= maxScheduledTxs {
+		return common.Hash{}, errScheduleFull
+	}
+	enc, err := rlp.EncodeToBytes(entry)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	rawdb.WriteScheduledTx(s.db, hash, enc)
+	s.pending[hash] = entry
+
+	log.Info("Scheduled transaction", "hash", hash, "from", entry.from, "nonce", entry.tx.Nonce(), "number", number, "time", timestamp)
+	return hash, nil
+}
+
+// cancel drops a pending scheduled transaction, returning whether it existed.
+func (s *txScheduler) cancel(hash common.Hash) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if _, ok := s.pending[hash]; !ok {
+		return false
+	}
+	delete(s.pending, hash)
+	rawdb.DeleteScheduledTx(s.db, hash)
+
+	log.Info("Cancelled scheduled transaction", "hash", hash)
+	return true
+}
+
+// process submits the transactions due on top of the given head, ordered by
+// sender and nonce so that sequences of transactions are accepted.
+func (s *txScheduler) process(head *types.Header) {
+	s.lock.Lock()
+	var due []*scheduledTx
+	for hash, entry := range s.pending {
+		if entry.due(head) {
+			due = append(due, entry)
+			delete(s.pending, hash)
+			rawdb.DeleteScheduledTx(s.db, hash)
+		}
+	}
+	s.lock.Unlock()
+
+	if len(due) == 0 {
+		return
+	}
+	slices.SortFunc(due, func(a, b *scheduledTx) int {
+		if c := a.from.Cmp(b.from); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.tx.Nonce(), b.tx.Nonce())
+	})
+	results := make([]*scheduledResult, 0, len(due))
+	for _, entry := range due {
+		err := s.submit(context.Background(), entry.tx)
+		if err != nil {
+			log.Warn("Failed to submit scheduled transaction", "hash", entry.tx.Hash(), "from", entry.from, "nonce", entry.tx.Nonce(), "err", err)
+		} else {
+			log.Info("Submitted scheduled transaction", "hash", entry.tx.Hash(), "from", entry.from, "nonce", entry.tx.Nonce(), "number", head.Number)
+		}
+		results = append(results, &scheduledResult{tx: entry, number: head.Number.Uint64(), err: err})
+	}
+	s.lock.Lock()
+	s.results = append(s.results, results...)
+	if len(s.results) > maxScheduledResults {
+		s.results = slices.Clone(s.results[len(s.results)-maxScheduledResults:])
+	}
+	s.lock.Unlock()
+}
+
+// scheduledTxInfo is the RPC representation of a scheduled transaction.
+type scheduledTxInfo struct {
+	Hash      common.Hash     `json:"hash"`
+	From      common.Address  `json:"from"`
+	Nonce     hexutil.Uint64  `json:"nonce"`
+	NotBefore TxScheduleArgs  `json:"notBefore"`
+	Added     hexutil.Uint64  `json:"added"`
+	Status    string          `json:"status"`                // scheduled, submitted or failed
+	Submitted *hexutil.Uint64 `json:"submittedAt,omitempty"` // Chain head number at the submission
+	Error     string          `json:"error,omitempty"`
+}
+
+func newScheduledTxInfo(entry *scheduledTx, status string) *scheduledTxInfo {
+	info := &scheduledTxInfo{
+		Hash:   entry.tx.Hash(),
+		From:   entry.from,
+		Nonce:  hexutil.Uint64(entry.tx.Nonce()),
+		Added:  hexutil.Uint64(entry.Added),
+		Status: status,
+	}
+	if entry.Number != 0 {
+		info.NotBefore.BlockNumber = (*hexutil.Uint64)(&entry.Number)
+	}
+	if entry.Time != 0 {
+		info.NotBefore.Timestamp = (*hexutil.Uint64)(&entry.Time)
+	}
+	return info
+}
+
+// list returns the pending scheduled transactions ordered by schedule, followed
+// by the recent submissions, most recent first.
+func (s *txScheduler) list() []*scheduledTxInfo {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	pending := slices.SortedFunc(maps.Values(s.pending), func(a, b *scheduledTx) int {
+		if c := cmp.Compare(a.Number, b.Number); c != 0 {
+			return c
+		}
+		if c := cmp.Compare(a.Time, b.Time); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Added, b.Added)
+	})
+	infos := make([]*scheduledTxInfo, 0, len(pending)+len(s.results))
+	for _, entry := range pending {
+		infos = append(infos, newScheduledTxInfo(entry, "scheduled"))
+	}
+	for i := len(s.results) - 1; i >= 0; i-- {
+		res := s.results[i]
+		info := newScheduledTxInfo(res.tx, "submitted")
+		info.Submitted = (*hexutil.Uint64)(&res.number)
+		if res.err != nil {
+			info.Status, info.Error = "failed", res.err.Error()
+		}
+		infos = append(infos, info)
+	}
+	return infos
+}
@@ -0,0 +1,123 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func TestTxScheduler(t *testing.T) {
+	var (
+		key, _ = crypto.GenerateKey()
+		db     = rawdb.NewMemoryDatabase()
+		gspec  = &core.Genesis{Config: params.TestChainConfig, BaseFee: big.NewInt(params.InitialBaseFee)}
+		signer = types.LatestSigner(params.TestChainConfig)
+	)
+	_, blocks, _ := core.GenerateChainWithGenesis(gspec, ethash.NewFaker(), 3, nil)
+	chain, err := core.NewBlockChain(db, gspec, ethash.NewFaker(), nil)
+	if err != nil {
+		t.Fatalf("failed to create chain: %v", err)
+	}
+	defer chain.Stop()
+
+	var submitted []*types.Transaction
+	submit := func(ctx context.Context, tx *types.Transaction) error {
+		submitted = append(submitted, tx)
+		if tx.Nonce() == 2 {
+			return errors.New("rejected")
+		}
+		return nil
+	}
+	newTx := func(nonce uint64) []byte {
+		tx := types.MustSignNewTx(key, signer, &types.LegacyTx{Nonce: nonce, To: &common.Address{}, Gas: params.TxGas, GasPrice: big.NewInt(params.InitialBaseFee)})
+		blob, _ := tx.MarshalBinary()
+		return blob
+	}
+	s := newTxScheduler(db, chain, submit)
+
+	// Invalid schedules are rejected
+	if _, err := s.schedule(newTx(0), 0, 0); !errors.Is(err, errScheduleMissing) {
+		t.Fatalf("missing schedule: have %v, want %v", err, errScheduleMissing)
+	}
+	// Schedule transactions out of nonce order
+	hash1, err := s.schedule(newTx(1), 2, 0)
+	if err != nil {
+		t.Fatalf("failed to schedule: %v", err)
+	}
+	if _, err := s.schedule(newTx(0), 2, 0); err != nil {
+		t.Fatalf("failed to schedule: %v", err)
+	}
+	if _, err := s.schedule(newTx(0), 2, 0); !errors.Is(err, errScheduledTxKnown) {
+		t.Fatalf("duplicate schedule: have %v, want %v", err, errScheduledTxKnown)
+	}
+	hash2, err := s.schedule(newTx(2), 3, 0)
+	if err != nil {
+		t.Fatalf("failed to schedule: %v", err)
+	}
+	hash3, err := s.schedule(newTx(3), 3, 0)
+	if err != nil {
+		t.Fatalf("failed to schedule: %v", err)
+	}
+	if !s.cancel(hash3) || s.cancel(hash3) {
+		t.Fatal("failed to cancel scheduled transaction")
+	}
+	// Restart the scheduler, the transactions should be reloaded
+	s = newTxScheduler(db, chain, submit)
+	if len(s.pending) != 3 {
+		t.Fatalf("wrong number of reloaded transactions: have %d, want 3", len(s.pending))
+	}
+	// Import the blocks one by one, checking the submissions
+	for i, want := range []int{0, 2, 3} {
+		if _, err := chain.InsertChain(blocks[i : i+1]); err != nil {
+			t.Fatalf("failed to insert block %d: %v", i+1, err)
+		}
+		s.process(chain.CurrentHeader())
+		if len(submitted) != want {
+			t.Fatalf("block %d: wrong number of submissions: have %d, want %d", i+1, len(submitted), want)
+		}
+	}
+	if submitted[0].Nonce() != 0 || submitted[1].Hash() != hash1 || submitted[2].Hash() != hash2 {
+		t.Fatal("wrong submission order")
+	}
+	if _, err := s.schedule(newTx(4), 3, 0); !errors.Is(err, errScheduleReached) {
+		t.Fatalf("reached schedule: have %v, want %v", err, errScheduleReached)
+	}
+	if txs := rawdb.ReadScheduledTxs(db); len(txs) != 0 {
+		t.Fatalf("submitted transactions still persisted: %d", len(txs))
+	}
+	infos := s.list()
+	if len(infos) != 3 {
+		t.Fatalf("wrong number of reported transactions: have %d, want 3", len(infos))
+	}
+	if infos[0].Hash != hash2 || infos[0].Status != "failed" || infos[0].Error != "rejected" {
+		t.Fatalf("wrong failed submission report: %+v", infos[0])
+	}
+	if infos[1].Hash != hash1 || infos[1].Status != "submitted" || uint64(*infos[1].Submitted) != 2 {
+		t.Fatalf("wrong submission report: %+v", infos[1])
+	}
+}
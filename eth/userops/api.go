@@ -0,0 +1,50 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package userops
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// API exposes the user operation mempool over RPC, in the eth namespace as
+// specified by ERC-4337.
+type API struct {
+	service *Service
+}
+
+// NewAPI creates the RPC service for the user operation mempool.
+func NewAPI(service *Service) *API {
+	return &API{service: service}
+}
+
+// SendUserOperation validates a user operation and adds it to the mempool,
+// returning its hash.
+func (api *API) SendUserOperation(op UserOperation, entryPoint common.Address) (common.Hash, error) {
+	return api.service.Add(&op, entryPoint)
+}
+
+// SupportedEntryPoints returns the EntryPoint contracts operations can be
+// submitted to.
+func (api *API) SupportedEntryPoints() []common.Address {
+	return []common.Address{api.service.config.EntryPoint}
+}
+
+// GetUserOperationByHash returns the pending user operation with the given hash,
+// or nil if it's not in the mempool.
+func (api *API) GetUserOperationByHash(hash common.Hash) *UserOperation {
+	return api.service.Get(hash)
+}
@@ -0,0 +1,178 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package userops
+
+import (
+	"errors"
+	"math/big"
+	"slices"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	// maxPoolOps is the maximum number of operations held by the pool.
+	maxPoolOps = 4096
+
+	// maxSenderOps is the maximum number of operations of a single sender held
+	// by the pool, as senders are not staked.
+	maxSenderOps = 4
+
+	// priceBump is the minimum fee increase in percent required to replace an
+	// operation with the same sender and nonce.
+	priceBump = 10
+)
+
+var (
+	errKnownOp         = errors.New("already known")
+	errPoolFull        = errors.New("user operation pool is full")
+	errSenderLimit     = errors.New("too many pending user operations of sender")
+	errReplaceUnderpay = errors.New("replacement user operation underpriced")
+)
+
+// senderNonce identifies the slot of an operation in the pool.
+type senderNonce struct {
+	sender common.Address
+	nonce  string // Nonce encoded as hex, nonces are 256 bit with a key part
+}
+
+// pooledOp is an operation held by the pool.
+type pooledOp struct {
+	op   *UserOperation
+	hash common.Hash
+}
+
+// pool holds the validated user operations waiting for inclusion.
+type pool struct {
+	ops   map[common.Hash]*pooledOp
+	slots map[senderNonce]common.Hash
+	lock  sync.RWMutex
+}
+
+func newPool() *pool {
+	return &pool{
+		ops:   make(map[common.Hash]*pooledOp),
+		slots: make(map[senderNonce]common.Hash),
+	}
+}
+
+func slotOf(op *UserOperation) senderNonce {
+	return senderNonce{sender: op.Sender, nonce: op.Nonce.String()}
+}
+
+// add inserts an operation into the pool, replacing the operation of the same
+// sender and nonce if the new one pays sufficiently higher fees.
+func (p *pool) add(entry *pooledOp) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if _, ok := p.ops[entry.hash]; ok {
+		return errKnownOp
+	}
+	slot := slotOf(entry.op)
+	if hash, ok := p.slots[slot]; ok {
+		old := p.ops[hash].op
+		if !bumped(old.MaxFeePerGas.ToInt(), entry.op.MaxFeePerGas.ToInt()) || !bumped(old.MaxPriorityFeePerGas.ToInt(), entry.op.MaxPriorityFeePerGas.ToInt()) {
+			return errReplaceUnderpay
+		}
+		delete(p.ops, hash)
+	} else {
+		if len(p.ops) >= maxPoolOps {
+			return errPoolFull
+		}
+		var count int
+		for s := range p.slots {
+			if s.sender == entry.op.Sender {
+				count++
+			}
+		}
+		if count >= maxSenderOps {
+			return errSenderLimit
+		}
+	}
+	p.ops[entry.hash] = entry
+	p.slots[slot] = entry.hash
+	return nil
+}
+
+// bumped reports whether the new fee exceeds the old one by the price bump.
+func bumped(prev, next *big.Int) bool {
+	threshold := new(big.Int).Mul(prev, big.NewInt(100+priceBump))
+	return threshold.Cmp(new(big.Int).Mul(next, big.NewInt(100))) <= 0
+}
+
+// get returns the operation with the given hash.
+func (p *pool) get(hash common.Hash) *pooledOp {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	return p.ops[hash]
+}
+
+// remove drops the operations with the given hashes from the pool.
+func (p *pool) remove(hashes ...common.Hash) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	for _, hash := range hashes {
+		entry, ok := p.ops[hash]
+		if !ok {
+			continue
+		}
+		delete(p.ops, hash)
+		delete(p.slots, slotOf(entry.op))
+	}
+}
+
+// pending returns the pooled operations paying at least the given base fee,
+// ordered by priority fee. Only the operation with the lowest nonce of each
+// sender is returned, as the operations of a sender are included one by one.
+func (p *pool) pending(baseFee *big.Int) []*pooledOp {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	first := make(map[common.Address]*pooledOp)
+	for _, entry := range p.ops {
+		if prev := first[entry.op.Sender]; prev == nil || entry.op.Nonce.ToInt().Cmp(prev.op.Nonce.ToInt()) < 0 {
+			first[entry.op.Sender] = entry
+		}
+	}
+	var ops []*pooledOp
+	for _, entry := range first {
+		if baseFee != nil && entry.op.MaxFeePerGas.ToInt().Cmp(baseFee) < 0 {
+			continue
+		}
+		ops = append(ops, entry)
+	}
+	slices.SortFunc(ops, func(a, b *pooledOp) int {
+		return tip(b.op, baseFee).Cmp(tip(a.op, baseFee))
+	})
+	return ops
+}
+
+// tip returns the effective priority fee of an operation at the given base fee.
+func tip(op *UserOperation, baseFee *big.Int) *big.Int {
+	if baseFee == nil {
+		return op.MaxPriorityFeePerGas.ToInt()
+	}
+	tip := new(big.Int).Sub(op.MaxFeePerGas.ToInt(), baseFee)
+	if tip.Cmp(op.MaxPriorityFeePerGas.ToInt()) > 0 {
+		tip.Set(op.MaxPriorityFeePerGas.ToInt())
+	}
+	return tip
+}
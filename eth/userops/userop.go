@@ -0,0 +1,214 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package userops
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// DefaultEntryPoint is the canonical deployment of the v0.7 EntryPoint contract.
+var DefaultEntryPoint = common.HexToAddress("0x0000000071727De22E5E9d8BAf0edAc6f37da032")
+
+// entryPointABI is the subset of the v0.7 EntryPoint interface used by the bundler.
+const entryPointABI = `[
+	{"type":"function","name":"handleOps","inputs":[{"name":"ops","type":"tuple[]","components":[
+		{"name":"sender","type":"address"},
+		{"name":"nonce","type":"uint256"},
+		{"name":"initCode","type":"bytes"},
+		{"name":"callData","type":"bytes"},
+		{"name":"accountGasLimits","type":"bytes32"},
+		{"name":"preVerificationGas","type":"uint256"},
+		{"name":"gasFees","type":"bytes32"},
+		{"name":"paymasterAndData","type":"bytes"},
+		{"name":"signature","type":"bytes"}]},
+		{"name":"beneficiary","type":"address"}],"outputs":[]},
+	{"type":"error","name":"FailedOp","inputs":[{"name":"opIndex","type":"uint256"},{"name":"reason","type":"string"}]},
+	{"type":"error","name":"FailedOpWithRevert","inputs":[{"name":"opIndex","type":"uint256"},{"name":"reason","type":"string"},{"name":"inner","type":"bytes"}]},
+	{"type":"event","name":"UserOperationEvent","inputs":[
+		{"name":"userOpHash","type":"bytes32","indexed":true},
+		{"name":"sender","type":"address","indexed":true},
+		{"name":"paymaster","type":"address","indexed":true},
+		{"name":"nonce","type":"uint256","indexed":false},
+		{"name":"success","type":"bool","indexed":false},
+		{"name":"actualGasCost","type":"uint256","indexed":false},
+		{"name":"actualGasUsed","type":"uint256","indexed":false}]}
+]`
+
+var (
+	entryPoint, _ = abi.JSON(strings.NewReader(entryPointABI))
+
+	// userOperationEventTopic is the topic of the log emitted by the EntryPoint
+	// for every user operation included in a block.
+	userOperationEventTopic = entryPoint.Events["UserOperationEvent"].ID
+)
+
+// UserOperation is an ERC-4337 user operation in the v0.7 RPC representation,
+// with the packed fields of the EntryPoint split into their components.
+type UserOperation struct {
+	Sender                        common.Address  `json:"sender"`
+	Nonce                         *hexutil.Big    `json:"nonce"`
+	Factory                       *common.Address `json:"factory,omitempty"`
+	FactoryData                   hexutil.Bytes   `json:"factoryData,omitempty"`
+	CallData                      hexutil.Bytes   `json:"callData"`
+	CallGasLimit                  hexutil.Uint64  `json:"callGasLimit"`
+	VerificationGasLimit          hexutil.Uint64  `json:"verificationGasLimit"`
+	PreVerificationGas            hexutil.Uint64  `json:"preVerificationGas"`
+	MaxFeePerGas                  *hexutil.Big    `json:"maxFeePerGas"`
+	MaxPriorityFeePerGas          *hexutil.Big    `json:"maxPriorityFeePerGas"`
+	Paymaster                     *common.Address `json:"paymaster,omitempty"`
+	PaymasterVerificationGasLimit hexutil.Uint64  `json:"paymasterVerificationGasLimit,omitempty"`
+	PaymasterPostOpGasLimit       hexutil.Uint64  `json:"paymasterPostOpGasLimit,omitempty"`
+	PaymasterData                 hexutil.Bytes   `json:"paymasterData,omitempty"`
+	Signature                     hexutil.Bytes   `json:"signature"`
+}
+
+// packedUserOperation is the PackedUserOperation struct of the EntryPoint.
+type packedUserOperation struct {
+	Sender             common.Address
+	Nonce              *big.Int
+	InitCode           []byte
+	CallData           []byte
+	AccountGasLimits   [32]byte
+	PreVerificationGas *big.Int
+	GasFees            [32]byte
+	PaymasterAndData   []byte
+	Signature          []byte
+}
+
+// sanitize checks that the mandatory fields of the operation are set.
+func (op *UserOperation) sanitize() error {
+	switch {
+	case op.Nonce == nil:
+		return errors.New("missing nonce")
+	case op.MaxFeePerGas == nil || op.MaxPriorityFeePerGas == nil:
+		return errors.New("missing fee fields")
+	case op.MaxFeePerGas.ToInt().Cmp(op.MaxPriorityFeePerGas.ToInt()) < 0:
+		return errors.New("max priority fee per gas higher than max fee per gas")
+	case op.MaxFeePerGas.ToInt().BitLen() > 128 || op.Nonce.ToInt().BitLen() > 256:
+		return errors.New("fee or nonce out of range")
+	case op.Factory == nil && len(op.FactoryData) > 0:
+		return errors.New("factory data without factory")
+	case op.Paymaster == nil && (len(op.PaymasterData) > 0 || op.PaymasterVerificationGasLimit != 0 || op.PaymasterPostOpGasLimit != 0):
+		return errors.New("paymaster fields without paymaster")
+	}
+	return nil
+}
+
+// Gas returns the maximum amount of gas the operation may consume.
+func (op *UserOperation) Gas() uint64 {
+	return uint64(op.PreVerificationGas + op.VerificationGasLimit + op.CallGasLimit + op.PaymasterVerificationGasLimit + op.PaymasterPostOpGasLimit)
+}
+
+// pack converts the operation into the representation of the EntryPoint.
+func (op *UserOperation) pack() packedUserOperation {
+	packed := packedUserOperation{
+		Sender:             op.Sender,
+		Nonce:              op.Nonce.ToInt(),
+		CallData:           op.CallData,
+		PreVerificationGas: new(big.Int).SetUint64(uint64(op.PreVerificationGas)),
+		Signature:          op.Signature,
+	}
+	if op.Factory != nil {
+		packed.InitCode = append(op.Factory.Bytes(), op.FactoryData...)
+	}
+	packed.AccountGasLimits = packUint128s(new(big.Int).SetUint64(uint64(op.VerificationGasLimit)), new(big.Int).SetUint64(uint64(op.CallGasLimit)))
+	packed.GasFees = packUint128s(op.MaxPriorityFeePerGas.ToInt(), op.MaxFeePerGas.ToInt())
+
+	if op.Paymaster != nil {
+		gas := packUint128s(new(big.Int).SetUint64(uint64(op.PaymasterVerificationGasLimit)), new(big.Int).SetUint64(uint64(op.PaymasterPostOpGasLimit)))
+		packed.PaymasterAndData = append(append(op.Paymaster.Bytes(), gas[:]...), op.PaymasterData...)
+	}
+	return packed
+}
+
+// Hash returns the hash identifying the operation, as computed by the given
+// EntryPoint on the given chain.
+func (op *UserOperation) Hash(entryPoint common.Address, chainID *big.Int) common.Hash {
+	packed := op.pack()
+
+	var enc []byte
+	enc = append(enc, common.LeftPadBytes(packed.Sender.Bytes(), 32)...)
+	enc = append(enc, common.LeftPadBytes(packed.Nonce.Bytes(), 32)...)
+	enc = append(enc, crypto.Keccak256(packed.InitCode)...)
+	enc = append(enc, crypto.Keccak256(packed.CallData)...)
+	enc = append(enc, packed.AccountGasLimits[:]...)
+	enc = append(enc, common.LeftPadBytes(packed.PreVerificationGas.Bytes(), 32)...)
+	enc = append(enc, packed.GasFees[:]...)
+	enc = append(enc, crypto.Keccak256(packed.PaymasterAndData)...)
+
+	return crypto.Keccak256Hash(
+		crypto.Keccak256(enc),
+		common.LeftPadBytes(entryPoint.Bytes(), 32),
+		common.LeftPadBytes(chainID.Bytes(), 32),
+	)
+}
+
+// packUint128s packs two 128 bit integers into a 32 byte word, the first one
+// occupying the high half.
+func packUint128s(high, low *big.Int) [32]byte {
+	var word [32]byte
+	high.FillBytes(word[:16])
+	low.FillBytes(word[16:])
+	return word
+}
+
+// encodeHandleOps returns the calldata of the EntryPoint call executing the
+// given operations.
+func encodeHandleOps(ops []*UserOperation, beneficiary common.Address) ([]byte, error) {
+	packed := make([]packedUserOperation, len(ops))
+	for i, op := range ops {
+		packed[i] = op.pack()
+	}
+	return entryPoint.Pack("handleOps", packed, beneficiary)
+}
+
+// failedOp is a user operation rejected by the EntryPoint.
+type failedOp struct {
+	index  int
+	reason string
+}
+
+// decodeFailedOp extracts the rejected operation from the revert data of the
+// EntryPoint, if the revert was caused by one.
+func decodeFailedOp(data []byte) (*failedOp, bool) {
+	for _, name := range []string{"FailedOp", "FailedOpWithRevert"} {
+		abiErr := entryPoint.Errors[name]
+		if len(data) < 4 || !bytes.Equal(data[:4], abiErr.ID[:4]) {
+			continue
+		}
+		values, err := abiErr.Unpack(data)
+		if err != nil {
+			return nil, false
+		}
+		fields := values.([]interface{})
+		index, _ := fields[0].(*big.Int)
+		reason, _ := fields[1].(string)
+		if index == nil || !index.IsInt64() {
+			return nil, false
+		}
+		return &failedOp{index: int(index.Int64()), reason: reason}, true
+	}
+	return nil, false
+}
@@ -0,0 +1,244 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package userops implements an ERC-4337 user operation mempool with a bundler
+// integrated into block building.
+//
+// User operations submitted via eth_sendUserOperation are validated by
+// simulating their inclusion through the EntryPoint contract, enforcing the
+// ERC-7562 rules for unstaked entities on the validation phase. Every block
+// built by the node starts with a handleOps transaction, signed by the bundler
+// account, executing the pending operations.
+package userops
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/eth"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/node"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// DefaultMaxBundleGas is the default gas limit of the operations in a bundle.
+const DefaultMaxBundleGas = 10_000_000
+
+var errUnsupportedEntryPoint = errors.New("unsupported entry point")
+
+// Config are the settings of the user operation mempool.
+type Config struct {
+	EntryPoint   common.Address    // EntryPoint contract the operations are submitted to
+	Key          *ecdsa.PrivateKey // Key of the bundler account signing the bundles
+	MaxBundleGas uint64            // Maximum total gas limit of the operations in a bundle
+}
+
+// Service holds the pending user operations and bundles them into the blocks
+// built by the miner.
+type Service struct {
+	config  Config
+	chain   *core.BlockChain
+	bundler common.Address
+	signer  types.Signer
+	pool    *pool
+
+	closed chan struct{}
+	wg     sync.WaitGroup
+}
+
+// New creates the user operation mempool on top of the given chain.
+func New(chain *core.BlockChain, config Config) *Service {
+	if config.MaxBundleGas == 0 {
+		config.MaxBundleGas = DefaultMaxBundleGas
+	}
+	return &Service{
+		config:  config,
+		chain:   chain,
+		bundler: crypto.PubkeyToAddress(config.Key.PublicKey),
+		signer:  types.LatestSigner(chain.Config()),
+		pool:    newPool(),
+		closed:  make(chan struct{}),
+	}
+}
+
+// Register registers the user operation mempool into the node stack and hands
+// its bundles to the miner.
+func Register(stack *node.Node, backend *eth.Ethereum, config Config) *Service {
+	s := New(backend.BlockChain(), config)
+	stack.RegisterAPIs([]rpc.API{{
+		Namespace: "eth",
+		Service:   NewAPI(s),
+	}})
+	stack.RegisterLifecycle(s)
+	backend.Miner().AddBundleSource(s)
+
+	log.Info("Enabled user operation mempool", "entrypoint", config.EntryPoint, "bundler", s.bundler)
+	return s
+}
+
+// Start implements node.Lifecycle, starting the tracking of included operations.
+func (s *Service) Start() error {
+	s.wg.Add(1)
+	go s.loop()
+	return nil
+}
+
+// Stop implements node.Lifecycle, terminating the tracking of included operations.
+func (s *Service) Stop() error {
+	close(s.closed)
+	s.wg.Wait()
+	return nil
+}
+
+// loop drops the operations included by canonical blocks from the pool.
+func (s *Service) loop() {
+	defer s.wg.Done()
+
+	events := make(chan core.ChainEvent, 16)
+	sub := s.chain.SubscribeChainEvent(events)
+	defer sub.Unsubscribe()
+
+	for {
+		select {
+		case ev := <-events:
+			var included []common.Hash
+			for _, receipt := range ev.Receipts {
+				for _, l := range receipt.Logs {
+					if l.Address == s.config.EntryPoint && len(l.Topics) > 1 && l.Topics[0] == userOperationEventTopic {
+						included = append(included, l.Topics[1])
+					}
+				}
+			}
+			s.pool.remove(included...)
+		case <-sub.Err():
+			return
+		case <-s.closed:
+			return
+		}
+	}
+}
+
+// Add validates a user operation against the current head state and adds it
+// to the pool.
+func (s *Service) Add(op *UserOperation, entryPoint common.Address) (common.Hash, error) {
+	if entryPoint != s.config.EntryPoint {
+		return common.Hash{}, errUnsupportedEntryPoint
+	}
+	if err := op.sanitize(); err != nil {
+		return common.Hash{}, err
+	}
+	hash := op.Hash(entryPoint, s.chain.Config().ChainID)
+	if s.pool.get(hash) != nil {
+		return common.Hash{}, errKnownOp
+	}
+	head := s.chain.CurrentBlock()
+	statedb, err := s.chain.StateAt(head.Root)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	if _, err := validate(s.chain.Config(), s.chain, head, statedb, entryPoint, s.bundler, op); err != nil {
+		return common.Hash{}, fmt.Errorf("user operation rejected: %w", err)
+	}
+	if err := s.pool.add(&pooledOp{op: op, hash: hash}); err != nil {
+		return common.Hash{}, err
+	}
+	log.Debug("Accepted user operation", "hash", hash, "sender", op.Sender, "nonce", op.Nonce)
+	return hash, nil
+}
+
+// Get returns the pending user operation with the given hash.
+func (s *Service) Get(hash common.Hash) *UserOperation {
+	if entry := s.pool.get(hash); entry != nil {
+		return entry.op
+	}
+	return nil
+}
+
+// Bundle implements miner.BundleSource, returning the handleOps transaction
+// executing the pending operations on top of the given state. Operations
+// rejected by the EntryPoint are dropped from the pool.
+func (s *Service) Bundle(header *types.Header, statedb *state.StateDB) []*types.Transaction {
+	var (
+		ops []*UserOperation
+		gas uint64
+	)
+	for _, entry := range s.pool.pending(header.BaseFee) {
+		if gas+entry.op.Gas() > s.config.MaxBundleGas {
+			continue
+		}
+		ops = append(ops, entry.op)
+		gas += entry.op.Gas()
+	}
+	var sim *simulation
+	for len(ops) > 0 {
+		var err error
+		sim, err = simulate(s.chain.Config(), s.chain, header, statedb.Copy(), s.config.EntryPoint, s.bundler, ops, nil)
+		if err != nil {
+			log.Warn("Failed to simulate user operation bundle", "ops", len(ops), "err", err)
+			return nil
+		}
+		if sim.failed == nil {
+			break
+		}
+		if sim.failed.index >= len(ops) {
+			log.Warn("EntryPoint rejected unknown user operation", "index", sim.failed.index, "reason", sim.failed.reason)
+			return nil
+		}
+		failed := ops[sim.failed.index]
+		log.Debug("Dropping rejected user operation", "sender", failed.Sender, "nonce", failed.Nonce, "reason", sim.failed.reason)
+
+		s.pool.remove(failed.Hash(s.config.EntryPoint, s.chain.Config().ChainID))
+		ops = append(ops[:sim.failed.index], ops[sim.failed.index+1:]...)
+	}
+	if len(ops) == 0 {
+		return nil
+	}
+	data, err := encodeHandleOps(ops, s.bundler)
+	if err != nil {
+		return nil
+	}
+	// Leave headroom on top of the simulated gas, as the EntryPoint checks the
+	// gas left against the limits of the operations.
+	limit := min(sim.gasUsed+sim.gasUsed/5, sim.gasLimit)
+
+	feeCap := new(big.Int)
+	if header.BaseFee != nil {
+		feeCap.Set(header.BaseFee)
+	}
+	tx, err := types.SignNewTx(s.config.Key, s.signer, &types.DynamicFeeTx{
+		ChainID:   s.chain.Config().ChainID,
+		Nonce:     statedb.GetNonce(s.bundler),
+		GasTipCap: new(big.Int),
+		GasFeeCap: feeCap,
+		Gas:       limit,
+		To:        &s.config.EntryPoint,
+		Data:      data,
+	})
+	if err != nil {
+		log.Warn("Failed to sign user operation bundle", "err", err)
+		return nil
+	}
+	log.Debug("Bundled user operations", "ops", len(ops), "gas", limit, "hash", tx.Hash())
+	return []*types.Transaction{tx}
+}
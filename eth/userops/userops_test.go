@@ -0,0 +1,200 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package userops
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/core/vm/runtime"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func newOp(sender common.Address, nonce int64, maxFee, maxTip int64) *UserOperation {
+	return &UserOperation{
+		Sender:               sender,
+		Nonce:                (*hexutil.Big)(big.NewInt(nonce)),
+		CallGasLimit:         100_000,
+		VerificationGasLimit: 100_000,
+		PreVerificationGas:   21_000,
+		MaxFeePerGas:         (*hexutil.Big)(big.NewInt(maxFee)),
+		MaxPriorityFeePerGas: (*hexutil.Big)(big.NewInt(maxTip)),
+	}
+}
+
+func addOp(p *pool, op *UserOperation) error {
+	return p.add(&pooledOp{op: op, hash: op.Hash(DefaultEntryPoint, big.NewInt(1))})
+}
+
+func TestPoolReplacement(t *testing.T) {
+	var (
+		p      = newPool()
+		sender = common.Address{0x01}
+	)
+	if err := addOp(p, newOp(sender, 0, 100, 10)); err != nil {
+		t.Fatalf("failed to add operation: %v", err)
+	}
+	if err := addOp(p, newOp(sender, 0, 100, 10)); !errors.Is(err, errKnownOp) {
+		t.Fatalf("duplicate operation: have %v, want %v", err, errKnownOp)
+	}
+	if err := addOp(p, newOp(sender, 0, 109, 11)); !errors.Is(err, errReplaceUnderpay) {
+		t.Fatalf("underpriced replacement: have %v, want %v", err, errReplaceUnderpay)
+	}
+	if err := addOp(p, newOp(sender, 0, 110, 11)); err != nil {
+		t.Fatalf("failed to replace operation: %v", err)
+	}
+	if len(p.ops) != 1 || len(p.slots) != 1 {
+		t.Fatalf("unexpected pool size after replacement: %d ops, %d slots", len(p.ops), len(p.slots))
+	}
+	for i := 1; i < maxSenderOps; i++ {
+		if err := addOp(p, newOp(sender, int64(i), 100, 10)); err != nil {
+			t.Fatalf("failed to add operation %d: %v", i, err)
+		}
+	}
+	if err := addOp(p, newOp(sender, maxSenderOps, 100, 10)); !errors.Is(err, errSenderLimit) {
+		t.Fatalf("operation over sender limit: have %v, want %v", err, errSenderLimit)
+	}
+}
+
+func TestPoolPending(t *testing.T) {
+	p := newPool()
+	addOp(p, newOp(common.Address{0x01}, 1, 100, 50)) // Higher nonce of the same sender
+	addOp(p, newOp(common.Address{0x01}, 0, 100, 10))
+	addOp(p, newOp(common.Address{0x02}, 0, 100, 20))
+	addOp(p, newOp(common.Address{0x03}, 0, 40, 20)) // Under the base fee
+
+	pending := p.pending(big.NewInt(50))
+	if len(pending) != 2 {
+		t.Fatalf("unexpected number of pending operations: have %d, want 2", len(pending))
+	}
+	if pending[0].op.Sender != (common.Address{0x02}) {
+		t.Errorf("unexpected first operation sender %x", pending[0].op.Sender)
+	}
+	if pending[1].op.Sender != (common.Address{0x01}) || pending[1].op.Nonce.ToInt().Sign() != 0 {
+		t.Errorf("unexpected second operation: sender %x nonce %v", pending[1].op.Sender, pending[1].op.Nonce)
+	}
+}
+
+func TestValidationRules(t *testing.T) {
+	var (
+		entry   = common.Address{0xee}
+		sender  = common.Address{0xaa}
+		library = common.Address{0xbb}
+	)
+	// The entry point calls the sender, which calls the library.
+	call := func(to common.Address) []byte {
+		code := []byte{byte(vm.PUSH1), 0, byte(vm.PUSH1), 0, byte(vm.PUSH1), 0, byte(vm.PUSH1), 0, byte(vm.PUSH1), 0, byte(vm.PUSH20)}
+		code = append(code, to.Bytes()...)
+		return append(code, byte(vm.GAS), byte(vm.CALL), byte(vm.POP), byte(vm.STOP))
+	}
+	tests := []struct {
+		name    string
+		sender  []byte
+		library []byte
+		fail    bool
+	}{
+		{
+			name:   "banned opcode",
+			sender: []byte{byte(vm.TIMESTAMP), byte(vm.POP), byte(vm.STOP)},
+			fail:   true,
+		},
+		{
+			name:   "gas not followed by call",
+			sender: []byte{byte(vm.GAS), byte(vm.POP), byte(vm.STOP)},
+			fail:   true,
+		},
+		{
+			name:   "own storage",
+			sender: []byte{byte(vm.PUSH1), 0, byte(vm.SLOAD), byte(vm.POP), byte(vm.STOP)},
+		},
+		{
+			name:    "unassociated storage",
+			sender:  call(library),
+			library: []byte{byte(vm.PUSH1), 0, byte(vm.SLOAD), byte(vm.POP), byte(vm.STOP)},
+			fail:    true,
+		},
+		{
+			// library: sload(keccak256(sender . 0) + 1)
+			name:   "associated storage",
+			sender: call(library),
+			library: append(append([]byte{byte(vm.PUSH20)}, sender.Bytes()...),
+				byte(vm.PUSH1), 0, byte(vm.MSTORE),
+				byte(vm.PUSH1), 0x40, byte(vm.PUSH1), 0, byte(vm.KECCAK256),
+				byte(vm.PUSH1), 1, byte(vm.ADD), byte(vm.SLOAD), byte(vm.POP), byte(vm.STOP)),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			statedb, _ := state.New(types.EmptyRootHash, state.NewDatabaseForTesting())
+			statedb.SetCode(entry, call(sender), 0)
+			statedb.SetCode(sender, test.sender, 0)
+			statedb.SetCode(library, test.library, 0)
+
+			tracer := newValidationTracer(entry, &UserOperation{Sender: sender})
+			_, _, err := runtime.Call(entry, nil, &runtime.Config{
+				ChainConfig: params.MergedTestChainConfig,
+				State:       statedb,
+				EVMConfig:   vm.Config{Tracer: tracer.hooks()},
+			})
+			if err != nil {
+				t.Fatalf("execution failed: %v", err)
+			}
+			if test.fail && tracer.err == nil {
+				t.Fatal("expected validation rule violation")
+			}
+			if !test.fail && tracer.err != nil {
+				t.Fatalf("unexpected validation rule violation: %v", tracer.err)
+			}
+		})
+	}
+}
+
+func TestHandleOpsRoundtrip(t *testing.T) {
+	paymaster := common.Address{0x02}
+	op := newOp(common.Address{0x01}, 7, 100, 10)
+	op.Paymaster = &paymaster
+	op.PaymasterVerificationGasLimit = 50_000
+	op.PaymasterData = []byte{0xca, 0xfe}
+
+	data, err := encodeHandleOps([]*UserOperation{op}, common.Address{0x03})
+	if err != nil {
+		t.Fatalf("failed to encode handleOps: %v", err)
+	}
+	args, err := entryPoint.Methods["handleOps"].Inputs.Unpack(data[4:])
+	if err != nil {
+		t.Fatalf("failed to decode handleOps: %v", err)
+	}
+	if beneficiary := args[1].(common.Address); beneficiary != (common.Address{0x03}) {
+		t.Errorf("unexpected beneficiary %x", beneficiary)
+	}
+	packed := op.pack()
+	if want := append(append(paymaster.Bytes(), make([]byte, 32)...), 0xca, 0xfe); len(packed.PaymasterAndData) != len(want) {
+		t.Errorf("unexpected paymasterAndData length: have %d, want %d", len(packed.PaymasterAndData), len(want))
+	}
+	if got := new(big.Int).SetBytes(packed.GasFees[:16]); got.Int64() != 10 {
+		t.Errorf("unexpected packed priority fee %v", got)
+	}
+	if got := new(big.Int).SetBytes(packed.GasFees[16:]); got.Int64() != 100 {
+		t.Errorf("unexpected packed max fee %v", got)
+	}
+}
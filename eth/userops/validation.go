@@ -0,0 +1,257 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package userops
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/holiman/uint256"
+)
+
+// associatedSlots is the number of consecutive storage slots, starting at a
+// hash of the sender address, considered associated with the sender.
+const associatedSlots = 128
+
+// maxHashedSize is the maximum size of the hashed values tracked for deriving
+// the storage slots associated with the sender.
+const maxHashedSize = 1024
+
+// bannedOpcodes are the opcodes the validation of an operation must not use,
+// as their result may differ between the simulation and the inclusion.
+var bannedOpcodes = map[vm.OpCode]bool{
+	vm.GASPRICE:     true,
+	vm.GASLIMIT:     true,
+	vm.PREVRANDAO:   true,
+	vm.TIMESTAMP:    true,
+	vm.BASEFEE:      true,
+	vm.BLOCKHASH:    true,
+	vm.NUMBER:       true,
+	vm.SELFBALANCE:  true,
+	vm.BALANCE:      true,
+	vm.ORIGIN:       true,
+	vm.CREATE:       true,
+	vm.COINBASE:     true,
+	vm.SELFDESTRUCT: true,
+	vm.BLOBHASH:     true,
+	vm.BLOBBASEFEE:  true,
+	vm.INVALID:      true,
+}
+
+// validationTracer enforces a subset of the ERC-7562 validation rules for
+// unstaked entities while the EntryPoint validates an operation. The
+// validation phase ends when the EntryPoint calls itself to execute the
+// operations.
+type validationTracer struct {
+	entryPoint common.Address
+	sender     common.Address
+	factory    bool // Whether the operation deploys the sender
+
+	executing bool                 // Set once the validation phase is over
+	creates   int                  // Number of CREATE2 invocations during validation
+	gasOp     bool                 // Set if the previous opcode was GAS
+	hashes    map[common.Hash]bool // Hashes of values starting with the sender address
+	err       error                // First violated rule
+}
+
+func newValidationTracer(entryPoint common.Address, op *UserOperation) *validationTracer {
+	return &validationTracer{
+		entryPoint: entryPoint,
+		sender:     op.Sender,
+		factory:    op.Factory != nil,
+		hashes:     make(map[common.Hash]bool),
+	}
+}
+
+func (t *validationTracer) hooks() *tracing.Hooks {
+	return &tracing.Hooks{
+		OnEnter:  t.onEnter,
+		OnOpcode: t.onOpcode,
+	}
+}
+
+func (t *validationTracer) onEnter(depth int, typ byte, from common.Address, to common.Address, input []byte, gas uint64, value *big.Int) {
+	if depth == 1 && from == t.entryPoint && to == t.entryPoint {
+		t.executing = true
+	}
+}
+
+func (t *validationTracer) onOpcode(pc uint64, op byte, gas, cost uint64, scope tracing.OpContext, rData []byte, depth int, err error) {
+	if t.executing || t.err != nil || scope.Address() == t.entryPoint {
+		return
+	}
+	opcode := vm.OpCode(op)
+
+	// GAS is only allowed as the gas argument of a call.
+	if t.gasOp {
+		t.gasOp = false
+		switch opcode {
+		case vm.CALL, vm.CALLCODE, vm.DELEGATECALL, vm.STATICCALL:
+		default:
+			t.fail("opcode GAS not followed by a call at %#x", scope.Address())
+			return
+		}
+	}
+	stack := scope.StackData()
+	switch {
+	case bannedOpcodes[opcode]:
+		t.fail("banned opcode %v used by %#x", opcode, scope.Address())
+
+	case opcode == vm.GAS:
+		t.gasOp = true
+
+	case opcode == vm.CREATE2:
+		t.creates++
+		if !t.factory || t.creates > 1 {
+			t.fail("opcode CREATE2 used by %#x outside of the sender deployment", scope.Address())
+		}
+
+	case opcode == vm.KECCAK256 && len(stack) >= 2:
+		// Track the hashes of values prefixed by the sender, storage slots
+		// derived from them (mappings keyed by the sender) are associated
+		// with the sender.
+		offset, size := stack[len(stack)-1], stack[len(stack)-2]
+		if !offset.IsUint64() || !size.IsUint64() || size.Uint64() < 32 || size.Uint64() > maxHashedSize {
+			return
+		}
+		// The memory is expanded by the opcode itself, the missing part is zero.
+		var (
+			memory = scope.MemoryData()
+			data   = make([]byte, size.Uint64())
+		)
+		if start := offset.Uint64(); start < uint64(len(memory)) {
+			copy(data, memory[start:min(start+size.Uint64(), uint64(len(memory)))])
+		}
+		if common.BytesToAddress(data[:32]) == t.sender && isZero(data[:12]) {
+			t.hashes[crypto.Keccak256Hash(data)] = true
+		}
+
+	case (opcode == vm.SLOAD || opcode == vm.SSTORE) && len(stack) >= 1:
+		if scope.Address() == t.sender {
+			return
+		}
+		slot := stack[len(stack)-1]
+		if !t.associated(&slot) {
+			t.fail("access of storage slot %#x of %#x not associated with the sender", slot.Bytes32(), scope.Address())
+		}
+	}
+}
+
+// associated reports whether the storage slot is associated with the sender.
+func (t *validationTracer) associated(slot *uint256.Int) bool {
+	if common.Hash(slot.Bytes32()) == common.BytesToHash(t.sender.Bytes()) {
+		return true
+	}
+	for hash := range t.hashes {
+		base := new(uint256.Int).SetBytes(hash[:])
+		if slot.Cmp(base) >= 0 && new(uint256.Int).Sub(slot, base).CmpUint64(associatedSlots) < 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func (t *validationTracer) fail(format string, args ...interface{}) {
+	if t.err == nil {
+		t.err = fmt.Errorf(format, args...)
+	}
+}
+
+func isZero(b []byte) bool {
+	for _, v := range b {
+		if v != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// simulation is the outcome of executing operations through the EntryPoint.
+type simulation struct {
+	gasUsed  uint64    // Gas consumed by the execution, before refunds
+	gasLimit uint64    // Gas available to the execution
+	failed   *failedOp // Operation rejected by the EntryPoint, if any
+}
+
+// simulate executes the given operations through handleOps of the EntryPoint
+// on top of the given state. If a tracer is given, it observes the execution.
+func simulate(config *params.ChainConfig, chain core.ChainContext, header *types.Header, statedb *state.StateDB, entryPoint, bundler common.Address, ops []*UserOperation, tracer *tracing.Hooks) (*simulation, error) {
+	data, err := encodeHandleOps(ops, bundler)
+	if err != nil {
+		return nil, err
+	}
+	gasLimit := header.GasLimit
+	if config.IsOsaka(header.Number, header.Time) && gasLimit > params.MaxTxGas {
+		gasLimit = params.MaxTxGas
+	}
+	msg := &core.Message{
+		From:            bundler,
+		To:              &entryPoint,
+		Value:           new(big.Int),
+		GasLimit:        gasLimit,
+		GasPrice:        new(big.Int),
+		GasFeeCap:       new(big.Int),
+		GasTipCap:       new(big.Int),
+		Data:            data,
+		SkipNonceChecks: true,
+	}
+	blockCtx, err := core.NewEVMBlockContext(header, chain, nil)
+	if err != nil {
+		return nil, err
+	}
+	evm := vm.NewEVM(blockCtx, statedb, config, vm.Config{Tracer: tracer, NoBaseFee: true})
+
+	result, err := core.ApplyMessage(evm, msg, new(core.GasPool).AddGas(gasLimit))
+	if err != nil {
+		return nil, err
+	}
+	sim := &simulation{gasUsed: result.MaxUsedGas, gasLimit: gasLimit}
+	if result.Failed() {
+		failed, ok := decodeFailedOp(result.Revert())
+		if !ok {
+			return nil, fmt.Errorf("handleOps failed: %v", result.Err)
+		}
+		sim.failed = failed
+	}
+	return sim, nil
+}
+
+// validate checks an operation by simulating its inclusion on top of the given
+// state, enforcing the validation rules.
+func validate(config *params.ChainConfig, chain core.ChainContext, header *types.Header, statedb *state.StateDB, entryPoint, bundler common.Address, op *UserOperation) (uint64, error) {
+	tracer := newValidationTracer(entryPoint, op)
+	sim, err := simulate(config, chain, header, statedb, entryPoint, bundler, []*UserOperation{op}, tracer.hooks())
+	if err != nil {
+		return 0, err
+	}
+	if sim.failed != nil {
+		return 0, errors.New(sim.failed.reason)
+	}
+	if tracer.err != nil {
+		return 0, tracer.err
+	}
+	return sim.gasUsed, nil
+}
@@ -0,0 +1,214 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/consensus/misc/eip4844"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/stateless"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/trie"
+)
+
+// verifyReexec is the number of blocks re-executed to regenerate a missing
+// parent state when verifying blocks on a hash-scheme database.
+const verifyReexec = 128
+
+// BlockVerification is the outcome of re-executing a block and cross-checking
+// the results against its header and the database.
+type BlockVerification struct {
+	Number     hexutil.Uint64 `json:"number"`
+	Hash       common.Hash    `json:"hash"`
+	Mismatches []string       `json:"mismatches,omitempty"` // Inconsistencies found
+	Error      string         `json:"error,omitempty"`      // Set if the block could not be verified
+}
+
+// verifyRange streams the verification of the given canonical blocks as a JSON
+// array, one element per block, flushing each element as soon as it is done.
+func (eth *Ethereum) verifyRange(start, end uint64) func(w io.Writer) error {
+	return func(w io.Writer) error {
+		if _, err := io.WriteString(w, "["); err != nil {
+			return err
+		}
+		enc := json.NewEncoder(w)
+		for number := start; number <= end; number++ {
+			if number > start {
+				if _, err := io.WriteString(w, ","); err != nil {
+					return err
+				}
+			}
+			var result *BlockVerification
+			if block := eth.blockchain.GetBlockByNumber(number); block == nil {
+				result = &BlockVerification{Number: hexutil.Uint64(number), Error: "block not found"}
+			} else {
+				result = eth.verifyBlock(context.Background(), block)
+			}
+			if err := enc.Encode(result); err != nil {
+				return err
+			}
+			// Push the progress out, a disconnected client fails the flush
+			// and aborts the verification.
+			if f, ok := w.(interface{ Flush() error }); ok {
+				if err := f.Flush(); err != nil {
+					return err
+				}
+			}
+		}
+		_, err := io.WriteString(w, "]")
+		return err
+	}
+}
+
+// verifyBlock re-executes a block on top of its parent state and checks that
+// the computed state root, receipts, gas and blob gas usage match the header.
+// The block is also executed statelessly, using the witness collected during
+// the regular execution, as an independent check of the state root.
+func (eth *Ethereum) verifyBlock(ctx context.Context, block *types.Block) *BlockVerification {
+	result := &BlockVerification{
+		Number: hexutil.Uint64(block.NumberU64()),
+		Hash:   block.Hash(),
+	}
+	mismatch := func(field string, have, want interface{}) {
+		result.Mismatches = append(result.Mismatches, fmt.Sprintf("%s: computed %v, header %v", field, have, want))
+	}
+	if block.NumberU64() == 0 {
+		result.Error = "genesis is not executed"
+		return result
+	}
+	var (
+		config = eth.blockchain.Config()
+		header = block.Header()
+	)
+	parent := eth.blockchain.GetBlock(block.ParentHash(), block.NumberU64()-1)
+	if parent == nil {
+		result.Error = fmt.Sprintf("parent %#x not found", block.ParentHash())
+		return result
+	}
+	// Check the integrity of the stored block body and receipts.
+	if hash := types.DeriveSha(block.Transactions(), trie.NewStackTrie(nil)); hash != header.TxHash {
+		mismatch("transactions root", hash, header.TxHash)
+	}
+	if receipts := eth.blockchain.GetReceiptsByHash(block.Hash()); receipts != nil {
+		if hash := types.DeriveSha(receipts, trie.NewStackTrie(nil)); hash != header.ReceiptHash {
+			mismatch("stored receipts root", hash, header.ReceiptHash)
+		}
+	}
+	// Check the data availability accounting of the block.
+	if config.IsCancun(block.Number(), block.Time()) {
+		var blobGas uint64
+		for _, tx := range block.Transactions() {
+			blobGas += tx.BlobGas()
+		}
+		if header.BlobGasUsed == nil || *header.BlobGasUsed != blobGas {
+			mismatch("blob gas used", blobGas, optional(header.BlobGasUsed))
+		}
+		excess := eip4844.CalcExcessBlobGas(config, parent.Header(), block.Time())
+		if header.ExcessBlobGas == nil || *header.ExcessBlobGas != excess {
+			mismatch("excess blob gas", excess, optional(header.ExcessBlobGas))
+		}
+	}
+	// Re-execute the block on top of the stored parent state.
+	statedb, release, err := eth.verifyState(ctx, parent)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	defer release()
+
+	witness, err := stateless.NewWitness(header, eth.blockchain)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	statedb.StartPrefetcher("verify", witness, nil)
+	defer statedb.StopPrefetcher()
+
+	eth.blockchain.LoadSenders([]*types.Block{block})
+	res, err := eth.blockchain.Processor().Process(block, statedb, vm.Config{})
+	if err != nil {
+		result.Error = fmt.Sprintf("execution failed: %v", err)
+		return result
+	}
+	if res.GasUsed != header.GasUsed {
+		mismatch("gas used", res.GasUsed, header.GasUsed)
+	}
+	if bloom := types.MergeBloom(res.Receipts); bloom != header.Bloom {
+		mismatch("logs bloom", hexutil.Bytes(bloom.Bytes()), hexutil.Bytes(header.Bloom.Bytes()))
+	}
+	if hash := types.DeriveSha(res.Receipts, trie.NewStackTrie(nil)); hash != header.ReceiptHash {
+		mismatch("receipts root", hash, header.ReceiptHash)
+	}
+	if root := statedb.IntermediateRoot(config.IsEIP158(block.Number())); root != header.Root {
+		mismatch("state root", root, header.Root)
+	}
+	// Execute the block again, statelessly, with the state root and receipts
+	// root cleared so that they are computed independently.
+	stripped := types.CopyHeader(header)
+	stripped.Root = common.Hash{}
+	stripped.ReceiptHash = common.Hash{}
+	task := types.NewBlockWithHeader(stripped).WithBody(*block.Body())
+
+	stateRoot, receiptRoot, err := core.ExecuteStateless(config, vm.Config{}, task, witness)
+	if err != nil {
+		result.Mismatches = append(result.Mismatches, fmt.Sprintf("stateless execution failed: %v", err))
+		return result
+	}
+	if stateRoot != header.Root {
+		mismatch("stateless state root", stateRoot, header.Root)
+	}
+	if receiptRoot != header.ReceiptHash {
+		mismatch("stateless receipts root", receiptRoot, header.ReceiptHash)
+	}
+	return result
+}
+
+// verifyState returns the state of the given block for re-executing its child.
+// Unlike for tracing, the state must be able to compute its root, which rules
+// out the historical state of path-scheme databases.
+func (eth *Ethereum) verifyState(ctx context.Context, block *types.Block) (*state.StateDB, func(), error) {
+	if eth.blockchain.TrieDB().Scheme() == rawdb.HashScheme {
+		statedb, release, err := eth.stateAtBlock(ctx, block, verifyReexec, nil, true, false)
+		if err != nil {
+			return nil, nil, err
+		}
+		return statedb, release, nil
+	}
+	statedb, err := eth.blockchain.StateAt(block.Root())
+	if err != nil {
+		return nil, nil, errors.New("parent state is not available")
+	}
+	return statedb, func() {}, nil
+}
+
+// optional formats an optional header field.
+func optional(v *uint64) interface{} {
+	if v == nil {
+		return "missing"
+	}
+	return *v
+}
@@ -0,0 +1,107 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package watchpoints
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// API exposes the storage watchpoints over RPC.
+type API struct {
+	service *Service
+}
+
+// NewAPI creates the RPC service for the watchpoints.
+func NewAPI(service *Service) *API {
+	return &API{service: service}
+}
+
+// Add registers a watchpoint on the given storage slot. If a webhook URL is
+// given, every change of the slot is posted to it as JSON.
+func (api *API) Add(address common.Address, slot common.Hash, webhook *string) (*Watchpoint, error) {
+	var url string
+	if webhook != nil {
+		url = *webhook
+	}
+	return api.service.Add(address, slot, url)
+}
+
+// Remove unregisters the watchpoint with the given id.
+func (api *API) Remove(id hexutil.Uint64) error {
+	return api.service.Remove(uint64(id))
+}
+
+// List returns all the registered watchpoints.
+func (api *API) List() []Watchpoint {
+	return api.service.List()
+}
+
+// Changes creates a subscription that is notified whenever a canonical block
+// modifies one of the given storage slots of the address. The slots are watched
+// for as long as the subscription is alive.
+func (api *API) Changes(ctx context.Context, address common.Address, slots []common.Hash) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+	if len(slots) == 0 {
+		return nil, errors.New("no storage slots specified")
+	}
+	var (
+		rpcSub  = notifier.CreateSubscription()
+		chain   = api.service.chain
+		watched = make(map[common.Hash]struct{}, len(slots))
+	)
+	for _, slot := range slots {
+		if _, ok := watched[slot]; ok {
+			continue
+		}
+		watched[slot] = struct{}{}
+		chain.WatchStorage(address, slot)
+	}
+	go func() {
+		notifications := make(chan []Notification, 16)
+		sub := api.service.subscribe(notifications)
+		defer func() {
+			sub.Unsubscribe()
+			for slot := range watched {
+				chain.UnwatchStorage(address, slot)
+			}
+		}()
+		for {
+			select {
+			case batch := <-notifications:
+				for _, n := range batch {
+					if n.Address != address {
+						continue
+					}
+					if _, ok := watched[n.Slot]; ok {
+						notifier.Notify(rpcSub.ID, n)
+					}
+				}
+			case <-rpcSub.Err():
+				return
+			}
+		}
+	}()
+	return rpcSub, nil
+}
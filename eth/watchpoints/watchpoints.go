@@ -0,0 +1,286 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package watchpoints implements notifications for changes of storage slots.
+//
+// Operators register (address, slot) pairs and get notified, through an RPC
+// subscription or a webhook, whenever a canonical block modifies one of them.
+// Changes are detected while the block is imported, so no polling is involved.
+package watchpoints
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/eth"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/node"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+const (
+	// webhookQueueSize is the number of webhook deliveries that can be pending
+	// before new ones are dropped.
+	webhookQueueSize = 1024
+
+	// webhookTimeout is the maximum time allowed for delivering a webhook.
+	webhookTimeout = 5 * time.Second
+)
+
+var errUnknownWatchpoint = errors.New("unknown watchpoint")
+
+// Chain is the subset of the blockchain needed to track storage slots.
+type Chain interface {
+	WatchStorage(addr common.Address, slot common.Hash)
+	UnwatchStorage(addr common.Address, slot common.Hash)
+	SubscribeStorageWatchEvent(ch chan<- core.StorageWatchEvent) event.Subscription
+}
+
+// Watchpoint is a storage slot registered by an operator.
+type Watchpoint struct {
+	ID      hexutil.Uint64 `json:"id"`
+	Address common.Address `json:"address"`
+	Slot    common.Hash    `json:"slot"`
+	Webhook string         `json:"webhook,omitempty"`
+}
+
+// Notification reports the change of a watched slot by a canonical block.
+type Notification struct {
+	BlockNumber hexutil.Uint64 `json:"blockNumber"`
+	BlockHash   common.Hash    `json:"blockHash"`
+	Address     common.Address `json:"address"`
+	Slot        common.Hash    `json:"slot"`
+	Prev        common.Hash    `json:"prev"`
+	Value       common.Hash    `json:"value"`
+}
+
+// webhookDelivery is a notification to be posted to a webhook.
+type webhookDelivery struct {
+	url          string
+	notification Notification
+}
+
+// Service tracks the registered watchpoints and dispatches the notifications
+// of their changes.
+type Service struct {
+	chain  Chain
+	client *http.Client
+
+	points map[uint64]*Watchpoint
+	nextID uint64
+	lock   sync.RWMutex
+
+	feed    event.Feed // Feed of []Notification for RPC subscribers
+	webhook chan webhookDelivery
+	closed  chan struct{}
+	wg      sync.WaitGroup
+}
+
+// New creates the watchpoint service on top of the given chain.
+func New(chain Chain) *Service {
+	return &Service{
+		chain:   chain,
+		client:  &http.Client{Timeout: webhookTimeout},
+		points:  make(map[uint64]*Watchpoint),
+		webhook: make(chan webhookDelivery, webhookQueueSize),
+		closed:  make(chan struct{}),
+	}
+}
+
+// Register registers the watchpoint service into the node stack.
+func Register(stack *node.Node, backend *eth.Ethereum) *Service {
+	s := New(backend.BlockChain())
+	stack.RegisterAPIs([]rpc.API{{
+		Namespace: "watch",
+		Service:   NewAPI(s),
+	}})
+	stack.RegisterLifecycle(s)
+	return s
+}
+
+// Start implements node.Lifecycle, starting the notification dispatchers.
+func (s *Service) Start() error {
+	s.wg.Add(2)
+	go s.loop()
+	go s.deliverWebhooks()
+	return nil
+}
+
+// Stop implements node.Lifecycle, terminating the notification dispatchers.
+func (s *Service) Stop() error {
+	close(s.closed)
+	s.wg.Wait()
+	return nil
+}
+
+// Add registers a new watchpoint, optionally posting its changes to a webhook.
+func (s *Service) Add(addr common.Address, slot common.Hash, webhook string) (*Watchpoint, error) {
+	if webhook != "" {
+		u, err := url.Parse(webhook)
+		if err != nil {
+			return nil, fmt.Errorf("invalid webhook: %w", err)
+		}
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return nil, fmt.Errorf("invalid webhook scheme %q", u.Scheme)
+		}
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.nextID++
+	wp := &Watchpoint{
+		ID:      hexutil.Uint64(s.nextID),
+		Address: addr,
+		Slot:    slot,
+		Webhook: webhook,
+	}
+	s.points[s.nextID] = wp
+	s.chain.WatchStorage(addr, slot)
+
+	log.Info("Added storage watchpoint", "id", s.nextID, "address", addr, "slot", slot)
+	return wp, nil
+}
+
+// Remove unregisters a watchpoint.
+func (s *Service) Remove(id uint64) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	wp, ok := s.points[id]
+	if !ok {
+		return errUnknownWatchpoint
+	}
+	delete(s.points, id)
+	s.chain.UnwatchStorage(wp.Address, wp.Slot)
+
+	log.Info("Removed storage watchpoint", "id", id, "address", wp.Address, "slot", wp.Slot)
+	return nil
+}
+
+// List returns the registered watchpoints, ordered by id.
+func (s *Service) List() []Watchpoint {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	list := make([]Watchpoint, 0, len(s.points))
+	for _, wp := range s.points {
+		list = append(list, *wp)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].ID < list[j].ID })
+	return list
+}
+
+// subscribe registers a subscription for the notifications of all watched slots.
+func (s *Service) subscribe(ch chan<- []Notification) event.Subscription {
+	return s.feed.Subscribe(ch)
+}
+
+// loop converts the storage changes reported by the chain into notifications.
+func (s *Service) loop() {
+	defer s.wg.Done()
+
+	events := make(chan core.StorageWatchEvent, 16)
+	sub := s.chain.SubscribeStorageWatchEvent(events)
+	defer sub.Unsubscribe()
+
+	for {
+		select {
+		case ev := <-events:
+			notifications := make([]Notification, 0, len(ev.Changes))
+			for _, change := range ev.Changes {
+				notifications = append(notifications, Notification{
+					BlockNumber: hexutil.Uint64(ev.Header.Number.Uint64()),
+					BlockHash:   ev.Header.Hash(),
+					Address:     change.Address,
+					Slot:        change.Slot,
+					Prev:        change.Prev,
+					Value:       change.Value,
+				})
+			}
+			s.feed.Send(notifications)
+			s.queueWebhooks(notifications)
+
+		case <-sub.Err():
+			return
+		case <-s.closed:
+			return
+		}
+	}
+}
+
+// queueWebhooks schedules the delivery of the notifications to the webhooks of
+// the matching watchpoints. Deliveries are dropped if the queue is full.
+func (s *Service) queueWebhooks(notifications []Notification) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	for _, n := range notifications {
+		for _, wp := range s.points {
+			if wp.Webhook == "" || wp.Address != n.Address || wp.Slot != n.Slot {
+				continue
+			}
+			select {
+			case s.webhook <- webhookDelivery{url: wp.Webhook, notification: n}:
+			default:
+				log.Warn("Dropped watchpoint webhook, queue full", "id", uint64(wp.ID), "block", uint64(n.BlockNumber))
+			}
+		}
+	}
+}
+
+// deliverWebhooks posts the queued notifications to their webhooks.
+func (s *Service) deliverWebhooks() {
+	defer s.wg.Done()
+
+	for {
+		select {
+		case d := <-s.webhook:
+			if err := s.post(d); err != nil {
+				log.Warn("Failed to deliver watchpoint webhook", "url", d.url, "err", err)
+			}
+		case <-s.closed:
+			return
+		}
+	}
+}
+
+// post sends a single notification to its webhook as JSON.
+func (s *Service) post(d webhookDelivery) error {
+	blob, err := json.Marshal(d.notification)
+	if err != nil {
+		return err
+	}
+	res, err := s.client.Post(d.url, "application/json", bytes.NewReader(blob))
+	if err != nil {
+		return err
+	}
+	res.Body.Close()
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %s", res.Status)
+	}
+	return nil
+}
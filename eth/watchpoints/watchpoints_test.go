@@ -0,0 +1,135 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package watchpoints
+
+import (
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+type testChain struct {
+	watched map[common.Address]map[common.Hash]int
+	feed    event.Feed
+}
+
+func newTestChain() *testChain {
+	return &testChain{watched: make(map[common.Address]map[common.Hash]int)}
+}
+
+func (c *testChain) WatchStorage(addr common.Address, slot common.Hash) {
+	if c.watched[addr] == nil {
+		c.watched[addr] = make(map[common.Hash]int)
+	}
+	c.watched[addr][slot]++
+}
+
+func (c *testChain) UnwatchStorage(addr common.Address, slot common.Hash) {
+	c.watched[addr][slot]--
+}
+
+func (c *testChain) SubscribeStorageWatchEvent(ch chan<- core.StorageWatchEvent) event.Subscription {
+	return c.feed.Subscribe(ch)
+}
+
+func TestWatchpointRegistry(t *testing.T) {
+	var (
+		chain = newTestChain()
+		s     = New(chain)
+		addr  = common.HexToAddress("0x1")
+		slot  = common.HexToHash("0x2")
+	)
+	if _, err := s.Add(addr, slot, "ftp://example.com"); err == nil {
+		t.Fatal("expected invalid webhook scheme to be rejected")
+	}
+	wp, err := s.Add(addr, slot, "")
+	if err != nil {
+		t.Fatalf("failed to add watchpoint: %v", err)
+	}
+	if chain.watched[addr][slot] != 1 {
+		t.Fatal("slot not watched on the chain")
+	}
+	if list := s.List(); len(list) != 1 || list[0] != *wp {
+		t.Fatalf("unexpected watchpoints: %v", list)
+	}
+	if err := s.Remove(uint64(wp.ID)); err != nil {
+		t.Fatalf("failed to remove watchpoint: %v", err)
+	}
+	if err := s.Remove(uint64(wp.ID)); err != errUnknownWatchpoint {
+		t.Fatalf("unexpected error removing unknown watchpoint: %v", err)
+	}
+	if chain.watched[addr][slot] != 0 {
+		t.Fatal("slot still watched on the chain")
+	}
+}
+
+func TestWatchpointWebhook(t *testing.T) {
+	received := make(chan Notification, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var n Notification
+		if err := json.NewDecoder(r.Body).Decode(&n); err != nil {
+			t.Errorf("failed to decode webhook: %v", err)
+		}
+		received <- n
+	}))
+	defer server.Close()
+
+	var (
+		chain = newTestChain()
+		s     = New(chain)
+		addr  = common.HexToAddress("0x1")
+		slot  = common.HexToHash("0x2")
+	)
+	s.Start()
+	defer s.Stop()
+
+	if _, err := s.Add(addr, slot, server.URL); err != nil {
+		t.Fatalf("failed to add watchpoint: %v", err)
+	}
+	header := &types.Header{Number: big.NewInt(10)}
+	change := state.StorageChange{Address: addr, Slot: slot, Prev: common.HexToHash("0x3"), Value: common.HexToHash("0x4")}
+
+	// Wait for the service loop to subscribe before sending the event
+	for chain.feed.Send(core.StorageWatchEvent{Header: header, Changes: []state.StorageChange{change}}) == 0 {
+		time.Sleep(10 * time.Millisecond)
+	}
+	select {
+	case n := <-received:
+		want := Notification{
+			BlockNumber: 10,
+			BlockHash:   header.Hash(),
+			Address:     addr,
+			Slot:        slot,
+			Prev:        change.Prev,
+			Value:       change.Value,
+		}
+		if n != want {
+			t.Fatalf("unexpected notification: have %+v, want %+v", n, want)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("webhook not delivered")
+	}
+}
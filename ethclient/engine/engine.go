@@ -0,0 +1,255 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package engine provides a typed RPC client for the engine API, which consensus
+// clients use to drive an execution client.
+package engine
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ethereum/go-ethereum/beacon/engine"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/node"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// Client is a wrapper around rpc.Client that implements the engine API methods.
+//
+// The methods map directly to the versioned engine API calls, it is up to the caller
+// to choose the version matching the fork of the payload. NewPayload selects the
+// version automatically from the contents of a payload envelope.
+type Client struct {
+	c *rpc.Client
+}
+
+// Dial connects a client to the authenticated engine API endpoint at the given URL.
+// Requests are authenticated with JWT tokens signed by the given secret.
+func Dial(ctx context.Context, rawurl string, jwtSecret [32]byte, options ...rpc.ClientOption) (*Client, error) {
+	options = append([]rpc.ClientOption{rpc.WithHTTPAuth(node.NewJWTAuth(jwtSecret))}, options...)
+	c, err := rpc.DialOptions(ctx, rawurl, options...)
+	if err != nil {
+		return nil, err
+	}
+	return New(c), nil
+}
+
+// New creates a client that uses the given RPC client.
+func New(c *rpc.Client) *Client {
+	return &Client{c}
+}
+
+// Close closes the underlying RPC connection.
+func (ec *Client) Close() {
+	ec.c.Close()
+}
+
+// Client gets the underlying RPC client.
+func (ec *Client) Client() *rpc.Client {
+	return ec.c
+}
+
+// ExchangeCapabilities returns the engine API methods supported by the execution
+// client. The given methods are the ones supported by the caller.
+func (ec *Client) ExchangeCapabilities(ctx context.Context, methods []string) ([]string, error) {
+	var result []string
+	err := ec.c.CallContext(ctx, &result, "engine_exchangeCapabilities", methods)
+	return result, err
+}
+
+// GetClientVersionV1 exchanges client version information with the execution client.
+func (ec *Client) GetClientVersionV1(ctx context.Context, info engine.ClientVersionV1) ([]engine.ClientVersionV1, error) {
+	var result []engine.ClientVersionV1
+	err := ec.c.CallContext(ctx, &result, "engine_getClientVersionV1", info)
+	return result, err
+}
+
+// ForkchoiceUpdatedV1 updates the fork choice of the execution client, and starts
+// building a payload if attributes are given (Paris).
+func (ec *Client) ForkchoiceUpdatedV1(ctx context.Context, state engine.ForkchoiceStateV1, attr *engine.PayloadAttributes) (*engine.ForkChoiceResponse, error) {
+	return ec.forkchoiceUpdated(ctx, "engine_forkchoiceUpdatedV1", state, attr)
+}
+
+// ForkchoiceUpdatedV2 is like ForkchoiceUpdatedV1, for payloads with withdrawals
+// (Shanghai).
+func (ec *Client) ForkchoiceUpdatedV2(ctx context.Context, state engine.ForkchoiceStateV1, attr *engine.PayloadAttributes) (*engine.ForkChoiceResponse, error) {
+	return ec.forkchoiceUpdated(ctx, "engine_forkchoiceUpdatedV2", state, attr)
+}
+
+// ForkchoiceUpdatedV3 is like ForkchoiceUpdatedV2, for payloads with a parent beacon
+// block root (Cancun and later).
+func (ec *Client) ForkchoiceUpdatedV3(ctx context.Context, state engine.ForkchoiceStateV1, attr *engine.PayloadAttributes) (*engine.ForkChoiceResponse, error) {
+	return ec.forkchoiceUpdated(ctx, "engine_forkchoiceUpdatedV3", state, attr)
+}
+
+func (ec *Client) forkchoiceUpdated(ctx context.Context, method string, state engine.ForkchoiceStateV1, attr *engine.PayloadAttributes) (*engine.ForkChoiceResponse, error) {
+	var result engine.ForkChoiceResponse
+	if err := ec.c.CallContext(ctx, &result, method, state, attr); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetPayloadV1 retrieves a payload built by the execution client (Paris).
+func (ec *Client) GetPayloadV1(ctx context.Context, id engine.PayloadID) (*engine.ExecutableData, error) {
+	var result engine.ExecutableData
+	if err := ec.c.CallContext(ctx, &result, "engine_getPayloadV1", id); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetPayloadV2 retrieves a payload built by the execution client, along with its
+// value (Shanghai).
+func (ec *Client) GetPayloadV2(ctx context.Context, id engine.PayloadID) (*engine.ExecutionPayloadEnvelope, error) {
+	return ec.getPayload(ctx, "engine_getPayloadV2", id)
+}
+
+// GetPayloadV3 is like GetPayloadV2, and includes the blobs bundle (Cancun).
+func (ec *Client) GetPayloadV3(ctx context.Context, id engine.PayloadID) (*engine.ExecutionPayloadEnvelope, error) {
+	return ec.getPayload(ctx, "engine_getPayloadV3", id)
+}
+
+// GetPayloadV4 is like GetPayloadV3, and includes the execution requests (Prague).
+func (ec *Client) GetPayloadV4(ctx context.Context, id engine.PayloadID) (*engine.ExecutionPayloadEnvelope, error) {
+	return ec.getPayload(ctx, "engine_getPayloadV4", id)
+}
+
+// GetPayloadV5 is like GetPayloadV4, with cell proofs in the blobs bundle (Osaka).
+func (ec *Client) GetPayloadV5(ctx context.Context, id engine.PayloadID) (*engine.ExecutionPayloadEnvelope, error) {
+	return ec.getPayload(ctx, "engine_getPayloadV5", id)
+}
+
+func (ec *Client) getPayload(ctx context.Context, method string, id engine.PayloadID) (*engine.ExecutionPayloadEnvelope, error) {
+	var result engine.ExecutionPayloadEnvelope
+	if err := ec.c.CallContext(ctx, &result, method, id); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// NewPayloadV1 submits a payload to the execution client for validation (Paris).
+func (ec *Client) NewPayloadV1(ctx context.Context, payload *engine.ExecutableData) (*engine.PayloadStatusV1, error) {
+	return ec.newPayload(ctx, "engine_newPayloadV1", payload)
+}
+
+// NewPayloadV2 is like NewPayloadV1, for payloads with withdrawals (Shanghai).
+func (ec *Client) NewPayloadV2(ctx context.Context, payload *engine.ExecutableData) (*engine.PayloadStatusV1, error) {
+	return ec.newPayload(ctx, "engine_newPayloadV2", payload)
+}
+
+// NewPayloadV3 is like NewPayloadV2, additionally passing the versioned hashes of
+// the blobs in the payload and the parent beacon block root (Cancun).
+func (ec *Client) NewPayloadV3(ctx context.Context, payload *engine.ExecutableData, versionedHashes []common.Hash, beaconRoot *common.Hash) (*engine.PayloadStatusV1, error) {
+	return ec.newPayload(ctx, "engine_newPayloadV3", payload, versionedHashes, beaconRoot)
+}
+
+// NewPayloadV4 is like NewPayloadV3, additionally passing the execution requests
+// (Prague).
+func (ec *Client) NewPayloadV4(ctx context.Context, payload *engine.ExecutableData, versionedHashes []common.Hash, beaconRoot *common.Hash, requests []hexutil.Bytes) (*engine.PayloadStatusV1, error) {
+	return ec.newPayload(ctx, "engine_newPayloadV4", payload, versionedHashes, beaconRoot, requests)
+}
+
+func (ec *Client) newPayload(ctx context.Context, method string, args ...interface{}) (*engine.PayloadStatusV1, error) {
+	var result engine.PayloadStatusV1
+	if err := ec.c.CallContext(ctx, &result, method, args...); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// NewPayload submits the payload of an envelope, as returned by GetPayload, using the
+// newPayload version matching the payload contents. The versioned hashes are taken
+// from the blob transactions of the payload. The beacon root is required for Cancun
+// and later payloads, and must be nil before.
+func (ec *Client) NewPayload(ctx context.Context, env *engine.ExecutionPayloadEnvelope, beaconRoot *common.Hash) (*engine.PayloadStatusV1, error) {
+	payload := env.ExecutionPayload
+	if payload == nil {
+		return nil, errors.New("envelope has no payload")
+	}
+	switch {
+	case payload.BlobGasUsed == nil:
+		if beaconRoot != nil {
+			return nil, errors.New("beacon root given for pre-Cancun payload")
+		}
+		if payload.Withdrawals != nil {
+			return ec.NewPayloadV2(ctx, payload)
+		}
+		return ec.NewPayloadV1(ctx, payload)
+	case beaconRoot == nil:
+		return nil, errors.New("missing beacon root for Cancun payload")
+	}
+	hashes, err := versionedHashes(payload)
+	if err != nil {
+		return nil, err
+	}
+	if env.Requests != nil {
+		requests := make([]hexutil.Bytes, len(env.Requests))
+		for i, req := range env.Requests {
+			requests[i] = req
+		}
+		return ec.NewPayloadV4(ctx, payload, hashes, beaconRoot, requests)
+	}
+	return ec.NewPayloadV3(ctx, payload, hashes, beaconRoot)
+}
+
+// versionedHashes collects the blob hashes of the transactions in a payload.
+func versionedHashes(payload *engine.ExecutableData) ([]common.Hash, error) {
+	hashes := make([]common.Hash, 0)
+	for _, enc := range payload.Transactions {
+		var tx types.Transaction
+		if err := tx.UnmarshalBinary(enc); err != nil {
+			return nil, err
+		}
+		hashes = append(hashes, tx.BlobHashes()...)
+	}
+	return hashes, nil
+}
+
+// GetPayloadBodiesByHashV1 retrieves the transactions and withdrawals of the blocks
+// with the given hashes. Unknown blocks have a nil body.
+func (ec *Client) GetPayloadBodiesByHashV1(ctx context.Context, hashes []common.Hash) ([]*engine.ExecutionPayloadBody, error) {
+	var result []*engine.ExecutionPayloadBody
+	err := ec.c.CallContext(ctx, &result, "engine_getPayloadBodiesByHashV1", hashes)
+	return result, err
+}
+
+// GetPayloadBodiesByRangeV1 retrieves the transactions and withdrawals of count blocks
+// starting at the given number. Bodies past the head of the chain are omitted.
+func (ec *Client) GetPayloadBodiesByRangeV1(ctx context.Context, start, count uint64) ([]*engine.ExecutionPayloadBody, error) {
+	var result []*engine.ExecutionPayloadBody
+	err := ec.c.CallContext(ctx, &result, "engine_getPayloadBodiesByRangeV1", hexutil.Uint64(start), hexutil.Uint64(count))
+	return result, err
+}
+
+// GetBlobsV1 retrieves blobs and their proofs from the blob pool of the execution
+// client. Unknown blobs are nil.
+func (ec *Client) GetBlobsV1(ctx context.Context, hashes []common.Hash) ([]*engine.BlobAndProofV1, error) {
+	var result []*engine.BlobAndProofV1
+	err := ec.c.CallContext(ctx, &result, "engine_getBlobsV1", hashes)
+	return result, err
+}
+
+// GetBlobsV2 retrieves blobs and their cell proofs from the blob pool of the
+// execution client. The result is nil unless all blobs are known.
+func (ec *Client) GetBlobsV2(ctx context.Context, hashes []common.Hash) ([]*engine.BlobAndProofV2, error) {
+	var result []*engine.BlobAndProofV2
+	err := ec.c.CallContext(ctx, &result, "engine_getBlobsV2", hashes)
+	return result, err
+}
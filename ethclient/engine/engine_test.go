@@ -0,0 +1,175 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package engine
+
+import (
+	"context"
+	"math/big"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/beacon/engine"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/node"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// testEngine records the newPayload version called by the client.
+type testEngine struct {
+	version int
+	hashes  []common.Hash
+}
+
+func (e *testEngine) NewPayloadV1(engine.ExecutableData) engine.PayloadStatusV1 {
+	e.version = 1
+	return engine.PayloadStatusV1{Status: engine.VALID}
+}
+
+func (e *testEngine) NewPayloadV2(engine.ExecutableData) engine.PayloadStatusV1 {
+	e.version = 2
+	return engine.PayloadStatusV1{Status: engine.VALID}
+}
+
+func (e *testEngine) NewPayloadV3(_ engine.ExecutableData, hashes []common.Hash, _ *common.Hash) engine.PayloadStatusV1 {
+	e.version, e.hashes = 3, hashes
+	return engine.PayloadStatusV1{Status: engine.VALID}
+}
+
+func (e *testEngine) NewPayloadV4(_ engine.ExecutableData, hashes []common.Hash, _ *common.Hash, _ []hexutil.Bytes) engine.PayloadStatusV1 {
+	e.version, e.hashes = 4, hashes
+	return engine.PayloadStatusV1{Status: engine.VALID}
+}
+
+func (e *testEngine) GetPayloadBodiesByRangeV1(start, count hexutil.Uint64) []*engine.ExecutionPayloadBody {
+	return make([]*engine.ExecutionPayloadBody, count)
+}
+
+// testPayload creates a payload with all required fields set.
+func testPayload(withdrawals bool, blobGasUsed *uint64, txs ...[]byte) *engine.ExecutableData {
+	payload := &engine.ExecutableData{
+		BaseFeePerGas: big.NewInt(params.InitialBaseFee),
+		Transactions:  append([][]byte{}, txs...),
+		BlobGasUsed:   blobGasUsed,
+	}
+	if withdrawals {
+		payload.Withdrawals = []*types.Withdrawal{}
+	}
+	return payload
+}
+
+func newTestEngine(t *testing.T, secret [32]byte) (*testEngine, string) {
+	var (
+		backend = new(testEngine)
+		srv     = rpc.NewServer()
+	)
+	if err := srv.RegisterName("engine", backend); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(srv.Stop)
+	hs := httptest.NewServer(node.NewHTTPHandlerStack(srv, nil, []string{"*"}, secret[:]))
+	t.Cleanup(hs.Close)
+	return backend, hs.URL
+}
+
+func TestAuthentication(t *testing.T) {
+	secret := [32]byte{1}
+	_, url := newTestEngine(t, secret)
+
+	ec, err := Dial(context.Background(), url, secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ec.Close()
+	bodies, err := ec.GetPayloadBodiesByRangeV1(context.Background(), 1, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(bodies) != 3 {
+		t.Fatalf("wrong number of bodies: %d", len(bodies))
+	}
+
+	bad, err := Dial(context.Background(), url, [32]byte{2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer bad.Close()
+	if _, err := bad.GetPayloadBodiesByRangeV1(context.Background(), 1, 3); err == nil {
+		t.Fatal("request with wrong secret succeeded")
+	}
+}
+
+func TestNewPayloadVersion(t *testing.T) {
+	secret := [32]byte{1}
+	backend, url := newTestEngine(t, secret)
+	ec, err := Dial(context.Background(), url, secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ec.Close()
+
+	blobTx, err := types.NewTx(&types.BlobTx{BlobHashes: []common.Hash{{0x01}}}).MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var (
+		root    = common.Hash{0xbe}
+		gasUsed = uint64(0)
+	)
+	tests := []struct {
+		env     *engine.ExecutionPayloadEnvelope
+		root    *common.Hash
+		version int
+		hashes  int
+	}{
+		{env: &engine.ExecutionPayloadEnvelope{ExecutionPayload: testPayload(false, nil)}, version: 1},
+		{env: &engine.ExecutionPayloadEnvelope{ExecutionPayload: testPayload(true, nil)}, version: 2},
+		{
+			env:     &engine.ExecutionPayloadEnvelope{ExecutionPayload: testPayload(true, &gasUsed, blobTx)},
+			root:    &root,
+			version: 3,
+			hashes:  1,
+		},
+		{
+			env:     &engine.ExecutionPayloadEnvelope{ExecutionPayload: testPayload(true, &gasUsed), Requests: [][]byte{}},
+			root:    &root,
+			version: 4,
+		},
+	}
+	for i, test := range tests {
+		status, err := ec.NewPayload(context.Background(), test.env, test.root)
+		if err != nil {
+			t.Fatalf("test %d: %v", i, err)
+		}
+		if status.Status != engine.VALID {
+			t.Fatalf("test %d: wrong status %s", i, status.Status)
+		}
+		if backend.version != test.version {
+			t.Fatalf("test %d: wrong version: have %d, want %d", i, backend.version, test.version)
+		}
+		if test.version >= 3 && len(backend.hashes) != test.hashes {
+			t.Fatalf("test %d: wrong number of versioned hashes: have %d, want %d", i, len(backend.hashes), test.hashes)
+		}
+	}
+
+	// Cancun payloads require the beacon root.
+	if _, err := ec.NewPayload(context.Background(), tests[2].env, nil); err == nil {
+		t.Fatal("expected error for missing beacon root")
+	}
+}
@@ -51,6 +51,16 @@ func DialContext(ctx context.Context, rawurl string) (*Client, error) {
 	return NewClient(c), nil
 }
 
+// DialMulti connects a client to several endpoints, failing over between them. See
+// rpc.DialMulti for the available options.
+func DialMulti(ctx context.Context, endpoints []string, options ...rpc.ClientOption) (*Client, error) {
+	c, err := rpc.DialMulti(ctx, endpoints, options...)
+	if err != nil {
+		return nil, err
+	}
+	return NewClient(c), nil
+}
+
 // NewClient creates a client that uses the given RPC client.
 func NewClient(c *rpc.Client) *Client {
 	return &Client{c}
@@ -107,6 +117,19 @@ func (ec *Client) BlockByNumber(ctx context.Context, number *big.Int) (*types.Bl
 	return ec.getBlock(ctx, "eth_getBlockByNumber", toBlockNumArg(number), true)
 }
 
+// BlockWithReceiptsByHash returns the given full block along with the receipts
+// of its transactions, retrieved in a single request.
+func (ec *Client) BlockWithReceiptsByHash(ctx context.Context, hash common.Hash) (*types.Block, []*types.Receipt, error) {
+	return ec.getBlockWithReceipts(ctx, "eth_getBlockByHash", hash, true, true)
+}
+
+// BlockWithReceiptsByNumber returns a block from the current canonical chain
+// along with the receipts of its transactions, retrieved in a single request.
+// If `number` is nil, the latest known block is returned.
+func (ec *Client) BlockWithReceiptsByNumber(ctx context.Context, number *big.Int) (*types.Block, []*types.Receipt, error) {
+	return ec.getBlockWithReceipts(ctx, "eth_getBlockByNumber", toBlockNumArg(number), true, true)
+}
+
 // BlockNumber returns the most recent block number
 func (ec *Client) BlockNumber(ctx context.Context) (uint64, error) {
 	var result hexutil.Uint64
@@ -144,7 +167,32 @@ func (ec *Client) getBlock(ctx context.Context, method string, args ...interface
 	if err != nil {
 		return nil, err
 	}
+	return ec.decodeBlock(ctx, raw)
+}
+
+func (ec *Client) getBlockWithReceipts(ctx context.Context, method string, args ...interface{}) (*types.Block, []*types.Receipt, error) {
+	var raw json.RawMessage
+	err := ec.c.CallContext(ctx, &raw, method, args...)
+	if err != nil {
+		return nil, nil, err
+	}
+	block, err := ec.decodeBlock(ctx, raw)
+	if err != nil {
+		return nil, nil, err
+	}
+	var body struct {
+		Receipts []*types.Receipt `json:"receipts"`
+	}
+	if err := json.Unmarshal(raw, &body); err != nil {
+		return nil, nil, err
+	}
+	if len(body.Receipts) != len(block.Transactions()) {
+		return nil, nil, fmt.Errorf("server returned %d receipts for %d transactions", len(body.Receipts), len(block.Transactions()))
+	}
+	return block, body.Receipts, nil
+}
 
+func (ec *Client) decodeBlock(ctx context.Context, raw json.RawMessage) (*types.Block, error) {
 	// Decode header and transactions.
 	var head *types.Header
 	if err := json.Unmarshal(raw, &head); err != nil {
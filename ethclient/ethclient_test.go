@@ -375,6 +375,31 @@ func testGetBlock(t *testing.T, client *rpc.Client) {
 	if block.Header().Hash() != headerH.Hash() {
 		t.Fatalf("HeaderByHash returned wrong header: want %v got %v", block.Header().Hash().Hex(), headerH.Hash().Hex())
 	}
+	// Get block with receipts by number and hash
+	want, err := ec.BlockReceipts(context.Background(), rpc.BlockNumberOrHashWithHash(block.Hash(), false))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	blockR, receipts, err := ec.BlockWithReceiptsByNumber(context.Background(), new(big.Int).SetUint64(blockNumber))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if block.Hash() != blockR.Hash() {
+		t.Fatalf("BlockWithReceiptsByNumber returned wrong block: want %v got %v", block.Hash().Hex(), blockR.Hash().Hex())
+	}
+	if len(receipts) != 2 || !reflect.DeepEqual(receipts, want) {
+		t.Fatalf("BlockWithReceiptsByNumber returned wrong receipts: want %v got %v", want, receipts)
+	}
+	blockR, receipts, err = ec.BlockWithReceiptsByHash(context.Background(), block.Hash())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if block.Hash() != blockR.Hash() {
+		t.Fatalf("BlockWithReceiptsByHash returned wrong block: want %v got %v", block.Hash().Hex(), blockR.Hash().Hex())
+	}
+	if !reflect.DeepEqual(receipts, want) {
+		t.Fatalf("BlockWithReceiptsByHash returned wrong receipts: want %v got %v", want, receipts)
+	}
 }
 
 func testStatusFunctions(t *testing.T, client *rpc.Client) {
@@ -66,6 +66,30 @@ func (*HandlerT) Vmodule(pattern string) error {
 	return glogger.Vmodule(pattern)
 }
 
+// SetSubsystemVerbosity sets the log verbosity of a single subsystem, e.g. the
+// miner or the txpool, overriding the global verbosity and the vmodule patterns
+// for its packages. Omitting the level removes the override.
+func (*HandlerT) SetSubsystemVerbosity(name string, level *int) error {
+	if level == nil {
+		return glogger.SetSubsystemVerbosity(name, nil)
+	}
+	lvl := log.FromLegacyLevel(*level)
+	return glogger.SetSubsystemVerbosity(name, &lvl)
+}
+
+// SubsystemVerbosity returns the log verbosity overrides currently set per
+// subsystem, along with the names of all subsystems which can be adjusted.
+func (*HandlerT) SubsystemVerbosity() map[string]any {
+	levels := make(map[string]string)
+	for name, level := range glogger.SubsystemLevels() {
+		levels[name] = log.LevelString(level)
+	}
+	return map[string]any{
+		"subsystems": log.Subsystems(),
+		"levels":     levels,
+	}
+}
+
 // MemStats returns detailed runtime memory statistics.
 func (*HandlerT) MemStats() *runtime.MemStats {
 	s := new(runtime.MemStats)
@@ -102,7 +126,14 @@ func (h *HandlerT) StartCPUProfile(file string) error {
 	if err != nil {
 		return err
 	}
+	// Take the CPU profiler over from the continuous uploader, if running.
+	if uploader != nil {
+		uploader.suspend()
+	}
 	if err := pprof.StartCPUProfile(f); err != nil {
+		if uploader != nil {
+			uploader.resume()
+		}
 		f.Close()
 		return err
 	}
@@ -116,14 +147,18 @@ func (h *HandlerT) StartCPUProfile(file string) error {
 func (h *HandlerT) StopCPUProfile() error {
 	h.mu.Lock()
 	defer h.mu.Unlock()
-	pprof.StopCPUProfile()
+	// Only stop the CPU profiler if owned, it might be used by the uploader.
 	if h.cpuW == nil {
 		return errors.New("CPU profiling not in progress")
 	}
+	pprof.StopCPUProfile()
 	log.Info("Done writing CPU profile", "dump", h.cpuFile)
 	h.cpuW.Close()
 	h.cpuW = nil
 	h.cpuFile = ""
+	if uploader != nil {
+		uploader.resume()
+	}
 	return nil
 }
 
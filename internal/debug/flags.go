@@ -26,6 +26,7 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"time"
 
 	"github.com/ethereum/go-ethereum/internal/flags"
 	"github.com/ethereum/go-ethereum/log"
@@ -50,6 +51,12 @@ var (
 		Value:    "",
 		Category: flags.LoggingCategory,
 	}
+	logSubsystemsFlag = &cli.StringFlag{
+		Name:     "log.subsystems",
+		Usage:    "Per-subsystem verbosity: comma-separated list of <subsystem>=<level> (e.g. miner=4,txpool=2), overriding --verbosity and --log.vmodule",
+		Value:    "",
+		Category: flags.LoggingCategory,
+	}
 	vmoduleFlag = &cli.StringFlag{
 		Name:     "vmodule",
 		Usage:    "Per-module verbosity: comma-separated list of <pattern>=<level> (e.g. eth/*=5,p2p=4)",
@@ -130,6 +137,22 @@ var (
 		Usage:    "Turn on block profiling with the given rate",
 		Category: flags.LoggingCategory,
 	}
+	pprofUploadFlag = &cli.StringFlag{
+		Name:     "pprof.upload",
+		Usage:    "Continuously ship CPU and heap profiles to the given Pyroscope-compatible endpoint (e.g. http://pyroscope:4040)",
+		Category: flags.LoggingCategory,
+	}
+	pprofUploadPeriodFlag = &cli.DurationFlag{
+		Name:     "pprof.upload.period",
+		Usage:    "Time period covered by each continuously shipped profile",
+		Value:    10 * time.Second,
+		Category: flags.LoggingCategory,
+	}
+	pprofUploadLabelsFlag = &cli.StringFlag{
+		Name:     "pprof.upload.labels",
+		Usage:    "Comma-separated list of <key>=<value> labels attached to the shipped profiles (e.g. instance=sequencer-1)",
+		Category: flags.LoggingCategory,
+	}
 	cpuprofileFlag = &cli.StringFlag{
 		Name:     "pprof.cpuprofile",
 		Usage:    "Write CPU profile to the given file",
@@ -146,6 +169,7 @@ var (
 var Flags = []cli.Flag{
 	verbosityFlag,
 	logVmoduleFlag,
+	logSubsystemsFlag,
 	vmoduleFlag,
 	logjsonFlag,
 	logFormatFlag,
@@ -162,11 +186,15 @@ var Flags = []cli.Flag{
 	blockprofilerateFlag,
 	cpuprofileFlag,
 	traceFlag,
+	pprofUploadFlag,
+	pprofUploadPeriodFlag,
+	pprofUploadLabelsFlag,
 }
 
 var (
 	glogger       *log.GlogHandler
 	logOutputFile io.WriteCloser
+	uploader      *profiler
 )
 
 func init() {
@@ -263,6 +291,9 @@ func Setup(ctx *cli.Context) error {
 		}
 	}
 	glogger.Vmodule(vmodule)
+	if err := glogger.SubsystemVerbosity(ctx.String(logSubsystemsFlag.Name)); err != nil {
+		return fmt.Errorf("invalid --%s: %v", logSubsystemsFlag.Name, err)
+	}
 
 	log.SetDefault(log.NewLogger(glogger))
 
@@ -287,6 +318,21 @@ func Setup(ctx *cli.Context) error {
 		}
 	}
 
+	// continuous profiling
+	if endpoint := ctx.String(pprofUploadFlag.Name); endpoint != "" {
+		if ctx.IsSet(cpuprofileFlag.Name) {
+			return fmt.Errorf("--%s and --%s are mutually exclusive", pprofUploadFlag.Name, cpuprofileFlag.Name)
+		}
+		labels, err := parseLabels(ctx.String(pprofUploadLabelsFlag.Name))
+		if err != nil {
+			return fmt.Errorf("invalid --%s: %v", pprofUploadLabelsFlag.Name, err)
+		}
+		if uploader, err = newProfiler(endpoint, "geth", labels, ctx.Duration(pprofUploadPeriodFlag.Name)); err != nil {
+			return err
+		}
+		uploader.start()
+	}
+
 	// pprof server
 	if ctx.Bool(pprofFlag.Name) {
 		listenHost := ctx.String(pprofAddrFlag.Name)
@@ -321,6 +367,9 @@ func StartPProf(address string, withMetrics bool) {
 // Exit stops all running profiles, flushing their output to the
 // respective file.
 func Exit() {
+	if uploader != nil {
+		uploader.stop()
+	}
 	Handler.StopCPUProfile()
 	Handler.StopGoTrace()
 	if logOutputFile != nil {
@@ -0,0 +1,264 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package debug
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"runtime/pprof"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// profileUploadTimeout is the maximum time allowed for shipping a single profile.
+const profileUploadTimeout = 10 * time.Second
+
+// profiler continuously collects CPU and heap profiles and ships them to a
+// remote endpoint accepting the Pyroscope ingestion API (Pyroscope itself, or
+// Grafana agents and Parca setups fronted by a compatible receiver).
+//
+// Each CPU profile covers a single period, so consecutive uploads are deltas
+// which the server aggregates. Samples carry the pprof labels set by the
+// subsystems (e.g. subsystem=import), allowing them to be told apart.
+//
+// The Go CPU profiler is process-wide. The uploader gives it up whenever a CPU
+// profile is requested through the debug API (debug_cpuProfile and friends) and
+// resumes once that finishes. Other users (e.g. the /debug/pprof/profile HTTP
+// endpoint) can only grab it between two periods; while they hold it, periods
+// are shipped without a CPU profile.
+type profiler struct {
+	endpoint string
+	name     string // application name including the static labels
+	period   time.Duration
+	client   *http.Client
+
+	lock      sync.Mutex
+	profiling bool // Whether the uploader currently holds the CPU profiler
+	suspended bool // Whether the CPU profiler was yielded to the debug API
+
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+// newProfiler creates a continuous profiler shipping to the given endpoint. The
+// static labels are attached to every profile uploaded.
+func newProfiler(endpoint string, app string, labels map[string]string, period time.Duration) (*profiler, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid profiling endpoint: %v", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("invalid profiling endpoint scheme %q", u.Scheme)
+	}
+	if period < time.Second {
+		return nil, fmt.Errorf("profiling period too short: %v", period)
+	}
+	return &profiler{
+		endpoint: strings.TrimSuffix(endpoint, "/") + "/ingest",
+		name:     app + formatLabels(labels),
+		period:   period,
+		client:   &http.Client{Timeout: profileUploadTimeout},
+		quit:     make(chan struct{}),
+	}, nil
+}
+
+// formatLabels renders the labels in the Pyroscope application name format,
+// i.e. {key1=value1,key2=value2}.
+func formatLabels(labels map[string]string) string {
+	if len(labels) == 0 {
+		return ""
+	}
+	keys := make([]string, 0, len(labels))
+	for key := range labels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	b.WriteByte('{')
+	for i, key := range keys {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(key + "=" + labels[key])
+	}
+	b.WriteByte('}')
+	return b.String()
+}
+
+// parseLabels parses a comma-separated list of key=value pairs.
+func parseLabels(spec string) (map[string]string, error) {
+	labels := make(map[string]string)
+	for _, item := range strings.Split(spec, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(item, "=")
+		if !ok || key == "" || value == "" {
+			return nil, fmt.Errorf("invalid label %q, expected key=value", item)
+		}
+		if strings.ContainsAny(key+value, "{},=") {
+			return nil, fmt.Errorf("invalid characters in label %q", item)
+		}
+		labels[key] = value
+	}
+	return labels, nil
+}
+
+// start launches the profiling loop.
+func (p *profiler) start() {
+	p.wg.Add(1)
+	go p.loop()
+}
+
+// stop terminates the profiling loop, discarding the partially collected period.
+func (p *profiler) stop() {
+	close(p.quit)
+	p.wg.Wait()
+}
+
+// suspend stops the CPU profile of the current period (discarding it) and keeps
+// the CPU profiler free until resume is called.
+func (p *profiler) suspend() {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if p.profiling {
+		pprof.StopCPUProfile()
+		p.profiling = false
+	}
+	p.suspended = true
+}
+
+// resume allows the uploader to collect CPU profiles again from the next period.
+func (p *profiler) resume() {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.suspended = false
+}
+
+// startCPU starts the CPU profile of a new period, unless the profiler is
+// suspended or in use elsewhere.
+func (p *profiler) startCPU(w *bytes.Buffer) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if p.suspended {
+		return
+	}
+	if err := pprof.StartCPUProfile(w); err != nil {
+		log.Debug("CPU profiler busy, skipping period", "err", err)
+		return
+	}
+	p.profiling = true
+}
+
+// stopCPU stops the CPU profile of the current period, reporting whether it has
+// been collected for the full period.
+func (p *profiler) stopCPU() bool {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if !p.profiling {
+		return false
+	}
+	pprof.StopCPUProfile()
+	p.profiling = false
+	return true
+}
+
+func (p *profiler) loop() {
+	defer p.wg.Done()
+
+	log.Info("Started continuous profiling", "endpoint", p.endpoint, "name", p.name, "period", p.period)
+	timer := time.NewTimer(p.period)
+	defer timer.Stop()
+
+	for {
+		var (
+			cpu   bytes.Buffer
+			start = time.Now()
+		)
+		// The CPU profiler is process-wide: if it's in use elsewhere, skip the
+		// CPU profile for this period but keep shipping the heap ones.
+		p.startCPU(&cpu)
+		timer.Reset(p.period)
+
+		select {
+		case <-timer.C:
+		case <-p.quit:
+			p.stopCPU()
+			return
+		}
+		end := time.Now()
+		if p.stopCPU() {
+			p.upload("cpu", start, end, &cpu)
+		}
+		var heap bytes.Buffer
+		if err := pprof.Lookup("heap").WriteTo(&heap, 0); err != nil {
+			log.Debug("Failed to collect heap profile", "err", err)
+			continue
+		}
+		p.upload("heap", start, end, &heap)
+	}
+}
+
+// upload ships a single pprof encoded profile covering the given time range.
+func (p *profiler) upload(kind string, from, until time.Time, profile *bytes.Buffer) {
+	query := url.Values{
+		"name":    {p.name},
+		"from":    {fmt.Sprint(from.Unix())},
+		"until":   {fmt.Sprint(until.Unix())},
+		"format":  {"pprof"},
+		"spyName": {"gospy"},
+	}
+	if kind == "cpu" {
+		// Go samples the CPU at 100Hz, heap profiles are cumulative snapshots.
+		query.Set("sampleRate", "100")
+	}
+	size := profile.Len()
+
+	ctx, cancel := context.WithTimeout(context.Background(), profileUploadTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.endpoint+"?"+query.Encode(), profile)
+	if err != nil {
+		log.Warn("Failed to create profile upload", "kind", kind, "err", err)
+		return
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+	res, err := p.client.Do(req)
+	if err != nil {
+		log.Warn("Failed to upload profile", "kind", kind, "err", err)
+		return
+	}
+	res.Body.Close()
+	if res.StatusCode/100 != 2 {
+		log.Warn("Profile upload rejected", "kind", kind, "status", res.Status)
+		return
+	}
+	log.Trace("Uploaded profile", "kind", kind, "size", size)
+}
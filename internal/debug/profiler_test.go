@@ -0,0 +1,201 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package debug
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"runtime/pprof"
+	"testing"
+	"time"
+)
+
+func TestNewProfiler(t *testing.T) {
+	tests := []struct {
+		endpoint string
+		labels   map[string]string
+		period   time.Duration
+		ingest   string
+		name     string
+		fail     bool
+	}{
+		{endpoint: "http://localhost:4040", period: time.Second, ingest: "http://localhost:4040/ingest", name: "geth"},
+		{endpoint: "https://localhost:4040/", period: time.Minute, ingest: "https://localhost:4040/ingest", name: "geth"},
+		{
+			endpoint: "http://localhost:4040",
+			labels:   map[string]string{"network": "mainnet", "instance": "a"},
+			period:   time.Second,
+			ingest:   "http://localhost:4040/ingest",
+			name:     "geth{instance=a,network=mainnet}",
+		},
+		{endpoint: "localhost:4040", period: time.Second, fail: true},
+		{endpoint: "ftp://localhost:4040", period: time.Second, fail: true},
+		{endpoint: "http://localhost:4040", period: time.Millisecond, fail: true},
+	}
+	for i, tt := range tests {
+		p, err := newProfiler(tt.endpoint, "geth", tt.labels, tt.period)
+		if tt.fail {
+			if err == nil {
+				t.Errorf("test %d: expected error", i)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("test %d: unexpected error: %v", i, err)
+			continue
+		}
+		if p.endpoint != tt.ingest {
+			t.Errorf("test %d: endpoint mismatch: have %s, want %s", i, p.endpoint, tt.ingest)
+		}
+		if p.name != tt.name {
+			t.Errorf("test %d: name mismatch: have %s, want %s", i, p.name, tt.name)
+		}
+	}
+}
+
+func TestParseLabels(t *testing.T) {
+	tests := []struct {
+		spec   string
+		labels map[string]string
+		fail   bool
+	}{
+		{spec: "", labels: map[string]string{}},
+		{spec: "a=b", labels: map[string]string{"a": "b"}},
+		{spec: " a=b , c=d,", labels: map[string]string{"a": "b", "c": "d"}},
+		{spec: "a", fail: true},
+		{spec: "a=", fail: true},
+		{spec: "=b", fail: true},
+		{spec: "a=b=c", fail: true},
+		{spec: "a={b}", fail: true},
+	}
+	for i, tt := range tests {
+		labels, err := parseLabels(tt.spec)
+		if tt.fail {
+			if err == nil {
+				t.Errorf("test %d: expected error for %q", i, tt.spec)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("test %d: unexpected error for %q: %v", i, tt.spec, err)
+			continue
+		}
+		if !reflect.DeepEqual(labels, tt.labels) {
+			t.Errorf("test %d: labels mismatch: have %v, want %v", i, labels, tt.labels)
+		}
+	}
+}
+
+// upload is a profile received by the test ingestion server.
+type upload struct {
+	query url.Values
+	size  int
+}
+
+// startProfiler runs a profiler with a short period against a test server,
+// returning the channel of the uploads it receives.
+func startProfiler(t *testing.T) (*profiler, chan upload) {
+	t.Helper()
+
+	uploads := make(chan upload, 16)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/ingest" {
+			t.Errorf("unexpected upload path %s", r.URL.Path)
+		}
+		body, _ := io.ReadAll(r.Body)
+		uploads <- upload{query: r.URL.Query(), size: len(body)}
+	}))
+	t.Cleanup(srv.Close)
+
+	p, err := newProfiler(srv.URL, "geth", map[string]string{"instance": "test"}, time.Second)
+	if err != nil {
+		t.Fatalf("failed to create profiler: %v", err)
+	}
+	p.period = 100 * time.Millisecond
+	p.start()
+	t.Cleanup(p.stop)
+
+	return p, uploads
+}
+
+// nextUpload waits for the next profile shipped by the profiler.
+func nextUpload(t *testing.T, uploads chan upload) upload {
+	t.Helper()
+
+	select {
+	case up := <-uploads:
+		return up
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout waiting for profile upload")
+	}
+	return upload{}
+}
+
+func TestProfilerUpload(t *testing.T) {
+	_, uploads := startProfiler(t)
+
+	// Every period ships a CPU profile followed by a heap profile
+	for i, kind := range []string{"cpu", "heap", "cpu", "heap"} {
+		up := nextUpload(t, uploads)
+		if up.query.Get("name") != "geth{instance=test}" {
+			t.Errorf("upload %d: name mismatch: have %s", i, up.query.Get("name"))
+		}
+		if up.query.Get("format") != "pprof" {
+			t.Errorf("upload %d: format mismatch: have %s", i, up.query.Get("format"))
+		}
+		if have := up.query.Get("sampleRate"); (have == "100") != (kind == "cpu") {
+			t.Errorf("upload %d: unexpected sample rate for %s profile: %q", i, kind, have)
+		}
+		if up.size == 0 {
+			t.Errorf("upload %d: empty %s profile", i, kind)
+		}
+	}
+}
+
+func TestProfilerSuspend(t *testing.T) {
+	p, uploads := startProfiler(t)
+
+	// Wait until the loop is running, then take the CPU profiler over
+	nextUpload(t, uploads)
+	p.suspend()
+
+	if err := pprof.StartCPUProfile(io.Discard); err != nil {
+		t.Fatalf("CPU profiler not yielded: %v", err)
+	}
+	// Skip the profiles of the period being shipped, then ensure only heap
+	// profiles are uploaded
+	for nextUpload(t, uploads).query.Has("sampleRate") {
+	}
+	for i := 0; i < 3; i++ {
+		if up := nextUpload(t, uploads); up.query.Has("sampleRate") {
+			t.Errorf("upload %d: CPU profile shipped while suspended", i)
+		}
+	}
+	pprof.StopCPUProfile()
+	p.resume()
+
+	// CPU profiles should be shipped again after resuming
+	for i := 0; i < 4; i++ {
+		if nextUpload(t, uploads).query.Has("sampleRate") {
+			return
+		}
+	}
+	t.Error("no CPU profile shipped after resuming")
+}
@@ -0,0 +1,220 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package ethapi
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/internal/ethapi/override"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// accessListReport is the result of the `eth_createAccessListV2` RPC call. On
+// top of the access list, it details the effect of the list on the transaction
+// size and on the gas spent by each of the touched contracts.
+type accessListReport struct {
+	Accesslist         *types.AccessList `json:"accessList"`
+	Error              string            `json:"error,omitempty"`
+	GasUsed            hexutil.Uint64    `json:"gasUsed"`
+	GasUsedWithoutList hexutil.Uint64    `json:"gasUsedWithoutAccessList"`
+	IntrinsicGas       hexutil.Uint64    `json:"intrinsicGas"`
+
+	// Transaction size, the access list being part of the data made available
+	DataSize      hexutil.Uint64 `json:"dataSize"`      // Encoded size of the unsigned transaction without access list
+	DataSizeDelta hexutil.Uint64 `json:"dataSizeDelta"` // Bytes added to the transaction by the access list
+
+	// Cold accesses of the execution without access list, which the list turns
+	// into cheaper warm ones
+	ColdAccounts hexutil.Uint64 `json:"coldAccounts"`
+	ColdSlots    hexutil.Uint64 `json:"coldSlots"`
+
+	Contracts []*contractGasUsage `json:"contracts"`
+}
+
+// contractGasUsage is the gas spent executing the code of a contract, excluding
+// the gas spent by the calls it makes.
+type contractGasUsage struct {
+	Address     common.Address `json:"address"`
+	Calls       hexutil.Uint64 `json:"calls"`
+	GasUsed     hexutil.Uint64 `json:"gasUsed"`
+	StorageKeys hexutil.Uint64 `json:"storageKeys"`
+}
+
+// CreateAccessListV2 creates an EIP-2930 type AccessList for the given transaction
+// like CreateAccessList, additionally reporting the size and gas impact of the
+// list and the gas used by each of the contracts touched by the transaction.
+func (api *BlockChainAPI) CreateAccessListV2(ctx context.Context, args TransactionArgs, blockNrOrHash *rpc.BlockNumberOrHash, stateOverrides *override.StateOverride) (*accessListReport, error) {
+	bNrOrHash := rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+	if blockNrOrHash != nil {
+		bNrOrHash = *blockNrOrHash
+	}
+	acl, gasUsed, vmerr, err := AccessList(ctx, api.b, bNrOrHash, args, stateOverrides)
+	if err != nil {
+		return nil, err
+	}
+	report := &accessListReport{Accesslist: &acl, GasUsed: hexutil.Uint64(gasUsed)}
+	if vmerr != nil {
+		report.Error = vmerr.Error()
+	}
+	if err := analyzeAccessList(ctx, api.b, bNrOrHash, args, stateOverrides, acl, report); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// analyzeAccessList executes the transaction once with the given access list to
+// break its gas down per contract and once without it to measure its savings.
+func analyzeAccessList(ctx context.Context, b Backend, blockNrOrHash rpc.BlockNumberOrHash, args TransactionArgs, stateOverrides *override.StateOverride, acl types.AccessList, report *accessListReport) error {
+	db, header, blockCtx, err := accessListContext(ctx, b, blockNrOrHash, &args, stateOverrides)
+	if db == nil || err != nil {
+		return err
+	}
+	// Measure the impact of the list on the transaction itself
+	args.AccessList = nil
+	report.DataSize = hexutil.Uint64(args.ToTransaction(types.LegacyTxType).Size())
+
+	blob, err := rlp.EncodeToBytes(acl)
+	if err != nil {
+		return err
+	}
+	empty, _ := rlp.EncodeToBytes(types.AccessList{})
+	report.DataSizeDelta = hexutil.Uint64(len(blob) - len(empty))
+
+	rules := b.ChainConfig().Rules(header.Number, header.Difficulty.Sign() == 0, header.Time)
+	intrinsic, err := core.IntrinsicGas(args.data(), acl, args.AuthorizationList, args.To == nil, rules.IsHomestead, rules.IsIstanbul, rules.IsShanghai)
+	if err != nil {
+		return err
+	}
+	report.IntrinsicGas = hexutil.Uint64(intrinsic)
+
+	// Execute without the access list, every listed item is accessed cold
+	res, err := applyAccessListCall(ctx, b, db.Copy(), header, blockCtx, args, nil)
+	if err != nil {
+		return err
+	}
+	report.GasUsedWithoutList = hexutil.Uint64(res.UsedGas)
+	for _, tuple := range acl {
+		report.ColdAccounts++
+		report.ColdSlots += hexutil.Uint64(len(tuple.StorageKeys))
+	}
+	// Execute with the access list, tracking the gas spent in each call frame
+	args.AccessList = &acl
+	tracer := newGasBreakdownTracer()
+	if _, err := applyAccessListCall(ctx, b, db.Copy(), header, blockCtx, args, tracer.hooks()); err != nil {
+		return err
+	}
+	report.Contracts = tracer.usage
+	for _, usage := range report.Contracts {
+		for _, tuple := range acl {
+			if tuple.Address == usage.Address {
+				usage.StorageKeys += hexutil.Uint64(len(tuple.StorageKeys))
+			}
+		}
+	}
+	return nil
+}
+
+// applyAccessListCall executes the transaction on the given state the same way
+// the access list creation does.
+func applyAccessListCall(ctx context.Context, b Backend, db *state.StateDB, header *types.Header, blockCtx vm.BlockContext, args TransactionArgs, hooks *tracing.Hooks) (*core.ExecutionResult, error) {
+	msg := args.ToMessage(header.BaseFee, true)
+	evm := b.GetEVM(ctx, db, header, &vm.Config{Tracer: hooks, NoBaseFee: true}, blockCtx)
+
+	// Lower the basefee to 0 to avoid breaking EVM
+	// invariants (basefee < feecap).
+	if msg.GasPrice.Sign() == 0 {
+		evm.Context.BaseFee = new(big.Int)
+	}
+	if msg.BlobGasFeeCap != nil && msg.BlobGasFeeCap.BitLen() == 0 {
+		evm.Context.BlobBaseFee = new(big.Int)
+	}
+	res, err := core.ApplyMessage(evm, msg, new(core.GasPool).AddGas(msg.GasLimit))
+	if err != nil {
+		return nil, fmt.Errorf("failed to apply transaction: %v err: %v", args.ToTransaction(types.LegacyTxType).Hash(), err)
+	}
+	return res, nil
+}
+
+// gasBreakdownTracer attributes the gas used by each call frame to the contract
+// whose code is executed, deducting the gas used by the nested frames.
+type gasBreakdownTracer struct {
+	frames []gasFrame
+	usage  []*contractGasUsage
+	index  map[common.Address]*contractGasUsage
+}
+
+type gasFrame struct {
+	code     common.Address // Address of the code executed in the frame
+	children uint64         // Gas used by the frames nested in this one
+	ignored  bool           // Whether the frame is a selfdestruct pseudo-call
+}
+
+func newGasBreakdownTracer() *gasBreakdownTracer {
+	return &gasBreakdownTracer{index: make(map[common.Address]*contractGasUsage)}
+}
+
+func (t *gasBreakdownTracer) hooks() *tracing.Hooks {
+	return &tracing.Hooks{
+		OnEnter: t.onEnter,
+		OnExit:  t.onExit,
+	}
+}
+
+func (t *gasBreakdownTracer) onEnter(depth int, typ byte, from common.Address, to common.Address, input []byte, gas uint64, value *big.Int) {
+	if vm.OpCode(typ) == vm.SELFDESTRUCT {
+		// Not a real call, but reported with a matching exit
+		t.frames = append(t.frames, gasFrame{ignored: true})
+		return
+	}
+	t.frames = append(t.frames, gasFrame{code: to})
+
+	usage := t.index[to]
+	if usage == nil {
+		usage = &contractGasUsage{Address: to}
+		t.index[to] = usage
+		t.usage = append(t.usage, usage)
+	}
+	usage.Calls++
+}
+
+func (t *gasBreakdownTracer) onExit(depth int, output []byte, gasUsed uint64, err error, reverted bool) {
+	if len(t.frames) == 0 {
+		return
+	}
+	frame := t.frames[len(t.frames)-1]
+	t.frames = t.frames[:len(t.frames)-1]
+
+	if frame.ignored {
+		return
+	}
+	if gasUsed > frame.children {
+		t.index[frame.code].GasUsed += hexutil.Uint64(gasUsed - frame.children)
+	}
+	if len(t.frames) > 0 {
+		t.frames[len(t.frames)-1].children += gasUsed
+	}
+}
@@ -35,12 +35,15 @@ import (
 	"github.com/ethereum/go-ethereum/consensus/misc/eip1559"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/forkid"
+	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/txpool"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/eth/gasestimator"
 	"github.com/ethereum/go-ethereum/eth/tracers/logger"
+	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/internal/ethapi/override"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/p2p"
@@ -280,6 +283,52 @@ func (api *TxPoolAPI) Inspect() map[string]map[string]map[string]string {
 	return content
 }
 
+// Dropped creates a subscription that is triggered each time a transaction is
+// dropped from the pool without being included, e.g. replaced, underpriced or
+// evicted from a full pool. Transactions are reported with the sender and the
+// reason of the drop. If addresses are given, only the transactions of these
+// senders are reported.
+func (api *TxPoolAPI) Dropped(ctx context.Context, addresses *[]common.Address) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+	var filter map[common.Address]struct{}
+	if addresses != nil {
+		filter = make(map[common.Address]struct{}, len(*addresses))
+		for _, addr := range *addresses {
+			filter[addr] = struct{}{}
+		}
+	}
+	rpcSub := notifier.CreateSubscription()
+
+	go func() {
+		events := make(chan txpool.DroppedTxsEvent, 128)
+		droppedSub := api.b.SubscribeDroppedTxsEvent(events)
+		defer droppedSub.Unsubscribe()
+
+		for {
+			select {
+			case ev := <-events:
+				for _, tx := range ev.Txs {
+					if filter != nil {
+						if _, ok := filter[tx.From]; !ok {
+							continue
+						}
+					}
+					notifier.Notify(rpcSub.ID, tx)
+				}
+			case <-rpcSub.Err():
+				return
+			case <-droppedSub.Err():
+				return
+			}
+		}
+	}()
+
+	return rpcSub, nil
+}
+
 // EthereumAccountAPI provides an API to access accounts managed by this node.
 // It offers only methods that can retrieve accounts.
 type EthereumAccountAPI struct {
@@ -398,16 +447,7 @@ func (api *BlockChainAPI) GetProof(ctx context.Context, address common.Address,
 		}
 		// Create the proofs for the storageKeys.
 		for i, key := range keys {
-			// Output key encoding is a bit special: if the input was a 32-byte hash, it is
-			// returned as such. Otherwise, we apply the QUANTITY encoding mandated by the
-			// JSON-RPC spec for getProof. This behavior exists to preserve backwards
-			// compatibility with older client versions.
-			var outputKey string
-			if keyLengths[i] != 32 {
-				outputKey = hexutil.EncodeBig(key.Big())
-			} else {
-				outputKey = hexutil.Encode(key[:])
-			}
+			outputKey := encodeStorageKey(key, keyLengths[i])
 			if storageTrie == nil {
 				storageProof[i] = StorageResult{outputKey, &hexutil.Big{}, []string{}}
 				continue
@@ -441,6 +481,236 @@ func (api *BlockChainAPI) GetProof(ctx context.Context, address common.Address,
 	}, statedb.Error()
 }
 
+// encodeStorageKey encodes a storage key of a proof. The output key encoding is
+// a bit special: if the input was a 32-byte hash, it is returned as such.
+// Otherwise, we apply the QUANTITY encoding mandated by the JSON-RPC spec for
+// getProof. This behavior exists to preserve backwards compatibility with older
+// client versions.
+func encodeStorageKey(key common.Hash, inputLength int) string {
+	if inputLength != 32 {
+		return hexutil.EncodeBig(key.Big())
+	}
+	return hexutil.Encode(key[:])
+}
+
+// maxProofBatchItems is the maximum number of accounts and storage slots proven
+// at once by GetProofBatch.
+const maxProofBatchItems = 1024
+
+// ProofRequest is an account and its storage slots to prove.
+type ProofRequest struct {
+	Address     common.Address `json:"address"`
+	StorageKeys []string       `json:"storageKeys"`
+}
+
+// BatchProofResult is the Merkle proof of multiple accounts and storage slots.
+// The trie nodes shared by the proofs are included once, the proofs reference
+// them by index.
+type BatchProofResult struct {
+	Nodes    []hexutil.Bytes      `json:"nodes"`
+	Accounts []BatchAccountResult `json:"accounts"`
+}
+
+// BatchAccountResult is the proof of an account in a batch, referencing the
+// nodes of the batch.
+type BatchAccountResult struct {
+	Address      common.Address       `json:"address"`
+	AccountProof []int                `json:"accountProof"`
+	Balance      *hexutil.Big         `json:"balance"`
+	CodeHash     common.Hash          `json:"codeHash"`
+	Nonce        hexutil.Uint64       `json:"nonce"`
+	StorageHash  common.Hash          `json:"storageHash"`
+	StorageProof []BatchStorageResult `json:"storageProof"`
+}
+
+// BatchStorageResult is the proof of a storage slot in a batch, referencing the
+// nodes of the batch.
+type BatchStorageResult struct {
+	Key   string       `json:"key"`
+	Value *hexutil.Big `json:"value"`
+	Proof []int        `json:"proof"`
+}
+
+// proofNodes collects the deduplicated trie nodes of multiple proofs.
+type proofNodes struct {
+	index map[common.Hash]int
+	nodes []hexutil.Bytes
+}
+
+// proofRefs implements ethdb.KeyValueWriter and collects a proof as references
+// into a set of shared nodes.
+type proofRefs struct {
+	set  *proofNodes
+	refs []int
+}
+
+func (r *proofRefs) Put(key []byte, value []byte) error {
+	hash := common.BytesToHash(key)
+	idx, ok := r.set.index[hash]
+	if !ok {
+		idx = len(r.set.nodes)
+		r.set.index[hash] = idx
+		r.set.nodes = append(r.set.nodes, common.CopyBytes(value))
+	}
+	r.refs = append(r.refs, idx)
+	return nil
+}
+
+func (r *proofRefs) Delete(key []byte) error {
+	panic("not supported")
+}
+
+// GetProofBatch returns the Merkle proofs of multiple accounts and their storage
+// slots. The trie nodes shared by the proofs are deduplicated.
+func (api *BlockChainAPI) GetProofBatch(ctx context.Context, requests []ProofRequest, blockNrOrHash rpc.BlockNumberOrHash) (*BatchProofResult, error) {
+	// Deserialize all keys. This prevents state access on invalid input.
+	var (
+		keys       = make([][]common.Hash, len(requests))
+		keyLengths = make([][]int, len(requests))
+		items      = len(requests)
+	)
+	for i, req := range requests {
+		items += len(req.StorageKeys)
+		keys[i] = make([]common.Hash, len(req.StorageKeys))
+		keyLengths[i] = make([]int, len(req.StorageKeys))
+		for j, hexKey := range req.StorageKeys {
+			var err error
+			keys[i][j], keyLengths[i][j], err = decodeStorageKey(hexKey)
+			if err != nil {
+				return nil, &invalidParamsError{fmt.Sprintf("%v: %q", err, hexKey)}
+			}
+		}
+	}
+	if items > maxProofBatchItems {
+		return nil, &invalidParamsError{fmt.Sprintf("too many accounts and storage keys: %d > %d", items, maxProofBatchItems)}
+	}
+	statedb, header, err := api.b.StateAndHeaderByNumberOrHash(ctx, blockNrOrHash)
+	if statedb == nil || err != nil {
+		return nil, err
+	}
+	tr, err := trie.NewStateTrie(trie.StateTrieID(header.Root), statedb.Database().TrieDB())
+	if err != nil {
+		return nil, err
+	}
+	var (
+		set    = &proofNodes{index: make(map[common.Hash]int), nodes: []hexutil.Bytes{}}
+		result = &BatchProofResult{Accounts: make([]BatchAccountResult, len(requests))}
+	)
+	for i, req := range requests {
+		accountProof := &proofRefs{set: set, refs: []int{}}
+		if err := tr.Prove(crypto.Keccak256(req.Address.Bytes()), accountProof); err != nil {
+			return nil, err
+		}
+		storageRoot := statedb.GetStorageRoot(req.Address)
+
+		var storageTrie *trie.StateTrie
+		if len(keys[i]) > 0 && storageRoot != types.EmptyRootHash && storageRoot != (common.Hash{}) {
+			id := trie.StorageTrieID(header.Root, crypto.Keccak256Hash(req.Address.Bytes()), storageRoot)
+			if storageTrie, err = trie.NewStateTrie(id, statedb.Database().TrieDB()); err != nil {
+				return nil, err
+			}
+		}
+		storageProof := make([]BatchStorageResult, len(keys[i]))
+		for j, key := range keys[i] {
+			outputKey := encodeStorageKey(key, keyLengths[i][j])
+			if storageTrie == nil {
+				storageProof[j] = BatchStorageResult{outputKey, &hexutil.Big{}, []int{}}
+				continue
+			}
+			proof := &proofRefs{set: set, refs: []int{}}
+			if err := storageTrie.Prove(crypto.Keccak256(key.Bytes()), proof); err != nil {
+				return nil, err
+			}
+			value := (*hexutil.Big)(statedb.GetState(req.Address, key).Big())
+			storageProof[j] = BatchStorageResult{outputKey, value, proof.refs}
+		}
+		result.Accounts[i] = BatchAccountResult{
+			Address:      req.Address,
+			AccountProof: accountProof.refs,
+			Balance:      (*hexutil.Big)(statedb.GetBalance(req.Address).ToBig()),
+			CodeHash:     statedb.GetCodeHash(req.Address),
+			Nonce:        hexutil.Uint64(statedb.GetNonce(req.Address)),
+			StorageHash:  storageRoot,
+			StorageProof: storageProof,
+		}
+	}
+	result.Nodes = set.nodes
+	return result, statedb.Error()
+}
+
+// maxStorageRangeSlots is the maximum number of storage slots proven at once by
+// GetStorageRangeProof.
+const maxStorageRangeSlots = 1024
+
+// StorageRangeResult is the proof of a contiguous range of storage slots of an
+// account, in the format used by snap sync.
+type StorageRangeResult struct {
+	Address      common.Address `json:"address"`
+	AccountProof []string       `json:"accountProof"`
+	StorageHash  common.Hash    `json:"storageHash"`
+	Origin       common.Hash    `json:"origin"`
+	Slots        []StorageSlot  `json:"slots"`
+	Proof        []string       `json:"proof"` // Proof of the range boundaries
+	More         bool           `json:"more"`  // Whether more slots follow the range
+}
+
+// StorageSlot is a storage slot of a proven range, keyed by the hash of the
+// slot key.
+type StorageSlot struct {
+	Hash  common.Hash   `json:"hash"`
+	Value hexutil.Bytes `json:"value"` // RLP-encoded slot value
+}
+
+// GetStorageRangeProof returns the storage slots of an account in the range
+// starting at the given slot key hash, up to maxResults slots, along with the
+// Merkle proof of the range against the storage root and of the account.
+func (api *BlockChainAPI) GetStorageRangeProof(ctx context.Context, address common.Address, origin common.Hash, maxResults int, blockNrOrHash rpc.BlockNumberOrHash) (*StorageRangeResult, error) {
+	if maxResults <= 0 || maxResults > maxStorageRangeSlots {
+		return nil, &invalidParamsError{fmt.Sprintf("maxResults must be within 1 and %d", maxStorageRangeSlots)}
+	}
+	statedb, header, err := api.b.StateAndHeaderByNumberOrHash(ctx, blockNrOrHash)
+	if statedb == nil || err != nil {
+		return nil, err
+	}
+	result := &StorageRangeResult{
+		Address:      address,
+		AccountProof: []string{},
+		StorageHash:  statedb.GetStorageRoot(address),
+		Origin:       origin,
+		Slots:        []StorageSlot{},
+		Proof:        []string{},
+	}
+	tr, err := trie.NewStateTrie(trie.StateTrieID(header.Root), statedb.Database().TrieDB())
+	if err != nil {
+		return nil, err
+	}
+	if err := tr.Prove(crypto.Keccak256(address.Bytes()), (*proofList)(&result.AccountProof)); err != nil {
+		return nil, err
+	}
+	if result.StorageHash == types.EmptyRootHash || result.StorageHash == (common.Hash{}) {
+		return result, statedb.Error()
+	}
+	id := trie.StorageTrieID(header.Root, crypto.Keccak256Hash(address.Bytes()), result.StorageHash)
+	st, err := trie.NewStateTrie(id, statedb.Database().TrieDB())
+	if err != nil {
+		return nil, err
+	}
+	proof, err := st.ProveRange(origin.Bytes(), maxResults)
+	if err != nil {
+		return nil, err
+	}
+	for i, key := range proof.Keys {
+		result.Slots = append(result.Slots, StorageSlot{Hash: common.BytesToHash(key), Value: proof.Values[i]})
+	}
+	for _, node := range proof.Proof {
+		result.Proof = append(result.Proof, hexutil.Encode(node))
+	}
+	if result.More, err = proof.Verify(result.StorageHash); err != nil {
+		return nil, err
+	}
+	return result, statedb.Error()
+}
+
 // decodeStorageKey parses a hex-encoded 32-byte hash.
 // For legacy compatibility reasons, we parse these keys leniently,
 // with the 0x prefix being optional.
@@ -497,7 +767,36 @@ func (api *BlockChainAPI) GetHeaderByHash(ctx context.Context, hash common.Hash)
 //   - When number is -4 the chain safe block is returned.
 //   - When fullTx is true all transactions in the block are returned, otherwise
 //     only the transaction hash is returned.
-func (api *BlockChainAPI) GetBlockByNumber(ctx context.Context, number rpc.BlockNumber, fullTx bool) (map[string]interface{}, error) {
+//   - When inclReceipts is true the receipts of the block are returned in the
+//     additional "receipts" field, derived from the already loaded block.
+func (api *BlockChainAPI) GetBlockByNumber(ctx context.Context, number rpc.BlockNumber, fullTx bool, inclReceipts *bool) (map[string]interface{}, error) {
+	withReceipts := inclReceipts != nil && *inclReceipts
+	if number == rpc.PendingBlockNumber && withReceipts {
+		// Retrieve the pending block and receipts together, otherwise they
+		// might belong to different pending snapshots.
+		block, receipts, _ := api.b.Pending()
+		if block == nil {
+			return nil, nil
+		}
+		if receipts == nil {
+			return nil, errors.New("pending receipts is not available")
+		}
+		response := RPCMarshalBlock(block, true, fullTx, api.b.ChainConfig())
+		marshalled, err := api.marshalBlockReceipts(block, receipts)
+		if err != nil {
+			return nil, err
+		}
+		// Pending blocks need to nil out a few fields, including the block
+		// hash referenced by the receipts.
+		for _, field := range []string{"hash", "nonce", "miner"} {
+			response[field] = nil
+		}
+		for _, receipt := range marshalled {
+			receipt["blockHash"] = nil
+		}
+		response["receipts"] = marshalled
+		return response, nil
+	}
 	block, err := api.b.BlockByNumber(ctx, number)
 	if block != nil && err == nil {
 		response := RPCMarshalBlock(block, true, fullTx, api.b.ChainConfig())
@@ -507,17 +806,31 @@ func (api *BlockChainAPI) GetBlockByNumber(ctx context.Context, number rpc.Block
 				response[field] = nil
 			}
 		}
+		if withReceipts {
+			if response["receipts"], err = api.blockReceipts(ctx, block); err != nil {
+				return nil, err
+			}
+		}
 		return response, nil
 	}
 	return nil, err
 }
 
-// GetBlockByHash returns the requested block. When fullTx is true all transactions in the block are returned in full
-// detail, otherwise only the transaction hash is returned.
-func (api *BlockChainAPI) GetBlockByHash(ctx context.Context, hash common.Hash, fullTx bool) (map[string]interface{}, error) {
+// GetBlockByHash returns the requested block.
+//   - When fullTx is true all transactions in the block are returned, otherwise
+//     only the transaction hash is returned.
+//   - When inclReceipts is true the receipts of the block are returned in the
+//     additional "receipts" field, derived from the already loaded block.
+func (api *BlockChainAPI) GetBlockByHash(ctx context.Context, hash common.Hash, fullTx bool, inclReceipts *bool) (map[string]interface{}, error) {
 	block, err := api.b.BlockByHash(ctx, hash)
 	if block != nil {
-		return RPCMarshalBlock(block, true, fullTx, api.b.ChainConfig()), nil
+		response := RPCMarshalBlock(block, true, fullTx, api.b.ChainConfig())
+		if inclReceipts != nil && *inclReceipts {
+			if response["receipts"], err = api.blockReceipts(ctx, block); err != nil {
+				return nil, err
+			}
+		}
+		return response, nil
 	}
 	return nil, err
 }
@@ -600,26 +913,33 @@ func (api *BlockChainAPI) GetStorageAt(ctx context.Context, address common.Addre
 
 // GetBlockReceipts returns the block receipts for the given block hash or number or tag.
 func (api *BlockChainAPI) GetBlockReceipts(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) ([]map[string]interface{}, error) {
-	var (
-		err      error
-		block    *types.Block
-		receipts types.Receipts
-	)
 	if blockNr, ok := blockNrOrHash.Number(); ok && blockNr == rpc.PendingBlockNumber {
-		block, receipts, _ = api.b.Pending()
+		block, receipts, _ := api.b.Pending()
 		if block == nil {
 			return nil, errors.New("pending receipts is not available")
 		}
-	} else {
-		block, err = api.b.BlockByNumberOrHash(ctx, blockNrOrHash)
-		if block == nil || err != nil {
-			return nil, err
-		}
-		receipts, err = api.b.GetReceipts(ctx, block.Hash())
-		if err != nil {
-			return nil, err
-		}
+		return api.marshalBlockReceipts(block, receipts)
+	}
+	block, err := api.b.BlockByNumberOrHash(ctx, blockNrOrHash)
+	if block == nil || err != nil {
+		return nil, err
 	}
+	return api.blockReceipts(ctx, block)
+}
+
+// blockReceipts retrieves the receipts of the given canonical or side-chain
+// block and converts them into their RPC representation.
+func (api *BlockChainAPI) blockReceipts(ctx context.Context, block *types.Block) ([]map[string]interface{}, error) {
+	receipts, err := api.b.GetReceiptsByBlock(ctx, block)
+	if err != nil {
+		return nil, err
+	}
+	return api.marshalBlockReceipts(block, receipts)
+}
+
+// marshalBlockReceipts converts the receipts of the given block into their RPC
+// representation.
+func (api *BlockChainAPI) marshalBlockReceipts(block *types.Block, receipts types.Receipts) ([]map[string]interface{}, error) {
 	txs := block.Transactions()
 	if len(txs) != len(receipts) {
 		return nil, fmt.Errorf("receipts length mismatch: %d vs %d", len(txs), len(receipts))
@@ -687,8 +1007,26 @@ func (context *ChainContext) GetHeaderByHash(hash common.Hash) *types.Header {
 	return header
 }
 
-func doCall(ctx context.Context, b Backend, args TransactionArgs, state *state.StateDB, header *types.Header, overrides *override.StateOverride, blockOverrides *override.BlockOverrides, timeout time.Duration, globalGasCap uint64) (*core.ExecutionResult, error) {
-	blockCtx := core.NewEVMBlockContext(header, NewChainContext(ctx, b), nil)
+// WarmSlots retrieves the storage slots accessed by a block, used by the warm
+// slots rules. The slots are only available if the backend can retrieve them
+// itself or exposes its database.
+func (context *ChainContext) WarmSlots(hash common.Hash, number uint64) (types.AccessList, bool) {
+	if b, ok := context.b.(interface {
+		WarmSlots(common.Hash, uint64) (types.AccessList, bool)
+	}); ok {
+		return b.WarmSlots(hash, number)
+	}
+	if b, ok := context.b.(interface{ ChainDb() ethdb.Database }); ok {
+		return rawdb.ReadWarmSlots(b.ChainDb(), hash, number)
+	}
+	return nil, false
+}
+
+func doCall(ctx context.Context, b Backend, args TransactionArgs, state *state.StateDB, header *types.Header, overrides *override.StateOverride, blockOverrides *override.BlockOverrides, gasOverrides *override.GasOverrides, timeout time.Duration, globalGasCap uint64) (*core.ExecutionResult, error) {
+	blockCtx, err := core.NewEVMBlockContext(header, NewChainContext(ctx, b), nil)
+	if err != nil {
+		return nil, err
+	}
 	if blockOverrides != nil {
 		if err := blockOverrides.Apply(&blockCtx); err != nil {
 			return nil, err
@@ -717,10 +1055,10 @@ func doCall(ctx context.Context, b Backend, args TransactionArgs, state *state.S
 	} else {
 		gp.AddGas(globalGasCap)
 	}
-	return applyMessage(ctx, b, args, state, header, timeout, gp, &blockCtx, &vm.Config{NoBaseFee: true}, precompiles)
+	return applyMessage(ctx, b, args, state, header, timeout, gp, &blockCtx, &vm.Config{NoBaseFee: true}, precompiles, gasOverrides)
 }
 
-func applyMessage(ctx context.Context, b Backend, args TransactionArgs, state *state.StateDB, header *types.Header, timeout time.Duration, gp *core.GasPool, blockContext *vm.BlockContext, vmConfig *vm.Config, precompiles vm.PrecompiledContracts) (*core.ExecutionResult, error) {
+func applyMessage(ctx context.Context, b Backend, args TransactionArgs, state *state.StateDB, header *types.Header, timeout time.Duration, gp *core.GasPool, blockContext *vm.BlockContext, vmConfig *vm.Config, precompiles vm.PrecompiledContracts, gasOverrides *override.GasOverrides) (*core.ExecutionResult, error) {
 	// Get a new instance of the EVM.
 	if err := args.CallDefaults(gp.Gas(), blockContext.BaseFee, b.ChainConfig().ChainID); err != nil {
 		return nil, err
@@ -734,10 +1072,13 @@ func applyMessage(ctx context.Context, b Backend, args TransactionArgs, state *s
 	if msg.BlobGasFeeCap != nil && msg.BlobGasFeeCap.BitLen() == 0 {
 		blockContext.BlobBaseFee = new(big.Int)
 	}
-	evm := b.GetEVM(ctx, state, header, vmConfig, blockContext)
+	evm := b.GetEVM(ctx, state, header, vmConfig, *blockContext)
 	if precompiles != nil {
 		evm.SetPrecompiles(precompiles)
 	}
+	if err := gasOverrides.Apply(evm, precompiles); err != nil {
+		return nil, err
+	}
 	res, err := applyMessageWithEVM(ctx, evm, msg, timeout, gp)
 	// If an internal state error occurred, let that have precedence. Otherwise,
 	// a "trie root missing" type of error will masquerade as e.g. "insufficient gas"
@@ -768,28 +1109,29 @@ func applyMessageWithEVM(ctx context.Context, evm *vm.EVM, msg *core.Message, ti
 	return result, nil
 }
 
-func DoCall(ctx context.Context, b Backend, args TransactionArgs, blockNrOrHash rpc.BlockNumberOrHash, overrides *override.StateOverride, blockOverrides *override.BlockOverrides, timeout time.Duration, globalGasCap uint64) (*core.ExecutionResult, error) {
+func DoCall(ctx context.Context, b Backend, args TransactionArgs, blockNrOrHash rpc.BlockNumberOrHash, overrides *override.StateOverride, blockOverrides *override.BlockOverrides, gasOverrides *override.GasOverrides, timeout time.Duration, globalGasCap uint64) (*core.ExecutionResult, error) {
 	defer func(start time.Time) { log.Debug("Executing EVM call finished", "runtime", time.Since(start)) }(time.Now())
 
 	state, header, err := b.StateAndHeaderByNumberOrHash(ctx, blockNrOrHash)
 	if state == nil || err != nil {
 		return nil, err
 	}
-	return doCall(ctx, b, args, state, header, overrides, blockOverrides, timeout, globalGasCap)
+	return doCall(ctx, b, args, state, header, overrides, blockOverrides, gasOverrides, timeout, globalGasCap)
 }
 
 // Call executes the given transaction on the state for the given block number.
 //
-// Additionally, the caller can specify a batch of contract for fields overriding.
+// Additionally, the caller can specify a batch of contract for fields overriding,
+// and a table of gas costs replacing the ones of the protocol.
 //
 // Note, this function doesn't make and changes in the state/blockchain and is
 // useful to execute and retrieve values.
-func (api *BlockChainAPI) Call(ctx context.Context, args TransactionArgs, blockNrOrHash *rpc.BlockNumberOrHash, overrides *override.StateOverride, blockOverrides *override.BlockOverrides) (hexutil.Bytes, error) {
+func (api *BlockChainAPI) Call(ctx context.Context, args TransactionArgs, blockNrOrHash *rpc.BlockNumberOrHash, overrides *override.StateOverride, blockOverrides *override.BlockOverrides, gasOverrides *override.GasOverrides) (hexutil.Bytes, error) {
 	if blockNrOrHash == nil {
 		latest := rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
 		blockNrOrHash = &latest
 	}
-	result, err := DoCall(ctx, api.b, args, *blockNrOrHash, overrides, blockOverrides, api.b.RPCEVMTimeout(), api.b.RPCGasCap())
+	result, err := DoCall(ctx, api.b, args, *blockNrOrHash, overrides, blockOverrides, gasOverrides, api.b.RPCEVMTimeout(), api.b.RPCGasCap())
 	if err != nil {
 		return nil, err
 	}
@@ -838,6 +1180,37 @@ func (api *BlockChainAPI) SimulateV1(ctx context.Context, opts simOpts, blockNrO
 	return sim.execute(ctx, opts.BlockStateCalls)
 }
 
+// CallMany executes bundles of calls in order on top of the state of the given
+// block, each call seeing the state changes of the calls before it. For every
+// call, the return data, logs and gas used are returned, or the error which
+// made the call invalid.
+//
+// Unlike SimulateV1, no blocks are assembled, making it a lighter alternative to
+// evaluate a sequence of transactions against the current chain state.
+//
+// Note, this function doesn't make any changes in the state/blockchain and is
+// useful to execute and retrieve values.
+func (api *BlockChainAPI) CallMany(ctx context.Context, bundles []callBundle, blockNrOrHash *rpc.BlockNumberOrHash, overrides *override.StateOverride) ([][]*callManyResult, error) {
+	var calls int
+	for _, bundle := range bundles {
+		calls += len(bundle.Transactions)
+	}
+	if calls == 0 {
+		return nil, &invalidParamsError{message: "empty input"}
+	} else if calls > maxCallManyCalls {
+		return nil, &clientLimitExceededError{message: "too many calls"}
+	}
+	if blockNrOrHash == nil {
+		latest := rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+		blockNrOrHash = &latest
+	}
+	state, header, err := api.b.StateAndHeaderByNumberOrHash(ctx, *blockNrOrHash)
+	if state == nil || err != nil {
+		return nil, err
+	}
+	return callMany(ctx, api.b, bundles, state, header, overrides, api.b.RPCEVMTimeout(), api.b.RPCGasCap())
+}
+
 // DoEstimateGas returns the lowest possible gas limit that allows the transaction to run
 // successfully at block `blockNrOrHash`. It returns error if the transaction would revert, or if
 // there are unexpected failures. The gas limit is capped by both `args.Gas` (if non-nil &
@@ -848,7 +1221,10 @@ func DoEstimateGas(ctx context.Context, b Backend, args TransactionArgs, blockNr
 	if state == nil || err != nil {
 		return 0, err
 	}
-	blockCtx := core.NewEVMBlockContext(header, NewChainContext(ctx, b), nil)
+	blockCtx, err := core.NewEVMBlockContext(header, NewChainContext(ctx, b), nil)
+	if err != nil {
+		return 0, err
+	}
 	if blockOverrides != nil {
 		if err := blockOverrides.Apply(&blockCtx); err != nil {
 			return 0, err
@@ -1039,60 +1415,29 @@ func newRPCTransaction(tx *types.Transaction, blockHash common.Hash, blockNumber
 			result.ChainID = (*hexutil.Big)(id)
 		}
 
-	case types.AccessListTxType:
+	default:
+		// Typed transactions are marshalled according to the fields their
+		// registered type carries.
 		al := tx.AccessList()
 		yparity := hexutil.Uint64(v.Sign())
 		result.Accesses = &al
 		result.ChainID = (*hexutil.Big)(tx.ChainId())
 		result.YParity = &yparity
 
-	case types.DynamicFeeTxType:
-		al := tx.AccessList()
-		yparity := hexutil.Uint64(v.Sign())
-		result.Accesses = &al
-		result.ChainID = (*hexutil.Big)(tx.ChainId())
-		result.YParity = &yparity
-		result.GasFeeCap = (*hexutil.Big)(tx.GasFeeCap())
-		result.GasTipCap = (*hexutil.Big)(tx.GasTipCap())
-		// if the transaction has been mined, compute the effective gas price
-		if baseFee != nil && blockHash != (common.Hash{}) {
-			// price = min(gasTipCap + baseFee, gasFeeCap)
-			result.GasPrice = (*hexutil.Big)(effectiveGasPrice(tx, baseFee))
-		} else {
-			result.GasPrice = (*hexutil.Big)(tx.GasFeeCap())
-		}
-
-	case types.BlobTxType:
-		al := tx.AccessList()
-		yparity := hexutil.Uint64(v.Sign())
-		result.Accesses = &al
-		result.ChainID = (*hexutil.Big)(tx.ChainId())
-		result.YParity = &yparity
-		result.GasFeeCap = (*hexutil.Big)(tx.GasFeeCap())
-		result.GasTipCap = (*hexutil.Big)(tx.GasTipCap())
-		// if the transaction has been mined, compute the effective gas price
-		if baseFee != nil && blockHash != (common.Hash{}) {
-			result.GasPrice = (*hexutil.Big)(effectiveGasPrice(tx, baseFee))
-		} else {
-			result.GasPrice = (*hexutil.Big)(tx.GasFeeCap())
+		if spec := types.LookupTxType(tx.Type()); spec != nil && spec.DynamicFee {
+			result.GasFeeCap = (*hexutil.Big)(tx.GasFeeCap())
+			result.GasTipCap = (*hexutil.Big)(tx.GasTipCap())
+			// if the transaction has been mined, compute the effective gas price
+			if baseFee != nil && blockHash != (common.Hash{}) {
+				// price = min(gasTipCap + baseFee, gasFeeCap)
+				result.GasPrice = (*hexutil.Big)(effectiveGasPrice(tx, baseFee))
+			} else {
+				result.GasPrice = (*hexutil.Big)(tx.GasFeeCap())
+			}
 		}
+		// Type specific fields are left empty by the transactions lacking them
 		result.MaxFeePerBlobGas = (*hexutil.Big)(tx.BlobGasFeeCap())
 		result.BlobVersionedHashes = tx.BlobHashes()
-
-	case types.SetCodeTxType:
-		al := tx.AccessList()
-		yparity := hexutil.Uint64(v.Sign())
-		result.Accesses = &al
-		result.ChainID = (*hexutil.Big)(tx.ChainId())
-		result.YParity = &yparity
-		result.GasFeeCap = (*hexutil.Big)(tx.GasFeeCap())
-		result.GasTipCap = (*hexutil.Big)(tx.GasTipCap())
-		// if the transaction has been mined, compute the effective gas price
-		if baseFee != nil && blockHash != (common.Hash{}) {
-			result.GasPrice = (*hexutil.Big)(effectiveGasPrice(tx, baseFee))
-		} else {
-			result.GasPrice = (*hexutil.Big)(tx.GasFeeCap())
-		}
 		result.AuthorizationList = tx.SetCodeAuthorizations()
 	}
 	return result
@@ -1241,34 +1586,10 @@ func (api *BlockChainAPI) Config(ctx context.Context) (*configResponse, error) {
 // If the accesslist creation fails an error is returned.
 // If the transaction itself fails, an vmErr is returned.
 func AccessList(ctx context.Context, b Backend, blockNrOrHash rpc.BlockNumberOrHash, args TransactionArgs, stateOverrides *override.StateOverride) (acl types.AccessList, gasUsed uint64, vmErr error, err error) {
-	// Retrieve the execution context
-	db, header, err := b.StateAndHeaderByNumberOrHash(ctx, blockNrOrHash)
+	db, header, blockCtx, err := accessListContext(ctx, b, blockNrOrHash, &args, stateOverrides)
 	if db == nil || err != nil {
 		return nil, 0, nil, err
 	}
-
-	// Apply state overrides immediately after StateAndHeaderByNumberOrHash.
-	// If not applied here, there could be cases where user-specified overrides (e.g., nonce)
-	// may conflict with default values from the database, leading to inconsistencies.
-	if stateOverrides != nil {
-		if err := stateOverrides.Apply(db, nil); err != nil {
-			return nil, 0, nil, err
-		}
-	}
-
-	// Ensure any missing fields are filled, extract the recipient and input data
-	if err = args.setFeeDefaults(ctx, b, header); err != nil {
-		return nil, 0, nil, err
-	}
-	if args.Nonce == nil {
-		nonce := hexutil.Uint64(db.GetNonce(args.from()))
-		args.Nonce = &nonce
-	}
-	blockCtx := core.NewEVMBlockContext(header, NewChainContext(ctx, b), nil)
-	if err = args.CallDefaults(b.RPCGasCap(), blockCtx.BaseFee, b.ChainConfig().ChainID); err != nil {
-		return nil, 0, nil, err
-	}
-
 	var to common.Address
 	if args.To != nil {
 		to = *args.To
@@ -1324,7 +1645,7 @@ func AccessList(ctx context.Context, b Backend, blockNrOrHash rpc.BlockNumberOrH
 		// Apply the transaction with the access list tracer
 		tracer := logger.NewAccessListTracer(accessList, addressesToExclude)
 		config := vm.Config{Tracer: tracer.Hooks(), NoBaseFee: true}
-		evm := b.GetEVM(ctx, statedb, header, &config, nil)
+		evm := b.GetEVM(ctx, statedb, header, &config, blockCtx)
 
 		// Lower the basefee to 0 to avoid breaking EVM
 		// invariants (basefee < feecap).
@@ -1345,6 +1666,42 @@ func AccessList(ctx context.Context, b Backend, blockNrOrHash rpc.BlockNumberOrH
 	}
 }
 
+// accessListContext retrieves the state and header to create an access list on
+// and fills the missing transaction fields.
+func accessListContext(ctx context.Context, b Backend, blockNrOrHash rpc.BlockNumberOrHash, args *TransactionArgs, stateOverrides *override.StateOverride) (*state.StateDB, *types.Header, vm.BlockContext, error) {
+	// Retrieve the execution context
+	db, header, err := b.StateAndHeaderByNumberOrHash(ctx, blockNrOrHash)
+	if db == nil || err != nil {
+		return nil, nil, vm.BlockContext{}, err
+	}
+
+	// Apply state overrides immediately after StateAndHeaderByNumberOrHash.
+	// If not applied here, there could be cases where user-specified overrides (e.g., nonce)
+	// may conflict with default values from the database, leading to inconsistencies.
+	if stateOverrides != nil {
+		if err := stateOverrides.Apply(db, nil); err != nil {
+			return nil, nil, vm.BlockContext{}, err
+		}
+	}
+
+	// Ensure any missing fields are filled, extract the recipient and input data
+	if err = args.setFeeDefaults(ctx, b, header); err != nil {
+		return nil, nil, vm.BlockContext{}, err
+	}
+	if args.Nonce == nil {
+		nonce := hexutil.Uint64(db.GetNonce(args.from()))
+		args.Nonce = &nonce
+	}
+	blockCtx, err := core.NewEVMBlockContext(header, NewChainContext(ctx, b), nil)
+	if err != nil {
+		return nil, nil, vm.BlockContext{}, err
+	}
+	if err = args.CallDefaults(b.RPCGasCap(), blockCtx.BaseFee, b.ChainConfig().ChainID); err != nil {
+		return nil, nil, vm.BlockContext{}, err
+	}
+	return db, header, blockCtx, nil
+}
+
 // TransactionAPI exposes methods for reading and creating transaction data.
 type TransactionAPI struct {
 	b         Backend
@@ -1522,6 +1879,16 @@ func MarshalReceipt(receipt *types.Receipt, blockHash common.Hash, blockNumber u
 	if receipt.Logs == nil {
 		fields["logs"] = []*types.Log{}
 	}
+	// Report why a failed transaction halted, if it was recorded at import. Halt
+	// reasons are only recorded with --history.haltreasons, and are dropped once
+	// the block is moved to the ancient store.
+	if receipt.HaltReason != "" {
+		fields["haltReason"] = receipt.HaltReason
+	}
+	// Likewise for the decoded revert reason, recorded with --history.revertreasons.
+	if receipt.RevertReason != "" {
+		fields["revertReason"] = receipt.RevertReason
+	}
 
 	if tx.Type() == types.BlobTxType {
 		fields["blobGasUsed"] = hexutil.Uint64(receipt.BlobGasUsed)
@@ -47,8 +47,10 @@ import (
 	"github.com/ethereum/go-ethereum/core/filtermaps"
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/txpool"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/core/vm/program"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/crypto/kzg4844"
 	"github.com/ethereum/go-ethereum/ethdb"
@@ -458,6 +460,7 @@ func fakeBlockHash(txh common.Hash) common.Hash {
 func newTestBackend(t *testing.T, n int, gspec *core.Genesis, engine consensus.Engine, generator func(i int, b *core.BlockGen)) *testBackend {
 	options := core.DefaultConfig().WithArchive(true)
 	options.TxLookupLimit = 0 // index all txs
+	options.VmConfig.RecordHaltReasons = true
 
 	accman, acc := newTestAccountManager(t)
 	gspec.Alloc[acc.Address] = types.Account{Balance: big.NewInt(params.Ether)}
@@ -590,15 +593,14 @@ func (b testBackend) GetReceipts(ctx context.Context, hash common.Hash) (types.R
 	receipts := rawdb.ReadReceipts(b.db, hash, header.Number.Uint64(), header.Time, b.chain.Config())
 	return receipts, nil
 }
-func (b testBackend) GetEVM(ctx context.Context, state *state.StateDB, header *types.Header, vmConfig *vm.Config, blockContext *vm.BlockContext) *vm.EVM {
+func (b testBackend) GetReceiptsByBlock(ctx context.Context, block *types.Block) (types.Receipts, error) {
+	return rawdb.ReadBlockReceipts(b.db, block, b.chain.Config()), nil
+}
+func (b testBackend) GetEVM(ctx context.Context, state *state.StateDB, header *types.Header, vmConfig *vm.Config, blockContext vm.BlockContext) *vm.EVM {
 	if vmConfig == nil {
 		vmConfig = b.chain.GetVMConfig()
 	}
-	context := core.NewEVMBlockContext(header, b.chain, nil)
-	if blockContext != nil {
-		context = *blockContext
-	}
-	return vm.NewEVM(context, state, b.chain.Config(), *vmConfig)
+	return vm.NewEVM(blockContext, state, b.chain.Config(), *vmConfig)
 }
 func (b testBackend) SubscribeChainEvent(ch chan<- core.ChainEvent) event.Subscription {
 	return b.chainFeed.Subscribe(ch)
@@ -679,6 +681,9 @@ func (b testBackend) TxPoolContentFrom(addr common.Address) ([]*types.Transactio
 func (b testBackend) SubscribeNewTxsEvent(events chan<- core.NewTxsEvent) event.Subscription {
 	panic("implement me")
 }
+func (b testBackend) SubscribeDroppedTxsEvent(events chan<- txpool.DroppedTxsEvent) event.Subscription {
+	panic("implement me")
+}
 func (b testBackend) ChainConfig() *params.ChainConfig { return b.chain.Config() }
 func (b testBackend) Engine() consensus.Engine         { return b.chain.Engine() }
 func (b testBackend) GetLogs(ctx context.Context, blockHash common.Hash, number uint64) ([][]*types.Log, error) {
@@ -1025,12 +1030,14 @@ func TestCall(t *testing.T) {
 		b.SetPoS()
 	}))
 	randomAccounts := newAccounts(3)
+	identity := common.BytesToAddress([]byte{0x4})
 	var testSuite = []struct {
 		name           string
 		blockNumber    rpc.BlockNumber
 		overrides      override.StateOverride
 		call           TransactionArgs
 		blockOverrides override.BlockOverrides
+		gasOverrides   override.GasOverrides
 		expectErr      error
 		want           string
 	}{
@@ -1285,9 +1292,50 @@ func TestCall(t *testing.T) {
 			},
 			expectErr: errors.New(`block override "withdrawals" is not supported for this RPC method`),
 		},
+		// Gas cost overrides, the code returns the gas left after GAS
+		{
+			name:        "opcode-gas-override",
+			blockNumber: rpc.LatestBlockNumber,
+			call: TransactionArgs{
+				From: &accounts[1].addr,
+				To:   &randomAccounts[2].addr,
+				Gas:  newUint64(100000),
+			},
+			overrides: override.StateOverride{
+				randomAccounts[2].addr: {
+					Code: hex2Bytes("5a60005260206000f3"),
+				},
+			},
+			gasOverrides: override.GasOverrides{
+				Opcodes: map[string]hexutil.Uint64{"GAS": 1000},
+			},
+			want: "0x00000000000000000000000000000000000000000000000000000000000130b0",
+		},
+		{
+			name:        "precompile-gas-override",
+			blockNumber: rpc.LatestBlockNumber,
+			call: TransactionArgs{
+				From: &accounts[1].addr,
+				To:   &identity,
+				Gas:  newUint64(50000),
+			},
+			gasOverrides: override.GasOverrides{
+				Precompiles: map[common.Address]hexutil.Uint64{identity: 1000000},
+			},
+			expectErr: vm.ErrOutOfGas,
+		},
+		{
+			name:        "unknown-opcode-gas-override",
+			blockNumber: rpc.LatestBlockNumber,
+			call:        TransactionArgs{From: &accounts[1].addr},
+			gasOverrides: override.GasOverrides{
+				Opcodes: map[string]hexutil.Uint64{"FOO": 1},
+			},
+			expectErr: errors.New(`unknown opcode "FOO"`),
+		},
 	}
 	for _, tc := range testSuite {
-		result, err := api.Call(context.Background(), tc.call, &rpc.BlockNumberOrHash{BlockNumber: &tc.blockNumber}, &tc.overrides, &tc.blockOverrides)
+		result, err := api.Call(context.Background(), tc.call, &rpc.BlockNumberOrHash{BlockNumber: &tc.blockNumber}, &tc.overrides, &tc.blockOverrides, &tc.gasOverrides)
 		if tc.expectErr != nil {
 			if err == nil {
 				t.Errorf("test %s: want error %v, have nothing", tc.name, tc.expectErr)
@@ -1311,6 +1359,134 @@ func TestCall(t *testing.T) {
 	}
 }
 
+func TestGetProofBatch(t *testing.T) {
+	t.Parallel()
+
+	var (
+		accounts = newAccounts(2)
+		contract = common.HexToAddress("0x0000000000000000000000000000000000000bee")
+		genesis  = &core.Genesis{
+			Config: params.MergedTestChainConfig,
+			Alloc: types.GenesisAlloc{
+				accounts[0].addr: {Balance: big.NewInt(params.Ether)},
+				accounts[1].addr: {Balance: big.NewInt(params.Ether)},
+				contract: {Code: []byte{0x00}, Storage: map[common.Hash]common.Hash{
+					{0x01}: {0x01},
+					{0x02}: {0x02},
+				}},
+			},
+		}
+	)
+	api := NewBlockChainAPI(newTestBackend(t, 1, genesis, beacon.New(ethash.NewFaker()), func(i int, b *core.BlockGen) {
+		b.SetPoS()
+	}))
+	var (
+		latest   = rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+		slots    = []string{common.Hash{0x01}.Hex(), common.Hash{0x02}.Hex(), common.Hash{0x03}.Hex()}
+		requests = []ProofRequest{
+			{Address: accounts[0].addr},
+			{Address: accounts[1].addr},
+			{Address: contract, StorageKeys: slots},
+		}
+	)
+	batch, err := api.GetProofBatch(context.Background(), requests, latest)
+	if err != nil {
+		t.Fatalf("failed to get batch proof: %v", err)
+	}
+	// Expanding the node references must yield the individual proofs
+	expand := func(refs []int) []string {
+		proof := make([]string, len(refs))
+		for i, ref := range refs {
+			proof[i] = batch.Nodes[ref].String()
+		}
+		return proof
+	}
+	var total int
+	for i, req := range requests {
+		want, err := api.GetProof(context.Background(), req.Address, req.StorageKeys, latest)
+		if err != nil {
+			t.Fatalf("failed to get proof of %x: %v", req.Address, err)
+		}
+		have := batch.Accounts[i]
+		if !reflect.DeepEqual(expand(have.AccountProof), want.AccountProof) {
+			t.Errorf("account %d: wrong account proof", i)
+		}
+		if have.StorageHash != want.StorageHash || have.Balance.ToInt().Cmp(want.Balance.ToInt()) != 0 {
+			t.Errorf("account %d: wrong account fields", i)
+		}
+		total += len(want.AccountProof)
+		for j, slot := range want.StorageProof {
+			if have.StorageProof[j].Key != slot.Key || have.StorageProof[j].Value.ToInt().Cmp(slot.Value.ToInt()) != 0 {
+				t.Errorf("account %d slot %d: wrong slot", i, j)
+			}
+			if !reflect.DeepEqual(expand(have.StorageProof[j].Proof), slot.Proof) {
+				t.Errorf("account %d slot %d: wrong storage proof", i, j)
+			}
+			total += len(slot.Proof)
+		}
+	}
+	// The account trie root is shared by all proofs
+	if len(batch.Nodes) >= total {
+		t.Errorf("proof nodes not deduplicated: %d nodes for %d proof entries", len(batch.Nodes), total)
+	}
+}
+
+func TestCallMany(t *testing.T) {
+	t.Parallel()
+
+	var (
+		accounts = newAccounts(3)
+		logger   = common.HexToAddress("0x0000000000000000000000000000000000000bee")
+		genesis  = &core.Genesis{
+			Config: params.MergedTestChainConfig,
+			Alloc: types.GenesisAlloc{
+				accounts[0].addr: {Balance: big.NewInt(params.Ether)},
+				accounts[1].addr: {Balance: big.NewInt(params.Ether)},
+				// Emits an empty LOG0
+				logger: {Code: common.Hex2Bytes("60006000a000")},
+			},
+		}
+		value = (*hexutil.Big)(big.NewInt(params.Ether / 10 * 6))
+	)
+	api := NewBlockChainAPI(newTestBackend(t, 1, genesis, beacon.New(ethash.NewFaker()), func(i int, b *core.BlockGen) {
+		b.SetPoS()
+	}))
+	bundles := []callBundle{
+		{Transactions: []TransactionArgs{
+			{From: &accounts[0].addr, To: &accounts[1].addr, Value: value},
+			// Insufficient funds after the first transfer
+			{From: &accounts[0].addr, To: &accounts[1].addr, Value: value},
+		}},
+		{Transactions: []TransactionArgs{
+			// Only possible with the funds received in the first bundle
+			{From: &accounts[1].addr, To: &accounts[2].addr, Value: (*hexutil.Big)(big.NewInt(params.Ether / 10 * 15))},
+			{From: &accounts[2].addr, To: &logger},
+		}},
+	}
+	latest := rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+	results, err := api.CallMany(context.Background(), bundles, &latest, nil)
+	if err != nil {
+		t.Fatalf("failed to execute calls: %v", err)
+	}
+	if len(results) != 2 || len(results[0]) != 2 || len(results[1]) != 2 {
+		t.Fatalf("wrong result layout: %v", results)
+	}
+	for _, res := range []*callManyResult{results[0][0], results[1][0]} {
+		if res.Error != nil || res.GasUsed != hexutil.Uint64(params.TxGas) {
+			t.Errorf("wrong transfer result: gas %d, error %v", res.GasUsed, res.Error)
+		}
+	}
+	if res := results[0][1]; res.Error == nil || res.Error.Code != errCodeInsufficientFunds {
+		t.Errorf("wrong error for invalid call: %v", res.Error)
+	}
+	if res := results[1][1]; res.Error != nil || len(res.Logs) != 1 || res.Logs[0].Address != logger {
+		t.Errorf("wrong logs: %v, error %v", res.Logs, res.Error)
+	}
+	if _, err := api.CallMany(context.Background(), []callBundle{{}}, &latest, nil); err == nil {
+		t.Error("expected error for empty input")
+	}
+}
+
 func TestSimulateV1(t *testing.T) {
 	t.Parallel()
 	// Initialize test accounts
@@ -3268,12 +3444,13 @@ func TestRPCGetBlockOrHeader(t *testing.T) {
 	pendingHash := backend.pending.Hash()
 
 	var testSuite = []struct {
-		blockNumber rpc.BlockNumber
-		blockHash   *common.Hash
-		fullTx      bool
-		reqHeader   bool
-		file        string
-		expectErr   error
+		blockNumber  rpc.BlockNumber
+		blockHash    *common.Hash
+		fullTx       bool
+		inclReceipts bool
+		reqHeader    bool
+		file         string
+		expectErr    error
 	}{
 		// 0. latest header
 		{
@@ -3423,9 +3600,57 @@ func TestRPCGetBlockOrHeader(t *testing.T) {
 			fullTx:    true,
 			file:      "hash-pending-fullTx",
 		},
+		// 26. genesis block + receipts
+		{
+			blockNumber:  rpc.BlockNumber(0),
+			inclReceipts: true,
+			file:         "number-0-receipts",
+		},
+		// 27. #1 block + receipts
+		{
+			blockNumber:  rpc.BlockNumber(1),
+			inclReceipts: true,
+			file:         "number-1-receipts",
+		},
+		// 28. latest-1 block + fullTx + receipts
+		{
+			blockNumber:  rpc.BlockNumber(9),
+			fullTx:       true,
+			inclReceipts: true,
+			file:         "number-latest-1-fullTx-receipts",
+		},
+		// 29. pending block + receipts
+		{
+			blockNumber:  rpc.PendingBlockNumber,
+			inclReceipts: true,
+			file:         "tag-pending-receipts",
+		},
+		// 30. genesis block by hash + receipts
+		{
+			blockHash:    &blockHashes[0],
+			inclReceipts: true,
+			file:         "hash-genesis-receipts",
+		},
+		// 31. #1 block by hash + fullTx + receipts
+		{
+			blockHash:    &blockHashes[1],
+			fullTx:       true,
+			inclReceipts: true,
+			file:         "hash-1-fullTx-receipts",
+		},
+		// 32. unknown hash + receipts
+		{
+			blockHash:    &common.Hash{},
+			inclReceipts: true,
+			file:         "hash-empty-receipts",
+		},
 	}
 
 	for i, tt := range testSuite {
+		query := rpc.BlockNumberOrHashWithNumber(tt.blockNumber)
+		if tt.blockHash != nil {
+			query = rpc.BlockNumberOrHashWithHash(*tt.blockHash, false)
+		}
 		var (
 			result map[string]interface{}
 			err    error
@@ -3436,7 +3661,7 @@ func TestRPCGetBlockOrHeader(t *testing.T) {
 				result = api.GetHeaderByHash(context.Background(), *tt.blockHash)
 				rpc = "eth_getHeaderByHash"
 			} else {
-				result, err = api.GetBlockByHash(context.Background(), *tt.blockHash, tt.fullTx)
+				result, err = api.GetBlockByHash(context.Background(), *tt.blockHash, tt.fullTx, &tt.inclReceipts)
 				rpc = "eth_getBlockByHash"
 			}
 		} else {
@@ -3444,7 +3669,7 @@ func TestRPCGetBlockOrHeader(t *testing.T) {
 				result, err = api.GetHeaderByNumber(context.Background(), tt.blockNumber)
 				rpc = "eth_getHeaderByNumber"
 			} else {
-				result, err = api.GetBlockByNumber(context.Background(), tt.blockNumber, tt.fullTx)
+				result, err = api.GetBlockByNumber(context.Background(), tt.blockNumber, tt.fullTx, &tt.inclReceipts)
 				rpc = "eth_getBlockByNumber"
 			}
 		}
@@ -3464,6 +3689,17 @@ func TestRPCGetBlockOrHeader(t *testing.T) {
 		}
 
 		testRPCResponseWithFile(t, i, result, rpc, tt.file)
+
+		// Receipts embedded in the block must match the standalone ones. The
+		// pending block is excluded as its receipts hide the block hash.
+		if tt.inclReceipts && result != nil && result["hash"] != nil {
+			receipts, err := api.GetBlockReceipts(context.Background(), query)
+			if err != nil {
+				t.Errorf("test %d: failed to retrieve block receipts: %v", i, err)
+				continue
+			}
+			require.Equalf(t, receipts, result["receipts"], "test %d: embedded receipts mismatch", i)
+		}
 	}
 }
 
@@ -3859,8 +4095,9 @@ func TestEstimateGasWithMovePrecompile(t *testing.T) {
 	if err != nil {
 		t.Fatalf("EstimateGas failed: %v", err)
 	}
-	if gas != 21366 {
-		t.Fatalf("mismatched gas: %d, want 21366", gas)
+	// The precompile execution fits within the EIP-7623 calldata floor.
+	if gas != 21200 {
+		t.Fatalf("mismatched gas: %d, want 21200", gas)
 	}
 }
 
@@ -4054,3 +4291,48 @@ func TestSendRawTransactionSync_Timeout(t *testing.T) {
 		t.Fatalf("expected ErrorData=%s, got %v", want, got)
 	}
 }
+
+func TestCreateAccessListV2(t *testing.T) {
+	t.Parallel()
+
+	// The caller calls the callee, which reads slots 0 and 1. The list only
+	// covers the callee, the caller being warm as the transaction recipient.
+	var (
+		accounts = newAccounts(1)
+		caller   = common.HexToAddress("0xc0ffee")
+		callee   = common.HexToAddress("0xbeef")
+		genesis  = &core.Genesis{
+			Config: params.MergedTestChainConfig,
+			Alloc: types.GenesisAlloc{
+				accounts[0].addr: {Balance: big.NewInt(params.Ether)},
+				callee: {Code: program.New().
+					Push(0).Op(vm.SLOAD).Op(vm.POP).
+					Push(1).Op(vm.SLOAD).Op(vm.STOP).Bytes()},
+				caller: {Code: program.New().
+					Call(nil, callee, big.NewInt(0), 0, 0, 0, 0).Op(vm.STOP).Bytes()},
+			},
+		}
+		backend = newTestBackend(t, 1, genesis, beacon.New(ethash.NewFaker()), nil)
+		api     = NewBlockChainAPI(backend)
+		gas     = hexutil.Uint64(100000)
+		args    = TransactionArgs{From: &accounts[0].addr, To: &caller, Gas: &gas}
+	)
+	report, err := api.CreateAccessListV2(context.Background(), args, nil, nil)
+	require.NoError(t, err)
+	require.Empty(t, report.Error)
+
+	// The order of the storage keys in the list is unspecified.
+	require.Len(t, *report.Accesslist, 1)
+	require.Equal(t, callee, (*report.Accesslist)[0].Address)
+	require.ElementsMatch(t, []common.Hash{{}, common.HexToHash("0x1")}, (*report.Accesslist)[0].StorageKeys)
+	require.Equal(t, hexutil.Uint64(1), report.ColdAccounts)
+	require.Equal(t, hexutil.Uint64(2), report.ColdSlots)
+	require.Less(t, report.GasUsed, report.GasUsedWithoutList)
+	require.NotZero(t, report.DataSizeDelta)
+
+	require.Len(t, report.Contracts, 2)
+	require.Equal(t, caller, report.Contracts[0].Address)
+	require.Equal(t, callee, report.Contracts[1].Address)
+	require.Equal(t, hexutil.Uint64(2), report.Contracts[1].StorageKeys)
+	require.Equal(t, hexutil.Uint64(2*params.WarmStorageReadCostEIP2929+3+2+3), report.Contracts[1].GasUsed)
+}
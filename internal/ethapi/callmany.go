@@ -0,0 +1,187 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package ethapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	gomath "math"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/internal/ethapi/override"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// maxCallManyCalls is the maximum number of calls, across all bundles, that
+// can be executed in a single eth_callMany request.
+const maxCallManyCalls = 1024
+
+// callBundle is a batch of calls executed sequentially in the context of the
+// same block, optionally with some of the block fields overridden.
+type callBundle struct {
+	Transactions   []TransactionArgs        `json:"transactions"`
+	BlockOverrides *override.BlockOverrides `json:"blockOverride"`
+}
+
+// callManyResult is the result of a call executed by eth_callMany.
+type callManyResult struct {
+	ReturnValue hexutil.Bytes  `json:"returnData"`
+	Logs        []*types.Log   `json:"logs"`
+	GasUsed     hexutil.Uint64 `json:"gasUsed"`
+	BlobGasUsed hexutil.Uint64 `json:"blobGasUsed,omitempty"`
+	Status      hexutil.Uint64 `json:"status"`
+	Error       *callError     `json:"error,omitempty"`
+}
+
+func (r *callManyResult) MarshalJSON() ([]byte, error) {
+	type callManyResultAlias callManyResult
+	// Marshal logs to be an empty array instead of nil when empty
+	if r.Logs == nil {
+		r.Logs = []*types.Log{}
+	}
+	return json.Marshal((*callManyResultAlias)(r))
+}
+
+// callMany executes the calls of the given bundles in order on top of the
+// given state. Every call sees the state changes of the calls before it.
+//
+// Calls which are invalid, e.g. due to a wrong nonce or insufficient funds, are
+// reported in their result and don't change the state. The execution is only
+// aborted if the request is cancelled or times out.
+func callMany(ctx context.Context, b Backend, bundles []callBundle, state *state.StateDB, header *types.Header, overrides *override.StateOverride, timeout time.Duration, gasCap uint64) ([][]*callManyResult, error) {
+	baseCtx, err := core.NewEVMBlockContext(header, NewChainContext(ctx, b), nil)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		rules       = b.ChainConfig().Rules(baseCtx.BlockNumber, baseCtx.Random != nil, baseCtx.Time)
+		precompiles = vm.ActivePrecompiledContracts(rules)
+	)
+	if err := overrides.Apply(state, precompiles); err != nil {
+		return nil, err
+	}
+	// Setup context so it may be cancelled the calls have completed
+	// or, in case of unmetered gas, setup a context with a timeout.
+	var cancel context.CancelFunc
+	if timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+	} else {
+		ctx, cancel = context.WithCancel(ctx)
+	}
+	defer cancel()
+
+	// The gas cap applies to all the calls together.
+	if gasCap == 0 {
+		gasCap = gomath.MaxUint64
+	}
+	var (
+		gp      = new(core.GasPool).AddGas(gasCap)
+		results = make([][]*callManyResult, len(bundles))
+		txIndex int
+	)
+	for i, bundle := range bundles {
+		blockCtx := baseCtx
+		if err := bundle.BlockOverrides.Apply(&blockCtx); err != nil {
+			return nil, err
+		}
+		results[i] = make([]*callManyResult, len(bundle.Transactions))
+		for j, args := range bundle.Transactions {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			if err := args.CallDefaults(gp.Gas(), blockCtx.BaseFee, b.ChainConfig().ChainID); err != nil {
+				return nil, err
+			}
+			var (
+				msg     = args.ToMessage(blockCtx.BaseFee, true)
+				txHash  = args.ToTransaction(types.DynamicFeeTxType).Hash()
+				callCtx = blockCtx
+			)
+			// Lower the basefee to 0 to avoid breaking EVM
+			// invariants (basefee < feecap).
+			if msg.GasPrice.Sign() == 0 {
+				callCtx.BaseFee = new(big.Int)
+			}
+			if msg.BlobGasFeeCap != nil && msg.BlobGasFeeCap.BitLen() == 0 {
+				callCtx.BlobBaseFee = new(big.Int)
+			}
+			state.SetTxContext(txHash, txIndex)
+			txIndex++
+
+			var (
+				snapshot = state.Snapshot()
+				gas      = gp.Gas()
+				evm      = b.GetEVM(ctx, state, header, &vm.Config{NoBaseFee: true}, callCtx)
+			)
+			evm.SetPrecompiles(precompiles)
+			result, err := applyMessageWithEVM(ctx, evm, msg, timeout, gp)
+			if evm.Cancelled() {
+				return nil, err
+			}
+			// If an internal state error occurred, let that have precedence.
+			if err := state.Error(); err != nil {
+				return nil, err
+			}
+			if err != nil {
+				// Undo any partial change of the invalid call, e.g. the gas
+				// purchased before the intrinsic gas check failed.
+				state.RevertToSnapshot(snapshot)
+				gp.SetGas(gas)
+
+				txErr := txValidationError(err)
+				results[i][j] = &callManyResult{
+					Status: hexutil.Uint64(types.ReceiptStatusFailed),
+					Error:  &callError{Message: txErr.Message, Code: txErr.Code},
+				}
+				continue
+			}
+			state.Finalise(true)
+			results[i][j] = newCallManyResult(result, msg, state.GetLogs(txHash, callCtx.BlockNumber.Uint64(), common.Hash{}, callCtx.Time))
+		}
+	}
+	return results, nil
+}
+
+// newCallManyResult assembles the result of an executed call.
+func newCallManyResult(result *core.ExecutionResult, msg *core.Message, logs []*types.Log) *callManyResult {
+	res := &callManyResult{
+		ReturnValue: result.Return(),
+		Logs:        logs,
+		GasUsed:     hexutil.Uint64(result.UsedGas),
+		BlobGasUsed: hexutil.Uint64(len(msg.BlobHashes) * params.BlobTxBlobGasPerBlob),
+		Status:      hexutil.Uint64(types.ReceiptStatusSuccessful),
+	}
+	if result.Failed() {
+		res.Status = hexutil.Uint64(types.ReceiptStatusFailed)
+		if errors.Is(result.Err, vm.ErrExecutionReverted) {
+			// If the result contains a revert reason, try to unpack it.
+			revertErr := newRevertError(result.Revert())
+			res.Error = &callError{Message: revertErr.Error(), Code: errCodeReverted, Data: revertErr.ErrorData().(string)}
+		} else {
+			res.Error = &callError{Message: result.Err.Error(), Code: errCodeVMError}
+		}
+	}
+	return res
+}
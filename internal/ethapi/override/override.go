@@ -119,6 +119,43 @@ func (diff *StateOverride) Apply(statedb *state.StateDB, precompiles vm.Precompi
 	return nil
 }
 
+// GasOverrides is a table of gas costs replacing the ones of the protocol during
+// the execution of a message call, for evaluating repricing proposals.
+type GasOverrides struct {
+	// Opcodes maps opcode names, e.g. "SLOAD", to their constant gas cost. The
+	// dynamic part of the costs is not affected.
+	Opcodes map[string]hexutil.Uint64 `json:"opcodes"`
+
+	// Precompiles maps precompile addresses to their gas cost, which replaces
+	// the input dependent cost.
+	Precompiles map[common.Address]hexutil.Uint64 `json:"precompiles"`
+}
+
+// Apply replaces the gas costs of the EVM, whose precompiles are the given set.
+func (o *GasOverrides) Apply(evm *vm.EVM, precompiles vm.PrecompiledContracts) error {
+	if o == nil {
+		return nil
+	}
+	costs := make(map[vm.OpCode]uint64, len(o.Opcodes))
+	for name, gas := range o.Opcodes {
+		op := vm.StringToOp(name)
+		if op.String() != name {
+			return fmt.Errorf("unknown opcode %q", name)
+		}
+		costs[op] = uint64(gas)
+	}
+	prices := make(map[common.Address]uint64, len(o.Precompiles))
+	for addr, gas := range o.Precompiles {
+		if _, ok := precompiles[addr]; !ok {
+			return fmt.Errorf("account %s is not a precompile", addr.Hex())
+		}
+		prices[addr] = uint64(gas)
+	}
+	evm.SetConstantGas(costs)
+	evm.SetPrecompileGas(prices)
+	return nil
+}
+
 // BlockOverrides is a set of header fields to override.
 type BlockOverrides struct {
 	Number        *hexutil.Big
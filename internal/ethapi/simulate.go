@@ -30,9 +30,11 @@ import (
 	"github.com/ethereum/go-ethereum/consensus/misc/eip1559"
 	"github.com/ethereum/go-ethereum/consensus/misc/eip4844"
 	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/state"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/internal/ethapi/override"
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/rpc"
@@ -160,6 +162,11 @@ type simulator struct {
 	traceTransfers bool
 	validate       bool
 	fullTx         bool
+
+	// warmSlots holds the storage slots accessed by the simulated blocks, for
+	// the warm slots rules. As the state is not committed between blocks, the
+	// slots accessed by earlier simulated blocks are included too.
+	warmSlots map[common.Hash]types.AccessList
 }
 
 // execute runs the simulation of a series of blocks.
@@ -200,6 +207,12 @@ func (sim *simulator) execute(ctx context.Context, blocks []simBlock) ([]*simBlo
 			return nil, err
 		}
 		headers[bi] = result.Header()
+		if sim.chainConfig.IsWarmSlots(result.Number(), result.Time()) {
+			if sim.warmSlots == nil {
+				sim.warmSlots = make(map[common.Hash]types.AccessList)
+			}
+			sim.warmSlots[result.Hash()] = sim.state.AccessedSlots()
+		}
 		results[bi] = &simBlockResult{fullTx: sim.fullTx, chainConfig: sim.chainConfig, Block: result, Calls: callResults, senders: senders}
 		parent = result.Header()
 	}
@@ -229,7 +242,10 @@ func (sim *simulator) processBlock(ctx context.Context, block *simBlock, header,
 		}
 		header.ExcessBlobGas = &excess
 	}
-	blockContext := core.NewEVMBlockContext(header, sim.newSimulatedChainContext(ctx, headers), nil)
+	blockContext, err := core.NewEVMBlockContext(header, sim.newSimulatedChainContext(ctx, headers), nil)
+	if err != nil {
+		return nil, nil, nil, err
+	}
 	if block.BlockOverrides.BlobBaseFee != nil {
 		blockContext.BlobBaseFee = block.BlockOverrides.BlobBaseFee.ToInt()
 	}
@@ -264,11 +280,8 @@ func (sim *simulator) processBlock(ctx context.Context, block *simBlock, header,
 	if precompiles != nil {
 		evm.SetPrecompiles(precompiles)
 	}
-	if sim.chainConfig.IsPrague(header.Number, header.Time) || sim.chainConfig.IsVerkle(header.Number, header.Time) {
-		core.ProcessParentBlockHash(header.ParentHash, evm)
-	}
-	if header.ParentBeaconRoot != nil {
-		core.ProcessBeaconBlockRoot(*header.ParentBeaconRoot, evm)
+	if err := core.ProcessPreBlockActions(header, evm); err != nil {
+		return nil, nil, nil, err
 	}
 	var allLogs []*types.Log
 	for i, call := range block.Calls {
@@ -324,22 +337,10 @@ func (sim *simulator) processBlock(ctx context.Context, block *simBlock, header,
 	if sim.chainConfig.IsCancun(header.Number, header.Time) {
 		header.BlobGasUsed = &blobGasUsed
 	}
-	var requests [][]byte
 	// Process EIP-7685 requests
-	if sim.chainConfig.IsPrague(header.Number, header.Time) {
-		requests = [][]byte{}
-		// EIP-6110
-		if err := core.ParseDepositLogs(&requests, allLogs, sim.chainConfig); err != nil {
-			return nil, nil, nil, err
-		}
-		// EIP-7002
-		if err := core.ProcessWithdrawalQueue(&requests, evm); err != nil {
-			return nil, nil, nil, err
-		}
-		// EIP-7251
-		if err := core.ProcessConsolidationQueue(&requests, evm); err != nil {
-			return nil, nil, nil, err
-		}
+	requests, err := core.ProcessPostBlockActions(header, allLogs, evm)
+	if err != nil {
+		return nil, nil, nil, err
 	}
 	if requests != nil {
 		reqHash := types.CalcRequestsHash(requests)
@@ -508,13 +509,14 @@ func (sim *simulator) makeHeaders(blocks []simBlock) ([]*types.Header, error) {
 }
 
 func (sim *simulator) newSimulatedChainContext(ctx context.Context, headers []*types.Header) *ChainContext {
-	return NewChainContext(ctx, &simBackend{base: sim.base, b: sim.b, headers: headers})
+	return NewChainContext(ctx, &simBackend{base: sim.base, b: sim.b, headers: headers, warmSlots: sim.warmSlots})
 }
 
 type simBackend struct {
-	b       ChainContextBackend
-	base    *types.Header
-	headers []*types.Header
+	b         ChainContextBackend
+	base      *types.Header
+	headers   []*types.Header
+	warmSlots map[common.Hash]types.AccessList
 }
 
 func (b *simBackend) Engine() consensus.Engine {
@@ -561,3 +563,15 @@ func (b *simBackend) HeaderByHash(ctx context.Context, hash common.Hash) (*types
 func (b *simBackend) CurrentHeader() *types.Header {
 	return b.b.CurrentHeader()
 }
+
+// WarmSlots retrieves the storage slots accessed by a simulated block, or by a
+// block of the chain if the backend exposes its database.
+func (b *simBackend) WarmSlots(hash common.Hash, number uint64) (types.AccessList, bool) {
+	if slots, ok := b.warmSlots[hash]; ok {
+		return slots, true
+	}
+	if db, ok := b.b.(interface{ ChainDb() ethdb.Database }); ok {
+		return rawdb.ReadWarmSlots(db.ChainDb(), hash, number)
+	}
+	return nil, false
+}
@@ -0,0 +1,240 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package ethapi
+
+import (
+	"container/heap"
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/consensus/misc/eip1559"
+	"github.com/ethereum/go-ethereum/consensus/misc/eip4844"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// txInspection explains the state of a transaction in the pool and estimates
+// when it is going to be included.
+type txInspection struct {
+	Hash       common.Hash    `json:"hash"`
+	From       common.Address `json:"from"`
+	Nonce      hexutil.Uint64 `json:"nonce"`
+	Status     string         `json:"status"`     // pending or queued
+	FirstSeen  hexutil.Uint64 `json:"firstSeen"`  // Unix time the transaction was first seen
+	TimeInPool hexutil.Uint64 `json:"timeInPool"` // Seconds spent in the pool
+
+	// Reasons the transaction can't be included in the next block, if any
+	Reasons []string `json:"reasons"`
+
+	// Inclusion estimate, omitted if the transaction can't be included
+	EffectiveTip    *hexutil.Big    `json:"effectiveTip,omitempty"`    // Miner tip at the next block base fee
+	Position        *hexutil.Uint64 `json:"position,omitempty"`        // Executable transactions ordered before it
+	GasAhead        *hexutil.Uint64 `json:"gasAhead,omitempty"`        // Gas of the transactions ordered before it
+	EstimatedBlocks *hexutil.Uint64 `json:"estimatedBlocks,omitempty"` // Blocks until inclusion if blocks are full
+}
+
+// InspectTx explains why a pooled transaction is or isn't executable, where it
+// stands in the block building order and estimates how many blocks it takes to
+// be included. The estimate assumes the block builder orders transactions by
+// effective tip and that blocks are filled up to the current gas limit with the
+// currently pooled transactions. Nil is returned if the transaction isn't in the
+// pool.
+func (api *TxPoolAPI) InspectTx(ctx context.Context, hash common.Hash) (*txInspection, error) {
+	tx := api.b.GetPoolTransaction(hash)
+	if tx == nil {
+		return nil, nil
+	}
+	signer := types.LatestSigner(api.b.ChainConfig())
+	from, err := types.Sender(signer, tx)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := api.b.GetPoolNonce(ctx, from)
+	if err != nil {
+		return nil, err
+	}
+	pending, queued := api.b.TxPoolContent()
+	res := inspectPoolTx(api.b.ChainConfig(), api.b.CurrentHeader(), tx, from, nonce, pending, queued, time.Now())
+
+	// Check the balance of the sender against the cost of the transaction and of
+	// all the ones before it
+	state, _, err := api.b.StateAndHeaderByNumber(ctx, rpc.LatestBlockNumber)
+	if state == nil || err != nil {
+		return nil, err
+	}
+	cost := new(big.Int)
+	for _, list := range [][]*types.Transaction{pending[from], queued[from]} {
+		for _, ptx := range list {
+			if ptx.Nonce() <= tx.Nonce() {
+				cost.Add(cost, ptx.Cost())
+			}
+		}
+	}
+	if balance := state.GetBalance(from).ToBig(); balance.Cmp(cost) < 0 {
+		res.Reasons = append(res.Reasons, fmt.Sprintf("insufficient balance: have %v, want %v", balance, cost))
+	}
+	return res, nil
+}
+
+// inspectPoolTx inspects a pooled transaction against the pool content and the
+// current chain head. The nonce is the next executable nonce of the sender.
+func inspectPoolTx(config *params.ChainConfig, head *types.Header, tx *types.Transaction, from common.Address, nonce uint64, pending, queued map[common.Address][]*types.Transaction, now time.Time) *txInspection {
+	res := &txInspection{
+		Hash:       tx.Hash(),
+		From:       from,
+		Nonce:      hexutil.Uint64(tx.Nonce()),
+		Status:     "queued",
+		FirstSeen:  hexutil.Uint64(tx.Time().Unix()),
+		TimeInPool: hexutil.Uint64(now.Sub(tx.Time()) / time.Second),
+		Reasons:    []string{},
+	}
+	for _, ptx := range pending[from] {
+		if ptx.Hash() == tx.Hash() {
+			res.Status = "pending"
+		}
+	}
+	if res.Status == "queued" && tx.Nonce() > nonce {
+		res.Reasons = append(res.Reasons, fmt.Sprintf("nonce gap: next executable nonce %d", nonce))
+	}
+	// Check the fees against the ones of the next block
+	next := new(big.Int).Add(head.Number, common.Big1)
+
+	var baseFee *big.Int
+	if config.IsLondon(next) {
+		baseFee = eip1559.CalcBaseFee(config, head)
+		if tx.GasFeeCapIntCmp(baseFee) < 0 {
+			res.Reasons = append(res.Reasons, fmt.Sprintf("fee cap below base fee: have %v, want %v", tx.GasFeeCap(), baseFee))
+		}
+	}
+	if tx.Type() == types.BlobTxType && head.ExcessBlobGas != nil {
+		if blobFee := eip4844.CalcBlobFee(config, head); tx.BlobGasFeeCapIntCmp(blobFee) < 0 {
+			res.Reasons = append(res.Reasons, fmt.Sprintf("blob fee cap below blob base fee: have %v, want %v", tx.BlobGasFeeCap(), blobFee))
+		}
+	}
+	// Check the size of the transaction against the block limits
+	if tx.Gas() > head.GasLimit {
+		res.Reasons = append(res.Reasons, fmt.Sprintf("gas above block gas limit: have %d, want %d", tx.Gas(), head.GasLimit))
+	}
+	if config.IsOsaka(next, head.Time) && tx.Gas() > params.MaxTxGas {
+		res.Reasons = append(res.Reasons, fmt.Sprintf("gas above transaction gas cap: have %d, want %d", tx.Gas(), params.MaxTxGas))
+	}
+	if tx.Type() == types.BlobTxType && config.IsCancun(next, head.Time) {
+		if max := eip4844.MaxBlobGasPerBlock(config, head.Time); tx.BlobGas() > max {
+			res.Reasons = append(res.Reasons, fmt.Sprintf("blob gas above block blob gas limit: have %d, want %d", tx.BlobGas(), max))
+		}
+	}
+	if res.Status != "pending" || len(res.Reasons) > 0 {
+		return res
+	}
+	// Replay the block building order over the executable transactions
+	position, gasAhead, ok := poolOrderPosition(tx, pending, baseFee)
+	if !ok {
+		return res
+	}
+	tip, _ := tx.EffectiveGasTip(baseFee)
+	res.EffectiveTip = (*hexutil.Big)(tip)
+	res.Position = (*hexutil.Uint64)(&position)
+	res.GasAhead = (*hexutil.Uint64)(&gasAhead)
+
+	blocks := uint64(1)
+	if head.GasLimit > 0 {
+		blocks += gasAhead / head.GasLimit
+	}
+	res.EstimatedBlocks = (*hexutil.Uint64)(&blocks)
+	return res
+}
+
+// poolOrderPosition replays the ordering of the block builder over the pending
+// transactions: the account heads are picked by highest effective tip, then by
+// arrival time. It returns the number and gas of the transactions picked before
+// the given one. Accounts are skipped from their first transaction below the
+// base fee, which can't be included.
+func poolOrderPosition(target *types.Transaction, pending map[common.Address][]*types.Transaction, baseFee *big.Int) (uint64, uint64, bool) {
+	heads := make(poolTxHeads, 0, len(pending))
+	for _, txs := range pending {
+		if head, ok := newPoolTxHead(txs, baseFee); ok {
+			heads = append(heads, head)
+		}
+	}
+	heap.Init(&heads)
+
+	var position, gas uint64
+	for len(heads) > 0 {
+		head := heads[0]
+		tx := head.txs[0]
+		if tx.Hash() == target.Hash() {
+			return position, gas, true
+		}
+		position++
+		gas += tx.Gas()
+
+		if next, ok := newPoolTxHead(head.txs[1:], baseFee); ok {
+			heads[0] = next
+			heap.Fix(&heads, 0)
+		} else {
+			heap.Pop(&heads)
+		}
+	}
+	return 0, 0, false
+}
+
+// poolTxHead is the next transaction of an account in the block building order.
+type poolTxHead struct {
+	txs []*types.Transaction // Remaining transactions of the account
+	tip *big.Int             // Effective tip of the first one
+}
+
+func newPoolTxHead(txs []*types.Transaction, baseFee *big.Int) (*poolTxHead, bool) {
+	if len(txs) == 0 {
+		return nil, false
+	}
+	tip, err := txs[0].EffectiveGasTip(baseFee)
+	if err != nil {
+		return nil, false
+	}
+	return &poolTxHead{txs: txs, tip: tip}, true
+}
+
+// poolTxHeads is a heap of account heads, highest tip first.
+type poolTxHeads []*poolTxHead
+
+func (h poolTxHeads) Len() int { return len(h) }
+func (h poolTxHeads) Less(i, j int) bool {
+	if c := h[i].tip.Cmp(h[j].tip); c != 0 {
+		return c > 0
+	}
+	return h[i].txs[0].Time().Before(h[j].txs[0].Time())
+}
+func (h poolTxHeads) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+
+func (h *poolTxHeads) Push(x any) {
+	*h = append(*h, x.(*poolTxHead))
+}
+
+func (h *poolTxHeads) Pop() any {
+	old := *h
+	n := len(old)
+	x := old[n-1]
+	old[n-1] = nil
+	*h = old[0 : n-1]
+	return x
+}
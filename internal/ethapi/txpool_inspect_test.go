@@ -0,0 +1,101 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package ethapi
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func TestInspectPoolTx(t *testing.T) {
+	var (
+		config  = params.TestChainConfig
+		signer  = types.LatestSigner(config)
+		keyA, _ = crypto.GenerateKey()
+		keyB, _ = crypto.GenerateKey()
+		addrA   = crypto.PubkeyToAddress(keyA.PublicKey)
+		addrB   = crypto.PubkeyToAddress(keyB.PublicKey)
+
+		// Half full parent, the base fee stays unchanged
+		head = &types.Header{Number: big.NewInt(10), GasLimit: 50_000, GasUsed: 25_000, BaseFee: big.NewInt(2 * params.GWei)}
+	)
+	newTx := func(key *ecdsa.PrivateKey, nonce uint64, tip, feeCap int64) *types.Transaction {
+		return types.MustSignNewTx(key, signer, &types.DynamicFeeTx{
+			ChainID:   config.ChainID,
+			Nonce:     nonce,
+			To:        &common.Address{},
+			Gas:       params.TxGas,
+			GasTipCap: big.NewInt(tip * params.GWei),
+			GasFeeCap: big.NewInt(feeCap * params.GWei),
+		})
+	}
+	var (
+		a0 = newTx(keyA, 0, 3, 10)
+		a1 = newTx(keyA, 1, 1, 10)
+		a2 = newTx(keyA, 2, 5, 1) // Fee cap below the base fee
+		a4 = newTx(keyA, 4, 5, 10)
+		b0 = newTx(keyB, 0, 2, 10)
+		b1 = newTx(keyB, 1, 2, 10)
+
+		pending = map[common.Address][]*types.Transaction{
+			addrA: {a0, a1, a2},
+			addrB: {b0, b1},
+		}
+		queued = map[common.Address][]*types.Transaction{
+			addrA: {a4},
+		}
+		now = time.Now().Add(5 * time.Second)
+	)
+	// The builder picks A0 (tip 3), B0 and B1 (tip 2), then A1 (tip 1)
+	res := inspectPoolTx(config, head, a1, addrA, 3, pending, queued, now)
+	if res.Status != "pending" || len(res.Reasons) != 0 {
+		t.Fatalf("wrong executable status: %s %v", res.Status, res.Reasons)
+	}
+	if res.Position == nil || *res.Position != 3 {
+		t.Fatalf("wrong position: %v", res.Position)
+	}
+	if uint64(*res.GasAhead) != 3*params.TxGas || *res.EstimatedBlocks != 2 {
+		t.Fatalf("wrong inclusion estimate: gas %d, blocks %d", *res.GasAhead, *res.EstimatedBlocks)
+	}
+	if res.EffectiveTip.ToInt().Cmp(big.NewInt(params.GWei)) != 0 {
+		t.Fatalf("wrong effective tip: %v", res.EffectiveTip)
+	}
+	if res.TimeInPool < 5 {
+		t.Fatalf("wrong time in pool: %d", res.TimeInPool)
+	}
+	// A2 can't pay for the base fee
+	res = inspectPoolTx(config, head, a2, addrA, 3, pending, queued, now)
+	if len(res.Reasons) != 1 || !strings.HasPrefix(res.Reasons[0], "fee cap below base fee") {
+		t.Fatalf("wrong underpriced reasons: %v", res.Reasons)
+	}
+	if res.Position != nil {
+		t.Fatal("estimate reported for an underpriced transaction")
+	}
+	// A4 waits for the missing nonce
+	res = inspectPoolTx(config, head, a4, addrA, 3, pending, queued, now)
+	if res.Status != "queued" || len(res.Reasons) != 1 || !strings.HasPrefix(res.Reasons[0], "nonce gap") {
+		t.Fatalf("wrong queued status: %s %v", res.Status, res.Reasons)
+	}
+}
@@ -0,0 +1,242 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package feevectors generates and verifies deterministic test vectors for the
+// transaction fee functions, so that other clients can check their implementations
+// against each other before fee mismatches surface on a live network.
+//
+// A vector contains an encoded transaction, and for each fork its intrinsic gas,
+// EIP-7623 floor data gas, blob base fee and the minimum fee the transaction pays
+// in a block with the given fee parameters.
+package feevectors
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/consensus/misc/eip4844"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// errUnsupportedTx is returned if a transaction can't be included under a fork.
+var errUnsupportedTx = errors.New("transaction not supported by fork")
+
+// Params are the fee parameters of the block a transaction is priced in.
+type Params struct {
+	Fork          string         `json:"fork"`
+	BaseFee       *hexutil.Big   `json:"baseFee"`
+	ExcessBlobGas hexutil.Uint64 `json:"excessBlobGas"`
+}
+
+// Result contains the outputs of the fee functions for a transaction under a fork.
+type Result struct {
+	Fork         string          `json:"fork"`
+	IntrinsicGas hexutil.Uint64  `json:"intrinsicGas"`
+	FloorDataGas *hexutil.Uint64 `json:"floorDataGas,omitempty"` // Since Prague
+	BlobBaseFee  *hexutil.Big    `json:"blobBaseFee,omitempty"`  // Since Cancun
+	MinFee       *hexutil.Big    `json:"minFee"`
+}
+
+// Vector is the test vector of a single transaction. Forks which the transaction
+// can't be included in have no result.
+type Vector struct {
+	Tx         hexutil.Bytes  `json:"tx"`
+	DataTokens hexutil.Uint64 `json:"dataTokens"`
+	Results    []Result       `json:"results"`
+}
+
+// Suite is a set of test vectors, priced under the same fee parameters.
+type Suite struct {
+	Params  []Params `json:"params"`
+	Vectors []Vector `json:"vectors"`
+}
+
+// DefaultParams are the fee parameters vectors are generated with.
+var DefaultParams = []Params{
+	{Fork: "shanghai", BaseFee: (*hexutil.Big)(big.NewInt(7 * params.GWei))},
+	{Fork: "cancun", BaseFee: (*hexutil.Big)(big.NewInt(12 * params.GWei)), ExcessBlobGas: 7864320},
+	{Fork: "prague", BaseFee: (*hexutil.Big)(big.NewInt(3 * params.GWei)), ExcessBlobGas: 20_000_000},
+	{Fork: "osaka", BaseFee: (*hexutil.Big)(big.NewInt(params.GWei)), ExcessBlobGas: 50_000_000},
+}
+
+// forkTimes maps the supported forks to their activation time on mainnet, which is
+// the chain configuration results are computed with.
+var forkTimes = map[string]uint64{
+	"shanghai": *params.MainnetChainConfig.ShanghaiTime,
+	"cancun":   *params.MainnetChainConfig.CancunTime,
+	"prague":   *params.MainnetChainConfig.PragueTime,
+	"osaka":    *params.MainnetChainConfig.OsakaTime,
+}
+
+// blockNumber is the number of the block transactions are priced in. Forks are
+// scheduled by time after the merge, so any post-merge number works.
+var blockNumber = big.NewInt(20_000_000)
+
+// Generate creates count test vectors with the default parameters. The vectors are
+// fully determined by the seed.
+func Generate(seed int64, count int) (*Suite, error) {
+	var (
+		gen   = newTxGenerator(seed)
+		suite = &Suite{Params: DefaultParams}
+	)
+	for i := 0; i < count; i++ {
+		tx, err := gen.next(i)
+		if err != nil {
+			return nil, err
+		}
+		vector, err := makeVector(tx, suite.Params)
+		if err != nil {
+			return nil, fmt.Errorf("vector %d: %w", i, err)
+		}
+		suite.Vectors = append(suite.Vectors, *vector)
+	}
+	return suite, nil
+}
+
+// Verify recomputes the outputs of all vectors in the suite from their transaction
+// and the suite parameters, and returns an error describing every mismatch.
+func Verify(suite *Suite) error {
+	var errs []error
+	for i, vector := range suite.Vectors {
+		tx := new(types.Transaction)
+		if err := tx.UnmarshalBinary(vector.Tx); err != nil {
+			errs = append(errs, fmt.Errorf("vector %d: invalid transaction: %v", i, err))
+			continue
+		}
+		want, err := makeVector(tx, suite.Params)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("vector %d: %v", i, err))
+			continue
+		}
+		if vector.DataTokens != want.DataTokens {
+			errs = append(errs, fmt.Errorf("vector %d: data tokens mismatch: have %d, want %d", i, vector.DataTokens, want.DataTokens))
+		}
+		errs = append(errs, compareResults(i, vector.Results, want.Results)...)
+	}
+	return errors.Join(errs...)
+}
+
+// compareResults checks the results of a vector against the expected ones.
+func compareResults(index int, have, want []Result) []error {
+	var (
+		errs  []error
+		avail = make(map[string]Result, len(have))
+	)
+	for _, res := range have {
+		avail[res.Fork] = res
+	}
+	for _, res := range want {
+		got, ok := avail[res.Fork]
+		if !ok {
+			errs = append(errs, fmt.Errorf("vector %d: missing %s result", index, res.Fork))
+			continue
+		}
+		delete(avail, res.Fork)
+
+		// Compare the encodings, which are canonical for all fields.
+		haveJSON, _ := json.Marshal(got)
+		wantJSON, _ := json.Marshal(res)
+		if !bytes.Equal(haveJSON, wantJSON) {
+			errs = append(errs, fmt.Errorf("vector %d: %s result mismatch: have %s, want %s", index, res.Fork, haveJSON, wantJSON))
+		}
+	}
+	for fork := range avail {
+		errs = append(errs, fmt.Errorf("vector %d: unexpected %s result", index, fork))
+	}
+	return errs
+}
+
+// makeVector computes the test vector of a transaction.
+func makeVector(tx *types.Transaction, feeParams []Params) (*Vector, error) {
+	enc, err := tx.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	vector := &Vector{Tx: enc, DataTokens: hexutil.Uint64(dataTokens(tx.Data()))}
+	for _, p := range feeParams {
+		res, err := price(tx, p)
+		if errors.Is(err, errUnsupportedTx) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		vector.Results = append(vector.Results, *res)
+	}
+	return vector, nil
+}
+
+// dataTokens returns the number of EIP-7623 calldata tokens in data.
+func dataTokens(data []byte) uint64 {
+	zeros := uint64(bytes.Count(data, []byte{0}))
+	return zeros + (uint64(len(data))-zeros)*params.TxTokenPerNonZeroByte
+}
+
+// price computes the fee function outputs for a transaction under the given fork
+// and fee parameters.
+func price(tx *types.Transaction, p Params) (*Result, error) {
+	time, ok := forkTimes[p.Fork]
+	if !ok {
+		return nil, fmt.Errorf("unknown fork %q", p.Fork)
+	}
+	if p.BaseFee == nil {
+		return nil, fmt.Errorf("missing %s base fee", p.Fork)
+	}
+	var (
+		config = params.MainnetChainConfig
+		rules  = config.Rules(blockNumber, true, time)
+	)
+	switch {
+	case tx.Type() == types.BlobTxType && !rules.IsCancun:
+		return nil, errUnsupportedTx
+	case tx.Type() == types.SetCodeTxType && !rules.IsPrague:
+		return nil, errUnsupportedTx
+	}
+	// Transactions not paying the base fee can't be included.
+	baseFee := p.BaseFee.ToInt()
+	tip, err := tx.EffectiveGasTip(baseFee)
+	if err != nil {
+		return nil, errUnsupportedTx
+	}
+	gas, err := core.IntrinsicGas(tx.Data(), tx.AccessList(), tx.SetCodeAuthorizations(), tx.To() == nil, rules.IsHomestead, rules.IsIstanbul, rules.IsShanghai)
+	if err != nil {
+		return nil, err
+	}
+	res := &Result{Fork: p.Fork, IntrinsicGas: hexutil.Uint64(gas)}
+	if rules.IsPrague {
+		floor, err := core.FloorDataGas(tx.Data())
+		if err != nil {
+			return nil, err
+		}
+		res.FloorDataGas = (*hexutil.Uint64)(&floor)
+		gas = max(gas, floor)
+	}
+	fee := new(big.Int).Mul(new(big.Int).SetUint64(gas), new(big.Int).Add(baseFee, tip))
+	if rules.IsCancun {
+		excess := uint64(p.ExcessBlobGas)
+		blobBaseFee := eip4844.CalcBlobFee(config, &types.Header{Number: blockNumber, Time: time, ExcessBlobGas: &excess})
+		res.BlobBaseFee = (*hexutil.Big)(blobBaseFee)
+		fee.Add(fee, new(big.Int).Mul(new(big.Int).SetUint64(tx.BlobGas()), blobBaseFee))
+	}
+	res.MinFee = (*hexutil.Big)(fee)
+	return res, nil
+}
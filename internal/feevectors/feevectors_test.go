@@ -0,0 +1,59 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package feevectors
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+func TestGenerateVerify(t *testing.T) {
+	suite, err := Generate(1, 48)
+	if err != nil {
+		t.Fatalf("failed to generate vectors: %v", err)
+	}
+	// Generation must be deterministic
+	again, _ := Generate(1, 48)
+	enc, _ := json.Marshal(suite)
+	encAgain, _ := json.Marshal(again)
+	if !bytes.Equal(enc, encAgain) {
+		t.Fatalf("vector generation is not deterministic")
+	}
+	// Vectors must survive a roundtrip through their encoding
+	var dec Suite
+	if err := json.Unmarshal(enc, &dec); err != nil {
+		t.Fatalf("failed to decode vectors: %v", err)
+	}
+	if err := Verify(&dec); err != nil {
+		t.Fatalf("failed to verify vectors: %v", err)
+	}
+	// Blob transactions can't be included before Cancun
+	if blob := dec.Vectors[4]; len(blob.Results) != len(DefaultParams)-1 || blob.Results[0].Fork != "cancun" {
+		t.Errorf("wrong blob transaction results: %+v", blob.Results)
+	}
+	// Mismatching outputs must be reported
+	dec.Vectors[0].Results[0].MinFee = (*hexutil.Big)(big.NewInt(1))
+	dec.Vectors[1].DataTokens++
+	dec.Vectors[2].Results = dec.Vectors[2].Results[1:]
+	if err := Verify(&dec); err == nil {
+		t.Fatalf("tampered vectors verified")
+	}
+}
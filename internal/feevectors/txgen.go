@@ -0,0 +1,159 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package feevectors
+
+import (
+	"crypto/ecdsa"
+	"encoding/binary"
+	"math/big"
+	"math/rand"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/holiman/uint256"
+)
+
+// dataSizes are the calldata lengths of generated transactions, covering empty
+// data, word boundaries and the init code size limit.
+var dataSizes = []int{0, 1, 31, 32, 33, 68, 256, 1024, 4096, params.MaxInitCodeSize}
+
+// txGenerator creates pseudo-random signed transactions of all types. The output
+// only depends on the seed: the signing key is derived from it, and signatures are
+// deterministic (RFC 6979).
+type txGenerator struct {
+	rand    *rand.Rand
+	key     *ecdsa.PrivateKey
+	signer  types.Signer
+	chainID *uint256.Int
+}
+
+func newTxGenerator(seed int64) *txGenerator {
+	var enc [8]byte
+	binary.BigEndian.PutUint64(enc[:], uint64(seed))
+	key, _ := crypto.ToECDSA(crypto.Keccak256(enc[:]))
+
+	return &txGenerator{
+		rand:    rand.New(rand.NewSource(seed)),
+		key:     key,
+		signer:  types.LatestSigner(params.MainnetChainConfig),
+		chainID: uint256.MustFromBig(params.MainnetChainConfig.ChainID),
+	}
+}
+
+// next creates the i-th transaction, cycling through the transaction types.
+func (g *txGenerator) next(i int) (*types.Transaction, error) {
+	var (
+		to     = g.address()
+		nonce  = g.rand.Uint64() % 1024
+		gas    = uint64(10_000_000)
+		data   = g.data()
+		tip    = g.gwei(3)
+		feeCap = new(big.Int).Add(g.gwei(100), big.NewInt(20*params.GWei))
+		inner  types.TxData
+	)
+	switch i % 6 {
+	case 0:
+		inner = &types.LegacyTx{Nonce: nonce, GasPrice: feeCap, Gas: gas, To: &to, Data: data}
+	case 1:
+		inner = &types.LegacyTx{Nonce: nonce, GasPrice: feeCap, Gas: gas, Data: data}
+	case 2:
+		inner = &types.AccessListTx{ChainID: g.chainID.ToBig(), Nonce: nonce, GasPrice: feeCap, Gas: gas, To: &to, Data: data, AccessList: g.accessList()}
+	case 3:
+		inner = &types.DynamicFeeTx{ChainID: g.chainID.ToBig(), Nonce: nonce, GasTipCap: tip, GasFeeCap: feeCap, Gas: gas, To: &to, Data: data, AccessList: g.accessList()}
+	case 4:
+		hashes := make([]common.Hash, 1+g.rand.Intn(6))
+		for j := range hashes {
+			g.rand.Read(hashes[j][:])
+			hashes[j][0] = 0x01 // KZG versioned hash
+		}
+		inner = &types.BlobTx{
+			ChainID:    g.chainID,
+			Nonce:      nonce,
+			GasTipCap:  uint256.MustFromBig(tip),
+			GasFeeCap:  uint256.MustFromBig(feeCap),
+			Gas:        gas,
+			To:         to,
+			Data:       data,
+			AccessList: g.accessList(),
+			BlobFeeCap: uint256.MustFromBig(g.gwei(50)),
+			BlobHashes: hashes,
+		}
+	case 5:
+		auths := make([]types.SetCodeAuthorization, 1+g.rand.Intn(3))
+		for j := range auths {
+			auth, err := types.SignSetCode(g.key, types.SetCodeAuthorization{
+				ChainID: *g.chainID,
+				Address: g.address(),
+				Nonce:   g.rand.Uint64() % 1024,
+			})
+			if err != nil {
+				return nil, err
+			}
+			auths[j] = auth
+		}
+		inner = &types.SetCodeTx{
+			ChainID:    g.chainID,
+			Nonce:      nonce,
+			GasTipCap:  uint256.MustFromBig(tip),
+			GasFeeCap:  uint256.MustFromBig(feeCap),
+			Gas:        gas,
+			To:         to,
+			Data:       data,
+			AccessList: g.accessList(),
+			AuthList:   auths,
+		}
+	}
+	return types.SignNewTx(g.key, g.signer, inner)
+}
+
+func (g *txGenerator) address() (addr common.Address) {
+	g.rand.Read(addr[:])
+	return addr
+}
+
+// gwei returns a random amount below the given number of gwei.
+func (g *txGenerator) gwei(limit int64) *big.Int {
+	return big.NewInt(g.rand.Int63n(limit * params.GWei))
+}
+
+// data returns calldata of a random size, with a random share of zero bytes.
+func (g *txGenerator) data() []byte {
+	var (
+		data  = make([]byte, dataSizes[g.rand.Intn(len(dataSizes))])
+		zeros = g.rand.Intn(101)
+	)
+	for i := range data {
+		if g.rand.Intn(100) >= zeros {
+			data[i] = byte(1 + g.rand.Intn(255))
+		}
+	}
+	return data
+}
+
+func (g *txGenerator) accessList() types.AccessList {
+	list := make(types.AccessList, g.rand.Intn(4))
+	for i := range list {
+		list[i].Address = g.address()
+		list[i].StorageKeys = make([]common.Hash, g.rand.Intn(4))
+		for j := range list[i].StorageKeys {
+			g.rand.Read(list[i].StorageKeys[j][:])
+		}
+	}
+	return list
+}
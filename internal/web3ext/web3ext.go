@@ -27,6 +27,7 @@ var Modules = map[string]string{
 	"rpc":    RpcJs,
 	"txpool": TxpoolJs,
 	"dev":    DevJs,
+	"watch":  WatchJs,
 }
 
 const CliqueJs = `
@@ -121,6 +122,16 @@ web3._extend({
 			call: 'admin_importChain',
 			params: 1
 		}),
+		new web3._extend.Method({
+			name: 'exportTxPool',
+			call: 'admin_exportTxPool',
+			params: 1
+		}),
+		new web3._extend.Method({
+			name: 'importTxPool',
+			call: 'admin_importTxPool',
+			params: 1
+		}),
 		new web3._extend.Method({
 			name: 'sleepBlocks',
 			call: 'admin_sleepBlocks',
@@ -158,6 +169,75 @@ web3._extend({
 			name: 'stopWS',
 			call: 'admin_stopWS'
 		}),
+		new web3._extend.Method({
+			name: 'reloadSecrets',
+			call: 'admin_reloadSecrets'
+		}),
+		new web3._extend.Method({
+			name: 'setTxGossip',
+			call: 'admin_setTxGossip',
+			params: 2
+		}),
+		new web3._extend.Method({
+			name: 'allowDeepReorg',
+			call: 'admin_allowDeepReorg',
+			params: 1
+		}),
+		new web3._extend.Method({
+			name: 'addLocal',
+			call: 'admin_addLocal',
+			params: 1
+		}),
+		new web3._extend.Method({
+			name: 'removeLocal',
+			call: 'admin_removeLocal',
+			params: 1
+		}),
+		new web3._extend.Method({
+			name: 'getConfig',
+			call: 'admin_getConfig',
+			params: 1,
+			inputFormatter: [null]
+		}),
+		new web3._extend.Method({
+			name: 'setConfig',
+			call: 'admin_setConfig',
+			params: 2
+		}),
+		new web3._extend.Method({
+			name: 'addPeerGroup',
+			call: 'admin_addPeerGroup',
+			params: 1
+		}),
+		new web3._extend.Method({
+			name: 'removePeerGroup',
+			call: 'admin_removePeerGroup',
+			params: 1
+		}),
+		new web3._extend.Method({
+			name: 'addAuthClient',
+			call: 'admin_addAuthClient',
+			params: 1
+		}),
+		new web3._extend.Method({
+			name: 'removeAuthClient',
+			call: 'admin_removeAuthClient',
+			params: 1
+		}),
+		new web3._extend.Method({
+			name: 'startDrain',
+			call: 'admin_startDrain',
+			params: 1,
+			inputFormatter: [null]
+		}),
+		new web3._extend.Method({
+			name: 'stopDrain',
+			call: 'admin_stopDrain'
+		}),
+		new web3._extend.Method({
+			name: 'drainStatus',
+			call: 'admin_drainStatus'
+		}),
 	],
 	properties: [
 		new web3._extend.Property({
@@ -172,6 +252,26 @@ web3._extend({
 			name: 'datadir',
 			getter: 'admin_datadir'
 		}),
+		new web3._extend.Property({
+			name: 'peerGroups',
+			getter: 'admin_peerGroups'
+		}),
+		new web3._extend.Property({
+			name: 'authClients',
+			getter: 'admin_authClients'
+		}),
+		new web3._extend.Property({
+			name: 'txGossip',
+			getter: 'admin_txGossip'
+		}),
+		new web3._extend.Property({
+			name: 'locals',
+			getter: 'admin_locals'
+		}),
+		new web3._extend.Property({
+			name: 'peerStats',
+			getter: 'admin_peerStats'
+		}),
 	]
 });
 `
@@ -223,6 +323,11 @@ web3._extend({
 			params: 1,
 			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter]
 		}),
+		new web3._extend.Method({
+			name: 'purgeContractStorage',
+			call: 'debug_purgeContractStorage',
+			params: 1
+		}),
 		new web3._extend.Method({
 			name: 'chaindbProperty',
 			call: 'debug_chaindbProperty',
@@ -242,6 +347,16 @@ web3._extend({
 			call: 'debug_vmodule',
 			params: 1
 		}),
+		new web3._extend.Method({
+			name: 'setSubsystemVerbosity',
+			call: 'debug_setSubsystemVerbosity',
+			params: 2,
+			inputFormatter: [null, null]
+		}),
+		new web3._extend.Method({
+			name: 'subsystemVerbosity',
+			call: 'debug_subsystemVerbosity',
+		}),
 		new web3._extend.Method({
 			name: 'stacks',
 			call: 'debug_stacks',
@@ -410,6 +525,11 @@ web3._extend({
 			call: 'debug_getBadBlocks',
 			params: 0,
 		}),
+		new web3._extend.Method({
+			name: 'reorgHistory',
+			call: 'debug_reorgHistory',
+			params: 0,
+		}),
 		new web3._extend.Method({
 			name: 'storageRangeAt',
 			call: 'debug_storageRangeAt',
@@ -427,6 +547,21 @@ web3._extend({
 			params: 2,
 			inputFormatter:[null, null],
 		}),
+		new web3._extend.Method({
+			name: 'getStateDiff',
+			call: 'debug_getStateDiff',
+			params: 1,
+		}),
+		new web3._extend.Method({
+			name: 'verifyRange',
+			call: 'debug_verifyRange',
+			params: 2,
+		}),
+		new web3._extend.Method({
+			name: 'replayPayloadBuild',
+			call: 'debug_replayPayloadBuild',
+			params: 1,
+		}),
 		new web3._extend.Method({
 			name: 'freezeClient',
 			call: 'debug_freezeClient',
@@ -453,6 +588,12 @@ web3._extend({
 			call: 'debug_dbAncients',
 			params: 0
 		}),
+		new web3._extend.Method({
+			name: 'stateStats',
+			call: 'debug_stateStats',
+			params: 1,
+			inputFormatter: [null]
+		}),
 		new web3._extend.Method({
 			name: 'setTrieFlushInterval',
 			call: 'debug_setTrieFlushInterval',
@@ -463,6 +604,11 @@ web3._extend({
 			call: 'debug_getTrieFlushInterval',
 			params: 0
 		}),
+		new web3._extend.Method({
+			name: 'indexerStatus',
+			call: 'debug_indexerStatus',
+			params: 0
+		}),
 		new web3._extend.Method({
 			name: 'sync',
 			call: 'debug_sync',
@@ -567,12 +713,44 @@ web3._extend({
 			params: 3,
 			inputFormatter: [web3._extend.formatters.inputAddressFormatter, null, web3._extend.formatters.inputBlockNumberFormatter]
 		}),
+		new web3._extend.Method({
+			name: 'getProofBatch',
+			call: 'eth_getProofBatch',
+			params: 2,
+			inputFormatter: [null, web3._extend.formatters.inputBlockNumberFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'getStorageRangeProof',
+			call: 'eth_getStorageRangeProof',
+			params: 4,
+			inputFormatter: [web3._extend.formatters.inputAddressFormatter, null, null, web3._extend.formatters.inputBlockNumberFormatter]
+		}),
 		new web3._extend.Method({
 			name: 'createAccessList',
 			call: 'eth_createAccessList',
 			params: 2,
 			inputFormatter: [null, web3._extend.formatters.inputBlockNumberFormatter],
 		}),
+		new web3._extend.Method({
+			name: 'createAccessListV2',
+			call: 'eth_createAccessListV2',
+			params: 2,
+			inputFormatter: [null, web3._extend.formatters.inputBlockNumberFormatter],
+		}),
+		new web3._extend.Method({
+			name: 'scheduleRawTransaction',
+			call: 'eth_scheduleRawTransaction',
+			params: 2
+		}),
+		new web3._extend.Method({
+			name: 'scheduledTransactions',
+			call: 'eth_scheduledTransactions',
+		}),
+		new web3._extend.Method({
+			name: 'cancelScheduledTransaction',
+			call: 'eth_cancelScheduledTransaction',
+			params: 1
+		}),
 		new web3._extend.Method({
 			name: 'feeHistory',
 			call: 'eth_feeHistory',
@@ -587,8 +765,8 @@ web3._extend({
 		new web3._extend.Method({
 			name: 'call',
 			call: 'eth_call',
-			params: 4,
-			inputFormatter: [web3._extend.formatters.inputCallFormatter, web3._extend.formatters.inputDefaultBlockNumberFormatter, null, null],
+			params: 5,
+			inputFormatter: [web3._extend.formatters.inputCallFormatter, web3._extend.formatters.inputDefaultBlockNumberFormatter, null, null, null],
 		}),
 		new web3._extend.Method({
 			name: 'simulateV1',
@@ -596,11 +774,28 @@ web3._extend({
 			params: 2,
 			inputFormatter: [null, web3._extend.formatters.inputDefaultBlockNumberFormatter],
 		}),
+		new web3._extend.Method({
+			name: 'callMany',
+			call: 'eth_callMany',
+			params: 3,
+			inputFormatter: [null, web3._extend.formatters.inputDefaultBlockNumberFormatter, null],
+		}),
 		new web3._extend.Method({
 			name: 'getBlockReceipts',
 			call: 'eth_getBlockReceipts',
 			params: 1,
 		}),
+		new web3._extend.Method({
+			name: 'getBlobSidecars',
+			call: 'eth_getBlobSidecars',
+			params: 1,
+			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter],
+		}),
+		new web3._extend.Method({
+			name: 'getBlobs',
+			call: 'eth_getBlobs',
+			params: 1,
+		}),
 		new web3._extend.Method({
 			name: 'config',
 			call: 'eth_config',
@@ -625,6 +820,10 @@ web3._extend({
 			getter: 'eth_maxPriorityFeePerGas',
 			outputFormatter: web3._extend.utils.toBigNumber
 		}),
+		new web3._extend.Property({
+			name: 'inclusionRequirements',
+			getter: 'eth_inclusionRequirements'
+		}),
 	]
 });
 `
@@ -650,8 +849,22 @@ web3._extend({
 			params: 1,
 			inputFormatter: [web3._extend.utils.fromDecimal]
 		}),
+		new web3._extend.Method({
+			name: 'setTxPolicy',
+			call: 'miner_setTxPolicy',
+			params: 1
+		}),
+		new web3._extend.Method({
+			name: 'reloadTxPolicy',
+			call: 'miner_reloadTxPolicy',
+		}),
 	],
-	properties: []
+	properties: [
+		new web3._extend.Property({
+			name: 'txPolicy',
+			getter: 'miner_txPolicy'
+		}),
+	]
 });
 `
 
@@ -709,6 +922,11 @@ web3._extend({
 			call: 'txpool_contentFrom',
 			params: 1,
 		}),
+		new web3._extend.Method({
+			name: 'inspectTx',
+			call: 'txpool_inspectTx',
+			params: 1,
+		}),
 	]
 });
 `
@@ -728,6 +946,39 @@ web3._extend({
 			call: 'dev_setFeeRecipient',
 			params: 1
 		}),
+		new web3._extend.Method({
+			name: 'warpTime',
+			call: 'dev_warpTime',
+			params: 1,
+			inputFormatter: [web3._extend.utils.fromDecimal]
+		}),
 	],
 });
 `
+
+const WatchJs = `
+web3._extend({
+	property: 'watch',
+	methods:
+	[
+		new web3._extend.Method({
+			name: 'add',
+			call: 'watch_add',
+			params: 3,
+			inputFormatter: [web3._extend.formatters.inputAddressFormatter, null, null]
+		}),
+		new web3._extend.Method({
+			name: 'remove',
+			call: 'watch_remove',
+			params: 1,
+		}),
+	],
+	properties:
+	[
+		new web3._extend.Property({
+			name: 'list',
+			getter: 'watch_list'
+		}),
+	]
+});
+`
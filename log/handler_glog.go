@@ -24,6 +24,7 @@ import (
 	"maps"
 	"regexp"
 	"runtime"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
@@ -33,6 +34,31 @@ import (
 // errVmoduleSyntax is returned when a user vmodule pattern is invalid.
 var errVmoduleSyntax = errors.New("expect comma-separated list of filename=N")
 
+// errSubsystemSyntax is returned when a user subsystem verbosity list is invalid.
+var errSubsystemSyntax = errors.New("expect comma-separated list of subsystem=N")
+
+// modulePath is the import path prefix of all go-ethereum packages.
+const modulePath = "github.com/ethereum/go-ethereum/"
+
+// subsystems maps the names of the subsystems whose verbosity can be set
+// individually to the packages, relative to the module root, they consist of.
+// Sub-packages belong to the same subsystem unless claimed by a more specific
+// entry.
+var subsystems = map[string][]string{
+	"miner":      {"miner"},
+	"txpool":     {"core/txpool"},
+	"engine":     {"eth/catalyst", "beacon/engine"},
+	"downloader": {"eth/downloader"},
+	"p2p":        {"p2p"},
+	"rpc":        {"rpc"},
+}
+
+// Subsystems returns the sorted names of the subsystems whose verbosity can be
+// set individually.
+func Subsystems() []string {
+	return slices.Sorted(maps.Keys(subsystems))
+}
+
 // GlogHandler is a log handler that mimics the filtering features of Google's
 // glog logger: setting global log levels; overriding with callsite pattern
 // matches; and requesting backtraces at certain positions.
@@ -46,14 +72,85 @@ type GlogHandler struct {
 	siteCache map[uintptr]slog.Level // Cache of callsite pattern evaluations
 	location  string                 // file:line location where to do a stackdump at
 	lock      sync.RWMutex           // Lock protecting the override pattern list
+
+	subsystems *subsystemLevels // Subsystem verbosities, shared with derived handlers
 }
 
 // NewGlogHandler creates a new log handler with filtering functionality similar
 // to Google's glog logger. The returned handler implements Handler.
 func NewGlogHandler(h slog.Handler) *GlogHandler {
 	return &GlogHandler{
-		origin: h,
+		origin:     h,
+		subsystems: newSubsystemLevels(),
+	}
+}
+
+// subsystemLevels holds the verbosity overrides of subsystems. Unlike the global
+// level and the vmodule patterns, it is shared by all handlers derived via
+// WithAttrs, so runtime changes also apply to loggers created earlier.
+type subsystemLevels struct {
+	active atomic.Bool // Whether any override is set, atomically accessible
+
+	levels map[string]slog.Level // Verbosity of each overridden subsystem
+	sites  map[uintptr]string    // Cache of callsite subsystem evaluations
+	lock   sync.RWMutex          // Lock protecting the levels and the site cache
+}
+
+func newSubsystemLevels() *subsystemLevels {
+	return &subsystemLevels{
+		levels: make(map[string]slog.Level),
+		sites:  make(map[uintptr]string),
+	}
+}
+
+// level returns the verbosity of the subsystem the given callsite belongs to,
+// if one was set.
+func (s *subsystemLevels) level(pc uintptr) (slog.Level, bool) {
+	s.lock.RLock()
+	name, cached := s.sites[pc]
+	if cached {
+		lvl, ok := s.levels[name]
+		s.lock.RUnlock()
+		return lvl, ok
+	}
+	s.lock.RUnlock()
+
+	fs := runtime.CallersFrames([]uintptr{pc})
+	frame, _ := fs.Next()
+	name = subsystemOf(frame.Function)
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.sites[pc] = name
+	lvl, ok := s.levels[name]
+	return lvl, ok
+}
+
+// subsystemOf returns the subsystem a function belongs to, or the empty string
+// if it's not part of any.
+func subsystemOf(function string) string {
+	if !strings.HasPrefix(function, modulePath) {
+		return ""
+	}
+	// Strip the receiver and function name from the package path
+	pkg := function[len(modulePath):]
+	if slash := strings.LastIndexByte(pkg, '/'); slash >= 0 {
+		if dot := strings.IndexByte(pkg[slash:], '.'); dot >= 0 {
+			pkg = pkg[:slash+dot]
+		}
+	} else if dot := strings.IndexByte(pkg, '.'); dot >= 0 {
+		pkg = pkg[:dot]
+	}
+	var match, matchDir string
+	for name, dirs := range subsystems {
+		for _, dir := range dirs {
+			if (pkg == dir || strings.HasPrefix(pkg, dir+"/")) && len(dir) > len(matchDir) {
+				match, matchDir = name, dir
+			}
+		}
 	}
+	return match
 }
 
 // pattern contains a filter for the Vmodule option, holding a verbosity level
@@ -139,11 +236,73 @@ func (h *GlogHandler) Vmodule(ruleset string) error {
 	return nil
 }
 
+// SetSubsystemVerbosity sets the verbosity of a single subsystem, taking
+// precedence over both the global verbosity and the vmodule patterns for all
+// log calls made by its packages. The verbosity can be lowered as well as
+// raised. A nil level removes the override.
+func (h *GlogHandler) SetSubsystemVerbosity(name string, level *slog.Level) error {
+	if _, ok := subsystems[name]; !ok {
+		return fmt.Errorf("unknown subsystem %q, want one of %s", name, strings.Join(Subsystems(), ", "))
+	}
+	s := h.subsystems
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if level == nil {
+		delete(s.levels, name)
+	} else {
+		s.levels[name] = *level
+	}
+	s.active.Store(len(s.levels) != 0)
+	return nil
+}
+
+// SubsystemVerbosity sets the verbosity of subsystems, replacing any earlier
+// overrides. The syntax of the argument is a comma-separated list of
+// subsystem=N, where N is a V level, e.g. "miner=4,txpool=2".
+func (h *GlogHandler) SubsystemVerbosity(ruleset string) error {
+	levels := make(map[string]slog.Level)
+	for _, rule := range strings.Split(ruleset, ",") {
+		// Empty strings such as from a trailing comma can be ignored
+		if len(rule) == 0 {
+			continue
+		}
+		name, value, ok := strings.Cut(rule, "=")
+		name, value = strings.TrimSpace(name), strings.TrimSpace(value)
+		if !ok || len(name) == 0 || len(value) == 0 {
+			return errSubsystemSyntax
+		}
+		l, err := strconv.Atoi(value)
+		if err != nil {
+			return errSubsystemSyntax
+		}
+		if _, ok := subsystems[name]; !ok {
+			return fmt.Errorf("unknown subsystem %q, want one of %s", name, strings.Join(Subsystems(), ", "))
+		}
+		levels[name] = FromLegacyLevel(l)
+	}
+	s := h.subsystems
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.levels = levels
+	s.active.Store(len(levels) != 0)
+	return nil
+}
+
+// SubsystemLevels returns the verbosity overrides currently set per subsystem.
+func (h *GlogHandler) SubsystemLevels() map[string]slog.Level {
+	h.subsystems.lock.RLock()
+	defer h.subsystems.lock.RUnlock()
+
+	return maps.Clone(h.subsystems.levels)
+}
+
 // Enabled implements slog.Handler, reporting whether the handler handles records
 // at the given level.
 func (h *GlogHandler) Enabled(ctx context.Context, lvl slog.Level) bool {
 	// fast-track skipping logging if override not enabled and the provided verbosity is above configured
-	return h.override.Load() || slog.Level(h.level.Load()) <= lvl
+	return h.override.Load() || h.subsystems.active.Load() || slog.Level(h.level.Load()) <= lvl
 }
 
 // WithAttrs implements slog.Handler, returning a new Handler whose attributes
@@ -157,10 +316,11 @@ func (h *GlogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
 	patterns = append(patterns, h.patterns...)
 
 	res := GlogHandler{
-		origin:    h.origin.WithAttrs(attrs),
-		patterns:  patterns,
-		siteCache: siteCache,
-		location:  h.location,
+		origin:     h.origin.WithAttrs(attrs),
+		patterns:   patterns,
+		siteCache:  siteCache,
+		location:   h.location,
+		subsystems: h.subsystems,
 	}
 
 	res.level.Store(h.level.Load())
@@ -179,10 +339,23 @@ func (h *GlogHandler) WithGroup(name string) slog.Handler {
 // Handle implements slog.Handler, filtering a log record through the global,
 // local and backtrace filters, finally emitting it if either allow it through.
 func (h *GlogHandler) Handle(_ context.Context, r slog.Record) error {
+	// Subsystem verbosities take precedence over everything else
+	if h.subsystems.active.Load() {
+		if lvl, ok := h.subsystems.level(r.PC); ok {
+			if lvl <= r.Level {
+				return h.origin.Handle(context.Background(), r)
+			}
+			return nil
+		}
+	}
 	// If the global log level allows, fast track logging
 	if slog.Level(h.level.Load()) <= r.Level {
 		return h.origin.Handle(context.Background(), r)
 	}
+	// Records only get here below the global level due to subsystem verbosities
+	if !h.override.Load() {
+		return nil
+	}
 
 	// Check callsite cache for previously calculated log levels
 	h.lock.RLock()
@@ -33,6 +33,65 @@ func TestLoggingWithVmodule(t *testing.T) {
 	}
 }
 
+// TestLoggingWithSubsystems checks that subsystem verbosities override both the
+// global verbosity and vmodule, in either direction.
+func TestLoggingWithSubsystems(t *testing.T) {
+	subsystems["logtest"] = []string{"log"}
+	defer delete(subsystems, "logtest")
+
+	out := new(bytes.Buffer)
+	glog := NewGlogHandler(NewTerminalHandlerWithLevel(out, LevelTrace, false))
+	glog.Verbosity(LevelInfo)
+	logger := NewLogger(glog).With("derived", true)
+
+	// Raise the verbosity of the subsystem above the global one
+	if err := glog.SubsystemVerbosity("logtest=5"); err != nil {
+		t.Fatal(err)
+	}
+	logger.Trace("raised")
+	if !strings.Contains(out.String(), "raised") {
+		t.Fatal("raised subsystem verbosity not applied")
+	}
+	// Lower it below the global one at runtime
+	out.Reset()
+	level := LevelError
+	if err := glog.SetSubsystemVerbosity("logtest", &level); err != nil {
+		t.Fatal(err)
+	}
+	logger.Warn("lowered")
+	if out.Len() != 0 {
+		t.Fatalf("lowered subsystem verbosity not applied: %q", out.String())
+	}
+	// Removing the override restores the global verbosity
+	if err := glog.SetSubsystemVerbosity("logtest", nil); err != nil {
+		t.Fatal(err)
+	}
+	logger.Warn("restored")
+	logger.Debug("hidden")
+	if have := out.String(); !strings.Contains(have, "restored") || strings.Contains(have, "hidden") {
+		t.Fatalf("global verbosity not restored: %q", have)
+	}
+	if err := glog.SubsystemVerbosity("nosuchsystem=3"); err == nil {
+		t.Fatal("unknown subsystem accepted")
+	}
+}
+
+func TestSubsystemOf(t *testing.T) {
+	for function, want := range map[string]string{
+		"github.com/ethereum/go-ethereum/miner.(*Miner).generateWork":                    "miner",
+		"github.com/ethereum/go-ethereum/core/txpool/legacypool.(*LegacyPool).add":       "txpool",
+		"github.com/ethereum/go-ethereum/eth/catalyst.(*ConsensusAPI).forkchoiceUpdated": "engine",
+		"github.com/ethereum/go-ethereum/p2p/discover.(*UDPv5).handle":                   "p2p",
+		"github.com/ethereum/go-ethereum/core.(*BlockChain).insertChain":                 "",
+		"github.com/ethereum/go-ethereum/minerx.foo":                                     "",
+		"github.com/other/miner.foo":                                                     "",
+	} {
+		if have := subsystemOf(function); have != want {
+			t.Errorf("%s: have subsystem %q, want %q", function, have, want)
+		}
+	}
+}
+
 func TestTerminalHandlerWithAttrs(t *testing.T) {
 	out := new(bytes.Buffer)
 	glog := NewGlogHandler(NewTerminalHandlerWithLevel(out, LevelTrace, false).WithAttrs([]slog.Attr{slog.String("baz", "bat")}))
@@ -33,6 +33,11 @@ type Config struct {
 	InfluxDBToken        string `toml:",omitempty"`
 	InfluxDBBucket       string `toml:",omitempty"`
 	InfluxDBOrganization string `toml:",omitempty"`
+
+	EnableOTLP       bool    `toml:",omitempty"`
+	OTLPEndpoint     string  `toml:",omitempty"`
+	OTLPTracing      bool    `toml:",omitempty"`
+	OTLPTracingRatio float64 `toml:",omitempty"`
 }
 
 // DefaultConfig is the default config for metrics used in go-ethereum.
@@ -53,4 +58,10 @@ var DefaultConfig = Config{
 	InfluxDBToken:        "test",
 	InfluxDBBucket:       "geth",
 	InfluxDBOrganization: "geth",
+
+	// OpenTelemetry-specific flags
+	EnableOTLP:       false,
+	OTLPEndpoint:     "http://localhost:4318",
+	OTLPTracing:      false,
+	OTLPTracingRatio: 1,
 }
@@ -0,0 +1,219 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package otlp
+
+import (
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
+)
+
+// Aggregation temporality of cumulative sums, see the OTLP metrics data model.
+const aggregationCumulative = 2
+
+// quantiles are the quantiles reported for histograms and timers.
+var quantiles = []float64{0.5, 0.75, 0.95, 0.99, 0.999, 0.9999}
+
+type metricsRequest struct {
+	ResourceMetrics []resourceMetrics `json:"resourceMetrics"`
+}
+
+type resourceMetrics struct {
+	Resource     resource       `json:"resource"`
+	ScopeMetrics []scopeMetrics `json:"scopeMetrics"`
+}
+
+type scopeMetrics struct {
+	Scope   scope    `json:"scope"`
+	Metrics []metric `json:"metrics"`
+}
+
+type metric struct {
+	Name    string   `json:"name"`
+	Unit    string   `json:"unit,omitempty"`
+	Sum     *sum     `json:"sum,omitempty"`
+	Gauge   *gauge   `json:"gauge,omitempty"`
+	Summary *summary `json:"summary,omitempty"`
+}
+
+type sum struct {
+	DataPoints             []numberDataPoint `json:"dataPoints"`
+	AggregationTemporality int               `json:"aggregationTemporality"`
+	IsMonotonic            bool              `json:"isMonotonic"`
+}
+
+type gauge struct {
+	DataPoints []numberDataPoint `json:"dataPoints"`
+}
+
+type summary struct {
+	DataPoints []summaryDataPoint `json:"dataPoints"`
+}
+
+type numberDataPoint struct {
+	Attributes        []keyValue `json:"attributes,omitempty"`
+	StartTimeUnixNano string     `json:"startTimeUnixNano,omitempty"`
+	TimeUnixNano      string     `json:"timeUnixNano"`
+	AsInt             *string    `json:"asInt,omitempty"`
+	AsDouble          *float64   `json:"asDouble,omitempty"`
+}
+
+type summaryDataPoint struct {
+	StartTimeUnixNano string          `json:"startTimeUnixNano,omitempty"`
+	TimeUnixNano      string          `json:"timeUnixNano"`
+	Count             string          `json:"count"`
+	Sum               float64         `json:"sum"`
+	QuantileValues    []quantileValue `json:"quantileValues"`
+}
+
+type quantileValue struct {
+	Quantile float64 `json:"quantile"`
+	Value    float64 `json:"value"`
+}
+
+type reporter struct {
+	reg       metrics.Registry
+	interval  time.Duration
+	namespace string
+	resource  resource
+	client    *client
+	start     time.Time
+}
+
+// OTLP starts an OpenTelemetry reporter which pushes the metrics of the given
+// registry to the OTLP/HTTP collector at endpoint every d interval. The given
+// attributes identify the node as the source of the metrics. This function
+// blocks forever, so it should be run in its own goroutine.
+func OTLP(r metrics.Registry, d time.Duration, endpoint string, namespace string, attrs map[string]string) {
+	rep := &reporter{
+		reg:       r,
+		interval:  d,
+		namespace: namespace,
+		resource:  resource{Attributes: newAttributes(attrs)},
+		client:    newClient(endpoint),
+		start:     time.Now(),
+	}
+	rep.run()
+}
+
+func (r *reporter) run() {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if err := r.send(time.Now()); err != nil {
+			log.Warn("Unable to send metrics to OTLP collector", "err", err)
+		}
+	}
+}
+
+// send exports a snapshot of all metrics in the registry.
+func (r *reporter) send(now time.Time) error {
+	return r.client.post("/v1/metrics", r.collect(now))
+}
+
+// collect converts the current state of the registry into an OTLP request.
+func (r *reporter) collect(now time.Time) *metricsRequest {
+	var (
+		start = formatTime(r.start)
+		ts    = formatTime(now)
+		list  []metric
+	)
+	r.reg.Each(func(name string, i interface{}) {
+		if m, ok := convert(r.namespace+name, i, start, ts); ok {
+			list = append(list, m)
+		}
+	})
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+
+	return &metricsRequest{
+		ResourceMetrics: []resourceMetrics{{
+			Resource: r.resource,
+			ScopeMetrics: []scopeMetrics{{
+				Scope:   instrumentationScope,
+				Metrics: list,
+			}},
+		}},
+	}
+}
+
+// convert maps a single go-ethereum metric onto the closest OTLP data type:
+// counters and meters become cumulative sums, gauges remain gauges and all
+// distributions are exported as summaries. Timer values are in nanoseconds.
+func convert(name string, i interface{}, start, ts string) (metric, bool) {
+	switch m := i.(type) {
+	case *metrics.Counter:
+		// Counters may be decremented, so they are not monotonic
+		return metric{Name: name, Sum: cumulative(numberDataPoint{StartTimeUnixNano: start, TimeUnixNano: ts, AsInt: formatInt(m.Snapshot().Count())}, false)}, true
+	case *metrics.CounterFloat64:
+		v := m.Snapshot().Count()
+		return metric{Name: name, Sum: cumulative(numberDataPoint{StartTimeUnixNano: start, TimeUnixNano: ts, AsDouble: &v}, false)}, true
+	case *metrics.Meter:
+		return metric{Name: name, Sum: cumulative(numberDataPoint{StartTimeUnixNano: start, TimeUnixNano: ts, AsInt: formatInt(m.Snapshot().Count())}, true)}, true
+	case *metrics.Gauge:
+		return metric{Name: name, Gauge: &gauge{DataPoints: []numberDataPoint{{TimeUnixNano: ts, AsInt: formatInt(m.Snapshot().Value())}}}}, true
+	case *metrics.GaugeFloat64:
+		v := m.Snapshot().Value()
+		return metric{Name: name, Gauge: &gauge{DataPoints: []numberDataPoint{{TimeUnixNano: ts, AsDouble: &v}}}}, true
+	case *metrics.GaugeInfo:
+		// Info gauges carry their value in the attributes of a constant point.
+		point := numberDataPoint{
+			Attributes:   newAttributes(m.Snapshot().Value()),
+			TimeUnixNano: ts,
+			AsInt:        formatInt(1),
+		}
+		return metric{Name: name, Gauge: &gauge{DataPoints: []numberDataPoint{point}}}, true
+	case metrics.Histogram:
+		s := m.Snapshot()
+		return metric{Name: name, Summary: newSummary(start, ts, s.Count(), float64(s.Sum()), s.Percentiles(quantiles))}, true
+	case *metrics.Timer:
+		s := m.Snapshot()
+		return metric{Name: name, Unit: "ns", Summary: newSummary(start, ts, s.Count(), float64(s.Sum()), s.Percentiles(quantiles))}, true
+	case *metrics.ResettingTimer:
+		s := m.Snapshot()
+		if s.Count() == 0 {
+			return metric{}, false
+		}
+		return metric{Name: name, Unit: "ns", Summary: newSummary(ts, ts, int64(s.Count()), s.Mean()*float64(s.Count()), s.Percentiles(quantiles))}, true
+	}
+	return metric{}, false
+}
+
+func cumulative(point numberDataPoint, monotonic bool) *sum {
+	return &sum{
+		DataPoints:             []numberDataPoint{point},
+		AggregationTemporality: aggregationCumulative,
+		IsMonotonic:            monotonic,
+	}
+}
+
+func newSummary(start, ts string, count int64, total float64, ps []float64) *summary {
+	point := summaryDataPoint{
+		StartTimeUnixNano: start,
+		TimeUnixNano:      ts,
+		Count:             strconv.FormatInt(count, 10),
+		Sum:               total,
+		QuantileValues:    make([]quantileValue, len(ps)),
+	}
+	for i, p := range ps {
+		point.QuantileValues[i] = quantileValue{Quantile: quantiles[i], Value: p}
+	}
+	return &summary{DataPoints: []summaryDataPoint{point}}
+}
@@ -0,0 +1,144 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package otlp implements metric and trace export using the OpenTelemetry
+// protocol (OTLP) over HTTP with JSON encoding, allowing geth to feed into
+// any OpenTelemetry collector without additional bridges.
+package otlp
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// requestTimeout is the maximum time allowed for a single export request.
+const requestTimeout = 10 * time.Second
+
+// keyValue is an OTLP attribute.
+type keyValue struct {
+	Key   string   `json:"key"`
+	Value anyValue `json:"value"`
+}
+
+// anyValue is an OTLP attribute value. Only one of the fields is set. As per
+// the protobuf JSON mapping, 64 bit integers are encoded as strings.
+type anyValue struct {
+	StringValue *string  `json:"stringValue,omitempty"`
+	IntValue    *string  `json:"intValue,omitempty"`
+	DoubleValue *float64 `json:"doubleValue,omitempty"`
+	BoolValue   *bool    `json:"boolValue,omitempty"`
+}
+
+// newKeyValue converts an attribute to its OTLP representation. Unsupported
+// value types are encoded as their string form.
+func newKeyValue(key string, value any) keyValue {
+	var v anyValue
+	switch value := value.(type) {
+	case string:
+		v.StringValue = &value
+	case bool:
+		v.BoolValue = &value
+	case int:
+		v.IntValue = formatInt(int64(value))
+	case int64:
+		v.IntValue = formatInt(value)
+	case uint64:
+		s := strconv.FormatUint(value, 10)
+		v.IntValue = &s
+	case float64:
+		v.DoubleValue = &value
+	default:
+		s := fmt.Sprint(value)
+		v.StringValue = &s
+	}
+	return keyValue{Key: key, Value: v}
+}
+
+// newAttributes converts a string map into a sorted list of attributes.
+func newAttributes(attrs map[string]string) []keyValue {
+	keys := make([]string, 0, len(attrs))
+	for key := range attrs {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	kvs := make([]keyValue, 0, len(keys))
+	for _, key := range keys {
+		kvs = append(kvs, newKeyValue(key, attrs[key]))
+	}
+	return kvs
+}
+
+type resource struct {
+	Attributes []keyValue `json:"attributes"`
+}
+
+type scope struct {
+	Name string `json:"name"`
+}
+
+// instrumentationScope is the scope all exported telemetry is attributed to.
+var instrumentationScope = scope{Name: "github.com/ethereum/go-ethereum"}
+
+func formatInt(n int64) *string {
+	s := strconv.FormatInt(n, 10)
+	return &s
+}
+
+func formatTime(t time.Time) string {
+	return strconv.FormatInt(t.UnixNano(), 10)
+}
+
+// client posts OTLP payloads to a collector.
+type client struct {
+	http     *http.Client
+	endpoint string
+}
+
+func newClient(endpoint string) *client {
+	return &client{
+		http:     &http.Client{Timeout: requestTimeout},
+		endpoint: strings.TrimSuffix(endpoint, "/"),
+	}
+}
+
+// post sends the JSON encoded payload to the given signal path of the collector,
+// e.g. /v1/metrics.
+func (c *client) post(path string, payload any) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	resp, err := c.http.Post(c.endpoint+path, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("collector returned %s: %s", resp.Status, bytes.TrimSpace(msg))
+	}
+	io.Copy(io.Discard, resp.Body)
+	return nil
+}
@@ -0,0 +1,154 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package otlp
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/metrics"
+)
+
+func TestMetricsExport(t *testing.T) {
+	metrics.Enable()
+
+	reg := metrics.NewRegistry()
+	metrics.NewRegisteredCounter("foo/count", reg).Inc(3)
+	metrics.NewRegisteredGauge("foo/gauge", reg).Update(7)
+	metrics.NewRegisteredTimer("foo/timer", reg).Update(time.Millisecond)
+
+	var received metricsRequest
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/metrics" {
+			t.Errorf("wrong export path: %s", r.URL.Path)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			t.Errorf("failed to decode request: %v", err)
+		}
+	}))
+	defer srv.Close()
+
+	rep := &reporter{
+		reg:       reg,
+		namespace: "geth.",
+		resource:  resource{Attributes: newAttributes(map[string]string{"service.name": "geth"})},
+		client:    newClient(srv.URL),
+		start:     time.Now(),
+	}
+	if err := rep.send(time.Now()); err != nil {
+		t.Fatalf("failed to send metrics: %v", err)
+	}
+	if len(received.ResourceMetrics) != 1 || len(received.ResourceMetrics[0].ScopeMetrics) != 1 {
+		t.Fatalf("unexpected request layout: %+v", received)
+	}
+	list := received.ResourceMetrics[0].ScopeMetrics[0].Metrics
+	if len(list) != 3 {
+		t.Fatalf("wrong number of metrics: have %d, want 3", len(list))
+	}
+	if m := list[0]; m.Name != "geth.foo/count" || m.Sum == nil || *m.Sum.DataPoints[0].AsInt != "3" {
+		t.Errorf("wrong counter: %+v", m)
+	}
+	if m := list[1]; m.Name != "geth.foo/gauge" || m.Gauge == nil || *m.Gauge.DataPoints[0].AsInt != "7" {
+		t.Errorf("wrong gauge: %+v", m)
+	}
+	if m := list[2]; m.Name != "geth.foo/timer" || m.Summary == nil || m.Summary.DataPoints[0].Count != "1" {
+		t.Errorf("wrong timer: %+v", m)
+	}
+}
+
+func TestTraceparent(t *testing.T) {
+	const header = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+	sc, ok := ParseTraceparent(header)
+	if !ok {
+		t.Fatal("failed to parse valid traceparent")
+	}
+	if hex.EncodeToString(sc.TraceID[:]) != "4bf92f3577b34da6a3ce929d0e0e4736" || !sc.Sampled {
+		t.Fatalf("wrong span context: %+v", sc)
+	}
+	if sc.Traceparent() != header {
+		t.Fatalf("wrong traceparent: have %s, want %s", sc.Traceparent(), header)
+	}
+	for _, invalid := range []string{
+		"",
+		"00-00000000000000000000000000000000-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01",
+		"ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra",
+		"00-4bf92f3577b34da6a3ce929d0e0e473x-00f067aa0ba902b7-01",
+	} {
+		if _, ok := ParseTraceparent(invalid); ok {
+			t.Errorf("invalid traceparent %q accepted", invalid)
+		}
+	}
+}
+
+func TestSpanPropagation(t *testing.T) {
+	// Spans are no-ops while tracing is disabled
+	if _, span := StartSpan(context.Background(), "disabled"); span != nil {
+		t.Fatal("span started with tracing disabled")
+	}
+	exp := &spanExporter{ratio: 1, queue: make(chan *Span, 16)}
+	tracer.Store(exp)
+	defer tracer.Store(nil)
+
+	header := http.Header{}
+	header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	ctx := Extract(context.Background(), header)
+
+	ctx, parent := StartSpan(ctx, "parent")
+	_, child := StartSpan(ctx, "child")
+	child.SetError(errors.New("failed"))
+	child.End()
+	parent.End()
+
+	req := exp.request([]*Span{<-exp.queue, <-exp.queue})
+	spans := req.ResourceSpans[0].ScopeSpans[0].Spans
+	if spans[0].Name != "child" || spans[1].Name != "parent" {
+		t.Fatalf("wrong span order: %s, %s", spans[0].Name, spans[1].Name)
+	}
+	for _, span := range spans {
+		if span.TraceID != "4bf92f3577b34da6a3ce929d0e0e4736" {
+			t.Errorf("span %s: wrong trace id %s", span.Name, span.TraceID)
+		}
+	}
+	if spans[1].ParentSpanID != "00f067aa0ba902b7" {
+		t.Errorf("wrong remote parent: %s", spans[1].ParentSpanID)
+	}
+	if spans[0].ParentSpanID != spans[1].SpanID {
+		t.Errorf("wrong child parent: have %s, want %s", spans[0].ParentSpanID, spans[1].SpanID)
+	}
+	if spans[0].Status == nil || spans[0].Status.Code != statusError {
+		t.Errorf("child error not recorded: %+v", spans[0].Status)
+	}
+	// Unsampled callers must not be traced
+	header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00")
+	if _, span := StartSpan(Extract(context.Background(), header), "unsampled"); span != nil {
+		t.Fatal("span started for unsampled trace")
+	}
+	out := http.Header{}
+	Inject(ctx, out)
+	if sc, _ := ParseTraceparent(out.Get("traceparent")); hex.EncodeToString(sc.SpanID[:]) != spans[1].SpanID {
+		t.Errorf("wrong injected parent: %s", out.Get("traceparent"))
+	}
+}
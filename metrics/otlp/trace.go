@@ -0,0 +1,343 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package otlp
+
+import (
+	"context"
+	crand "crypto/rand"
+	"encoding/hex"
+	"math/rand"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
+)
+
+const (
+	// traceparentHeader is the W3C trace context propagation header.
+	traceparentHeader = "traceparent"
+
+	spanQueueSize   = 4096            // Number of finished spans buffered for export
+	spanBatchSize   = 512             // Maximum number of spans per export request
+	spanFlushPeriod = 5 * time.Second // Maximum time a finished span waits for export
+
+	// Span status codes, see the OTLP trace data model.
+	statusError = 2
+)
+
+var (
+	spansExportedMeter = metrics.NewRegisteredMeter("otlp/spans/exported", nil)
+	spansDroppedMeter  = metrics.NewRegisteredMeter("otlp/spans/dropped", nil)
+
+	// tracer is the active span exporter, nil if tracing is disabled.
+	tracer atomic.Pointer[spanExporter]
+)
+
+// SpanContext identifies a span within a trace.
+type SpanContext struct {
+	TraceID [16]byte
+	SpanID  [8]byte
+	Sampled bool
+}
+
+// IsValid reports whether the span context has a trace and span id set.
+func (sc SpanContext) IsValid() bool {
+	return sc.TraceID != [16]byte{} && sc.SpanID != [8]byte{}
+}
+
+// Traceparent formats the span context as a W3C traceparent header value.
+func (sc SpanContext) Traceparent() string {
+	flags := "00"
+	if sc.Sampled {
+		flags = "01"
+	}
+	return "00-" + hex.EncodeToString(sc.TraceID[:]) + "-" + hex.EncodeToString(sc.SpanID[:]) + "-" + flags
+}
+
+// ParseTraceparent parses a W3C traceparent header value.
+func ParseTraceparent(value string) (SpanContext, bool) {
+	// version "-" trace-id "-" parent-id "-" trace-flags
+	if len(value) < 55 || value[2] != '-' || value[35] != '-' || value[52] != '-' {
+		return SpanContext{}, false
+	}
+	// Version ff is invalid, version 00 must not carry any trailing data.
+	if value[:2] == "ff" || (value[:2] == "00" && len(value) != 55) {
+		return SpanContext{}, false
+	}
+	var (
+		sc    SpanContext
+		flags [1]byte
+	)
+	if _, err := hex.Decode(sc.TraceID[:], []byte(value[3:35])); err != nil {
+		return SpanContext{}, false
+	}
+	if _, err := hex.Decode(sc.SpanID[:], []byte(value[36:52])); err != nil {
+		return SpanContext{}, false
+	}
+	if _, err := hex.Decode(flags[:], []byte(value[53:55])); err != nil {
+		return SpanContext{}, false
+	}
+	sc.Sampled = flags[0]&0x01 != 0
+	if !sc.IsValid() {
+		return SpanContext{}, false
+	}
+	return sc, true
+}
+
+type spanContextKey struct{}
+
+// ContextWithSpanContext returns a copy of ctx carrying the given span context,
+// making it the parent of spans started from the returned context.
+func ContextWithSpanContext(ctx context.Context, sc SpanContext) context.Context {
+	return context.WithValue(ctx, spanContextKey{}, sc)
+}
+
+// SpanContextFromContext returns the span context carried by ctx, if any.
+func SpanContextFromContext(ctx context.Context) (SpanContext, bool) {
+	sc, ok := ctx.Value(spanContextKey{}).(SpanContext)
+	return sc, ok
+}
+
+// Extract returns a copy of ctx carrying the span context propagated via the
+// traceparent header of an incoming request. If the header is absent or
+// malformed, ctx is returned unchanged.
+func Extract(ctx context.Context, header http.Header) context.Context {
+	value := header.Get(traceparentHeader)
+	if value == "" {
+		return ctx
+	}
+	sc, ok := ParseTraceparent(value)
+	if !ok {
+		return ctx
+	}
+	return ContextWithSpanContext(ctx, sc)
+}
+
+// Inject sets the traceparent header of an outgoing request to the span
+// context carried by ctx, if any.
+func Inject(ctx context.Context, header http.Header) {
+	if sc, ok := SpanContextFromContext(ctx); ok && sc.IsValid() {
+		header.Set(traceparentHeader, sc.Traceparent())
+	}
+}
+
+// Span is a timed operation within a trace. A nil span is valid and ignores all
+// calls, which is what StartSpan returns while tracing is disabled.
+type Span struct {
+	name     string
+	sc       SpanContext
+	parentID [8]byte
+	start    time.Time
+	end      time.Time
+	attrs    []keyValue
+	err      string
+	exporter *spanExporter
+}
+
+// StartSpan starts a span with the given name as a child of the span carried by
+// ctx, or as the root of a new trace if there is none. The returned context
+// carries the new span. The span must be ended by calling End.
+func StartSpan(ctx context.Context, name string) (context.Context, *Span) {
+	exp := tracer.Load()
+	if exp == nil {
+		return ctx, nil
+	}
+	parent, hasParent := SpanContextFromContext(ctx)
+	if hasParent && !parent.Sampled {
+		// The caller decided not to record this trace, keep propagating that.
+		return ctx, nil
+	}
+	span := &Span{
+		name:     name,
+		start:    time.Now(),
+		exporter: exp,
+	}
+	if hasParent && parent.IsValid() {
+		span.sc.TraceID = parent.TraceID
+		span.parentID = parent.SpanID
+	} else {
+		crand.Read(span.sc.TraceID[:])
+		if exp.ratio < 1 && rand.Float64() >= exp.ratio {
+			return ContextWithSpanContext(ctx, SpanContext{TraceID: span.sc.TraceID, SpanID: randomSpanID()}), nil
+		}
+	}
+	span.sc.SpanID = randomSpanID()
+	span.sc.Sampled = true
+	return ContextWithSpanContext(ctx, span.sc), span
+}
+
+// SetAttribute records a key-value pair on the span. Supported value types are
+// strings, booleans, integers and floats; anything else is recorded in its
+// string form.
+func (s *Span) SetAttribute(key string, value any) {
+	if s == nil {
+		return
+	}
+	s.attrs = append(s.attrs, newKeyValue(key, value))
+}
+
+// SetError marks the span as failed. A nil error is ignored.
+func (s *Span) SetError(err error) {
+	if s == nil || err == nil {
+		return
+	}
+	s.err = err.Error()
+}
+
+// End finishes the span and queues it for export.
+func (s *Span) End() {
+	if s == nil {
+		return
+	}
+	s.end = time.Now()
+	s.exporter.enqueue(s)
+}
+
+// EnableTracing starts exporting spans to the OTLP/HTTP collector at endpoint.
+// The given attributes identify the node as the source of the spans. Root spans
+// are sampled with the given ratio, while spans whose parent was propagated from
+// a caller follow the caller's sampling decision.
+func EnableTracing(endpoint string, ratio float64, attrs map[string]string) {
+	exp := &spanExporter{
+		client:   newClient(endpoint),
+		resource: resource{Attributes: newAttributes(attrs)},
+		ratio:    ratio,
+		queue:    make(chan *Span, spanQueueSize),
+	}
+	if tracer.CompareAndSwap(nil, exp) {
+		go exp.loop()
+	}
+}
+
+// spanExporter batches finished spans and posts them to the collector.
+type spanExporter struct {
+	client   *client
+	resource resource
+	ratio    float64
+	queue    chan *Span
+}
+
+// enqueue schedules a finished span for export. Spans are dropped rather than
+// blocking the traced operation if the collector can't keep up.
+func (e *spanExporter) enqueue(s *Span) {
+	select {
+	case e.queue <- s:
+	default:
+		spansDroppedMeter.Mark(1)
+	}
+}
+
+func (e *spanExporter) loop() {
+	ticker := time.NewTicker(spanFlushPeriod)
+	defer ticker.Stop()
+
+	batch := make([]*Span, 0, spanBatchSize)
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		if err := e.client.post("/v1/traces", e.request(batch)); err != nil {
+			log.Debug("Unable to send spans to OTLP collector", "spans", len(batch), "err", err)
+			spansDroppedMeter.Mark(int64(len(batch)))
+		} else {
+			spansExportedMeter.Mark(int64(len(batch)))
+		}
+		batch = batch[:0]
+	}
+	for {
+		select {
+		case s := <-e.queue:
+			batch = append(batch, s)
+			if len(batch) >= spanBatchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		}
+	}
+}
+
+type tracesRequest struct {
+	ResourceSpans []resourceSpans `json:"resourceSpans"`
+}
+
+type resourceSpans struct {
+	Resource   resource     `json:"resource"`
+	ScopeSpans []scopeSpans `json:"scopeSpans"`
+}
+
+type scopeSpans struct {
+	Scope scope      `json:"scope"`
+	Spans []spanJSON `json:"spans"`
+}
+
+type spanJSON struct {
+	TraceID           string     `json:"traceId"`
+	SpanID            string     `json:"spanId"`
+	ParentSpanID      string     `json:"parentSpanId,omitempty"`
+	Name              string     `json:"name"`
+	Kind              int        `json:"kind"`
+	StartTimeUnixNano string     `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string     `json:"endTimeUnixNano"`
+	Attributes        []keyValue `json:"attributes,omitempty"`
+	Status            *status    `json:"status,omitempty"`
+}
+
+type status struct {
+	Message string `json:"message,omitempty"`
+	Code    int    `json:"code"`
+}
+
+// request converts a batch of finished spans into an OTLP request.
+func (e *spanExporter) request(batch []*Span) *tracesRequest {
+	spans := make([]spanJSON, len(batch))
+	for i, s := range batch {
+		spans[i] = spanJSON{
+			TraceID:           hex.EncodeToString(s.sc.TraceID[:]),
+			SpanID:            hex.EncodeToString(s.sc.SpanID[:]),
+			Name:              s.name,
+			Kind:              1, // SPAN_KIND_INTERNAL
+			StartTimeUnixNano: formatTime(s.start),
+			EndTimeUnixNano:   formatTime(s.end),
+			Attributes:        s.attrs,
+		}
+		if s.parentID != [8]byte{} {
+			spans[i].ParentSpanID = hex.EncodeToString(s.parentID[:])
+		}
+		if s.err != "" {
+			spans[i].Status = &status{Message: s.err, Code: statusError}
+		}
+	}
+	return &tracesRequest{
+		ResourceSpans: []resourceSpans{{
+			Resource: e.resource,
+			ScopeSpans: []scopeSpans{{
+				Scope: instrumentationScope,
+				Spans: spans,
+			}},
+		}},
+	}
+}
+
+func randomSpanID() (id [8]byte) {
+	for id == [8]byte{} {
+		crand.Read(id[:])
+	}
+	return id
+}
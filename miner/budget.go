@@ -0,0 +1,121 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package miner
+
+import (
+	"errors"
+	runtimemetrics "runtime/metrics"
+
+	"github.com/ethereum/go-ethereum/metrics"
+)
+
+// heapAllocsMetric is the runtime metric of the cumulative heap allocations.
+const heapAllocsMetric = "/gc/heap/allocs:bytes"
+
+var (
+	errBlockInterruptedByBudget = errors.New("memory budget exhausted while building block")
+	errBuildWorkersExhausted    = errors.New("too many concurrent block builds")
+
+	buildAllocsHist   = metrics.NewRegisteredHistogram("miner/build/allocs", nil, metrics.NewExpDecaySample(1028, 0.015))
+	buildWorkersGauge = metrics.NewRegisteredGauge("miner/build/workers", nil)
+	overMemoryMeter   = metrics.NewRegisteredMeter("miner/build/overbudget/memory", nil)
+	overWorkersMeter  = metrics.NewRegisteredMeter("miner/build/overbudget/workers", nil)
+)
+
+// buildSlots limits the number of block builds filling transactions concurrently.
+// Every payload being built is refreshed on its own goroutine, so a flood of
+// payload requests could otherwise starve the build of the payload that counts.
+type buildSlots chan struct{}
+
+// newBuildSlots creates the build limiter, nil if builds are unbounded.
+func newBuildSlots(limit int) buildSlots {
+	if limit <= 0 {
+		return nil
+	}
+	return make(buildSlots, limit)
+}
+
+// acquire reserves a slot for a build, returning false if all are in use.
+func (s buildSlots) acquire() bool {
+	if s == nil {
+		return true
+	}
+	select {
+	case s <- struct{}{}:
+		buildWorkersGauge.Update(int64(len(s)))
+		return true
+	default:
+		return false
+	}
+}
+
+// release frees the slot of a finished build.
+func (s buildSlots) release() {
+	if s == nil {
+		return
+	}
+	<-s
+	buildWorkersGauge.Update(int64(len(s)))
+}
+
+// buildBudget tracks the memory allocated while filling a block, which is mostly
+// spent on the state copies and journals of the executed transactions.
+//
+// The allocations are measured process-wide since the Go runtime doesn't track
+// them per goroutine, so allocation-heavy work running in parallel (e.g. block
+// imports) is charged to the build as well. The budget is meant as a guard
+// against pathological transactions, not as a precise accounting.
+type buildBudget struct {
+	limit  uint64 // maximum bytes allocated during the build, 0 if unbounded
+	start  uint64 // cumulative heap allocations at the start of the build
+	sample []runtimemetrics.Sample
+}
+
+// newBuildBudget starts tracking the allocations of a build.
+func newBuildBudget(limit uint64) *buildBudget {
+	b := &buildBudget{
+		limit:  limit,
+		sample: []runtimemetrics.Sample{{Name: heapAllocsMetric}},
+	}
+	b.start = b.allocs()
+	return b
+}
+
+// allocs returns the cumulative heap allocations of the process.
+func (b *buildBudget) allocs() uint64 {
+	runtimemetrics.Read(b.sample)
+	if b.sample[0].Value.Kind() != runtimemetrics.KindUint64 {
+		return 0
+	}
+	return b.sample[0].Value.Uint64()
+}
+
+// used returns the bytes allocated since the start of the build.
+func (b *buildBudget) used() uint64 {
+	if now := b.allocs(); now > b.start {
+		return now - b.start
+	}
+	return 0
+}
+
+// exceeded reports whether the build allocated more than its budget.
+func (b *buildBudget) exceeded() bool {
+	if b == nil || b.limit == 0 {
+		return false
+	}
+	return b.used() > b.limit
+}
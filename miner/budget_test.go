@@ -0,0 +1,62 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package miner
+
+import "testing"
+
+var budgetSink [][]byte
+
+func TestBuildSlots(t *testing.T) {
+	slots := newBuildSlots(2)
+	if !slots.acquire() || !slots.acquire() {
+		t.Fatal("failed to acquire free slots")
+	}
+	if slots.acquire() {
+		t.Fatal("acquired slot over the limit")
+	}
+	slots.release()
+	if !slots.acquire() {
+		t.Fatal("failed to acquire released slot")
+	}
+	// Unbounded builds never run out of slots.
+	var unbounded buildSlots = newBuildSlots(0)
+	for i := 0; i < 10; i++ {
+		if !unbounded.acquire() {
+			t.Fatal("failed to acquire unbounded slot")
+		}
+	}
+}
+
+func TestBuildBudget(t *testing.T) {
+	budget := newBuildBudget(4 * 1024 * 1024)
+	if budget.exceeded() {
+		t.Fatal("budget exceeded without allocations")
+	}
+	for i := 0; i < 8; i++ {
+		budgetSink = append(budgetSink, make([]byte, 1024*1024))
+	}
+	if !budget.exceeded() {
+		t.Fatalf("budget not exceeded, allocated %d", budget.used())
+	}
+	budgetSink = nil
+
+	// Nil and zero budgets are unbounded.
+	var none *buildBudget
+	if none.exceeded() || newBuildBudget(0).exceeded() {
+		t.Fatal("unbounded budget exceeded")
+	}
+}
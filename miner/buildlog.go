@@ -0,0 +1,229 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package miner
+
+import (
+	"errors"
+	"maps"
+	"slices"
+
+	"github.com/ethereum/go-ethereum/beacon/engine"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/lru"
+	"github.com/ethereum/go-ethereum/core/txpool"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// maxBuildRecords is the number of payload builds kept in the replay log.
+const maxBuildRecords = 32
+
+// Sources of the transactions considered for a payload.
+const (
+	sourceBundle   = "bundle"
+	sourcePriority = "priority"
+	sourcePool     = "pool"
+)
+
+// Reasons for skipping a transaction, besides the policy verdicts and the
+// execution errors.
+const (
+	skipGasLeft       = "not enough gas left"
+	skipBlobSpace     = "not enough blob space left"
+	skipEvicted       = "evicted from the pool"
+	skipBlockSize     = "block size limit reached"
+	skipTipFloor      = "tip below floor"
+	skipReplayProtect = "replay protected before EIP-155"
+	skipNonceTooLow   = "nonce too low"
+)
+
+var errUnknownBuild = errors.New("unknown payload build")
+
+// BuildDecision is the outcome for a transaction considered for a payload.
+type BuildDecision struct {
+	Hash     common.Hash    `json:"hash"`
+	Sender   common.Address `json:"sender"`
+	Source   string         `json:"source"`
+	Included bool           `json:"included"`
+	Reason   string         `json:"reason,omitempty"`
+}
+
+// BuildRecord is the log of the build of the delivered version of a payload: the
+// attributes and the pool snapshot it started from, and the decision taken for
+// each transaction considered, in order. Transactions of the snapshot without a
+// decision were never reached, either because the block was full or because an
+// earlier transaction of the same account was skipped.
+type BuildRecord struct {
+	ID           engine.PayloadID  `json:"id"`
+	Parent       common.Hash       `json:"parentHash"`
+	Timestamp    hexutil.Uint64    `json:"timestamp"`
+	FeeRecipient common.Address    `json:"feeRecipient"`
+	Random       common.Hash       `json:"prevRandao"`
+	Withdrawals  types.Withdrawals `json:"withdrawals"`
+	BeaconRoot   *common.Hash      `json:"parentBeaconBlockRoot,omitempty"`
+	GasLimit     hexutil.Uint64    `json:"gasLimit"`
+	BaseFee      *hexutil.Big      `json:"baseFeePerGas,omitempty"`
+	Pending      []common.Hash     `json:"pending"`
+	Decisions    []BuildDecision   `json:"decisions"`
+	Interrupted  string            `json:"interrupted,omitempty"`
+	Block        common.Hash       `json:"blockHash"`
+
+	params  generateParams                 // Build parameters, for replaying
+	extra   []byte                         // Extra data of the header
+	senders map[common.Hash]common.Address // Senders of the snapshot transactions
+	source  string                         // Source of the transactions being committed
+	bundles []*types.Transaction           // Transactions of the bundle sources
+	floors  *tipFloors
+	policy  *txPolicy
+	locals  localsBudget
+
+	prioPlain, prioBlob     map[common.Address][]*txpool.LazyTransaction
+	normalPlain, normalBlob map[common.Address][]*txpool.LazyTransaction
+}
+
+// BuildReplay is the result of re-executing a recorded payload build.
+type BuildReplay struct {
+	Record    *BuildRecord    `json:"record"`
+	Decisions []BuildDecision `json:"decisions"`
+	Block     common.Hash     `json:"blockHash"`
+	Matches   bool            `json:"matches"` // Whether the replay built the recorded block
+}
+
+func newBuildRecord(params *generateParams, header *types.Header) *BuildRecord {
+	r := &BuildRecord{
+		Parent:       header.ParentHash,
+		Timestamp:    hexutil.Uint64(header.Time),
+		FeeRecipient: params.coinbase,
+		Random:       params.random,
+		Withdrawals:  params.withdrawals,
+		BeaconRoot:   params.beaconRoot,
+		GasLimit:     hexutil.Uint64(header.GasLimit),
+		BaseFee:      (*hexutil.Big)(header.BaseFee),
+		params:       *params,
+		extra:        header.Extra,
+		senders:      make(map[common.Hash]common.Address),
+	}
+	r.params.record, r.params.replay = false, nil
+	return r
+}
+
+// snapshot records the pending transactions the build starts from. The maps
+// are copied as the ordering consumes them.
+func (r *BuildRecord) snapshot(prioPlain, prioBlob, normalPlain, normalBlob map[common.Address][]*txpool.LazyTransaction, floors *tipFloors, policy *txPolicy, locals localsBudget) {
+	if r == nil {
+		return
+	}
+	r.prioPlain, r.prioBlob = maps.Clone(prioPlain), maps.Clone(prioBlob)
+	r.normalPlain, r.normalBlob = maps.Clone(normalPlain), maps.Clone(normalBlob)
+	r.floors, r.policy, r.locals = floors, policy, locals
+
+	for _, set := range []map[common.Address][]*txpool.LazyTransaction{prioPlain, prioBlob, normalPlain, normalBlob} {
+		for from, txs := range set {
+			for _, tx := range txs {
+				r.senders[tx.Hash] = from
+				r.Pending = append(r.Pending, tx.Hash)
+			}
+		}
+	}
+	slices.SortFunc(r.Pending, func(a, b common.Hash) int { return a.Cmp(b) })
+}
+
+// setSource sets the source of the transactions committed next.
+func (r *BuildRecord) setSource(source string) {
+	if r != nil {
+		r.source = source
+	}
+}
+
+// bundle records a transaction of a bundle source.
+func (r *BuildRecord) bundle(tx *types.Transaction, from common.Address) {
+	if r == nil {
+		return
+	}
+	r.bundles = append(r.bundles, tx)
+	r.senders[tx.Hash()] = from
+}
+
+// include records the inclusion of a transaction.
+func (r *BuildRecord) include(hash common.Hash) {
+	if r != nil {
+		r.Decisions = append(r.Decisions, BuildDecision{Hash: hash, Sender: r.senders[hash], Source: r.source, Included: true})
+	}
+}
+
+// skip records the exclusion of a transaction.
+func (r *BuildRecord) skip(hash common.Hash, reason string) {
+	if r != nil {
+		r.Decisions = append(r.Decisions, BuildDecision{Hash: hash, Sender: r.senders[hash], Source: r.source, Reason: reason})
+	}
+}
+
+// buildLog keeps the records of the latest payload builds.
+type buildLog struct {
+	records *lru.Cache[engine.PayloadID, *BuildRecord]
+}
+
+func newBuildLog() *buildLog {
+	return &buildLog{records: lru.NewCache[engine.PayloadID, *BuildRecord](maxBuildRecords)}
+}
+
+func (l *buildLog) add(record *BuildRecord) {
+	l.records.Add(record.ID, record)
+}
+
+func (l *buildLog) get(id engine.PayloadID) *BuildRecord {
+	record, _ := l.records.Get(id)
+	return record
+}
+
+// replayTransactions commits the transactions of a recorded build, in the
+// order the build considered them.
+func (miner *Miner) replayTransactions(env *environment, record *BuildRecord) error {
+	env.policy, env.locals = record.policy, record.locals
+
+	env.record.setSource(sourceBundle)
+	miner.commitBundle(env, record.bundles)
+
+	return miner.commitPending(env,
+		maps.Clone(record.prioPlain), maps.Clone(record.prioBlob),
+		maps.Clone(record.normalPlain), maps.Clone(record.normalBlob),
+		record.floors, nil)
+}
+
+// ReplayPayloadBuild re-executes the delivered build of a payload on its parent
+// state, with the recorded attributes, pool snapshot and inclusion settings,
+// and reports the decisions taken. The replay runs to completion, so it may go
+// further than a build interrupted by the recommit timeout.
+func (miner *Miner) ReplayPayloadBuild(id engine.PayloadID) (*BuildReplay, error) {
+	record := miner.buildLog.get(id)
+	if record == nil {
+		return nil, errUnknownBuild
+	}
+	params := record.params
+	params.replay = record
+
+	r := miner.generateWork(&params, false)
+	if r.err != nil {
+		return nil, r.err
+	}
+	return &BuildReplay{
+		Record:    record,
+		Decisions: r.record.Decisions,
+		Block:     r.block.Hash(),
+		Matches:   r.block.Hash() == record.Block,
+	}, nil
+}
@@ -0,0 +1,111 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package miner
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/beacon/engine"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func TestReplayPayloadBuild(t *testing.T) {
+	w, b := newTestWorker(t, params.TestChainConfig, ethash.NewFaker(), rawdb.NewMemoryDatabase(), 0)
+
+	args := &BuildPayloadArgs{
+		Parent:       b.chain.CurrentBlock().Hash(),
+		Timestamp:    uint64(time.Now().Unix()),
+		FeeRecipient: common.HexToAddress("0xdeadbeef"),
+	}
+	payload, err := w.buildPayload(args, false)
+	if err != nil {
+		t.Fatalf("Failed to build payload %v", err)
+	}
+	full := payload.ResolveFull()
+
+	replay, err := w.ReplayPayloadBuild(payload.id)
+	if err != nil {
+		t.Fatalf("Failed to replay payload build: %v", err)
+	}
+	if !replay.Matches || replay.Block != full.ExecutionPayload.BlockHash {
+		t.Fatalf("Replay built a different block: have %x, want %x", replay.Block, full.ExecutionPayload.BlockHash)
+	}
+	want := []BuildDecision{{Hash: pendingTxs[0].Hash(), Sender: testBankAddress, Source: sourcePool, Included: true}}
+	if !reflect.DeepEqual(replay.Record.Decisions, want) {
+		t.Fatalf("Wrong recorded decisions: have %+v, want %+v", replay.Record.Decisions, want)
+	}
+	if !reflect.DeepEqual(replay.Decisions, want) {
+		t.Fatalf("Wrong replayed decisions: have %+v, want %+v", replay.Decisions, want)
+	}
+	if len(replay.Record.Pending) != 1 || replay.Record.Pending[0] != pendingTxs[0].Hash() {
+		t.Fatalf("Wrong pool snapshot: %v", replay.Record.Pending)
+	}
+	if _, err := w.ReplayPayloadBuild(engine.PayloadID{1}); !errors.Is(err, errUnknownBuild) {
+		t.Fatalf("Wrong error for unknown payload: have %v, want %v", err, errUnknownBuild)
+	}
+}
+
+func TestBuildLocalsBudget(t *testing.T) {
+	hash := pendingTxs[0].Hash()
+	tests := []struct {
+		budget uint64
+		want   []BuildDecision
+	}{
+		// Unbounded lane, the local transaction is included with priority.
+		{0, []BuildDecision{{Hash: hash, Sender: testBankAddress, Source: sourcePriority, Included: true}}},
+		// Budget fitting the transaction.
+		{params.TxGas, []BuildDecision{{Hash: hash, Sender: testBankAddress, Source: sourcePriority, Included: true}}},
+		// Budget too low for any transaction, the lane is skipped and the local
+		// transaction competes with the others.
+		{params.TxGas - 1, []BuildDecision{
+			{Hash: hash, Sender: testBankAddress, Source: sourcePool, Included: true},
+		}},
+	}
+	for i, test := range tests {
+		w, b := newTestWorker(t, params.TestChainConfig, ethash.NewFaker(), rawdb.NewMemoryDatabase(), 0)
+		w.SetPrioAddresses([]common.Address{testBankAddress})
+		w.config.LocalsGas = test.budget
+
+		payload, err := w.buildPayload(&BuildPayloadArgs{
+			Parent:       b.chain.CurrentBlock().Hash(),
+			Timestamp:    uint64(time.Now().Unix()),
+			FeeRecipient: common.HexToAddress("0xdeadbeef"),
+		}, false)
+		if err != nil {
+			t.Fatalf("test %d: failed to build payload: %v", i, err)
+		}
+		if have := len(payload.ResolveFull().ExecutionPayload.Transactions); have != 1 {
+			t.Fatalf("test %d: wrong transaction count: have %d, want 1", i, have)
+		}
+		replay, err := w.ReplayPayloadBuild(payload.id)
+		if err != nil {
+			t.Fatalf("test %d: failed to replay payload build: %v", i, err)
+		}
+		if !reflect.DeepEqual(replay.Record.Decisions, test.want) {
+			t.Errorf("test %d: wrong decisions: have %+v, want %+v", i, replay.Record.Decisions, test.want)
+		}
+		if !replay.Matches {
+			t.Errorf("test %d: replay built a different block", i)
+		}
+	}
+}
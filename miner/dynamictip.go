@@ -0,0 +1,95 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package miner
+
+import (
+	"slices"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/txpool"
+	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/holiman/uint256"
+)
+
+// dynamicTipPressure is the number of blocks worth of pending gas above which
+// the pool is considered under pressure, activating the dynamic minimum tip.
+const dynamicTipPressure = 2
+
+// dynamicTipGauge is the dynamic minimum tip enforced in the last built block.
+var dynamicTipGauge = metrics.NewRegisteredGauge("miner/tip/dynamic", nil)
+
+// effectiveTip returns the tip per gas the transaction pays at the given base fee.
+func effectiveTip(tx *txpool.LazyTransaction, baseFee *uint256.Int) *uint256.Int {
+	if baseFee == nil {
+		return tx.GasTipCap
+	}
+	tip := new(uint256.Int).Sub(tx.GasFeeCap, baseFee)
+	if tip.Gt(tx.GasTipCap) {
+		return tx.GasTipCap
+	}
+	return tip
+}
+
+// dynamicTip returns the minimum effective tip the pending transactions have to
+// pay to be included in a block with the given gas limit. While the pool holds
+// less than dynamicTipPressure blocks worth of gas, nil is returned. Otherwise
+// the floor is the given percentile of the effective tips of the pending
+// transactions, capped at max if set.
+func dynamicTip(pending map[common.Address][]*txpool.LazyTransaction, gasLimit uint64, baseFee *uint256.Int, percentile int, max *uint256.Int) *uint256.Int {
+	if percentile <= 0 {
+		return nil
+	}
+	var (
+		gas  uint64
+		tips []*uint256.Int
+	)
+	for _, txs := range pending {
+		for _, tx := range txs {
+			gas += tx.Gas
+			tips = append(tips, effectiveTip(tx, baseFee))
+		}
+	}
+	if gas <= dynamicTipPressure*gasLimit {
+		return nil
+	}
+	slices.SortFunc(tips, func(a, b *uint256.Int) int { return a.Cmp(b) })
+
+	floor := tips[min((len(tips)-1)*percentile/100, len(tips)-1)]
+	if max != nil && !max.IsZero() && floor.Gt(max) {
+		floor = max
+	}
+	return floor
+}
+
+// filterByTip drops the pending transactions paying an effective tip below the
+// floor, along with the subsequent transactions of the same account which would
+// be unexecutable without them.
+func filterByTip(pending map[common.Address][]*txpool.LazyTransaction, baseFee *uint256.Int, floor *uint256.Int) {
+	for addr, txs := range pending {
+		for i, tx := range txs {
+			if effectiveTip(tx, baseFee).Lt(floor) {
+				txs = txs[:i]
+				break
+			}
+		}
+		if len(txs) == 0 {
+			delete(pending, addr)
+		} else {
+			pending[addr] = txs
+		}
+	}
+}
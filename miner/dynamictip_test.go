@@ -0,0 +1,69 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package miner
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/txpool"
+	"github.com/holiman/uint256"
+)
+
+func TestDynamicTip(t *testing.T) {
+	lazy := func(tip uint64) *txpool.LazyTransaction {
+		return &txpool.LazyTransaction{
+			GasFeeCap: uint256.NewInt(100 + tip),
+			GasTipCap: uint256.NewInt(tip),
+			Gas:       100,
+		}
+	}
+	var (
+		a, b    = common.Address{0xa}, common.Address{0xb}
+		baseFee = uint256.NewInt(100)
+		pending = map[common.Address][]*txpool.LazyTransaction{
+			a: {lazy(1), lazy(2), lazy(3), lazy(4), lazy(5)},
+			b: {lazy(6), lazy(7), lazy(8), lazy(9), lazy(10)},
+		}
+	)
+	// No floor without pool pressure
+	if floor := dynamicTip(pending, 500, baseFee, 50, nil); floor != nil {
+		t.Fatalf("floor set without pressure: %v", floor)
+	}
+	// The percentile of the tips is required under pressure
+	floor := dynamicTip(pending, 400, baseFee, 50, nil)
+	if floor == nil || floor.Uint64() != 5 {
+		t.Fatalf("floor mismatch: have %v, want 5", floor)
+	}
+	if capped := dynamicTip(pending, 400, baseFee, 50, uint256.NewInt(3)); capped == nil || capped.Uint64() != 3 {
+		t.Fatalf("capped floor mismatch: have %v, want 3", capped)
+	}
+	// Filtering drops whole accounts below the floor, and keeps others intact
+	pending[a] = []*txpool.LazyTransaction{lazy(5), lazy(4), lazy(6)}
+	filterByTip(pending, baseFee, floor)
+	if have := len(pending[a]); have != 1 {
+		t.Fatalf("filtered account transaction count mismatch: have %d, want 1", have)
+	}
+	if have := len(pending[b]); have != 5 {
+		t.Fatalf("intact account transaction count mismatch: have %d, want 5", have)
+	}
+	// The effective tip is capped by the fee cap above the base fee
+	filterByTip(pending, uint256.NewInt(104), floor)
+	if _, ok := pending[a]; ok {
+		t.Fatalf("account paying below the floor at higher base fee retained")
+	}
+}
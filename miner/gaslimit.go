@@ -0,0 +1,88 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package miner
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
+)
+
+// ErrGasCeilOutOfBounds is returned if a requested gas ceiling lies outside the
+// configured gas limit floor and ceiling.
+var ErrGasCeilOutOfBounds = errors.New("gas limit target out of bounds")
+
+// gasCeilRejectedMeter counts the gas limit targets rejected by the guardrails.
+var gasCeilRejectedMeter = metrics.NewRegisteredMeter("miner/gaslimit/rejected", nil)
+
+// checkGasCeil validates a gas limit target against the configured bounds.
+func (c *Config) checkGasCeil(ceil uint64) error {
+	if c.GasLimitMin != 0 && ceil < c.GasLimitMin {
+		return fmt.Errorf("%w: %d below minimum %d", ErrGasCeilOutOfBounds, ceil, c.GasLimitMin)
+	}
+	if c.GasLimitMax != 0 && ceil > c.GasLimitMax {
+		return fmt.Errorf("%w: %d above maximum %d", ErrGasCeilOutOfBounds, ceil, c.GasLimitMax)
+	}
+	return nil
+}
+
+// clampGasCeil forces a gas limit target into the configured bounds.
+func (c *Config) clampGasCeil(ceil uint64) uint64 {
+	if c.GasLimitMin != 0 && ceil < c.GasLimitMin {
+		return c.GasLimitMin
+	}
+	if c.GasLimitMax != 0 && ceil > c.GasLimitMax {
+		return c.GasLimitMax
+	}
+	return ceil
+}
+
+// calcGasLimit computes the gas limit of a block built on top of a parent with
+// the given gas limit. On top of the protocol bounds enforced by CalcGasLimit,
+// the change is capped to GasLimitStep if configured. The caller must hold the
+// config lock.
+func (c *Config) calcGasLimit(parentGasLimit uint64) uint64 {
+	limit := core.CalcGasLimit(parentGasLimit, c.GasCeil)
+	if step := c.GasLimitStep; step != 0 {
+		switch {
+		case limit > parentGasLimit && limit-parentGasLimit > step:
+			limit = parentGasLimit + step
+		case limit < parentGasLimit && parentGasLimit-limit > step:
+			limit = parentGasLimit - step
+		}
+	}
+	return limit
+}
+
+// SetGasCeil sets the gaslimit to strive for when mining blocks post 1559.
+// For pre-1559 blocks, it sets the ceiling. Targets outside the configured
+// bounds are rejected.
+func (miner *Miner) SetGasCeil(ceil uint64) error {
+	miner.confMu.Lock()
+	defer miner.confMu.Unlock()
+
+	if err := miner.config.checkGasCeil(ceil); err != nil {
+		gasCeilRejectedMeter.Mark(1)
+		log.Warn("Rejected gas limit target", "target", ceil, "err", err)
+		return err
+	}
+	miner.config.GasCeil = ceil
+	return nil
+}
@@ -0,0 +1,59 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package miner
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCalcGasLimitStep(t *testing.T) {
+	tests := []struct {
+		parent, ceil, step, want uint64
+	}{
+		{30_000_000, 60_000_000, 0, 30_000_000 + 30_000_000/1024 - 1}, // protocol bound
+		{30_000_000, 60_000_000, 1000, 30_001_000},                    // capped increase
+		{30_000_000, 10_000_000, 1000, 29_999_000},                    // capped decrease
+		{30_000_000, 30_000_500, 1000, 30_000_500},                    // within step
+		{30_000_000, 30_000_000, 1000, 30_000_000},                    // at target
+	}
+	for i, tt := range tests {
+		config := Config{GasCeil: tt.ceil, GasLimitStep: tt.step}
+		if have := config.calcGasLimit(tt.parent); have != tt.want {
+			t.Errorf("test %d: gas limit mismatch: have %d, want %d", i, have, tt.want)
+		}
+	}
+}
+
+func TestSetGasCeilBounds(t *testing.T) {
+	miner := &Miner{config: &Config{GasCeil: 30_000_000, GasLimitMin: 20_000_000, GasLimitMax: 40_000_000}}
+
+	if err := miner.SetGasCeil(35_000_000); err != nil {
+		t.Fatalf("target within bounds rejected: %v", err)
+	}
+	for _, ceil := range []uint64{19_999_999, 40_000_001} {
+		if err := miner.SetGasCeil(ceil); !errors.Is(err, ErrGasCeilOutOfBounds) {
+			t.Fatalf("target %d: expected out of bounds error, got %v", ceil, err)
+		}
+	}
+	if miner.config.GasCeil != 35_000_000 {
+		t.Fatalf("rejected target applied: have %d", miner.config.GasCeil)
+	}
+	if have := miner.config.clampGasCeil(1); have != 20_000_000 {
+		t.Fatalf("wrong clamped target: have %d", have)
+	}
+}
@@ -30,6 +30,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/state"
 	"github.com/ethereum/go-ethereum/core/txpool"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/params"
 )
 
@@ -46,9 +47,21 @@ type Config struct {
 	PendingFeeRecipient common.Address `toml:"-"`          // Address for pending block rewards.
 	ExtraData           hexutil.Bytes  `toml:",omitempty"` // Block extra data set by the miner
 	GasCeil             uint64         // Target gas ceiling for mined blocks.
+	GasLimitMin         uint64         `toml:",omitempty"` // Lowest accepted gas limit target (0 = unbounded)
+	GasLimitMax         uint64         `toml:",omitempty"` // Highest accepted gas limit target (0 = unbounded)
+	GasLimitStep        uint64         `toml:",omitempty"` // Maximum gas limit change per block (0 = protocol bound only)
 	GasPrice            *big.Int       // Minimum gas price for mining a transaction
 	Recommit            time.Duration  // The time interval for miner to re-create mining work.
 	MaxBlobsPerBlock    int            // Maximum number of blobs per block (0 for unset uses protocol default)
+	LocalsGas           uint64         `toml:",omitempty"` // Gas budget of the priority lane of local transactions (0 = unbounded)
+	LocalsBlobs         int            `toml:",omitempty"` // Blob budget of the priority lane of local transactions (0 = unbounded)
+	TipFloors           []TipFloor     `toml:",omitempty"` // Minimum tips of transaction classes, on top of GasPrice
+	TipPercentile       int            `toml:",omitempty"` // Percentile of pending tips required from remote transactions under pool pressure (0 = disabled)
+	TipMax              *big.Int       `toml:",omitempty"` // Upper bound of the dynamic minimum tip (nil = unbounded)
+	Policy              TxPolicy       `toml:",omitempty"` // Addresses and selectors transactions may interact with
+	PolicyFile          string         `toml:",omitempty"` // File the policy is loaded from, reloadable at runtime
+	BuildWorkers        int            `toml:",omitempty"` // Maximum number of concurrent block builds (0 = unbounded)
+	BuildMemory         uint64         `toml:",omitempty"` // Maximum bytes allocated while filling a block (0 = unbounded)
 }
 
 // DefaultConfig contains default settings for miner.
@@ -66,12 +79,16 @@ var DefaultConfig = Config{
 // Miner is the main object which takes care of submitting new work to consensus
 // engine and gathering the sealing result.
 type Miner struct {
-	confMu      sync.RWMutex // The lock used to protect the config fields: GasCeil, GasTip and Extradata
+	confMu      sync.RWMutex // The lock used to protect the config fields: GasCeil, GasTip, Extradata and Recommit
 	config      *Config
 	chainConfig *params.ChainConfig
 	engine      consensus.Engine
 	txpool      *txpool.TxPool
 	prio        []common.Address // A list of senders to prioritize
+	policy      *txPolicy        // Transaction inclusion policy, nil if none
+	bundles     []BundleSource   // Sources of node-built transactions
+	buildLog    *buildLog        // Records of the latest payload builds
+	slots       buildSlots       // Limiter of the concurrent block builds
 	chain       *core.BlockChain
 	pending     *pending
 	pendingMu   sync.Mutex // Lock protects the pending block
@@ -79,16 +96,41 @@ type Miner struct {
 
 // New creates a new miner with provided config.
 func New(eth Backend, config Config, engine consensus.Engine) *Miner {
+	if err := config.checkGasCeil(config.GasCeil); err != nil {
+		ceil := config.clampGasCeil(config.GasCeil)
+		log.Warn("Clamping gas limit target to the configured bounds", "target", config.GasCeil, "updated", ceil)
+		config.GasCeil = ceil
+	}
 	return &Miner{
 		config:      &config,
 		chainConfig: eth.BlockChain().Config(),
 		engine:      engine,
 		txpool:      eth.TxPool(),
+		policy:      newTxPolicy(config.Policy),
 		chain:       eth.BlockChain(),
 		pending:     &pending{},
+		buildLog:    newBuildLog(),
+		slots:       newBuildSlots(config.BuildWorkers),
 	}
 }
 
+// BundleSource provides transactions assembled by the node itself, such as the
+// bundles of an integrated ERC-4337 bundler. They are included ahead of the
+// transactions of the pool.
+type BundleSource interface {
+	// Bundle returns the transactions to include on top of the given state,
+	// which may be modified freely.
+	Bundle(header *types.Header, statedb *state.StateDB) []*types.Transaction
+}
+
+// AddBundleSource adds a source of the transactions included ahead of the
+// pool transactions. The sources are queried in the order they were added.
+func (miner *Miner) AddBundleSource(src BundleSource) {
+	miner.confMu.Lock()
+	miner.bundles = append(miner.bundles, src)
+	miner.confMu.Unlock()
+}
+
 // Pending returns the currently pending block and associated receipts, logs
 // and statedb. The returned values can be nil in case the pending block is
 // not initialized.
@@ -118,22 +160,43 @@ func (miner *Miner) SetPrioAddresses(prio []common.Address) {
 	miner.confMu.Unlock()
 }
 
-// SetGasCeil sets the gaslimit to strive for when mining blocks post 1559.
-// For pre-1559 blocks, it sets the ceiling.
-func (miner *Miner) SetGasCeil(ceil uint64) {
+// SetGasTip sets the minimum gas tip for inclusion.
+func (miner *Miner) SetGasTip(tip *big.Int) error {
 	miner.confMu.Lock()
-	miner.config.GasCeil = ceil
+	miner.config.GasPrice = tip
 	miner.confMu.Unlock()
+	return nil
 }
 
-// SetGasTip sets the minimum gas tip for inclusion.
-func (miner *Miner) SetGasTip(tip *big.Int) error {
+// minRecommit is the shortest accepted payload rebuilding interval.
+const minRecommit = 100 * time.Millisecond
+
+// Recommit returns the interval at which payloads are rebuilt, which also
+// bounds the time spent filling a single payload with transactions.
+func (miner *Miner) Recommit() time.Duration {
+	miner.confMu.RLock()
+	defer miner.confMu.RUnlock()
+	return miner.config.Recommit
+}
+
+// SetRecommit sets the interval at which payloads are rebuilt.
+func (miner *Miner) SetRecommit(interval time.Duration) error {
+	if interval < minRecommit {
+		return fmt.Errorf("recommit interval %v below minimum %v", interval, minRecommit)
+	}
 	miner.confMu.Lock()
-	miner.config.GasPrice = tip
+	miner.config.Recommit = interval
 	miner.confMu.Unlock()
 	return nil
 }
 
+// GasCeil returns the gas limit target of built blocks.
+func (miner *Miner) GasCeil() uint64 {
+	miner.confMu.RLock()
+	defer miner.confMu.RUnlock()
+	return miner.config.GasCeil
+}
+
 // BuildPayload builds the payload according to the provided parameters.
 func (miner *Miner) BuildPayload(args *BuildPayloadArgs, witness bool) (*Payload, error) {
 	return miner.buildPayload(args, witness)
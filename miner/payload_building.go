@@ -28,11 +28,21 @@ import (
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/stateless"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/rlp"
 )
 
+var (
+	// Revenue of the fee recipient in the latest built payload, in gwei
+	revenueTotalGauge     = metrics.NewRegisteredGauge("miner/payload/revenue/total", nil)
+	revenuePriorityGauge  = metrics.NewRegisteredGauge("miner/payload/revenue/priority", nil)
+	revenueTransfersGauge = metrics.NewRegisteredGauge("miner/payload/revenue/transfers", nil)
+	revenueBurntGauge     = metrics.NewRegisteredGauge("miner/payload/revenue/burnt", nil)
+)
+
 // BuildPayloadArgs contains the provided parameters for building payload.
 // Check engine-api specification for more details.
 // https://github.com/ethereum/execution-apis/blob/main/src/engine/cancun.md#payloadattributesv3
@@ -78,6 +88,8 @@ type Payload struct {
 	emptyRequests [][]byte
 	requests      [][]byte
 	fullFees      *big.Int
+	fullRevenue   *engine.PayloadRevenue
+	log           *buildLog // Log to record the builds of the full block in, if any
 	stop          chan struct{}
 	lock          sync.Mutex
 	cond          *sync.Cond
@@ -113,9 +125,13 @@ func (payload *Payload) update(r *newPayloadResult, elapsed time.Duration) {
 	if payload.full == nil || r.fees.Cmp(payload.fullFees) > 0 {
 		payload.full = r.block
 		payload.fullFees = r.fees
+		payload.fullRevenue = r.revenue
 		payload.sidecars = r.sidecars
 		payload.requests = r.requests
 		payload.fullWitness = r.witness
+		if payload.log != nil && r.record != nil {
+			payload.log.add(r.record)
+		}
 
 		feesInEther := new(big.Float).Quo(new(big.Float).SetInt(r.fees), big.NewFloat(params.Ether))
 		log.Info("Updated payload",
@@ -129,10 +145,25 @@ func (payload *Payload) update(r *newPayloadResult, elapsed time.Duration) {
 			"root", r.block.Root(),
 			"elapsed", common.PrettyDuration(elapsed),
 		)
+		reportRevenue(r.revenue)
 	}
 	payload.cond.Broadcast() // fire signal for notifying full block
 }
 
+// reportRevenue updates the revenue metrics with the given payload revenue.
+func reportRevenue(revenue *engine.PayloadRevenue) {
+	if revenue == nil {
+		return
+	}
+	gwei := func(v *hexutil.Big) int64 {
+		return new(big.Int).Quo(v.ToInt(), big.NewInt(params.GWei)).Int64()
+	}
+	revenueTotalGauge.Update(gwei(revenue.Total))
+	revenuePriorityGauge.Update(gwei(revenue.PriorityFees))
+	revenueTransfersGauge.Update(gwei(revenue.DirectTransfers))
+	revenueBurntGauge.Update(gwei(revenue.BurntFees))
+}
+
 // Resolve returns the latest built payload and also terminates the background
 // thread for updating payload. It's safe to be called multiple times.
 func (payload *Payload) Resolve() *engine.ExecutionPayloadEnvelope {
@@ -146,6 +177,7 @@ func (payload *Payload) Resolve() *engine.ExecutionPayloadEnvelope {
 	}
 	if payload.full != nil {
 		envelope := engine.BlockToExecutableData(payload.full, payload.fullFees, payload.sidecars, payload.requests)
+		envelope.Revenue = payload.fullRevenue
 		if payload.fullWitness != nil {
 			envelope.Witness = new(hexutil.Bytes)
 			*envelope.Witness, _ = rlp.EncodeToBytes(payload.fullWitness) // cannot fail
@@ -198,6 +230,7 @@ func (payload *Payload) ResolveFull() *engine.ExecutionPayloadEnvelope {
 		close(payload.stop)
 	}
 	envelope := engine.BlockToExecutableData(payload.full, payload.fullFees, payload.sidecars, payload.requests)
+	envelope.Revenue = payload.fullRevenue
 	if payload.fullWitness != nil {
 		envelope.Witness = new(hexutil.Bytes)
 		*envelope.Witness, _ = rlp.EncodeToBytes(payload.fullWitness) // cannot fail
@@ -226,6 +259,7 @@ func (miner *Miner) buildPayload(args *BuildPayloadArgs, witness bool) (*Payload
 	}
 	// Construct a payload object for return.
 	payload := newPayload(empty.block, empty.requests, empty.witness, args.Id())
+	payload.log = miner.buildLog
 
 	// Spin up a routine for updating the payload in background. This strategy
 	// can maximum the revenue for including transactions with highest fee.
@@ -249,6 +283,8 @@ func (miner *Miner) buildPayload(args *BuildPayloadArgs, witness bool) (*Payload
 			withdrawals: args.Withdrawals,
 			beaconRoot:  args.BeaconRoot,
 			noTxs:       false,
+			record:      true,
+			evms:        new(vm.EVMPool), // Reused across the rebuilds of this routine
 		}
 
 		for {
@@ -257,11 +293,12 @@ func (miner *Miner) buildPayload(args *BuildPayloadArgs, witness bool) (*Payload
 				start := time.Now()
 				r := miner.generateWork(fullParams, witness)
 				if r.err == nil {
+					r.record.ID = payload.id
 					payload.update(r, time.Since(start))
 				} else {
 					log.Info("Error while generating work", "id", payload.id, "err", r.err)
 				}
-				timer.Reset(miner.config.Recommit)
+				timer.Reset(miner.Recommit())
 			case <-payload.stop:
 				log.Info("Stopping work on payload", "id", payload.id, "reason", "delivery")
 				return
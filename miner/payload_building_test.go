@@ -32,6 +32,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/txpool"
 	"github.com/ethereum/go-ethereum/core/txpool/legacypool"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/params"
@@ -103,7 +104,7 @@ type testWorkerBackend struct {
 	genesis *core.Genesis
 }
 
-func newTestWorkerBackend(t *testing.T, chainConfig *params.ChainConfig, engine consensus.Engine, db ethdb.Database, n int) *testWorkerBackend {
+func newTestWorkerBackend(t testing.TB, chainConfig *params.ChainConfig, engine consensus.Engine, db ethdb.Database, n int) *testWorkerBackend {
 	var gspec = &core.Genesis{
 		Config: chainConfig,
 		Alloc:  types.GenesisAlloc{testBankAddress: {Balance: testBankFunds}},
@@ -135,7 +136,7 @@ func newTestWorkerBackend(t *testing.T, chainConfig *params.ChainConfig, engine
 func (b *testWorkerBackend) BlockChain() *core.BlockChain { return b.chain }
 func (b *testWorkerBackend) TxPool() *txpool.TxPool       { return b.txPool }
 
-func newTestWorker(t *testing.T, chainConfig *params.ChainConfig, engine consensus.Engine, db ethdb.Database, blocks int) (*Miner, *testWorkerBackend) {
+func newTestWorker(t testing.TB, chainConfig *params.ChainConfig, engine consensus.Engine, db ethdb.Database, blocks int) (*Miner, *testWorkerBackend) {
 	backend := newTestWorkerBackend(t, chainConfig, engine, db, blocks)
 	backend.txPool.Add(pendingTxs, true)
 	w := New(backend, testConfig, engine)
@@ -184,6 +185,22 @@ func TestBuildPayload(t *testing.T) {
 	full := payload.ResolveFull()
 	verify(full, len(pendingTxs))
 
+	if empty.Revenue != nil {
+		t.Fatal("Unexpected revenue of empty payload")
+	}
+	if full.Revenue == nil {
+		t.Fatal("Missing revenue of full payload")
+	}
+	if full.Revenue.PriorityFees.ToInt().Cmp(full.BlockValue) != 0 {
+		t.Fatalf("Priority fees mismatch, want %v, got %v", full.BlockValue, full.Revenue.PriorityFees)
+	}
+	if full.Revenue.Total.ToInt().Cmp(full.BlockValue) != 0 {
+		t.Fatalf("Total revenue mismatch, want %v, got %v", full.BlockValue, full.Revenue.Total)
+	}
+	if full.Revenue.DirectTransfers.ToInt().Sign() != 0 {
+		t.Fatalf("Unexpected direct transfers %v", full.Revenue.DirectTransfers)
+	}
+
 	// Ensure resolve can be called multiple times and the
 	// result should be unchanged
 	dataOne := payload.Resolve()
@@ -193,6 +210,43 @@ func TestBuildPayload(t *testing.T) {
 	}
 }
 
+// BenchmarkBuildPayload measures repeated builds of a payload on top of the same
+// parent, like the rebuilds of a payload being updated, with and without the
+// EVMs being reused across the builds.
+func BenchmarkBuildPayload(b *testing.B) {
+	w, backend := newTestWorker(b, params.TestChainConfig, ethash.NewFaker(), rawdb.NewMemoryDatabase(), 0)
+
+	signer := types.LatestSigner(params.TestChainConfig)
+	for nonce := uint64(1); nonce <= 100; nonce++ {
+		tx := types.MustSignNewTx(testBankKey, signer, &types.LegacyTx{
+			Nonce:    nonce,
+			To:       &testUserAddress,
+			Value:    big.NewInt(1000),
+			Gas:      params.TxGas,
+			GasPrice: big.NewInt(params.InitialBaseFee),
+		})
+		if err := backend.txPool.Add([]*types.Transaction{tx}, true)[0]; err != nil {
+			b.Fatalf("failed to add transaction: %v", err)
+		}
+	}
+	build := func(b *testing.B, evms *vm.EVMPool) {
+		b.ReportAllocs()
+		for b.Loop() {
+			res := w.generateWork(&generateParams{
+				timestamp:  uint64(time.Now().Unix()),
+				parentHash: backend.chain.CurrentBlock().Hash(),
+				coinbase:   common.HexToAddress("0xdeadbeef"),
+				evms:       evms,
+			}, false)
+			if res.err != nil {
+				b.Fatalf("failed to build payload: %v", res.err)
+			}
+		}
+	}
+	b.Run("fresh", func(b *testing.B) { build(b, nil) })
+	b.Run("pooled", func(b *testing.B) { build(b, new(vm.EVMPool)) })
+}
+
 func TestPayloadId(t *testing.T) {
 	t.Parallel()
 	ids := make(map[string]int)
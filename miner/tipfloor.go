@@ -0,0 +1,125 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package miner
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/holiman/uint256"
+)
+
+// TipFloor is a minimum effective tip required for a class of transactions, on
+// top of the general minimum gas price. It allows charging more for transactions
+// which are expensive to include apart from their gas, e.g. large ones.
+type TipFloor struct {
+	Types   []uint8  `toml:",omitempty"` // Transaction types the floor applies to, all if empty
+	MinSize uint64   `toml:",omitempty"` // Minimum encoded size of transactions the floor applies to
+	MinTip  *big.Int // Minimum effective tip per gas
+}
+
+// ParseTipFloor parses a floor specification of the form
+// "<type>,<type>...:<min size>:<min tip>". The type list may be empty to match
+// all transaction types.
+func ParseTipFloor(spec string) (TipFloor, error) {
+	parts := strings.Split(spec, ":")
+	if len(parts) != 3 {
+		return TipFloor{}, fmt.Errorf("invalid tip floor %q", spec)
+	}
+	var floor TipFloor
+	if parts[0] != "" {
+		for _, s := range strings.Split(parts[0], ",") {
+			typ, err := strconv.ParseUint(s, 10, 8)
+			if err != nil {
+				return TipFloor{}, fmt.Errorf("invalid transaction type in tip floor %q: %v", spec, err)
+			}
+			floor.Types = append(floor.Types, uint8(typ))
+		}
+	}
+	size, err := strconv.ParseUint(parts[1], 10, 64)
+	if err != nil {
+		return TipFloor{}, fmt.Errorf("invalid size in tip floor %q: %v", spec, err)
+	}
+	floor.MinSize = size
+
+	tip, ok := new(big.Int).SetString(parts[2], 10)
+	if !ok {
+		return TipFloor{}, fmt.Errorf("invalid tip in tip floor %q", spec)
+	}
+	floor.MinTip = tip
+	return floor, floor.Validate()
+}
+
+// Validate checks the floor for invalid values.
+func (f *TipFloor) Validate() error {
+	if f.MinTip == nil || f.MinTip.Sign() < 0 || f.MinTip.BitLen() > 256 {
+		return errors.New("invalid minimum tip")
+	}
+	return nil
+}
+
+// applies reports whether the floor applies to the given transaction.
+func (f *TipFloor) applies(tx *types.Transaction) bool {
+	if len(f.Types) > 0 && !slices.Contains(f.Types, tx.Type()) {
+		return false
+	}
+	return tx.Size() >= f.MinSize
+}
+
+// tipFloors is the set of floors in effect for a block, converted for quick
+// comparison against the effective tips of the transactions.
+type tipFloors struct {
+	floors []TipFloor
+	tips   []*uint256.Int
+}
+
+func newTipFloors(floors []TipFloor) *tipFloors {
+	t := &tipFloors{floors: floors}
+	for _, f := range floors {
+		t.tips = append(t.tips, uint256.MustFromBig(f.MinTip))
+	}
+	return t
+}
+
+// check returns the highest floor applying to the transaction, if its effective
+// tip is below it.
+func (t *tipFloors) check(tx *types.Transaction, tip *uint256.Int) (*uint256.Int, bool) {
+	var floor *uint256.Int
+	for i := range t.floors {
+		if t.floors[i].applies(tx) && (floor == nil || t.tips[i].Gt(floor)) {
+			floor = t.tips[i]
+		}
+	}
+	if floor == nil || !tip.Lt(floor) {
+		return nil, true
+	}
+	return floor, false
+}
+
+// InclusionRequirements returns the minimum gas tip and the tip floors which
+// transactions have to satisfy to be included in built blocks.
+func (miner *Miner) InclusionRequirements() (*big.Int, []TipFloor) {
+	miner.confMu.RLock()
+	defer miner.confMu.RUnlock()
+
+	return new(big.Int).Set(miner.config.GasPrice), slices.Clone(miner.config.TipFloors)
+}
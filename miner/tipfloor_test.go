@@ -0,0 +1,93 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package miner
+
+import (
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func TestParseTipFloor(t *testing.T) {
+	tests := []struct {
+		spec string
+		want TipFloor
+		fail bool
+	}{
+		{spec: ":0:1000", want: TipFloor{MinTip: big.NewInt(1000)}},
+		{spec: "2,3:4096:2000000000", want: TipFloor{Types: []uint8{2, 3}, MinSize: 4096, MinTip: big.NewInt(2000000000)}},
+		{spec: "2:4096", fail: true},
+		{spec: "256::1", fail: true},
+		{spec: "2:-1:1", fail: true},
+		{spec: "2:0:-1", fail: true},
+		{spec: "2:0:abc", fail: true},
+	}
+	for _, test := range tests {
+		floor, err := ParseTipFloor(test.spec)
+		if test.fail {
+			if err == nil {
+				t.Errorf("%q: expected error", test.spec)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", test.spec, err)
+		} else if !reflect.DeepEqual(floor, test.want) {
+			t.Errorf("%q: wrong floor: have %+v, want %+v", test.spec, floor, test.want)
+		}
+	}
+}
+
+func TestBuildPayloadTipFloors(t *testing.T) {
+	tests := []struct {
+		floors []TipFloor
+		txs    int
+	}{
+		// No floor, the pending access list transaction is included.
+		{nil, len(pendingTxs)},
+		// Floor for other transaction types.
+		{[]TipFloor{{Types: []uint8{types.DynamicFeeTxType}, MinTip: big.NewInt(params.GWei)}}, len(pendingTxs)},
+		// Floor for larger transactions.
+		{[]TipFloor{{MinSize: 1024, MinTip: big.NewInt(params.GWei)}}, len(pendingTxs)},
+		// Floor applying to the transaction.
+		{[]TipFloor{{Types: []uint8{types.AccessListTxType}, MinTip: big.NewInt(params.GWei)}}, 0},
+		{[]TipFloor{{MinTip: big.NewInt(1)}, {MinTip: big.NewInt(params.GWei)}}, 0},
+	}
+	for i, test := range tests {
+		w, b := newTestWorker(t, params.TestChainConfig, ethash.NewFaker(), rawdb.NewMemoryDatabase(), 0)
+		w.config.TipFloors = test.floors
+
+		payload, err := w.buildPayload(&BuildPayloadArgs{
+			Parent:       b.chain.CurrentBlock().Hash(),
+			Timestamp:    uint64(time.Now().Unix()),
+			FeeRecipient: common.HexToAddress("0xdeadbeef"),
+		}, false)
+		if err != nil {
+			t.Fatalf("test %d: failed to build payload: %v", i, err)
+		}
+		if have := len(payload.ResolveFull().ExecutionPayload.Transactions); have != test.txs {
+			t.Errorf("test %d: wrong transaction count: have %d, want %d", i, have, test.txs)
+		}
+	}
+}
@@ -0,0 +1,257 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package miner
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/lru"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
+)
+
+// errTxPolicy is returned when the execution of a transaction touches an address
+// denied by the transaction policy.
+var errTxPolicy = errors.New("transaction excluded by policy")
+
+var policyExcludedMeter = metrics.NewRegisteredMeter("miner/policy/excluded", nil)
+
+// policyAuditCacheSize is the number of excluded transactions remembered to only
+// log their exclusion once, blocks being rebuilt repeatedly from the same pool.
+const policyAuditCacheSize = 4096
+
+// Selector is the 4-byte function selector of a contract call.
+type Selector [4]byte
+
+// MarshalText implements encoding.TextMarshaler.
+func (s Selector) MarshalText() ([]byte, error) {
+	return hexutil.Bytes(s[:]).MarshalText()
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *Selector) UnmarshalText(input []byte) error {
+	return hexutil.UnmarshalFixedText("Selector", input, s[:])
+}
+
+// String implements fmt.Stringer.
+func (s Selector) String() string {
+	return hexutil.Encode(s[:])
+}
+
+// TxPolicy restricts the transactions included in built blocks based on the
+// addresses and contract functions they interact with. Transactions excluded by
+// the policy stay in the pool.
+//
+// A transaction is excluded if its sender, its recipient, the authorities and
+// delegates of its authorizations, or any address accessed by its execution is
+// denied, or if it calls a denied selector. If allowed addresses are set, either
+// the sender or the recipient of the transaction must be allowed. If allowed
+// selectors are set, contract calls must use one of them.
+type TxPolicy struct {
+	DenyAddresses  []common.Address `toml:",omitempty" json:"denyAddresses,omitempty"`
+	AllowAddresses []common.Address `toml:",omitempty" json:"allowAddresses,omitempty"`
+	DenySelectors  []Selector       `toml:",omitempty" json:"denySelectors,omitempty"`
+	AllowSelectors []Selector       `toml:",omitempty" json:"allowSelectors,omitempty"`
+}
+
+// LoadTxPolicy reads a JSON encoded transaction policy from the given file.
+func LoadTxPolicy(path string) (TxPolicy, error) {
+	blob, err := os.ReadFile(path)
+	if err != nil {
+		return TxPolicy{}, err
+	}
+	var policy TxPolicy
+	dec := json.NewDecoder(bytes.NewReader(blob))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&policy); err != nil {
+		return TxPolicy{}, fmt.Errorf("invalid transaction policy %s: %v", path, err)
+	}
+	return policy, nil
+}
+
+// Empty reports whether the policy doesn't exclude any transaction.
+func (p *TxPolicy) Empty() bool {
+	return len(p.DenyAddresses) == 0 && len(p.AllowAddresses) == 0 && len(p.DenySelectors) == 0 && len(p.AllowSelectors) == 0
+}
+
+// txPolicy is the transaction policy converted for quick lookups.
+type txPolicy struct {
+	denyAddrs  map[common.Address]struct{}
+	allowAddrs map[common.Address]struct{}
+	denySels   map[Selector]struct{}
+	allowSels  map[Selector]struct{}
+
+	audited *lru.Cache[common.Hash, struct{}] // Excluded transactions already logged
+}
+
+func newTxPolicy(p TxPolicy) *txPolicy {
+	if p.Empty() {
+		return nil
+	}
+	set := func(items []common.Address) map[common.Address]struct{} {
+		if len(items) == 0 {
+			return nil
+		}
+		m := make(map[common.Address]struct{}, len(items))
+		for _, item := range items {
+			m[item] = struct{}{}
+		}
+		return m
+	}
+	sels := func(items []Selector) map[Selector]struct{} {
+		if len(items) == 0 {
+			return nil
+		}
+		m := make(map[Selector]struct{}, len(items))
+		for _, item := range items {
+			m[item] = struct{}{}
+		}
+		return m
+	}
+	return &txPolicy{
+		denyAddrs:  set(p.DenyAddresses),
+		allowAddrs: set(p.AllowAddresses),
+		denySels:   sels(p.DenySelectors),
+		allowSels:  sels(p.AllowSelectors),
+		audited:    lru.NewCache[common.Hash, struct{}](policyAuditCacheSize),
+	}
+}
+
+func (p *txPolicy) denied(addr common.Address) bool {
+	_, ok := p.denyAddrs[addr]
+	return ok
+}
+
+func (p *txPolicy) allowed(addr common.Address) bool {
+	_, ok := p.allowAddrs[addr]
+	return ok
+}
+
+// check returns the reason the transaction from the given sender is excluded
+// by the policy, if any. It only considers the transaction itself, not the
+// addresses accessed by its execution.
+func (p *txPolicy) check(tx *types.Transaction, from common.Address) string {
+	if p == nil {
+		return ""
+	}
+	to := tx.To()
+	if p.denied(from) {
+		return "denied sender"
+	}
+	if to != nil && p.denied(*to) {
+		return "denied recipient"
+	}
+	for _, auth := range tx.SetCodeAuthorizations() {
+		if p.denied(auth.Address) {
+			return "denied delegate"
+		}
+		if authority, err := auth.Authority(); err == nil && p.denied(authority) {
+			return "denied authority"
+		}
+	}
+	if p.allowAddrs != nil && !p.allowed(from) && (to == nil || !p.allowed(*to)) {
+		return "sender and recipient not allowed"
+	}
+	if to != nil && len(tx.Data()) >= 4 {
+		sel := Selector(tx.Data()[:4])
+		if _, ok := p.denySels[sel]; ok {
+			return "denied selector"
+		}
+		if _, ok := p.allowSels[sel]; p.allowSels != nil && !ok {
+			return "selector not allowed"
+		}
+	}
+	return ""
+}
+
+// checksAccessed reports whether the policy inspects the addresses accessed by
+// the execution of the transactions.
+func (p *txPolicy) checksAccessed() bool {
+	return p != nil && len(p.denyAddrs) > 0
+}
+
+// checkAccessed returns the denied address accessed by the execution of the
+// current transaction, if any. The accessed addresses are tracked by the access
+// list of the state, which is only maintained from the Berlin fork, and must be
+// checked before the transaction is finalised to be able to revert it.
+func (p *txPolicy) checkAccessed(db *state.StateDB, coinbase common.Address) (common.Address, bool) {
+	if p == nil {
+		return common.Address{}, false
+	}
+	for addr := range p.denyAddrs {
+		// The fee recipient is warm for every transaction since Shanghai
+		if addr != coinbase && db.AddressInAccessList(addr) {
+			return addr, true
+		}
+	}
+	return common.Address{}, false
+}
+
+// audit logs the exclusion of a transaction, once per transaction.
+func (p *txPolicy) audit(tx *types.Transaction, from common.Address, reason string, ctx ...interface{}) {
+	policyExcludedMeter.Mark(1)
+	if p.audited.Contains(tx.Hash()) {
+		return
+	}
+	p.audited.Add(tx.Hash(), struct{}{})
+	log.Info("Excluded transaction by policy", append([]interface{}{"hash", tx.Hash(), "from", from, "to", tx.To(), "reason", reason}, ctx...)...)
+}
+
+// TxPolicy returns the transaction policy in effect.
+func (miner *Miner) TxPolicy() TxPolicy {
+	miner.confMu.RLock()
+	defer miner.confMu.RUnlock()
+
+	return miner.config.Policy
+}
+
+// SetTxPolicy replaces the transaction policy in effect. It applies from the
+// next block built.
+func (miner *Miner) SetTxPolicy(policy TxPolicy) {
+	miner.confMu.Lock()
+	miner.config.Policy = policy
+	miner.policy = newTxPolicy(policy)
+	miner.confMu.Unlock()
+
+	log.Info("Updated transaction policy", "denyaddrs", len(policy.DenyAddresses), "allowaddrs", len(policy.AllowAddresses),
+		"denyselectors", len(policy.DenySelectors), "allowselectors", len(policy.AllowSelectors))
+}
+
+// ReloadTxPolicy reloads the transaction policy from the configured file.
+func (miner *Miner) ReloadTxPolicy() error {
+	miner.confMu.RLock()
+	path := miner.config.PolicyFile
+	miner.confMu.RUnlock()
+
+	if path == "" {
+		return errors.New("no transaction policy file configured")
+	}
+	policy, err := LoadTxPolicy(path)
+	if err != nil {
+		return err
+	}
+	miner.SetTxPolicy(policy)
+	return nil
+}
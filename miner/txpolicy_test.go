@@ -0,0 +1,186 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package miner
+
+import (
+	"math/big"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/txpool"
+	"github.com/ethereum/go-ethereum/core/txpool/legacypool"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func TestTxPolicyCheck(t *testing.T) {
+	var (
+		from   = common.HexToAddress("0x01")
+		to     = common.HexToAddress("0x02")
+		plain  = types.NewTx(&types.LegacyTx{To: &to})
+		call   = types.NewTx(&types.LegacyTx{To: &to, Data: []byte{0xa9, 0x05, 0x9c, 0xbb, 0x00}})
+		create = types.NewTx(&types.LegacyTx{Data: []byte{0xa9, 0x05, 0x9c, 0xbb}})
+	)
+	tests := []struct {
+		policy TxPolicy
+		tx     *types.Transaction
+		denied bool
+	}{
+		{TxPolicy{}, call, false},
+		{TxPolicy{DenyAddresses: []common.Address{from}}, call, true},
+		{TxPolicy{DenyAddresses: []common.Address{to}}, call, true},
+		{TxPolicy{DenyAddresses: []common.Address{to}}, create, false},
+		{TxPolicy{AllowAddresses: []common.Address{from}}, call, false},
+		{TxPolicy{AllowAddresses: []common.Address{to}}, call, false},
+		{TxPolicy{AllowAddresses: []common.Address{to}}, create, true},
+		{TxPolicy{DenySelectors: []Selector{{0xa9, 0x05, 0x9c, 0xbb}}}, call, true},
+		{TxPolicy{DenySelectors: []Selector{{0xa9, 0x05, 0x9c, 0xbb}}}, create, false},
+		{TxPolicy{AllowSelectors: []Selector{{0xa9, 0x05, 0x9c, 0xbb}}}, call, false},
+		{TxPolicy{AllowSelectors: []Selector{{0x09, 0x5e, 0xa7, 0xb3}}}, call, true},
+		{TxPolicy{AllowSelectors: []Selector{{0x09, 0x5e, 0xa7, 0xb3}}}, plain, false},
+	}
+	for i, test := range tests {
+		reason := newTxPolicy(test.policy).check(test.tx, from)
+		if denied := reason != ""; denied != test.denied {
+			t.Errorf("test %d: wrong result: have %v (%q), want %v", i, denied, reason, test.denied)
+		}
+	}
+}
+
+func TestLoadTxPolicy(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "policy.json")
+	blob := `{"denyAddresses": ["0x0000000000000000000000000000000000000001"], "allowSelectors": ["0xa9059cbb"]}`
+	if err := os.WriteFile(path, []byte(blob), 0644); err != nil {
+		t.Fatal(err)
+	}
+	policy, err := LoadTxPolicy(path)
+	if err != nil {
+		t.Fatalf("failed to load policy: %v", err)
+	}
+	want := TxPolicy{
+		DenyAddresses:  []common.Address{common.HexToAddress("0x01")},
+		AllowSelectors: []Selector{{0xa9, 0x05, 0x9c, 0xbb}},
+	}
+	if !reflect.DeepEqual(policy, want) {
+		t.Fatalf("wrong policy: have %+v, want %+v", policy, want)
+	}
+	if err := os.WriteFile(path, []byte(`{"denySelectors": ["0xa9059c"]}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadTxPolicy(path); err == nil {
+		t.Fatal("expected error for short selector")
+	}
+}
+
+func TestBuildPayloadTxPolicy(t *testing.T) {
+	tests := []struct {
+		policy TxPolicy
+		txs    int
+	}{
+		{TxPolicy{}, len(pendingTxs)},
+		{TxPolicy{DenyAddresses: []common.Address{common.HexToAddress("0xdead")}}, len(pendingTxs)},
+		{TxPolicy{DenyAddresses: []common.Address{testBankAddress}}, 0},
+		{TxPolicy{DenyAddresses: []common.Address{testUserAddress}}, 0},
+		{TxPolicy{AllowAddresses: []common.Address{testUserAddress}}, len(pendingTxs)},
+		{TxPolicy{AllowAddresses: []common.Address{common.HexToAddress("0xdead")}}, 0},
+	}
+	for i, test := range tests {
+		w, b := newTestWorker(t, params.TestChainConfig, ethash.NewFaker(), rawdb.NewMemoryDatabase(), 0)
+		w.SetTxPolicy(test.policy)
+
+		payload, err := w.buildPayload(&BuildPayloadArgs{
+			Parent:       b.chain.CurrentBlock().Hash(),
+			Timestamp:    uint64(time.Now().Unix()),
+			FeeRecipient: common.HexToAddress("0xdeadbeef"),
+		}, false)
+		if err != nil {
+			t.Fatalf("test %d: failed to build payload: %v", i, err)
+		}
+		if have := len(payload.ResolveFull().ExecutionPayload.Transactions); have != test.txs {
+			t.Errorf("test %d: wrong transaction count: have %d, want %d", i, have, test.txs)
+		}
+	}
+}
+
+// Tests that a transaction accessing a denied address during its execution is
+// excluded from the block, and that the block stays valid without it.
+func TestBuildPayloadTxPolicyAccessed(t *testing.T) {
+	var (
+		denied   = common.HexToAddress("0xdead")
+		contract = common.HexToAddress("0xc0de")
+		signer   = types.LatestSigner(params.TestChainConfig)
+		gspec    = &core.Genesis{
+			Config: params.TestChainConfig,
+			Alloc: types.GenesisAlloc{
+				testBankAddress: {Balance: testBankFunds},
+				testUserAddress: {Balance: testBankFunds},
+				// PUSH20 <denied> BALANCE POP STOP
+				contract: {Code: append(append([]byte{0x73}, denied.Bytes()...), 0x31, 0x50, 0x00)},
+			},
+		}
+		engine = ethash.NewFaker()
+	)
+	chain, err := core.NewBlockChain(rawdb.NewMemoryDatabase(), gspec, engine, nil)
+	if err != nil {
+		t.Fatalf("failed to create chain: %v", err)
+	}
+	defer chain.Stop()
+	pool, _ := txpool.New(testTxPoolConfig.PriceLimit, chain, []txpool.SubPool{legacypool.New(testTxPoolConfig, chain)})
+	defer pool.Close()
+
+	accessing := types.MustSignNewTx(testBankKey, signer, &types.LegacyTx{
+		To:       &contract,
+		Gas:      100000,
+		GasPrice: big.NewInt(params.InitialBaseFee),
+	})
+	plain := types.MustSignNewTx(testUserKey, signer, &types.LegacyTx{
+		To:       &testBankAddress,
+		Value:    big.NewInt(1000),
+		Gas:      params.TxGas,
+		GasPrice: big.NewInt(params.InitialBaseFee),
+	})
+	for _, err := range pool.Add([]*types.Transaction{accessing, plain}, true) {
+		if err != nil {
+			t.Fatalf("failed to add transaction: %v", err)
+		}
+	}
+	w := New(&testWorkerBackend{chain: chain, txPool: pool, genesis: gspec}, testConfig, engine)
+	w.SetTxPolicy(TxPolicy{DenyAddresses: []common.Address{denied}})
+
+	res := w.generateWork(&generateParams{
+		timestamp:  uint64(time.Now().Unix()),
+		parentHash: chain.CurrentBlock().Hash(),
+		coinbase:   common.HexToAddress("0xdeadbeef"),
+	}, false)
+	if res.err != nil {
+		t.Fatalf("failed to build block: %v", res.err)
+	}
+	if txs := res.block.Transactions(); len(txs) != 1 || txs[0].Hash() != plain.Hash() {
+		t.Fatalf("wrong transactions included: have %d, want only %x", len(txs), plain.Hash())
+	}
+	// The state of the excluded transaction must have been reverted.
+	if _, err := chain.InsertChain(types.Blocks{res.block}); err != nil {
+		t.Fatalf("built block is invalid: %v", err)
+	}
+}
@@ -17,13 +17,18 @@
 package miner
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"maps"
 	"math/big"
+	"runtime/pprof"
 	"sync/atomic"
 	"time"
 
+	"github.com/ethereum/go-ethereum/beacon/engine"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/consensus/misc/eip1559"
 	"github.com/ethereum/go-ethereum/consensus/misc/eip4844"
 	"github.com/ethereum/go-ethereum/core"
@@ -33,6 +38,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics/otlp"
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/holiman/uint256"
 )
@@ -41,6 +47,9 @@ var (
 	errBlockInterruptedByNewHead  = errors.New("new head arrived while building block")
 	errBlockInterruptedByRecommit = errors.New("recommit interrupt while building block")
 	errBlockInterruptedByTimeout  = errors.New("timeout while building block")
+
+	// miningProfileLabels are the pprof labels attached to block building.
+	miningProfileLabels = pprof.Labels("subsystem", "mining")
 )
 
 // maxBlobsPerBlock returns the maximum number of blobs per block.
@@ -62,7 +71,14 @@ type environment struct {
 	size     uint64         // size of the block we are building
 	gasPool  *core.GasPool  // available gas used to pack transactions
 	coinbase common.Address
+	balance  *uint256.Int // Balance of the fee recipient before the block
 	evm      *vm.EVM
+	policy   *txPolicy    // Transaction inclusion policy, nil if none
+	record   *BuildRecord // Log of the build decisions, nil if not recorded
+
+	locals    localsBudget // Budgets of the priority lane of local transactions
+	blobLimit int          // Blob limit of the lane being filled, 0 if unbounded
+	budget    *buildBudget // Memory budget of the build, nil if unbounded
 
 	header   *types.Header
 	txs      []*types.Transaction
@@ -73,6 +89,13 @@ type environment struct {
 	witness *stateless.Witness
 }
 
+// localsBudget is the gas and blob budget of the priority lane of the local
+// transactions in a block. Zero values leave the lane unbounded.
+type localsBudget struct {
+	gas   uint64
+	blobs int
+}
+
 // txFits reports whether the transaction fits into the block size limit.
 func (env *environment) txFitsSize(tx *types.Transaction) bool {
 	return env.size+tx.Size() < params.MaxBlockSize-maxBlockSizeBufferZone
@@ -95,11 +118,13 @@ type newPayloadResult struct {
 	err      error
 	block    *types.Block
 	fees     *big.Int               // total block fees
+	revenue  *engine.PayloadRevenue // revenue of the fee recipient
 	sidecars []*types.BlobTxSidecar // collected blobs of blob transactions
 	stateDB  *state.StateDB         // StateDB after executing the transactions
 	receipts []*types.Receipt       // Receipts collected during construction
 	requests [][]byte               // Consensus layer requests collected during block construction
 	witness  *stateless.Witness     // Witness is an optional stateless proof
+	record   *BuildRecord           // Log of the build decisions, if recorded
 }
 
 // generateParams wraps various settings for generating sealing task.
@@ -112,14 +137,42 @@ type generateParams struct {
 	withdrawals types.Withdrawals // List of withdrawals to include in block (shanghai field)
 	beaconRoot  *common.Hash      // The beacon root (cancun field).
 	noTxs       bool              // Flag whether an empty block without any transaction is expected
+	record      bool              // Flag whether the build decisions are to be recorded
+	replay      *BuildRecord      // Recorded build to replay instead of filling from the pool
+	evms        *vm.EVMPool       // EVMs of the building goroutine, nil to construct fresh ones
 }
 
 // generateWork generates a sealing block based on the given parameters.
-func (miner *Miner) generateWork(genParam *generateParams, witness bool) *newPayloadResult {
+func (miner *Miner) generateWork(genParam *generateParams, witness bool) (result *newPayloadResult) {
+	// Attribute the profiling samples of the block building to the miner. The
+	// labels only apply for the duration of the build, pprof.Do reinstates the
+	// ones of the parent context afterwards.
+	pprof.Do(context.Background(), miningProfileLabels, func(ctx context.Context) {
+		result = miner.buildWork(ctx, genParam, witness)
+	})
+	return result
+}
+
+// buildWork assembles the sealing block requested by generateWork.
+func (miner *Miner) buildWork(ctx context.Context, genParam *generateParams, witness bool) (result *newPayloadResult) {
+	_, span := otlp.StartSpan(ctx, "miner.generateWork")
+	defer func() {
+		span.SetAttribute("empty", genParam.noTxs)
+		if result.block != nil {
+			span.SetAttribute("number", result.block.NumberU64())
+			span.SetAttribute("txs", len(result.block.Transactions()))
+			span.SetAttribute("gas_used", result.block.GasUsed())
+		}
+		span.SetError(result.err)
+		span.End()
+	}()
 	work, err := miner.prepareWork(genParam, witness)
 	if err != nil {
 		return &newPayloadResult{err: err}
 	}
+	// The EVM is only needed while assembling the block, return it to the pool
+	// afterwards to save the allocations of the next build.
+	defer genParam.evms.Put(work.evm)
 
 	// Check withdrawals fit max block size.
 	// Due to the cap on withdrawal count, this can actually never happen, but we still need to
@@ -131,16 +184,40 @@ func (miner *Miner) generateWork(genParam *generateParams, witness bool) *newPay
 	// Also add size of withdrawals to work block size.
 	work.size += uint64(genParam.withdrawals.Size())
 
-	if !genParam.noTxs {
+	if genParam.record || genParam.replay != nil {
+		work.record = newBuildRecord(genParam, work.header)
+	}
+	if genParam.replay != nil {
+		if err := miner.replayTransactions(work, genParam.replay); err != nil {
+			return &newPayloadResult{err: err}
+		}
+	} else if !genParam.noTxs {
+		if !miner.slots.acquire() {
+			overWorkersMeter.Mark(1)
+			return &newPayloadResult{err: errBuildWorkersExhausted}
+		}
+		defer miner.slots.release()
+
+		work.budget = newBuildBudget(miner.config.BuildMemory)
 		interrupt := new(atomic.Int32)
-		timer := time.AfterFunc(miner.config.Recommit, func() {
+		recommit := miner.Recommit()
+		timer := time.AfterFunc(recommit, func() {
 			interrupt.Store(commitInterruptTimeout)
 		})
 		defer timer.Stop()
 
 		err := miner.fillTransactions(interrupt, work)
 		if errors.Is(err, errBlockInterruptedByTimeout) {
-			log.Warn("Block building is interrupted", "allowance", common.PrettyDuration(miner.config.Recommit))
+			log.Warn("Block building is interrupted", "allowance", common.PrettyDuration(recommit))
+		}
+		allocs := work.budget.used()
+		buildAllocsHist.Update(int64(allocs))
+		if errors.Is(err, errBlockInterruptedByBudget) {
+			overMemoryMeter.Mark(1)
+			log.Warn("Block building exceeded memory budget", "allocated", common.StorageSize(allocs), "budget", common.StorageSize(miner.config.BuildMemory), "txs", len(work.txs))
+		}
+		if err != nil && work.record != nil {
+			work.record.Interrupted = err.Error()
 		}
 	}
 	body := types.Body{Transactions: work.txs, Withdrawals: genParam.withdrawals}
@@ -151,39 +228,35 @@ func (miner *Miner) generateWork(genParam *generateParams, witness bool) *newPay
 	}
 
 	// Collect consensus-layer requests if Prague is enabled.
-	var requests [][]byte
-	if miner.chainConfig.IsPrague(work.header.Number, work.header.Time) {
-		requests = [][]byte{}
-		// EIP-6110 deposits
-		if err := core.ParseDepositLogs(&requests, allLogs, miner.chainConfig); err != nil {
-			return &newPayloadResult{err: err}
-		}
-		// EIP-7002
-		if err := core.ProcessWithdrawalQueue(&requests, work.evm); err != nil {
-			return &newPayloadResult{err: err}
-		}
-		// EIP-7251 consolidations
-		if err := core.ProcessConsolidationQueue(&requests, work.evm); err != nil {
-			return &newPayloadResult{err: err}
-		}
+	requests, err := core.ProcessPostBlockActions(work.header, allLogs, work.evm)
+	if err != nil {
+		return &newPayloadResult{err: err}
 	}
 	if requests != nil {
 		reqHash := types.CalcRequestsHash(requests)
 		work.header.RequestsHash = &reqHash
 	}
 
+	// Measure the fee recipient balance before the withdrawals are credited
+	balance := work.state.GetBalance(work.coinbase).Clone()
+
 	block, err := miner.engine.FinalizeAndAssemble(miner.chain, work.header, work.state, &body, work.receipts)
 	if err != nil {
 		return &newPayloadResult{err: err}
 	}
+	if work.record != nil {
+		work.record.Block = block.Hash()
+	}
 	return &newPayloadResult{
 		block:    block,
 		fees:     totalFees(block, work.receipts),
+		revenue:  payloadRevenue(block, work.receipts, work.balance, balance),
 		sidecars: work.sidecars,
 		stateDB:  work.state,
 		receipts: work.receipts,
 		requests: requests,
 		witness:  work.witness,
+		record:   work.record,
 	}
 }
 
@@ -216,7 +289,7 @@ func (miner *Miner) prepareWork(genParams *generateParams, witness bool) (*envir
 	header := &types.Header{
 		ParentHash: parent.Hash(),
 		Number:     new(big.Int).Add(parent.Number, common.Big1),
-		GasLimit:   core.CalcGasLimit(parent.GasLimit, miner.config.GasCeil),
+		GasLimit:   miner.config.calcGasLimit(parent.GasLimit),
 		Time:       timestamp,
 		Coinbase:   genParams.coinbase,
 	}
@@ -224,6 +297,12 @@ func (miner *Miner) prepareWork(genParams *generateParams, witness bool) (*envir
 	if len(miner.config.ExtraData) != 0 {
 		header.Extra = miner.config.ExtraData
 	}
+	// Replays use the header fields of the recorded build, regardless of
+	// configuration changes since.
+	if genParams.replay != nil {
+		header.GasLimit = uint64(genParams.replay.GasLimit)
+		header.Extra = genParams.replay.extra
+	}
 	// Set the randomness field from the beacon chain if it's available.
 	if genParams.random != (common.Hash{}) {
 		header.MixDigest = genParams.random
@@ -233,7 +312,7 @@ func (miner *Miner) prepareWork(genParams *generateParams, witness bool) (*envir
 		header.BaseFee = eip1559.CalcBaseFee(miner.chainConfig, parent)
 		if !miner.chainConfig.IsLondon(parent.Number) {
 			parentGasLimit := parent.GasLimit * miner.chainConfig.ElasticityMultiplier()
-			header.GasLimit = core.CalcGasLimit(parentGasLimit, miner.config.GasCeil)
+			header.GasLimit = miner.config.calcGasLimit(parentGasLimit)
 		}
 	}
 	// Run the consensus preparation with the default or customized consensus engine.
@@ -255,27 +334,28 @@ func (miner *Miner) prepareWork(genParams *generateParams, witness bool) (*envir
 	// Could potentially happen if starting to mine in an odd state.
 	// Note genParams.coinbase can be different with header.Coinbase
 	// since clique algorithm can modify the coinbase field in header.
-	env, err := miner.makeEnv(parent, header, genParams.coinbase, witness)
+	env, err := miner.makeEnv(parent, header, genParams.coinbase, witness, genParams.evms)
 	if err != nil {
 		log.Error("Failed to create sealing context", "err", err)
 		return nil, err
 	}
-	if header.ParentBeaconRoot != nil {
-		core.ProcessBeaconBlockRoot(*header.ParentBeaconRoot, env.evm)
-	}
-	if miner.chainConfig.IsPrague(header.Number, header.Time) {
-		core.ProcessParentBlockHash(header.ParentHash, env.evm)
+	if err := core.ProcessPreBlockActions(header, env.evm); err != nil {
+		return nil, err
 	}
 	return env, nil
 }
 
 // makeEnv creates a new environment for the sealing block.
-func (miner *Miner) makeEnv(parent *types.Header, header *types.Header, coinbase common.Address, witness bool) (*environment, error) {
+func (miner *Miner) makeEnv(parent *types.Header, header *types.Header, coinbase common.Address, witness bool, evms *vm.EVMPool) (*environment, error) {
 	// Retrieve the parent state to execute on top.
 	state, err := miner.chain.StateAt(parent.Root)
 	if err != nil {
 		return nil, err
 	}
+	blockCtx, err := core.NewEVMBlockContext(header, miner.chain, &coinbase)
+	if err != nil {
+		return nil, err
+	}
 	if witness {
 		bundle, err := stateless.NewWitness(header, miner.chain)
 		if err != nil {
@@ -289,9 +369,10 @@ func (miner *Miner) makeEnv(parent *types.Header, header *types.Header, coinbase
 		state:    state,
 		size:     uint64(header.Size()),
 		coinbase: coinbase,
+		balance:  state.GetBalance(coinbase).Clone(),
 		header:   header,
 		witness:  state.Witness(),
-		evm:      vm.NewEVM(core.NewEVMBlockContext(header, miner.chain, &coinbase), state, miner.chainConfig, vm.Config{}),
+		evm:      evms.Get(blockCtx, state, miner.chainConfig, vm.Config{}),
 	}, nil
 }
 
@@ -343,23 +424,46 @@ func (miner *Miner) applyTransaction(env *environment, tx *types.Transaction) (*
 	var (
 		snap = env.state.Snapshot()
 		gp   = env.gasPool.Gas()
+		used = env.header.GasUsed
+
+		receipt *types.Receipt
+		err     error
 	)
-	receipt, err := core.ApplyTransaction(env.evm, env.gasPool, env.state, env.header, tx, &env.header.GasUsed)
+	if env.policy.checksAccessed() {
+		// Check the addresses accessed by the execution before the state is
+		// finalised, while the transaction can still be reverted.
+		check := func(msg *core.Message) error {
+			if addr, denied := env.policy.checkAccessed(env.state, env.coinbase); denied {
+				env.policy.audit(tx, msg.From, "denied address accessed", "address", addr)
+				return errTxPolicy
+			}
+			return nil
+		}
+		receipt, err = core.ApplyTransactionWithCheck(env.evm, env.gasPool, env.state, env.header, tx, &env.header.GasUsed, check)
+	} else {
+		receipt, err = core.ApplyTransaction(env.evm, env.gasPool, env.state, env.header, tx, &env.header.GasUsed)
+	}
 	if err != nil {
 		env.state.RevertToSnapshot(snap)
 		env.gasPool.SetGas(gp)
+		env.header.GasUsed = used
+		return nil, err
 	}
-	return receipt, err
+	return receipt, nil
 }
 
-func (miner *Miner) commitTransactions(env *environment, plainTxs, blobTxs *transactionsByPriceAndNonce, interrupt *atomic.Int32) error {
+func (miner *Miner) commitTransactions(env *environment, plainTxs, blobTxs *transactionsByPriceAndNonce, floors *tipFloors, interrupt *atomic.Int32) error {
 	var (
 		isCancun = miner.chainConfig.IsCancun(env.header.Number, env.header.Time)
 		gasLimit = env.header.GasLimit
+		maxBlobs = miner.maxBlobsPerBlock(env.header.Time)
 	)
 	if env.gasPool == nil {
 		env.gasPool = new(core.GasPool).AddGas(gasLimit)
 	}
+	if env.blobLimit > 0 {
+		maxBlobs = min(maxBlobs, env.blobLimit)
+	}
 	for {
 		// Check interruption signal and abort building if it's fired.
 		if interrupt != nil {
@@ -367,6 +471,11 @@ func (miner *Miner) commitTransactions(env *environment, plainTxs, blobTxs *tran
 				return signalToErr(signal)
 			}
 		}
+		// Abort building if the transactions executed so far used up the memory
+		// budget, sealing the block with what's been included.
+		if env.budget.exceeded() {
+			return errBlockInterruptedByBudget
+		}
 		// If we don't have enough gas for any further transactions then we're done.
 		if env.gasPool.Gas() < params.TxGas {
 			log.Trace("Not enough gas for further transactions", "have", env.gasPool, "want", params.TxGas)
@@ -374,7 +483,7 @@ func (miner *Miner) commitTransactions(env *environment, plainTxs, blobTxs *tran
 		}
 		// If we don't have enough blob space for any further blob transactions,
 		// skip that list altogether
-		if !blobTxs.Empty() && env.blobs >= miner.maxBlobsPerBlock(env.header.Time) {
+		if !blobTxs.Empty() && env.blobs >= maxBlobs {
 			log.Trace("Not enough blob space for further blob transactions")
 			blobTxs.Clear()
 			// Fall though to pick up any plain txs
@@ -382,6 +491,7 @@ func (miner *Miner) commitTransactions(env *environment, plainTxs, blobTxs *tran
 		// Retrieve the next transaction and abort if all done.
 		var (
 			ltx *txpool.LazyTransaction
+			tip *uint256.Int
 			txs *transactionsByPriceAndNonce
 		)
 		pltx, ptip := plainTxs.Peek()
@@ -389,14 +499,14 @@ func (miner *Miner) commitTransactions(env *environment, plainTxs, blobTxs *tran
 
 		switch {
 		case pltx == nil:
-			txs, ltx = blobTxs, bltx
+			txs, ltx, tip = blobTxs, bltx, btip
 		case bltx == nil:
-			txs, ltx = plainTxs, pltx
+			txs, ltx, tip = plainTxs, pltx, ptip
 		default:
 			if ptip.Lt(btip) {
-				txs, ltx = blobTxs, bltx
+				txs, ltx, tip = blobTxs, bltx, btip
 			} else {
-				txs, ltx = plainTxs, pltx
+				txs, ltx, tip = plainTxs, pltx, ptip
 			}
 		}
 		if ltx == nil {
@@ -405,6 +515,7 @@ func (miner *Miner) commitTransactions(env *environment, plainTxs, blobTxs *tran
 		// If we don't have enough space for the next transaction, skip the account.
 		if env.gasPool.Gas() < ltx.Gas {
 			log.Trace("Not enough gas left for transaction", "hash", ltx.Hash, "left", env.gasPool.Gas(), "needed", ltx.Gas)
+			env.record.skip(ltx.Hash, skipGasLeft)
 			txs.Pop()
 			continue
 		}
@@ -413,9 +524,10 @@ func (miner *Miner) commitTransactions(env *environment, plainTxs, blobTxs *tran
 		// blobs or not, however the max check panics when called on a chain without
 		// a defined schedule, so we need to verify it's safe to call.
 		if isCancun {
-			left := miner.maxBlobsPerBlock(env.header.Time) - env.blobs
+			left := maxBlobs - env.blobs
 			if left < int(ltx.BlobGas/params.BlobTxBlobGasPerBlob) {
 				log.Trace("Not enough blob space left for transaction", "hash", ltx.Hash, "left", left, "needed", ltx.BlobGas/params.BlobTxBlobGasPerBlob)
+				env.record.skip(ltx.Hash, skipBlobSpace)
 				txs.Pop()
 				continue
 			}
@@ -425,6 +537,7 @@ func (miner *Miner) commitTransactions(env *environment, plainTxs, blobTxs *tran
 		tx := ltx.Resolve()
 		if tx == nil {
 			log.Trace("Ignoring evicted transaction", "hash", ltx.Hash)
+			env.record.skip(ltx.Hash, skipEvicted)
 			txs.Pop()
 			continue
 		}
@@ -432,16 +545,34 @@ func (miner *Miner) commitTransactions(env *environment, plainTxs, blobTxs *tran
 		// if inclusion of the transaction would put the block size over the
 		// maximum we allow, don't add any more txs to the payload.
 		if !env.txFitsSize(tx) {
+			env.record.skip(ltx.Hash, skipBlockSize)
 			break
 		}
+		// Skip the account if the transaction doesn't pay the tip required for
+		// its class. Later transactions of the account can't be included either.
+		if floor, ok := floors.check(tx, tip); !ok {
+			log.Trace("Ignoring transaction below tip floor", "hash", ltx.Hash, "tip", tip, "floor", floor)
+			env.record.skip(ltx.Hash, skipTipFloor)
+			txs.Pop()
+			continue
+		}
 		// Error may be ignored here. The error has already been checked
 		// during transaction acceptance in the transaction pool.
 		from, _ := types.Sender(env.signer, tx)
 
+		// Skip the account if the transaction is excluded by the policy
+		if reason := env.policy.check(tx, from); reason != "" {
+			env.policy.audit(tx, from, reason)
+			env.record.skip(ltx.Hash, reason)
+			txs.Pop()
+			continue
+		}
+
 		// Check whether the tx is replay protected. If we're not in the EIP155 hf
 		// phase, start ignoring the sender until we do.
 		if tx.Protected() && !miner.chainConfig.IsEIP155(env.header.Number) {
 			log.Trace("Ignoring replay protected transaction", "hash", ltx.Hash, "eip155", miner.chainConfig.EIP155Block)
+			env.record.skip(ltx.Hash, skipReplayProtect)
 			txs.Pop()
 			continue
 		}
@@ -453,31 +584,70 @@ func (miner *Miner) commitTransactions(env *environment, plainTxs, blobTxs *tran
 		case errors.Is(err, core.ErrNonceTooLow):
 			// New head notification data race between the transaction pool and miner, shift
 			log.Trace("Skipping transaction with low nonce", "hash", ltx.Hash, "sender", from, "nonce", tx.Nonce())
+			env.record.skip(ltx.Hash, skipNonceTooLow)
 			txs.Shift()
 
 		case errors.Is(err, nil):
 			// Everything ok, collect the logs and shift in the next transaction from the same account
+			env.record.include(ltx.Hash)
 			txs.Shift()
 
 		default:
 			// Transaction is regarded as invalid, drop all consecutive transactions from
 			// the same sender because of `nonce-too-high` clause.
 			log.Debug("Transaction failed, account skipped", "hash", ltx.Hash, "err", err)
+			env.record.skip(ltx.Hash, err.Error())
 			txs.Pop()
 		}
 	}
 	return nil
 }
 
+// commitBundles includes the transactions of the bundle source, skipping the
+// ones failing to apply.
+func (miner *Miner) commitBundles(env *environment, src BundleSource) {
+	if env.gasPool == nil {
+		env.gasPool = new(core.GasPool).AddGas(env.header.GasLimit)
+	}
+	miner.commitBundle(env, src.Bundle(types.CopyHeader(env.header), env.state.Copy()))
+}
+
+// commitBundle includes the given bundle transactions, skipping the ones
+// failing to apply.
+func (miner *Miner) commitBundle(env *environment, txs []*types.Transaction) {
+	for _, tx := range txs {
+		if env.record != nil {
+			from, _ := types.Sender(env.signer, tx)
+			env.record.bundle(tx, from)
+		}
+		if err := miner.commitTransaction(env, tx); err != nil {
+			log.Debug("Skipping bundle transaction", "hash", tx.Hash(), "err", err)
+			env.record.skip(tx.Hash(), err.Error())
+			continue
+		}
+		env.record.include(tx.Hash())
+	}
+}
+
 // fillTransactions retrieves the pending transactions from the txpool and fills them
 // into the given sealing block. The transaction selection and ordering strategy can
 // be customized with the plugin in the future.
 func (miner *Miner) fillTransactions(interrupt *atomic.Int32, env *environment) error {
 	miner.confMu.RLock()
 	tip := miner.config.GasPrice
+	floors := newTipFloors(miner.config.TipFloors)
+	percentile, tipMax := miner.config.TipPercentile, miner.config.TipMax
 	prio := miner.prio
+	bundles := miner.bundles
+	env.policy = miner.policy
+	env.locals = localsBudget{gas: miner.config.LocalsGas, blobs: miner.config.LocalsBlobs}
 	miner.confMu.RUnlock()
 
+	env.record.setSource(sourceBundle)
+	for _, src := range bundles {
+		miner.commitBundles(env, src)
+	}
+
 	// Retrieve the pending transactions pre-filtered by the 1559/4844 dynamic fees
 	filter := txpool.PendingFilter{
 		MinTip: uint256.MustFromBig(tip),
@@ -516,26 +686,108 @@ func (miner *Miner) fillTransactions(interrupt *atomic.Int32, env *environment)
 			prioBlobTxs[account] = txs
 		}
 	}
-	// Fill the block with all available pending transactions.
+	// If the pool is under pressure, require a higher tip from the remote plain
+	// transactions to keep cheap spam from filling up the block.
+	var maxTip *uint256.Int
+	if tipMax != nil {
+		maxTip = uint256.MustFromBig(tipMax)
+	}
+	if floor := dynamicTip(normalPlainTxs, env.header.GasLimit, filter.BaseFee, percentile, maxTip); floor != nil {
+		filterByTip(normalPlainTxs, filter.BaseFee, floor)
+		dynamicTipGauge.Update(int64(floor.Uint64()))
+	} else {
+		dynamicTipGauge.Update(0)
+	}
+	return miner.commitPending(env, prioPlainTxs, prioBlobTxs, normalPlainTxs, normalBlobTxs, floors, interrupt)
+}
+
+// commitPending fills the block with the pending transactions, the ones of the
+// prioritized senders first.
+func (miner *Miner) commitPending(env *environment, prioPlainTxs, prioBlobTxs, normalPlainTxs, normalBlobTxs map[common.Address][]*txpool.LazyTransaction, floors *tipFloors, interrupt *atomic.Int32) error {
+	env.record.snapshot(prioPlainTxs, prioBlobTxs, normalPlainTxs, normalBlobTxs, floors, env.policy, env.locals)
+
 	if len(prioPlainTxs) > 0 || len(prioBlobTxs) > 0 {
+		// The ordering consumes the maps, keep the originals around to recover
+		// the transactions not fitting into the budgets of the lane.
+		prioPlain, prioBlob := maps.Clone(prioPlainTxs), maps.Clone(prioBlobTxs)
+
 		plainTxs := newTransactionsByPriceAndNonce(env.signer, prioPlainTxs, env.header.BaseFee)
 		blobTxs := newTransactionsByPriceAndNonce(env.signer, prioBlobTxs, env.header.BaseFee)
 
-		if err := miner.commitTransactions(env, plainTxs, blobTxs, interrupt); err != nil {
+		env.record.setSource(sourcePriority)
+		if err := miner.commitLocals(env, plainTxs, blobTxs, floors, interrupt); err != nil {
 			return err
 		}
+		// The local transactions beyond the budgets compete with the others.
+		if env.locals.gas > 0 || env.locals.blobs > 0 {
+			normalPlainTxs = withUnincluded(env, normalPlainTxs, prioPlain)
+			normalBlobTxs = withUnincluded(env, normalBlobTxs, prioBlob)
+		}
 	}
 	if len(normalPlainTxs) > 0 || len(normalBlobTxs) > 0 {
 		plainTxs := newTransactionsByPriceAndNonce(env.signer, normalPlainTxs, env.header.BaseFee)
 		blobTxs := newTransactionsByPriceAndNonce(env.signer, normalBlobTxs, env.header.BaseFee)
 
-		if err := miner.commitTransactions(env, plainTxs, blobTxs, interrupt); err != nil {
+		env.record.setSource(sourcePool)
+		if err := miner.commitTransactions(env, plainTxs, blobTxs, floors, interrupt); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
+// commitLocals fills the priority lane of the block with the transactions of
+// the local accounts, within the gas and blob budgets of the lane.
+func (miner *Miner) commitLocals(env *environment, plainTxs, blobTxs *transactionsByPriceAndNonce, floors *tipFloors, interrupt *atomic.Int32) error {
+	if env.gasPool == nil {
+		env.gasPool = new(core.GasPool).AddGas(env.header.GasLimit)
+	}
+	gasPool := env.gasPool
+	if env.locals.gas > 0 && env.locals.gas < gasPool.Gas() {
+		env.gasPool = new(core.GasPool).AddGas(env.locals.gas)
+	}
+	if env.locals.blobs > 0 {
+		env.blobLimit = env.blobs + env.locals.blobs
+	}
+	var (
+		lanePool = env.gasPool
+		laneGas  = lanePool.Gas()
+	)
+	err := miner.commitTransactions(env, plainTxs, blobTxs, floors, interrupt)
+
+	// Charge the gas used by the lane to the block
+	if lanePool != gasPool {
+		gasPool.SubGas(laneGas - lanePool.Gas())
+		env.gasPool = gasPool
+	}
+	env.blobLimit = 0
+	return err
+}
+
+// withUnincluded adds the transactions of the given accounts which weren't
+// included in the block to the pending set.
+func withUnincluded(env *environment, pending, accounts map[common.Address][]*txpool.LazyTransaction) map[common.Address][]*txpool.LazyTransaction {
+	included := make(map[common.Hash]struct{}, len(env.txs))
+	for _, tx := range env.txs {
+		included[tx.Hash()] = struct{}{}
+	}
+	for addr, txs := range accounts {
+		var left []*txpool.LazyTransaction
+		for _, tx := range txs {
+			if _, ok := included[tx.Hash]; !ok {
+				left = append(left, tx)
+			}
+		}
+		if len(left) > 0 {
+			if pending == nil {
+				pending = make(map[common.Address][]*txpool.LazyTransaction)
+			}
+			pending[addr] = left
+		}
+	}
+	return pending
+}
+
 // totalFees computes total consumed miner fees in Wei. Block transactions and receipts have to have the same order.
 func totalFees(block *types.Block, receipts []*types.Receipt) *big.Int {
 	feesWei := new(big.Int)
@@ -546,6 +798,33 @@ func totalFees(block *types.Block, receipts []*types.Receipt) *big.Int {
 	return feesWei
 }
 
+// payloadRevenue computes the revenue of the fee recipient of the block, given
+// its balance before and after the transactions. Block transactions and receipts
+// have to have the same order.
+func payloadRevenue(block *types.Block, receipts []*types.Receipt, before, after *uint256.Int) *engine.PayloadRevenue {
+	var (
+		tips  = totalFees(block, receipts)
+		total = new(big.Int).Sub(after.ToBig(), before.ToBig())
+		burnt = new(big.Int)
+		blobs = new(big.Int)
+	)
+	for _, receipt := range receipts {
+		if block.BaseFee() != nil {
+			burnt.Add(burnt, new(big.Int).Mul(new(big.Int).SetUint64(receipt.GasUsed), block.BaseFee()))
+		}
+		if receipt.BlobGasPrice != nil {
+			blobs.Add(blobs, new(big.Int).Mul(new(big.Int).SetUint64(receipt.BlobGasUsed), receipt.BlobGasPrice))
+		}
+	}
+	return &engine.PayloadRevenue{
+		PriorityFees:    (*hexutil.Big)(tips),
+		DirectTransfers: (*hexutil.Big)(new(big.Int).Sub(total, tips)),
+		Total:           (*hexutil.Big)(total),
+		BurntFees:       (*hexutil.Big)(burnt),
+		BlobFees:        (*hexutil.Big)(blobs),
+	}
+}
+
 // signalToErr converts the interruption signal to a concrete error type for return.
 // The given signal must be a valid interruption signal.
 func signalToErr(signal int32) error {
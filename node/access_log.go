@@ -0,0 +1,236 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package node
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand"
+	"net"
+	"os"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+const (
+	// accessLogQueue is the number of entries buffered for writing, further
+	// entries are dropped while the destination is not keeping up.
+	accessLogQueue = 4096
+
+	// accessLogRedialInterval is the minimum time between two attempts to
+	// connect to a socket destination.
+	accessLogRedialInterval = 5 * time.Second
+)
+
+// accessLogEntry is a line of the RPC access log.
+type accessLogEntry struct {
+	Time       time.Time `json:"t"`
+	Transport  string    `json:"transport"`
+	Remote     string    `json:"remote,omitempty"`
+	Client     string    `json:"client,omitempty"`
+	Method     string    `json:"method"`
+	ParamsSize int       `json:"paramsSize"`
+	Duration   float64   `json:"durationMs"`
+	Error      int       `json:"error,omitempty"`
+	Slow       bool      `json:"slow,omitempty"`
+}
+
+// accessLog writes a structured entry for the method calls served by the RPC
+// endpoints to a file or socket. Only a sample of the calls is logged, except
+// for the calls exceeding the slow call threshold, which are always logged and
+// reported in the node log too.
+type accessLog struct {
+	dest     string
+	sampling float64
+	slow     time.Duration
+
+	entries chan *accessLogEntry
+	dropped atomic.Uint64
+	quit    chan struct{}
+	done    chan struct{}
+}
+
+// newAccessLog creates an access log writing to dest, which is either a file
+// path or a socket address of the form unix://path, tcp://host:port or
+// udp://host:port. The file is opened right away, sockets are connected when
+// the first entry is written. A sampling rate of one logs all calls and a slow
+// threshold of zero disables the slow call log.
+func newAccessLog(dest string, sampling float64, slow time.Duration) (*accessLog, error) {
+	if sampling < 0 || sampling > 1 {
+		return nil, fmt.Errorf("invalid access log sampling rate %v", sampling)
+	}
+	l := &accessLog{
+		dest:     dest,
+		sampling: sampling,
+		slow:     slow,
+		entries:  make(chan *accessLogEntry, accessLogQueue),
+		quit:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+	var out io.WriteCloser
+	if _, _, ok := accessLogSocket(dest); !ok {
+		file, err := os.OpenFile(dest, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+		if err != nil {
+			return nil, err
+		}
+		out = file
+	}
+	go l.loop(out)
+	return l, nil
+}
+
+// accessLogSocket splits a socket destination into network and address.
+func accessLogSocket(dest string) (network, addr string, ok bool) {
+	for _, network := range []string{"unix", "tcp", "udp"} {
+		if addr, ok := strings.CutPrefix(dest, network+"://"); ok {
+			return network, addr, true
+		}
+	}
+	return "", "", false
+}
+
+// logCall is an rpc.CallLogger queueing an entry for the call, if it is sampled
+// or slow.
+func (l *accessLog) logCall(ctx context.Context, info rpc.CallInfo) {
+	slow := l.slow > 0 && info.Duration >= l.slow
+	if !slow && (l.sampling == 0 || rand.Float64() >= l.sampling) {
+		return
+	}
+	peer := rpc.PeerInfoFromContext(ctx)
+	entry := &accessLogEntry{
+		Time:       time.Now(),
+		Transport:  peer.Transport,
+		Remote:     peer.RemoteAddr,
+		Client:     accessLogClient(ctx),
+		Method:     info.Method,
+		ParamsSize: info.ParamsSize,
+		Duration:   float64(info.Duration) / float64(time.Millisecond),
+		Error:      info.ErrorCode,
+		Slow:       slow,
+	}
+	if slow {
+		log.Warn("Slow RPC call", "method", info.Method, "transport", entry.Transport, "remote", entry.Remote, "client", entry.Client, "elapsed", common.PrettyDuration(info.Duration))
+	}
+	select {
+	case l.entries <- entry:
+	default:
+		l.dropped.Add(1)
+	}
+}
+
+// accessLogClient identifies the client of a call: the name of the auth client
+// which signed the JWT, or a fingerprint of the API key. The raw API key is
+// never written to the log.
+func accessLogClient(ctx context.Context) string {
+	if client, _ := ctx.Value(authClientContextKey{}).(*authClient); client != nil {
+		return client.config.Name
+	}
+	if key, _ := ctx.Value(apiKeyContextKey{}).(string); key != "" {
+		hash := sha256.Sum256([]byte(key))
+		return "apikey:" + hex.EncodeToString(hash[:4])
+	}
+	return ""
+}
+
+func (l *accessLog) loop(out io.WriteCloser) {
+	defer close(l.done)
+
+	w := &accessLogWriter{dest: l.dest, out: out, dropped: &l.dropped}
+	defer w.close()
+
+	report := time.NewTicker(time.Minute)
+	defer report.Stop()
+
+	for {
+		select {
+		case <-l.quit:
+			// Write the queued entries before exiting.
+			for {
+				select {
+				case entry := <-l.entries:
+					w.write(entry)
+				default:
+					return
+				}
+			}
+		case <-report.C:
+			if n := l.dropped.Swap(0); n > 0 {
+				log.Warn("Dropped RPC access log entries", "count", n)
+			}
+		case entry := <-l.entries:
+			w.write(entry)
+		}
+	}
+}
+
+// close stops the log after writing the queued entries.
+func (l *accessLog) close() {
+	close(l.quit)
+	<-l.done
+}
+
+// accessLogWriter writes entries to the destination of the access log. Socket
+// destinations are connected lazily and reconnected after write failures.
+type accessLogWriter struct {
+	dest     string
+	out      io.WriteCloser
+	lastDial time.Time
+	dropped  *atomic.Uint64
+}
+
+func (w *accessLogWriter) write(entry *accessLogEntry) {
+	if w.out == nil {
+		// Connect to the socket, not trying too often if it's down.
+		if time.Since(w.lastDial) < accessLogRedialInterval {
+			w.dropped.Add(1)
+			return
+		}
+		w.lastDial = time.Now()
+		network, addr, _ := accessLogSocket(w.dest)
+		conn, err := net.DialTimeout(network, addr, time.Second)
+		if err != nil {
+			log.Debug("Failed to connect to RPC access log", "dest", w.dest, "err", err)
+			w.dropped.Add(1)
+			return
+		}
+		w.out = conn
+	}
+	line, _ := json.Marshal(entry)
+	if _, err := w.out.Write(append(line, '\n')); err != nil {
+		log.Debug("Failed to write RPC access log", "dest", w.dest, "err", err)
+		w.dropped.Add(1)
+		if _, _, ok := accessLogSocket(w.dest); ok {
+			w.out.Close()
+			w.out = nil
+		}
+	}
+}
+
+func (w *accessLogWriter) close() {
+	if w.out != nil {
+		w.out.Close()
+	}
+}
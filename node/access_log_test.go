@@ -0,0 +1,63 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package node
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestAccessLog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "access.log")
+	accessLog, err := newAccessLog(path, 1, time.Hour)
+	if err != nil {
+		t.Fatalf("failed to open access log: %v", err)
+	}
+	srv := createAndStartServer(t, &httpConfig{rpcEndpointConfig: rpcEndpointConfig{accessLog: accessLog}}, false, nil, nil)
+	url := "http://" + srv.listenAddr()
+	rpcRequest(t, url, "test_greet", APIKeyHeader, "key")
+	rpcRequest(t, url, "test_missing")
+	srv.stop()
+	accessLog.close()
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	var entries []accessLogEntry
+	for scanner := bufio.NewScanner(file); scanner.Scan(); {
+		var entry accessLogEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			t.Fatalf("invalid entry %q: %v", scanner.Text(), err)
+		}
+		entries = append(entries, entry)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("wrong number of entries: have %d, want 2", len(entries))
+	}
+	if e := entries[0]; e.Method != "test_greet" || e.Transport != "http" || e.Client == "" || e.Client == "key" || e.Error != 0 || e.Slow {
+		t.Errorf("wrong entry for successful call: %+v", e)
+	}
+	if e := entries[1]; e.Method != "test_missing" || e.Client != "" || e.Error != -32601 {
+		t.Errorf("wrong entry for failed call: %+v", e)
+	}
+}
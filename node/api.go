@@ -120,6 +120,42 @@ func (api *adminAPI) RemoveTrustedPeer(url string) (bool, error) {
 	return true, nil
 }
 
+// AddPeerGroup registers a named group of peers which are dialed with the given
+// priority and connection limits. An existing group of the same name is replaced.
+func (api *adminAPI) AddPeerGroup(group p2p.PeerGroup) (bool, error) {
+	// Make sure the server is running, fail otherwise
+	server := api.node.Server()
+	if server == nil {
+		return false, ErrNodeStopped
+	}
+	if err := server.AddPeerGroup(group); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// RemovePeerGroup unregisters a peer group. Connections to its members are kept.
+func (api *adminAPI) RemovePeerGroup(name string) (bool, error) {
+	// Make sure the server is running, fail otherwise
+	server := api.node.Server()
+	if server == nil {
+		return false, ErrNodeStopped
+	}
+	if err := server.RemovePeerGroup(name); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// PeerGroups retrieves the connection state and health of the peer groups.
+func (api *adminAPI) PeerGroups() ([]p2p.PeerGroupInfo, error) {
+	server := api.node.Server()
+	if server == nil {
+		return nil, ErrNodeStopped
+	}
+	return server.PeerGroupsInfo(), nil
+}
+
 // PeerEvents creates an RPC subscription which receives peer events from the
 // node's p2p.Server
 func (api *adminAPI) PeerEvents(ctx context.Context) (*rpc.Subscription, error) {
@@ -181,6 +217,11 @@ func (api *adminAPI) StartHTTP(host *string, port *int, cors *string, apis *stri
 		rpcEndpointConfig: rpcEndpointConfig{
 			batchItemLimit:         api.node.config.BatchRequestLimit,
 			batchResponseSizeLimit: api.node.config.BatchResponseMaxSize,
+			dedupMethods:           api.node.config.DedupMethods,
+			streamedMethods:        api.node.config.StreamedMethods,
+			drainRejectedMethods:   api.node.config.DrainRejectedMethods,
+			permissions:            api.node.rpcPermissions,
+			accessLog:              api.node.accessLog,
 		},
 	}
 	if cors != nil {
@@ -259,6 +300,11 @@ func (api *adminAPI) StartWS(host *string, port *int, allowedOrigins *string, ap
 		rpcEndpointConfig: rpcEndpointConfig{
 			batchItemLimit:         api.node.config.BatchRequestLimit,
 			batchResponseSizeLimit: api.node.config.BatchResponseMaxSize,
+			dedupMethods:           api.node.config.DedupMethods,
+			streamedMethods:        api.node.config.StreamedMethods,
+			drainRejectedMethods:   api.node.config.DrainRejectedMethods,
+			permissions:            api.node.rpcPermissions,
+			accessLog:              api.node.accessLog,
 		},
 	}
 	if apis != nil {
@@ -297,6 +343,66 @@ func (api *adminAPI) StopWS() (bool, error) {
 	return true, nil
 }
 
+// ReloadSecrets re-reads the JWT secret of the authenticated RPC endpoints from
+// disk, allowing it to be rotated without restarting the node.
+func (api *adminAPI) ReloadSecrets() (bool, error) {
+	if err := api.node.ReloadSecrets(); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// AddAuthClient registers an additional consumer of the authenticated RPC endpoints
+// with its own JWT secret, permitted methods and rate limit. An existing client with
+// the same name is replaced.
+func (api *adminAPI) AddAuthClient(client AuthClient) (bool, error) {
+	if err := api.node.AddAuthClient(client); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// RemoveAuthClient unregisters an additional consumer of the authenticated RPC
+// endpoints. Its tokens are rejected afterwards.
+func (api *adminAPI) RemoveAuthClient(name string) (bool, error) {
+	if err := api.node.RemoveAuthClient(name); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// AuthClients lists the additional consumers of the authenticated RPC endpoints.
+func (api *adminAPI) AuthClients() []AuthClient {
+	return api.node.AuthClients()
+}
+
+// StartDrain puts the public RPC endpoints into drain mode, allowing the node to be
+// taken out of a load balancer rotation without failing requests. If pauseTxPool is
+// set, the transaction pool also stops accepting new transactions.
+func (api *adminAPI) StartDrain(pauseTxPool *bool) (bool, error) {
+	api.node.StartDrain(pauseTxPool != nil && *pauseTxPool)
+	return true, nil
+}
+
+// StopDrain resumes normal operation of the public RPC endpoints.
+func (api *adminAPI) StopDrain() (bool, error) {
+	api.node.StopDrain()
+	return true, nil
+}
+
+// drainStatus reports the drain mode of the node.
+type drainStatus struct {
+	Draining bool `json:"draining"`
+	InFlight int  `json:"inFlight"` // requests still served by the public endpoints
+}
+
+// DrainStatus returns whether the node is draining and how many requests are still
+// in flight, so operators can wait for them to finish before restarting.
+func (api *adminAPI) DrainStatus() drainStatus {
+	draining, inflight := api.node.Draining()
+	return drainStatus{Draining: draining, InFlight: inflight}
+}
+
 // Peers retrieves all the information we know about each individual peer at the
 // protocol granularity.
 func (api *adminAPI) Peers() ([]*p2p.PeerInfo, error) {
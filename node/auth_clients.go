@@ -0,0 +1,212 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package node
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"golang.org/x/time/rate"
+)
+
+// defaultAuthClient is the name of the consumer authenticating with the node's
+// own JWT secret. It has access to all methods without rate limit.
+const defaultAuthClient = "default"
+
+const (
+	errcodeAuthMethodDenied = -32001
+	errcodeAuthRateLimited  = -32005
+)
+
+// AuthClient configures an additional consumer of the authenticated RPC endpoints.
+// Every client signs its tokens with its own secret, which determines the methods
+// it may call and how often.
+type AuthClient struct {
+	// Name identifies the client in logs and the admin API.
+	Name string `json:"name"`
+
+	// JWTSecret is the path to the hex-encoded jwt secret of the client.
+	JWTSecret string `json:"jwtSecret"`
+
+	// Methods lists the methods the client may call. An entry of the form
+	// "namespace_*" permits the whole namespace. If empty, all methods are allowed.
+	Methods []string `json:"methods,omitempty" toml:",omitempty"`
+
+	// RateLimit is the maximum number of calls per second. Zero means unlimited.
+	RateLimit float64 `json:"rateLimit,omitempty" toml:",omitempty"`
+}
+
+// ParseAuthClient parses a client specification of the form
+// "<name>:<secret file>:<method>|<method>...:<calls per second>". The method list
+// may be empty to allow all methods and a rate of zero disables rate limiting.
+func ParseAuthClient(spec string) (AuthClient, error) {
+	name, rest, ok := strings.Cut(spec, ":")
+	if !ok {
+		return AuthClient{}, fmt.Errorf("invalid auth client %q", spec)
+	}
+	// The secret path may contain colons itself, so split the rest from the right.
+	parts := strings.Split(rest, ":")
+	if len(parts) < 3 {
+		return AuthClient{}, fmt.Errorf("invalid auth client %q", spec)
+	}
+	var (
+		path    = strings.Join(parts[:len(parts)-2], ":")
+		methods = parts[len(parts)-2]
+		limit   = parts[len(parts)-1]
+	)
+	client := AuthClient{Name: name, JWTSecret: path}
+	if methods != "" {
+		client.Methods = strings.Split(methods, "|")
+	}
+	rps, err := strconv.ParseFloat(limit, 64)
+	if err != nil {
+		return AuthClient{}, fmt.Errorf("invalid rate limit of auth client %q: %v", name, err)
+	}
+	client.RateLimit = rps
+	return client, client.validate()
+}
+
+func (c *AuthClient) validate() error {
+	switch {
+	case c.Name == "":
+		return errors.New("auth client name missing")
+	case c.Name == defaultAuthClient:
+		return fmt.Errorf("auth client name %q is reserved", c.Name)
+	case c.JWTSecret == "":
+		return fmt.Errorf("jwt secret of auth client %q missing", c.Name)
+	case c.RateLimit < 0 || math.IsNaN(c.RateLimit) || math.IsInf(c.RateLimit, 0):
+		return fmt.Errorf("invalid rate limit of auth client %q", c.Name)
+	}
+	return nil
+}
+
+// authClient is a consumer of the authenticated endpoints.
+type authClient struct {
+	config  AuthClient
+	key     *jwtSecretKey
+	limiter *rate.Limiter // nil if unlimited
+}
+
+func newAuthClient(config AuthClient, key *jwtSecretKey) *authClient {
+	client := &authClient{config: config, key: key}
+	if config.RateLimit > 0 {
+		client.limiter = rate.NewLimiter(rate.Limit(config.RateLimit), max(1, int(config.RateLimit)))
+	}
+	return client
+}
+
+// allowed reports whether the client may call the given method.
+func (c *authClient) allowed(method string) bool {
+	return len(c.config.Methods) == 0 || methodAllowed(c.config.Methods, method)
+}
+
+// methodAllowed reports whether a method is in the given list. An entry ending
+// in "*" matches all methods with the preceding prefix.
+func methodAllowed(allowed []string, method string) bool {
+	for _, m := range allowed {
+		if m == method {
+			return true
+		}
+		if prefix, ok := strings.CutSuffix(m, "*"); ok && strings.HasPrefix(method, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// authError is returned to clients exceeding their permissions.
+type authError struct {
+	code int
+	msg  string
+}
+
+func (e *authError) Error() string  { return e.msg }
+func (e *authError) ErrorCode() int { return e.code }
+
+type authClientContextKey struct{}
+
+// authClientSet holds the consumers of the authenticated endpoints. Clients can be
+// added and removed while the endpoints are serving.
+type authClientSet struct {
+	mu      sync.RWMutex
+	clients []*authClient // the default client is always first
+}
+
+func newAuthClientSet(key *jwtSecretKey) *authClientSet {
+	return &authClientSet{
+		clients: []*authClient{newAuthClient(AuthClient{Name: defaultAuthClient}, key)},
+	}
+}
+
+// add registers a client, replacing any existing client with the same name.
+func (s *authClientSet) add(client *authClient) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, c := range s.clients {
+		if c.config.Name == client.config.Name {
+			s.clients[i] = client
+			return
+		}
+	}
+	s.clients = append(s.clients, client)
+}
+
+// remove unregisters the named client, reporting whether it existed.
+func (s *authClientSet) remove(name string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if name == defaultAuthClient {
+		return false
+	}
+	n := len(s.clients)
+	s.clients = slices.DeleteFunc(s.clients, func(c *authClient) bool {
+		return c.config.Name == name
+	})
+	return len(s.clients) != n
+}
+
+// list returns the clients in registration order.
+func (s *authClientSet) list() []*authClient {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return slices.Clone(s.clients)
+}
+
+// filter is an rpc.CallFilter enforcing the permissions and rate limits of the
+// client which authenticated the request.
+func (s *authClientSet) filter(ctx context.Context, method string) error {
+	client, _ := ctx.Value(authClientContextKey{}).(*authClient)
+	if client == nil {
+		return nil
+	}
+	if !client.allowed(method) {
+		return &authError{errcodeAuthMethodDenied, fmt.Sprintf("method %s not allowed for client %s", method, client.config.Name)}
+	}
+	if client.limiter != nil && !client.limiter.Allow() {
+		return &authError{errcodeAuthRateLimited, fmt.Sprintf("rate limit of client %s exceeded", client.config.Name)}
+	}
+	return nil
+}
@@ -24,6 +24,7 @@ import (
 	"path/filepath"
 	"runtime"
 	"strings"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
@@ -204,9 +205,43 @@ type Config struct {
 	// BatchResponseMaxSize is the maximum number of bytes returned from a batched rpc call.
 	BatchResponseMaxSize int `toml:",omitempty"`
 
+	// DedupMethods lists the RPC methods whose identical concurrent calls share a
+	// single execution on the HTTP and WebSocket endpoints.
+	DedupMethods []string `toml:",omitempty"`
+
+	// StreamedMethods lists the RPC methods whose list results are written to the
+	// connection element by element instead of being buffered as a whole.
+	StreamedMethods []string `toml:",omitempty"`
+
+	// DrainRejectedMethods lists the long-running RPC methods which are rejected
+	// while the HTTP and WebSocket endpoints are draining, in addition to
+	// subscriptions.
+	DrainRejectedMethods []string `toml:",omitempty"`
+
 	// JWTSecret is the path to the hex-encoded jwt secret.
 	JWTSecret string `toml:",omitempty"`
 
+	// AuthClients are additional consumers of the authenticated RPC endpoints, each
+	// with their own JWT secret, permitted methods and rate limit.
+	AuthClients []AuthClient `toml:",omitempty"`
+
+	// RPCPermissions is the path to a JSON file restricting the methods callable
+	// per transport, API key and authenticated client. The file is reloaded when
+	// it changes.
+	RPCPermissions string `toml:",omitempty"`
+
+	// RPCAccessLog is the file path or socket address (unix://, tcp:// or udp://)
+	// where an entry is written for the served RPC method calls.
+	RPCAccessLog string `toml:",omitempty"`
+
+	// RPCAccessLogSampling is the fraction of the method calls written to the
+	// access log.
+	RPCAccessLogSampling float64 `toml:",omitempty"`
+
+	// RPCSlowCallThreshold is the duration beyond which method calls are always
+	// written to the access log and reported as slow. Zero disables it.
+	RPCSlowCallThreshold time.Duration `toml:",omitempty"`
+
 	// EnablePersonal enables the deprecated personal namespace.
 	EnablePersonal bool `toml:"-"`
 
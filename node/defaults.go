@@ -53,6 +53,32 @@ var (
 	DefaultAuthModules = []string{"eth", "engine"}
 )
 
+// DefaultStreamedMethods are the RPC methods with potentially huge list results, which
+// are streamed to the client by default.
+var DefaultStreamedMethods = []string{
+	"eth_getLogs",
+	"debug_traceBlock",
+	"debug_traceBlockByNumber",
+	"debug_traceBlockByHash",
+	"debug_traceBlockFromFile",
+	"debug_traceBadBlock",
+}
+
+// DefaultDrainRejectedMethods are the long-running RPC methods which are rejected
+// by default while the endpoints are draining.
+var DefaultDrainRejectedMethods = []string{
+	"debug_traceBlock",
+	"debug_traceBlockByNumber",
+	"debug_traceBlockByHash",
+	"debug_traceBlockFromFile",
+	"debug_traceBadBlock",
+	"debug_traceTransaction",
+	"debug_traceCall",
+	"debug_intermediateRoots",
+	"debug_standardTraceBlockToFile",
+	"debug_standardTraceBadBlockToFile",
+}
+
 // DefaultConfig contains reasonable default settings.
 var DefaultConfig = Config{
 	DataDir:              DefaultDataDir(),
@@ -67,6 +93,9 @@ var DefaultConfig = Config{
 	WSModules:            []string{"net", "web3"},
 	BatchRequestLimit:    1000,
 	BatchResponseMaxSize: 25 * 1000 * 1000,
+	StreamedMethods:      DefaultStreamedMethods,
+	DrainRejectedMethods: DefaultDrainRejectedMethods,
+	RPCAccessLogSampling: 1,
 	GraphQLVirtualHosts:  []string{"localhost"},
 	P2P: p2p.Config{
 		ListenAddr:  ":30303",
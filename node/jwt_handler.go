@@ -17,8 +17,10 @@
 package node
 
 import (
+	"context"
 	"net/http"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/golang-jwt/jwt/v4"
@@ -26,18 +28,40 @@ import (
 
 const jwtExpiryTimeout = 60 * time.Second
 
+// jwtSecretKey holds the secret used to authenticate tokens. The secret can be
+// replaced while the endpoint is serving, allowing it to be rotated without a
+// restart.
+type jwtSecretKey struct {
+	secret atomic.Pointer[[]byte]
+}
+
+func newJWTSecretKey(secret []byte) *jwtSecretKey {
+	key := new(jwtSecretKey)
+	key.set(secret)
+	return key
+}
+
+// get returns the current secret.
+func (key *jwtSecretKey) get() []byte {
+	return *key.secret.Load()
+}
+
+// set replaces the secret.
+func (key *jwtSecretKey) set(secret []byte) {
+	key.secret.Store(&secret)
+}
+
 type jwtHandler struct {
-	keyFunc func(token *jwt.Token) (interface{}, error)
+	clients *authClientSet
 	next    http.Handler
 }
 
-// newJWTHandler creates a http.Handler with jwt authentication support.
-func newJWTHandler(secret []byte, next http.Handler) http.Handler {
+// newJWTHandler creates a http.Handler with jwt authentication support. Tokens
+// are accepted if they are signed with the secret of any of the clients.
+func newJWTHandler(clients *authClientSet, next http.Handler) http.Handler {
 	return &jwtHandler{
-		keyFunc: func(token *jwt.Token) (interface{}, error) {
-			return secret, nil
-		},
-		next: next,
+		clients: clients,
+		next:    next,
 	}
 }
 
@@ -46,6 +70,9 @@ func (handler *jwtHandler) ServeHTTP(out http.ResponseWriter, r *http.Request) {
 	var (
 		strToken string
 		claims   jwt.RegisteredClaims
+		token    *jwt.Token
+		client   *authClient
+		err      error
 	)
 	if auth := r.Header.Get("Authorization"); strings.HasPrefix(auth, "Bearer ") {
 		strToken = strings.TrimPrefix(auth, "Bearer ")
@@ -54,13 +81,24 @@ func (handler *jwtHandler) ServeHTTP(out http.ResponseWriter, r *http.Request) {
 		http.Error(out, "missing token", http.StatusUnauthorized)
 		return
 	}
-	// We explicitly set only HS256 allowed, and also disables the
-	// claim-check: the RegisteredClaims internally requires 'iat' to
-	// be no later than 'now', but we allow for a bit of drift.
-	token, err := jwt.ParseWithClaims(strToken, &claims, handler.keyFunc,
-		jwt.WithValidMethods([]string{"HS256"}),
-		jwt.WithoutClaimsValidation())
-
+	// Find the client whose secret signed the token.
+	for _, c := range handler.clients.list() {
+		key := c.key
+		keyFunc := func(token *jwt.Token) (interface{}, error) {
+			return key.get(), nil
+		}
+		// We explicitly set only HS256 allowed, and also disables the
+		// claim-check: the RegisteredClaims internally requires 'iat' to
+		// be no later than 'now', but we allow for a bit of drift.
+		claims = jwt.RegisteredClaims{}
+		token, err = jwt.ParseWithClaims(strToken, &claims, keyFunc,
+			jwt.WithValidMethods([]string{"HS256"}),
+			jwt.WithoutClaimsValidation())
+		if err == nil {
+			client = c
+			break
+		}
+	}
 	switch {
 	case err != nil:
 		http.Error(out, err.Error(), http.StatusUnauthorized)
@@ -75,6 +113,7 @@ func (handler *jwtHandler) ServeHTTP(out http.ResponseWriter, r *http.Request) {
 	case time.Until(claims.IssuedAt.Time) > jwtExpiryTimeout:
 		http.Error(out, "future token", http.StatusUnauthorized)
 	default:
-		handler.next.ServeHTTP(out, r)
+		ctx := context.WithValue(r.Context(), authClientContextKey{}, client)
+		handler.next.ServeHTTP(out, r.WithContext(ctx))
 	}
 }
@@ -65,6 +65,17 @@ type Node struct {
 	wsAuth        *httpServer //
 	ipc           *ipcServer  // Stores information about the ipc http server
 	inprocHandler *rpc.Server // In-process RPC request handler to process the API requests
+	drainHooks    []DrainHook // Callbacks notified when the node enters or leaves drain mode
+	draining      bool        // Whether the public RPC endpoints are draining
+
+	jwtSecret      *jwtSecretKey   // Secret of the authenticated endpoints, nil if disabled
+	jwtSecretFile  string          // File the JWT secret was loaded from
+	authClients    *authClientSet  // Consumers of the authenticated endpoints, nil if disabled
+	secretsWatcher *secretsWatcher // Reloads the JWT secret when its file changes
+
+	rpcPermissions     *rpcPermissions // Method permissions of the RPC endpoints, nil if disabled
+	permissionsWatcher *secretsWatcher // Reloads the RPC permissions when their file changes
+	accessLog          *accessLog      // Access log of the RPC endpoints, nil if disabled
 
 	databases map[*closeTrackingDB]struct{} // All open databases
 }
@@ -105,6 +116,7 @@ func New(conf *Config) (*Node, error) {
 	}
 	server := rpc.NewServer()
 	server.SetBatchLimits(conf.BatchRequestLimit, conf.BatchResponseMaxSize)
+	server.SetStreamedMethods(conf.StreamedMethods)
 	node := &Node{
 		config:        conf,
 		inprocHandler: server,
@@ -335,14 +347,13 @@ func (n *Node) closeDataDir() {
 // present, it generates a new secret and stores to the given location.
 func ObtainJWTSecret(fileName string) ([]byte, error) {
 	// try reading from file
-	if data, err := os.ReadFile(fileName); err == nil {
-		jwtSecret := common.FromHex(strings.TrimSpace(string(data)))
-		if len(jwtSecret) == 32 {
-			log.Info("Loaded JWT secret file", "path", fileName, "crc32", fmt.Sprintf("%#x", crc32.ChecksumIEEE(jwtSecret)))
-			return jwtSecret, nil
+	if _, err := os.Stat(fileName); err == nil {
+		jwtSecret, err := readJWTSecret(fileName)
+		if err != nil {
+			return nil, err
 		}
-		log.Error("Invalid JWT secret", "path", fileName, "length", len(jwtSecret))
-		return nil, errors.New("invalid JWT secret")
+		log.Info("Loaded JWT secret file", "path", fileName, "crc32", fmt.Sprintf("%#x", crc32.ChecksumIEEE(jwtSecret)))
+		return jwtSecret, nil
 	}
 	// Need to generate one
 	jwtSecret := make([]byte, 32)
@@ -359,16 +370,123 @@ func ObtainJWTSecret(fileName string) ([]byte, error) {
 	return jwtSecret, nil
 }
 
+// readJWTSecret loads a hex-encoded jwt-secret from the given file.
+func readJWTSecret(fileName string) ([]byte, error) {
+	data, err := os.ReadFile(fileName)
+	if err != nil {
+		return nil, err
+	}
+	jwtSecret := common.FromHex(strings.TrimSpace(string(data)))
+	if len(jwtSecret) != 32 {
+		log.Error("Invalid JWT secret", "path", fileName, "length", len(jwtSecret))
+		return nil, errors.New("invalid JWT secret")
+	}
+	return jwtSecret, nil
+}
+
+// ReloadSecrets re-reads the JWT secret of the authenticated endpoints from disk.
+// Requests received afterwards must carry tokens signed with the new secret.
+func (n *Node) ReloadSecrets() error {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	if n.jwtSecret == nil {
+		return errors.New("authenticated RPC endpoints not enabled")
+	}
+	secret, err := readJWTSecret(n.jwtSecretFile)
+	if err != nil {
+		return err
+	}
+	n.jwtSecret.set(secret)
+	log.Info("Reloaded JWT secret file", "path", n.jwtSecretFile, "crc32", fmt.Sprintf("%#x", crc32.ChecksumIEEE(secret)))
+
+	for _, client := range n.authClients.list() {
+		if client.config.Name == defaultAuthClient {
+			continue
+		}
+		secret, err := readJWTSecret(client.config.JWTSecret)
+		if err != nil {
+			return fmt.Errorf("auth client %s: %v", client.config.Name, err)
+		}
+		client.key.set(secret)
+	}
+	return nil
+}
+
+// newAuthClientFromConfig loads the secret of an additional consumer of the authenticated
+// endpoints.
+func newAuthClientFromConfig(config AuthClient) (*authClient, error) {
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+	secret, err := readJWTSecret(config.JWTSecret)
+	if err != nil {
+		return nil, fmt.Errorf("auth client %s: %v", config.Name, err)
+	}
+	return newAuthClient(config, newJWTSecretKey(secret)), nil
+}
+
+// AddAuthClient registers an additional consumer of the authenticated endpoints,
+// replacing any existing client with the same name.
+func (n *Node) AddAuthClient(config AuthClient) error {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	if n.authClients == nil {
+		return errors.New("authenticated RPC endpoints not enabled")
+	}
+	client, err := newAuthClientFromConfig(config)
+	if err != nil {
+		return err
+	}
+	n.authClients.add(client)
+	log.Info("Added auth client", "name", config.Name, "methods", len(config.Methods), "ratelimit", config.RateLimit)
+	return nil
+}
+
+// RemoveAuthClient unregisters an additional consumer of the authenticated endpoints.
+func (n *Node) RemoveAuthClient(name string) error {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	if n.authClients == nil {
+		return errors.New("authenticated RPC endpoints not enabled")
+	}
+	if !n.authClients.remove(name) {
+		return fmt.Errorf("unknown auth client %q", name)
+	}
+	log.Info("Removed auth client", "name", name)
+	return nil
+}
+
+// AuthClients returns the additional consumers of the authenticated endpoints.
+func (n *Node) AuthClients() []AuthClient {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	var clients []AuthClient
+	if n.authClients == nil {
+		return clients
+	}
+	for _, client := range n.authClients.list() {
+		if client.config.Name != defaultAuthClient {
+			clients = append(clients, client.config)
+		}
+	}
+	return clients
+}
+
 // obtainJWTSecret loads the jwt-secret, either from the provided config,
 // or from the default location. If neither of those are present, it generates
 // a new secret and stores to the default location.
-func (n *Node) obtainJWTSecret(cliParam string) ([]byte, error) {
+func (n *Node) obtainJWTSecret(cliParam string) ([]byte, string, error) {
 	fileName := cliParam
 	if len(fileName) == 0 {
 		// no path provided, use default
 		fileName = n.ResolvePath(datadirJWTKey)
 	}
-	return ObtainJWTSecret(fileName)
+	secret, err := ObtainJWTSecret(fileName)
+	return secret, fileName, err
 }
 
 // startRPC is a helper method to configure all the various RPC endpoints during node
@@ -378,10 +496,32 @@ func (n *Node) startRPC() error {
 	if err := n.startInProc(n.rpcAPIs); err != nil {
 		return err
 	}
-
+	// Load the method permissions, enforced on all endpoints but the in-process one.
+	if n.config.RPCPermissions != "" {
+		perms, err := newRPCPermissions(n.config.RPCPermissions)
+		if err != nil {
+			return err
+		}
+		n.rpcPermissions = perms
+	}
+	if n.config.RPCAccessLog != "" {
+		accessLog, err := newAccessLog(n.config.RPCAccessLog, n.config.RPCAccessLogSampling, n.config.RPCSlowCallThreshold)
+		if err != nil {
+			return err
+		}
+		n.accessLog = accessLog
+	}
 	// Configure IPC.
 	if n.ipc.endpoint != "" {
-		if err := n.ipc.start(n.rpcAPIs); err != nil {
+		configure := func(srv *rpc.Server) {
+			if n.rpcPermissions != nil {
+				srv.SetCallFilter(n.rpcPermissions.filter(false))
+			}
+			if n.accessLog != nil {
+				srv.SetCallLogger(n.accessLog.logCall)
+			}
+		}
+		if err := n.ipc.start(n.rpcAPIs, configure); err != nil {
 			return err
 		}
 	}
@@ -393,6 +533,11 @@ func (n *Node) startRPC() error {
 	rpcConfig := rpcEndpointConfig{
 		batchItemLimit:         n.config.BatchRequestLimit,
 		batchResponseSizeLimit: n.config.BatchResponseMaxSize,
+		dedupMethods:           n.config.DedupMethods,
+		streamedMethods:        n.config.StreamedMethods,
+		drainRejectedMethods:   n.config.DrainRejectedMethods,
+		permissions:            n.rpcPermissions,
+		accessLog:              n.accessLog,
 	}
 
 	initHttp := func(server *httpServer, port int) error {
@@ -429,14 +574,16 @@ func (n *Node) startRPC() error {
 		return nil
 	}
 
-	initAuth := func(port int, secret []byte) error {
+	initAuth := func(port int, clients *authClientSet) error {
 		// Enable auth via HTTP
 		server := n.httpAuth
 		if err := server.setListenAddr(n.config.AuthAddr, port); err != nil {
 			return err
 		}
 		sharedConfig := rpcEndpointConfig{
-			jwtSecret:              secret,
+			authClients:            clients,
+			permissions:            n.rpcPermissions,
+			accessLog:              n.accessLog,
 			batchItemLimit:         engineAPIBatchItemLimit,
 			batchResponseSizeLimit: engineAPIBatchResponseSizeLimit,
 			httpBodyLimit:          engineAPIBodyLimit,
@@ -486,11 +633,20 @@ func (n *Node) startRPC() error {
 	}
 	// Configure authenticated API
 	if len(openAPIs) != len(allAPIs) {
-		jwtSecret, err := n.obtainJWTSecret(n.config.JWTSecret)
+		jwtSecret, fileName, err := n.obtainJWTSecret(n.config.JWTSecret)
 		if err != nil {
 			return err
 		}
-		if err := initAuth(n.config.AuthPort, jwtSecret); err != nil {
+		n.jwtSecret, n.jwtSecretFile = newJWTSecretKey(jwtSecret), fileName
+		n.authClients = newAuthClientSet(n.jwtSecret)
+		for _, config := range n.config.AuthClients {
+			client, err := newAuthClientFromConfig(config)
+			if err != nil {
+				return err
+			}
+			n.authClients.add(client)
+		}
+		if err := initAuth(n.config.AuthPort, n.authClients); err != nil {
 			return err
 		}
 	}
@@ -500,6 +656,23 @@ func (n *Node) startRPC() error {
 			return err
 		}
 	}
+	// Watch the JWT secret for rotations. Failing to do so is not fatal, as
+	// the secret can still be reloaded via admin_reloadSecrets.
+	if n.jwtSecret != nil {
+		watcher, err := newSecretsWatcher(n.jwtSecretFile, n.ReloadSecrets)
+		if err != nil {
+			n.log.Warn("Failed to watch JWT secret file", "path", n.jwtSecretFile, "err", err)
+		}
+		n.secretsWatcher = watcher
+	}
+	// Likewise, watch the RPC permissions for changes.
+	if n.rpcPermissions != nil {
+		watcher, err := newSecretsWatcher(n.rpcPermissions.path, n.rpcPermissions.reload)
+		if err != nil {
+			n.log.Warn("Failed to watch RPC permissions file", "path", n.rpcPermissions.path, "err", err)
+		}
+		n.permissionsWatcher = watcher
+	}
 	return nil
 }
 
@@ -515,12 +688,24 @@ func (n *Node) wsServerForPort(port int, authenticated bool) *httpServer {
 }
 
 func (n *Node) stopRPC() {
+	if n.secretsWatcher != nil {
+		n.secretsWatcher.close()
+		n.secretsWatcher = nil
+	}
+	if n.permissionsWatcher != nil {
+		n.permissionsWatcher.close()
+		n.permissionsWatcher = nil
+	}
 	n.http.stop()
 	n.ws.stop()
 	n.httpAuth.stop()
 	n.wsAuth.stop()
 	n.ipc.stop()
 	n.stopInProc()
+	if n.accessLog != nil {
+		n.accessLog.close()
+		n.accessLog = nil
+	}
 }
 
 // startInProc registers all RPC APIs on the inproc server.
@@ -579,6 +764,65 @@ func (n *Node) RegisterAPIs(apis []rpc.API) {
 	n.rpcAPIs = append(n.rpcAPIs, apis...)
 }
 
+// DrainHook is called when the node enters or leaves drain mode. If pauseIngress
+// is set, services should also stop admitting new work, e.g. transactions.
+type DrainHook func(draining bool, pauseIngress bool)
+
+// RegisterDrainHook registers a callback to be notified about drain mode changes.
+func (n *Node) RegisterDrainHook(hook DrainHook) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	if n.state != initializingState {
+		panic("can't register drain hook on running/stopped node")
+	}
+	n.drainHooks = append(n.drainHooks, hook)
+}
+
+// StartDrain puts the public HTTP and WebSocket endpoints into drain mode. In-flight
+// requests are allowed to finish, but new WebSocket connections, subscriptions and
+// long-running calls are refused and the health check reports the node unavailable.
+// Authenticated endpoints and IPC are not affected.
+func (n *Node) StartDrain(pauseIngress bool) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	n.draining = true
+	n.http.setDraining(true)
+	n.ws.setDraining(true)
+	for _, hook := range n.drainHooks {
+		hook(true, pauseIngress)
+	}
+	n.log.Info("Started draining RPC endpoints", "pauseIngress", pauseIngress)
+}
+
+// StopDrain leaves drain mode, resuming normal operation of all endpoints.
+func (n *Node) StopDrain() {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	n.draining = false
+	n.http.setDraining(false)
+	n.ws.setDraining(false)
+	for _, hook := range n.drainHooks {
+		hook(false, false)
+	}
+	n.log.Info("Stopped draining RPC endpoints")
+}
+
+// Draining reports whether the node is in drain mode, along with the number of
+// requests still being served by the public endpoints.
+func (n *Node) Draining() (bool, int) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	inflight := n.http.inFlight()
+	if n.ws != n.http {
+		inflight += n.ws.inFlight()
+	}
+	return n.draining, inflight
+}
+
 // getAPIs return two sets of APIs, both the ones that do not require
 // authentication, and the complete set
 func (n *Node) getAPIs() (unauthenticated, all []rpc.API) {
@@ -23,6 +23,7 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"reflect"
 	"testing"
 	"time"
 
@@ -235,3 +236,159 @@ func offsetTimeAuth(secret [32]byte, offset time.Duration) rpc.HTTPAuth {
 		return nil
 	}
 }
+
+// TestAuthSecretReload checks that the JWT secret can be rotated while the node is
+// running, both explicitly and by replacing the secret file.
+func TestAuthSecretReload(t *testing.T) {
+	var oldSecret, newSecret [32]byte
+	crand.Read(oldSecret[:])
+	crand.Read(newSecret[:])
+
+	jwtPath := filepath.Join(t.TempDir(), "jwt_secret")
+	if err := os.WriteFile(jwtPath, []byte(hexutil.Encode(oldSecret[:])), 0600); err != nil {
+		t.Fatalf("failed to prepare jwt secret file: %v", err)
+	}
+	node, err := New(&Config{AuthAddr: "127.0.0.1", AuthPort: 0, JWTSecret: jwtPath})
+	if err != nil {
+		t.Fatalf("could not create a new node: %v", err)
+	}
+	node.RegisterAPIs([]rpc.API{{
+		Namespace:     "engine",
+		Service:       helloRPC("hello engine"),
+		Authenticated: true,
+	}})
+	if err := node.Start(); err != nil {
+		t.Fatalf("failed to start test node: %v", err)
+	}
+	defer node.Close()
+
+	endpoint := node.HTTPAuthEndpoint()
+	(&authTest{endpoint: endpoint, prov: NewJWTAuth(oldSecret), expectCall2Fail: true}).Run(t)
+
+	// Rotate the secret and reload it explicitly.
+	if err := os.WriteFile(jwtPath, []byte(hexutil.Encode(newSecret[:])), 0600); err != nil {
+		t.Fatalf("failed to rotate jwt secret file: %v", err)
+	}
+	if err := node.ReloadSecrets(); err != nil {
+		t.Fatalf("failed to reload secrets: %v", err)
+	}
+	(&authTest{endpoint: endpoint, prov: NewJWTAuth(oldSecret), expectCall1Fail: true}).Run(t)
+	(&authTest{endpoint: endpoint, prov: NewJWTAuth(newSecret), expectCall2Fail: true}).Run(t)
+
+	// Rotate the secret back, the file watcher should pick it up.
+	if err := os.WriteFile(jwtPath, []byte(hexutil.Encode(oldSecret[:])), 0600); err != nil {
+		t.Fatalf("failed to rotate jwt secret file: %v", err)
+	}
+	for deadline := time.Now().Add(5 * time.Second); ; {
+		if string(node.jwtSecret.get()) == string(oldSecret[:]) {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("secret file change not detected")
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	(&authTest{endpoint: endpoint, prov: NewJWTAuth(oldSecret), expectCall2Fail: true}).Run(t)
+}
+
+// TestAuthClients checks that additional consumers of the authenticated endpoints
+// are restricted to their permitted methods and rate limits.
+func TestAuthClients(t *testing.T) {
+	dir := t.TempDir()
+	writeSecret := func(name string) [32]byte {
+		var secret [32]byte
+		crand.Read(secret[:])
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(hexutil.Encode(secret[:])), 0600); err != nil {
+			t.Fatalf("failed to prepare jwt secret file: %v", err)
+		}
+		return secret
+	}
+	var (
+		secret        = writeSecret("jwt_secret")
+		monitorSecret = writeSecret("monitor_secret")
+		limitedSecret = writeSecret("limited_secret")
+		addedSecret   = writeSecret("added_secret")
+	)
+	node, err := New(&Config{
+		AuthAddr:  "127.0.0.1",
+		AuthPort:  0,
+		JWTSecret: filepath.Join(dir, "jwt_secret"),
+		AuthClients: []AuthClient{
+			{Name: "monitor", JWTSecret: filepath.Join(dir, "monitor_secret"), Methods: []string{"engine_helloWorld"}},
+			{Name: "limited", JWTSecret: filepath.Join(dir, "limited_secret"), RateLimit: 0.1},
+		},
+	})
+	if err != nil {
+		t.Fatalf("could not create a new node: %v", err)
+	}
+	node.RegisterAPIs([]rpc.API{
+		{Namespace: "engine", Service: helloRPC("hello engine"), Authenticated: true},
+		{Namespace: "eth", Service: helloRPC("hello eth"), Authenticated: true},
+	})
+	if err := node.Start(); err != nil {
+		t.Fatalf("failed to start test node: %v", err)
+	}
+	defer node.Close()
+
+	// The node's own secret and the restricted client work side by side.
+	for _, endpoint := range []string{node.HTTPAuthEndpoint(), node.WSAuthEndpoint()} {
+		(&authTest{endpoint: endpoint, prov: NewJWTAuth(secret)}).Run(t)
+		(&authTest{endpoint: endpoint, prov: NewJWTAuth(monitorSecret), expectCall2Fail: true}).Run(t)
+	}
+	// The rate limited client gets a single call.
+	(&authTest{endpoint: node.HTTPAuthEndpoint(), prov: NewJWTAuth(limitedSecret), expectCall2Fail: true}).Run(t)
+
+	// Clients can be managed at runtime.
+	(&authTest{endpoint: node.WSAuthEndpoint(), prov: NewJWTAuth(addedSecret), expectDialFail: true}).Run(t)
+	if err := node.AddAuthClient(AuthClient{Name: "added", JWTSecret: filepath.Join(dir, "added_secret")}); err != nil {
+		t.Fatalf("failed to add auth client: %v", err)
+	}
+	(&authTest{endpoint: node.WSAuthEndpoint(), prov: NewJWTAuth(addedSecret)}).Run(t)
+	if have := len(node.AuthClients()); have != 3 {
+		t.Fatalf("wrong number of auth clients: have %d, want 3", have)
+	}
+	if err := node.RemoveAuthClient("added"); err != nil {
+		t.Fatalf("failed to remove auth client: %v", err)
+	}
+	(&authTest{endpoint: node.WSAuthEndpoint(), prov: NewJWTAuth(addedSecret), expectDialFail: true}).Run(t)
+	if err := node.RemoveAuthClient(defaultAuthClient); err == nil {
+		t.Fatal("removed the default auth client")
+	}
+}
+
+func TestParseAuthClient(t *testing.T) {
+	tests := []struct {
+		spec string
+		want AuthClient
+		fail bool
+	}{
+		{spec: "monitor:/tmp/secret::0", want: AuthClient{Name: "monitor", JWTSecret: "/tmp/secret"}},
+		{spec: "monitor:C:\\secret:engine_getPayloadBodiesByHashV1|eth_*:2.5", want: AuthClient{
+			Name:      "monitor",
+			JWTSecret: "C:\\secret",
+			Methods:   []string{"engine_getPayloadBodiesByHashV1", "eth_*"},
+			RateLimit: 2.5,
+		}},
+		{spec: "monitor:/tmp/secret", fail: true},
+		{spec: "monitor:/tmp/secret::x", fail: true},
+		{spec: "monitor:/tmp/secret::-1", fail: true},
+		{spec: "default:/tmp/secret::0", fail: true},
+		{spec: ":/tmp/secret::0", fail: true},
+	}
+	for _, test := range tests {
+		have, err := ParseAuthClient(test.spec)
+		if test.fail {
+			if err == nil {
+				t.Errorf("%q: expected error", test.spec)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", test.spec, err)
+			continue
+		}
+		if !reflect.DeepEqual(have, test.want) {
+			t.Errorf("%q: wrong result\nhave %+v\nwant %+v", test.spec, have, test.want)
+		}
+	}
+}
@@ -0,0 +1,172 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package node
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/p2p"
+)
+
+// ReloadableConfig lists the fields of Config which Reload can apply to a
+// running node. Changes to any other field only take effect after a restart.
+var ReloadableConfig = []string{
+	"HTTPModules",
+	"WSModules",
+	"AuthClients",
+	"P2P.MaxPeers",
+	"P2P.PeerGroups",
+}
+
+// reloadOrigin is the origin of setting changes recorded by Reload.
+const reloadOrigin = "config reload"
+
+// Reload applies the given fields of config to the running node. The fields
+// must be listed in ReloadableConfig: the modules served over HTTP and WebSocket,
+// the consumers of the authenticated endpoints along with their rate limits, the
+// peer limit and the peer groups. Fields which are not listed keep any value set
+// at runtime. It returns the names of the fields which were applied.
+func (n *Node) Reload(config *Config, fields []string) ([]string, error) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	if n.state != runningState {
+		return nil, ErrNodeStopped
+	}
+	var (
+		applied []string
+		errs    []error
+	)
+	openAPIs, _ := n.getAPIs()
+	for _, name := range fields {
+		var (
+			from, to any
+			err      error
+		)
+		switch name {
+		case "HTTPModules":
+			from, to = n.config.HTTPModules, config.HTTPModules
+			if n.http.rpcAllowed() {
+				err = n.http.setRPCModules(openAPIs, config.HTTPModules)
+			}
+			if err == nil {
+				n.config.HTTPModules = slices.Clone(config.HTTPModules)
+			}
+		case "WSModules":
+			from, to = n.config.WSModules, config.WSModules
+			if server := n.wsServerForPort(n.config.WSPort, false); server.wsAllowed() {
+				err = server.setWSModules(openAPIs, config.WSModules)
+			}
+			if err == nil {
+				n.config.WSModules = slices.Clone(config.WSModules)
+			}
+		case "AuthClients":
+			from, to = len(n.config.AuthClients), len(config.AuthClients)
+			err = n.reloadAuthClients(config.AuthClients)
+		case "P2P.MaxPeers":
+			from, to = n.server.PeerLimit(), config.P2P.MaxPeers
+			err = n.server.SetPeerLimit(config.P2P.MaxPeers)
+		case "P2P.PeerGroups":
+			from, to = len(n.config.P2P.PeerGroups), len(config.P2P.PeerGroups)
+			err = n.reloadPeerGroups(config.P2P.PeerGroups)
+		default:
+			err = errors.New("not reloadable")
+		}
+		if err != nil {
+			log.Warn("Rejected runtime setting change", "name", name, "origin", reloadOrigin, "err", err)
+			errs = append(errs, fmt.Errorf("%s: %v", name, err))
+			continue
+		}
+		log.Info("Changed runtime setting", "name", name, "old", from, "new", to, "origin", reloadOrigin)
+		applied = append(applied, name)
+	}
+	return applied, errors.Join(errs...)
+}
+
+// reloadAuthClients replaces the additional consumers of the authenticated
+// endpoints. Clients whose configuration didn't change keep their rate limiter
+// state. The caller must hold n.lock.
+func (n *Node) reloadAuthClients(configs []AuthClient) error {
+	if n.authClients == nil {
+		return errors.New("authenticated RPC endpoints not enabled")
+	}
+	// Load all secrets before touching the running set, so a bad entry
+	// doesn't leave it half updated.
+	current := make(map[string]AuthClient)
+	for _, client := range n.authClients.list() {
+		current[client.config.Name] = client.config
+	}
+	var (
+		added []*authClient
+		keep  = make(map[string]bool)
+	)
+	for _, config := range configs {
+		keep[config.Name] = true
+		if old, ok := current[config.Name]; ok && reflect.DeepEqual(old, config) {
+			continue
+		}
+		client, err := newAuthClientFromConfig(config)
+		if err != nil {
+			return err
+		}
+		added = append(added, client)
+	}
+	for name := range current {
+		if name != defaultAuthClient && !keep[name] {
+			n.authClients.remove(name)
+			log.Info("Removed auth client", "name", name)
+		}
+	}
+	for _, client := range added {
+		n.authClients.add(client)
+		log.Info("Added auth client", "name", client.config.Name, "methods", len(client.config.Methods), "ratelimit", client.config.RateLimit)
+	}
+	n.config.AuthClients = slices.Clone(configs)
+	return nil
+}
+
+// reloadPeerGroups replaces the peer groups of the p2p server. The caller must
+// hold n.lock.
+func (n *Node) reloadPeerGroups(groups []p2p.PeerGroup) error {
+	current := make(map[string]p2p.PeerGroup)
+	for _, group := range n.config.P2P.PeerGroups {
+		current[group.Name] = group
+	}
+	keep := make(map[string]bool)
+	for _, group := range groups {
+		keep[group.Name] = true
+		if old, ok := current[group.Name]; ok && reflect.DeepEqual(old, group) {
+			continue
+		}
+		if err := n.server.AddPeerGroup(group); err != nil {
+			return err
+		}
+	}
+	for name := range current {
+		if !keep[name] {
+			if err := n.server.RemovePeerGroup(name); err != nil {
+				return err
+			}
+		}
+	}
+	n.config.P2P.PeerGroups = slices.Clone(groups)
+	return nil
+}
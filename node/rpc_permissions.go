@@ -0,0 +1,183 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package node
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"sync/atomic"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// APIKeyHeader is the HTTP header carrying the API key of a request, checked
+// against the RPC permissions file.
+const APIKeyHeader = "X-Api-Key"
+
+// Transports whose method permissions can be restricted.
+const (
+	transportHTTP = "http"
+	transportWS   = "ws"
+	transportIPC  = "ipc"
+	transportAuth = "auth"
+)
+
+// RPCPermissions is the content of the RPC permissions file. It restricts the
+// methods callable on the RPC endpoints beyond the namespaces enabled for them.
+//
+// Every list holds method names, where an entry of the form "namespace_*"
+// permits the whole namespace and "*" all methods.
+type RPCPermissions struct {
+	// Transports lists the methods allowed per transport, one of "http", "ws",
+	// "ipc" and "auth". Transports without an entry are not restricted.
+	Transports map[string][]string `json:"transports,omitempty"`
+
+	// APIKeys lists the methods allowed for calls presenting an API key in the
+	// X-Api-Key header, replacing the list of the transport. Calls with an
+	// unknown key are rejected.
+	APIKeys map[string][]string `json:"apiKeys,omitempty"`
+
+	// Clients lists the methods allowed for the consumers of the authenticated
+	// endpoints, identified by the name of the client whose secret signed the
+	// JWT. They apply in addition to the list of the auth transport.
+	Clients map[string][]string `json:"clients,omitempty"`
+}
+
+// LoadRPCPermissions reads an RPC permissions file.
+func LoadRPCPermissions(path string) (*RPCPermissions, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	perms := new(RPCPermissions)
+	if err := json.Unmarshal(data, perms); err != nil {
+		return nil, fmt.Errorf("invalid RPC permissions file %s: %v", path, err)
+	}
+	for transport := range perms.Transports {
+		switch transport {
+		case transportHTTP, transportWS, transportIPC, transportAuth:
+		default:
+			return nil, fmt.Errorf("invalid RPC permissions file %s: unknown transport %q", path, transport)
+		}
+	}
+	return perms, nil
+}
+
+// rpcPermissions enforces the RPC permissions file. The permissions can be
+// replaced while the endpoints are serving.
+type rpcPermissions struct {
+	path    string
+	current atomic.Pointer[RPCPermissions]
+}
+
+func newRPCPermissions(path string) (*rpcPermissions, error) {
+	p := &rpcPermissions{path: path}
+	if err := p.reload(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// reload reads the permissions file again. The current permissions are kept if
+// the file is invalid.
+func (p *rpcPermissions) reload() error {
+	perms, err := LoadRPCPermissions(p.path)
+	if err != nil {
+		return err
+	}
+	p.current.Store(perms)
+	log.Info("Loaded RPC permissions", "path", p.path, "transports", len(perms.Transports), "apikeys", len(perms.APIKeys), "clients", len(perms.Clients))
+	return nil
+}
+
+// filter returns an rpc.CallFilter enforcing the permissions on an endpoint.
+// Authenticated endpoints are subject to the auth transport and client lists,
+// all others to the list of the transport of the connection and API keys.
+func (p *rpcPermissions) filter(authenticated bool) rpc.CallFilter {
+	return func(ctx context.Context, method string) error {
+		perms := p.current.Load()
+		if authenticated {
+			if allowed, ok := perms.Transports[transportAuth]; ok && !methodAllowed(allowed, method) {
+				return &authError{errcodeAuthMethodDenied, fmt.Sprintf("method %s not allowed on %s transport", method, transportAuth)}
+			}
+			client, _ := ctx.Value(authClientContextKey{}).(*authClient)
+			if client == nil {
+				return nil
+			}
+			if allowed, ok := perms.Clients[client.config.Name]; ok && !methodAllowed(allowed, method) {
+				return &authError{errcodeAuthMethodDenied, fmt.Sprintf("method %s not allowed for client %s", method, client.config.Name)}
+			}
+			return nil
+		}
+		if key, _ := ctx.Value(apiKeyContextKey{}).(string); key != "" {
+			allowed, ok := perms.APIKeys[key]
+			if !ok {
+				return &authError{errcodeAuthMethodDenied, "unknown API key"}
+			}
+			if !methodAllowed(allowed, method) {
+				return &authError{errcodeAuthMethodDenied, fmt.Sprintf("method %s not allowed for API key", method)}
+			}
+			return nil
+		}
+		transport := rpc.PeerInfoFromContext(ctx).Transport
+		if allowed, ok := perms.Transports[transport]; ok && !methodAllowed(allowed, method) {
+			return &authError{errcodeAuthMethodDenied, fmt.Sprintf("method %s not allowed on %s transport", method, transport)}
+		}
+		return nil
+	}
+}
+
+type apiKeyContextKey struct{}
+
+// apiKeyHandler attaches the API key of a request to its context, so it is
+// available to the call filter of the RPC server.
+type apiKeyHandler struct {
+	next http.Handler
+}
+
+func newAPIKeyHandler(next http.Handler) http.Handler {
+	return &apiKeyHandler{next: next}
+}
+
+func (h *apiKeyHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if key := r.Header.Get(APIKeyHeader); key != "" {
+		r = r.WithContext(context.WithValue(r.Context(), apiKeyContextKey{}, key))
+	}
+	h.next.ServeHTTP(w, r)
+}
+
+// chainCallFilters combines call filters, a call must pass all of them.
+func chainCallFilters(filters ...rpc.CallFilter) rpc.CallFilter {
+	switch len(filters) {
+	case 0:
+		return nil
+	case 1:
+		return filters[0]
+	}
+	return func(ctx context.Context, method string) error {
+		for _, filter := range filters {
+			if err := filter(ctx, method); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package node
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRPCPermissions(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "permissions.json")
+	writePermissions := func(perms RPCPermissions) {
+		data, _ := json.Marshal(perms)
+		if err := os.WriteFile(path, data, 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writePermissions(RPCPermissions{
+		Transports: map[string][]string{"http": {"test_greet"}},
+		APIKeys:    map[string][]string{"key": {"rpc_*"}},
+	})
+	perms, err := newRPCPermissions(path)
+	if err != nil {
+		t.Fatalf("failed to load permissions: %v", err)
+	}
+	srv := createAndStartServer(t, &httpConfig{rpcEndpointConfig: rpcEndpointConfig{permissions: perms}}, false, nil, nil)
+	defer srv.stop()
+	url := "http://" + srv.listenAddr()
+
+	check := func(method string, allowed bool, headers ...string) {
+		t.Helper()
+		var result struct {
+			Error *struct {
+				Code int `json:"code"`
+			} `json:"error"`
+		}
+		resp := rpcRequest(t, url, method, headers...)
+		if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+			t.Fatalf("invalid response: %v", err)
+		}
+		switch {
+		case allowed && result.Error != nil:
+			t.Errorf("%s %v: call denied, want allowed", method, headers)
+		case !allowed && (result.Error == nil || result.Error.Code != errcodeAuthMethodDenied):
+			t.Errorf("%s %v: call allowed, want denied", method, headers)
+		}
+	}
+	check("test_greet", true)
+	check("rpc_modules", false)
+	check("rpc_modules", true, APIKeyHeader, "key")
+	check("test_greet", false, APIKeyHeader, "key")
+	check("test_greet", false, APIKeyHeader, "unknown")
+
+	// Permissions are replaced on reload.
+	writePermissions(RPCPermissions{Transports: map[string][]string{"http": {"*"}}})
+	if err := perms.reload(); err != nil {
+		t.Fatalf("failed to reload permissions: %v", err)
+	}
+	check("rpc_modules", true)
+	check("rpc_modules", false, APIKeyHeader, "key")
+
+	// Invalid files are rejected, keeping the current permissions.
+	writePermissions(RPCPermissions{Transports: map[string][]string{"grpc": {"*"}}})
+	if err := perms.reload(); err == nil {
+		t.Fatal("invalid transport accepted")
+	}
+	check("rpc_modules", true)
+}
@@ -54,10 +54,15 @@ type wsConfig struct {
 }
 
 type rpcEndpointConfig struct {
-	jwtSecret              []byte // optional JWT secret
+	authClients            *authClientSet  // optional JWT authentication
+	permissions            *rpcPermissions // optional method permissions
+	accessLog              *accessLog      // optional access log
 	batchItemLimit         int
 	batchResponseSizeLimit int
 	httpBodyLimit          int
+	dedupMethods           []string
+	streamedMethods        []string
+	drainRejectedMethods   []string
 }
 
 type rpcHandler struct {
@@ -74,6 +79,7 @@ type httpServer struct {
 	mu       sync.Mutex
 	server   *http.Server
 	listener net.Listener // non-nil when server is running
+	draining bool         // Whether the RPC servers behind the endpoint are draining
 
 	// HTTP RPC handler things.
 
@@ -171,7 +177,7 @@ func (h *httpServer) start() error {
 	}
 	// Log http endpoint.
 	h.log.Info("HTTP server started",
-		"endpoint", listener.Addr(), "auth", h.httpConfig.jwtSecret != nil,
+		"endpoint", listener.Addr(), "auth", h.httpConfig.authClients != nil,
 		"prefix", h.httpConfig.prefix,
 		"cors", strings.Join(h.httpConfig.CorsAllowedOrigins, ","),
 		"vhosts", strings.Join(h.httpConfig.Vhosts, ","),
@@ -292,6 +298,35 @@ func (h *httpServer) doStop() {
 	h.server, h.listener = nil, nil
 }
 
+// setDraining switches the drain mode of the RPC servers behind this endpoint.
+// The mode is retained, handlers created later on start in the same mode.
+func (h *httpServer) setDraining(draining bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.draining = draining
+	for _, handler := range []*rpcHandler{h.httpHandler.Load(), h.wsHandler.Load()} {
+		switch {
+		case handler == nil:
+		case draining:
+			handler.server.StartDrain()
+		default:
+			handler.server.StopDrain()
+		}
+	}
+}
+
+// inFlight returns the number of requests being served by this endpoint.
+func (h *httpServer) inFlight() int {
+	var n int
+	for _, handler := range []*rpcHandler{h.httpHandler.Load(), h.wsHandler.Load()} {
+		if handler != nil {
+			n += handler.server.InFlight()
+		}
+	}
+	return n
+}
+
 // enableRPC turns on JSON-RPC over HTTP on the server.
 func (h *httpServer) enableRPC(apis []rpc.API, config httpConfig) error {
 	h.mu.Lock()
@@ -302,20 +337,94 @@ func (h *httpServer) enableRPC(apis []rpc.API, config httpConfig) error {
 	}
 
 	// Create RPC server and handler.
+	srv, err := h.newRPCServer(apis, config.Modules, config.rpcEndpointConfig)
+	if err != nil {
+		return err
+	}
+	h.httpConfig = config
+	h.httpHandler.Store(&rpcHandler{
+		Handler: config.withAPIKey(newHTTPHandlerStack(srv, config.CorsAllowedOrigins, config.Vhosts, config.authClients)),
+		prefix:  config.prefix,
+		server:  srv,
+	})
+	return nil
+}
+
+// newRPCServer creates an RPC server for a handler of the endpoint, draining if
+// the endpoint is. This is internal, the caller must hold h.mu.
+func (h *httpServer) newRPCServer(apis []rpc.API, modules []string, config rpcEndpointConfig) (*rpc.Server, error) {
+	srv, err := newRPCServer(apis, modules, config)
+	if err != nil {
+		return nil, err
+	}
+	if h.draining {
+		srv.StartDrain()
+	}
+	return srv, nil
+}
+
+// newRPCServer creates an RPC server exposing the given modules.
+func newRPCServer(apis []rpc.API, modules []string, config rpcEndpointConfig) (*rpc.Server, error) {
 	srv := rpc.NewServer()
 	srv.SetBatchLimits(config.batchItemLimit, config.batchResponseSizeLimit)
+	srv.SetDeduplicatedMethods(config.dedupMethods)
+	srv.SetStreamedMethods(config.streamedMethods)
+	srv.SetDrainRejectedMethods(config.drainRejectedMethods)
+	var filters []rpc.CallFilter
+	if config.authClients != nil {
+		filters = append(filters, config.authClients.filter)
+	}
+	if config.permissions != nil {
+		filters = append(filters, config.permissions.filter(config.authClients != nil))
+	}
+	if filter := chainCallFilters(filters...); filter != nil {
+		srv.SetCallFilter(filter)
+	}
+	if config.accessLog != nil {
+		srv.SetCallLogger(config.accessLog.logCall)
+	}
 	if config.httpBodyLimit > 0 {
 		srv.SetHTTPBodyLimit(config.httpBodyLimit)
 	}
-	if err := RegisterApis(apis, config.Modules, srv); err != nil {
+	if err := RegisterApis(apis, modules, srv); err != nil {
+		return nil, err
+	}
+	return srv, nil
+}
+
+// withAPIKey makes the API keys of requests available to the permissions
+// filter and access log of an unauthenticated endpoint.
+func (config rpcEndpointConfig) withAPIKey(handler http.Handler) http.Handler {
+	if (config.permissions == nil && config.accessLog == nil) || config.authClients != nil {
+		return handler
+	}
+	return newAPIKeyHandler(handler)
+}
+
+// setRPCModules replaces the HTTP RPC handler with one exposing the given
+// modules. The listener stays open, requests arriving after the swap are
+// served by the new handler.
+func (h *httpServer) setRPCModules(apis []rpc.API, modules []string) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	old := h.httpHandler.Load()
+	if old == nil {
+		return errors.New("JSON-RPC over HTTP is not enabled")
+	}
+	config := h.httpConfig
+	config.Modules = modules
+	srv, err := h.newRPCServer(apis, config.Modules, config.rpcEndpointConfig)
+	if err != nil {
 		return err
 	}
 	h.httpConfig = config
 	h.httpHandler.Store(&rpcHandler{
-		Handler: NewHTTPHandlerStack(srv, config.CorsAllowedOrigins, config.Vhosts, config.jwtSecret),
+		Handler: config.withAPIKey(newHTTPHandlerStack(srv, config.CorsAllowedOrigins, config.Vhosts, config.authClients)),
 		prefix:  config.prefix,
 		server:  srv,
 	})
+	old.server.Stop()
 	return nil
 }
 
@@ -338,20 +447,43 @@ func (h *httpServer) enableWS(apis []rpc.API, config wsConfig) error {
 		return errors.New("JSON-RPC over WebSocket is already enabled")
 	}
 	// Create RPC server and handler.
-	srv := rpc.NewServer()
-	srv.SetBatchLimits(config.batchItemLimit, config.batchResponseSizeLimit)
-	if config.httpBodyLimit > 0 {
-		srv.SetHTTPBodyLimit(config.httpBodyLimit)
+	srv, err := h.newRPCServer(apis, config.Modules, config.rpcEndpointConfig)
+	if err != nil {
+		return err
 	}
-	if err := RegisterApis(apis, config.Modules, srv); err != nil {
+	h.wsConfig = config
+	h.wsHandler.Store(&rpcHandler{
+		Handler: config.withAPIKey(newWSHandlerStack(srv.WebsocketHandler(config.Origins), config.authClients)),
+		prefix:  config.prefix,
+		server:  srv,
+	})
+	return nil
+}
+
+// setWSModules replaces the WebSocket RPC handler with one exposing the given
+// modules. Open connections are closed, clients see the new modules when they
+// reconnect.
+func (h *httpServer) setWSModules(apis []rpc.API, modules []string) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	old := h.wsHandler.Load()
+	if old == nil {
+		return errors.New("JSON-RPC over WebSocket is not enabled")
+	}
+	config := h.wsConfig
+	config.Modules = modules
+	srv, err := h.newRPCServer(apis, config.Modules, config.rpcEndpointConfig)
+	if err != nil {
 		return err
 	}
 	h.wsConfig = config
 	h.wsHandler.Store(&rpcHandler{
-		Handler: NewWSHandlerStack(srv.WebsocketHandler(config.Origins), config.jwtSecret),
+		Handler: config.withAPIKey(newWSHandlerStack(srv.WebsocketHandler(config.Origins), config.authClients)),
 		prefix:  config.prefix,
 		server:  srv,
 	})
+	old.server.Stop()
 	return nil
 }
 
@@ -395,19 +527,35 @@ func isWebsocket(r *http.Request) bool {
 
 // NewHTTPHandlerStack returns wrapped http-related handlers
 func NewHTTPHandlerStack(srv http.Handler, cors []string, vhosts []string, jwtSecret []byte) http.Handler {
+	var clients *authClientSet
+	if len(jwtSecret) != 0 {
+		clients = newAuthClientSet(newJWTSecretKey(jwtSecret))
+	}
+	return newHTTPHandlerStack(srv, cors, vhosts, clients)
+}
+
+func newHTTPHandlerStack(srv http.Handler, cors []string, vhosts []string, clients *authClientSet) http.Handler {
 	// Wrap the CORS-handler within a host-handler
 	handler := newCorsHandler(srv, cors)
 	handler = newVHostHandler(vhosts, handler)
-	if len(jwtSecret) != 0 {
-		handler = newJWTHandler(jwtSecret, handler)
+	if clients != nil {
+		handler = newJWTHandler(clients, handler)
 	}
 	return newGzipHandler(handler)
 }
 
 // NewWSHandlerStack returns a wrapped ws-related handler.
 func NewWSHandlerStack(srv http.Handler, jwtSecret []byte) http.Handler {
+	var clients *authClientSet
 	if len(jwtSecret) != 0 {
-		return newJWTHandler(jwtSecret, srv)
+		clients = newAuthClientSet(newJWTSecretKey(jwtSecret))
+	}
+	return newWSHandlerStack(srv, clients)
+}
+
+func newWSHandlerStack(srv http.Handler, clients *authClientSet) http.Handler {
+	if clients != nil {
+		return newJWTHandler(clients, srv)
 	}
 	return srv
 }
@@ -561,6 +709,11 @@ func (w *gzipResponseWriter) Flush() {
 	}
 }
 
+// Unwrap returns the underlying writer, for use by http.ResponseController.
+func (w *gzipResponseWriter) Unwrap() http.ResponseWriter {
+	return w.resp
+}
+
 func (w *gzipResponseWriter) close() {
 	if w.gz == nil {
 		return
@@ -597,15 +750,16 @@ func newIPCServer(log log.Logger, endpoint string) *ipcServer {
 	return &ipcServer{log: log, endpoint: endpoint}
 }
 
-// start starts the httpServer's http.Server
-func (is *ipcServer) start(apis []rpc.API) error {
+// start starts the httpServer's http.Server. The configure function, if non-nil,
+// is invoked on the RPC server before it starts serving.
+func (is *ipcServer) start(apis []rpc.API, configure func(*rpc.Server)) error {
 	is.mu.Lock()
 	defer is.mu.Unlock()
 
 	if is.listener != nil {
 		return nil // already running
 	}
-	listener, srv, err := rpc.StartIPCEndpoint(is.endpoint, apis)
+	listener, srv, err := rpc.StartConfiguredIPCEndpoint(is.endpoint, apis, configure)
 	if err != nil {
 		is.log.Warn("IPC opening failed", "url", is.endpoint, "error", err)
 		return err
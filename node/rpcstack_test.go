@@ -235,6 +235,58 @@ func Test_checkPath(t *testing.T) {
 	}
 }
 
+// TestDrain checks that a draining server refuses new WebSocket connections and fails
+// health checks, while still serving regular HTTP calls.
+func TestDrain(t *testing.T) {
+	srv := createAndStartServer(t, &httpConfig{}, true, &wsConfig{Origins: []string{"*"}}, nil)
+	defer srv.stop()
+	url := "http://" + srv.listenAddr()
+	wsURL := "ws://" + srv.listenAddr()
+
+	srv.setDraining(true)
+	assert.Error(t, wsRequest(t, wsURL))
+	resp, err := http.Get(url)
+	assert.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	resp = rpcRequest(t, url, "rpc_modules")
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	srv.setDraining(false)
+	assert.NoError(t, wsRequest(t, wsURL))
+	resp, err = http.Get(url)
+	assert.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+// TestDrainRestart checks that the drain mode survives the endpoint being stopped
+// and started again, as done by admin_stopHTTP and admin_startHTTP.
+func TestDrainRestart(t *testing.T) {
+	srv := createAndStartServer(t, &httpConfig{}, true, &wsConfig{Origins: []string{"*"}}, nil)
+	defer srv.stop()
+
+	srv.setDraining(true)
+	srv.stop()
+	assert.NoError(t, srv.enableRPC(apis(), httpConfig{}))
+	assert.NoError(t, srv.enableWS(nil, wsConfig{Origins: []string{"*"}}))
+	assert.NoError(t, srv.setListenAddr("localhost", 0))
+	assert.NoError(t, srv.start())
+
+	url := "http://" + srv.listenAddr()
+	wsURL := "ws://" + srv.listenAddr()
+	assert.Error(t, wsRequest(t, wsURL))
+	resp, err := http.Get(url)
+	assert.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+
+	// Handlers replaced while the endpoint is running must drain too
+	assert.NoError(t, srv.setWSModules(nil, nil))
+	assert.Error(t, wsRequest(t, wsURL))
+}
+
 func createAndStartServer(t *testing.T, conf *httpConfig, ws bool, wsConf *wsConfig, timeouts *rpc.HTTPTimeouts) *httpServer {
 	t.Helper()
 
@@ -339,7 +391,7 @@ func TestJWT(t *testing.T) {
 		ss, _ := jwt.NewWithClaims(method, testClaim(input)).SignedString(secret)
 		return ss
 	}
-	cfg := rpcEndpointConfig{jwtSecret: []byte("secret")}
+	cfg := rpcEndpointConfig{authClients: newAuthClientSet(newJWTSecretKey([]byte("secret")))}
 	httpcfg := &httpConfig{rpcEndpointConfig: cfg}
 	wscfg := &wsConfig{Origins: []string{"*"}, rpcEndpointConfig: cfg}
 	srv := createAndStartServer(t, httpcfg, true, wscfg, nil)
@@ -0,0 +1,97 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package node
+
+import (
+	"path/filepath"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/fsnotify/fsnotify"
+)
+
+// secretsWatcher invokes a reload callback whenever a secret or other watched
+// file changes on disk.
+// The parent directory is watched instead of the file itself, so that secrets
+// replaced by an atomic rename (as done by most secret managers) are noticed too.
+type secretsWatcher struct {
+	watcher *fsnotify.Watcher
+	quit    chan struct{}
+	done    chan struct{}
+}
+
+func newSecretsWatcher(path string, reload func() error) (*secretsWatcher, error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	path = filepath.Clean(path)
+	if err := watcher.Add(filepath.Dir(path)); err != nil {
+		watcher.Close()
+		return nil, err
+	}
+	w := &secretsWatcher{
+		watcher: watcher,
+		quit:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	go w.loop(path, reload)
+	return w, nil
+}
+
+func (w *secretsWatcher) loop(path string, reload func() error) {
+	defer close(w.done)
+	defer w.watcher.Close()
+
+	// Writers often touch the file several times in quick succession, so the
+	// reload is delayed a bit to only act on the final contents.
+	const debounceDuration = 500 * time.Millisecond
+	debounce := time.NewTimer(0)
+	if !debounce.Stop() {
+		<-debounce.C
+	}
+	defer debounce.Stop()
+
+	for {
+		select {
+		case <-w.quit:
+			return
+		case ev, ok := <-w.watcher.Events:
+			if !ok {
+				return
+			}
+			if filepath.Clean(ev.Name) == path && ev.Op&(fsnotify.Write|fsnotify.Create) != 0 {
+				debounce.Reset(debounceDuration)
+			}
+		case err, ok := <-w.watcher.Errors:
+			if !ok {
+				return
+			}
+			log.Info("Secrets watcher error", "err", err)
+		case <-debounce.C:
+			if err := reload(); err != nil {
+				log.Warn("Failed to reload watched file", "path", path, "err", err)
+			}
+		}
+	}
+}
+
+// close stops watching and waits for the watcher loop to exit.
+func (w *secretsWatcher) close() {
+	close(w.quit)
+	<-w.done
+}
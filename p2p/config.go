@@ -79,6 +79,10 @@ type Config struct {
 	// allowed to connect, even above the peer limit.
 	TrustedNodes []*enode.Node
 
+	// PeerGroups are named sets of nodes which are dialed like static nodes,
+	// with per-group dial priorities, peer limits and health tracking.
+	PeerGroups []PeerGroup `toml:",omitempty"`
+
 	// Connectivity can be restricted to certain IP networks.
 	// If this option is set to a non-nil value, only hosts which match one of the
 	// IP networks contained in the list are considered.
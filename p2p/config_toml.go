@@ -28,6 +28,7 @@ func (c Config) MarshalTOML() (interface{}, error) {
 		BootstrapNodesV5 []*enode.Node `toml:",omitempty"`
 		StaticNodes      []*enode.Node
 		TrustedNodes     []*enode.Node
+		PeerGroups       []PeerGroup      `toml:",omitempty"`
 		NetRestrict      *netutil.Netlist `toml:",omitempty"`
 		NodeDatabase     string           `toml:",omitempty"`
 		Protocols        []Protocol       `toml:"-" json:"-"`
@@ -52,6 +53,7 @@ func (c Config) MarshalTOML() (interface{}, error) {
 	enc.BootstrapNodesV5 = c.BootstrapNodesV5
 	enc.StaticNodes = c.StaticNodes
 	enc.TrustedNodes = c.TrustedNodes
+	enc.PeerGroups = c.PeerGroups
 	enc.NetRestrict = c.NetRestrict
 	enc.NodeDatabase = c.NodeDatabase
 	enc.Protocols = c.Protocols
@@ -80,6 +82,7 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 		BootstrapNodesV5 []*enode.Node `toml:",omitempty"`
 		StaticNodes      []*enode.Node
 		TrustedNodes     []*enode.Node
+		PeerGroups       []PeerGroup      `toml:",omitempty"`
 		NetRestrict      *netutil.Netlist `toml:",omitempty"`
 		NodeDatabase     *string          `toml:",omitempty"`
 		Protocols        []Protocol       `toml:"-" json:"-"`
@@ -131,6 +134,9 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 	if dec.TrustedNodes != nil {
 		c.TrustedNodes = dec.TrustedNodes
 	}
+	if dec.PeerGroups != nil {
+		c.PeerGroups = dec.PeerGroups
+	}
 	if dec.NetRestrict != nil {
 		c.NetRestrict = dec.NetRestrict
 	}
@@ -102,6 +102,7 @@ type dialScheduler struct {
 	doneCh        chan *dialTask
 	addStaticCh   chan *enode.Node
 	remStaticCh   chan *enode.Node
+	setLimitCh    chan int
 	addPeerCh     chan *conn
 	remPeerCh     chan *conn
 
@@ -139,6 +140,10 @@ type dialConfig struct {
 	log            log.Logger
 	clock          mclock.Clock
 	rand           *mrand.Rand
+
+	// staticPriority returns the dial priority of a static node and whether
+	// it should be dialed at all. If nil, all static nodes are equal.
+	staticPriority func(enode.ID) (int, bool)
 }
 
 func (cfg dialConfig) withDefaults() dialConfig {
@@ -174,6 +179,7 @@ func newDialScheduler(config dialConfig, it enode.Iterator, setupFunc dialSetupF
 		nodesIn:       make(chan *enode.Node),
 		addStaticCh:   make(chan *enode.Node),
 		remStaticCh:   make(chan *enode.Node),
+		setLimitCh:    make(chan int),
 		addPeerCh:     make(chan *conn),
 		remPeerCh:     make(chan *conn),
 	}
@@ -207,6 +213,14 @@ func (d *dialScheduler) removeStatic(n *enode.Node) {
 	}
 }
 
+// setMaxDialPeers changes the maximum number of dialed peers.
+func (d *dialScheduler) setMaxDialPeers(n int) {
+	select {
+	case d.setLimitCh <- n:
+	case <-d.ctx.Done():
+	}
+}
+
 // peerAdded updates the peer set.
 func (d *dialScheduler) peerAdded(c *conn) {
 	select {
@@ -300,6 +314,9 @@ loop:
 				}
 			}
 
+		case n := <-d.setLimitCh:
+			d.maxDialPeers = n
+
 		case <-d.historyTimer.C():
 			d.expireHistory()
 
@@ -402,7 +419,10 @@ func (d *dialScheduler) checkDial(n *enode.Node) error {
 // startStaticDials starts n static dial tasks.
 func (d *dialScheduler) startStaticDials(n int) (started int) {
 	for started = 0; started < n && len(d.staticPool) > 0; started++ {
-		idx := d.rand.Intn(len(d.staticPool))
+		idx := d.pickStatic()
+		if idx < 0 {
+			break
+		}
 		task := d.staticPool[idx]
 		d.startDial(task)
 		d.removeFromStaticPool(idx)
@@ -410,6 +430,35 @@ func (d *dialScheduler) startStaticDials(n int) (started int) {
 	return started
 }
 
+// pickStatic selects a random task among the static dials with the highest
+// priority. It returns -1 if none of the tasks should be dialed.
+func (d *dialScheduler) pickStatic() int {
+	if d.staticPriority == nil {
+		return d.rand.Intn(len(d.staticPool))
+	}
+	var (
+		best       = -1
+		bestPrio   int
+		candidates int
+	)
+	for i, task := range d.staticPool {
+		prio, ok := d.staticPriority(task.dest().ID())
+		switch {
+		case !ok:
+			continue
+		case best < 0 || prio > bestPrio:
+			best, bestPrio, candidates = i, prio, 1
+		case prio == bestPrio:
+			// Reservoir sampling among the tasks of equal priority.
+			candidates++
+			if d.rand.Intn(candidates) == 0 {
+				best = i
+			}
+		}
+	}
+	return best
+}
+
 // updateStaticPool attempts to move the given static dial back into staticPool.
 func (d *dialScheduler) updateStaticPool(id enode.ID) {
 	task, ok := d.static[id]
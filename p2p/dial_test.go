@@ -279,6 +279,77 @@ func TestDialSchedRemoveStatic(t *testing.T) {
 	})
 }
 
+// This test checks that static dials are ordered by priority and that nodes
+// without a dial slot are skipped.
+func TestDialSchedStaticPriority(t *testing.T) {
+	t.Parallel()
+
+	priorities := map[enode.ID]int{
+		uintID(0x01): 0,
+		uintID(0x02): 1,
+		uintID(0x03): 2,
+	}
+	config := dialConfig{
+		maxActiveDials: 1,
+		maxDialPeers:   4,
+		staticPriority: func(id enode.ID) (int, bool) {
+			prio, ok := priorities[id]
+			return prio, ok
+		},
+	}
+	runDialTest(t, config, []dialTestRound{
+		// Static nodes are added while all slots are taken.
+		{
+			peersAdded: []*conn{
+				{flags: dynDialedConn, node: newNode(uintID(0xFFFC), "")},
+				{flags: dynDialedConn, node: newNode(uintID(0xFFFD), "")},
+				{flags: dynDialedConn, node: newNode(uintID(0xFFFE), "")},
+				{flags: dynDialedConn, node: newNode(uintID(0xFFFF), "")},
+			},
+			update: func(d *dialScheduler) {
+				d.addStatic(newNode(uintID(0x01), "127.0.0.1:30303"))
+				d.addStatic(newNode(uintID(0x02), "127.0.0.2:30303"))
+				d.addStatic(newNode(uintID(0x03), "127.0.0.3:30303"))
+				d.addStatic(newNode(uintID(0x04), "127.0.0.4:30303"))
+			},
+		},
+		// The node with the highest priority is dialed first.
+		{
+			peersRemoved: []enode.ID{
+				uintID(0xFFFC),
+				uintID(0xFFFD),
+				uintID(0xFFFE),
+				uintID(0xFFFF),
+			},
+			wantNewDials: []*enode.Node{
+				newNode(uintID(0x03), "127.0.0.3:30303"),
+			},
+		},
+		{
+			succeeded: []enode.ID{
+				uintID(0x03),
+			},
+			wantNewDials: []*enode.Node{
+				newNode(uintID(0x02), "127.0.0.2:30303"),
+			},
+		},
+		{
+			succeeded: []enode.ID{
+				uintID(0x02),
+			},
+			wantNewDials: []*enode.Node{
+				newNode(uintID(0x01), "127.0.0.1:30303"),
+			},
+		},
+		// 0x04 is never dialed although a slot is free.
+		{
+			succeeded: []enode.ID{
+				uintID(0x01),
+			},
+		},
+	})
+}
+
 // This test checks that static dials are selected at random.
 func TestDialSchedManyStaticNodes(t *testing.T) {
 	t.Parallel()
@@ -0,0 +1,160 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package msgrate
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/mclock"
+	"github.com/ethereum/go-ethereum/metrics"
+)
+
+var (
+	bandwidthInMeter       = metrics.NewRegisteredMeter("sync/bandwidth/in", nil)
+	bandwidthThrottleMeter = metrics.NewRegisteredMeter("sync/bandwidth/throttle", nil)
+	bandwidthDebtGauge     = metrics.NewRegisteredGauge("sync/bandwidth/debt", nil)
+)
+
+// Limiter caps the bandwidth of the data retrieved from remote peers, both in
+// total and per peer, using token buckets refilled at the configured rates.
+//
+// The size of a response is not known when requesting it, so the buckets are
+// charged after the fact with the size of the delivered data and may go into
+// debt. A peer may be sent new requests only while neither its own nor the
+// global bucket is in debt.
+//
+// A nil limiter imposes no limits.
+type Limiter struct {
+	clock    mclock.Clock
+	global   *bucket            // Bucket shared by all peers, nil if unlimited
+	peerRate uint64             // Bandwidth allowance of a single peer, 0 if unlimited
+	peers    map[string]*bucket // Buckets of the individual peers
+
+	lock sync.Mutex
+}
+
+// NewLimiter creates a limiter which caps the total bandwidth to rate and the
+// bandwidth of a single peer to peerRate bytes per second. Zero values mean no
+// limit, and a nil limiter is returned if neither is set.
+func NewLimiter(rate uint64, peerRate uint64) *Limiter {
+	if rate == 0 && peerRate == 0 {
+		return nil
+	}
+	l := &Limiter{
+		clock:    mclock.System{},
+		peerRate: peerRate,
+		peers:    make(map[string]*bucket),
+	}
+	if rate > 0 {
+		l.global = newBucket(rate, l.clock.Now())
+	}
+	return l
+}
+
+// Delay returns the time until the peer may be sent a new request, or zero if
+// it is not throttled.
+func (l *Limiter) Delay(peer string) time.Duration {
+	if l == nil {
+		return 0
+	}
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	now := l.clock.Now()
+	var delay time.Duration
+	if l.global != nil {
+		delay = l.global.delay(now)
+	}
+	if b := l.peers[peer]; b != nil {
+		delay = max(delay, b.delay(now))
+	}
+	if delay > 0 {
+		bandwidthThrottleMeter.Mark(1)
+	}
+	return delay
+}
+
+// Charge accounts the given number of bytes delivered by the peer.
+func (l *Limiter) Charge(peer string, size int) {
+	if l == nil {
+		return
+	}
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	bandwidthInMeter.Mark(int64(size))
+
+	now := l.clock.Now()
+	if l.global != nil {
+		l.global.take(now, size)
+		bandwidthDebtGauge.Update(int64(max(-l.global.tokens, 0)))
+	}
+	if l.peerRate > 0 {
+		b := l.peers[peer]
+		if b == nil {
+			b = newBucket(l.peerRate, now)
+			l.peers[peer] = b
+		}
+		b.take(now, size)
+	}
+}
+
+// Remove drops the bucket of a disconnected peer.
+func (l *Limiter) Remove(peer string) {
+	if l == nil {
+		return
+	}
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	delete(l.peers, peer)
+}
+
+// bucket is a token bucket holding up to one second worth of bandwidth.
+type bucket struct {
+	rate   float64 // Refill rate in bytes per second
+	tokens float64 // Available bytes, negative if in debt
+	last   mclock.AbsTime
+}
+
+func newBucket(rate uint64, now mclock.AbsTime) *bucket {
+	return &bucket{rate: float64(rate), tokens: float64(rate), last: now}
+}
+
+// refill adds the tokens accumulated since the last update.
+func (b *bucket) refill(now mclock.AbsTime) {
+	elapsed := time.Duration(now - b.last).Seconds()
+	b.tokens = min(b.rate, b.tokens+elapsed*b.rate)
+	b.last = now
+}
+
+// delay returns the time until the debt of the bucket is repaid.
+func (b *bucket) delay(now mclock.AbsTime) time.Duration {
+	b.refill(now)
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(math.Ceil(-b.tokens / b.rate * float64(time.Second)))
+}
+
+// take removes size tokens from the bucket.
+func (b *bucket) take(now mclock.AbsTime, size int) {
+	b.refill(now)
+	b.tokens -= float64(size)
+}
@@ -0,0 +1,74 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package msgrate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/mclock"
+)
+
+func TestLimiter(t *testing.T) {
+	clock := new(mclock.Simulated)
+	l := NewLimiter(3000, 1000)
+	l.clock = clock
+	l.global.last = clock.Now()
+
+	// The first second worth of data is allowed without throttling
+	l.Charge("a", 1000)
+	if delay := l.Delay("a"); delay != 0 {
+		t.Fatalf("peer throttled within its allowance: %v", delay)
+	}
+	// Going into debt throttles the peer until it's repaid, but not the others
+	l.Charge("a", 500)
+	if delay := l.Delay("a"); delay != 500*time.Millisecond {
+		t.Fatalf("peer delay mismatch: have %v, want %v", delay, 500*time.Millisecond)
+	}
+	if delay := l.Delay("b"); delay != 0 {
+		t.Fatalf("other peer throttled: %v", delay)
+	}
+	clock.Run(500 * time.Millisecond)
+	if delay := l.Delay("a"); delay != 0 {
+		t.Fatalf("peer throttled after repaying its debt: %v", delay)
+	}
+	// Exceeding the global allowance throttles all peers
+	l.Charge("b", 1500)
+	l.Charge("c", 1500)
+	l.Charge("d", 1500)
+	if delay := l.Delay("e"); delay != 500*time.Millisecond {
+		t.Fatalf("global delay mismatch: have %v, want %v", delay, 500*time.Millisecond)
+	}
+	// Removed peers start over with a full allowance
+	clock.Run(time.Second)
+	l.Charge("a", 1500)
+	l.Remove("a")
+	if delay := l.Delay("a"); delay != 0 {
+		t.Fatalf("removed peer throttled: %v", delay)
+	}
+}
+
+func TestLimiterUnlimited(t *testing.T) {
+	l := NewLimiter(0, 0)
+	if l != nil {
+		t.Fatal("limiter created without limits")
+	}
+	l.Charge("a", 1<<30)
+	if delay := l.Delay("a"); delay != 0 {
+		t.Fatalf("nil limiter throttled: %v", delay)
+	}
+}
@@ -0,0 +1,184 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package msgrate
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	// scoreLatencyTarget is the response latency up to which a peer isn't
+	// penalized, slower peers are scored proportionally lower.
+	scoreLatencyTarget = 500 * time.Millisecond
+
+	// scoreLatencyImpact is the weight of a new latency measurement in the
+	// moving average of a peer.
+	scoreLatencyImpact = 0.1
+)
+
+// PeerStats is the quality record of a peer.
+type PeerStats struct {
+	Latency    time.Duration `json:"latency"`    // Moving average of the response latency
+	Deliveries uint64        `json:"deliveries"` // Number of requests answered in time
+	Timeouts   uint64        `json:"timeouts"`   // Number of requests timed out
+	Useful     uint64        `json:"useful"`     // Number of delivered items accepted
+	Useless    uint64        `json:"useless"`    // Number of delivered items rejected or not needed
+	Score      float64       `json:"score"`      // Overall quality of the peer in [0, 1]
+}
+
+// score calculates the overall quality of the peer as the product of its
+// delivery success rate, its useful data ratio and its latency factor. The
+// rates are smoothed so that new peers start with a perfect score.
+func (s *PeerStats) score() float64 {
+	var (
+		success = float64(s.Deliveries+1) / float64(s.Deliveries+s.Timeouts+1)
+		useful  = float64(s.Useful+1) / float64(s.Useful+s.Useless+1)
+		latency = 1.0
+	)
+	if s.Latency > scoreLatencyTarget {
+		latency = float64(scoreLatencyTarget) / float64(s.Latency)
+	}
+	return success * useful * latency
+}
+
+// Scores tracks the quality of the data served by the remote peers. The sync
+// and the fetchers share a single instance, so that all of them can favor the
+// peers being responsive and delivering useful data.
+//
+// A nil tracker records nothing and scores all peers as perfect.
+type Scores struct {
+	peers map[string]*PeerStats
+	lock  sync.RWMutex
+}
+
+// NewScores creates an empty peer quality tracker.
+func NewScores() *Scores {
+	return &Scores{peers: make(map[string]*PeerStats)}
+}
+
+// stats returns the record of the peer, creating it if it doesn't exist yet.
+// The caller must hold the write lock.
+func (s *Scores) stats(peer string) *PeerStats {
+	stats := s.peers[peer]
+	if stats == nil {
+		stats = new(PeerStats)
+		s.peers[peer] = stats
+	}
+	return stats
+}
+
+// Delivered records a response of the peer received in time.
+func (s *Scores) Delivered(peer string, latency time.Duration) {
+	if s == nil {
+		return
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	stats := s.stats(peer)
+	if stats.Deliveries == 0 {
+		stats.Latency = latency
+	} else {
+		stats.Latency = time.Duration((1-scoreLatencyImpact)*float64(stats.Latency) + scoreLatencyImpact*float64(latency))
+	}
+	stats.Deliveries++
+}
+
+// TimedOut records a request to the peer which wasn't answered in time.
+func (s *Scores) TimedOut(peer string) {
+	if s == nil {
+		return
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.stats(peer).Timeouts++
+}
+
+// Served records the number of useful and useless items delivered by the peer.
+func (s *Scores) Served(peer string, useful, useless int) {
+	if s == nil || (useful == 0 && useless == 0) {
+		return
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	stats := s.stats(peer)
+	stats.Useful += uint64(useful)
+	stats.Useless += uint64(useless)
+}
+
+// Score returns the overall quality of the peer in [0, 1].
+func (s *Scores) Score(peer string) float64 {
+	if s == nil {
+		return 1
+	}
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	stats := s.peers[peer]
+	if stats == nil {
+		return 1
+	}
+	return stats.score()
+}
+
+// Stats returns the quality record of the peer.
+func (s *Scores) Stats(peer string) PeerStats {
+	if s == nil {
+		return PeerStats{Score: 1}
+	}
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	stats := s.peers[peer]
+	if stats == nil {
+		return PeerStats{Score: 1}
+	}
+	res := *stats
+	res.Score = stats.score()
+	return res
+}
+
+// All returns the quality records of all tracked peers.
+func (s *Scores) All() map[string]PeerStats {
+	if s == nil {
+		return nil
+	}
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	all := make(map[string]PeerStats, len(s.peers))
+	for peer, stats := range s.peers {
+		res := *stats
+		res.Score = stats.score()
+		all[peer] = res
+	}
+	return all
+}
+
+// Remove drops the record of a disconnected peer.
+func (s *Scores) Remove(peer string) {
+	if s == nil {
+		return
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	delete(s.peers, peer)
+}
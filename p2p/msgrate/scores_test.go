@@ -0,0 +1,65 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package msgrate
+
+import (
+	"testing"
+	"time"
+)
+
+func TestScores(t *testing.T) {
+	s := NewScores()
+
+	// Unknown peers are considered perfect
+	if score := s.Score("a"); score != 1 {
+		t.Fatalf("unknown peer score mismatch: have %v, want 1", score)
+	}
+	// Fast peers delivering useful data keep a perfect score
+	s.Delivered("a", 100*time.Millisecond)
+	s.Served("a", 10, 0)
+	if score := s.Score("a"); score != 1 {
+		t.Fatalf("good peer score mismatch: have %v, want 1", score)
+	}
+	// Slow, timing out peers delivering junk are penalized on all counts
+	s.Delivered("b", 2*scoreLatencyTarget)
+	for i := 0; i < 2; i++ {
+		s.TimedOut("b")
+	}
+	s.Served("b", 1, 2)
+	if have, want := s.Score("b"), 0.5*0.5*0.5; have != want {
+		t.Fatalf("bad peer score mismatch: have %v, want %v", have, want)
+	}
+	// The latency is averaged over the deliveries
+	s.Delivered("b", 0)
+	if have, want := s.Stats("b").Latency, time.Duration(0.9*float64(2*scoreLatencyTarget)); have != want {
+		t.Fatalf("latency mismatch: have %v, want %v", have, want)
+	}
+	if all := s.All(); len(all) != 2 {
+		t.Fatalf("tracked peer count mismatch: have %d, want 2", len(all))
+	}
+	// Removed peers start over
+	s.Remove("b")
+	if score := s.Score("b"); score != 1 {
+		t.Fatalf("removed peer score mismatch: have %v, want 1", score)
+	}
+	// A nil tracker scores everyone perfect
+	var n *Scores
+	n.TimedOut("a")
+	if score := n.Score("a"); score != 1 {
+		t.Fatalf("nil tracker score mismatch: have %v, want 1", score)
+	}
+}
@@ -0,0 +1,184 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package nat
+
+import (
+	"errors"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// rediscoverInterval is the minimum time between two discoveries of the
+// gateways on the local network.
+const rediscoverInterval = 5 * time.Minute
+
+var errNoGateway = errors.New("no NAT gateway available")
+
+// sharedAddressSpace is the range used by carrier-grade NATs (RFC 6598).
+var sharedAddressSpace = net.IPNet{IP: net.IP{100, 64, 0, 0}, Mask: net.CIDRMask(10, 32)}
+
+type mappingKey struct {
+	protocol string
+	intport  int
+}
+
+// gateways is a port mapper using all gateways discovered on the local network.
+// Mappings are requested from the gateway which accepted the last one, falling
+// over to the others if it fails. When all gateways fail, they are discovered
+// again. The external IP is obtained via STUN if no gateway reports a public
+// address, as is the case behind a carrier-grade NAT.
+type gateways struct {
+	discover func() []Interface
+	stun     Interface
+
+	mu         sync.Mutex
+	list       []Interface
+	active     int                      // index of the preferred gateway
+	failed     bool                     // set if no gateway accepted the last mapping
+	discovered time.Time                // time of the last discovery
+	mappings   map[mappingKey]Interface // gateway holding each mapping
+}
+
+func newGateways(discover func() []Interface, stun Interface) *gateways {
+	return &gateways{
+		discover:   discover,
+		stun:       stun,
+		list:       discover(),
+		discovered: time.Now(),
+		mappings:   make(map[mappingKey]Interface),
+	}
+}
+
+// discoverGateways returns the UPnP and NAT-PMP gateways of the local network.
+func discoverGateways() []Interface {
+	upnp := make(chan Interface, 1)
+	go func() { upnp <- discoverUPnP() }()
+
+	gws := discoverPMPGateways()
+	if gw := <-upnp; gw != nil {
+		gws = append([]Interface{gw}, gws...)
+	}
+	return gws
+}
+
+// rediscover refreshes the gateway list if no gateway is usable and the last
+// discovery is old enough.
+func (g *gateways) rediscover() {
+	if len(g.list) > 0 && !g.failed {
+		return
+	}
+	if time.Since(g.discovered) < rediscoverInterval {
+		return
+	}
+	g.list, g.active, g.failed = g.discover(), 0, false
+	g.discovered = time.Now()
+	log.Debug("Discovered NAT gateways", "count", len(g.list))
+}
+
+func (g *gateways) AddMapping(protocol string, extport, intport int, name string, lifetime time.Duration) (uint16, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.rediscover()
+	key := mappingKey{protocol, intport}
+	err := errNoGateway
+	for i := range g.list {
+		var (
+			index = (g.active + i) % len(g.list)
+			gw    = g.list[index]
+			port  uint16
+		)
+		if port, err = gw.AddMapping(protocol, extport, intport, name, lifetime); err != nil {
+			log.Debug("Gateway couldn't add port mapping", "gateway", gw, "err", err)
+			continue
+		}
+		// Release the mapping on the gateway previously holding it.
+		if prev := g.mappings[key]; prev != nil && prev != gw {
+			prev.DeleteMapping(protocol, extport, intport)
+		}
+		g.mappings[key] = gw
+		g.active, g.failed = index, false
+		return port, nil
+	}
+	g.failed = true
+	return 0, err
+}
+
+func (g *gateways) DeleteMapping(protocol string, extport, intport int) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	key := mappingKey{protocol, intport}
+	gw := g.mappings[key]
+	if gw == nil {
+		return nil
+	}
+	delete(g.mappings, key)
+	return gw.DeleteMapping(protocol, extport, intport)
+}
+
+func (g *gateways) ExternalIP() (net.IP, error) {
+	var (
+		gw  = g.gateway()
+		ip  net.IP
+		err = errNoGateway
+	)
+	if gw != nil {
+		ip, err = gw.ExternalIP()
+		if err == nil && isPublicIP(ip) {
+			return ip, nil
+		}
+		log.Debug("Gateway didn't report public external IP", "gateway", gw, "ip", ip, "err", err)
+	}
+	if g.stun == nil {
+		return ip, err
+	}
+	stunIP, stunErr := g.stun.ExternalIP()
+	if stunErr != nil && ip != nil {
+		return ip, nil // Better than nothing
+	}
+	return stunIP, stunErr
+}
+
+func (g *gateways) String() string {
+	if gw := g.gateway(); gw != nil {
+		return gw.String()
+	}
+	if g.stun != nil {
+		return g.stun.String()
+	}
+	return "none"
+}
+
+// gateway returns the preferred gateway, or nil if none was discovered.
+func (g *gateways) gateway() Interface {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if len(g.list) == 0 {
+		return nil
+	}
+	return g.list[g.active]
+}
+
+// isPublicIP reports whether ip is reachable from the Internet.
+func isPublicIP(ip net.IP) bool {
+	return ip.IsGlobalUnicast() && !ip.IsPrivate() && !sharedAddressSpace.Contains(ip)
+}
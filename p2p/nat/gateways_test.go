@@ -0,0 +1,166 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package nat
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+)
+
+// fakeGateway is a gateway assigning a fixed external port.
+type fakeGateway struct {
+	name     string
+	ip       net.IP
+	port     uint16 // assigned external port, the requested one if zero
+	fail     bool
+	mappings map[int]int // external port by internal port
+}
+
+func newFakeGateway(name string, ip net.IP) *fakeGateway {
+	return &fakeGateway{name: name, ip: ip, mappings: make(map[int]int)}
+}
+
+func (g *fakeGateway) AddMapping(protocol string, extport, intport int, name string, lifetime time.Duration) (uint16, error) {
+	if g.fail {
+		return 0, errors.New("mapping failed")
+	}
+	port := uint16(extport)
+	if g.port != 0 {
+		port = g.port
+	}
+	g.mappings[intport] = int(port)
+	return port, nil
+}
+
+func (g *fakeGateway) DeleteMapping(protocol string, extport, intport int) error {
+	if g.mappings[intport] != extport {
+		return errors.New("unknown mapping")
+	}
+	delete(g.mappings, intport)
+	return nil
+}
+
+func (g *fakeGateway) ExternalIP() (net.IP, error) {
+	if g.ip == nil {
+		return nil, errors.New("no external IP")
+	}
+	return g.ip, nil
+}
+
+func (g *fakeGateway) String() string { return g.name }
+
+func TestGatewaysFailover(t *testing.T) {
+	var (
+		gw1 = newFakeGateway("gw1", net.IP{1, 2, 3, 4})
+		gw2 = newFakeGateway("gw2", net.IP{5, 6, 7, 8})
+		g   = newGateways(func() []Interface { return []Interface{gw1, gw2} }, nil)
+	)
+	if _, err := g.AddMapping("TCP", 30303, 30303, "test", time.Minute); err != nil {
+		t.Fatalf("failed to add mapping: %v", err)
+	}
+	if len(gw1.mappings) != 1 || len(gw2.mappings) != 0 {
+		t.Fatalf("mapping not added on the first gateway")
+	}
+	// Fail the first gateway, the mapping should move to the second one.
+	gw1.fail = true
+	gw2.port = 40404
+	port, err := g.AddMapping("TCP", 30303, 30303, "test", time.Minute)
+	if err != nil {
+		t.Fatalf("failed to refresh mapping: %v", err)
+	}
+	if port != 40404 {
+		t.Errorf("wrong mapped port: have %d, want %d", port, 40404)
+	}
+	if len(gw1.mappings) != 0 || len(gw2.mappings) != 1 {
+		t.Fatalf("mapping not moved to the second gateway")
+	}
+	if ip, _ := g.ExternalIP(); !ip.Equal(gw2.ip) {
+		t.Errorf("wrong external IP: have %v, want %v", ip, gw2.ip)
+	}
+	if err := g.DeleteMapping("TCP", int(port), 30303); err != nil {
+		t.Fatalf("failed to delete mapping: %v", err)
+	}
+	if len(gw2.mappings) != 0 {
+		t.Fatalf("mapping not deleted")
+	}
+}
+
+func TestGatewaysRediscover(t *testing.T) {
+	var (
+		gw        = newFakeGateway("gw", net.IP{1, 2, 3, 4})
+		discovery int
+		g         = newGateways(func() []Interface {
+			discovery++
+			if discovery == 1 {
+				return nil
+			}
+			return []Interface{gw}
+		}, nil)
+	)
+	if _, err := g.AddMapping("UDP", 30303, 30303, "test", time.Minute); !errors.Is(err, errNoGateway) {
+		t.Fatalf("wrong error without gateway: have %v, want %v", err, errNoGateway)
+	}
+	// The gateway becomes available once the rediscovery interval has passed.
+	g.discovered = time.Now().Add(-rediscoverInterval)
+	if _, err := g.AddMapping("UDP", 30303, 30303, "test", time.Minute); err != nil {
+		t.Fatalf("failed to add mapping after rediscovery: %v", err)
+	}
+	if discovery != 2 {
+		t.Fatalf("wrong number of discoveries: have %d, want 2", discovery)
+	}
+}
+
+func TestGatewaysExternalIP(t *testing.T) {
+	stun := ExtIP{9, 9, 9, 9}
+	tests := []struct {
+		gateway net.IP
+		want    net.IP
+	}{
+		{gateway: net.IP{1, 2, 3, 4}, want: net.IP{1, 2, 3, 4}},
+		{gateway: net.IP{192, 168, 1, 2}, want: net.IP(stun)},
+		{gateway: net.IP{100, 64, 1, 2}, want: net.IP(stun)}, // Carrier-grade NAT
+		{gateway: nil, want: net.IP(stun)},
+	}
+	for _, test := range tests {
+		t.Run(fmt.Sprint(test.gateway), func(t *testing.T) {
+			gw := newFakeGateway("gw", test.gateway)
+			g := newGateways(func() []Interface { return []Interface{gw} }, stun)
+			ip, err := g.ExternalIP()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !ip.Equal(test.want) {
+				t.Errorf("wrong external IP: have %v, want %v", ip, test.want)
+			}
+		})
+	}
+}
+
+func TestMapAlternativePort(t *testing.T) {
+	gw := newFakeGateway("gw", nil)
+	gw.port = 40404
+
+	c := make(chan struct{})
+	close(c)
+	Map(gw, c, "TCP", 30303, 30303, "test")
+	if len(gw.mappings) != 0 {
+		t.Fatalf("mapping on alternative port not deleted: %v", gw.mappings)
+	}
+}
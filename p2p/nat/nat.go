@@ -55,7 +55,7 @@ type Interface interface {
 //
 //	"" or "none"         return nil
 //	"extip:77.12.33.4"   will assume the local machine is reachable on the given IP
-//	"any"                uses the first auto-detected mechanism
+//	"any"                uses all auto-detected gateways, falling back to STUN for the external IP
 //	"upnp"               uses the Universal Plug and Play protocol
 //	"pmp"                uses NAT-PMP with an auto-detected gateway address
 //	"pmp:192.168.0.1"    uses NAT-PMP with the given gateway address
@@ -102,21 +102,41 @@ const (
 // Map adds a port mapping on m and keeps it alive until c is closed.
 // This function is typically invoked in its own goroutine.
 //
-// Note that Map does not handle the situation where the NAT interface assigns a different
-// external port than the requested one.
+// If the NAT interface assigns a different external port than the requested one,
+// the assigned port is kept for refreshing the mapping.
 func Map(m Interface, c <-chan struct{}, protocol string, extport, intport int, name string) {
-	log := log.New("proto", protocol, "extport", extport, "intport", intport, "interface", m)
-	refresh := time.NewTimer(DefaultMapTimeout)
+	var (
+		log     = log.New("proto", protocol, "extport", extport, "intport", intport, "interface", m)
+		refresh = time.NewTimer(DefaultMapTimeout)
+		mapped  int // external port assigned by the NAT interface
+	)
+	add := func() {
+		port := extport
+		if mapped != 0 {
+			port = mapped
+		}
+		p, err := m.AddMapping(protocol, port, intport, name, DefaultMapTimeout)
+		if err != nil {
+			log.Debug("Couldn't add port mapping", "err", err)
+			return
+		}
+		if int(p) != mapped {
+			mapped = int(p)
+			if mapped != extport {
+				log.Info("Mapped alternative network port", "mapped", mapped)
+			} else {
+				log.Info("Mapped network port")
+			}
+		}
+	}
 	defer func() {
 		refresh.Stop()
-		log.Debug("Deleting port mapping")
-		m.DeleteMapping(protocol, extport, intport)
+		if mapped != 0 {
+			log.Debug("Deleting port mapping")
+			m.DeleteMapping(protocol, mapped, intport)
+		}
 	}()
-	if _, err := m.AddMapping(protocol, extport, intport, name, DefaultMapTimeout); err != nil {
-		log.Debug("Couldn't add port mapping", "err", err)
-	} else {
-		log.Info("Mapped network port")
-	}
+	add()
 	for {
 		select {
 		case _, ok := <-c:
@@ -125,9 +145,7 @@ func Map(m Interface, c <-chan struct{}, protocol string, extport, intport int,
 			}
 		case <-refresh.C:
 			log.Trace("Refreshing port mapping")
-			if _, err := m.AddMapping(protocol, extport, intport, name, DefaultMapTimeout); err != nil {
-				log.Debug("Couldn't add port mapping", "err", err)
-			}
+			add()
 			refresh.Reset(DefaultMapTimeout)
 		}
 	}
@@ -150,20 +168,15 @@ func (ExtIP) AddMapping(protocol string, extport, intport int, name string, life
 func (ExtIP) DeleteMapping(string, int, int) error { return nil }
 
 // Any returns a port mapper that tries to discover any supported
-// mechanism on the local network.
+// mechanism on the local network. Mappings are added on one of the
+// discovered gateways, failing over to the others. If no gateway reports
+// a public address, the external IP is discovered via STUN.
 func Any() Interface {
 	// TODO: attempt to discover whether the local machine has an
 	// Internet-class address. Return ExtIP in this case.
 	return startautodisc("any", func() Interface {
-		found := make(chan Interface, 2)
-		go func() { found <- discoverUPnP() }()
-		go func() { found <- discoverPMP() }()
-		for i := 0; i < cap(found); i++ {
-			if c := <-found; c != nil {
-				return c
-			}
-		}
-		return nil
+		stun, _ := newSTUN("")
+		return newGateways(discoverGateways, stun)
 	})
 }
 
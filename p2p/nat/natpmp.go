@@ -78,6 +78,15 @@ func (n *pmp) MarshalText() ([]byte, error) {
 }
 
 func discoverPMP() Interface {
+	if gws := discoverPMPGateways(); len(gws) > 0 {
+		return gws[0]
+	}
+	return nil
+}
+
+// discoverPMPGateways returns the NAT-PMP gateways of the local network,
+// ordered by response time.
+func discoverPMPGateways() []Interface {
 	// run external address lookups on all potential gateways
 	gws := potentialGateways()
 	found := make(chan *pmp, len(gws))
@@ -92,22 +101,23 @@ func discoverPMP() Interface {
 			}
 		}()
 	}
-	// return the one that responds first.
 	// discovery needs to be quick, so we stop caring about
 	// any responses after a very short timeout.
 	timeout := time.NewTimer(1 * time.Second)
 	defer timeout.Stop()
+
+	var responded []Interface
 	for range gws {
 		select {
 		case c := <-found:
 			if c != nil {
-				return c
+				responded = append(responded, c)
 			}
 		case <-timeout.C:
-			return nil
+			return responded
 		}
 	}
-	return nil
+	return responded
 }
 
 // TODO: improve this. We currently assume that (on most networks)
@@ -0,0 +1,262 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package p2p
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/ethereum/go-ethereum/p2p/enode"
+)
+
+// PeerGroup is a named set of nodes the server maintains connections to, such
+// as the sequencer or the archive nodes of an operator. Members of a group are
+// dialed like static nodes.
+type PeerGroup struct {
+	Name  string        `json:"name"`
+	Nodes []*enode.Node `json:"nodes"`
+
+	// Priority orders the dialing of groups. Members of groups with a higher
+	// priority are dialed before those of lower ones and before static nodes.
+	Priority int `json:"priority,omitempty" toml:",omitempty"`
+
+	// MinPeers is the number of connected members below which the group is
+	// reported as unhealthy.
+	MinPeers int `json:"minPeers,omitempty" toml:",omitempty"`
+
+	// MaxPeers limits the number of connected members, zero means unlimited.
+	// Further members are neither dialed nor accepted.
+	MaxPeers int `json:"maxPeers,omitempty" toml:",omitempty"`
+}
+
+// PeerGroupInfo reports the state of a peer group.
+type PeerGroupInfo struct {
+	Name      string   `json:"name"`
+	Priority  int      `json:"priority"`
+	MinPeers  int      `json:"minPeers"`
+	MaxPeers  int      `json:"maxPeers"`
+	Members   int      `json:"members"`
+	Connected []string `json:"connected"` // IDs of the connected members
+	Healthy   bool     `json:"healthy"`
+}
+
+// peerGroup is a registered peer group along with its connection state.
+type peerGroup struct {
+	config    PeerGroup
+	connected map[enode.ID]struct{}
+	gauge     *metrics.Gauge
+}
+
+func (g *peerGroup) healthy() bool {
+	return len(g.connected) >= g.config.MinPeers
+}
+
+func (g *peerGroup) full() bool {
+	return g.config.MaxPeers > 0 && len(g.connected) >= g.config.MaxPeers
+}
+
+// peerGroups tracks the peer groups of the server. It is accessed by the server
+// loop, the dialer and the API, hence the lock.
+type peerGroups struct {
+	mu      sync.Mutex
+	groups  map[string]*peerGroup
+	members map[enode.ID]*peerGroup // a node is member of at most one group
+}
+
+// add registers a group, given the set of currently connected peers. It returns
+// the members of a replaced group which are not part of the new one.
+func (pg *peerGroups) add(config PeerGroup, connected func(enode.ID) bool) ([]*enode.Node, error) {
+	if config.Name == "" {
+		return nil, errors.New("peer group name missing")
+	}
+	if config.MinPeers < 0 || config.MaxPeers < 0 {
+		return nil, fmt.Errorf("peer group %s: negative peer limit", config.Name)
+	}
+	pg.mu.Lock()
+	defer pg.mu.Unlock()
+
+	if pg.groups == nil {
+		pg.groups = make(map[string]*peerGroup)
+		pg.members = make(map[enode.ID]*peerGroup)
+	}
+	for _, n := range config.Nodes {
+		if other := pg.members[n.ID()]; other != nil && other.config.Name != config.Name {
+			return nil, fmt.Errorf("peer group %s: node %v is already member of group %s", config.Name, n.ID(), other.config.Name)
+		}
+	}
+	var dropped []*enode.Node
+	if old := pg.groups[config.Name]; old != nil {
+		for _, n := range old.config.Nodes {
+			delete(pg.members, n.ID())
+			if !slices.ContainsFunc(config.Nodes, func(m *enode.Node) bool { return m.ID() == n.ID() }) {
+				dropped = append(dropped, n)
+			}
+		}
+	}
+	group := &peerGroup{
+		config:    config,
+		connected: make(map[enode.ID]struct{}),
+		gauge:     metrics.GetOrRegisterGauge("p2p/groups/"+config.Name+"/peers", nil),
+	}
+	for _, n := range config.Nodes {
+		pg.members[n.ID()] = group
+		if connected(n.ID()) {
+			group.connected[n.ID()] = struct{}{}
+		}
+	}
+	group.gauge.Update(int64(len(group.connected)))
+	pg.groups[config.Name] = group
+	return dropped, nil
+}
+
+// remove unregisters a group, returning its members.
+func (pg *peerGroups) remove(name string) ([]*enode.Node, error) {
+	pg.mu.Lock()
+	defer pg.mu.Unlock()
+
+	group := pg.groups[name]
+	if group == nil {
+		return nil, fmt.Errorf("unknown peer group %q", name)
+	}
+	for _, n := range group.config.Nodes {
+		delete(pg.members, n.ID())
+	}
+	delete(pg.groups, name)
+	metrics.Unregister("p2p/groups/" + name + "/peers")
+	return group.config.Nodes, nil
+}
+
+// peerAdded records a connection to a node.
+func (pg *peerGroups) peerAdded(id enode.ID) {
+	pg.mu.Lock()
+	defer pg.mu.Unlock()
+
+	if group := pg.members[id]; group != nil {
+		group.connected[id] = struct{}{}
+		group.gauge.Update(int64(len(group.connected)))
+	}
+}
+
+// peerRemoved records a disconnect from a node.
+func (pg *peerGroups) peerRemoved(id enode.ID) {
+	pg.mu.Lock()
+	defer pg.mu.Unlock()
+
+	group := pg.members[id]
+	if group == nil {
+		return
+	}
+	wasHealthy := group.healthy()
+	delete(group.connected, id)
+	group.gauge.Update(int64(len(group.connected)))
+	if wasHealthy && !group.healthy() {
+		log.Warn("Peer group below minimum peer count", "group", group.config.Name, "peers", len(group.connected), "min", group.config.MinPeers)
+	}
+}
+
+// full reports whether the node is member of a group which reached its limit.
+func (pg *peerGroups) full(id enode.ID) bool {
+	pg.mu.Lock()
+	defer pg.mu.Unlock()
+
+	group := pg.members[id]
+	return group != nil && group.full()
+}
+
+// dialPriority returns the dial priority of a static node and whether it
+// should be dialed at all.
+func (pg *peerGroups) dialPriority(id enode.ID) (int, bool) {
+	pg.mu.Lock()
+	defer pg.mu.Unlock()
+
+	group := pg.members[id]
+	if group == nil {
+		return 0, true
+	}
+	return group.config.Priority, !group.full()
+}
+
+// info returns the state of all groups, ordered by name.
+func (pg *peerGroups) info() []PeerGroupInfo {
+	pg.mu.Lock()
+	defer pg.mu.Unlock()
+
+	infos := make([]PeerGroupInfo, 0, len(pg.groups))
+	for _, group := range pg.groups {
+		info := PeerGroupInfo{
+			Name:      group.config.Name,
+			Priority:  group.config.Priority,
+			MinPeers:  group.config.MinPeers,
+			MaxPeers:  group.config.MaxPeers,
+			Members:   len(group.config.Nodes),
+			Connected: make([]string, 0, len(group.connected)),
+			Healthy:   group.healthy(),
+		}
+		for id := range group.connected {
+			info.Connected = append(info.Connected, id.String())
+		}
+		sort.Strings(info.Connected)
+		infos = append(infos, info)
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].Name < infos[j].Name })
+	return infos
+}
+
+// AddPeerGroup registers a peer group and starts dialing its members. A group
+// with the same name is replaced.
+func (srv *Server) AddPeerGroup(group PeerGroup) error {
+	var dropped []*enode.Node
+	var err error
+	srv.doPeerOp(func(peers map[enode.ID]*Peer) {
+		dropped, err = srv.groups.add(group, func(id enode.ID) bool { return peers[id] != nil })
+	})
+	if err != nil {
+		return err
+	}
+	for _, n := range dropped {
+		srv.dialsched.removeStatic(n)
+	}
+	for _, n := range group.Nodes {
+		srv.dialsched.addStatic(n)
+	}
+	srv.log.Info("Added peer group", "name", group.Name, "members", len(group.Nodes), "priority", group.Priority)
+	return nil
+}
+
+// RemovePeerGroup unregisters a peer group. Its members are no longer dialed,
+// but existing connections are kept.
+func (srv *Server) RemovePeerGroup(name string) error {
+	nodes, err := srv.groups.remove(name)
+	if err != nil {
+		return err
+	}
+	for _, n := range nodes {
+		srv.dialsched.removeStatic(n)
+	}
+	srv.log.Info("Removed peer group", "name", name)
+	return nil
+}
+
+// PeerGroupsInfo returns the state of the registered peer groups.
+func (srv *Server) PeerGroupsInfo() []PeerGroupInfo {
+	return srv.groups.info()
+}
@@ -0,0 +1,77 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package p2p
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/p2p/enode"
+)
+
+func TestPeerGroups(t *testing.T) {
+	var (
+		pg    peerGroups
+		nodes = []*enode.Node{
+			newNode(uintID(0x01), "127.0.0.1:30303"),
+			newNode(uintID(0x02), "127.0.0.2:30303"),
+			newNode(uintID(0x03), "127.0.0.3:30303"),
+		}
+		none = func(enode.ID) bool { return false }
+	)
+	group := PeerGroup{Name: "seq", Nodes: nodes[:2], Priority: 5, MinPeers: 1, MaxPeers: 1}
+	if _, err := pg.add(group, none); err != nil {
+		t.Fatal(err)
+	}
+	defer pg.remove("seq")
+	if _, err := pg.add(PeerGroup{Name: "other", Nodes: nodes[1:]}, none); err == nil {
+		t.Fatal("expected error for node in two groups")
+	}
+	if prio, ok := pg.dialPriority(nodes[0].ID()); prio != 5 || !ok {
+		t.Fatalf("wrong dial priority: %d %v", prio, ok)
+	}
+	if info := pg.info(); len(info) != 1 || info[0].Healthy {
+		t.Fatalf("group should be unhealthy: %+v", info)
+	}
+
+	// Connecting one member fills the group.
+	pg.peerAdded(nodes[0].ID())
+	if !pg.full(nodes[1].ID()) {
+		t.Fatal("group should be full")
+	}
+	if _, ok := pg.dialPriority(nodes[1].ID()); ok {
+		t.Fatal("member of full group should not be dialed")
+	}
+	if info := pg.info(); !info[0].Healthy || len(info[0].Connected) != 1 {
+		t.Fatalf("wrong group state: %+v", info)
+	}
+	pg.peerRemoved(nodes[0].ID())
+	if pg.full(nodes[1].ID()) {
+		t.Fatal("group should not be full")
+	}
+
+	// Replacing the group returns the members which were dropped.
+	dropped, err := pg.add(PeerGroup{Name: "seq", Nodes: nodes[1:]}, none)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(dropped) != 1 || dropped[0].ID() != nodes[0].ID() {
+		t.Fatalf("wrong dropped nodes: %v", dropped)
+	}
+	if prio, ok := pg.dialPriority(nodes[0].ID()); prio != 0 || !ok {
+		t.Fatalf("wrong dial priority of non-member: %d %v", prio, ok)
+	}
+}
@@ -84,6 +84,8 @@ type Server struct {
 	lock    sync.Mutex // protects running
 	running bool
 
+	peerLimit atomic.Pointer[int] // peer limit changed at runtime, overriding MaxPeers
+
 	listener     net.Listener
 	ourHandshake *protoHandshake
 	loopWG       sync.WaitGroup // loop, listenLoop
@@ -96,6 +98,7 @@ type Server struct {
 	discv5    *discover.UDPv5
 	discmix   *enode.FairMix
 	dialsched *dialScheduler
+	groups    peerGroups
 
 	// This is read by the NAT port mapping loop.
 	portMappingRegister chan *portMapping
@@ -532,6 +535,7 @@ func (srv *Server) setupDialScheduler() {
 		netRestrict:    srv.NetRestrict,
 		dialer:         srv.Dialer,
 		clock:          srv.clock,
+		staticPriority: srv.groups.dialPriority,
 	}
 	if srv.discv4 != nil {
 		config.resolver = srv.discv4
@@ -543,20 +547,59 @@ func (srv *Server) setupDialScheduler() {
 	for _, n := range srv.StaticNodes {
 		srv.dialsched.addStatic(n)
 	}
+	for _, group := range srv.PeerGroups {
+		if _, err := srv.groups.add(group, func(enode.ID) bool { return false }); err != nil {
+			srv.log.Error("Invalid peer group", "err", err)
+			continue
+		}
+		for _, n := range group.Nodes {
+			srv.dialsched.addStatic(n)
+		}
+	}
+}
+
+// PeerLimit returns the maximum number of connected peers in effect, which is
+// MaxPeers unless changed at runtime.
+func (srv *Server) PeerLimit() int {
+	if limit := srv.peerLimit.Load(); limit != nil {
+		return *limit
+	}
+	return srv.MaxPeers
+}
+
+// SetPeerLimit changes the maximum number of connected peers of the running
+// server. Lowering the limit doesn't disconnect any peers, but no new peers are
+// accepted until the peer count drops below it.
+func (srv *Server) SetPeerLimit(limit int) error {
+	if limit < 0 {
+		return errors.New("negative peer limit")
+	}
+	srv.lock.Lock()
+	running := srv.running
+	srv.lock.Unlock()
+	if !running {
+		return errServerStopped
+	}
+	srv.peerLimit.Store(&limit)
+	srv.dialsched.setMaxDialPeers(srv.MaxDialedConns())
+
+	srv.log.Info("Changed peer limit", "maxpeers", limit, "dialed", srv.MaxDialedConns(), "inbound", srv.MaxInboundConns())
+	return nil
 }
 
 func (srv *Server) MaxInboundConns() int {
-	return srv.MaxPeers - srv.MaxDialedConns()
+	return srv.PeerLimit() - srv.MaxDialedConns()
 }
 
 func (srv *Server) MaxDialedConns() (limit int) {
-	if srv.NoDial || srv.MaxPeers == 0 {
+	maxPeers := srv.PeerLimit()
+	if srv.NoDial || maxPeers == 0 {
 		return 0
 	}
 	if srv.DialRatio == 0 {
-		limit = srv.MaxPeers / defaultDialRatio
+		limit = maxPeers / defaultDialRatio
 	} else {
-		limit = srv.MaxPeers / srv.DialRatio
+		limit = maxPeers / srv.DialRatio
 	}
 	if limit == 0 {
 		limit = 1
@@ -699,6 +742,7 @@ running:
 				peers[c.node.ID()] = p
 				srv.log.Debug("Adding p2p peer", "peercount", len(peers), "id", p.ID(), "conn", c.flags, "addr", p.RemoteAddr(), "name", p.Name())
 				srv.dialsched.peerAdded(c)
+				srv.groups.peerAdded(c.node.ID())
 				if p.Inbound() {
 					inboundCount++
 					serveSuccessMeter.Mark(1)
@@ -717,6 +761,7 @@ running:
 			delete(peers, pd.ID())
 			srv.log.Debug("Removing p2p peer", "peercount", len(peers), "id", pd.ID(), "duration", d, "req", pd.requested, "err", pd.err)
 			srv.dialsched.peerRemoved(pd.rw)
+			srv.groups.peerRemoved(pd.ID())
 			if pd.Inbound() {
 				inboundCount--
 				activeInboundPeerGauge.Dec(1)
@@ -752,12 +797,14 @@ running:
 
 func (srv *Server) postHandshakeChecks(peers map[enode.ID]*Peer, inboundCount int, c *conn) error {
 	switch {
-	case !c.is(trustedConn) && len(peers) >= srv.MaxPeers:
+	case !c.is(trustedConn) && len(peers) >= srv.PeerLimit():
 		return DiscTooManyPeers
 	case !c.is(trustedConn) && c.is(inboundConn) && inboundCount >= srv.MaxInboundConns():
 		return DiscTooManyPeers
 	case peers[c.node.ID()] != nil:
 		return DiscAlreadyConnected
+	case srv.groups.full(c.node.ID()):
+		return DiscTooManyPeers
 	case c.node.ID() == srv.localnode.ID():
 		return DiscSelf
 	default:
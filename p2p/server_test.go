@@ -375,6 +375,70 @@ func TestServerPeerLimits(t *testing.T) {
 	conn.Close()
 }
 
+func TestServerSetPeerLimit(t *testing.T) {
+	srvkey := newkey()
+	clientkey := newkey()
+	clientnode := enode.NewV4(&clientkey.PublicKey, nil, 0, 0)
+
+	var tp = &setupTransport{
+		pubkey: &clientkey.PublicKey,
+		phs: protoHandshake{
+			ID: crypto.FromECDSAPub(&clientkey.PublicKey)[1:],
+		},
+	}
+	srv := &Server{
+		Config: Config{
+			PrivateKey:  srvkey,
+			MaxPeers:    0,
+			NoDial:      true,
+			NoDiscovery: true,
+			Protocols:   []Protocol{discard},
+			Logger:      testlog.Logger(t, log.LvlTrace),
+		},
+		newTransport: func(fd net.Conn, dialDest *ecdsa.PublicKey) transport { return tp },
+	}
+	if err := srv.SetPeerLimit(1); err != errServerStopped {
+		t.Fatalf("wrong error for stopped server: %v", err)
+	}
+	if err := srv.Start(); err != nil {
+		t.Fatalf("couldn't start server: %v", err)
+	}
+	defer srv.Stop()
+
+	setup := func() error {
+		conn, _ := net.Pipe()
+		defer conn.Close()
+		srv.SetupConn(conn, inboundConn, clientnode)
+		return tp.closeErr
+	}
+	if err := setup(); err != DiscTooManyPeers {
+		t.Fatalf("unexpected close error: %q", err)
+	}
+	// Raising the limit lets the peer through the limit check.
+	if err := srv.SetPeerLimit(1); err != nil {
+		t.Fatal(err)
+	}
+	if limit := srv.PeerLimit(); limit != 1 {
+		t.Fatalf("wrong peer limit: %d", limit)
+	}
+	if inbound := srv.MaxInboundConns(); inbound != 1 {
+		t.Fatalf("wrong inbound limit: %d", inbound)
+	}
+	if err := setup(); err != DiscUselessPeer {
+		t.Fatalf("unexpected close error: %q", err)
+	}
+	// Lowering it again rejects the peer.
+	if err := srv.SetPeerLimit(0); err != nil {
+		t.Fatal(err)
+	}
+	if err := setup(); err != DiscTooManyPeers {
+		t.Fatalf("unexpected close error: %q", err)
+	}
+	if err := srv.SetPeerLimit(-1); err == nil {
+		t.Fatal("negative peer limit accepted")
+	}
+}
+
 func TestServerSetupConn(t *testing.T) {
 	var (
 		clientkey, srvkey = newkey(), newkey()
@@ -19,6 +19,7 @@ package params
 import (
 	"errors"
 	"fmt"
+	"maps"
 	"math"
 	"math/big"
 
@@ -468,6 +469,17 @@ type ChainConfig struct {
 	AmsterdamTime *uint64 `json:"amsterdamTime,omitempty"` // Amsterdam switch time (nil = no fork, 0 = already on amsterdam)
 	VerkleTime    *uint64 `json:"verkleTime,omitempty"`    // Verkle switch time (nil = no fork, 0 = already on verkle)
 
+	// WarmSlotsTime is the switch time of the experimental rules treating the
+	// storage slots accessed by the parent block as warm in each transaction,
+	// meant for evaluating cross-block access lists on devnets.
+	WarmSlotsTime *uint64 `json:"warmSlotsTime,omitempty"` // Warm slots switch time (nil = no fork, 0 = already on)
+
+	// BlockHashHistoryTime is the switch time of the EIP-2935 block hash
+	// history ahead of Prague. The history contract is installed at the first
+	// block of the fork, or in the genesis state if the fork is active there,
+	// so the hashes of the last 8191 blocks can be read with eth_call.
+	BlockHashHistoryTime *uint64 `json:"blockHashHistoryTime,omitempty"` // Block hash history switch time (nil = no fork, 0 = already on)
+
 	// TerminalTotalDifficulty is the amount of total difficulty reached by
 	// the network that triggers the consensus upgrade.
 	TerminalTotalDifficulty *big.Int `json:"terminalTotalDifficulty,omitempty"`
@@ -487,10 +499,17 @@ type ChainConfig struct {
 	// those cases.
 	EnableVerkleAtGenesis bool `json:"enableVerkleAtGenesis,omitempty"`
 
+	// Engine names a consensus engine registered through consensus.RegisterEngine.
+	// If set, it takes precedence over the built-in engines below.
+	Engine string `json:"engine,omitempty"`
+
 	// Various consensus engines
 	Ethash             *EthashConfig       `json:"ethash,omitempty"`
 	Clique             *CliqueConfig       `json:"clique,omitempty"`
 	BlobScheduleConfig *BlobScheduleConfig `json:"blobSchedule,omitempty"`
+
+	// GasSchedule overrides the gas costs of the protocol for custom chains.
+	GasSchedule *GasScheduleConfig `json:"gasSchedule,omitempty"`
 }
 
 // EthashConfig is the consensus engine configs for proof-of-work based sealing.
@@ -598,6 +617,12 @@ func (c *ChainConfig) String() string {
 	if c.VerkleTime != nil {
 		result += fmt.Sprintf(", VerkleTime: %v", *c.VerkleTime)
 	}
+	if c.WarmSlotsTime != nil {
+		result += fmt.Sprintf(", WarmSlotsTime: %v", *c.WarmSlotsTime)
+	}
+	if c.BlockHashHistoryTime != nil {
+		result += fmt.Sprintf(", BlockHashHistoryTime: %v", *c.BlockHashHistoryTime)
+	}
 	result += "}"
 	return result
 }
@@ -613,6 +638,8 @@ func (c *ChainConfig) Description() string {
 	}
 	banner += fmt.Sprintf("Chain ID:  %v (%s)\n", c.ChainID, network)
 	switch {
+	case c.Engine != "":
+		banner += fmt.Sprintf("Consensus: %s (registered engine)\n", c.Engine)
 	case c.Ethash != nil:
 		banner += "Consensus: Beacon (proof-of-stake), merged from Ethash (proof-of-work)\n"
 	case c.Clique != nil:
@@ -693,6 +720,12 @@ func (c *ChainConfig) Description() string {
 	if c.VerkleTime != nil {
 		banner += fmt.Sprintf(" - Verkle:                      @%-10v blob: (%s)\n", *c.VerkleTime, c.BlobScheduleConfig.Verkle)
 	}
+	if c.WarmSlotsTime != nil {
+		banner += fmt.Sprintf(" - Warm slots (experimental):   @%-10v\n", *c.WarmSlotsTime)
+	}
+	if c.BlockHashHistoryTime != nil {
+		banner += fmt.Sprintf(" - Block hash history:          @%-10v\n", *c.BlockHashHistoryTime)
+	}
 	banner += fmt.Sprintf("\nAll fork specifications can be found at https://ethereum.github.io/execution-specs/src/ethereum/forks/\n")
 	return banner
 }
@@ -726,6 +759,82 @@ type BlobScheduleConfig struct {
 	Amsterdam *BlobConfig `json:"amsterdam,omitempty"`
 }
 
+// GasScheduleConfig determines the gas cost overrides in effect per fork. The
+// schedule of the latest active fork defining one applies, forks without a
+// schedule inherit the one of their predecessor.
+type GasScheduleConfig struct {
+	London   *GasSchedule `json:"london,omitempty"`
+	Shanghai *GasSchedule `json:"shanghai,omitempty"`
+	Cancun   *GasSchedule `json:"cancun,omitempty"`
+	Prague   *GasSchedule `json:"prague,omitempty"`
+	Osaka    *GasSchedule `json:"osaka,omitempty"`
+}
+
+// GasSchedule is a table of gas costs replacing the ones of the protocol.
+type GasSchedule struct {
+	// Opcodes maps opcode names, e.g. "SSTORE", to their constant gas cost. The
+	// dynamic part of the costs is not affected.
+	Opcodes map[string]uint64 `json:"opcodes,omitempty"`
+
+	// Precompiles maps precompile addresses to their gas cost, which replaces
+	// the input dependent cost.
+	Precompiles map[common.Address]uint64 `json:"precompiles,omitempty"`
+}
+
+// effective returns the gas schedules in effect during London, Shanghai, Cancun,
+// Prague and Osaka, forks without a schedule inheriting the previous one.
+func (gs *GasScheduleConfig) effective() [5]*GasSchedule {
+	var schedules [5]*GasSchedule
+	if gs == nil {
+		return schedules
+	}
+	var cur *GasSchedule
+	for i, schedule := range []*GasSchedule{gs.London, gs.Shanghai, gs.Cancun, gs.Prague, gs.Osaka} {
+		if schedule != nil {
+			cur = schedule
+		}
+		schedules[i] = cur
+	}
+	return schedules
+}
+
+// equal reports whether two gas schedules override the same costs, a nil
+// schedule overriding none.
+func (s *GasSchedule) equal(other *GasSchedule) bool {
+	var a, b GasSchedule
+	if s != nil {
+		a = *s
+	}
+	if other != nil {
+		b = *other
+	}
+	return maps.Equal(a.Opcodes, b.Opcodes) && maps.Equal(a.Precompiles, b.Precompiles)
+}
+
+// ActiveGasSchedule returns the gas schedule in effect under the given rules, or
+// nil if the protocol gas costs apply.
+func (c *ChainConfig) ActiveGasSchedule(rules Rules) *GasSchedule {
+	gs := c.GasSchedule
+	if gs == nil {
+		return nil
+	}
+	for _, cur := range []struct {
+		active   bool
+		schedule *GasSchedule
+	}{
+		{rules.IsOsaka, gs.Osaka},
+		{rules.IsPrague, gs.Prague},
+		{rules.IsCancun, gs.Cancun},
+		{rules.IsShanghai, gs.Shanghai},
+		{rules.IsLondon, gs.London},
+	} {
+		if cur.active && cur.schedule != nil {
+			return cur.schedule
+		}
+	}
+	return nil
+}
+
 // IsHomestead returns whether num is either equal to the homestead block or greater.
 func (c *ChainConfig) IsHomestead(num *big.Int) bool {
 	return isBlockForked(c.HomesteadBlock, num)
@@ -871,6 +980,25 @@ func (c *ChainConfig) IsVerkle(num *big.Int, time uint64) bool {
 	return c.IsLondon(num) && isTimestampForked(c.VerkleTime, time)
 }
 
+// IsWarmSlots returns whether time is either equal to the experimental warm
+// slots fork time or greater.
+func (c *ChainConfig) IsWarmSlots(num *big.Int, time uint64) bool {
+	return c.IsBerlin(num) && isTimestampForked(c.WarmSlotsTime, time)
+}
+
+// IsBlockHashHistory returns whether the EIP-2935 block hash history is kept
+// at the given block, either from Prague on or from the block hash history
+// switch time if it comes earlier.
+func (c *ChainConfig) IsBlockHashHistory(num *big.Int, time uint64) bool {
+	return c.IsPrague(num, time) || c.IsVerkle(num, time) || c.IsBlockHashHistoryFork(num, time)
+}
+
+// IsBlockHashHistoryFork returns whether time is either equal to the block
+// hash history switch time or greater.
+func (c *ChainConfig) IsBlockHashHistoryFork(num *big.Int, time uint64) bool {
+	return c.IsLondon(num) && isTimestampForked(c.BlockHashHistoryTime, time)
+}
+
 // IsVerkleGenesis checks whether the verkle fork is activated at the genesis block.
 //
 // Verkle mode is considered enabled if the verkle fork time is configured,
@@ -1020,6 +1148,25 @@ func (c *ChainConfig) CheckConfigForkOrder() error {
 			}
 		}
 	}
+
+	// Check that gas schedules are only defined for scheduled forks.
+	if gs := c.GasSchedule; gs != nil {
+		for _, cur := range []struct {
+			name      string
+			scheduled bool
+			schedule  *GasSchedule
+		}{
+			{name: "london", scheduled: c.LondonBlock != nil, schedule: gs.London},
+			{name: "shanghai", scheduled: c.ShanghaiTime != nil, schedule: gs.Shanghai},
+			{name: "cancun", scheduled: c.CancunTime != nil, schedule: gs.Cancun},
+			{name: "prague", scheduled: c.PragueTime != nil, schedule: gs.Prague},
+			{name: "osaka", scheduled: c.OsakaTime != nil, schedule: gs.Osaka},
+		} {
+			if cur.schedule != nil && !cur.scheduled {
+				return fmt.Errorf("invalid chain configuration: entry for unscheduled fork %q in gasSchedule", cur.name)
+			}
+		}
+	}
 	return nil
 }
 
@@ -1125,6 +1272,32 @@ func (c *ChainConfig) checkCompatible(newcfg *ChainConfig, headNumber *big.Int,
 	if isForkTimestampIncompatible(c.AmsterdamTime, newcfg.AmsterdamTime, headTimestamp) {
 		return newTimestampCompatError("Amsterdam fork timestamp", c.AmsterdamTime, newcfg.AmsterdamTime)
 	}
+	if isForkTimestampIncompatible(c.WarmSlotsTime, newcfg.WarmSlotsTime, headTimestamp) {
+		return newTimestampCompatError("Warm slots fork timestamp", c.WarmSlotsTime, newcfg.WarmSlotsTime)
+	}
+	if isForkTimestampIncompatible(c.BlockHashHistoryTime, newcfg.BlockHashHistoryTime, headTimestamp) {
+		return newTimestampCompatError("Block hash history fork timestamp", c.BlockHashHistoryTime, newcfg.BlockHashHistoryTime)
+	}
+	// Changing the gas schedule in effect during an active fork changes the
+	// execution of the blocks already processed. Schedules are inherited by
+	// later forks, so the schedules in effect are compared.
+	stored, updated := c.GasSchedule.effective(), newcfg.GasSchedule.effective()
+	if (isBlockForked(c.LondonBlock, headNumber) || isBlockForked(newcfg.LondonBlock, headNumber)) && !stored[0].equal(updated[0]) {
+		return newBlockCompatError("London gas schedule", c.LondonBlock, newcfg.LondonBlock)
+	}
+	for i, fork := range []struct {
+		name            string
+		stored, updated *uint64
+	}{
+		{"Shanghai", c.ShanghaiTime, newcfg.ShanghaiTime},
+		{"Cancun", c.CancunTime, newcfg.CancunTime},
+		{"Prague", c.PragueTime, newcfg.PragueTime},
+		{"Osaka", c.OsakaTime, newcfg.OsakaTime},
+	} {
+		if (isTimestampForked(fork.stored, headTimestamp) || isTimestampForked(fork.updated, headTimestamp)) && !stored[i+1].equal(updated[i+1]) {
+			return newTimestampCompatError(fork.name+" gas schedule", fork.stored, fork.updated)
+		}
+	}
 	return nil
 }
 
@@ -1382,6 +1555,7 @@ type Rules struct {
 	IsBerlin, IsLondon                                      bool
 	IsMerge, IsShanghai, IsCancun, IsPrague, IsOsaka        bool
 	IsAmsterdam, IsVerkle                                   bool
+	IsWarmSlots                                             bool
 }
 
 // Rules ensures c's ChainID is not nil.
@@ -1414,5 +1588,6 @@ func (c *ChainConfig) Rules(num *big.Int, isMerge bool, timestamp uint64) Rules
 		IsAmsterdam:      isMerge && c.IsAmsterdam(num, timestamp),
 		IsVerkle:         isVerkle,
 		IsEIP4762:        isVerkle,
+		IsWarmSlots:      c.IsWarmSlots(num, timestamp) && !isVerkle,
 	}
 }
@@ -110,6 +110,30 @@ func TestCheckCompatible(t *testing.T) {
 				RewindToTime: 9,
 			},
 		},
+		{
+			stored:        &ChainConfig{ShanghaiTime: newUint64(10), CancunTime: newUint64(20)},
+			new:           &ChainConfig{ShanghaiTime: newUint64(10), CancunTime: newUint64(20), GasSchedule: &GasScheduleConfig{Cancun: &GasSchedule{Opcodes: map[string]uint64{"SLOAD": 100}}}},
+			headTimestamp: 15,
+			wantErr:       nil,
+		},
+		{
+			stored:        &ChainConfig{ShanghaiTime: newUint64(10), CancunTime: newUint64(20)},
+			new:           &ChainConfig{ShanghaiTime: newUint64(10), CancunTime: newUint64(20), GasSchedule: &GasScheduleConfig{Cancun: &GasSchedule{Opcodes: map[string]uint64{"SLOAD": 100}}}},
+			headTimestamp: 25,
+			wantErr: &ConfigCompatError{
+				What:         "Cancun gas schedule",
+				StoredTime:   newUint64(20),
+				NewTime:      newUint64(20),
+				RewindToTime: 19,
+			},
+		},
+		{
+			// Spelling out the inherited schedule doesn't change the schedule in effect.
+			stored:        &ChainConfig{ShanghaiTime: newUint64(10), CancunTime: newUint64(20), GasSchedule: &GasScheduleConfig{Shanghai: &GasSchedule{Opcodes: map[string]uint64{"SLOAD": 100}}}},
+			new:           &ChainConfig{ShanghaiTime: newUint64(10), CancunTime: newUint64(20), GasSchedule: &GasScheduleConfig{Shanghai: &GasSchedule{Opcodes: map[string]uint64{"SLOAD": 100}}, Cancun: &GasSchedule{Opcodes: map[string]uint64{"SLOAD": 100}}}},
+			headTimestamp: 25,
+			wantErr:       nil,
+		},
 	}
 
 	for _, test := range tests {
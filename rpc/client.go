@@ -79,6 +79,7 @@ type Client struct {
 	idgen    func() ID // for subscriptions
 	isHTTP   bool      // connection type: http, ws or ipc
 	services *serviceRegistry
+	multi    *multiClient // set for clients created by DialMulti
 
 	idCounter atomic.Uint32
 
@@ -88,6 +89,12 @@ type Client struct {
 	// config fields
 	batchItemLimit       int
 	batchResponseMaxSize int
+	drain                *drainState
+	dedup                *callDeduplicator
+	streamed             streamedMethods
+	filter               CallFilter
+	callLog              CallLogger
+	baseCtx              context.Context
 
 	// writeConn is used for writing to the connection on the caller's goroutine. It should
 	// only be accessed outside of dispatch, with the write lock held. The write lock is
@@ -117,9 +124,17 @@ type clientConn struct {
 
 func (c *Client) newClientConn(conn ServerCodec) *clientConn {
 	ctx := context.Background()
+	if c.baseCtx != nil {
+		ctx = c.baseCtx
+	}
 	ctx = context.WithValue(ctx, clientContextKey{}, c)
 	ctx = context.WithValue(ctx, peerInfoContextKey{}, conn.peerInfo())
 	handler := newHandler(ctx, conn, c.idgen, c.services, c.batchItemLimit, c.batchResponseMaxSize)
+	handler.drain = c.drain
+	handler.dedup = c.dedup
+	handler.streamed = c.streamed
+	handler.filter = c.filter
+	handler.callLog = c.callLog
 	return &clientConn{conn, handler}
 }
 
@@ -247,6 +262,12 @@ func initClient(conn ServerCodec, services *serviceRegistry, cfg *clientConfig)
 		idgen:                cfg.idgen,
 		batchItemLimit:       cfg.batchItemLimit,
 		batchResponseMaxSize: cfg.batchResponseLimit,
+		drain:                cfg.drain,
+		dedup:                cfg.dedup,
+		streamed:             cfg.streamed,
+		filter:               cfg.filter,
+		callLog:              cfg.callLog,
+		baseCtx:              cfg.baseCtx,
 		writeConn:            conn,
 		close:                make(chan struct{}),
 		closing:              make(chan struct{}),
@@ -276,6 +297,9 @@ func initClient(conn ServerCodec, services *serviceRegistry, cfg *clientConfig)
 // subscription an error is returned. Otherwise a new service is created and added to the
 // service collection this client provides to the server.
 func (c *Client) RegisterName(name string, receiver interface{}) error {
+	if c.multi != nil {
+		return errors.New("services can't be registered on multi-endpoint clients")
+	}
 	return c.services.registerName(name, receiver)
 }
 
@@ -296,6 +320,10 @@ func (c *Client) SupportedModules() (map[string]string, error) {
 
 // Close closes the client, aborting any in-flight requests.
 func (c *Client) Close() {
+	if c.multi != nil {
+		c.multi.close()
+		return
+	}
 	if c.isHTTP {
 		return
 	}
@@ -310,6 +338,10 @@ func (c *Client) Close() {
 // This method only works for clients using HTTP, it doesn't have
 // any effect for clients using another transport.
 func (c *Client) SetHeader(key, value string) {
+	if c.multi != nil {
+		c.multi.forEach(func(client *Client) { client.SetHeader(key, value) })
+		return
+	}
 	if !c.isHTTP {
 		return
 	}
@@ -338,6 +370,9 @@ func (c *Client) CallContext(ctx context.Context, result interface{}, method str
 	if result != nil && reflect.TypeOf(result).Kind() != reflect.Ptr {
 		return fmt.Errorf("call result parameter must be pointer or nil interface: %v", result)
 	}
+	if c.multi != nil {
+		return c.multi.call(ctx, result, method, args)
+	}
 	msg, err := c.newMessage(method, args...)
 	if err != nil {
 		return err
@@ -397,6 +432,9 @@ func (c *Client) BatchCall(b []BatchElem) error {
 //
 // Note that batch calls may not be executed atomically on the server side.
 func (c *Client) BatchCallContext(ctx context.Context, b []BatchElem) error {
+	if c.multi != nil {
+		return c.multi.batchCall(ctx, b)
+	}
 	var (
 		msgs = make([]*jsonrpcMessage, len(b))
 		byID = make(map[string]int, len(b))
@@ -468,6 +506,9 @@ func (c *Client) BatchCallContext(ctx context.Context, b []BatchElem) error {
 
 // Notify sends a notification, i.e. a method call that doesn't expect a response.
 func (c *Client) Notify(ctx context.Context, method string, args ...interface{}) error {
+	if c.multi != nil {
+		return c.multi.notify(ctx, method, args)
+	}
 	op := new(requestOp)
 	msg, err := c.newMessage(method, args...)
 	if err != nil {
@@ -507,6 +548,9 @@ func (c *Client) Subscribe(ctx context.Context, namespace string, channel interf
 	if chanVal.IsNil() {
 		panic("channel given to Subscribe must not be nil")
 	}
+	if c.multi != nil {
+		return c.multi.subscribe(ctx, namespace, channel, args)
+	}
 	if c.isHTTP {
 		return nil, ErrNotificationsUnsupported
 	}
@@ -536,6 +580,9 @@ func (c *Client) Subscribe(ctx context.Context, namespace string, channel interf
 // transport. When this returns false, Subscribe and related methods will return
 // ErrNotificationsUnsupported.
 func (c *Client) SupportsSubscriptions() bool {
+	if c.multi != nil {
+		return c.multi.supportsSubscriptions()
+	}
 	return !c.isHTTP
 }
 
@@ -17,7 +17,9 @@
 package rpc
 
 import (
+	"context"
 	"net/http"
+	"time"
 
 	"github.com/gorilla/websocket"
 )
@@ -41,6 +43,18 @@ type clientConfig struct {
 	idgen              func() ID
 	batchItemLimit     int
 	batchResponseLimit int
+	drain              *drainState       // set for connections served by a Server
+	dedup              *callDeduplicator // set for connections served by a Server
+	streamed           streamedMethods   // set for connections served by a Server
+	filter             CallFilter        // set for connections served by a Server
+	callLog            CallLogger        // set for connections served by a Server
+	baseCtx            context.Context   // parent context of calls, set for connections served by a Server
+
+	// Multi-endpoint options
+	healthInterval time.Duration
+	healthMethod   string
+	hedgeDelay     time.Duration
+	hedgedMethods  []string
 }
 
 func (cfg *clientConfig) initHeaders() {
@@ -142,3 +156,26 @@ func WithBatchResponseSizeLimit(sizeLimit int) ClientOption {
 		cfg.batchResponseLimit = sizeLimit
 	})
 }
+
+// WithHealthCheck configures the health checks of clients created by DialMulti. Every
+// interval, each endpoint is called with the given method, which should be cheap and
+// take no arguments. Endpoints are considered unhealthy while the call fails.
+//
+// By default, endpoints are checked every 5 seconds using web3_clientVersion.
+func WithHealthCheck(interval time.Duration, method string) ClientOption {
+	return optionFunc(func(cfg *clientConfig) {
+		cfg.healthInterval = interval
+		cfg.healthMethod = method
+	})
+}
+
+// WithHedging enables hedged requests for the given methods on clients created by
+// DialMulti. If an endpoint hasn't responded to a call of one of these methods within
+// the delay, the call is sent to the next endpoint as well, and the first response is
+// used. Only methods without side effects should be hedged.
+func WithHedging(delay time.Duration, methods ...string) ClientOption {
+	return optionFunc(func(cfg *clientConfig) {
+		cfg.hedgeDelay = delay
+		cfg.hedgedMethods = methods
+	})
+}
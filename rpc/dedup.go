@@ -0,0 +1,127 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"sync"
+)
+
+// callDeduplicator shares the execution of identical concurrent calls to expensive
+// read-only methods, so that a herd of retrying clients only executes them once.
+type callDeduplicator struct {
+	methods map[string]struct{}
+
+	mu    sync.Mutex
+	calls map[string]*sharedCall
+}
+
+// sharedCall is an in-flight call which may be waited on by multiple callers.
+type sharedCall struct {
+	done    chan struct{} // closed when the call has finished
+	val     interface{}
+	err     error
+	waiters int                // number of callers waiting for the result
+	cancel  context.CancelFunc // aborts the call once all waiters have left
+}
+
+func newCallDeduplicator(methods []string) *callDeduplicator {
+	if len(methods) == 0 {
+		return nil
+	}
+	d := &callDeduplicator{
+		methods: make(map[string]struct{}, len(methods)),
+		calls:   make(map[string]*sharedCall),
+	}
+	for _, method := range methods {
+		d.methods[method] = struct{}{}
+	}
+	return d
+}
+
+// enabled reports whether calls of the given method are deduplicated.
+func (d *callDeduplicator) enabled(method string) bool {
+	if d == nil {
+		return false
+	}
+	_, ok := d.methods[method]
+	return ok
+}
+
+// call executes fn, unless an identical call is already in progress, in which case
+// it waits for the result of that call and reports it as shared. Calls are identical
+// if their method and parameters match, regardless of the JSON formatting of the
+// parameters.
+//
+// The shared execution runs on a context detached from the caller which started
+// it, so a leaving caller does not abort the call for the others. The context is
+// cancelled once every waiting caller has gone.
+func (d *callDeduplicator) call(ctx context.Context, msg *jsonrpcMessage, fn func(context.Context) (interface{}, error)) (val interface{}, shared bool, err error) {
+	var buf bytes.Buffer
+	buf.WriteString(msg.Method)
+	buf.WriteByte(0)
+	if err := json.Compact(&buf, msg.Params); err != nil {
+		buf.Write(msg.Params)
+	}
+	key := buf.String()
+
+	d.mu.Lock()
+	c, shared := d.calls[key]
+	if shared {
+		rpcDedupMeter.Mark(1)
+	} else {
+		callCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		c = &sharedCall{done: make(chan struct{}), cancel: cancel}
+		d.calls[key] = c
+		go func() {
+			c.val, c.err = fn(callCtx)
+			d.mu.Lock()
+			d.forget(key, c)
+			d.mu.Unlock()
+			cancel()
+			close(c.done)
+		}()
+	}
+	c.waiters++
+	d.mu.Unlock()
+
+	select {
+	case <-c.done:
+		return c.val, shared, c.err
+	case <-ctx.Done():
+		d.mu.Lock()
+		c.waiters--
+		if c.waiters == 0 {
+			// Nobody is interested in the result anymore. Abort the call and make
+			// sure that new callers don't join the aborted execution.
+			c.cancel()
+			d.forget(key, c)
+		}
+		d.mu.Unlock()
+		return nil, shared, ctx.Err()
+	}
+}
+
+// forget removes the given call from the in-flight set, unless it has been
+// replaced by a newer execution already. The lock must be held.
+func (d *callDeduplicator) forget(key string, c *sharedCall) {
+	if d.calls[key] == c {
+		delete(d.calls, key)
+	}
+}
@@ -25,6 +25,13 @@ import (
 
 // StartIPCEndpoint starts an IPC endpoint.
 func StartIPCEndpoint(ipcEndpoint string, apis []API) (net.Listener, *Server, error) {
+	return StartConfiguredIPCEndpoint(ipcEndpoint, apis, nil)
+}
+
+// StartConfiguredIPCEndpoint starts an IPC endpoint. The configure function, if
+// non-nil, is invoked on the server before it starts serving, e.g. to install a
+// call filter.
+func StartConfiguredIPCEndpoint(ipcEndpoint string, apis []API, configure func(*Server)) (net.Listener, *Server, error) {
 	// Register all the APIs exposed by the services.
 	var (
 		handler    = NewServer()
@@ -42,6 +49,9 @@ func StartIPCEndpoint(ipcEndpoint string, apis []API) (net.Listener, *Server, er
 		}
 	}
 	log.Debug("IPCs registered", "namespaces", strings.Join(registered, ","))
+	if configure != nil {
+		configure(handler)
+	}
 	// All APIs registered, start the IPC listener.
 	listener, err := ipcListen(ipcEndpoint)
 	if err != nil {
@@ -23,14 +23,19 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"runtime/pprof"
 	"strconv"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics/otlp"
 )
 
+// rpcProfileLabels are the pprof labels attached to method call processing.
+var rpcProfileLabels = pprof.Labels("subsystem", "rpc")
+
 // handler handles JSON-RPC messages. There is one handler per connection. Note that
 // handler is not safe for concurrent use. Message handling never blocks indefinitely
 // because RPCs are processed on background goroutines launched by handler.
@@ -65,6 +70,11 @@ type handler struct {
 	allowSubscribe       bool
 	batchRequestLimit    int
 	batchResponseMaxSize int
+	drain                *drainState       // drain mode of the serving Server, nil for clients
+	dedup                *callDeduplicator // shared execution of identical calls, nil if disabled
+	streamed             streamedMethods   // methods whose list results are streamed
+	filter               CallFilter        // access control of the serving Server, nil if disabled
+	callLog              CallLogger        // access log of the serving Server, nil if disabled
 
 	subLock    sync.Mutex
 	serverSubs map[ID]*Subscription
@@ -227,7 +237,11 @@ func (h *handler) handleBatch(msgs []*jsonrpcMessage) {
 			if msg == nil {
 				break
 			}
+			// Streamed results are buffered, the batch response is written at once.
 			resp := h.handleCallMsg(cp, msg)
+			if resp != nil {
+				resp = resp.buffered()
+			}
 			callBuffer.pushResponse(resp)
 			if resp != nil && h.batchResponseMaxSize != 0 {
 				responseBytes += len(resp.Result)
@@ -362,12 +376,27 @@ func (h *handler) addSubscriptions(nn []*Notifier) {
 	defer h.subLock.Unlock()
 
 	for _, n := range nn {
-		if sub := n.takeSubscription(); sub != nil {
+		if sub := n.takeSubscription(); sub != nil && !sub.closed {
 			h.serverSubs[sub.ID] = sub
 		}
 	}
 }
 
+// closeServerSubscription removes a subscription ended by the server and closes
+// its error channel, unless the client unsubscribed already.
+func (h *handler) closeServerSubscription(sub *Subscription, err error) {
+	h.subLock.Lock()
+	defer h.subLock.Unlock()
+
+	if sub.closed {
+		return
+	}
+	sub.err <- err
+	close(sub.err)
+	sub.closed = true
+	delete(h.serverSubs, sub.ID)
+}
+
 // cancelServerSubscriptions removes all subscriptions and closes their error channels.
 func (h *handler) cancelServerSubscriptions(err error) {
 	h.subLock.Lock()
@@ -376,6 +405,7 @@ func (h *handler) cancelServerSubscriptions(err error) {
 	for id, s := range h.serverSubs {
 		s.err <- err
 		close(s.err)
+		s.closed = true
 		delete(h.serverSubs, id)
 	}
 }
@@ -387,6 +417,10 @@ func (h *handler) startCallProc(fn func(*callProc)) {
 		ctx, cancel := context.WithCancel(h.rootCtx)
 		defer h.callWG.Done()
 		defer cancel()
+
+		// Attribute the profiling samples of the call to the RPC subsystem.
+		ctx = pprof.WithLabels(ctx, rpcProfileLabels)
+		pprof.SetGoroutineLabels(ctx)
 		fn(&callProc{ctx: ctx})
 	}()
 }
@@ -465,12 +499,14 @@ func (h *handler) handleCallMsg(ctx *callProc, msg *jsonrpcMessage) *jsonrpcMess
 	start := time.Now()
 	switch {
 	case msg.isNotification():
-		h.handleCall(ctx, msg)
+		resp := h.handleCall(ctx, msg)
 		h.log.Debug("Served "+msg.Method, "duration", time.Since(start))
+		h.logCall(ctx, msg, resp, start)
 		return nil
 
 	case msg.isCall():
 		resp := h.handleCall(ctx, msg)
+		h.logCall(ctx, msg, resp, start)
 		var logctx []any
 		logctx = append(logctx, "reqid", idForLog{msg.ID}, "duration", time.Since(start))
 		if resp.Error != nil {
@@ -492,8 +528,36 @@ func (h *handler) handleCallMsg(ctx *callProc, msg *jsonrpcMessage) *jsonrpcMess
 	}
 }
 
+// logCall reports a served call to the access log of the server.
+func (h *handler) logCall(cp *callProc, msg *jsonrpcMessage, resp *jsonrpcMessage, start time.Time) {
+	if h.callLog == nil {
+		return
+	}
+	info := CallInfo{
+		Method:     msg.Method,
+		ParamsSize: len(msg.Params),
+		Duration:   time.Since(start),
+	}
+	if resp != nil && resp.Error != nil {
+		info.ErrorCode = resp.Error.Code
+	}
+	h.callLog(cp.ctx, info)
+}
+
 // handleCall processes method calls.
 func (h *handler) handleCall(cp *callProc, msg *jsonrpcMessage) *jsonrpcMessage {
+	if h.filter != nil {
+		if err := h.filter(cp.ctx, msg.Method); err != nil {
+			return msg.errorResponse(err)
+		}
+	}
+	if h.drain != nil {
+		if h.drain.rejects(msg) {
+			return msg.errorResponse(&internalServerError{errcodeDraining, errMsgDraining})
+		}
+		h.drain.inflight.Add(1)
+		defer h.drain.inflight.Add(-1)
+	}
 	if msg.isSubscribe() {
 		return h.handleSubscribe(cp, msg)
 	}
@@ -516,7 +580,13 @@ func (h *handler) handleCall(cp *callProc, msg *jsonrpcMessage) *jsonrpcMessage
 		return msg.errorResponse(&invalidParamsError{err.Error()})
 	}
 	start := time.Now()
-	answer := h.runMethod(cp.ctx, msg, callb, args)
+	ctx, span := otlp.StartSpan(cp.ctx, "rpc."+msg.Method)
+	answer := h.runMethod(ctx, msg, callb, args)
+	if answer.Error != nil {
+		span.SetAttribute("rpc.error_code", answer.Error.Code)
+		span.SetError(answer.Error)
+	}
+	span.End()
 
 	// Collect the statistics for RPC calls if metrics is enabled.
 	// We only care about pure rpc call. Filter out subscription.
@@ -574,10 +644,31 @@ func (h *handler) handleSubscribe(cp *callProc, msg *jsonrpcMessage) *jsonrpcMes
 
 // runMethod runs the Go callback for an RPC method.
 func (h *handler) runMethod(ctx context.Context, msg *jsonrpcMessage, callb *callback, args []reflect.Value) *jsonrpcMessage {
-	result, err := callb.call(ctx, msg.Method, args)
+	var (
+		result interface{}
+		err    error
+	)
+	// Streamed results are written to the connection of a single caller, so calls
+	// of streamed methods are never shared.
+	if h.dedup.enabled(msg.Method) && !h.streamed.enabled(msg.Method) {
+		var shared bool
+		result, shared, err = h.dedup.call(ctx, msg, func(ctx context.Context) (interface{}, error) {
+			return callb.call(ctx, msg.Method, args)
+		})
+		// Methods may return a stream without being configured as streamed. The
+		// caller which joined the call runs it on its own in that case.
+		if shared && err == nil && isStreamedResult(result) {
+			result, err = callb.call(ctx, msg.Method, args)
+		}
+	} else {
+		result, err = callb.call(ctx, msg.Method, args)
+	}
 	if err != nil {
 		return msg.errorResponse(err)
 	}
+	if h.streamed.enabled(msg.Method) || isIterator(result) {
+		result = streamList(result)
+	}
 	return msg.response(result)
 }
 
@@ -591,6 +682,7 @@ func (h *handler) unsubscribe(ctx context.Context, id ID) (bool, error) {
 		return false, ErrSubscriptionNotFound
 	}
 	close(s.err)
+	s.closed = true
 	delete(h.serverSubs, id)
 	return true, nil
 }
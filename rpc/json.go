@@ -67,6 +67,8 @@ type jsonrpcMessage struct {
 	Params  json.RawMessage `json:"params,omitempty"`
 	Error   *jsonError      `json:"error,omitempty"`
 	Result  json.RawMessage `json:"result,omitempty"`
+
+	stream StreamedResult // result written directly to the connection, see StreamedResult
 }
 
 func (msg *jsonrpcMessage) isNotification() bool {
@@ -114,6 +116,9 @@ func (msg *jsonrpcMessage) errorResponse(err error) *jsonrpcMessage {
 }
 
 func (msg *jsonrpcMessage) response(result interface{}) *jsonrpcMessage {
+	if stream, ok := result.(StreamedResult); ok && stream != nil {
+		return &jsonrpcMessage{Version: vsn, ID: msg.ID, stream: stream}
+	}
 	enc, err := json.Marshal(result)
 	if err != nil {
 		return msg.errorResponse(&internalServerError{errcodeMarshalError, err.Error()})
@@ -186,6 +191,9 @@ type jsonCodec struct {
 	encMu   sync.Mutex       // guards the encoder
 	encode  encodeFunc       // encoder to allow multiple transports
 	conn    deadlineCloser
+	// streamWriter starts a message with a streamed result, nil if the transport
+	// doesn't support them.
+	streamWriter func() (io.WriteCloser, error)
 }
 
 type encodeFunc = func(v interface{}, isErrorResponse bool) error
@@ -218,7 +226,9 @@ func NewCodec(conn Conn) ServerCodec {
 	encode := func(v interface{}, isErrorResponse bool) error {
 		return enc.Encode(v)
 	}
-	return NewFuncCodec(conn, encode, dec.Decode)
+	codec := NewFuncCodec(conn, encode, dec.Decode).(*jsonCodec)
+	codec.streamWriter = connStreamWriter(conn)
+	return codec
 }
 
 func (c *jsonCodec) peerInfo() PeerInfo {
@@ -257,6 +267,13 @@ func (c *jsonCodec) writeJSON(ctx context.Context, v interface{}, isErrorRespons
 		deadline = time.Now().Add(defaultWriteTimeout)
 	}
 	c.conn.SetWriteDeadline(deadline)
+
+	if msg, ok := v.(*jsonrpcMessage); ok && msg.stream != nil {
+		if c.streamWriter != nil {
+			return c.writeStream(ctx, msg)
+		}
+		v = msg.buffered()
+	}
 	return c.encode(v, isErrorResponse)
 }
 
@@ -32,6 +32,9 @@ var (
 	serveTimeHistName = "rpc/duration"
 
 	rpcServingTimer = metrics.NewRegisteredTimer("rpc/duration/all", nil)
+
+	// rpcDedupMeter counts calls which shared the execution of an identical call.
+	rpcDedupMeter = metrics.NewRegisteredMeter("rpc/dedup", nil)
 )
 
 // updateServeTimeHistogram tracks the serving time of a remote RPC call.
@@ -0,0 +1,433 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+)
+
+const (
+	defaultHealthCheckInterval = 5 * time.Second
+	defaultHealthCheckMethod   = "web3_clientVersion"
+)
+
+var errNoEndpoint = errors.New("no endpoint available")
+
+// multiClient distributes the requests of a Client over several endpoints. Calls go
+// to the first healthy endpoint and fail over to the next one if the endpoint can't
+// be reached. Calls to hedged methods are additionally sent to the next endpoint if
+// no response arrived within the hedging delay, and the first response wins.
+// Subscriptions stick to a single endpoint as long as it stays healthy.
+type multiClient struct {
+	endpoints []*endpoint
+	options   []ClientOption
+
+	healthInterval time.Duration
+	healthMethod   string
+	hedgeDelay     time.Duration
+	hedged         map[string]struct{}
+
+	subMu sync.Mutex
+	subEP *endpoint // endpoint serving subscriptions
+
+	quit      chan struct{}
+	closeOnce sync.Once
+	wg        sync.WaitGroup
+}
+
+// endpoint is a single server of a multiClient.
+type endpoint struct {
+	url     string
+	healthy atomic.Bool
+
+	mu     sync.Mutex
+	client *Client // nil until dialed successfully
+}
+
+// DialMulti creates a client which spreads its requests over the given endpoints,
+// listed in order of preference. The returned client fails over to the next healthy
+// endpoint if an endpoint can't be reached, and checks the health of all endpoints
+// periodically, see WithHealthCheck. Read-only methods can be hedged across
+// endpoints, see WithHedging.
+//
+// Calls are only retried on another endpoint if the request failed in transport or
+// the server was unavailable. Errors returned by the called method are passed on to
+// the caller. Subscriptions are all created on the same endpoint, which is only
+// replaced when it becomes unhealthy; existing subscriptions then end with an error
+// and must be re-established by the caller.
+//
+// Endpoints which can't be dialed initially are retried by the health checks. Dialing
+// fails only if no endpoint could be reached.
+func DialMulti(ctx context.Context, endpoints []string, options ...ClientOption) (*Client, error) {
+	if len(endpoints) == 0 {
+		return nil, errors.New("no endpoints given")
+	}
+	cfg := new(clientConfig)
+	for _, opt := range options {
+		opt.applyOption(cfg)
+	}
+	m := &multiClient{
+		options:        options,
+		healthInterval: cfg.healthInterval,
+		healthMethod:   cfg.healthMethod,
+		hedgeDelay:     cfg.hedgeDelay,
+		hedged:         make(map[string]struct{}),
+		quit:           make(chan struct{}),
+	}
+	if m.healthInterval == 0 {
+		m.healthInterval = defaultHealthCheckInterval
+	}
+	if m.healthMethod == "" {
+		m.healthMethod = defaultHealthCheckMethod
+	}
+	for _, method := range cfg.hedgedMethods {
+		m.hedged[method] = struct{}{}
+	}
+	var (
+		dialed  int
+		lastErr error
+	)
+	for _, url := range endpoints {
+		ep := &endpoint{url: url}
+		if client, err := DialOptions(ctx, url, options...); err != nil {
+			log.Warn("Failed to dial RPC endpoint", "url", url, "err", err)
+			lastErr = err
+		} else {
+			ep.client = client
+			ep.healthy.Store(true)
+			dialed++
+		}
+		m.endpoints = append(m.endpoints, ep)
+	}
+	if dialed == 0 {
+		return nil, lastErr
+	}
+	m.wg.Add(1)
+	go m.healthLoop()
+
+	return &Client{multi: m, idgen: randomIDGenerator(), services: new(serviceRegistry)}, nil
+}
+
+// get returns the client of the endpoint, or nil if it isn't connected.
+func (ep *endpoint) get() *Client {
+	ep.mu.Lock()
+	defer ep.mu.Unlock()
+	return ep.client
+}
+
+// markFailed flags the endpoint unhealthy after a failed request.
+func (ep *endpoint) markFailed(err error) {
+	if ep.healthy.Swap(false) {
+		log.Warn("RPC endpoint failed", "url", ep.url, "err", err)
+	}
+}
+
+// candidates returns the endpoints in the order they should be tried: the healthy
+// ones by preference, followed by the unhealthy ones as a last resort.
+func (m *multiClient) candidates() []*endpoint {
+	var healthy, unhealthy []*endpoint
+	for _, ep := range m.endpoints {
+		if ep.healthy.Load() {
+			healthy = append(healthy, ep)
+		} else {
+			unhealthy = append(unhealthy, ep)
+		}
+	}
+	return append(healthy, unhealthy...)
+}
+
+// failover runs fn on the candidate endpoints until it succeeds or fails with an
+// error that another endpoint wouldn't fix.
+func (m *multiClient) failover(ctx context.Context, fn func(*Client) error) error {
+	err := errNoEndpoint
+	for _, ep := range m.candidates() {
+		client := ep.get()
+		if client == nil {
+			continue
+		}
+		if err = fn(client); !isTransportError(ctx, err) {
+			return err
+		}
+		ep.markFailed(err)
+	}
+	return err
+}
+
+func (m *multiClient) call(ctx context.Context, result interface{}, method string, args []interface{}) error {
+	if _, ok := m.hedged[method]; ok && m.hedgeDelay > 0 {
+		return m.hedgedCall(ctx, result, method, args)
+	}
+	return m.failover(ctx, func(c *Client) error {
+		return c.CallContext(ctx, result, method, args...)
+	})
+}
+
+// hedgedCall sends the call to the first candidate endpoint, and to one more endpoint
+// whenever the hedging delay passes without a response or an endpoint fails. The
+// first successful response is returned, the other requests are canceled.
+func (m *multiClient) hedgedCall(ctx context.Context, result interface{}, method string, args []interface{}) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type response struct {
+		ep  *endpoint
+		raw json.RawMessage
+		err error
+	}
+	var (
+		candidates = m.candidates()
+		responses  = make(chan response, len(candidates))
+		next       int
+		pending    int
+		lastErr    = errNoEndpoint
+	)
+	launch := func() bool {
+		for next < len(candidates) {
+			ep := candidates[next]
+			next++
+			client := ep.get()
+			if client == nil {
+				continue
+			}
+			pending++
+			go func() {
+				var raw json.RawMessage
+				err := client.CallContext(ctx, &raw, method, args...)
+				responses <- response{ep, raw, err}
+			}()
+			return true
+		}
+		return false
+	}
+	if !launch() {
+		return errNoEndpoint
+	}
+	hedge := time.NewTimer(m.hedgeDelay)
+	defer hedge.Stop()
+
+	for pending > 0 {
+		select {
+		case <-hedge.C:
+			if launch() {
+				hedge.Reset(m.hedgeDelay)
+			}
+		case resp := <-responses:
+			pending--
+			if resp.err == nil {
+				if result == nil {
+					return nil
+				}
+				return json.Unmarshal(resp.raw, result)
+			}
+			if !isTransportError(ctx, resp.err) {
+				return resp.err
+			}
+			resp.ep.markFailed(resp.err)
+			lastErr = resp.err
+			launch()
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return lastErr
+}
+
+func (m *multiClient) batchCall(ctx context.Context, b []BatchElem) error {
+	return m.failover(ctx, func(c *Client) error {
+		return c.BatchCallContext(ctx, b)
+	})
+}
+
+func (m *multiClient) notify(ctx context.Context, method string, args []interface{}) error {
+	return m.failover(ctx, func(c *Client) error {
+		return c.Notify(ctx, method, args...)
+	})
+}
+
+func (m *multiClient) callStream(ctx context.Context, w io.Writer, method string, args []interface{}) error {
+	// Once part of the result was written, the call can't be repeated.
+	cw := &countingWriter{w: w}
+	return m.failover(ctx, func(c *Client) error {
+		err := c.CallStream(ctx, cw, method, args...)
+		if err != nil && cw.n > 0 {
+			return &partialStreamError{err}
+		}
+		return err
+	})
+}
+
+// subscribe creates the subscription on the sticky subscription endpoint, choosing
+// a new one if there is none or it became unhealthy.
+func (m *multiClient) subscribe(ctx context.Context, namespace string, channel interface{}, args []interface{}) (*ClientSubscription, error) {
+	m.subMu.Lock()
+	defer m.subMu.Unlock()
+
+	if m.subEP != nil && m.subEP.healthy.Load() {
+		if client := m.subEP.get(); client != nil {
+			sub, err := client.Subscribe(ctx, namespace, channel, args...)
+			if !isTransportError(ctx, err) {
+				return sub, err
+			}
+			m.subEP.markFailed(err)
+		}
+	}
+	m.subEP = nil
+
+	err := errNoEndpoint
+	for _, ep := range m.candidates() {
+		client := ep.get()
+		if client == nil || !client.SupportsSubscriptions() {
+			continue
+		}
+		var sub *ClientSubscription
+		if sub, err = client.Subscribe(ctx, namespace, channel, args...); !isTransportError(ctx, err) {
+			if err == nil {
+				m.subEP = ep
+			}
+			return sub, err
+		}
+		ep.markFailed(err)
+	}
+	if errors.Is(err, errNoEndpoint) {
+		return nil, ErrNotificationsUnsupported
+	}
+	return nil, err
+}
+
+func (m *multiClient) supportsSubscriptions() bool {
+	for _, ep := range m.endpoints {
+		if client := ep.get(); client != nil && client.SupportsSubscriptions() {
+			return true
+		}
+	}
+	return false
+}
+
+// forEach runs fn on the clients of all connected endpoints.
+func (m *multiClient) forEach(fn func(*Client)) {
+	for _, ep := range m.endpoints {
+		if client := ep.get(); client != nil {
+			fn(client)
+		}
+	}
+}
+
+func (m *multiClient) close() {
+	m.closeOnce.Do(func() {
+		close(m.quit)
+		m.wg.Wait()
+		m.forEach((*Client).Close)
+	})
+}
+
+// healthLoop periodically checks all endpoints, reconnecting the ones which could
+// not be dialed.
+func (m *multiClient) healthLoop() {
+	defer m.wg.Done()
+
+	ticker := time.NewTicker(m.healthInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			var wg sync.WaitGroup
+			for _, ep := range m.endpoints {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					m.checkHealth(ep)
+				}()
+			}
+			wg.Wait()
+		case <-m.quit:
+			return
+		}
+	}
+}
+
+// checkHealth updates the health status of an endpoint.
+func (m *multiClient) checkHealth(ep *endpoint) {
+	ctx, cancel := context.WithTimeout(context.Background(), m.healthInterval)
+	defer cancel()
+
+	client := ep.get()
+	if client == nil {
+		c, err := DialOptions(ctx, ep.url, m.options...)
+		if err != nil {
+			return
+		}
+		ep.mu.Lock()
+		ep.client, client = c, c
+		ep.mu.Unlock()
+	}
+	var result json.RawMessage
+	if err := client.CallContext(ctx, &result, m.healthMethod); err != nil {
+		ep.markFailed(err)
+		return
+	}
+	if !ep.healthy.Swap(true) {
+		log.Info("RPC endpoint recovered", "url", ep.url)
+	}
+}
+
+// isTransportError reports whether err means the request didn't reach a working
+// server, so that it can be retried on another endpoint.
+func isTransportError(ctx context.Context, err error) bool {
+	if err == nil || ctx.Err() != nil || errors.Is(err, ErrNoResult) {
+		return false
+	}
+	var (
+		rpcErr     Error
+		httpErr    HTTPError
+		partialErr *partialStreamError
+	)
+	switch {
+	case errors.As(err, &rpcErr), errors.As(err, &partialErr):
+		return false
+	case errors.As(err, &httpErr):
+		return httpErr.StatusCode >= http.StatusInternalServerError || httpErr.StatusCode == http.StatusTooManyRequests
+	}
+	return true
+}
+
+// partialStreamError is returned if a streamed call failed after writing part of
+// its result, which prevents retrying it.
+type partialStreamError struct{ err error }
+
+func (e *partialStreamError) Error() string { return e.err.Error() }
+func (e *partialStreamError) Unwrap() error { return e.err }
+
+type countingWriter struct {
+	w io.Writer
+	n int
+}
+
+func (cw *countingWriter) Write(p []byte) (int, error) {
+	n, err := cw.w.Write(p)
+	cw.n += n
+	return n, err
+}
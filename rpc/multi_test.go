@@ -0,0 +1,154 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// countingHandler counts the requests to an HTTP handler, optionally holding them
+// until the test ends.
+type countingHandler struct {
+	h     http.Handler
+	count atomic.Int32
+	stall chan struct{}
+}
+
+func (ch *countingHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ch.count.Add(1)
+	if ch.stall != nil {
+		select {
+		case <-ch.stall:
+		case <-r.Context().Done():
+		}
+		return
+	}
+	ch.h.ServeHTTP(w, r)
+}
+
+func newMultiTestServers(t *testing.T, n int) ([]*httptest.Server, []*countingHandler, []string) {
+	var (
+		servers  []*httptest.Server
+		handlers []*countingHandler
+		urls     []string
+	)
+	for i := 0; i < n; i++ {
+		srv := newTestServer()
+		t.Cleanup(srv.Stop)
+		h := &countingHandler{h: srv}
+		hs := httptest.NewServer(h)
+		t.Cleanup(hs.Close)
+		servers = append(servers, hs)
+		handlers = append(handlers, h)
+		urls = append(urls, hs.URL)
+	}
+	return servers, handlers, urls
+}
+
+func TestMultiClientFailover(t *testing.T) {
+	servers, handlers, urls := newMultiTestServers(t, 2)
+	client, err := DialMulti(context.Background(), urls, WithHealthCheck(time.Hour, "test_null"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	// Calls go to the preferred endpoint while it works.
+	var result echoResult
+	if err := client.Call(&result, "test_echo", "hello", 1, nil); err != nil {
+		t.Fatal(err)
+	}
+	if handlers[0].count.Load() != 1 || handlers[1].count.Load() != 0 {
+		t.Fatalf("wrong request distribution: %d, %d", handlers[0].count.Load(), handlers[1].count.Load())
+	}
+
+	// Errors returned by the method are not retried.
+	err = client.Call(nil, "test_returnError")
+	var rpcErr Error
+	if !errors.As(err, &rpcErr) || rpcErr.ErrorCode() != 444 {
+		t.Fatalf("wrong error: %v", err)
+	}
+	if handlers[1].count.Load() != 0 {
+		t.Fatal("method error was retried on the next endpoint")
+	}
+
+	// Calls fail over once the endpoint is down.
+	servers[0].CloseClientConnections()
+	servers[0].Close()
+	if err := client.Call(&result, "test_echo", "hello", 2, nil); err != nil {
+		t.Fatal(err)
+	}
+	if result.Int != 2 || handlers[1].count.Load() != 1 {
+		t.Fatalf("call did not fail over: result %+v, %d requests", result, handlers[1].count.Load())
+	}
+	if client.multi.endpoints[0].healthy.Load() {
+		t.Fatal("failed endpoint still marked healthy")
+	}
+}
+
+func TestMultiClientHedging(t *testing.T) {
+	_, handlers, urls := newMultiTestServers(t, 2)
+	stall := make(chan struct{})
+	defer close(stall)
+	handlers[0].stall = stall
+
+	client, err := DialMulti(context.Background(), urls,
+		WithHealthCheck(time.Hour, "test_null"),
+		WithHedging(50*time.Millisecond, "test_echo"),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	// The hedged call is answered by the second endpoint.
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	var result echoResult
+	if err := client.CallContext(ctx, &result, "test_echo", "hello", 3, nil); err != nil {
+		t.Fatal(err)
+	}
+	if result.String != "hello" || result.Int != 3 {
+		t.Fatalf("wrong result: %+v", result)
+	}
+	if handlers[0].count.Load() != 1 || handlers[1].count.Load() != 1 {
+		t.Fatalf("wrong request distribution: %d, %d", handlers[0].count.Load(), handlers[1].count.Load())
+	}
+
+	// Other methods are not hedged.
+	ctx, cancel = context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	if err := client.CallContext(ctx, nil, "test_null"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected timeout, got %v", err)
+	}
+	if handlers[1].count.Load() != 1 {
+		t.Fatal("non-hedged method was sent to the second endpoint")
+	}
+}
+
+func TestMultiClientDialFailure(t *testing.T) {
+	_, err := DialMulti(context.Background(), []string{"ws://127.0.0.1:1", "/nonexistent/geth.ipc"})
+	if err == nil {
+		t.Fatal("expected error when no endpoint can be dialed")
+	}
+}
@@ -23,6 +23,7 @@ import (
 	"net"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/ethereum/go-ethereum/log"
 )
@@ -55,6 +56,51 @@ type Server struct {
 	batchResponseLimit int
 	httpBodyLimit      int
 	wsReadLimit        int64
+	drain              drainState
+	dedup              *callDeduplicator
+	streamed           streamedMethods
+	filter             CallFilter
+	callLog            CallLogger
+}
+
+// CallFilter decides whether a method call may be executed. The context is derived
+// from the HTTP request that delivered the call (or upgraded the connection for
+// WebSocket), so values attached by HTTP middleware are available to the filter.
+// A non-nil error is returned to the caller instead of running the method.
+type CallFilter func(ctx context.Context, method string) error
+
+// CallInfo describes a method call served by a Server.
+type CallInfo struct {
+	Method     string
+	ParamsSize int           // size of the encoded parameters in bytes
+	Duration   time.Duration // time taken to serve the call
+	ErrorCode  int           // JSON-RPC error code, zero if the call succeeded
+}
+
+// CallLogger is notified of every method call served by a Server, after the
+// response is created. The context is the same as passed to the CallFilter.
+type CallLogger func(ctx context.Context, info CallInfo)
+
+// drainState tracks the drain mode of a server along with the number of method
+// calls it is currently serving.
+type drainState struct {
+	active   atomic.Bool
+	inflight atomic.Int64
+	methods  map[string]struct{} // long-running methods rejected while draining
+}
+
+// rejects reports whether the given call must be refused because the server is
+// draining. Subscriptions and the configured methods (e.g. tracing) can run for a
+// long time, so they are not accepted anymore.
+func (d *drainState) rejects(msg *jsonrpcMessage) bool {
+	if !d.active.Load() {
+		return false
+	}
+	if msg.isSubscribe() {
+		return true
+	}
+	_, ok := d.methods[msg.Method]
+	return ok
 }
 
 // NewServer creates a new server instance with no registered handlers.
@@ -98,6 +144,58 @@ func (s *Server) SetWebsocketReadLimit(limit int64) {
 	s.wsReadLimit = limit
 }
 
+// SetDeduplicatedMethods enables sharing the execution of identical concurrent calls
+// to the given methods. This is meant for expensive read-only methods such as tracing
+// or log filtering, where many clients retrying the same request would otherwise
+// overload the node. Calls are identical if their method and parameters match.
+//
+// This method should be called before processing any requests via ServeCodec, ServeHTTP,
+// ServeListener etc.
+func (s *Server) SetDeduplicatedMethods(methods []string) {
+	s.dedup = newCallDeduplicator(methods)
+}
+
+// SetStreamedMethods enables streaming the results of the given methods. If a listed
+// method returns a list, its elements are encoded and written to the connection one
+// at a time, instead of buffering the entire encoded response in memory. This is
+// meant for methods with very large results, like block tracing or log queries.
+// Methods can also return a StreamedResult to control the encoding themselves.
+//
+// This method should be called before processing any requests via ServeCodec, ServeHTTP,
+// ServeListener etc.
+func (s *Server) SetStreamedMethods(methods []string) {
+	s.streamed = newStreamedMethods(methods)
+}
+
+// SetDrainRejectedMethods sets the methods which are rejected while the server is
+// draining, in addition to subscriptions. These are meant to be the methods which
+// can run for a long time, like tracing, as the drain would otherwise wait on them.
+//
+// This method should be called before processing any requests via ServeCodec, ServeHTTP,
+// ServeListener etc.
+func (s *Server) SetDrainRejectedMethods(methods []string) {
+	s.drain.methods = make(map[string]struct{}, len(methods))
+	for _, method := range methods {
+		s.drain.methods[method] = struct{}{}
+	}
+}
+
+// SetCallFilter installs a filter which is consulted before every method call.
+//
+// This method should be called before processing any requests via ServeCodec, ServeHTTP,
+// ServeListener etc.
+func (s *Server) SetCallFilter(filter CallFilter) {
+	s.filter = filter
+}
+
+// SetCallLogger installs a logger which is notified of every served method call.
+//
+// This method should be called before processing any requests via ServeCodec, ServeHTTP,
+// ServeListener etc.
+func (s *Server) SetCallLogger(logger CallLogger) {
+	s.callLog = logger
+}
+
 // RegisterName creates a service for the given receiver type under the given name. When no
 // methods on the given receiver match the criteria to be either an RPC method or a
 // subscription an error is returned. Otherwise a new service is created and added to the
@@ -112,6 +210,11 @@ func (s *Server) RegisterName(name string, receiver interface{}) error {
 //
 // Note that codec options are no longer supported.
 func (s *Server) ServeCodec(codec ServerCodec, options CodecOption) {
+	s.serveCodec(context.Background(), codec)
+}
+
+// serveCodec serves the codec, deriving the context of all calls from ctx.
+func (s *Server) serveCodec(ctx context.Context, codec ServerCodec) {
 	defer codec.close()
 
 	if !s.trackCodec(codec) {
@@ -123,6 +226,12 @@ func (s *Server) ServeCodec(codec ServerCodec, options CodecOption) {
 		idgen:              s.idgen,
 		batchItemLimit:     s.batchItemLimit,
 		batchResponseLimit: s.batchResponseLimit,
+		drain:              &s.drain,
+		dedup:              s.dedup,
+		streamed:           s.streamed,
+		filter:             s.filter,
+		callLog:            s.callLog,
+		baseCtx:            ctx,
 	}
 	c := initClient(codec, &s.services, cfg)
 	<-codec.closed()
@@ -158,6 +267,11 @@ func (s *Server) serveSingleRequest(ctx context.Context, codec ServerCodec) {
 
 	h := newHandler(ctx, codec, s.idgen, &s.services, s.batchItemLimit, s.batchResponseLimit)
 	h.allowSubscribe = false
+	h.drain = &s.drain
+	h.dedup = s.dedup
+	h.streamed = s.streamed
+	h.filter = s.filter
+	h.callLog = s.callLog
 	defer h.close(io.EOF, nil)
 
 	reqs, batch, err := codec.readBatch()
@@ -204,6 +318,33 @@ func (s *Server) Stop() {
 	}
 }
 
+// StartDrain puts the server into drain mode. While draining, in-flight requests and
+// regular method calls are still served, but new WebSocket connections, subscriptions
+// and long-running debug calls are rejected. HTTP health checks report the server as
+// unavailable, so that load balancers stop routing new traffic to it.
+func (s *Server) StartDrain() {
+	if s.drain.active.CompareAndSwap(false, true) {
+		log.Debug("RPC server draining")
+	}
+}
+
+// StopDrain leaves drain mode and resumes accepting all requests.
+func (s *Server) StopDrain() {
+	if s.drain.active.CompareAndSwap(true, false) {
+		log.Debug("RPC server drain stopped")
+	}
+}
+
+// Draining reports whether the server is in drain mode.
+func (s *Server) Draining() bool {
+	return s.drain.active.Load()
+}
+
+// InFlight returns the number of method calls currently being served.
+func (s *Server) InFlight() int {
+	return int(s.drain.inflight.Load())
+}
+
 // RPCService gives meta information about the server.
 // e.g. gives information about the loaded modules.
 type RPCService struct {
@@ -20,13 +20,18 @@ import (
 	"bufio"
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"io"
+	"iter"
 	"net"
+	"net/http"
 	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -291,3 +296,535 @@ func TestServerWebsocketReadLimit(t *testing.T) {
 		})
 	}
 }
+
+// This test checks that a draining server keeps serving regular calls on existing
+// connections, but rejects new connections, subscriptions and the configured calls.
+func TestServerDrain(t *testing.T) {
+	t.Parallel()
+
+	srv := newTestServer()
+	if err := srv.RegisterName("debug", new(testService)); err != nil {
+		t.Fatal(err)
+	}
+	defer srv.Stop()
+	srv.SetDrainRejectedMethods([]string{"debug_echo"})
+
+	httpsrv := httptest.NewServer(srv.WebsocketHandler([]string{"*"}))
+	defer httpsrv.Close()
+	wsURL := "ws:" + strings.TrimPrefix(httpsrv.URL, "http:")
+
+	client, err := DialOptions(context.Background(), wsURL)
+	if err != nil {
+		t.Fatalf("can't dial: %v", err)
+	}
+	defer client.Close()
+
+	srv.StartDrain()
+	if !srv.Draining() {
+		t.Fatal("server not draining")
+	}
+	// Regular calls on existing connections are still served.
+	var result echoResult
+	if err := client.Call(&result, "test_echo", "x", 1, &echoArgs{S: "y"}); err != nil {
+		t.Fatalf("call failed while draining: %v", err)
+	}
+	// Subscriptions and the configured calls are rejected.
+	checkDraining := func(err error) {
+		t.Helper()
+		var re Error
+		if !errors.As(err, &re) || re.ErrorCode() != errcodeDraining {
+			t.Fatalf("wrong error while draining: %v", err)
+		}
+	}
+	_, err = client.Subscribe(context.Background(), "nftest", make(chan int, 1), "someSubscription", 1, 1)
+	checkDraining(err)
+	checkDraining(client.Call(&result, "debug_echo", "x", 1, &echoArgs{S: "y"}))
+	if err := client.Call(&result, "debug_echoWithCtx", "x", 1, &echoArgs{S: "y"}); err != nil {
+		t.Fatalf("unlisted call failed while draining: %v", err)
+	}
+
+	// New connections are refused and health checks report unavailability.
+	if _, err := DialOptions(context.Background(), wsURL); err == nil {
+		t.Fatal("dial succeeded while draining")
+	}
+	rec := httptest.NewRecorder()
+	srv.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("wrong health check status while draining: %d", rec.Code)
+	}
+	if n := srv.InFlight(); n != 0 {
+		t.Fatalf("wrong in-flight count: %d", n)
+	}
+
+	// After stopping the drain, everything is accepted again.
+	srv.StopDrain()
+	sub, err := client.Subscribe(context.Background(), "nftest", make(chan int, 1), "someSubscription", 1, 1)
+	if err != nil {
+		t.Fatalf("subscribe failed after drain: %v", err)
+	}
+	sub.Unsubscribe()
+	rec = httptest.NewRecorder()
+	srv.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("wrong health check status after drain: %d", rec.Code)
+	}
+}
+
+type dedupTestService struct {
+	calls   atomic.Int32
+	release chan struct{}
+}
+
+func (s *dedupTestService) Wait(n int) int {
+	s.calls.Add(1)
+	<-s.release
+	return n
+}
+
+// This test checks that identical concurrent calls to deduplicated methods share
+// a single execution.
+func TestServerDeduplicatedMethods(t *testing.T) {
+	t.Parallel()
+
+	service := &dedupTestService{release: make(chan struct{})}
+	srv := NewServer()
+	srv.SetDeduplicatedMethods([]string{"dedup_wait"})
+	if err := srv.RegisterName("dedup", service); err != nil {
+		t.Fatal(err)
+	}
+	defer srv.Stop()
+	client := DialInProc(srv)
+	defer client.Close()
+
+	const callers = 8
+	var (
+		wg      sync.WaitGroup
+		results = make([]int, callers)
+		errs    = make([]error, callers)
+	)
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = client.Call(&results[i], "dedup_wait", 1)
+		}(i)
+	}
+	// Wait for the callers to be queued up, then let the single execution finish.
+	time.Sleep(200 * time.Millisecond)
+	close(service.release)
+	wg.Wait()
+
+	for i := 0; i < callers; i++ {
+		if errs[i] != nil || results[i] != 1 {
+			t.Fatalf("caller %d: wrong result %d, err %v", i, results[i], errs[i])
+		}
+	}
+	if calls := service.calls.Load(); calls != 1 {
+		t.Fatalf("wrong number of executions: have %d, want 1", calls)
+	}
+	// Calls with different parameters are executed separately.
+	var result int
+	if err := client.Call(&result, "dedup_wait", 2); err != nil || result != 2 {
+		t.Fatalf("wrong result %d, err %v", result, err)
+	}
+	if calls := service.calls.Load(); calls != 2 {
+		t.Fatalf("wrong number of executions: have %d, want 2", calls)
+	}
+}
+
+type dedupCancelService struct {
+	calls   atomic.Int32
+	aborted atomic.Int32
+	release chan struct{}
+}
+
+func (s *dedupCancelService) Wait(ctx context.Context, n int) (int, error) {
+	s.calls.Add(1)
+	select {
+	case <-s.release:
+		return n, nil
+	case <-ctx.Done():
+		s.aborted.Add(1)
+		return 0, ctx.Err()
+	}
+}
+
+// This test checks that a deduplicated call isn't aborted when the caller which
+// started it goes away, but only once all callers waiting for it are gone.
+func TestServerDeduplicatedMethodsCancel(t *testing.T) {
+	t.Parallel()
+
+	service := &dedupCancelService{release: make(chan struct{})}
+	srv := NewServer()
+	srv.SetDeduplicatedMethods([]string{"dedup_wait"})
+	if err := srv.RegisterName("dedup", service); err != nil {
+		t.Fatal(err)
+	}
+	defer srv.Stop()
+	httpsrv := httptest.NewServer(srv)
+	defer httpsrv.Close()
+	client, err := DialHTTP(httpsrv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	// Start the call with a leader, then join it with a follower.
+	leaderCtx, cancelLeader := context.WithCancel(context.Background())
+	leaderErr := make(chan error, 1)
+	go func() {
+		var result int
+		leaderErr <- client.CallContext(leaderCtx, &result, "dedup_wait", 1)
+	}()
+	for service.calls.Load() == 0 {
+		time.Sleep(10 * time.Millisecond)
+	}
+	var (
+		followerResult int
+		followerErr    = make(chan error, 1)
+	)
+	go func() {
+		followerErr <- client.Call(&followerResult, "dedup_wait", 1)
+	}()
+	time.Sleep(200 * time.Millisecond)
+
+	// Cancelling the leader must not affect the follower.
+	cancelLeader()
+	if err := <-leaderErr; err == nil {
+		t.Fatal("leader call succeeded after cancellation")
+	}
+	time.Sleep(200 * time.Millisecond)
+	close(service.release)
+	if err := <-followerErr; err != nil || followerResult != 1 {
+		t.Fatalf("wrong follower result %d, err %v", followerResult, err)
+	}
+	if calls := service.calls.Load(); calls != 1 {
+		t.Fatalf("wrong number of executions: have %d, want 1", calls)
+	}
+	if aborted := service.aborted.Load(); aborted != 0 {
+		t.Fatalf("shared call aborted %d times", aborted)
+	}
+}
+
+// This test checks that a deduplicated call is aborted once all callers are gone.
+func TestServerDeduplicatedMethodsAbort(t *testing.T) {
+	t.Parallel()
+
+	service := &dedupCancelService{release: make(chan struct{})}
+	srv := NewServer()
+	srv.SetDeduplicatedMethods([]string{"dedup_wait"})
+	if err := srv.RegisterName("dedup", service); err != nil {
+		t.Fatal(err)
+	}
+	defer srv.Stop()
+	httpsrv := httptest.NewServer(srv)
+	defer httpsrv.Close()
+	client, err := DialHTTP(httpsrv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	var result int
+	if err := client.CallContext(ctx, &result, "dedup_wait", 1); err == nil {
+		t.Fatal("call succeeded after timeout")
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for service.aborted.Load() == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("shared call not aborted after all callers left")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	// A new caller starts a fresh execution instead of joining the aborted one.
+	close(service.release)
+	if err := client.Call(&result, "dedup_wait", 1); err != nil || result != 1 {
+		t.Fatalf("wrong result %d, err %v", result, err)
+	}
+	if calls := service.calls.Load(); calls != 2 {
+		t.Fatalf("wrong number of executions: have %d, want 2", calls)
+	}
+}
+
+type streamTestService struct{}
+
+type streamTestItem struct {
+	Index int    `json:"index"`
+	Data  string `json:"data"`
+}
+
+func newStreamTestItem(i int) streamTestItem {
+	return streamTestItem{Index: i, Data: strings.Repeat("<x>", i%16)}
+}
+
+func (s *streamTestService) List(n int) []streamTestItem {
+	items := make([]streamTestItem, n)
+	for i := range items {
+		items[i] = newStreamTestItem(i)
+	}
+	return items
+}
+
+func (s *streamTestService) Iter(n int) iter.Seq[streamTestItem] {
+	return func(yield func(streamTestItem) bool) {
+		for i := 0; i < n; i++ {
+			if !yield(newStreamTestItem(i)) {
+				return
+			}
+		}
+	}
+}
+
+func (s *streamTestService) Bytes() []byte {
+	return []byte("raw")
+}
+
+func (s *streamTestService) Custom() StreamedResult {
+	return func(w io.Writer) error {
+		_, err := io.WriteString(w, `{"custom":true}`)
+		return err
+	}
+}
+
+func (s *streamTestService) Fail() StreamedResult {
+	return func(w io.Writer) error {
+		return errors.New("stream failed")
+	}
+}
+
+// This test checks that streamed results are delivered intact on all transports.
+func TestServerStreamedMethods(t *testing.T) {
+	t.Parallel()
+
+	srv := NewServer()
+	srv.SetStreamedMethods([]string{"stream_list", "stream_bytes"})
+	if err := srv.RegisterName("stream", new(streamTestService)); err != nil {
+		t.Fatal(err)
+	}
+	defer srv.Stop()
+	httpsrv := httptest.NewServer(srv)
+	defer httpsrv.Close()
+	wssrv := httptest.NewServer(srv.WebsocketHandler([]string{"*"}))
+	defer wssrv.Close()
+
+	clients := map[string]*Client{"inproc": DialInProc(srv)}
+	for name, url := range map[string]string{"http": httpsrv.URL, "ws": "ws:" + strings.TrimPrefix(wssrv.URL, "http:")} {
+		client, err := Dial(url)
+		if err != nil {
+			t.Fatal(err)
+		}
+		clients[name] = client
+	}
+	const items = 10000
+	want, _ := json.Marshal(new(streamTestService).List(items))
+
+	for name, client := range clients {
+		// Iterators are streamed even if the method isn't configured to be.
+		for _, method := range []string{"stream_list", "stream_iter"} {
+			var list []streamTestItem
+			if err := client.Call(&list, method, items); err != nil || len(list) != items {
+				t.Errorf("%s: wrong %s result (%d items), err %v", name, method, len(list), err)
+			}
+			var buf, compact bytes.Buffer
+			if err := client.CallStream(context.Background(), &buf, method, items); err != nil {
+				t.Fatalf("%s: stream call of %s failed: %v", name, method, err)
+			}
+			if err := json.Compact(&compact, buf.Bytes()); err != nil || !bytes.Equal(compact.Bytes(), want) {
+				t.Errorf("%s: streamed %s mismatch, err %v", name, method, err)
+			}
+		}
+		var buf bytes.Buffer
+		if err := client.CallStream(context.Background(), &buf, "stream_bytes"); err != nil || buf.String() != `"cmF3"` {
+			t.Errorf("%s: wrong bytes result %s, err %v", name, buf.String(), err)
+		}
+		buf.Reset()
+		if err := client.CallStream(context.Background(), &buf, "stream_custom"); err != nil || buf.String() != `{"custom":true}` {
+			t.Errorf("%s: wrong custom result %s, err %v", name, buf.String(), err)
+		}
+		if err := client.Call(nil, "stream_fail"); err == nil || err.Error() != "stream failed" {
+			t.Errorf("%s: wrong error for failing stream: %v", name, err)
+		}
+		// Streamed results in batches are buffered.
+		var custom map[string]bool
+		batch := []BatchElem{{Method: "stream_custom", Result: &custom}}
+		if err := client.BatchCall(batch); err != nil || batch[0].Error != nil || !custom["custom"] {
+			t.Errorf("%s: wrong batch result %v, err %v %v", name, custom, err, batch[0].Error)
+		}
+		var iterated []streamTestItem
+		batch = []BatchElem{{Method: "stream_iter", Args: []interface{}{3}, Result: &iterated}}
+		if err := client.BatchCall(batch); err != nil || batch[0].Error != nil || len(iterated) != 3 {
+			t.Errorf("%s: wrong batch iterator result %v, err %v %v", name, iterated, err, batch[0].Error)
+		}
+		client.Close()
+	}
+}
+
+type dedupStreamService struct {
+	calls   atomic.Int32
+	release chan struct{}
+}
+
+func (s *dedupStreamService) List(n int) []int {
+	s.calls.Add(1)
+	<-s.release
+	return make([]int, n)
+}
+
+// Items returns an iterator which can only be consumed once.
+func (s *dedupStreamService) Items(n int) iter.Seq[int] {
+	s.calls.Add(1)
+	<-s.release
+
+	items := make(chan int, n)
+	for i := 0; i < n; i++ {
+		items <- i
+	}
+	close(items)
+	return func(yield func(int) bool) {
+		for item := range items {
+			if !yield(item) {
+				return
+			}
+		}
+	}
+}
+
+// This test checks that streamed results are never shared between the callers of
+// deduplicated methods.
+func TestServerDeduplicatedStreams(t *testing.T) {
+	t.Parallel()
+
+	service := &dedupStreamService{release: make(chan struct{})}
+	srv := NewServer()
+	srv.SetDeduplicatedMethods([]string{"dedup_list", "dedup_items"})
+	srv.SetStreamedMethods([]string{"dedup_list"})
+	if err := srv.RegisterName("dedup", service); err != nil {
+		t.Fatal(err)
+	}
+	defer srv.Stop()
+	client := DialInProc(srv)
+	defer client.Close()
+
+	const (
+		callers = 8
+		items   = 1000
+	)
+	for _, method := range []string{"dedup_list", "dedup_items"} {
+		service.calls.Store(0)
+		service.release = make(chan struct{})
+
+		var (
+			wg      sync.WaitGroup
+			results = make([][]int, callers)
+			errs    = make([]error, callers)
+		)
+		for i := 0; i < callers; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				errs[i] = client.Call(&results[i], method, items)
+			}(i)
+		}
+		time.Sleep(200 * time.Millisecond)
+		close(service.release)
+		wg.Wait()
+
+		for i := 0; i < callers; i++ {
+			if errs[i] != nil || len(results[i]) != items {
+				t.Fatalf("%s: caller %d: wrong result length %d, err %v", method, i, len(results[i]), errs[i])
+			}
+		}
+		if calls := service.calls.Load(); calls != callers {
+			t.Fatalf("%s: wrong number of executions: have %d, want %d", method, calls, callers)
+		}
+	}
+}
+
+type filterTestKey struct{}
+
+// This test checks that the call filter can see values attached to the request
+// context by HTTP middleware, both for HTTP and WebSocket connections.
+func TestServerCallFilter(t *testing.T) {
+	t.Parallel()
+
+	srv := newTestServer()
+	srv.SetCallFilter(func(ctx context.Context, method string) error {
+		if ctx.Value(filterTestKey{}) == "admin" || method == "test_null" {
+			return nil
+		}
+		return errors.New("access denied")
+	})
+	defer srv.Stop()
+
+	withRole := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := context.WithValue(r.Context(), filterTestKey{}, r.Header.Get("X-Role"))
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+	httpsrv := httptest.NewServer(withRole(srv))
+	defer httpsrv.Close()
+	wssrv := httptest.NewServer(withRole(srv.WebsocketHandler([]string{"*"})))
+	defer wssrv.Close()
+
+	for _, url := range []string{httpsrv.URL, "ws:" + strings.TrimPrefix(wssrv.URL, "http:")} {
+		for _, role := range []string{"", "admin"} {
+			header := http.Header{"X-Role": []string{role}}
+			client, err := DialOptions(context.Background(), url, WithHeaders(header))
+			if err != nil {
+				t.Fatal(err)
+			}
+			var result string
+			if err := client.Call(nil, "test_null"); err != nil {
+				t.Errorf("%s role %q: unexpected error: %v", url, role, err)
+			}
+			err = client.Call(&result, "test_repeat", "x", 2)
+			switch {
+			case role == "admin" && err != nil:
+				t.Errorf("%s role %q: unexpected error: %v", url, role, err)
+			case role != "admin" && (err == nil || err.Error() != "access denied"):
+				t.Errorf("%s role %q: wrong error: %v", url, role, err)
+			}
+			client.Close()
+		}
+	}
+}
+
+// This test checks that the call logger is notified of served calls.
+func TestServerCallLogger(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mu    sync.Mutex
+		calls []CallInfo
+	)
+	srv := newTestServer()
+	srv.SetCallLogger(func(ctx context.Context, info CallInfo) {
+		mu.Lock()
+		defer mu.Unlock()
+		calls = append(calls, info)
+	})
+	defer srv.Stop()
+
+	client := DialInProc(srv)
+	defer client.Close()
+	var result string
+	if err := client.Call(&result, "test_repeat", "x", 2); err != nil {
+		t.Fatal(err)
+	}
+	client.Call(nil, "test_missing")
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(calls) != 2 {
+		t.Fatalf("wrong number of logged calls: %d", len(calls))
+	}
+	if calls[0].Method != "test_repeat" || calls[0].ParamsSize != len(`["x",2]`) || calls[0].ErrorCode != 0 {
+		t.Errorf("wrong info for successful call: %+v", calls[0])
+	}
+	if calls[1].Method != "test_missing" || calls[1].ErrorCode != -32601 {
+		t.Errorf("wrong info for failed call: %+v", calls[1])
+	}
+}
@@ -0,0 +1,231 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// streamContentType is the content type of streaming requests. The request and
+// response bodies carry a sequence of newline-delimited JSON-RPC messages.
+//
+// A stream is a single, long-running HTTP request with a full-duplex body, over
+// which the client pipelines calls. The server answers them as they complete,
+// which may be out of order, and the client matches responses by their IDs. Unlike
+// plain HTTP there is no per-call request overhead, and unlike WebSocket there
+// is no message framing, so large responses don't block the connection.
+const streamContentType = "application/x-ndjson"
+
+// isStreamRequest reports whether the request opens a stream.
+func isStreamRequest(r *http.Request) bool {
+	mt, _, err := mime.ParseMediaType(r.Header.Get("content-type"))
+	return err == nil && mt == streamContentType
+}
+
+// serveStream serves JSON-RPC over the full-duplex body of the request until
+// either side closes the stream.
+func (s *Server) serveStream(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	rc := http.NewResponseController(w)
+	if err := rc.EnableFullDuplex(); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	// The stream outlives the timeouts of the HTTP server, which are meant for
+	// single requests.
+	rc.SetReadDeadline(time.Time{})
+	rc.SetWriteDeadline(time.Time{})
+
+	w.Header().Set("content-type", streamContentType)
+	w.WriteHeader(http.StatusOK)
+	if err := rc.Flush(); err != nil {
+		return
+	}
+	info := PeerInfo{Transport: "stream", RemoteAddr: r.RemoteAddr}
+	info.HTTP.Version = r.Proto
+	info.HTTP.Host = r.Host
+	info.HTTP.Origin = r.Header.Get("Origin")
+	info.HTTP.UserAgent = r.Header.Get("User-Agent")
+
+	conn := &streamServerConn{body: r.Body, w: w, rc: rc, remote: r.RemoteAddr}
+	codec := &streamCodec{ServerCodec: NewCodec(conn), info: info}
+	s.serveCodec(context.WithoutCancel(r.Context()), codec)
+}
+
+// streamCodec is a JSON codec over a stream, which reports the HTTP request that
+// opened it as peer information.
+type streamCodec struct {
+	ServerCodec
+	info PeerInfo
+}
+
+func (c *streamCodec) peerInfo() PeerInfo {
+	return c.info
+}
+
+// streamServerConn is the server side of a stream. Every write is flushed so
+// that responses are delivered immediately.
+type streamServerConn struct {
+	body   io.ReadCloser
+	w      http.ResponseWriter
+	rc     *http.ResponseController
+	remote string
+}
+
+func (c *streamServerConn) Read(b []byte) (int, error) {
+	return c.body.Read(b)
+}
+
+func (c *streamServerConn) Write(b []byte) (int, error) {
+	n, err := c.w.Write(b)
+	if err != nil {
+		return n, err
+	}
+	return n, c.rc.Flush()
+}
+
+func (c *streamServerConn) Close() error {
+	return c.body.Close()
+}
+
+func (c *streamServerConn) SetWriteDeadline(t time.Time) error {
+	return c.rc.SetWriteDeadline(t)
+}
+
+func (c *streamServerConn) RemoteAddr() string {
+	return c.remote
+}
+
+// DialStream creates a new RPC client which pipelines all calls over a single
+// HTTP stream to the given endpoint. The HTTP client and header options apply to
+// the request opening the stream.
+func DialStream(ctx context.Context, endpoint string, options ...ClientOption) (*Client, error) {
+	if _, err := url.Parse(endpoint); err != nil {
+		return nil, err
+	}
+	cfg := new(clientConfig)
+	for _, opt := range options {
+		opt.applyOption(cfg)
+	}
+	return newClient(ctx, cfg, newClientTransportStream(endpoint, cfg))
+}
+
+func newClientTransportStream(endpoint string, cfg *clientConfig) reconnectFunc {
+	headers := make(http.Header, 3+len(cfg.httpHeaders))
+	headers.Set("accept", streamContentType)
+	headers.Set("content-type", streamContentType)
+	// Compression would buffer the responses.
+	headers.Set("accept-encoding", "identity")
+	for key, values := range cfg.httpHeaders {
+		headers[key] = values
+	}
+	client := cfg.httpClient
+	if client == nil {
+		client = new(http.Client)
+	}
+	return func(ctx context.Context) (ServerCodec, error) {
+		reader, writer := io.Pipe()
+
+		// The stream must not be canceled with the dial context, it lives
+		// until the connection is closed.
+		streamCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		req, err := http.NewRequestWithContext(streamCtx, http.MethodPost, endpoint, reader)
+		if err != nil {
+			cancel()
+			return nil, err
+		}
+		req.Header = headers.Clone()
+		if cfg.httpAuth != nil {
+			if err := cfg.httpAuth(req.Header); err != nil {
+				cancel()
+				return nil, err
+			}
+		}
+		type result struct {
+			resp *http.Response
+			err  error
+		}
+		done := make(chan result, 1)
+		go func() {
+			resp, err := client.Do(req)
+			done <- result{resp, err}
+		}()
+		var res result
+		select {
+		case res = <-done:
+		case <-ctx.Done():
+			cancel()
+			return nil, ctx.Err()
+		}
+		if res.err != nil {
+			cancel()
+			return nil, res.err
+		}
+		if res.resp.StatusCode != http.StatusOK {
+			defer cancel()
+			body, _ := io.ReadAll(res.resp.Body)
+			cleanlyCloseBody(res.resp.Body)
+			return nil, HTTPError{
+				Status:     res.resp.Status,
+				StatusCode: res.resp.StatusCode,
+				Body:       body,
+			}
+		}
+		conn := &streamClientConn{body: res.resp.Body, pipe: writer, cancel: cancel, remote: endpoint}
+		return NewCodec(conn), nil
+	}
+}
+
+// streamClientConn is the client side of a stream.
+type streamClientConn struct {
+	body   io.ReadCloser
+	pipe   *io.PipeWriter
+	cancel context.CancelFunc // aborts the request, unblocking reads
+	remote string
+}
+
+func (c *streamClientConn) Read(b []byte) (int, error) {
+	return c.body.Read(b)
+}
+
+func (c *streamClientConn) Write(b []byte) (int, error) {
+	return c.pipe.Write(b)
+}
+
+func (c *streamClientConn) Close() error {
+	c.pipe.Close()
+	c.cancel()
+	return c.body.Close()
+}
+
+func (c *streamClientConn) SetWriteDeadline(t time.Time) error {
+	return fmt.Errorf("stream to %s: deadline not supported", c.remote)
+}
+
+func (c *streamClientConn) RemoteAddr() string {
+	return c.remote
+}
@@ -0,0 +1,114 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"context"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestStreamCalls(t *testing.T) {
+	t.Parallel()
+
+	server := newTestServer()
+	defer server.Stop()
+	httpsrv := httptest.NewServer(server)
+	defer httpsrv.Close()
+
+	client, err := DialStream(context.Background(), httpsrv.URL)
+	if err != nil {
+		t.Fatal("can't dial:", err)
+	}
+	defer client.Close()
+
+	// Pipeline calls, a slow one must not delay the others.
+	var (
+		wg    sync.WaitGroup
+		fast  = make(chan error, 10)
+		sleep = make(chan error, 1)
+	)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		sleep <- client.Call(nil, "test_sleep", 500*time.Millisecond)
+	}()
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var resp echoResult
+			err := client.Call(&resp, "test_echo", "hello", i, &echoArgs{"world"})
+			if err == nil && (resp.String != "hello" || resp.Int != i) {
+				t.Errorf("wrong echo result: %+v", resp)
+			}
+			fast <- err
+		}()
+	}
+	for i := 0; i < 10; i++ {
+		select {
+		case err := <-fast:
+			if err != nil {
+				t.Fatal(err)
+			}
+		case <-sleep:
+			t.Fatal("slow call finished before fast ones")
+		}
+	}
+	wg.Wait()
+	if err := <-sleep; err != nil {
+		t.Fatal(err)
+	}
+
+	// The connection information reflects the stream.
+	var info PeerInfo
+	if err := client.Call(&info, "test_peerInfo"); err != nil {
+		t.Fatal(err)
+	}
+	if info.Transport != "stream" || info.HTTP.Version == "" {
+		t.Fatalf("wrong peer info: %+v", info)
+	}
+}
+
+func TestStreamSubscription(t *testing.T) {
+	t.Parallel()
+
+	server := newTestServer()
+	defer server.Stop()
+	httpsrv := httptest.NewServer(server)
+	defer httpsrv.Close()
+
+	client, err := DialStream(context.Background(), httpsrv.URL)
+	if err != nil {
+		t.Fatal("can't dial:", err)
+	}
+	defer client.Close()
+
+	ch := make(chan int)
+	sub, err := client.Subscribe(context.Background(), "nftest", ch, "someSubscription", 5, 0)
+	if err != nil {
+		t.Fatal("can't subscribe:", err)
+	}
+	defer sub.Unsubscribe()
+	for i := 0; i < 5; i++ {
+		if v := <-ch; v != i {
+			t.Fatalf("wrong notification value: have %d, want %d", v, i)
+		}
+	}
+}
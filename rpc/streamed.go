@@ -0,0 +1,397 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"reflect"
+	"time"
+)
+
+// streamBufferSize is the size of the buffer used to write streamed results. Output
+// is flushed to the connection whenever the buffer fills up.
+const streamBufferSize = 64 * 1024
+
+// StreamedResult is a method result which writes its JSON encoding to the connection
+// incrementally, instead of being marshaled into memory as a whole before sending.
+// Methods returning very large results can return a StreamedResult to bound their
+// memory usage.
+//
+// The function must write exactly one JSON value. Errors should be detected before
+// returning the StreamedResult from the method: once part of the response has been
+// written to the connection, a failing StreamedResult can't be turned into an error
+// response anymore, and the connection is closed instead.
+//
+// Responses are streamed over IPC, HTTP, WebSocket and in-process connections. In
+// batch responses, the result is buffered before sending.
+//
+// A StreamedResult is written at most once. Calls of methods returning one are not
+// shared with other callers when the method is deduplicated.
+type StreamedResult func(w io.Writer) error
+
+var (
+	jsonMarshalerType = reflect.TypeFor[json.Marshaler]()
+	textMarshalerType = reflect.TypeFor[encoding.TextMarshaler]()
+)
+
+// streamedMethods is the set of methods whose list results are streamed element by
+// element.
+type streamedMethods map[string]struct{}
+
+func newStreamedMethods(methods []string) streamedMethods {
+	if len(methods) == 0 {
+		return nil
+	}
+	s := make(streamedMethods, len(methods))
+	for _, method := range methods {
+		s[method] = struct{}{}
+	}
+	return s
+}
+
+// enabled reports whether the results of the given method are streamed.
+func (s streamedMethods) enabled(method string) bool {
+	_, ok := s[method]
+	return ok
+}
+
+// isStreamedResult reports whether the result is written to the connection by the
+// caller, i.e. it is a StreamedResult or an iterator.
+func isStreamedResult(result interface{}) bool {
+	if _, ok := result.(StreamedResult); ok {
+		return true
+	}
+	return isIterator(result)
+}
+
+// isIterator reports whether the result is an iterator (iter.Seq) of list elements.
+func isIterator(result interface{}) bool {
+	if result == nil {
+		return false
+	}
+	v := reflect.ValueOf(result)
+	return v.Kind() == reflect.Func && v.Type().CanSeq()
+}
+
+// streamList converts a list result into a StreamedResult which encodes the list one
+// element at a time, producing the same output as marshaling it as a slice at once.
+// Any other result is returned unchanged.
+//
+// Slice elements are already held in memory, streaming them only avoids buffering
+// the encoded response. Methods can return an iterator (iter.Seq) instead, whose
+// elements are produced while the response is written, to bound the memory used
+// by their results. Iterators can only be encoded this way.
+func streamList(result interface{}) interface{} {
+	if result == nil {
+		return nil
+	}
+	var (
+		v     = reflect.ValueOf(result)
+		elems iter.Seq[reflect.Value]
+	)
+	switch {
+	case isIterator(result):
+		if v.IsNil() {
+			return nil
+		}
+		elems = v.Seq()
+
+	case v.Kind() == reflect.Slice && !v.IsNil():
+		// Slices with a custom encoding, and byte slices, which are encoded as a
+		// string, can't be split into elements.
+		typ := v.Type()
+		if typ.Elem().Kind() == reflect.Uint8 || typ.Implements(jsonMarshalerType) || typ.Implements(textMarshalerType) {
+			return result
+		}
+		elems = func(yield func(reflect.Value) bool) {
+			for i := 0; i < v.Len(); i++ {
+				if !yield(v.Index(i)) {
+					return
+				}
+			}
+		}
+
+	default:
+		return result
+	}
+	return StreamedResult(func(w io.Writer) error {
+		if _, err := io.WriteString(w, "["); err != nil {
+			return err
+		}
+		var (
+			enc   = json.NewEncoder(w)
+			first = true
+			err   error
+		)
+		for elem := range elems {
+			if !first {
+				if _, err = io.WriteString(w, ","); err != nil {
+					break
+				}
+			}
+			first = false
+
+			// The encoder appends a newline after each value, which is valid
+			// whitespace inside a JSON array.
+			if err = enc.Encode(elem.Interface()); err != nil {
+				break
+			}
+		}
+		if err != nil {
+			return err
+		}
+		_, err = io.WriteString(w, "]")
+		return err
+	})
+}
+
+// buffered returns the message with its streamed result, if any, written into the
+// Result field. A failing stream is converted into an error response.
+func (msg *jsonrpcMessage) buffered() *jsonrpcMessage {
+	if msg.stream == nil {
+		return msg
+	}
+	var buf bytes.Buffer
+	if err := msg.stream(&buf); err != nil {
+		return msg.errorResponse(err)
+	}
+	return &jsonrpcMessage{Version: vsn, ID: msg.ID, Result: buf.Bytes()}
+}
+
+// writeStream writes a response with a streamed result directly to the underlying
+// connection. It must be called with encMu held.
+func (c *jsonCodec) writeStream(ctx context.Context, msg *jsonrpcMessage) error {
+	_, hasDeadline := ctx.Deadline()
+	dw := &deadlineWriter{conn: c.conn, open: c.streamWriter, extend: !hasDeadline}
+	w := bufio.NewWriterSize(dw, streamBufferSize)
+
+	id := msg.ID
+	if id == nil {
+		id = null
+	}
+	fmt.Fprintf(w, `{"jsonrpc":"%s","id":%s,"result":`, vsn, id)
+	if err := msg.stream(w); err != nil {
+		// If nothing was written yet, the failure can still be reported as an
+		// error response. Otherwise the client is left with a truncated message
+		// and the connection has to be dropped.
+		if dw.written == 0 {
+			return c.encode(msg.errorResponse(err), true)
+		}
+		c.close()
+		return err
+	}
+	w.WriteString("}\n")
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	return dw.Close()
+}
+
+// connStreamWriter returns a streamed message writer for transports which write
+// messages to the connection back to back.
+func connStreamWriter(conn io.Writer) func() (io.WriteCloser, error) {
+	return func() (io.WriteCloser, error) {
+		return nopWriteCloser{conn}, nil
+	}
+}
+
+type nopWriteCloser struct{ io.Writer }
+
+func (nopWriteCloser) Close() error { return nil }
+
+// deadlineWriter writes a message to a connection, extending the write deadline
+// before every write so that slowly produced results don't hit the timeout. The
+// message is only started on the first write.
+type deadlineWriter struct {
+	conn    deadlineCloser
+	open    func() (io.WriteCloser, error)
+	w       io.WriteCloser // message writer, nil until the first write
+	extend  bool
+	written int
+}
+
+func (dw *deadlineWriter) Write(p []byte) (int, error) {
+	if dw.extend {
+		dw.conn.SetWriteDeadline(time.Now().Add(defaultWriteTimeout))
+	}
+	if dw.w == nil {
+		w, err := dw.open()
+		if err != nil {
+			return 0, err
+		}
+		dw.w = w
+	}
+	n, err := dw.w.Write(p)
+	dw.written += n
+	return n, err
+}
+
+// Close finishes the message, if it has been started.
+func (dw *deadlineWriter) Close() error {
+	if dw.w == nil {
+		return nil
+	}
+	return dw.w.Close()
+}
+
+// CallStream performs a JSON-RPC call with the given arguments and writes the raw
+// JSON result to w. Over HTTP, the result is copied to w as it arrives, without
+// buffering the response in memory, which makes it suitable for calling methods
+// with very large results. On other transports, the result is written once the
+// full response has been received.
+//
+// If the method returns an error, nothing is written to w.
+func (c *Client) CallStream(ctx context.Context, w io.Writer, method string, args ...interface{}) error {
+	if c.multi != nil {
+		return c.multi.callStream(ctx, w, method, args)
+	}
+	if !c.isHTTP {
+		var result json.RawMessage
+		if err := c.CallContext(ctx, &result, method, args...); err != nil {
+			return err
+		}
+		_, err := w.Write(result)
+		return err
+	}
+	msg, err := c.newMessage(method, args...)
+	if err != nil {
+		return err
+	}
+	hc := c.writeConn.(*httpConn)
+	respBody, err := hc.doRequest(ctx, msg)
+	if err != nil {
+		return err
+	}
+	defer cleanlyCloseBody(respBody)
+
+	return readStreamedResponse(respBody, w)
+}
+
+// readStreamedResponse decodes a JSON-RPC response from r, copying its result to w.
+func readStreamedResponse(r io.Reader, w io.Writer) error {
+	dec := json.NewDecoder(r)
+	if tok, err := dec.Token(); err != nil {
+		return err
+	} else if tok != json.Delim('{') {
+		return fmt.Errorf("invalid response: unexpected token %v", tok)
+	}
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		switch key, _ := tok.(string); key {
+		case "error":
+			var jerr jsonError
+			if err := dec.Decode(&jerr); err != nil {
+				return err
+			}
+			return &jerr
+		case "result":
+			// The decoder can't copy a value without buffering it, so continue
+			// reading behind its buffer.
+			return copyJSONValue(w, io.MultiReader(dec.Buffered(), r))
+		default:
+			var skip json.RawMessage
+			if err := dec.Decode(&skip); err != nil {
+				return err
+			}
+		}
+	}
+	return ErrNoResult
+}
+
+// copyJSONValue copies the next JSON value from r to w. Leading whitespace and the
+// colon separating the value from its object key are skipped. The value is assumed
+// to be well-formed, only its end is detected.
+func copyJSONValue(w io.Writer, r io.Reader) error {
+	var (
+		br = bufio.NewReader(r)
+		bw = bufio.NewWriterSize(w, streamBufferSize)
+	)
+	c, err := br.ReadByte()
+	for err == nil && (c == ':' || isJSONSpace(c)) {
+		c, err = br.ReadByte()
+	}
+	if err != nil {
+		return err
+	}
+	switch c {
+	case '{', '[', '"':
+		var (
+			depth    int
+			inString bool
+			escaped  bool
+		)
+		for {
+			bw.WriteByte(c)
+			switch {
+			case escaped:
+				escaped = false
+			case inString && c == '\\':
+				escaped = true
+			case inString:
+				inString = c != '"'
+			case c == '"':
+				inString = true
+			case c == '{' || c == '[':
+				depth++
+			case c == '}' || c == ']':
+				depth--
+			}
+			if depth == 0 && !inString {
+				break
+			}
+			if c, err = br.ReadByte(); err != nil {
+				return unexpectedEOF(err)
+			}
+		}
+	default:
+		// Scalar values end at the next delimiter.
+		for {
+			bw.WriteByte(c)
+			next, err := br.Peek(1)
+			if err != nil && !errors.Is(err, io.EOF) {
+				return err
+			}
+			if len(next) == 0 || next[0] == ',' || next[0] == '}' || isJSONSpace(next[0]) {
+				break
+			}
+			c, _ = br.ReadByte()
+		}
+	}
+	return bw.Flush()
+}
+
+func isJSONSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\r' || c == '\n'
+}
+
+func unexpectedEOF(err error) error {
+	if errors.Is(err, io.EOF) {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
@@ -145,6 +145,20 @@ func (n *Notifier) Notify(id ID, data any) error {
 	return nil
 }
 
+// Close ends the subscription from the server side, when its notifications can
+// no longer be delivered. The error is sent on the Err channel of the
+// subscription, which is then closed. The client is not notified.
+func (n *Notifier) Close(err error) {
+	n.mu.Lock()
+	sub := n.sub
+	n.mu.Unlock()
+
+	if sub == nil {
+		panic("can't Close before subscription is created")
+	}
+	n.h.closeServerSubscription(sub, err)
+}
+
 // takeSubscription returns the subscription (if one has been created). No subscription can
 // be created after this call.
 func (n *Notifier) takeSubscription() *Subscription {
@@ -188,6 +202,7 @@ type Subscription struct {
 	ID        ID
 	namespace string
 	err       chan error // closed on unsubscribe
+	closed    bool       // set once err is closed, protected by the handler's subLock
 }
 
 // Err returns a channel that is closed when the client send an unsubscribe request.
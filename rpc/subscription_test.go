@@ -20,6 +20,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"math/big"
@@ -282,3 +283,39 @@ func TestNotify(t *testing.T) {
 		t.Errorf("have:\n%v\nwant:\n%v\n", have, want)
 	}
 }
+
+// Tests that a subscription closed by the server reports the error and is
+// forgotten by the handler, also when closed before being registered.
+func TestNotifierClose(t *testing.T) {
+	t.Parallel()
+
+	var (
+		h        = &handler{serverSubs: make(map[ID]*Subscription)}
+		errDone  = errors.New("done")
+		newNotif = func(id ID) *Notifier {
+			return &Notifier{h: h, sub: &Subscription{ID: id, err: make(chan error, 1)}}
+		}
+	)
+	// Close a registered subscription.
+	n := newNotif("registered")
+	h.addSubscriptions([]*Notifier{n})
+	n.Close(errDone)
+	if err, ok := <-n.sub.Err(); !ok || err != errDone {
+		t.Fatalf("wrong subscription error: %v", err)
+	}
+	if _, ok := <-n.sub.Err(); ok {
+		t.Fatal("error channel not closed")
+	}
+	if _, err := h.unsubscribe(context.Background(), "registered"); err != ErrSubscriptionNotFound {
+		t.Fatalf("closed subscription still registered: %v", err)
+	}
+	n.Close(errDone) // noop
+
+	// Close a subscription before the subscribe call returned.
+	n = newNotif("pending")
+	n.Close(errDone)
+	h.addSubscriptions([]*Notifier{n})
+	if _, err := h.unsubscribe(context.Background(), "pending"); err != ErrSubscriptionNotFound {
+		t.Fatalf("closed subscription registered: %v", err)
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"context"
 	"encoding/base64"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
 	"os"
@@ -55,13 +56,17 @@ func (s *Server) WebsocketHandler(allowedOrigins []string) http.Handler {
 		CheckOrigin:     wsHandshakeValidator(allowedOrigins),
 	}
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if s.drain.active.Load() {
+			http.Error(w, errMsgDraining, http.StatusServiceUnavailable)
+			return
+		}
 		conn, err := upgrader.Upgrade(w, r, nil)
 		if err != nil {
 			log.Debug("WebSocket upgrade failed", "err", err)
 			return
 		}
 		codec := newWebsocketCodec(conn, r.Host, r.Header, s.wsReadLimit)
-		s.ServeCodec(codec, 0)
+		s.serveCodec(context.WithoutCancel(r.Context()), codec)
 	})
 }
 
@@ -296,8 +301,13 @@ func newWebsocketCodec(conn *websocket.Conn, host string, req http.Header, readL
 	encode := func(v interface{}, isErrorResponse bool) error {
 		return conn.WriteJSON(v)
 	}
+	// Streamed results are written as a single message spanning multiple frames.
+	jsonc := NewFuncCodec(conn, encode, conn.ReadJSON).(*jsonCodec)
+	jsonc.streamWriter = func() (io.WriteCloser, error) {
+		return conn.NextWriter(websocket.TextMessage)
+	}
 	wc := &websocketCodec{
-		jsonCodec:    NewFuncCodec(conn, encode, conn.ReadJSON).(*jsonCodec),
+		jsonCodec:    jsonc,
 		conn:         conn,
 		pingReset:    make(chan struct{}, 1),
 		pongReceived: make(chan struct{}),
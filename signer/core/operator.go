@@ -0,0 +1,203 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+// operatorABI contains the methods of the rollup L1 contracts which are called
+// by operator accounts.
+const operatorABI = `[
+	{"type":"function","name":"setGasConfig","inputs":[{"name":"_overhead","type":"uint256"},{"name":"_scalar","type":"uint256"}]},
+	{"type":"function","name":"setGasConfigEcotone","inputs":[{"name":"_basefeeScalar","type":"uint32"},{"name":"_blobbasefeeScalar","type":"uint32"}]},
+	{"type":"function","name":"setGasLimit","inputs":[{"name":"_gasLimit","type":"uint64"}]},
+	{"type":"function","name":"setBatcherHash","inputs":[{"name":"_batcherHash","type":"bytes32"}]},
+	{"type":"function","name":"setUnsafeBlockSigner","inputs":[{"name":"_unsafeBlockSigner","type":"address"}]},
+	{"type":"function","name":"depositTransaction","inputs":[{"name":"_to","type":"address"},{"name":"_value","type":"uint256"},{"name":"_gasLimit","type":"uint64"},{"name":"_isCreation","type":"bool"},{"name":"_data","type":"bytes"}]}
+]`
+
+var operatorMethods = mustParseABI(operatorABI)
+
+func mustParseABI(def string) abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(def))
+	if err != nil {
+		panic(err)
+	}
+	return parsed
+}
+
+// OperatorContracts are the L1 addresses of a rollup deployment that the signer
+// reviews transactions to. Unset addresses are not checked.
+type OperatorContracts struct {
+	BatchInbox   common.Address // Receives batch submissions
+	Batcher      common.Address // Expected sender of batch submissions
+	SystemConfig common.Address // Holds the fee scalars and gas limit of the rollup
+	Portal       common.Address // Accepts deposits to the rollup
+}
+
+// operatorValidator extends a Validator with human-readable review messages for
+// rollup operator transactions: batch submissions, system configuration updates
+// and deposits.
+type operatorValidator struct {
+	Validator
+	contracts OperatorContracts
+}
+
+// NewOperatorValidator wraps the given validator, adding review messages for the
+// transactions sent to the given rollup contracts. The messages are shown to the
+// user and passed to the rule engine as part of the call info.
+func NewOperatorValidator(validator Validator, contracts OperatorContracts) Validator {
+	return &operatorValidator{validator, contracts}
+}
+
+// ValidateTransaction runs the wrapped validation and describes the transaction if
+// it is addressed to one of the rollup contracts.
+func (v *operatorValidator) ValidateTransaction(selector *string, tx *apitypes.SendTxArgs) (*apitypes.ValidationMessages, error) {
+	messages, err := v.Validator.ValidateTransaction(selector, tx)
+	if err != nil || tx.To == nil {
+		return messages, err
+	}
+	var data []byte
+	if tx.Input != nil {
+		data = *tx.Input
+	} else if tx.Data != nil {
+		data = *tx.Data
+	}
+	switch to := tx.To.Address(); to {
+	case common.Address{}:
+	case v.contracts.BatchInbox:
+		v.reviewBatch(tx, data, messages)
+	case v.contracts.SystemConfig:
+		reviewSystemConfig(data, messages)
+	case v.contracts.Portal:
+		reviewDeposit(tx, data, messages)
+	}
+	return messages, nil
+}
+
+// reviewBatch describes a batch submission to the batch inbox.
+func (v *operatorValidator) reviewBatch(tx *apitypes.SendTxArgs, data []byte, messages *apitypes.ValidationMessages) {
+	if v.contracts.Batcher != (common.Address{}) && tx.From.Address() != v.contracts.Batcher {
+		messages.Crit(fmt.Sprintf("Batch submission is not sent by the configured batcher %v", v.contracts.Batcher))
+	}
+	if tx.Value.ToInt().Sign() > 0 {
+		messages.Crit("Batch submission transfers value to the batch inbox")
+	}
+	switch blobs := len(tx.BlobHashes); {
+	case blobs > 0:
+		messages.Info(fmt.Sprintf("Batch submission carrying %d blob(s)", blobs))
+		if len(data) > 0 {
+			messages.Warn("Blob batch submission also carries calldata, which is ignored by the derivation")
+		}
+	case len(data) == 0:
+		messages.Warn("Batch submission without any batch data")
+	case data[0] != 0:
+		messages.Warn(fmt.Sprintf("Batch submission with unknown derivation version %d", data[0]))
+	default:
+		messages.Info(fmt.Sprintf("Batch submission carrying %d bytes of frame data in calldata", len(data)-1))
+	}
+}
+
+// reviewSystemConfig describes an update of the rollup system configuration.
+func reviewSystemConfig(data []byte, messages *apitypes.ValidationMessages) {
+	method, args, ok := unpackOperatorCall(data, messages)
+	if !ok {
+		return
+	}
+	switch method.Name {
+	case "setGasConfig":
+		messages.Info(fmt.Sprintf("Update fee parameters: overhead %v, scalar %v", args[0], args[1]))
+	case "setGasConfigEcotone":
+		base, blob := args[0].(uint32), args[1].(uint32)
+		messages.Info(fmt.Sprintf("Update fee scalars: base fee scalar %d, blob base fee scalar %d", base, blob))
+		if base == 0 && blob == 0 {
+			messages.Crit("Fee scalar update sets both scalars to zero, removing the L1 data fee")
+		}
+	case "setGasLimit":
+		messages.Info(fmt.Sprintf("Update rollup block gas limit to %d", args[0].(uint64)))
+	case "setBatcherHash":
+		hash := args[0].([32]byte)
+		messages.Warn(fmt.Sprintf("Change batcher to %v", common.BytesToAddress(hash[:])))
+	case "setUnsafeBlockSigner":
+		messages.Warn(fmt.Sprintf("Change unsafe block signer to %v", args[0].(common.Address)))
+	default:
+		messages.Warn(fmt.Sprintf("Unexpected system config method %q", method.Name))
+	}
+}
+
+// reviewDeposit describes a deposit transaction into the rollup.
+func reviewDeposit(tx *apitypes.SendTxArgs, data []byte, messages *apitypes.ValidationMessages) {
+	mint := tx.Value.ToInt()
+	if len(data) == 0 {
+		messages.Info(fmt.Sprintf("Deposit of %v wei to the sender address on L2", mint))
+		return
+	}
+	method, args, ok := unpackOperatorCall(data, messages)
+	if !ok {
+		return
+	}
+	if method.Name != "depositTransaction" {
+		messages.Warn(fmt.Sprintf("Unexpected portal method %q", method.Name))
+		return
+	}
+	var (
+		to       = args[0].(common.Address)
+		value    = args[1].(*big.Int)
+		gasLimit = args[2].(uint64)
+		creation = args[3].(bool)
+		calldata = args[4].([]byte)
+	)
+	if creation {
+		messages.Info(fmt.Sprintf("Deposit creating a contract on L2: mint %v wei, value %v wei, gas limit %d, %d bytes of init code", mint, value, gasLimit, len(calldata)))
+		if to != (common.Address{}) {
+			messages.Crit("Contract creation deposit with a non-zero recipient will revert")
+		}
+	} else {
+		messages.Info(fmt.Sprintf("Deposit to %v on L2: mint %v wei, value %v wei, gas limit %d, %d bytes of calldata", to, mint, value, gasLimit, len(calldata)))
+	}
+	// The portal requires enough gas to pay for the calldata of the deposit.
+	if minGas := params.TxGas + uint64(len(calldata))*params.TxDataNonZeroGasEIP2028; gasLimit < minGas {
+		messages.Crit(fmt.Sprintf("Deposit gas limit %d is below the minimum of %d and will revert", gasLimit, minGas))
+	}
+}
+
+// unpackOperatorCall decodes the calldata of an operator contract call.
+func unpackOperatorCall(data []byte, messages *apitypes.ValidationMessages) (*abi.Method, []interface{}, bool) {
+	if len(data) < 4 {
+		messages.Warn("Operator contract call without method selector")
+		return nil, nil, false
+	}
+	method, err := operatorMethods.MethodById(data[:4])
+	if err != nil {
+		messages.Warn(fmt.Sprintf("Unknown operator contract method %#x", data[:4]))
+		return nil, nil, false
+	}
+	args, err := method.Inputs.Unpack(data[4:])
+	if err != nil {
+		messages.Crit(fmt.Sprintf("Invalid arguments for %s: %v", method.Name, err))
+		return nil, nil, false
+	}
+	return method, args, true
+}
@@ -0,0 +1,102 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+type nopValidator struct{}
+
+func (nopValidator) ValidateTransaction(selector *string, tx *apitypes.SendTxArgs) (*apitypes.ValidationMessages, error) {
+	return new(apitypes.ValidationMessages), nil
+}
+
+func TestOperatorValidator(t *testing.T) {
+	var (
+		contracts = OperatorContracts{
+			BatchInbox:   common.HexToAddress("0xff00000000000000000000000000000000000010"),
+			Batcher:      common.HexToAddress("0x6887246668a3b87f54deb3b94ba47a6f63f32985"),
+			SystemConfig: common.HexToAddress("0x229047fed2591dbec1ef1118d64f7af3db9eb290"),
+			Portal:       common.HexToAddress("0xbeb5fc579115071764c7423a4f12edde41f106ed"),
+		}
+		validator = NewOperatorValidator(nopValidator{}, contracts)
+		other     = common.HexToAddress("0x1234")
+	)
+	pack := func(method string, args ...interface{}) []byte {
+		data, err := operatorMethods.Pack(method, args...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return data
+	}
+	tests := []struct {
+		from  common.Address
+		to    common.Address
+		value int64
+		data  []byte
+		blobs int
+		typ   string // type of the expected message
+		msg   string // substring of the expected message
+	}{
+		// Batch submissions
+		{from: contracts.Batcher, to: contracts.BatchInbox, data: []byte{0, 1, 2, 3}, typ: apitypes.INFO, msg: "3 bytes of frame data"},
+		{from: contracts.Batcher, to: contracts.BatchInbox, blobs: 2, typ: apitypes.INFO, msg: "2 blob(s)"},
+		{from: contracts.Batcher, to: contracts.BatchInbox, data: []byte{1, 2}, typ: apitypes.WARN, msg: "unknown derivation version 1"},
+		{from: other, to: contracts.BatchInbox, data: []byte{0, 1}, typ: apitypes.CRIT, msg: "not sent by the configured batcher"},
+		// System configuration updates
+		{from: other, to: contracts.SystemConfig, data: pack("setGasConfigEcotone", uint32(1368), uint32(810949)), typ: apitypes.INFO, msg: "base fee scalar 1368, blob base fee scalar 810949"},
+		{from: other, to: contracts.SystemConfig, data: pack("setGasConfigEcotone", uint32(0), uint32(0)), typ: apitypes.CRIT, msg: "both scalars to zero"},
+		{from: other, to: contracts.SystemConfig, data: pack("setUnsafeBlockSigner", other), typ: apitypes.WARN, msg: "unsafe block signer"},
+		{from: other, to: contracts.SystemConfig, data: []byte{1, 2, 3, 4}, typ: apitypes.WARN, msg: "Unknown operator contract method"},
+		// Deposits
+		{from: other, to: contracts.Portal, value: 100, typ: apitypes.INFO, msg: "Deposit of 100 wei"},
+		{from: other, to: contracts.Portal, value: 5, data: pack("depositTransaction", other, big.NewInt(1), uint64(100_000), false, []byte{}), typ: apitypes.INFO, msg: "mint 5 wei, value 1 wei, gas limit 100000"},
+		{from: other, to: contracts.Portal, data: pack("depositTransaction", other, big.NewInt(0), uint64(20_000), false, []byte{}), typ: apitypes.CRIT, msg: "below the minimum"},
+		{from: other, to: contracts.Portal, data: pack("depositTransaction", other, big.NewInt(0), uint64(100_000), true, []byte{1}), typ: apitypes.CRIT, msg: "non-zero recipient"},
+	}
+	for i, test := range tests {
+		var (
+			from = common.NewMixedcaseAddress(test.from)
+			to   = common.NewMixedcaseAddress(test.to)
+			data = hexutil.Bytes(test.data)
+			args = &apitypes.SendTxArgs{From: from, To: &to, Value: hexutil.Big(*big.NewInt(test.value)), Data: &data}
+		)
+		for j := 0; j < test.blobs; j++ {
+			args.BlobHashes = append(args.BlobHashes, common.Hash{0x01, byte(j)})
+		}
+		msgs, err := validator.ValidateTransaction(nil, args)
+		if err != nil {
+			t.Fatalf("test %d: %v", i, err)
+		}
+		var found bool
+		for _, msg := range msgs.Messages {
+			if msg.Typ == test.typ && strings.Contains(msg.Message, test.msg) {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("test %d: missing %s message containing %q, have %v", i, test.typ, test.msg, msgs.Messages)
+		}
+	}
+}
@@ -64,6 +64,7 @@ type btJSON struct {
 	BestBlock  common.UnprefixedHash `json:"lastblockhash"`
 	Network    string                `json:"network"`
 	SealEngine string                `json:"sealEngine"`
+	Config     *fixtureConfig        `json:"config"`
 }
 
 type btBlock struct {
@@ -116,6 +117,10 @@ func (t *BlockTest) Run(snapshotter bool, scheme string, witness bool, tracer *t
 	if !ok {
 		return UnsupportedForkError{t.json.Network}
 	}
+	config, err := t.json.Config.apply(config)
+	if err != nil {
+		return err
+	}
 
 	// import pre accounts & construct test genesis block & state root
 	// Commit genesis state
@@ -0,0 +1,102 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package tests
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// fixtureConfig is the config section of execution-spec-tests fixtures. It
+// carries the chain parameters a fixture was filled with that do not follow
+// from the fork name alone.
+type fixtureConfig struct {
+	Network      string                        `json:"network"`
+	ChainID      *math.HexOrDecimal256         `json:"chainid"`
+	BlobSchedule map[string]*fixtureBlobConfig `json:"blobSchedule"`
+}
+
+type fixtureBlobConfig struct {
+	Target         math.HexOrDecimal64 `json:"target"`
+	Max            math.HexOrDecimal64 `json:"max"`
+	UpdateFraction math.HexOrDecimal64 `json:"baseFeeUpdateFraction"`
+}
+
+// apply returns a copy of the given chain config, with the parameters of the
+// fixture config overriding the ones of the named fork. The input config is
+// shared between tests and is never modified.
+func (c *fixtureConfig) apply(config *params.ChainConfig) (*params.ChainConfig, error) {
+	if c == nil {
+		return config, nil
+	}
+	cpy := *config
+	if c.ChainID != nil {
+		cpy.ChainID = new(big.Int).Set((*big.Int)(c.ChainID))
+	}
+	if len(c.BlobSchedule) > 0 {
+		schedule := new(params.BlobScheduleConfig)
+		if config.BlobScheduleConfig != nil {
+			*schedule = *config.BlobScheduleConfig
+		}
+		for fork, bc := range c.BlobSchedule {
+			field, err := blobScheduleField(schedule, fork)
+			if err != nil {
+				return nil, err
+			}
+			*field = &params.BlobConfig{
+				Target:         int(bc.Target),
+				Max:            int(bc.Max),
+				UpdateFraction: uint64(bc.UpdateFraction),
+			}
+		}
+		cpy.BlobScheduleConfig = schedule
+	}
+	return &cpy, nil
+}
+
+// blobScheduleField returns the entry of the blob schedule belonging to the
+// named fork. Fixtures spell fork names capitalized, the schedule is matched
+// case-insensitively.
+func blobScheduleField(schedule *params.BlobScheduleConfig, fork string) (**params.BlobConfig, error) {
+	switch strings.ToLower(fork) {
+	case "cancun":
+		return &schedule.Cancun, nil
+	case "prague":
+		return &schedule.Prague, nil
+	case "osaka":
+		return &schedule.Osaka, nil
+	case "verkle":
+		return &schedule.Verkle, nil
+	case "bpo1":
+		return &schedule.BPO1, nil
+	case "bpo2":
+		return &schedule.BPO2, nil
+	case "bpo3":
+		return &schedule.BPO3, nil
+	case "bpo4":
+		return &schedule.BPO4, nil
+	case "bpo5":
+		return &schedule.BPO5, nil
+	case "amsterdam":
+		return &schedule.Amsterdam, nil
+	}
+	return nil, fmt.Errorf("unsupported fork %q in blob schedule", fork)
+}
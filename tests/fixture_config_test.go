@@ -0,0 +1,62 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package tests
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func TestFixtureConfigApply(t *testing.T) {
+	var fc fixtureConfig
+	err := json.Unmarshal([]byte(`{
+		"network": "Prague",
+		"chainid": "0x0a",
+		"blobSchedule": {
+			"Prague": {"target": "0x04", "max": "0x08", "baseFeeUpdateFraction": "0x4c6964"}
+		}
+	}`), &fc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	base := Forks["Prague"]
+	config, err := fc.apply(base)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if config.ChainID.Uint64() != 10 {
+		t.Errorf("chain id mismatch: have %v, want 10", config.ChainID)
+	}
+	want := params.BlobConfig{Target: 4, Max: 8, UpdateFraction: 5007716}
+	if have := *config.BlobScheduleConfig.Prague; have != want {
+		t.Errorf("prague blob config mismatch: have %v, want %v", have, want)
+	}
+	if config.BlobScheduleConfig.Cancun != base.BlobScheduleConfig.Cancun {
+		t.Error("cancun blob config not inherited from the fork")
+	}
+	// The shared fork definition must be left untouched
+	if base.ChainID.Uint64() != 1 || *base.BlobScheduleConfig.Prague != *params.DefaultPragueBlobConfig {
+		t.Error("fork config modified by fixture config")
+	}
+	// Unknown forks in the blob schedule are rejected
+	fc.BlobSchedule["Shanghai"] = fc.BlobSchedule["Prague"]
+	if _, err := fc.apply(base); err == nil {
+		t.Error("expected error for unknown blob schedule fork")
+	}
+}
@@ -254,6 +254,10 @@ func runBenchmark(b *testing.B, t *StateTest) {
 				b.Error(err)
 				return
 			}
+			if config, err = t.json.Config.apply(config); err != nil {
+				b.Error(err)
+				return
+			}
 			var rules = config.Rules(new(big.Int), false, 0)
 
 			vmconfig.ExtraEips = eips
@@ -294,7 +298,11 @@ func runBenchmark(b *testing.B, t *StateTest) {
 
 			// Prepare the EVM.
 			txContext := core.NewEVMTxContext(msg)
-			context := core.NewEVMBlockContext(block.Header(), &dummyChain{config: config}, &t.json.Env.Coinbase)
+			context, err := core.NewEVMBlockContext(block.Header(), &dummyChain{config: config}, &t.json.Env.Coinbase)
+			if err != nil {
+				b.Error(err)
+				return
+			}
 			context.GetHash = vmTestBlockHash
 			context.BaseFee = baseFee
 			evm := vm.NewEVM(context, state.StateDB, config, vmconfig)
@@ -70,6 +70,8 @@ type stJSON struct {
 	Tx   stTransaction            `json:"transaction"`
 	Out  hexutil.Bytes            `json:"out"`
 	Post map[string][]stPostState `json:"post"`
+
+	Config *fixtureConfig `json:"config"`
 }
 
 type stPostState struct {
@@ -256,6 +258,9 @@ func (t *StateTest) RunNoVerify(subtest StateSubtest, vmconfig vm.Config, snapsh
 	if err != nil {
 		return st, common.Hash{}, 0, UnsupportedForkError{subtest.Fork}
 	}
+	if config, err = t.json.Config.apply(config); err != nil {
+		return st, common.Hash{}, 0, err
+	}
 	vmconfig.ExtraEips = eips
 
 	block := t.genesis(config).ToBlock()
@@ -301,7 +306,10 @@ func (t *StateTest) RunNoVerify(subtest StateSubtest, vmconfig vm.Config, snapsh
 	}
 
 	// Prepare the EVM.
-	context := core.NewEVMBlockContext(block.Header(), &dummyChain{config: config}, &t.json.Env.Coinbase)
+	context, err := core.NewEVMBlockContext(block.Header(), &dummyChain{config: config}, &t.json.Env.Coinbase)
+	if err != nil {
+		return st, common.Hash{}, 0, err
+	}
 	context.GetHash = vmTestBlockHash
 	context.BaseFee = baseFee
 	context.Random = nil
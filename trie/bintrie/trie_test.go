@@ -22,6 +22,9 @@ import (
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/triedb/database"
+	"github.com/holiman/uint256"
 )
 
 var (
@@ -195,3 +198,64 @@ func TestMerkleizeMultipleEntries(t *testing.T) {
 		t.Fatalf("invalid root, expected=%x, got = %x", expected, got)
 	}
 }
+
+// testNodeDatabase holds the nodes of a committed binary trie, keyed by path.
+type testNodeDatabase map[string][]byte
+
+func (db testNodeDatabase) NodeReader(common.Hash) (database.NodeReader, error) {
+	return db, nil
+}
+
+func (db testNodeDatabase) Node(_ common.Hash, path []byte, _ common.Hash) ([]byte, error) {
+	return db[string(path)], nil
+}
+
+func TestWitness(t *testing.T) {
+	tr, err := NewBinaryTrie(types.EmptyBinaryHash, testNodeDatabase{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	addrs := []common.Address{{0x01}, {0x02}, {0x03}, {0x04}}
+	for i, addr := range addrs {
+		acc := &types.StateAccount{Nonce: uint64(i), Balance: uint256.NewInt(1), CodeHash: types.EmptyCodeHash.Bytes()}
+		if err := tr.UpdateAccount(addr, acc, 0); err != nil {
+			t.Fatal(err)
+		}
+	}
+	root, nodes := tr.Commit(false)
+	db := make(testNodeDatabase)
+	for path, n := range nodes.Nodes {
+		db[path] = n.Blob
+	}
+	if tr, err = NewBinaryTrie(root, db); err != nil {
+		t.Fatal(err)
+	}
+	// Only the root is resolved when opening the trie.
+	if witness := tr.Witness(); len(witness) != 1 || !bytes.Equal(witness[""], db[""]) {
+		t.Fatalf("wrong witness of the unaccessed trie: %x", witness)
+	}
+	acc, err := tr.GetAccount(addrs[2])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if acc == nil || acc.Nonce != 2 {
+		t.Fatalf("wrong account: %+v", acc)
+	}
+	witness := tr.Witness()
+	if len(witness) < 2 || len(witness) >= len(db) {
+		t.Fatalf("wrong witness size: have %d nodes, trie has %d", len(witness), len(db))
+	}
+	for path, blob := range witness {
+		if !bytes.Equal(blob, db[path]) {
+			t.Errorf("wrong witness node at path %x", path)
+		}
+	}
+	// The witness of a copy is independent of the original.
+	cpy := tr.Copy()
+	if _, err := cpy.GetAccount(addrs[0]); err != nil {
+		t.Fatal(err)
+	}
+	if len(tr.Witness()) != len(witness) {
+		t.Fatal("witness of the original trie changed by its copy")
+	}
+}
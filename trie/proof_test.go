@@ -27,6 +27,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethdb/memorydb"
 )
@@ -198,6 +199,53 @@ func TestRangeProof(t *testing.T) {
 	}
 }
 
+// TestProveRange tests the construction and verification of the range proofs
+// of consecutive trie entries.
+func TestProveRange(t *testing.T) {
+	trie, vals := randomTrie(4096)
+	var entries []*kv
+	for _, kv := range vals {
+		entries = append(entries, kv)
+	}
+	slices.SortFunc(entries, (*kv).cmp)
+
+	root := trie.Hash()
+	for i := 0; i < 100; i++ {
+		start := mrand.Intn(len(entries))
+		limit := mrand.Intn(100) + 1
+
+		proof, err := trie.ProveRange(entries[start].k, limit)
+		if err != nil {
+			t.Fatalf("Case %d: failed to prove range: %v", i, err)
+		}
+		want := min(limit, len(entries)-start)
+		if len(proof.Keys) != want {
+			t.Fatalf("Case %d: wrong range length: have %d, want %d", i, len(proof.Keys), want)
+		}
+		more, err := proof.Verify(root)
+		if err != nil {
+			t.Fatalf("Case %d: failed to verify range: %v", i, err)
+		}
+		if more != (start+want < len(entries)) {
+			t.Fatalf("Case %d: wrong continuation flag: have %v", i, more)
+		}
+		// Tampering with the range must be detected
+		proof.Values[0] = append(common.CopyBytes(proof.Values[0]), 0x01)
+		if _, err := proof.Verify(root); err == nil {
+			t.Fatalf("Case %d: tampered range verified", i)
+		}
+	}
+	// The range proof of an empty trie is empty
+	empty := NewEmpty(newTestDatabase(rawdb.NewMemoryDatabase(), rawdb.HashScheme))
+	proof, err := empty.ProveRange(make([]byte, 32), 10)
+	if err != nil {
+		t.Fatalf("Failed to prove empty range: %v", err)
+	}
+	if more, err := proof.Verify(types.EmptyRootHash); err != nil || more {
+		t.Fatalf("Failed to verify empty range: %v %v", more, err)
+	}
+}
+
 // TestRangeProofWithNonExistentProof tests normal range proof with two non-existent proofs.
 // The test cases are generated randomly.
 func TestRangeProofWithNonExistentProof(t *testing.T) {
@@ -0,0 +1,94 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package trie
+
+import (
+	"errors"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/trie/trienode"
+)
+
+// RangeProof is a Merkle proof of a contiguous range of trie entries, in the
+// format used by snap sync. It proves that the trie holds exactly the given
+// entries between the origin and the last key of the range.
+type RangeProof struct {
+	Origin []byte   // Key the range starts at, proven even if absent from the trie
+	Keys   [][]byte // Keys of the entries in the range, in ascending order
+	Values [][]byte // Values of the entries in the range
+	Proof  [][]byte // Nodes proving the boundaries of the range, nil for an empty trie
+}
+
+// ProveRange collects at most limit consecutive entries of the trie, starting
+// at the origin key, and constructs the proof of their range.
+func (t *Trie) ProveRange(origin []byte, limit int) (*RangeProof, error) {
+	if limit <= 0 {
+		return nil, errors.New("non-positive range limit")
+	}
+	result := &RangeProof{Origin: common.CopyBytes(origin)}
+	if t.Hash() == types.EmptyRootHash {
+		return result, nil
+	}
+	nodeIt, err := t.NodeIterator(origin)
+	if err != nil {
+		return nil, err
+	}
+	it := NewIterator(nodeIt)
+	for len(result.Keys) < limit && it.Next() {
+		result.Keys = append(result.Keys, common.CopyBytes(it.Key))
+		result.Values = append(result.Values, common.CopyBytes(it.Value))
+	}
+	if it.Err != nil {
+		return nil, it.Err
+	}
+	// Prove the origin and the last key of the range
+	proof := trienode.NewProofSet()
+	if err := t.Prove(origin, proof); err != nil {
+		return nil, err
+	}
+	if len(result.Keys) > 0 {
+		if err := t.Prove(result.Keys[len(result.Keys)-1], proof); err != nil {
+			return nil, err
+		}
+	}
+	result.Proof = proof.List()
+	return result, nil
+}
+
+// ProveRange collects at most limit consecutive entries of the trie, starting
+// at the origin key, and constructs the proof of their range. Note the keys of
+// the range are the hashed keys of the trie.
+func (t *StateTrie) ProveRange(origin []byte, limit int) (*RangeProof, error) {
+	return t.trie.ProveRange(origin, limit)
+}
+
+// Verify checks the range proof against the given trie root, returning whether
+// the trie holds more entries beyond the range.
+func (p *RangeProof) Verify(root common.Hash) (bool, error) {
+	var proof ethdb.KeyValueReader
+	if p.Proof != nil {
+		set := trienode.NewProofSet()
+		for _, node := range p.Proof {
+			set.Put(crypto.Keccak256(node), node)
+		}
+		proof = set
+	}
+	return VerifyRangeProof(root, p.Origin, p.Keys, p.Values, proof)
+}
@@ -231,7 +231,18 @@ func (t *TransitionTrie) UpdateContractCode(addr common.Address, codeHash common
 	return t.overlay.UpdateContractCode(addr, codeHash, code)
 }
 
-// Witness returns a set containing all trie nodes that have been accessed.
+// Witness returns a set containing all trie nodes that have been accessed, in
+// both the overlay and the base trie. The node paths of the two tries overlap,
+// so the set is keyed by the node blobs instead.
 func (t *TransitionTrie) Witness() map[string][]byte {
-	panic("not implemented")
+	witness := make(map[string][]byte)
+	for _, blob := range t.overlay.Witness() {
+		witness[string(blob)] = blob
+	}
+	if t.base != nil {
+		for _, blob := range t.base.Witness() {
+			witness[string(blob)] = blob
+		}
+	}
+	return witness
 }
@@ -28,6 +28,9 @@ const (
 	// defaultTrieCleanSize is the default memory allowance of clean trie cache.
 	defaultTrieCleanSize = 16 * 1024 * 1024
 
+	// defaultTrieHotSize is the default memory allowance of the hot trie node cache.
+	defaultTrieHotSize = 8 * 1024 * 1024
+
 	// defaultStateCleanSize is the default memory allowance of clean state cache.
 	defaultStateCleanSize = 16 * 1024 * 1024
 
@@ -55,6 +58,7 @@ var Defaults = &Config{
 	StateHistory:        params.FullImmutabilityThreshold,
 	EnableStateIndexing: false,
 	TrieCleanSize:       defaultTrieCleanSize,
+	TrieHotSize:         defaultTrieHotSize,
 	StateCleanSize:      defaultStateCleanSize,
 	WriteBufferSize:     defaultBufferSize,
 }
@@ -71,6 +75,7 @@ type Config struct {
 	StateHistory        uint64 // Number of recent blocks to maintain state history for, 0: full chain
 	EnableStateIndexing bool   // Whether to enable state history indexing for external state access
 	TrieCleanSize       int    // Maximum memory allowance (in bytes) for caching clean trie data
+	TrieHotSize         int    // Maximum memory allowance (in bytes) for caching hashed shallow trie nodes, 0: disabled
 	StateCleanSize      int    // Maximum memory allowance (in bytes) for caching clean state data
 	WriteBufferSize     int    // Maximum memory allowance (in bytes) for write buffer
 	ReadOnly            bool   // Flag whether the database is opened in read only mode
@@ -100,6 +105,9 @@ func (c *Config) fields() []interface{} {
 		list = append(list, "readonly", true)
 	}
 	list = append(list, "triecache", common.StorageSize(c.TrieCleanSize))
+	if c.TrieHotSize > 0 {
+		list = append(list, "triehotcache", common.StorageSize(c.TrieHotSize))
+	}
 	list = append(list, "statecache", common.StorageSize(c.StateCleanSize))
 	list = append(list, "buffer", common.StorageSize(c.WriteBufferSize))
 
@@ -130,9 +130,10 @@ type Database struct {
 	isVerkle bool       // Flag if database is used for verkle tree
 	hasher   nodeHasher // Trie node hasher
 
-	config *Config        // Configuration for database
-	diskdb ethdb.Database // Persistent storage for matured trie nodes
-	tree   *layerTree     // The group for all known layers
+	config   *Config        // Configuration for database
+	diskdb   ethdb.Database // Persistent storage for matured trie nodes
+	tree     *layerTree     // The group for all known layers
+	hotNodes *hotNodeCache  // Cache of the shallow disk layer nodes with their hashes, nil if disabled
 
 	stateFreezer ethdb.ResettableAncientStore // Freezer for storing state histories, nil possible in tests
 	stateIndexer *historyIndexer              // History indexer historical state data, nil possible
@@ -156,6 +157,9 @@ func New(diskdb ethdb.Database, config *Config, isVerkle bool) *Database {
 		diskdb:   diskdb,
 		hasher:   merkleNodeHasher,
 	}
+	if config.TrieHotSize > 0 {
+		db.hotNodes = newHotNodeCache(config.TrieHotSize)
+	}
 	// Establish a dedicated database namespace tailored for verkle-specific
 	// data, ensuring the isolation of both verkle and merkle tree data. It's
 	// important to note that the introduction of a prefix won't lead to
@@ -134,13 +134,26 @@ func (dl *diskLayer) node(owner common.Hash, path []byte, depth int) ([]byte, co
 	}
 	dirtyNodeMissMeter.Mark(1)
 
+	// Try to retrieve the shallow trie nodes from the hot cache, which saves
+	// hashing them again
+	hot := dl.db.hotNodes
+	if hot != nil {
+		if blob, hash, found := hot.get(owner, path); found {
+			return blob, hash, &nodeLoc{loc: locHotCache, depth: depth}, nil
+		}
+	}
 	// Try to retrieve the trie node from the clean memory cache
 	key := nodeCacheKey(owner, path)
 	if dl.nodes != nil {
 		if blob := dl.nodes.Get(nil, key); len(blob) > 0 {
 			cleanNodeHitMeter.Mark(1)
 			cleanNodeReadMeter.Mark(int64(len(blob)))
-			return blob, crypto.Keccak256Hash(blob), &nodeLoc{loc: locCleanCache, depth: depth}, nil
+
+			hash := crypto.Keccak256Hash(blob)
+			if hot != nil {
+				hot.set(owner, path, blob, hash)
+			}
+			return blob, hash, &nodeLoc{loc: locCleanCache, depth: depth}, nil
 		}
 		cleanNodeMissMeter.Mark(1)
 	}
@@ -160,7 +173,11 @@ func (dl *diskLayer) node(owner common.Hash, path []byte, depth int) ([]byte, co
 		dl.nodes.Set(key, blob)
 		cleanNodeWriteMeter.Mark(int64(len(blob)))
 	}
-	return blob, crypto.Keccak256Hash(blob), &nodeLoc{loc: locDiskLayer, depth: depth}, nil
+	hash := crypto.Keccak256Hash(blob)
+	if hot != nil {
+		hot.set(owner, path, blob, hash)
+	}
+	return blob, hash, &nodeLoc{loc: locDiskLayer, depth: depth}, nil
 }
 
 // account directly retrieves the account RLP associated with a particular
@@ -403,6 +420,11 @@ func (dl *diskLayer) commit(bottom *diffLayer, force bool) (*diskLayer, error) {
 	// Mark the diskLayer as stale before applying any mutations on top.
 	dl.stale = true
 
+	// Drop the hot nodes about to be modified, the buffer shadows them until
+	// the modifications are flushed.
+	if dl.db.hotNodes != nil {
+		dl.db.hotNodes.invalidate(bottom.nodes.nodeSet)
+	}
 	// Store the root->id lookup afterwards. All stored lookups are identified
 	// by the **unique** state root. It's impossible that in the same chain
 	// blocks are not adjacent but have the same root.
@@ -504,6 +526,10 @@ func (dl *diskLayer) revert(h *stateHistory) (*diskLayer, error) {
 
 	dl.stale = true
 
+	// Reverts are rare, simply drop all the hot nodes
+	if dl.db.hotNodes != nil {
+		dl.db.hotNodes.reset()
+	}
 	// Unindex the corresponding state history
 	if dl.db.stateIndexer != nil {
 		if err := dl.db.stateIndexer.shorten(dl.id); err != nil {
@@ -594,6 +620,9 @@ func (dl *diskLayer) resetCache() {
 	if dl.states != nil {
 		dl.states.Reset()
 	}
+	if dl.db.hotNodes != nil {
+		dl.db.hotNodes.reset()
+	}
 }
 
 // genMarker returns the current state snapshot generation progress marker. If
@@ -24,6 +24,11 @@ var (
 	cleanNodeReadMeter  = metrics.NewRegisteredMeter("pathdb/clean/node/read", nil)
 	cleanNodeWriteMeter = metrics.NewRegisteredMeter("pathdb/clean/node/write", nil)
 
+	hotNodeHitMeter   = metrics.NewRegisteredMeter("pathdb/hot/node/hit", nil)
+	hotNodeMissMeter  = metrics.NewRegisteredMeter("pathdb/hot/node/miss", nil)
+	hotNodeWriteMeter = metrics.NewRegisteredMeter("pathdb/hot/node/write", nil)
+	hotNodeSizeGauge  = metrics.NewRegisteredGauge("pathdb/hot/node/size", nil)
+
 	cleanStateHitMeter   = metrics.NewRegisteredMeter("pathdb/clean/state/hit", nil)
 	cleanStateMissMeter  = metrics.NewRegisteredMeter("pathdb/clean/state/miss", nil)
 	cleanStateReadMeter  = metrics.NewRegisteredMeter("pathdb/clean/state/read", nil)
@@ -52,6 +57,7 @@ var (
 	dirtyStateHitDepthHist = metrics.NewRegisteredHistogram("pathdb/dirty/state/depth", nil, metrics.NewExpDecaySample(1028, 0.015))
 
 	nodeCleanFalseMeter = metrics.NewRegisteredMeter("pathdb/clean/false", nil)
+	nodeHotFalseMeter   = metrics.NewRegisteredMeter("pathdb/hot/false", nil)
 	nodeDirtyFalseMeter = metrics.NewRegisteredMeter("pathdb/dirty/false", nil)
 	nodeDiskFalseMeter  = metrics.NewRegisteredMeter("pathdb/disk/false", nil)
 	nodeDiffFalseMeter  = metrics.NewRegisteredMeter("pathdb/diff/false", nil)
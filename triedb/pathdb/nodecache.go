@@ -0,0 +1,147 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package pathdb
+
+import (
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/lru"
+)
+
+// hotNodeMaxDepth is the maximum path length of the trie nodes tracked by the
+// hot node cache. The shallow nodes are resolved by nearly every state access
+// of the block execution.
+const hotNodeMaxDepth = 6
+
+// hotNodeOverhead is the approximate memory overhead of a cached node, on top
+// of the path and the blob.
+const hotNodeOverhead = 128
+
+// hotNode is a trie node held by the hot node cache along with its hash.
+type hotNode struct {
+	blob []byte
+	hash common.Hash
+}
+
+// hotNodeCache is a path-keyed cache of the shallow trie nodes of the disk layer
+// along with their hashes. Unlike the clean cache, which only holds the node
+// blobs, it saves rehashing the nodes which are read repeatedly block after
+// block.
+//
+// The cache mirrors the content of the disk layer below its write buffers: an
+// entry must be invalidated before the associated path is modified in the
+// buffer, which shadows the stale entry until it's flushed.
+type hotNodeCache struct {
+	lock  sync.Mutex
+	nodes lru.BasicLRU[string, hotNode]
+	size  int // Approximate memory used by the cached nodes
+	limit int // Maximum memory allowance
+}
+
+// newHotNodeCache creates a hot node cache with the given memory allowance.
+func newHotNodeCache(limit int) *hotNodeCache {
+	return &hotNodeCache{
+		nodes: lru.NewBasicLRU[string, hotNode](limit/hotNodeOverhead + 1),
+		limit: limit,
+	}
+}
+
+// tracked reports whether nodes at the given path are eligible for caching.
+func (c *hotNodeCache) tracked(path []byte) bool {
+	return len(path) <= hotNodeMaxDepth
+}
+
+// get returns the cached node and its hash at the given path, if any.
+func (c *hotNodeCache) get(owner common.Hash, path []byte) ([]byte, common.Hash, bool) {
+	if !c.tracked(path) {
+		return nil, common.Hash{}, false
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	n, ok := c.nodes.Get(string(nodeCacheKey(owner, path)))
+	if !ok {
+		hotNodeMissMeter.Mark(1)
+		return nil, common.Hash{}, false
+	}
+	hotNodeHitMeter.Mark(1)
+	return n.blob, n.hash, true
+}
+
+// set inserts the node at the given path, evicting the least recently used
+// nodes if the memory allowance is exceeded.
+func (c *hotNodeCache) set(owner common.Hash, path []byte, blob []byte, hash common.Hash) {
+	if !c.tracked(path) || len(blob) == 0 {
+		return
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	key := string(nodeCacheKey(owner, path))
+	if prev, ok := c.nodes.Peek(key); ok {
+		c.size -= len(key) + len(prev.blob) + hotNodeOverhead
+	}
+	c.nodes.Add(key, hotNode{blob: blob, hash: hash})
+	c.size += len(key) + len(blob) + hotNodeOverhead
+	hotNodeWriteMeter.Mark(int64(len(blob)))
+
+	for c.size > c.limit {
+		k, n, ok := c.nodes.RemoveOldest()
+		if !ok {
+			break
+		}
+		c.size -= len(k) + len(n.blob) + hotNodeOverhead
+	}
+	hotNodeSizeGauge.Update(int64(c.size))
+}
+
+// invalidate drops the cached nodes at the paths modified by the node set.
+func (c *hotNodeCache) invalidate(nodes *nodeSet) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	drop := func(owner common.Hash, path string) {
+		if !c.tracked([]byte(path)) {
+			return
+		}
+		key := string(nodeCacheKey(owner, []byte(path)))
+		if n, ok := c.nodes.Peek(key); ok {
+			c.nodes.Remove(key)
+			c.size -= len(key) + len(n.blob) + hotNodeOverhead
+		}
+	}
+	for path := range nodes.accountNodes {
+		drop(common.Hash{}, path)
+	}
+	for owner, subset := range nodes.storageNodes {
+		for path := range subset {
+			drop(owner, path)
+		}
+	}
+	hotNodeSizeGauge.Update(int64(c.size))
+}
+
+// reset drops all the cached nodes.
+func (c *hotNodeCache) reset() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.nodes.Purge()
+	c.size = 0
+	hotNodeSizeGauge.Update(0)
+}
@@ -0,0 +1,68 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package pathdb
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/trie/trienode"
+)
+
+func TestHotNodeCache(t *testing.T) {
+	var (
+		owner = common.HexToHash("0xdeadbeef")
+		blob  = bytes.Repeat([]byte{0x1}, 100)
+		hash  = crypto.Keccak256Hash(blob)
+		cache = newHotNodeCache(4 * (100 + hotNodeOverhead + 32 + 2))
+	)
+	// Deep nodes are not tracked
+	cache.set(owner, make([]byte, hotNodeMaxDepth+1), blob, hash)
+	if _, _, found := cache.get(owner, make([]byte, hotNodeMaxDepth+1)); found {
+		t.Fatal("deep node cached")
+	}
+	cache.set(common.Hash{}, []byte{0x1}, blob, hash)
+	cache.set(owner, []byte{0x1}, blob, hash)
+	if got, gotHash, found := cache.get(owner, []byte{0x1}); !found || !bytes.Equal(got, blob) || gotHash != hash {
+		t.Fatal("cached node not found")
+	}
+	// The nodes modified by a node set are dropped
+	cache.invalidate(newNodeSet(map[common.Hash]map[string]*trienode.Node{
+		owner: {string([]byte{0x1}): trienode.NewDeleted()},
+	}))
+	if _, _, found := cache.get(owner, []byte{0x1}); found {
+		t.Fatal("invalidated node still cached")
+	}
+	if _, _, found := cache.get(common.Hash{}, []byte{0x1}); !found {
+		t.Fatal("account node dropped")
+	}
+	// The least recently used nodes are evicted beyond the allowance
+	for i := byte(0); i < 10; i++ {
+		cache.set(owner, []byte{i, i}, blob, hash)
+	}
+	if cache.size > cache.limit {
+		t.Fatalf("cache size %d exceeds the limit %d", cache.size, cache.limit)
+	}
+	if _, _, found := cache.get(owner, []byte{0, 0}); found {
+		t.Fatal("oldest node not evicted")
+	}
+	if _, _, found := cache.get(owner, []byte{9, 9}); !found {
+		t.Fatal("newest node evicted")
+	}
+}
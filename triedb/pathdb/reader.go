@@ -35,6 +35,7 @@ import (
 const (
 	locDirtyCache = "dirty" // dirty cache
 	locCleanCache = "clean" // clean cache
+	locHotCache   = "hot"   // hot node cache
 	locDiskLayer  = "disk"  // persistent state
 	locDiffLayer  = "diff"  // diff layers
 )
@@ -75,6 +76,8 @@ func (r *reader) Node(owner common.Hash, path []byte, hash common.Hash) ([]byte,
 		switch loc.loc {
 		case locCleanCache:
 			nodeCleanFalseMeter.Mark(1)
+		case locHotCache:
+			nodeHotFalseMeter.Mark(1)
 		case locDirtyCache:
 			nodeDirtyFalseMeter.Mark(1)
 		case locDiffLayer: